@@ -64,6 +64,7 @@ func ParseBindMode(mp *types.MountPoint, mode string) error {
 	replaceMode := 0
 	copyMode := 0
 	propagationMode := 0
+	hardenModes := map[string]int{}
 
 	for _, m := range strings.Split(mode, ",") {
 		switch m {
@@ -87,6 +88,9 @@ func ParseBindMode(mp *types.MountPoint, mode string) error {
 		case "private", "rprivate", "slave", "rslave", "shared", "rshared":
 			mp.Propagation = m
 			propagationMode++
+		case "nosuid", "nodev", "noexec":
+			// applied to the bind mount by the remount of runtime.
+			hardenModes[m]++
 		default:
 			return fmt.Errorf("unknown bind mode: %s", mode)
 		}
@@ -95,6 +99,11 @@ func ParseBindMode(mp *types.MountPoint, mode string) error {
 	if defaultMode > 1 || rwMode > 1 || replaceMode > 1 || copyMode > 1 || propagationMode > 1 {
 		return fmt.Errorf("invalid bind mode: %s", mode)
 	}
+	for _, n := range hardenModes {
+		if n > 1 {
+			return fmt.Errorf("invalid bind mode: %s", mode)
+		}
+	}
 
 	if mode != "" {
 		mp.Mode = mode
@@ -169,6 +169,21 @@ func TestParseBindMode(t *testing.T) {
 			err:       false,
 			expectErr: nil,
 		},
+		{
+			mode: "ro,nosuid,nodev,noexec",
+			expectMountPoint: &types.MountPoint{
+				Mode:     "ro,nosuid,nodev,noexec",
+				RW:       false,
+				CopyData: true,
+			},
+			err:       false,
+			expectErr: nil,
+		},
+		{
+			mode:      "nosuid,nosuid",
+			err:       true,
+			expectErr: fmt.Errorf("invalid bind mode: nosuid,nosuid"),
+		},
 		{
 			mode: "z,Z",
 			expectMountPoint: &types.MountPoint{
@@ -558,7 +558,7 @@ func (s *Server) removeContainers(ctx context.Context, rw http.ResponseWriter, r
 func (s *Server) waitContainer(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
 	name := mux.Vars(req)["name"]
 
-	waitStatus, err := s.ContainerMgr.Wait(ctx, name)
+	waitStatus, err := s.ContainerMgr.Wait(ctx, name, req.FormValue("condition"))
 
 	if err != nil {
 		return err
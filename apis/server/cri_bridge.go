@@ -2,7 +2,15 @@ package server
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"strings"
+
+	metatypes "github.com/alibaba/pouch/cri/v1alpha2/types"
+	"github.com/alibaba/pouch/pkg/httputils"
+
+	"github.com/gorilla/mux"
 )
 
 func (s *Server) criExec(context context.Context, rw http.ResponseWriter, req *http.Request) (err error) {
@@ -28,3 +36,229 @@ func (s *Server) criPortForward(context context.Context, rw http.ResponseWriter,
 	s.StreamRouter.ServePortForward(rw, req)
 	return nil
 }
+
+func (s *Server) criCopy(context context.Context, rw http.ResponseWriter, req *http.Request) (err error) {
+	if s.StreamRouter == nil {
+		return EncodeResponse(rw, http.StatusNotImplemented, nil)
+	}
+	s.StreamRouter.ServeCopy(rw, req)
+	return nil
+}
+
+func (s *Server) criFsck(ctx context.Context, rw http.ResponseWriter, req *http.Request) (err error) {
+	if s.CriMgr == nil {
+		return EncodeResponse(rw, http.StatusNotImplemented, nil)
+	}
+
+	// only POST repairs the inconsistencies, GET just reports them.
+	repair := req.Method == http.MethodPost && httputils.BoolValue(req, "repair")
+	report, err := s.CriMgr.Fsck(ctx, repair)
+	if err != nil {
+		return err
+	}
+	return EncodeResponse(rw, http.StatusOK, report)
+}
+
+func (s *Server) criImportDockershim(ctx context.Context, rw http.ResponseWriter, req *http.Request) (err error) {
+	if s.CriMgr == nil {
+		return EncodeResponse(rw, http.StatusNotImplemented, nil)
+	}
+
+	// only POST imports the sandboxes, GET just reports them.
+	report, err := s.CriMgr.ImportDockershim(ctx, req.Method == http.MethodPost)
+	if err != nil {
+		return err
+	}
+	return EncodeResponse(rw, http.StatusOK, report)
+}
+
+func (s *Server) criCheckpointPodSandbox(ctx context.Context, rw http.ResponseWriter, req *http.Request) (err error) {
+	if s.CriMgr == nil {
+		return EncodeResponse(rw, http.StatusNotImplemented, nil)
+	}
+
+	options := &metatypes.PodCheckpointOptions{
+		Dir:  req.FormValue("dir"),
+		Exit: httputils.BoolValue(req, "exit"),
+	}
+	archive, err := s.CriMgr.CheckpointPodSandbox(ctx, mux.Vars(req)["id"], options)
+	if err != nil {
+		return err
+	}
+	return EncodeResponse(rw, http.StatusCreated, map[string]string{"Archive": archive})
+}
+
+func (s *Server) criRestorePodSandbox(ctx context.Context, rw http.ResponseWriter, req *http.Request) (err error) {
+	if s.CriMgr == nil {
+		return EncodeResponse(rw, http.StatusNotImplemented, nil)
+	}
+
+	archive := req.FormValue("archive")
+	if archive == "" {
+		return httputils.NewHTTPError(fmt.Errorf("the archive of checkpoint should be specified"), http.StatusBadRequest)
+	}
+	id, err := s.CriMgr.RestorePodSandbox(ctx, archive)
+	if err != nil {
+		return err
+	}
+	return EncodeResponse(rw, http.StatusCreated, map[string]string{"Id": id})
+}
+
+func (s *Server) criCopyContainer(ctx context.Context, rw http.ResponseWriter, req *http.Request) (err error) {
+	if s.CriMgr == nil {
+		return EncodeResponse(rw, http.StatusNotImplemented, nil)
+	}
+
+	path := req.FormValue("path")
+	if path == "" {
+		return httputils.NewHTTPError(fmt.Errorf("the path in container should be specified"), http.StatusBadRequest)
+	}
+	resp, err := s.CriMgr.CopyContainer(ctx, &metatypes.CopyRequest{
+		ContainerID:          mux.Vars(req)["id"],
+		Path:                 path,
+		ToContainer:          httputils.BoolValue(req, "toContainer"),
+		CopyUIDGID:           httputils.BoolValue(req, "copyUIDGID"),
+		NoOverwriteDirNonDir: httputils.BoolValue(req, "noOverwriteDirNonDir"),
+	})
+	if err != nil {
+		return err
+	}
+	return EncodeResponse(rw, http.StatusOK, resp)
+}
+
+func (s *Server) criCreateEphemeralContainer(ctx context.Context, rw http.ResponseWriter, req *http.Request) (err error) {
+	if s.CriMgr == nil {
+		return EncodeResponse(rw, http.StatusNotImplemented, nil)
+	}
+
+	r := &metatypes.EphemeralContainerRequest{}
+	if err := json.NewDecoder(req.Body).Decode(r); err != nil {
+		return httputils.NewHTTPError(err, http.StatusBadRequest)
+	}
+	if r.Name == "" || r.Image == "" {
+		return httputils.NewHTTPError(fmt.Errorf("the name and image of ephemeral container should be specified"), http.StatusBadRequest)
+	}
+	r.PodSandboxID = mux.Vars(req)["id"]
+
+	resp, err := s.CriMgr.CreateEphemeralContainer(ctx, r)
+	if err != nil {
+		return err
+	}
+	return EncodeResponse(rw, http.StatusCreated, resp)
+}
+
+func (s *Server) criDiagnoseSandbox(ctx context.Context, rw http.ResponseWriter, req *http.Request) (err error) {
+	if s.CriMgr == nil {
+		return EncodeResponse(rw, http.StatusNotImplemented, nil)
+	}
+
+	id := mux.Vars(req)["id"]
+	rw.Header().Set("Content-Type", "application/gzip")
+	rw.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", id+"-diagnostics.tar.gz"))
+	return s.CriMgr.DiagnoseSandbox(ctx, id, rw)
+}
+
+func (s *Server) criSelfTest(ctx context.Context, rw http.ResponseWriter, req *http.Request) (err error) {
+	if s.CriMgr == nil {
+		return EncodeResponse(rw, http.StatusNotImplemented, nil)
+	}
+
+	options := &metatypes.SelfTestOptions{
+		Image:       req.FormValue("image"),
+		Exec:        httputils.BoolValue(req, "exec"),
+		HostNetwork: httputils.BoolValue(req, "hostNetwork"),
+	}
+	report, err := s.CriMgr.SelfTest(ctx, options)
+	if err != nil {
+		return err
+	}
+	return EncodeResponse(rw, http.StatusOK, report)
+}
+
+func (s *Server) criDrain(ctx context.Context, rw http.ResponseWriter, req *http.Request) (err error) {
+	if s.CriMgr == nil {
+		return EncodeResponse(rw, http.StatusNotImplemented, nil)
+	}
+
+	// only POST turns on or off the drain mode, GET just reports it.
+	if req.Method == http.MethodPost {
+		s.CriMgr.SetDraining(httputils.BoolValue(req, "draining"))
+	}
+	return EncodeResponse(rw, http.StatusOK, map[string]bool{"Draining": s.CriMgr.Draining()})
+}
+
+func (s *Server) criDebugDump(ctx context.Context, rw http.ResponseWriter, req *http.Request) (err error) {
+	if s.CriMgr == nil {
+		return EncodeResponse(rw, http.StatusNotImplemented, nil)
+	}
+
+	// only POST sets the methods to dump, empty turns the dump off, GET just reports them.
+	if req.Method == http.MethodPost {
+		var methods []string
+		if v := strings.TrimSpace(req.FormValue("methods")); v != "" {
+			methods = strings.Split(v, ",")
+		}
+		s.CriMgr.SetDebugDumpMethods(methods)
+	}
+	return EncodeResponse(rw, http.StatusOK, map[string][]string{"Methods": s.CriMgr.DebugDumpMethods()})
+}
+
+func (s *Server) criPrefetch(ctx context.Context, rw http.ResponseWriter, req *http.Request) (err error) {
+	if s.CriMgr == nil {
+		return EncodeResponse(rw, http.StatusNotImplemented, nil)
+	}
+
+	// only POST queues the images, GET just reports the prefetches.
+	if req.Method != http.MethodPost {
+		return EncodeResponse(rw, http.StatusOK, s.CriMgr.PrefetchReport())
+	}
+
+	r := &metatypes.PrefetchRequest{}
+	if err := json.NewDecoder(req.Body).Decode(r); err != nil {
+		return httputils.NewHTTPError(err, http.StatusBadRequest)
+	}
+	if len(r.Images) == 0 {
+		return httputils.NewHTTPError(fmt.Errorf("the images to prefetch should be specified"), http.StatusBadRequest)
+	}
+
+	report, err := s.CriMgr.PrefetchImages(ctx, r)
+	if err != nil {
+		return err
+	}
+	return EncodeResponse(rw, http.StatusAccepted, report)
+}
+
+func (s *Server) criAttachDevice(ctx context.Context, rw http.ResponseWriter, req *http.Request) (err error) {
+	if s.CriMgr == nil {
+		return EncodeResponse(rw, http.StatusNotImplemented, nil)
+	}
+
+	r := &metatypes.DeviceRequest{}
+	if err := json.NewDecoder(req.Body).Decode(r); err != nil {
+		return httputils.NewHTTPError(err, http.StatusBadRequest)
+	}
+	r.ContainerID = mux.Vars(req)["id"]
+
+	resp, err := s.CriMgr.AttachDevice(ctx, r)
+	if err != nil {
+		return err
+	}
+	return EncodeResponse(rw, http.StatusOK, resp)
+}
+
+func (s *Server) criDetachDevice(ctx context.Context, rw http.ResponseWriter, req *http.Request) (err error) {
+	if s.CriMgr == nil {
+		return EncodeResponse(rw, http.StatusNotImplemented, nil)
+	}
+
+	resp, err := s.CriMgr.DetachDevice(ctx, &metatypes.DeviceRequest{
+		ContainerID:   mux.Vars(req)["id"],
+		HostPath:      req.FormValue("hostPath"),
+		ContainerPath: req.FormValue("containerPath"),
+		Permissions:   req.FormValue("permissions"),
+	})
+	if err != nil {
+		return err
+	}
+	return EncodeResponse(rw, http.StatusOK, resp)
+}
@@ -12,6 +12,7 @@ import (
 
 	serverTypes "github.com/alibaba/pouch/apis/server/types"
 	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/pkg/debug"
 	"github.com/alibaba/pouch/pkg/errtypes"
 	"github.com/alibaba/pouch/pkg/httputils"
 	"github.com/alibaba/pouch/pkg/log"
@@ -104,6 +105,33 @@ func initRoute(s *Server) *mux.Router {
 		{Method: http.MethodPost, Path: "/attach/{token}", HandlerFunc: s.criAttach},
 		{Method: http.MethodGet, Path: "/portforward/{token}", HandlerFunc: s.criPortForward},
 		{Method: http.MethodPost, Path: "/portforward/{token}", HandlerFunc: s.criPortForward},
+		{Method: http.MethodGet, Path: "/copy/{token}", HandlerFunc: s.criCopy},
+		{Method: http.MethodPost, Path: "/copy/{token}", HandlerFunc: s.criCopy},
+
+		// debug
+		{Method: http.MethodGet, Path: "/debug/profiler", HandlerFunc: s.debugProfiler},
+		{Method: http.MethodPost, Path: "/debug/profiler", HandlerFunc: s.debugProfiler},
+		{Method: http.MethodPost, Path: "/debug/dump", HandlerFunc: s.debugDump},
+
+		// cri debug
+		{Method: http.MethodGet, Path: "/debug/cri/fsck", HandlerFunc: s.criFsck},
+		{Method: http.MethodPost, Path: "/debug/cri/fsck", HandlerFunc: s.criFsck},
+		{Method: http.MethodGet, Path: "/debug/cri/import-dockershim", HandlerFunc: s.criImportDockershim},
+		{Method: http.MethodPost, Path: "/debug/cri/import-dockershim", HandlerFunc: s.criImportDockershim},
+		{Method: http.MethodPost, Path: "/debug/cri/sandboxes/{id:.*}/checkpoint", HandlerFunc: s.criCheckpointPodSandbox},
+		{Method: http.MethodPost, Path: "/debug/cri/sandboxes/restore", HandlerFunc: s.criRestorePodSandbox},
+		{Method: http.MethodPost, Path: "/debug/cri/containers/{id:.*}/copy", HandlerFunc: s.criCopyContainer},
+		{Method: http.MethodPost, Path: "/debug/cri/sandboxes/{id:.*}/ephemeral", HandlerFunc: s.criCreateEphemeralContainer},
+		{Method: http.MethodGet, Path: "/debug/cri/sandboxes/{id:.*}/diagnostics", HandlerFunc: s.criDiagnoseSandbox},
+		{Method: http.MethodPost, Path: "/debug/cri/selftest", HandlerFunc: s.criSelfTest},
+		{Method: http.MethodGet, Path: "/debug/cri/drain", HandlerFunc: s.criDrain},
+		{Method: http.MethodPost, Path: "/debug/cri/drain", HandlerFunc: s.criDrain},
+		{Method: http.MethodGet, Path: "/debug/cri/dump", HandlerFunc: s.criDebugDump},
+		{Method: http.MethodPost, Path: "/debug/cri/dump", HandlerFunc: s.criDebugDump},
+		{Method: http.MethodGet, Path: "/debug/cri/prefetch", HandlerFunc: s.criPrefetch},
+		{Method: http.MethodPost, Path: "/debug/cri/prefetch", HandlerFunc: s.criPrefetch},
+		{Method: http.MethodPost, Path: "/debug/cri/containers/{id:.*}/devices", HandlerFunc: s.criAttachDevice},
+		{Method: http.MethodDelete, Path: "/debug/cri/containers/{id:.*}/devices", HandlerFunc: s.criDetachDevice},
 
 		// copy
 		{Method: http.MethodPut, Path: "/containers/{name:.*}/archive", HandlerFunc: s.putContainersArchive},
@@ -123,23 +151,36 @@ func initRoute(s *Server) *mux.Router {
 		}
 	}
 
+	// the profiler could be turned on or off at runtime by /debug/profiler.
 	if s.Config.Debug || s.Config.EnableProfiler {
-		profilerSetup(r)
+		debug.SetProfilerEnabled(true)
 	}
+	profilerSetup(r)
 	return r
 }
 
 func profilerSetup(mainRouter *mux.Router) {
 	var r = mainRouter.PathPrefix("/debug/").Subrouter()
-	r.HandleFunc("/pprof/", pprof.Index)
-	r.HandleFunc("/pprof/cmdline", pprof.Cmdline)
-	r.HandleFunc("/pprof/profile", pprof.Profile)
-	r.HandleFunc("/pprof/symbol", pprof.Symbol)
-	r.HandleFunc("/pprof/trace", pprof.Trace)
-	r.HandleFunc("/pprof/block", pprof.Handler("block").ServeHTTP)
-	r.HandleFunc("/pprof/heap", pprof.Handler("heap").ServeHTTP)
-	r.HandleFunc("/pprof/goroutine", pprof.Handler("goroutine").ServeHTTP)
-	r.HandleFunc("/pprof/threadcreate", pprof.Handler("threadcreate").ServeHTTP)
+	r.HandleFunc("/pprof/", withProfilerEnabled(pprof.Index))
+	r.HandleFunc("/pprof/cmdline", withProfilerEnabled(pprof.Cmdline))
+	r.HandleFunc("/pprof/profile", withProfilerEnabled(pprof.Profile))
+	r.HandleFunc("/pprof/symbol", withProfilerEnabled(pprof.Symbol))
+	r.HandleFunc("/pprof/trace", withProfilerEnabled(pprof.Trace))
+	r.HandleFunc("/pprof/block", withProfilerEnabled(pprof.Handler("block").ServeHTTP))
+	r.HandleFunc("/pprof/heap", withProfilerEnabled(pprof.Handler("heap").ServeHTTP))
+	r.HandleFunc("/pprof/goroutine", withProfilerEnabled(pprof.Handler("goroutine").ServeHTTP))
+	r.HandleFunc("/pprof/threadcreate", withProfilerEnabled(pprof.Handler("threadcreate").ServeHTTP))
+}
+
+// withProfilerEnabled serves the pprof endpoint only if the profiler is enabled.
+func withProfilerEnabled(h http.HandlerFunc) http.HandlerFunc {
+	return func(rw http.ResponseWriter, req *http.Request) {
+		if !debug.ProfilerEnabled() {
+			http.NotFound(rw, req)
+			return
+		}
+		h(rw, req)
+	}
 }
 
 // withCancelHandler will use context to cancel the handler. Otherwise, if the
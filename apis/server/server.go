@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/alibaba/pouch/cri/stream"
+	criv1alpha2 "github.com/alibaba/pouch/cri/v1alpha2"
 	"github.com/alibaba/pouch/daemon/config"
 	"github.com/alibaba/pouch/daemon/mgr"
 	"github.com/alibaba/pouch/hookplugins"
@@ -28,6 +29,7 @@ type Server struct {
 	VolumeMgr        mgr.VolumeMgr
 	NetworkMgr       mgr.NetworkMgr
 	StreamRouter     stream.Router
+	CriMgr           criv1alpha2.CriMgr
 	listeners        []net.Listener
 	ContainerPlugin  hookplugins.ContainerPlugin
 	APIPlugin        hookplugins.APIPlugin
@@ -5,10 +5,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"path/filepath"
+	"strconv"
 	"time"
 
 	"github.com/alibaba/pouch/apis/filters"
 	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/pkg/debug"
 	"github.com/alibaba/pouch/pkg/httputils"
 	"github.com/alibaba/pouch/pkg/log"
 	"github.com/alibaba/pouch/pkg/utils"
@@ -160,6 +163,46 @@ func (s *Server) metrics(ctx context.Context, rw http.ResponseWriter, req *http.
 	return nil
 }
 
+// maxTraceSeconds is the max duration of the execution trace captured by /debug/dump.
+const maxTraceSeconds = 60
+
+func (s *Server) debugProfiler(ctx context.Context, rw http.ResponseWriter, req *http.Request) (err error) {
+	// only POST turns on or off the pprof endpoints, GET just reports it.
+	if req.Method == http.MethodPost {
+		enabled := httputils.BoolValue(req, "enabled")
+		debug.SetProfilerEnabled(enabled)
+		log.With(ctx).Infof("profiler is turned on: %v", enabled)
+	}
+	return EncodeResponse(rw, http.StatusOK, map[string]bool{"Enabled": debug.ProfilerEnabled()})
+}
+
+func (s *Server) debugDump(ctx context.Context, rw http.ResponseWriter, req *http.Request) (err error) {
+	var seconds int
+	if v := req.FormValue("trace"); v != "" {
+		if seconds, err = strconv.Atoi(v); err != nil || seconds < 0 || seconds > maxTraceSeconds {
+			return httputils.NewHTTPError(fmt.Errorf("invalid trace duration %q, should be 0-%d seconds", v, maxTraceSeconds), http.StatusBadRequest)
+		}
+	}
+
+	// the dumps are written under the home dir, so that they could be
+	// collected after the latency spikes without restarting pouchd.
+	dir := filepath.Join(s.Config.HomeDir, "debug")
+	stacks, err := debug.WriteStacks(dir)
+	if err != nil {
+		return err
+	}
+	files := []string{stacks}
+	if seconds > 0 {
+		trace, err := debug.WriteTrace(dir, time.Duration(seconds)*time.Second)
+		if err != nil {
+			return err
+		}
+		files = append(files, trace)
+	}
+	log.With(ctx).Infof("debug dumps are written to %v", files)
+	return EncodeResponse(rw, http.StatusOK, map[string][]string{"Files": files})
+}
+
 func eventTime(formTime string) (time.Time, error) {
 	t, tNano, err := utils.ParseTimestamp(formTime, -1)
 	if err != nil {
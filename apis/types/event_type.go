@@ -14,7 +14,7 @@ import (
 )
 
 // EventType The type of event. For example, "container" or "image",
-// Now we only support container, image, network and volume events.
+// Now we only support container, image, network, volume and cri events.
 //
 // swagger:model EventType
 type EventType string
@@ -24,6 +24,9 @@ const (
 	// EventTypeContainer captures enum value "container"
 	EventTypeContainer EventType = "container"
 
+	// EventTypeCri captures enum value "cri"
+	EventTypeCri EventType = "cri"
+
 	// EventTypeDaemon captures enum value "daemon"
 	EventTypeDaemon EventType = "daemon"
 
@@ -45,7 +48,7 @@ var eventTypeEnum []interface{}
 
 func init() {
 	var res []EventType
-	if err := json.Unmarshal([]byte(`["container","daemon","image","network","plugin","volume"]`), &res); err != nil {
+	if err := json.Unmarshal([]byte(`["container","cri","daemon","image","network","plugin","volume"]`), &res); err != nil {
 		panic(err)
 	}
 	for _, v := range res {
@@ -38,9 +38,110 @@ const (
 	// PidsLimitExtendAnnotation is the extend annotation of pids limit
 	PidsLimitExtendAnnotation = "io.alibaba.pouch.resources.pids-limit"
 
+	// PodPidsLimitExtendAnnotation is the extend annotation of the pids limit of the whole pod
+	PodPidsLimitExtendAnnotation = "io.alibaba.pouch.resources.pod-pids-limit"
+
+	// MemoryMinExtendAnnotation is the extend annotation of memory.min (in bytes) of container cgroup,
+	// which is only supported on cgroup v2
+	MemoryMinExtendAnnotation = "io.alibaba.pouch.resources.memory-min"
+	// MemoryLowExtendAnnotation is the extend annotation of memory.low (in bytes) of container cgroup
+	MemoryLowExtendAnnotation = "io.alibaba.pouch.resources.memory-low"
+	// MemoryHighExtendAnnotation is the extend annotation of memory.high (in bytes) of container cgroup
+	MemoryHighExtendAnnotation = "io.alibaba.pouch.resources.memory-high"
+	// CPUBurstExtendAnnotation is the extend annotation of the cpu burst (in microseconds) of container cgroup,
+	// which is the quota could be accumulated and used beyond the cpu quota in a period
+	CPUBurstExtendAnnotation = "io.alibaba.pouch.resources.cpu-burst"
+	// RdtClassExtendAnnotation is the extend annotation of the Intel RDT class of service of container,
+	// which is the directory created in resctrl filesystem
+	RdtClassExtendAnnotation = "io.alibaba.pouch.resources.rdt-class"
+	// ExclusiveCPUsExtendAnnotation is the extend annotation of the number of exclusive cpus of container,
+	// which are assigned by the cpuset manager
+	ExclusiveCPUsExtendAnnotation = "io.alibaba.pouch.resources.exclusive-cpus"
+	// NUMANodesExtendAnnotation is the extend annotation of the NUMA nodes hinted for the cpus of container,
+	// e.g. "0" or "0-1"
+	NUMANodesExtendAnnotation = "io.alibaba.pouch.resources.numa-nodes"
+	// PodMemoryMinExtendAnnotation is the extend annotation of memory.min (in bytes) of the pod cgroup
+	PodMemoryMinExtendAnnotation = "io.alibaba.pouch.resources.pod-memory-min"
+	// PodMemoryLowExtendAnnotation is the extend annotation of memory.low (in bytes) of the pod cgroup
+	PodMemoryLowExtendAnnotation = "io.alibaba.pouch.resources.pod-memory-low"
+	// PodMemoryHighExtendAnnotation is the extend annotation of memory.high (in bytes) of the pod cgroup
+	PodMemoryHighExtendAnnotation = "io.alibaba.pouch.resources.pod-memory-high"
+	// StopTimeoutExtendAnnotation is the extend annotation of the time duration (in time.Second)
+	// the containers of pod are given to stop before being killed when the pod is stopped
+	StopTimeoutExtendAnnotation = "io.alibaba.pouch.stop-timeout"
+
+	// StopSignalExtendAnnotation is the extend annotation of the signal to stop container,
+	// which overrides the STOPSIGNAL of image
+	StopSignalExtendAnnotation = "io.alibaba.pouch.stop-signal"
+
+	// PausedExtendAnnotation is the extend annotation of UpdateContainerResources to pause the container
+	// if it is "true" or unpause it if it is "false", the processes are frozen without being stopped
+	PausedExtendAnnotation = "io.alibaba.pouch.paused"
+
+	// UpgradeImageExtendAnnotation is the extend annotation of UpdateContainerResources to replace the image
+	// of container in place, the sandbox, ip, volumes and log of container are kept
+	UpgradeImageExtendAnnotation = "io.alibaba.pouch.upgrade.image"
+
+	// UpgradeCommandExtendAnnotation is the extend annotation of the new entrypoint of the upgraded container,
+	// in the format of JSON array, e.g. ["sh","-c"]
+	UpgradeCommandExtendAnnotation = "io.alibaba.pouch.upgrade.command"
+
+	// UpgradeArgsExtendAnnotation is the extend annotation of the new cmd of the upgraded container,
+	// in the format of JSON array, e.g. ["top"]
+	UpgradeArgsExtendAnnotation = "io.alibaba.pouch.upgrade.args"
+
+	// UlimitsExtendAnnotation is the extend annotation of ulimits, in the format of
+	// "name=soft[:hard][,name=soft[:hard]]"
+	UlimitsExtendAnnotation = "io.alibaba.pouch.resources.ulimits"
+
 	// PassthruKey specify whether an interface is pass through to qemu
 	PassthruKey = "io.alibaba.pouch.vm.passthru"
 
 	// PassthruIP is the IP for container
 	PassthruIP = "io.alibaba.pouch.vm.passthru.ip"
+
+	// StaticIPAnnotation is the static IP requested for the sandbox's default network interface
+	StaticIPAnnotation = "io.alibaba.pouch.network.static-ip"
+
+	// StaticMACAnnotation is the static MAC requested for the sandbox's default network interface
+	StaticMACAnnotation = "io.alibaba.pouch.network.static-mac"
+
+	// NetworkDevicesAnnotation is the host network devices (e.g. SR-IOV VFs) to move into the sandbox,
+	// in the format of "hostIfName[:containerIfName][,hostIfName[:containerIfName]]"
+	NetworkDevicesAnnotation = "io.alibaba.pouch.network.devices"
+
+	// NetworkPolicyIngressAnnotation is the ingress rules of sandbox, in the format of
+	// "allow|deny:cidr[:protocol[:port]][,...]"
+	NetworkPolicyIngressAnnotation = "io.alibaba.pouch.network.policy.ingress"
+
+	// NetworkPolicyEgressAnnotation is the egress rules of sandbox, in the same format as the ingress rules
+	NetworkPolicyEgressAnnotation = "io.alibaba.pouch.network.policy.egress"
+
+	// HealthCheckExecAnnotation is the command of health check run by "/bin/sh -c" in the container
+	HealthCheckExecAnnotation = "io.alibaba.pouch.healthcheck.exec"
+
+	// HealthCheckIntervalAnnotation is the interval of health check, e.g. "30s"
+	HealthCheckIntervalAnnotation = "io.alibaba.pouch.healthcheck.interval"
+
+	// HealthCheckTimeoutAnnotation is the timeout of each health check, e.g. "10s"
+	HealthCheckTimeoutAnnotation = "io.alibaba.pouch.healthcheck.timeout"
+
+	// HealthCheckRetriesAnnotation is the number of consecutive failures to consider the container unhealthy
+	HealthCheckRetriesAnnotation = "io.alibaba.pouch.healthcheck.retries"
+
+	// HealthCheckRestartAnnotation specify whether to restart the container when it becomes unhealthy
+	HealthCheckRestartAnnotation = "io.alibaba.pouch.healthcheck.restart-unhealthy"
+
+	// DefaultMountsExcludeAnnotation is the container paths of default mounts not injected into the containers
+	// of pod, in the format of "path[,path]", "*" excludes all the default mounts
+	DefaultMountsExcludeAnnotation = "io.alibaba.pouch.default-mounts.exclude"
+
+	// SubPathsAnnotation is the subdirectories of volumes mounted into the container instead of the volumes,
+	// in the format of "containerPath=subPath[,containerPath=subPath]", the subpath is relative to the host
+	// path of the mount at the container path, and is not allowed to escape it by symlinks
+	SubPathsAnnotation = "io.alibaba.pouch.mounts.subpaths"
+
+	// TimezoneExtendAnnotation is the timezone of containers, e.g. "Asia/Shanghai", which sets the TZ env and
+	// binds the zoneinfo file to /etc/localtime
+	TimezoneExtendAnnotation = "io.alibaba.pouch.timezone"
 )
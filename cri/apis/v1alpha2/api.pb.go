@@ -237,17 +237,26 @@ const (
 	// For example, a container with a PID namespace of NODE expects to view
 	// all of the processes on the host running the kubelet.
 	NamespaceMode_NODE NamespaceMode = 2
+	// TARGET targets the namespace of another container. When this is specified,
+	// a target_id must be specified in NamespaceOption and refer to a container
+	// previously created with NamespaceMode CONTAINER. This containers namespace
+	// will be made to match that of container target_id.
+	// For example, a container with a PID namespace of TARGET expects to view
+	// all of the processes that container target_id can view.
+	NamespaceMode_TARGET NamespaceMode = 3
 )
 
 var NamespaceMode_name = map[int32]string{
 	0: "POD",
 	1: "CONTAINER",
 	2: "NODE",
+	3: "TARGET",
 }
 var NamespaceMode_value = map[string]int32{
 	"POD":       0,
 	"CONTAINER": 1,
 	"NODE":      2,
+	"TARGET":    3,
 }
 
 func (x NamespaceMode) String() string {
@@ -523,12 +532,16 @@ type NamespaceOption struct {
 	// PID namespace for this container/sandbox.
 	// Note: The CRI default is POD, but the v1.PodSpec default is CONTAINER.
 	// The kubelet's runtime manager will set this to CONTAINER explicitly for v1 pods.
-	// Namespaces currently set by the kubelet: POD, CONTAINER, NODE
+	// Namespaces currently set by the kubelet: POD, CONTAINER, NODE, TARGET
 	Pid NamespaceMode `protobuf:"varint,2,opt,name=pid,proto3,enum=runtime.v1alpha2.NamespaceMode" json:"pid,omitempty"`
 	// IPC namespace for this container/sandbox.
 	// Note: There is currently no way to set CONTAINER scoped IPC in the Kubernetes API.
 	// Namespaces currently set by the kubelet: POD, NODE
 	Ipc NamespaceMode `protobuf:"varint,3,opt,name=ipc,proto3,enum=runtime.v1alpha2.NamespaceMode" json:"ipc,omitempty"`
+	// Target Container ID for NamespaceMode of TARGET. This container must have been
+	// previously created in the same pod. It is not possible to specify different targets
+	// for each namespace.
+	TargetId string `protobuf:"bytes,4,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
 }
 
 func (m *NamespaceOption) Reset()                    { *m = NamespaceOption{} }
@@ -556,6 +569,13 @@ func (m *NamespaceOption) GetIpc() NamespaceMode {
 	return NamespaceMode_POD
 }
 
+func (m *NamespaceOption) GetTargetId() string {
+	if m != nil {
+		return m.TargetId
+	}
+	return ""
+}
+
 // Int64Value is the wrapper of int64.
 type Int64Value struct {
 	// The value.
@@ -1374,6 +1394,10 @@ type LinuxContainerResources struct {
 	MemorySwappiness *Int64Value `protobuf:"bytes,109,opt,name=memory_swappiness,json=memorySwappiness" json:"memory_swappiness,omitempty"`
 	// List of ulimits to be set in the container
 	Ulimits []*Ulimit `protobuf:"bytes,110,rep,name=ulimits" json:"ulimits,omitempty"`
+	// Maximum number of processes in the container. Default: 0 (not specified).
+	PidsLimit int64 `protobuf:"varint,111,opt,name=pids_limit,json=pidsLimit,proto3" json:"pids_limit,omitempty"`
+	// Memory + swap limit in bytes. Default: 0 (not specified).
+	MemorySwapLimitInBytes int64 `protobuf:"varint,112,opt,name=memory_swap_limit_in_bytes,json=memorySwapLimitInBytes,proto3" json:"memory_swap_limit_in_bytes,omitempty"`
 }
 
 func (m *LinuxContainerResources) Reset()                    { *m = LinuxContainerResources{} }
@@ -1506,6 +1530,20 @@ func (m *LinuxContainerResources) GetUlimits() []*Ulimit {
 	return nil
 }
 
+func (m *LinuxContainerResources) GetPidsLimit() int64 {
+	if m != nil {
+		return m.PidsLimit
+	}
+	return 0
+}
+
+func (m *LinuxContainerResources) GetMemorySwapLimitInBytes() int64 {
+	if m != nil {
+		return m.MemorySwapLimitInBytes
+	}
+	return 0
+}
+
 // WeightDevice is a structure that holds device:weight pair
 type WeightDevice struct {
 	// Path of weightdevice.
@@ -3684,6 +3722,8 @@ func (m *ImageFsInfoResponse) GetImageFilesystems() []*FilesystemUsage {
 type ContainerStatsRequest struct {
 	// ID of the container for which to retrieve stats.
 	ContainerId string `protobuf:"bytes,1,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
+	// Verbose indicates whether to return extra information about the container.
+	Verbose bool `protobuf:"varint,2,opt,name=verbose,proto3" json:"verbose,omitempty"`
 }
 
 func (m *ContainerStatsRequest) Reset()                    { *m = ContainerStatsRequest{} }
@@ -3697,6 +3737,13 @@ func (m *ContainerStatsRequest) GetContainerId() string {
 	return ""
 }
 
+func (m *ContainerStatsRequest) GetVerbose() bool {
+	if m != nil {
+		return m.Verbose
+	}
+	return false
+}
+
 type ContainerStatsResponse struct {
 	// Stats of the container.
 	Stats *ContainerStats `protobuf:"bytes,1,opt,name=stats" json:"stats,omitempty"`
@@ -3716,6 +3763,8 @@ func (m *ContainerStatsResponse) GetStats() *ContainerStats {
 type ListContainerStatsRequest struct {
 	// Filter for the list request.
 	Filter *ContainerStatsFilter `protobuf:"bytes,1,opt,name=filter" json:"filter,omitempty"`
+	// Verbose indicates whether to return extra information about the containers.
+	Verbose bool `protobuf:"varint,2,opt,name=verbose,proto3" json:"verbose,omitempty"`
 }
 
 func (m *ListContainerStatsRequest) Reset()                    { *m = ListContainerStatsRequest{} }
@@ -3729,6 +3778,13 @@ func (m *ListContainerStatsRequest) GetFilter() *ContainerStatsFilter {
 	return nil
 }
 
+func (m *ListContainerStatsRequest) GetVerbose() bool {
+	if m != nil {
+		return m.Verbose
+	}
+	return false
+}
+
 // ContainerStatsFilter is used to filter containers.
 // All those fields are combined with 'AND'
 type ContainerStatsFilter struct {
@@ -3840,6 +3896,10 @@ type ContainerStats struct {
 	Memory *MemoryUsage `protobuf:"bytes,3,opt,name=memory" json:"memory,omitempty"`
 	// Usage of the writeable layer.
 	WritableLayer *FilesystemUsage `protobuf:"bytes,4,opt,name=writable_layer,json=writableLayer" json:"writable_layer,omitempty"`
+	// Info is extra information of the container stats. The key could be arbitrary string, and
+	// value should be in json format, e.g. the pressure stall information of the container.
+	// It should only be returned non-empty when Verbose is true.
+	Info map[string]string `protobuf:"bytes,5,rep,name=info" json:"info,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
 func (m *ContainerStats) Reset()                    { *m = ContainerStats{} }
@@ -3874,6 +3934,13 @@ func (m *ContainerStats) GetWritableLayer() *FilesystemUsage {
 	return nil
 }
 
+func (m *ContainerStats) GetInfo() map[string]string {
+	if m != nil {
+		return m.Info
+	}
+	return nil
+}
+
 // CpuUsage provides the CPU usage information.
 type CpuUsage struct {
 	// Timestamp in nanoseconds at which the information were collected. Must be > 0.
@@ -3906,6 +3973,8 @@ type MemoryUsage struct {
 	Timestamp int64 `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
 	// The amount of working set memory in bytes.
 	WorkingSetBytes *UInt64Value `protobuf:"bytes,2,opt,name=working_set_bytes,json=workingSetBytes" json:"working_set_bytes,omitempty"`
+	// The amount of swap memory in bytes.
+	SwapUsageBytes *UInt64Value `protobuf:"bytes,100,opt,name=swap_usage_bytes,json=swapUsageBytes" json:"swap_usage_bytes,omitempty"`
 }
 
 func (m *MemoryUsage) Reset()                    { *m = MemoryUsage{} }
@@ -3926,6 +3995,13 @@ func (m *MemoryUsage) GetWorkingSetBytes() *UInt64Value {
 	return nil
 }
 
+func (m *MemoryUsage) GetSwapUsageBytes() *UInt64Value {
+	if m != nil {
+		return m.SwapUsageBytes
+	}
+	return nil
+}
+
 type ReopenContainerLogRequest struct {
 	// ID of the container for which to reopen the log.
 	ContainerId string `protobuf:"bytes,1,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
@@ -5672,6 +5748,12 @@ func (m *NamespaceOption) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintApi(dAtA, i, uint64(m.Ipc))
 	}
+	if len(m.TargetId) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintApi(dAtA, i, uint64(len(m.TargetId)))
+		i += copy(dAtA[i:], m.TargetId)
+	}
 	return i, nil
 }
 
@@ -6849,6 +6931,20 @@ func (m *LinuxContainerResources) MarshalTo(dAtA []byte) (int, error) {
 			i += n
 		}
 	}
+	if m.PidsLimit != 0 {
+		dAtA[i] = 0xf8
+		i++
+		dAtA[i] = 0x6
+		i++
+		i = encodeVarintApi(dAtA, i, uint64(m.PidsLimit))
+	}
+	if m.MemorySwapLimitInBytes != 0 {
+		dAtA[i] = 0x80
+		i++
+		dAtA[i] = 0x7
+		i++
+		i = encodeVarintApi(dAtA, i, uint64(m.MemorySwapLimitInBytes))
+	}
 	return i, nil
 }
 
@@ -9624,6 +9720,16 @@ func (m *ContainerStatsRequest) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintApi(dAtA, i, uint64(len(m.ContainerId)))
 		i += copy(dAtA[i:], m.ContainerId)
 	}
+	if m.Verbose {
+		dAtA[i] = 0x10
+		i++
+		if m.Verbose {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
 	return i, nil
 }
 
@@ -9680,6 +9786,16 @@ func (m *ListContainerStatsRequest) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i += n68
 	}
+	if m.Verbose {
+		dAtA[i] = 0x10
+		i++
+		if m.Verbose {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
 	return i, nil
 }
 
@@ -9883,6 +9999,23 @@ func (m *ContainerStats) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i += n73
 	}
+	if len(m.Info) > 0 {
+		for k := range m.Info {
+			dAtA[i] = 0x2a
+			i++
+			v := m.Info[k]
+			mapSize := 1 + len(k) + sovApi(uint64(len(k))) + 1 + len(v) + sovApi(uint64(len(v)))
+			i = encodeVarintApi(dAtA, i, uint64(mapSize))
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintApi(dAtA, i, uint64(len(k)))
+			i += copy(dAtA[i:], k)
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintApi(dAtA, i, uint64(len(v)))
+			i += copy(dAtA[i:], v)
+		}
+	}
 	return i, nil
 }
 
@@ -9949,6 +10082,18 @@ func (m *MemoryUsage) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i += n75
 	}
+	if m.SwapUsageBytes != nil {
+		dAtA[i] = 0xa2
+		i++
+		dAtA[i] = 0x6
+		i++
+		i = encodeVarintApi(dAtA, i, uint64(m.SwapUsageBytes.Size()))
+		n76, err := m.SwapUsageBytes.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n76
+	}
 	return i, nil
 }
 
@@ -10285,6 +10430,10 @@ func (m *NamespaceOption) Size() (n int) {
 	if m.Ipc != 0 {
 		n += 1 + sovApi(uint64(m.Ipc))
 	}
+	l = len(m.TargetId)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
 	return n
 }
 
@@ -10784,6 +10933,12 @@ func (m *LinuxContainerResources) Size() (n int) {
 			n += 2 + l + sovApi(uint64(l))
 		}
 	}
+	if m.PidsLimit != 0 {
+		n += 2 + sovApi(uint64(m.PidsLimit))
+	}
+	if m.MemorySwapLimitInBytes != 0 {
+		n += 2 + sovApi(uint64(m.MemorySwapLimitInBytes))
+	}
 	return n
 }
 
@@ -11938,6 +12093,9 @@ func (m *ContainerStatsRequest) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovApi(uint64(l))
 	}
+	if m.Verbose {
+		n += 2
+	}
 	return n
 }
 
@@ -11958,6 +12116,9 @@ func (m *ListContainerStatsRequest) Size() (n int) {
 		l = m.Filter.Size()
 		n += 1 + l + sovApi(uint64(l))
 	}
+	if m.Verbose {
+		n += 2
+	}
 	return n
 }
 
@@ -12044,6 +12205,14 @@ func (m *ContainerStats) Size() (n int) {
 		l = m.WritableLayer.Size()
 		n += 1 + l + sovApi(uint64(l))
 	}
+	if len(m.Info) > 0 {
+		for k, v := range m.Info {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovApi(uint64(len(k))) + 1 + len(v) + sovApi(uint64(len(v)))
+			n += mapEntrySize + 1 + sovApi(uint64(mapEntrySize))
+		}
+	}
 	return n
 }
 
@@ -12070,6 +12239,10 @@ func (m *MemoryUsage) Size() (n int) {
 		l = m.WorkingSetBytes.Size()
 		n += 1 + l + sovApi(uint64(l))
 	}
+	if m.SwapUsageBytes != nil {
+		l = m.SwapUsageBytes.Size()
+		n += 2 + l + sovApi(uint64(l))
+	}
 	return n
 }
 
@@ -12237,6 +12410,7 @@ func (this *NamespaceOption) String() string {
 		`Network:` + fmt.Sprintf("%v", this.Network) + `,`,
 		`Pid:` + fmt.Sprintf("%v", this.Pid) + `,`,
 		`Ipc:` + fmt.Sprintf("%v", this.Ipc) + `,`,
+		`TargetId:` + fmt.Sprintf("%v", this.TargetId) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -12639,6 +12813,8 @@ func (this *LinuxContainerResources) String() string {
 		`MemoryReservation:` + fmt.Sprintf("%v", this.MemoryReservation) + `,`,
 		`MemorySwappiness:` + strings.Replace(fmt.Sprintf("%v", this.MemorySwappiness), "Int64Value", "Int64Value", 1) + `,`,
 		`Ulimits:` + strings.Replace(fmt.Sprintf("%v", this.Ulimits), "Ulimit", "Ulimit", 1) + `,`,
+		`PidsLimit:` + fmt.Sprintf("%v", this.PidsLimit) + `,`,
+		`MemorySwapLimitInBytes:` + fmt.Sprintf("%v", this.MemorySwapLimitInBytes) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -13529,6 +13705,7 @@ func (this *ContainerStatsRequest) String() string {
 	}
 	s := strings.Join([]string{`&ContainerStatsRequest{`,
 		`ContainerId:` + fmt.Sprintf("%v", this.ContainerId) + `,`,
+		`Verbose:` + fmt.Sprintf("%v", this.Verbose) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -13549,6 +13726,7 @@ func (this *ListContainerStatsRequest) String() string {
 	}
 	s := strings.Join([]string{`&ListContainerStatsRequest{`,
 		`Filter:` + strings.Replace(fmt.Sprintf("%v", this.Filter), "ContainerStatsFilter", "ContainerStatsFilter", 1) + `,`,
+		`Verbose:` + fmt.Sprintf("%v", this.Verbose) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -13622,11 +13800,22 @@ func (this *ContainerStats) String() string {
 	if this == nil {
 		return "nil"
 	}
+	keysForInfo := make([]string, 0, len(this.Info))
+	for k := range this.Info {
+		keysForInfo = append(keysForInfo, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Strings(keysForInfo)
+	mapStringForInfo := "map[string]string{"
+	for _, k := range keysForInfo {
+		mapStringForInfo += fmt.Sprintf("%v: %v,", k, this.Info[k])
+	}
+	mapStringForInfo += "}"
 	s := strings.Join([]string{`&ContainerStats{`,
 		`Attributes:` + strings.Replace(fmt.Sprintf("%v", this.Attributes), "ContainerAttributes", "ContainerAttributes", 1) + `,`,
 		`Cpu:` + strings.Replace(fmt.Sprintf("%v", this.Cpu), "CpuUsage", "CpuUsage", 1) + `,`,
 		`Memory:` + strings.Replace(fmt.Sprintf("%v", this.Memory), "MemoryUsage", "MemoryUsage", 1) + `,`,
 		`WritableLayer:` + strings.Replace(fmt.Sprintf("%v", this.WritableLayer), "FilesystemUsage", "FilesystemUsage", 1) + `,`,
+		`Info:` + mapStringForInfo + `,`,
 		`}`,
 	}, "")
 	return s
@@ -13649,6 +13838,7 @@ func (this *MemoryUsage) String() string {
 	s := strings.Join([]string{`&MemoryUsage{`,
 		`Timestamp:` + fmt.Sprintf("%v", this.Timestamp) + `,`,
 		`WorkingSetBytes:` + strings.Replace(fmt.Sprintf("%v", this.WorkingSetBytes), "UInt64Value", "UInt64Value", 1) + `,`,
+		`SwapUsageBytes:` + strings.Replace(fmt.Sprintf("%v", this.SwapUsageBytes), "UInt64Value", "UInt64Value", 1) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -14556,6 +14746,35 @@ func (m *NamespaceOption) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TargetId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TargetId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipApi(dAtA[iNdEx:])
@@ -18877,6 +19096,44 @@ func (m *LinuxContainerResources) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 111:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PidsLimit", wireType)
+			}
+			m.PidsLimit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PidsLimit |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 112:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MemorySwapLimitInBytes", wireType)
+			}
+			m.MemorySwapLimitInBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MemorySwapLimitInBytes |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipApi(dAtA[iNdEx:])
@@ -28211,6 +28468,26 @@ func (m *ContainerStatsRequest) Unmarshal(dAtA []byte) error {
 			}
 			m.ContainerId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Verbose", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Verbose = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipApi(dAtA[iNdEx:])
@@ -28377,6 +28654,26 @@ func (m *ListContainerStatsRequest) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Verbose", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Verbose = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipApi(dAtA[iNdEx:])
@@ -29214,6 +29511,124 @@ func (m *ContainerStats) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Info", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Info == nil {
+				m.Info = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowApi
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowApi
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthApi
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowApi
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthApi
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipApi(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthApi
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Info[mapkey] = mapvalue
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipApi(dAtA[iNdEx:])
@@ -29418,6 +29833,39 @@ func (m *MemoryUsage) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 100:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SwapUsageBytes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.SwapUsageBytes == nil {
+				m.SwapUsageBytes = &UInt64Value{}
+			}
+			if err := m.SwapUsageBytes.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipApi(dAtA[iNdEx:])
@@ -30192,339 +30640,343 @@ var (
 func init() { proto.RegisterFile("api.proto", fileDescriptorApi) }
 
 var fileDescriptorApi = []byte{
-	// 5333 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x7c, 0x4b, 0x73, 0x1b, 0x57,
-	0x76, 0x30, 0xf1, 0x20, 0x09, 0x1c, 0x10, 0x24, 0x78, 0x49, 0x89, 0x10, 0x64, 0xbd, 0x5a, 0x6f,
-	0xd9, 0xa2, 0x2c, 0x7a, 0x46, 0xb6, 0x1e, 0x23, 0x9b, 0x22, 0x29, 0x09, 0xdf, 0x48, 0x20, 0xbe,
-	0x06, 0x69, 0xd9, 0x63, 0x57, 0xf5, 0x34, 0xd1, 0x97, 0x60, 0x5b, 0x40, 0x77, 0xbb, 0x6f, 0x43,
-	0x14, 0x93, 0xaa, 0x94, 0xab, 0x52, 0x35, 0x8b, 0xac, 0xb2, 0xce, 0x72, 0x66, 0x91, 0x45, 0x36,
-	0xa9, 0x54, 0x65, 0x95, 0x6c, 0x92, 0x9a, 0x4a, 0xcd, 0x66, 0xaa, 0xb2, 0x4a, 0xe5, 0xb1, 0x89,
-	0x9d, 0xac, 0x66, 0x91, 0xca, 0x3f, 0x48, 0xea, 0xbe, 0x1a, 0xfd, 0xc4, 0x83, 0x96, 0xc7, 0xce,
-	0x8a, 0xb8, 0xa7, 0xcf, 0x39, 0xf7, 0xdc, 0x73, 0x4f, 0x9f, 0x7b, 0x1e, 0xb7, 0x09, 0x45, 0xdd,
-	0x31, 0x57, 0x1d, 0xd7, 0xf6, 0x6c, 0x54, 0x71, 0xfb, 0x96, 0x67, 0xf6, 0xf0, 0xea, 0xab, 0xdb,
-	0x7a, 0xd7, 0x39, 0xd0, 0xd7, 0x6a, 0x37, 0x3b, 0xa6, 0x77, 0xd0, 0xdf, 0x5b, 0x6d, 0xdb, 0xbd,
-	0x5b, 0x1d, 0xbb, 0x63, 0xdf, 0x62, 0x88, 0x7b, 0xfd, 0x7d, 0x36, 0x62, 0x03, 0xf6, 0x8b, 0x33,
-	0x50, 0x6e, 0xc0, 0xfc, 0xc7, 0xd8, 0x25, 0xa6, 0x6d, 0xa9, 0xf8, 0xcb, 0x3e, 0x26, 0x1e, 0xaa,
-	0xc2, 0xec, 0x2b, 0x0e, 0xa9, 0x66, 0xce, 0x67, 0xae, 0x15, 0x55, 0x39, 0x54, 0xfe, 0x3c, 0x03,
-	0x0b, 0x3e, 0x32, 0x71, 0x6c, 0x8b, 0xe0, 0x74, 0x6c, 0x74, 0x01, 0xe6, 0x84, 0x70, 0x9a, 0xa5,
-	0xf7, 0x70, 0x35, 0xcb, 0x1e, 0x97, 0x04, 0xac, 0xa1, 0xf7, 0x30, 0xba, 0x0a, 0x0b, 0x12, 0x45,
-	0x32, 0xc9, 0x31, 0xac, 0x79, 0x01, 0x16, 0xb3, 0xa1, 0x55, 0x58, 0x92, 0x88, 0xba, 0x63, 0xfa,
-	0xc8, 0x79, 0x86, 0xbc, 0x28, 0x1e, 0xad, 0x3b, 0xa6, 0xc0, 0x57, 0x3e, 0x83, 0xe2, 0x66, 0xa3,
-	0xb5, 0x61, 0x5b, 0xfb, 0x66, 0x87, 0x8a, 0x48, 0xb0, 0x4b, 0x69, 0xaa, 0x99, 0xf3, 0x39, 0x2a,
-	0xa2, 0x18, 0xa2, 0x1a, 0x14, 0x08, 0xd6, 0xdd, 0xf6, 0x01, 0x26, 0xd5, 0x2c, 0x7b, 0xe4, 0x8f,
-	0x29, 0x95, 0xed, 0x78, 0xa6, 0x6d, 0x91, 0x6a, 0x8e, 0x53, 0x89, 0xa1, 0xf2, 0xcb, 0x0c, 0x94,
-	0x9a, 0xb6, 0xeb, 0x3d, 0xd7, 0x1d, 0xc7, 0xb4, 0x3a, 0xe8, 0x0e, 0x14, 0x98, 0x2e, 0xdb, 0x76,
-	0x97, 0xe9, 0x60, 0x7e, 0xad, 0xb6, 0x1a, 0xdd, 0x96, 0xd5, 0xa6, 0xc0, 0x50, 0x7d, 0x5c, 0x74,
-	0x19, 0xe6, 0xdb, 0xb6, 0xe5, 0xe9, 0xa6, 0x85, 0x5d, 0xcd, 0xb1, 0x5d, 0x8f, 0xa9, 0x68, 0x5a,
-	0x2d, 0xfb, 0x50, 0x3a, 0x0b, 0x3a, 0x0d, 0xc5, 0x03, 0x9b, 0x78, 0x1c, 0x23, 0xc7, 0x30, 0x0a,
-	0x14, 0xc0, 0x1e, 0xae, 0xc0, 0x2c, 0x7b, 0x68, 0x3a, 0x42, 0x19, 0x33, 0x74, 0x58, 0x77, 0x94,
-	0xdf, 0x65, 0x60, 0xfa, 0xb9, 0xdd, 0xb7, 0xbc, 0xc8, 0x34, 0xba, 0x77, 0x20, 0x36, 0x2a, 0x30,
-	0x8d, 0xee, 0x1d, 0x0c, 0xa6, 0xa1, 0x18, 0x7c, 0xaf, 0xf8, 0x34, 0xf4, 0x61, 0x0d, 0x0a, 0x2e,
-	0xd6, 0x0d, 0xdb, 0xea, 0x1e, 0x31, 0x11, 0x0a, 0xaa, 0x3f, 0xa6, 0x9b, 0x48, 0x70, 0xd7, 0xb4,
-	0xfa, 0xaf, 0x35, 0x17, 0x77, 0xf5, 0x3d, 0xdc, 0x65, 0xa2, 0x14, 0xd4, 0x79, 0x01, 0x56, 0x39,
-	0x14, 0x6d, 0x42, 0xc9, 0x71, 0x6d, 0x47, 0xef, 0xe8, 0x54, 0x8f, 0xd5, 0x69, 0xa6, 0x2a, 0x25,
-	0xae, 0x2a, 0x26, 0x76, 0x73, 0x80, 0xa9, 0x06, 0xc9, 0x10, 0x82, 0x3c, 0x33, 0x27, 0x83, 0x89,
-	0xc8, 0x7e, 0x2b, 0x7f, 0x95, 0x81, 0x05, 0x6a, 0x50, 0xc4, 0xd1, 0xdb, 0x78, 0x9b, 0x6d, 0x13,
-	0xba, 0x0b, 0xb3, 0x16, 0xf6, 0x0e, 0x6d, 0xf7, 0xa5, 0xd8, 0x94, 0x73, 0xf1, 0x99, 0x7c, 0x9a,
-	0xe7, 0xb6, 0x81, 0x55, 0x89, 0x8f, 0x6e, 0x43, 0xce, 0x31, 0x0d, 0xa6, 0x84, 0x31, 0xc8, 0x28,
-	0x2e, 0x25, 0x31, 0x9d, 0x36, 0xd3, 0xcd, 0x38, 0x24, 0xa6, 0xd3, 0x56, 0x14, 0x80, 0xba, 0xe5,
-	0xdd, 0xf9, 0xd1, 0xc7, 0x7a, 0xb7, 0x8f, 0xd1, 0x32, 0x4c, 0xbf, 0xa2, 0x3f, 0x98, 0xb0, 0x39,
-	0x95, 0x0f, 0x94, 0xaf, 0x73, 0x70, 0xfa, 0x19, 0xd5, 0x61, 0x4b, 0xb7, 0x8c, 0x3d, 0xfb, 0x75,
-	0x0b, 0xb7, 0xfb, 0xae, 0xe9, 0x1d, 0x6d, 0xd8, 0x96, 0x87, 0x5f, 0x7b, 0xa8, 0x01, 0x8b, 0x96,
-	0xe4, 0xac, 0x49, 0x73, 0xa5, 0x1c, 0x4a, 0x6b, 0x17, 0x86, 0x08, 0xc1, 0x55, 0xa4, 0x56, 0xac,
-	0x30, 0x80, 0xa0, 0xa7, 0x83, 0xbd, 0x94, 0xdc, 0xb2, 0x8c, 0x5b, 0xc2, 0x92, 0x5a, 0x5b, 0x4c,
-	0x32, 0xc1, 0x4b, 0x6e, 0xb6, 0xe4, 0xf4, 0x00, 0xe8, 0x9b, 0xae, 0xe9, 0x44, 0xeb, 0x13, 0xec,
-	0x32, 0xc5, 0x94, 0xd6, 0xde, 0x8a, 0x73, 0x19, 0xa8, 0x40, 0x2d, 0xba, 0x7d, 0x6b, 0x9d, 0xec,
-	0x12, 0xec, 0x32, 0xc7, 0x20, 0xec, 0x4b, 0x73, 0x6d, 0xdb, 0xdb, 0x27, 0xd2, 0xa6, 0x24, 0x58,
-	0x65, 0x50, 0x74, 0x0b, 0x96, 0x48, 0xdf, 0x71, 0xba, 0xb8, 0x87, 0x2d, 0x4f, 0xef, 0x6a, 0x1d,
-	0xd7, 0xee, 0x3b, 0xa4, 0x3a, 0x7d, 0x3e, 0x77, 0x2d, 0xa7, 0xa2, 0xe0, 0xa3, 0x27, 0xec, 0x09,
-	0x3a, 0x0b, 0xe0, 0xb8, 0xe6, 0x2b, 0xb3, 0x8b, 0x3b, 0xd8, 0xa8, 0xce, 0x30, 0xa6, 0x01, 0x08,
-	0x7a, 0x17, 0x96, 0x09, 0x6e, 0xb7, 0xed, 0x9e, 0xa3, 0x39, 0xae, 0xbd, 0x6f, 0x76, 0x31, 0x7f,
-	0x23, 0x66, 0x99, 0xb9, 0x21, 0xf1, 0xac, 0xc9, 0x1f, 0xb1, 0x77, 0xe3, 0x21, 0xf3, 0x73, 0x74,
-	0xa5, 0x6c, 0xf2, 0x6a, 0x61, 0x8c, 0xa5, 0x02, 0x5b, 0x2a, 0x13, 0x49, 0xf9, 0x65, 0x16, 0x4e,
-	0x30, 0x4d, 0x36, 0x6d, 0x43, 0x6c, 0xb3, 0x70, 0x5c, 0x17, 0xa1, 0xdc, 0x66, 0x3c, 0x35, 0x47,
-	0x77, 0xb1, 0xe5, 0x89, 0x17, 0x77, 0x8e, 0x03, 0x9b, 0x0c, 0x86, 0x3e, 0x81, 0x0a, 0x11, 0x56,
-	0xa1, 0xb5, 0xb9, 0x59, 0x88, 0x3d, 0xbb, 0x19, 0x17, 0x61, 0x88, 0x2d, 0xa9, 0x0b, 0x24, 0x66,
-	0x5c, 0xb3, 0xe4, 0x88, 0xb4, 0xbd, 0x2e, 0xf7, 0x80, 0xa5, 0xb5, 0x1f, 0xa5, 0x30, 0x8c, 0x0a,
-	0xbe, 0xda, 0xe2, 0x64, 0x5b, 0x96, 0xe7, 0x1e, 0xa9, 0x92, 0x49, 0xed, 0x1e, 0xcc, 0x05, 0x1f,
-	0xa0, 0x0a, 0xe4, 0x5e, 0xe2, 0x23, 0xb1, 0x28, 0xfa, 0x73, 0xf0, 0x12, 0x70, 0xff, 0xc3, 0x07,
-	0xf7, 0xb2, 0x1f, 0x64, 0x14, 0x17, 0xd0, 0x60, 0x96, 0xe7, 0xd8, 0xd3, 0x0d, 0xdd, 0xd3, 0x7d,
-	0x5f, 0x90, 0x19, 0xf8, 0x02, 0xca, 0xb5, 0x2f, 0x5e, 0xde, 0xa2, 0x4a, 0x7f, 0xa2, 0xb7, 0xa0,
-	0xe8, 0x1b, 0xba, 0x38, 0x5f, 0x06, 0x00, 0xea, 0xe7, 0x75, 0xcf, 0xc3, 0x3d, 0xc7, 0x63, 0x26,
-	0x56, 0x56, 0xe5, 0x50, 0xf9, 0xaf, 0x3c, 0x54, 0x62, 0x7b, 0xf2, 0x11, 0x14, 0x7a, 0x62, 0x7a,
-	0xf1, 0xa2, 0x5d, 0x4a, 0x70, 0xf6, 0x31, 0x51, 0x55, 0x9f, 0x8a, 0xfa, 0x52, 0xea, 0x57, 0x03,
-	0x67, 0xa2, 0x3f, 0xa6, 0x3b, 0xde, 0xb5, 0x3b, 0x9a, 0x61, 0xba, 0xb8, 0xed, 0xd9, 0xee, 0x91,
-	0x10, 0x77, 0xae, 0x6b, 0x77, 0x36, 0x25, 0x0c, 0xdd, 0x03, 0x30, 0x2c, 0x42, 0x37, 0x7b, 0xdf,
-	0xec, 0x30, 0xa1, 0x4b, 0x6b, 0xa7, 0xe3, 0x42, 0xf8, 0x07, 0xa0, 0x5a, 0x34, 0x2c, 0x22, 0xc4,
-	0x7f, 0x04, 0x65, 0x7a, 0x8e, 0x68, 0x3d, 0x7e, 0x76, 0xf1, 0x37, 0xa5, 0xb4, 0x76, 0x26, 0x69,
-	0x0d, 0xfe, 0x09, 0xa7, 0xce, 0x39, 0x83, 0x01, 0x41, 0x8f, 0x61, 0x86, 0x39, 0x74, 0x52, 0x9d,
-	0x61, 0xc4, 0xab, 0xc3, 0x14, 0x20, 0x2c, 0xe2, 0x19, 0x23, 0xe0, 0x06, 0x21, 0xa8, 0xd1, 0x2e,
-	0x94, 0x74, 0xcb, 0xb2, 0x3d, 0x9d, 0x3b, 0x9a, 0x59, 0xc6, 0xec, 0xbd, 0x31, 0x98, 0xad, 0x0f,
-	0xa8, 0x38, 0xc7, 0x20, 0x1f, 0xf4, 0x13, 0x98, 0x66, 0x9e, 0x48, 0xbc, 0x88, 0x57, 0xc7, 0x34,
-	0x5a, 0x95, 0x53, 0xd5, 0xee, 0x42, 0x29, 0x20, 0xec, 0x24, 0x46, 0x5a, 0x7b, 0x08, 0x95, 0xa8,
-	0x68, 0x13, 0x19, 0xf9, 0x1f, 0xc2, 0xb2, 0xda, 0xb7, 0x06, 0x82, 0xc9, 0x88, 0xec, 0x1e, 0xcc,
-	0x88, 0xcd, 0xe6, 0x16, 0xa7, 0x8c, 0xd6, 0x91, 0x2a, 0x28, 0x82, 0x21, 0xd6, 0x81, 0x6e, 0x19,
-	0x5d, 0xec, 0x8a, 0x79, 0x65, 0x88, 0xf5, 0x94, 0x43, 0x95, 0x9f, 0xc0, 0x89, 0xc8, 0xe4, 0x22,
-	0xc2, 0xbb, 0x04, 0xf3, 0x8e, 0x6d, 0x68, 0x84, 0x83, 0x35, 0xd3, 0x90, 0x6e, 0xc8, 0xf1, 0x71,
-	0xeb, 0x06, 0x25, 0x6f, 0x79, 0xb6, 0x13, 0x17, 0x7e, 0x3c, 0xf2, 0x2a, 0x9c, 0x8c, 0x92, 0xf3,
-	0xe9, 0x95, 0x0f, 0x61, 0x45, 0xc5, 0x3d, 0xfb, 0x15, 0x3e, 0x2e, 0xeb, 0x1a, 0x54, 0xe3, 0x0c,
-	0x04, 0xf3, 0x4f, 0x61, 0x65, 0x00, 0x6d, 0x79, 0xba, 0xd7, 0x27, 0x13, 0x31, 0x17, 0xe1, 0xef,
-	0x9e, 0x4d, 0xf8, 0x76, 0x16, 0x54, 0x39, 0x54, 0xae, 0x07, 0x59, 0x37, 0x78, 0x64, 0xc1, 0x67,
-	0x40, 0xf3, 0x90, 0x35, 0x1d, 0xc1, 0x2e, 0x6b, 0x3a, 0xca, 0x53, 0x28, 0xfa, 0x47, 0x33, 0xba,
-	0x3f, 0x88, 0x3b, 0xb3, 0xe3, 0x1e, 0xe4, 0x7e, 0x68, 0xba, 0x13, 0x3b, 0x4a, 0xc4, 0x94, 0xf7,
-	0x01, 0x7c, 0x97, 0x27, 0x23, 0x84, 0xd3, 0x43, 0x18, 0xab, 0x01, 0x74, 0xe5, 0x5f, 0x43, 0x8e,
-	0x30, 0xb0, 0x08, 0xc3, 0x5f, 0x84, 0x11, 0x72, 0x8c, 0xd9, 0x63, 0x39, 0xc6, 0xf7, 0x61, 0x9a,
-	0x78, 0xba, 0x87, 0x45, 0x14, 0x75, 0x61, 0x18, 0x39, 0x15, 0x02, 0xab, 0x1c, 0x1f, 0x9d, 0x01,
-	0x68, 0xbb, 0x58, 0xf7, 0xb0, 0xa1, 0xe9, 0xdc, 0x8b, 0xe7, 0xd4, 0xa2, 0x80, 0xac, 0x7b, 0x68,
-	0x63, 0x10, 0x09, 0x4e, 0x33, 0xc1, 0xae, 0x0f, 0xe3, 0x1c, 0xda, 0xaa, 0x41, 0x4c, 0xe8, 0x7b,
-	0x95, 0x99, 0x31, 0xbd, 0x8a, 0x60, 0xc0, 0xa9, 0x02, 0x3e, 0x73, 0x76, 0xb4, 0xcf, 0xe4, 0xa4,
-	0xe3, 0xf8, 0xcc, 0xc2, 0x68, 0x9f, 0x29, 0x98, 0x0d, 0xf5, 0x99, 0xdf, 0xa7, 0xd3, 0xfb, 0x97,
-	0x0c, 0x54, 0xe3, 0xef, 0xa0, 0xf0, 0x3d, 0xf7, 0x60, 0x86, 0x30, 0xc8, 0x38, 0x9e, 0x4f, 0xd0,
-	0x0a, 0x0a, 0xf4, 0x14, 0xf2, 0xa6, 0xb5, 0x6f, 0xb3, 0xc4, 0x2e, 0x31, 0x76, 0x49, 0x9b, 0x75,
-	0xb5, 0x6e, 0xed, 0xdb, 0x5c, 0x49, 0x8c, 0x43, 0xed, 0x7d, 0x28, 0xfa, 0xa0, 0x89, 0xd6, 0xb6,
-	0x0d, 0xcb, 0x11, 0x93, 0xe5, 0xc1, 0xbe, 0x6f, 0xe9, 0x99, 0xc9, 0x2c, 0x5d, 0xf9, 0x2a, 0x1b,
-	0x7c, 0x13, 0x1f, 0x9b, 0x5d, 0x0f, 0xbb, 0xb1, 0x37, 0xf1, 0x81, 0xe4, 0xce, 0x5f, 0xc3, 0x2b,
-	0x23, 0xb9, 0xf3, 0x98, 0x54, 0xbc, 0x4c, 0x9f, 0xc3, 0x3c, 0xb3, 0x35, 0x8d, 0xe0, 0x2e, 0x0b,
-	0x38, 0x44, 0xf0, 0xf7, 0xe3, 0x61, 0x6c, 0xb8, 0x24, 0xdc, 0x62, 0x5b, 0x82, 0x8e, 0x6b, 0xb0,
-	0xdc, 0x0d, 0xc2, 0x6a, 0x1f, 0x01, 0x8a, 0x23, 0x4d, 0xa4, 0xd3, 0x16, 0x75, 0x71, 0x34, 0xfb,
-	0x4d, 0x38, 0x25, 0xf7, 0x99, 0x18, 0xe3, 0xd8, 0x0a, 0x17, 0x58, 0x15, 0x14, 0xca, 0xaf, 0x73,
-	0x00, 0x83, 0x87, 0xff, 0x87, 0x7c, 0xdb, 0x47, 0xbe, 0x5f, 0xe1, 0x81, 0xdc, 0xb5, 0x61, 0x8c,
-	0x13, 0x3d, 0xca, 0x76, 0xd8, 0xa3, 0xf0, 0x90, 0xee, 0xe6, 0x50, 0x36, 0x3f, 0x58, 0x5f, 0xf2,
-	0x0c, 0x4e, 0x46, 0x6d, 0x43, 0x38, 0x92, 0x35, 0x98, 0x36, 0x3d, 0xdc, 0xe3, 0x15, 0xa0, 0xc4,
-	0xec, 0x2c, 0x40, 0xc4, 0x51, 0x95, 0x0b, 0x50, 0xac, 0xf7, 0xf4, 0x0e, 0x6e, 0x39, 0xb8, 0x4d,
-	0x27, 0x35, 0xe9, 0x40, 0x08, 0xc2, 0x07, 0xca, 0x1a, 0x14, 0x7e, 0x8a, 0x8f, 0xf8, 0x4b, 0x3d,
-	0xa6, 0xa0, 0xca, 0x3f, 0x14, 0x60, 0x85, 0x9d, 0x15, 0x1b, 0xb2, 0xfe, 0xa2, 0x62, 0x62, 0xf7,
-	0xdd, 0x36, 0x26, 0x6c, 0xb7, 0x9d, 0xbe, 0xe6, 0x60, 0xd7, 0xb4, 0x0d, 0x51, 0x0a, 0x28, 0xb6,
-	0x9d, 0x7e, 0x93, 0x01, 0xd0, 0x69, 0xa0, 0x03, 0xed, 0xcb, 0xbe, 0x2d, 0x0c, 0x31, 0xa7, 0x16,
-	0xda, 0x4e, 0xff, 0xff, 0xd3, 0xb1, 0xa4, 0x25, 0x07, 0xba, 0x8b, 0x09, 0xb3, 0x33, 0x4e, 0xdb,
-	0x62, 0x00, 0x74, 0x1b, 0x4e, 0xf4, 0x70, 0xcf, 0x76, 0x8f, 0xb4, 0xae, 0xd9, 0x33, 0x3d, 0xcd,
-	0xb4, 0xb4, 0xbd, 0x23, 0x0f, 0x13, 0x61, 0x53, 0x88, 0x3f, 0x7c, 0x46, 0x9f, 0xd5, 0xad, 0x47,
-	0xf4, 0x09, 0x52, 0xa0, 0x6c, 0xdb, 0x3d, 0x8d, 0xb4, 0x6d, 0x17, 0x6b, 0xba, 0xf1, 0x05, 0x3b,
-	0x3e, 0x73, 0x6a, 0xc9, 0xb6, 0x7b, 0x2d, 0x0a, 0x5b, 0x37, 0xbe, 0x40, 0xe7, 0xa0, 0xd4, 0x76,
-	0xfa, 0x04, 0x7b, 0x1a, 0xfd, 0xc3, 0x4e, 0xc7, 0xa2, 0x0a, 0x1c, 0xb4, 0xe1, 0xf4, 0x49, 0x00,
-	0xa1, 0x47, 0xf5, 0x3f, 0x1b, 0x44, 0x78, 0x8e, 0x7b, 0x04, 0xbd, 0x00, 0x30, 0x4c, 0xf2, 0x52,
-	0xac, 0xca, 0x60, 0xfb, 0xf3, 0x41, 0xca, 0xf1, 0x1a, 0x57, 0xd9, 0xea, 0xa6, 0x49, 0x5e, 0x32,
-	0x05, 0x70, 0x53, 0x2c, 0x1a, 0x72, 0x8c, 0x2e, 0xc0, 0xdc, 0x5e, 0xf7, 0xa5, 0x69, 0x6b, 0x87,
-	0xd8, 0xec, 0x1c, 0x78, 0x55, 0xcc, 0xd2, 0xbb, 0x12, 0x83, 0xbd, 0x60, 0x20, 0xd4, 0x80, 0xa5,
-	0x20, 0x8a, 0x66, 0xe0, 0x57, 0x66, 0x1b, 0x57, 0xf7, 0x99, 0x10, 0x67, 0xe3, 0x42, 0x70, 0xb2,
-	0x4d, 0x86, 0xa5, 0x2e, 0x06, 0x38, 0x71, 0x10, 0x6a, 0xc1, 0x09, 0xce, 0x8f, 0x33, 0xd2, 0x5c,
-	0xac, 0x1b, 0xda, 0x9e, 0x43, 0xaa, 0x1d, 0xc6, 0xf1, 0x7c, 0x9c, 0xe3, 0xce, 0x81, 0x6b, 0x7b,
-	0x5e, 0x17, 0x0b, 0x9e, 0x88, 0x91, 0x8b, 0x01, 0xd6, 0x8d, 0x47, 0x0e, 0x3d, 0xf3, 0x4f, 0x86,
-	0x98, 0x1e, 0xba, 0xa6, 0x87, 0x19, 0xd7, 0x83, 0x31, 0xb9, 0x2e, 0x05, 0xb8, 0xbe, 0xa0, 0xd4,
-	0x49, 0x6c, 0x99, 0xac, 0xf5, 0x6d, 0x87, 0x54, 0xcd, 0x63, 0xb0, 0xa5, 0xc2, 0x52, 0x62, 0xf4,
-	0x02, 0x56, 0x12, 0xa4, 0x65, 0x7c, 0xbf, 0x18, 0x93, 0xef, 0x72, 0x54, 0x5c, 0xc6, 0xf8, 0x22,
-	0x94, 0x5f, 0x62, 0xd7, 0xc2, 0x5d, 0x8d, 0x9b, 0x6a, 0xf5, 0x25, 0xb3, 0xc6, 0x39, 0x0e, 0x7c,
-	0xce, 0x60, 0xe8, 0x26, 0x08, 0x43, 0xd6, 0x5c, 0x4c, 0xb0, 0xfb, 0x8a, 0x97, 0x1a, 0xbb, 0x0c,
-	0x73, 0x91, 0x3f, 0x51, 0x07, 0x0f, 0x50, 0x1d, 0x04, 0x50, 0x23, 0x87, 0x2c, 0xbd, 0xc5, 0x84,
-	0x54, 0x7b, 0x63, 0x14, 0x70, 0x2a, 0x9c, 0xac, 0xe5, 0x53, 0xa1, 0x35, 0x98, 0xed, 0xb3, 0x37,
-	0x8b, 0x54, 0x2d, 0xb6, 0xce, 0x6a, 0x9c, 0xc1, 0x2e, 0x43, 0x50, 0x25, 0x62, 0xed, 0x01, 0xcc,
-	0x87, 0xcd, 0x77, 0x22, 0x6f, 0x77, 0x0f, 0xe6, 0x42, 0xc6, 0x87, 0x20, 0x1f, 0x28, 0xef, 0xb2,
-	0xdf, 0xe8, 0x24, 0xcc, 0x70, 0x1c, 0x46, 0x5e, 0x56, 0xc5, 0x48, 0xf9, 0x00, 0xe6, 0xc3, 0x4a,
-	0x4f, 0xa4, 0x46, 0x90, 0x77, 0x65, 0x20, 0x91, 0x57, 0xd9, 0x6f, 0x65, 0x13, 0x66, 0xf8, 0x32,
-	0x12, 0xab, 0x2f, 0x08, 0xf2, 0x07, 0xba, 0x6b, 0x08, 0xe7, 0xc4, 0x7e, 0x53, 0x18, 0xb1, 0xf7,
-	0x3d, 0xe1, 0x92, 0xd8, 0x6f, 0x45, 0x87, 0x72, 0xa8, 0x7e, 0x48, 0x91, 0x58, 0xa1, 0x50, 0x30,
-	0xa3, 0xbf, 0xd9, 0xf4, 0x76, 0x57, 0xae, 0x9c, 0xfd, 0xa6, 0x30, 0xef, 0xc8, 0x91, 0x75, 0x1c,
-	0xf6, 0x9b, 0xaa, 0xa8, 0x8b, 0x5f, 0x89, 0xba, 0x73, 0x51, 0xe5, 0x03, 0xc5, 0x00, 0xd8, 0xd0,
-	0x1d, 0x7d, 0xcf, 0xec, 0x9a, 0xde, 0x11, 0xba, 0x0e, 0x15, 0xdd, 0x30, 0xb4, 0xb6, 0x84, 0x98,
-	0x58, 0x76, 0x03, 0x16, 0x74, 0xc3, 0xd8, 0x08, 0x80, 0xd1, 0xdb, 0xb0, 0x68, 0xb8, 0xb6, 0x13,
-	0xc6, 0xe5, 0xed, 0x81, 0x0a, 0x7d, 0x10, 0x44, 0x56, 0xfe, 0x73, 0x1a, 0xce, 0x84, 0x5d, 0x53,
-	0xb4, 0x46, 0xfb, 0x11, 0xcc, 0x45, 0x66, 0x4d, 0x31, 0xaf, 0x81, 0xb4, 0x6a, 0x88, 0x22, 0x52,
-	0xb3, 0xcc, 0xc6, 0x6a, 0x96, 0x89, 0x55, 0xe0, 0xdc, 0x1b, 0xad, 0x02, 0xe7, 0xdf, 0x48, 0x15,
-	0x78, 0x7a, 0xb2, 0x2a, 0xf0, 0x15, 0x56, 0xbb, 0x90, 0xd4, 0xcc, 0xd6, 0xf8, 0xf9, 0x52, 0xf6,
-	0x71, 0x2c, 0xd9, 0x46, 0x8a, 0x54, 0x8b, 0x67, 0x27, 0xa9, 0x16, 0x17, 0x52, 0xab, 0xc5, 0xd4,
-	0x6a, 0x1c, 0x47, 0x77, 0x7b, 0xb6, 0x2b, 0xcb, 0xc1, 0xd5, 0x22, 0x13, 0x61, 0x41, 0xc2, 0x45,
-	0x29, 0x38, 0xb5, 0x70, 0x0c, 0xa9, 0x85, 0xe3, 0xf3, 0x30, 0x67, 0xd9, 0x9a, 0x85, 0x0f, 0x35,
-	0xba, 0x97, 0xa4, 0x5a, 0xe2, 0x1b, 0x6b, 0xd9, 0x0d, 0x7c, 0xd8, 0xa4, 0x90, 0x58, 0x69, 0x79,
-	0x6e, 0xb2, 0xd2, 0x32, 0x3d, 0x01, 0x7b, 0x3a, 0x79, 0x89, 0x0d, 0x26, 0x0a, 0xa9, 0x96, 0x99,
-	0x11, 0x97, 0x38, 0x8c, 0xca, 0x40, 0xd0, 0x65, 0xf0, 0x95, 0x24, 0x90, 0xe6, 0x19, 0x52, 0x59,
-	0x42, 0x19, 0x9a, 0xf2, 0x37, 0x19, 0x58, 0x0e, 0x9b, 0xb9, 0x28, 0x28, 0x3e, 0x81, 0xa2, 0x2b,
-	0xcf, 0x62, 0x61, 0xda, 0xd7, 0xc7, 0x3e, 0xbc, 0xd5, 0x01, 0x2d, 0xfa, 0x59, 0x6a, 0x1d, 0xfb,
-	0xd6, 0x28, 0x7e, 0xa3, 0x2a, 0xd9, 0x4a, 0x1d, 0xce, 0xbd, 0x30, 0x2d, 0xc3, 0x3e, 0x24, 0xa9,
-	0x6f, 0x69, 0x82, 0xad, 0x65, 0x12, 0x6c, 0x4d, 0xf9, 0xbb, 0x0c, 0x9c, 0x8c, 0xf2, 0x12, 0xaa,
-	0xa8, 0xc7, 0x55, 0xf1, 0x76, 0x42, 0x08, 0x11, 0x21, 0x4e, 0x54, 0xc6, 0xe7, 0xa9, 0xca, 0xb8,
-	0x3d, 0x9a, 0xe3, 0x48, 0x75, 0xfc, 0x45, 0x06, 0x4e, 0xa5, 0x8a, 0x11, 0x89, 0x23, 0x33, 0xd1,
-	0x38, 0x52, 0xc4, 0xa0, 0x6d, 0xbb, 0x6f, 0x79, 0x81, 0x18, 0x74, 0x83, 0xf5, 0x1a, 0x79, 0xb0,
-	0xa7, 0xf5, 0xf4, 0xd7, 0x66, 0xaf, 0xdf, 0x13, 0x1e, 0x9f, 0xb2, 0x7b, 0xce, 0x21, 0xc7, 0x88,
-	0x42, 0x95, 0x75, 0x58, 0xf4, 0xa5, 0x1c, 0x5a, 0xf9, 0x0f, 0x54, 0xf2, 0xb3, 0xe1, 0x4a, 0xbe,
-	0x05, 0x33, 0xe2, 0x94, 0x7b, 0x13, 0xcd, 0xd0, 0xf3, 0x50, 0x72, 0xb0, 0xdb, 0x33, 0x09, 0xf1,
-	0x1d, 0x6d, 0x51, 0x0d, 0x82, 0x94, 0x5f, 0xcd, 0xc2, 0x42, 0xd4, 0x3a, 0x3e, 0x8c, 0x35, 0x0e,
-	0x2e, 0x26, 0x1c, 0x01, 0xd1, 0x85, 0x06, 0x52, 0xc8, 0xdb, 0x32, 0x03, 0xc9, 0xa6, 0x55, 0xef,
-	0xfc, 0x6c, 0x45, 0xa4, 0x27, 0x54, 0x23, 0x6d, 0xbb, 0xd7, 0xd3, 0x2d, 0x43, 0xf6, 0xb0, 0xc5,
-	0x90, 0xea, 0x4f, 0x77, 0x3b, 0x54, 0xed, 0x14, 0xcc, 0x7e, 0xd3, 0xcd, 0x3b, 0xb4, 0xdd, 0x97,
-	0xa6, 0xc5, 0x1a, 0x10, 0xcc, 0x59, 0x17, 0x55, 0x10, 0xa0, 0x4d, 0xd3, 0x45, 0xab, 0x90, 0xc7,
-	0xd6, 0x2b, 0x99, 0x23, 0x26, 0x34, 0xb9, 0x65, 0x2e, 0xa4, 0x32, 0x3c, 0x74, 0x0b, 0x66, 0x7a,
-	0xd4, 0x2c, 0x64, 0xd1, 0x6b, 0x25, 0xa5, 0xd7, 0xab, 0x0a, 0x34, 0x1a, 0x43, 0xf1, 0xa8, 0x51,
-	0x56, 0xb6, 0x12, 0x62, 0x28, 0x11, 0x23, 0x4a, 0x44, 0xb4, 0xe5, 0x67, 0xc0, 0xc5, 0xb4, 0xd4,
-	0x35, 0xb2, 0x15, 0x89, 0x69, 0xf0, 0x4e, 0x38, 0x0d, 0x06, 0xc6, 0x6b, 0x6d, 0x34, 0xaf, 0xe1,
-	0xbd, 0x88, 0x53, 0x50, 0xe8, 0xda, 0x1d, 0x6e, 0x46, 0x25, 0x7e, 0x3d, 0xa2, 0x6b, 0x77, 0x98,
-	0x15, 0x2d, 0xc3, 0x34, 0xf1, 0x0c, 0xd3, 0x62, 0x4e, 0xbd, 0xa0, 0xf2, 0x01, 0x7d, 0xf9, 0xd8,
-	0x0f, 0xcd, 0xb6, 0xda, 0xb8, 0x5a, 0x66, 0x8f, 0x8a, 0x0c, 0xb2, 0x6d, 0xb5, 0x59, 0x8e, 0xe9,
-	0x79, 0x47, 0xd5, 0x79, 0x06, 0xa7, 0x3f, 0xd1, 0x03, 0x59, 0x97, 0x5c, 0x48, 0x2b, 0xf6, 0x24,
-	0xb9, 0x6d, 0x59, 0x96, 0x7c, 0x04, 0xb3, 0x87, 0xdc, 0x11, 0x54, 0x2b, 0x8c, 0xfe, 0xda, 0x68,
-	0xf7, 0x22, 0x38, 0x48, 0x42, 0x7a, 0xc8, 0x58, 0xd8, 0xa3, 0x67, 0x98, 0x4d, 0x9d, 0x0c, 0x6b,
-	0xcc, 0xe7, 0xd4, 0x92, 0x85, 0xbd, 0xa6, 0x00, 0x51, 0x35, 0xb0, 0xec, 0x4e, 0x33, 0x0d, 0x96,
-	0x85, 0x15, 0xd5, 0x59, 0x36, 0xae, 0x1b, 0xdf, 0x67, 0xb5, 0xe0, 0xd7, 0x19, 0x38, 0xb9, 0xc1,
-	0x2a, 0x29, 0x01, 0x2f, 0x38, 0x49, 0xf1, 0xff, 0xae, 0xdf, 0x97, 0x49, 0xad, 0xd4, 0x47, 0xb5,
-	0x26, 0xdb, 0x32, 0x75, 0x98, 0x97, 0xcc, 0x05, 0x8b, 0xdc, 0xd8, 0xad, 0x9d, 0x32, 0x09, 0x0e,
-	0x95, 0x07, 0xb0, 0x12, 0x5b, 0x85, 0xa8, 0x7a, 0x5c, 0x80, 0xb9, 0x81, 0xb7, 0xf3, 0x17, 0x51,
-	0xf2, 0x61, 0x75, 0x43, 0xb9, 0x07, 0x27, 0x5a, 0x9e, 0xee, 0x7a, 0x31, 0x15, 0x8c, 0x41, 0xcb,
-	0x9a, 0x36, 0x61, 0x5a, 0xd1, 0x57, 0x69, 0xc1, 0x72, 0xcb, 0xb3, 0x9d, 0x63, 0x30, 0xa5, 0x3e,
-	0x8b, 0xae, 0xdf, 0xee, 0xcb, 0xd3, 0x45, 0x0e, 0x95, 0x15, 0xde, 0x62, 0x8a, 0xcf, 0x76, 0x1f,
-	0x4e, 0xf2, 0x0e, 0xcf, 0x71, 0x16, 0x71, 0x4a, 0xf6, 0x97, 0xe2, 0x7c, 0x9f, 0xc3, 0xd2, 0xe0,
-	0x50, 0x1d, 0x54, 0x6f, 0xef, 0x84, 0xab, 0xb7, 0xe7, 0x87, 0xec, 0x7a, 0xa8, 0x78, 0xfb, 0xab,
-	0x6c, 0xe0, 0x54, 0x48, 0xa9, 0xdd, 0xde, 0x0f, 0xd7, 0x6e, 0x2f, 0x8f, 0xe2, 0x1d, 0x2a, 0xdd,
-	0xc6, 0xad, 0x36, 0x97, 0x60, 0xb5, 0x9f, 0xc5, 0x0a, 0xbc, 0xf9, 0xb4, 0x0a, 0x79, 0x44, 0xda,
-	0xdf, 0x4b, 0x7d, 0x57, 0xe5, 0xf5, 0x5d, 0x7f, 0x6a, 0xbf, 0x21, 0x77, 0x37, 0x52, 0xdf, 0xbd,
-	0x30, 0x52, 0x5e, 0xbf, 0xbc, 0xfb, 0xd7, 0x79, 0x28, 0xfa, 0xcf, 0x62, 0x3a, 0x8f, 0xab, 0x2d,
-	0x9b, 0xa0, 0xb6, 0xe0, 0xf9, 0x9d, 0xfb, 0x56, 0xe7, 0x77, 0x7e, 0xec, 0xf3, 0xfb, 0x34, 0x14,
-	0xd9, 0x0f, 0xcd, 0xc5, 0xfb, 0xe2, 0x3c, 0x2e, 0x30, 0x80, 0x8a, 0xf7, 0x07, 0x66, 0x38, 0x33,
-	0x91, 0x19, 0x46, 0x2a, 0xca, 0xb3, 0xd1, 0x8a, 0xf2, 0x87, 0xfe, 0x79, 0xca, 0x8f, 0xe0, 0xab,
-	0x43, 0xf8, 0x26, 0x9e, 0xa4, 0x8d, 0xf0, 0x49, 0xca, 0x4f, 0xe5, 0x77, 0x86, 0x71, 0xf9, 0xc1,
-	0xd6, 0x93, 0x77, 0x79, 0x3d, 0x39, 0x68, 0x8b, 0xc2, 0xb3, 0xde, 0x07, 0xf0, 0x9d, 0x88, 0x2c,
-	0x2a, 0x9f, 0x1e, 0xb2, 0x46, 0x35, 0x80, 0x4e, 0xd9, 0x86, 0xb6, 0x66, 0xd0, 0x74, 0x1e, 0xcf,
-	0x3f, 0xa6, 0x74, 0x9c, 0xff, 0xb2, 0x10, 0xf0, 0x2f, 0x29, 0x5d, 0xda, 0x0f, 0x63, 0x9d, 0x8c,
-	0x09, 0xad, 0xf8, 0x4e, 0xb8, 0x91, 0x71, 0x4c, 0xab, 0x8b, 0xf5, 0x31, 0x58, 0xdc, 0xa3, 0xbb,
-	0xe2, 0x31, 0xaf, 0x33, 0x17, 0x05, 0x64, 0x9d, 0xe5, 0x15, 0xfb, 0xa6, 0x65, 0x92, 0x03, 0xfe,
-	0x7c, 0x86, 0xe7, 0x15, 0x12, 0xb4, 0xce, 0x2e, 0x49, 0xe2, 0xd7, 0xa6, 0xa7, 0xb5, 0x6d, 0x03,
-	0x33, 0x9b, 0x9e, 0x56, 0x0b, 0x14, 0xb0, 0x61, 0x1b, 0x78, 0xf0, 0xe6, 0x15, 0x8e, 0xf7, 0xe6,
-	0x15, 0x23, 0x6f, 0xde, 0x49, 0x98, 0x71, 0xb1, 0x4e, 0x6c, 0x4b, 0x24, 0xf7, 0x62, 0x44, 0xb7,
-	0xa6, 0x87, 0x09, 0xa1, 0x33, 0x89, 0x60, 0x4f, 0x0c, 0x03, 0x41, 0xea, 0xdc, 0xc8, 0x20, 0x75,
-	0x48, 0xf7, 0x37, 0x12, 0xa4, 0x96, 0x47, 0x06, 0xa9, 0xe3, 0x34, 0x7f, 0x03, 0x61, 0xfa, 0xfc,
-	0x78, 0x61, 0x7a, 0x30, 0xaa, 0x5d, 0x08, 0x47, 0xb5, 0x4f, 0x61, 0xf6, 0x95, 0xdd, 0xed, 0xf7,
-	0x30, 0x11, 0x95, 0xfc, 0xd5, 0xd1, 0xd2, 0x7d, 0xcc, 0x09, 0xc4, 0x6d, 0x31, 0x41, 0x1e, 0x2e,
-	0x2c, 0xe0, 0x6f, 0x51, 0x58, 0x08, 0x06, 0x9f, 0xfb, 0xa1, 0xe0, 0xd3, 0x4f, 0x68, 0x3a, 0xe3,
-	0x25, 0x34, 0xdf, 0xa3, 0x2b, 0xaa, 0xed, 0xc0, 0x5c, 0x50, 0x4f, 0x09, 0xb4, 0xab, 0x41, 0xda,
-	0xc4, 0xd4, 0x89, 0x33, 0x08, 0x3a, 0xb8, 0x02, 0xcc, 0x70, 0xa0, 0xf2, 0x4f, 0x19, 0x58, 0x89,
-	0x39, 0x25, 0xe1, 0xec, 0xee, 0x46, 0xba, 0xf0, 0x17, 0x46, 0xee, 0xa9, 0xdf, 0x84, 0x7f, 0x12,
-	0x6a, 0xc2, 0xbf, 0x37, 0x9a, 0xf0, 0x8d, 0xf7, 0xe0, 0xff, 0x36, 0x0b, 0xe7, 0x76, 0x1d, 0x23,
-	0x12, 0x1f, 0x0b, 0x33, 0x19, 0xdf, 0xed, 0x7e, 0x28, 0xf3, 0xac, 0xec, 0xa4, 0xa6, 0x28, 0x52,
-	0xad, 0x2f, 0xa1, 0x42, 0x1c, 0xdc, 0xd6, 0x82, 0x2f, 0x30, 0x7f, 0x45, 0x1e, 0x27, 0x34, 0x0a,
-	0x86, 0x0b, 0xbc, 0x4a, 0x5d, 0x55, 0xec, 0xa5, 0x5e, 0x20, 0x61, 0x68, 0xed, 0x11, 0x2c, 0x27,
-	0x21, 0x4e, 0xa4, 0x3e, 0x05, 0xce, 0xa7, 0x0b, 0x23, 0xe2, 0xe4, 0x9f, 0xc3, 0xc2, 0xd6, 0x6b,
-	0xdc, 0x6e, 0x1d, 0x59, 0xed, 0x09, 0x34, 0x5a, 0x81, 0x5c, 0xbb, 0x67, 0x88, 0xc2, 0x3a, 0xfd,
-	0x19, 0x0c, 0xfd, 0x73, 0xe1, 0xd0, 0x5f, 0x83, 0xca, 0x60, 0x06, 0x61, 0x95, 0x27, 0xa9, 0x55,
-	0x1a, 0x14, 0x99, 0x32, 0x9f, 0x53, 0xc5, 0x48, 0xc0, 0xb1, 0xcb, 0x2f, 0xba, 0x71, 0x38, 0x76,
-	0xdd, 0xf0, 0x11, 0x91, 0x0b, 0x1f, 0x11, 0xca, 0x9f, 0x65, 0xa0, 0x44, 0x67, 0xf8, 0x56, 0xf2,
-	0x8b, 0xec, 0x3c, 0x37, 0xc8, 0xce, 0xfd, 0x24, 0x3f, 0x1f, 0x4c, 0xf2, 0x07, 0x92, 0x4f, 0x33,
-	0x70, 0x5c, 0xf2, 0x19, 0x1f, 0x8e, 0x5d, 0x57, 0x39, 0x0f, 0x73, 0x5c, 0x36, 0xb1, 0xf2, 0x0a,
-	0xe4, 0xfa, 0x6e, 0x57, 0xee, 0x5f, 0xdf, 0xed, 0x2a, 0x7f, 0x92, 0x81, 0xf2, 0xba, 0xe7, 0xe9,
-	0xed, 0x83, 0x09, 0x16, 0xe0, 0x0b, 0x97, 0x0d, 0x0a, 0x17, 0x5f, 0xc4, 0x40, 0xdc, 0x7c, 0x8a,
-	0xb8, 0xd3, 0x21, 0x71, 0x15, 0x98, 0x97, 0xb2, 0xa4, 0x0a, 0xdc, 0x00, 0xd4, 0xb4, 0x5d, 0xef,
-	0xb1, 0xed, 0x1e, 0xea, 0xae, 0x31, 0x59, 0xda, 0x8d, 0x20, 0x2f, 0xbe, 0x96, 0xc8, 0x5d, 0x9b,
-	0x56, 0xd9, 0x6f, 0xe5, 0x2a, 0x2c, 0x85, 0xf8, 0xa5, 0x4e, 0xfc, 0x11, 0x94, 0xd8, 0x61, 0x2f,
-	0xf2, 0xaf, 0xdb, 0xc1, 0xb6, 0xfe, 0x58, 0xa1, 0x81, 0xf2, 0xff, 0x60, 0x91, 0x06, 0x85, 0x0c,
-	0xee, 0x7b, 0x90, 0x1f, 0x47, 0x92, 0x93, 0x33, 0x29, 0x8c, 0x22, 0x89, 0xc9, 0x6f, 0xb3, 0x30,
-	0xcd, 0xe0, 0xb1, 0x40, 0xed, 0x34, 0x3d, 0xfe, 0x1c, 0x5b, 0xf3, 0xf4, 0x8e, 0xff, 0x6d, 0x0a,
-	0x05, 0xec, 0xe8, 0x1d, 0x56, 0x72, 0x61, 0x0f, 0x0d, 0xb3, 0x83, 0x89, 0x27, 0x3f, 0x50, 0x29,
-	0x51, 0xd8, 0x26, 0x07, 0xb1, 0xa6, 0x9b, 0xf9, 0x07, 0x3c, 0xd9, 0xc8, 0xab, 0xec, 0x37, 0x5a,
-	0xe5, 0x57, 0xa3, 0xc7, 0xe9, 0xc2, 0xb0, 0x8b, 0xd3, 0x35, 0x28, 0x44, 0x1a, 0x2f, 0xfe, 0x18,
-	0x3d, 0x8c, 0x1e, 0xf4, 0x97, 0x52, 0x56, 0x9c, 0x7c, 0xbc, 0x7f, 0x47, 0xe7, 0xd9, 0x16, 0xa0,
-	0xe0, 0xde, 0x08, 0x2b, 0xb8, 0x05, 0x33, 0x6c, 0xeb, 0x64, 0xa0, 0xbe, 0x92, 0x22, 0xaa, 0x2a,
-	0xd0, 0x14, 0x1d, 0x10, 0xdf, 0xf6, 0x50, 0x70, 0x3e, 0xb9, 0xad, 0x0c, 0x09, 0xd6, 0xff, 0x3e,
-	0x03, 0x4b, 0xa1, 0x39, 0x84, 0xac, 0x37, 0xc3, 0x93, 0xa4, 0x8a, 0x2a, 0x26, 0xd8, 0x08, 0x9d,
-	0xaf, 0xb7, 0xd2, 0x44, 0xfa, 0x8e, 0xce, 0xd6, 0xdf, 0x66, 0x00, 0xd6, 0xfb, 0xde, 0x81, 0x28,
-	0x71, 0x07, 0xed, 0x25, 0x13, 0xb1, 0x97, 0x1a, 0x14, 0x1c, 0x9d, 0x90, 0x43, 0xdb, 0x95, 0xe9,
-	0xb5, 0x3f, 0x66, 0xc5, 0xe8, 0xbe, 0x77, 0x20, 0x7b, 0xba, 0xf4, 0x37, 0xba, 0x0c, 0xf3, 0xfc,
-	0x2b, 0x2d, 0x4d, 0x37, 0x0c, 0x17, 0x13, 0x22, 0x9a, 0xbb, 0x65, 0x0e, 0x5d, 0xe7, 0x40, 0x8a,
-	0x66, 0x1a, 0xd8, 0xf2, 0x4c, 0xef, 0x48, 0xf3, 0xec, 0x97, 0xd8, 0x12, 0x69, 0x72, 0x59, 0x42,
-	0x77, 0x28, 0x90, 0x77, 0xb9, 0x3a, 0x26, 0xf1, 0x5c, 0x89, 0x26, 0x1b, 0x89, 0x02, 0xca, 0xd0,
-	0xe8, 0xa6, 0x54, 0x9a, 0xfd, 0x6e, 0x97, 0xab, 0xf8, 0xf8, 0xdb, 0xfe, 0xae, 0x58, 0x50, 0x36,
-	0xed, 0x4d, 0x1b, 0x28, 0x4d, 0x2c, 0xf7, 0x0d, 0xd6, 0x03, 0xdf, 0x85, 0xc5, 0xc0, 0x1a, 0x84,
-	0x59, 0x85, 0xf2, 0x99, 0x4c, 0x38, 0x9f, 0x51, 0x9e, 0x00, 0xe2, 0x25, 0xb0, 0x6f, 0xb9, 0x6e,
-	0xe5, 0x04, 0x2c, 0x85, 0x18, 0x89, 0xf8, 0xe0, 0x06, 0x94, 0xc5, 0xad, 0x5a, 0x61, 0x28, 0xa7,
-	0xa0, 0x40, 0xfd, 0x7c, 0xdb, 0x34, 0x64, 0xc3, 0x7f, 0xd6, 0xb1, 0x8d, 0x0d, 0xd3, 0x70, 0x95,
-	0x17, 0x50, 0x56, 0xf9, 0x3c, 0x02, 0xf7, 0x31, 0xcc, 0x8b, 0x3b, 0xb8, 0x5a, 0xe8, 0x12, 0x7c,
-	0xd2, 0x47, 0x56, 0xc1, 0x49, 0xd4, 0xb2, 0x15, 0x1c, 0x2a, 0x06, 0xd4, 0x78, 0x20, 0x13, 0x62,
-	0x2f, 0x17, 0xfb, 0x18, 0xe4, 0x7d, 0xf8, 0x91, 0xb3, 0x84, 0xe9, 0xcb, 0x6e, 0x70, 0xa8, 0x9c,
-	0x81, 0xd3, 0x89, 0xb3, 0x08, 0x4d, 0x38, 0x50, 0x19, 0x3c, 0x30, 0x4c, 0x79, 0xf3, 0x81, 0xdd,
-	0x68, 0xc8, 0x04, 0x6e, 0x34, 0x9c, 0xf4, 0x23, 0xee, 0xac, 0x3c, 0x5a, 0x59, 0x38, 0x3d, 0xc8,
-	0x3c, 0x73, 0x69, 0x99, 0x67, 0x3e, 0x94, 0x79, 0x2a, 0x2d, 0x5f, 0x9f, 0xa2, 0x22, 0xf0, 0x88,
-	0x55, 0x2e, 0xf8, 0xdc, 0xd2, 0x21, 0x2a, 0xc3, 0x56, 0xc9, 0x51, 0xd5, 0x00, 0x95, 0x72, 0x1d,
-	0xca, 0x61, 0xd7, 0x18, 0xf0, 0x73, 0x99, 0x98, 0x9f, 0x9b, 0x8f, 0xb8, 0xb8, 0xf7, 0x23, 0xe9,
-	0x44, 0xba, 0x8e, 0x23, 0xc9, 0xc4, 0xc3, 0x90, 0xb3, 0xbb, 0x91, 0x70, 0x19, 0xe1, 0x3b, 0xf2,
-	0x73, 0xcb, 0xe2, 0x3c, 0x78, 0x4c, 0x28, 0xbd, 0x58, 0xb4, 0x72, 0x11, 0x4a, 0xbb, 0x69, 0x5f,
-	0xf0, 0xe5, 0xe5, 0x6d, 0xbf, 0x3b, 0xb0, 0xfc, 0xd8, 0xec, 0x62, 0x72, 0x44, 0x3c, 0xdc, 0xab,
-	0x33, 0xa7, 0xb4, 0x6f, 0x62, 0x17, 0x9d, 0x05, 0x60, 0xd9, 0xb4, 0x63, 0x9b, 0xfe, 0x87, 0x5d,
-	0x01, 0x88, 0xf2, 0xbb, 0x0c, 0x2c, 0x0c, 0x08, 0x77, 0x59, 0x15, 0xe1, 0x2d, 0x28, 0xd2, 0xf5,
-	0x12, 0x4f, 0xef, 0x39, 0xb2, 0x31, 0xeb, 0x03, 0xd0, 0x7d, 0x98, 0xde, 0x27, 0xb2, 0x7a, 0x99,
-	0xd8, 0x09, 0x4a, 0x12, 0x44, 0xcd, 0xef, 0x93, 0xba, 0x81, 0x1e, 0x00, 0xf4, 0x09, 0x36, 0x44,
-	0x33, 0x36, 0x97, 0x16, 0xc3, 0xec, 0x06, 0x2f, 0x6a, 0x50, 0x02, 0x7e, 0x51, 0xf0, 0x21, 0x94,
-	0x4c, 0xcb, 0x36, 0x30, 0x6b, 0x9e, 0x1b, 0xa2, 0xc0, 0x39, 0x82, 0x1c, 0x38, 0xc5, 0x2e, 0xc1,
-	0x86, 0x82, 0xc5, 0x59, 0x28, 0xf5, 0x2b, 0x0c, 0xa5, 0x01, 0x8b, 0xdc, 0x69, 0xed, 0xfb, 0x82,
-	0x4b, 0x8b, 0xbd, 0x30, 0x6c, 0x75, 0x4c, 0x5b, 0x6a, 0xc5, 0x14, 0x01, 0x97, 0x24, 0x55, 0xee,
-	0xc1, 0x89, 0x50, 0xba, 0x39, 0x41, 0xfe, 0xa7, 0x34, 0x23, 0x35, 0xbb, 0x81, 0x39, 0x8b, 0x8a,
-	0x98, 0xb4, 0xe6, 0x51, 0x15, 0x31, 0xc2, 0x2b, 0x62, 0x44, 0xf9, 0x0c, 0x4e, 0x85, 0x8a, 0x8b,
-	0x21, 0x89, 0x1e, 0x46, 0xe2, 0xc9, 0x2b, 0xa3, 0xb8, 0x46, 0x02, 0xcb, 0xff, 0xce, 0xc0, 0x72,
-	0x12, 0xc2, 0x31, 0x8b, 0xdf, 0x3f, 0x4f, 0xb9, 0x14, 0x7e, 0x77, 0x3c, 0xb1, 0x7e, 0x2f, 0x8d,
-	0x83, 0x1d, 0xa8, 0x25, 0xe9, 0x33, 0xbe, 0x4b, 0xb9, 0x49, 0x76, 0xe9, 0x17, 0xb9, 0x40, 0x13,
-	0x68, 0xdd, 0xf3, 0x5c, 0x73, 0xaf, 0x4f, 0x4d, 0xfe, 0x8d, 0x17, 0x56, 0xeb, 0x7e, 0x89, 0x90,
-	0xab, 0xf6, 0xf6, 0x10, 0xf2, 0x81, 0x1c, 0x89, 0x65, 0xc2, 0x4f, 0xc2, 0x65, 0x42, 0xde, 0xde,
-	0xb9, 0x33, 0x1e, 0xbf, 0x1f, 0x6c, 0x2d, 0xfe, 0x17, 0x59, 0x98, 0x0f, 0x6f, 0x11, 0xda, 0x02,
-	0xd0, 0x7d, 0xc9, 0xc5, 0x8b, 0x72, 0x79, 0xac, 0x65, 0xaa, 0x01, 0x42, 0xf4, 0x0e, 0xe4, 0xda,
-	0x4e, 0x5f, 0xec, 0x5a, 0x42, 0x11, 0x70, 0xc3, 0xe9, 0x73, 0x8f, 0x42, 0xd1, 0x68, 0xa6, 0x27,
-	0xee, 0x9f, 0xa6, 0x7a, 0x49, 0x7e, 0x17, 0x95, 0xd3, 0x08, 0x64, 0xf4, 0x14, 0xe6, 0x0f, 0x5d,
-	0xd3, 0xd3, 0xf7, 0xba, 0x58, 0xeb, 0xea, 0x47, 0xd8, 0x15, 0x5e, 0x72, 0x0c, 0x47, 0x56, 0x96,
-	0x84, 0xcf, 0x28, 0x9d, 0xf2, 0x47, 0x50, 0x90, 0x12, 0x8d, 0x38, 0x11, 0x76, 0x60, 0xa5, 0x4f,
-	0xd1, 0x34, 0x76, 0x81, 0xdb, 0xd2, 0x2d, 0x5b, 0x23, 0x98, 0x1e, 0xe3, 0xf2, 0xd3, 0xb2, 0x11,
-	0x2e, 0x7a, 0x99, 0x51, 0x6f, 0xd8, 0x2e, 0x6e, 0xe8, 0x96, 0xdd, 0xe2, 0xa4, 0xca, 0x2b, 0x28,
-	0x05, 0x16, 0x38, 0x42, 0x84, 0x3a, 0x2c, 0xca, 0x3b, 0x25, 0x04, 0x7b, 0xe2, 0x78, 0x19, 0x6b,
-	0xf2, 0x05, 0x41, 0xd7, 0xc2, 0x1e, 0xbf, 0x07, 0xf4, 0x10, 0x4e, 0xa9, 0xd8, 0x76, 0xb0, 0xe5,
-	0xef, 0xe7, 0x33, 0xbb, 0x33, 0x81, 0x07, 0x7f, 0x0b, 0x6a, 0x49, 0xf4, 0x22, 0x32, 0xbb, 0x07,
-	0x27, 0x9a, 0x7a, 0x9f, 0xe0, 0x63, 0xf6, 0xc1, 0xa3, 0xb4, 0x82, 0xeb, 0x03, 0x58, 0xd9, 0xb5,
-	0x9c, 0xe3, 0xf2, 0xad, 0x41, 0x35, 0x4e, 0x2d, 0x38, 0xdf, 0x91, 0xa1, 0xb6, 0x48, 0x82, 0x05,
-	0xd7, 0x73, 0x50, 0xe2, 0x19, 0xb6, 0x16, 0xc8, 0xc2, 0x80, 0x83, 0x1a, 0x7a, 0x0f, 0x2b, 0x27,
-	0x61, 0x39, 0x4c, 0x27, 0xf8, 0x3d, 0x14, 0xbd, 0xfc, 0xe3, 0x7e, 0x65, 0x79, 0x0a, 0x56, 0x62,
-	0xf4, 0x9c, 0xf5, 0x8d, 0x2b, 0x50, 0x90, 0xff, 0xfd, 0x02, 0xcd, 0x42, 0x6e, 0x67, 0xa3, 0x59,
-	0x99, 0xa2, 0x3f, 0x76, 0x37, 0x9b, 0x95, 0x0c, 0x2a, 0x40, 0xbe, 0xb5, 0xb1, 0xd3, 0xac, 0x64,
-	0x6f, 0xf4, 0xa0, 0x12, 0xfd, 0xd7, 0x0f, 0x68, 0x05, 0x96, 0x9a, 0xea, 0x76, 0x73, 0xfd, 0xc9,
-	0xfa, 0x4e, 0x7d, 0xbb, 0xa1, 0x35, 0xd5, 0xfa, 0xc7, 0xeb, 0x3b, 0x5b, 0x95, 0x29, 0x74, 0x01,
-	0xce, 0x04, 0x1f, 0x3c, 0xdd, 0x6e, 0xed, 0x68, 0x3b, 0xdb, 0xda, 0xc6, 0x76, 0x63, 0x67, 0xbd,
-	0xde, 0xd8, 0x52, 0x2b, 0x19, 0x74, 0x06, 0x4e, 0x05, 0x51, 0x1e, 0xd5, 0x37, 0xeb, 0xea, 0xd6,
-	0x06, 0xfd, 0xbd, 0xfe, 0xac, 0x92, 0xbd, 0x71, 0x1b, 0xca, 0xa1, 0xff, 0xca, 0x40, 0x45, 0x6a,
-	0x6e, 0x6f, 0x56, 0xa6, 0x50, 0x19, 0x8a, 0x41, 0x3e, 0x05, 0xc8, 0x37, 0xb6, 0x37, 0xb7, 0x2a,
-	0xd9, 0x1b, 0x4d, 0x58, 0x88, 0x7c, 0xa6, 0x83, 0x16, 0xa1, 0xdc, 0x5a, 0x6f, 0x6c, 0x3e, 0xda,
-	0xfe, 0x44, 0x53, 0xb7, 0xd6, 0x37, 0x3f, 0xad, 0x4c, 0xa1, 0x65, 0xa8, 0x48, 0x50, 0x63, 0x7b,
-	0x87, 0x43, 0x33, 0x11, 0xe8, 0xe3, 0xed, 0xdd, 0xc6, 0x66, 0xc5, 0xb8, 0xf1, 0x55, 0x26, 0xe2,
-	0xd5, 0x30, 0x3a, 0x01, 0x8b, 0xfe, 0xec, 0xda, 0x86, 0xba, 0xb5, 0xbe, 0xb3, 0x45, 0x85, 0x0a,
-	0x81, 0xd5, 0xdd, 0x46, 0xa3, 0xde, 0x78, 0xc2, 0xd9, 0x0e, 0xc0, 0x5b, 0x9f, 0xd4, 0x29, 0x72,
-	0x36, 0x8c, 0xbc, 0xdb, 0xf8, 0x69, 0x63, 0xfb, 0x45, 0xa3, 0x92, 0x43, 0x4b, 0xb0, 0x30, 0x00,
-	0x37, 0xd7, 0x77, 0x5b, 0x5b, 0x95, 0xfc, 0xda, 0xff, 0x2c, 0xc1, 0xbc, 0x8c, 0xb7, 0xb1, 0xcb,
-	0x6e, 0xc9, 0x35, 0x61, 0x56, 0xfe, 0xe7, 0x95, 0x84, 0x83, 0x32, 0xfc, 0xff, 0x62, 0x6a, 0x17,
-	0x86, 0x60, 0x08, 0xe3, 0x9a, 0x42, 0x7b, 0x2c, 0x0d, 0x09, 0x7c, 0x62, 0x75, 0x25, 0x31, 0xe8,
-	0x8f, 0x59, 0x5f, 0xed, 0xea, 0x48, 0x3c, 0x7f, 0x0e, 0x4c, 0x33, 0x8d, 0xe0, 0x37, 0xc4, 0xe8,
-	0x6a, 0x52, 0x8a, 0x90, 0xf0, 0x91, 0x72, 0xed, 0xda, 0x68, 0x44, 0x7f, 0x9a, 0x97, 0x50, 0x89,
-	0x7e, 0x4f, 0x8c, 0x12, 0xda, 0x01, 0x29, 0x1f, 0x2d, 0xd7, 0x6e, 0x8c, 0x83, 0x1a, 0x9c, 0x2c,
-	0xf6, 0xe5, 0xed, 0xf5, 0x71, 0x3e, 0x65, 0x4c, 0x9d, 0x2c, 0xed, 0xab, 0x47, 0xae, 0xc0, 0xf0,
-	0xe7, 0x53, 0x28, 0xf1, 0x33, 0xd7, 0x84, 0x8f, 0xef, 0x92, 0x14, 0x98, 0xfc, 0x25, 0x96, 0x32,
-	0x85, 0x0e, 0x60, 0x21, 0x72, 0x61, 0x09, 0x25, 0x90, 0x27, 0xdf, 0xcc, 0xaa, 0x5d, 0x1f, 0x03,
-	0x33, 0x6c, 0x11, 0xc1, 0x0b, 0x4a, 0xc9, 0x16, 0x91, 0x70, 0xfd, 0x29, 0xd9, 0x22, 0x12, 0xef,
-	0x3a, 0x31, 0xe3, 0x0e, 0x5d, 0x4c, 0x4a, 0x32, 0xee, 0xa4, 0xeb, 0x50, 0xb5, 0xab, 0x23, 0xf1,
-	0x82, 0x4a, 0x8b, 0x5c, 0x53, 0x4a, 0x52, 0x5a, 0xf2, 0x35, 0xa8, 0xda, 0xf5, 0x31, 0x30, 0xa3,
-	0x56, 0x30, 0xb8, 0xf4, 0x90, 0x66, 0x05, 0xb1, 0x2b, 0x3a, 0x69, 0x56, 0x10, 0xbf, 0x3f, 0x21,
-	0xac, 0x20, 0x72, 0x59, 0xe1, 0xda, 0x18, 0xed, 0xc1, 0x74, 0x2b, 0x48, 0x6e, 0x24, 0x2a, 0x53,
-	0xe8, 0x8f, 0x33, 0x50, 0x4d, 0xeb, 0x61, 0xa1, 0xdb, 0x13, 0x37, 0xdf, 0x6a, 0x6b, 0x93, 0x90,
-	0xf8, 0x52, 0x7c, 0x09, 0x28, 0x1e, 0x7e, 0xa0, 0xb7, 0x93, 0x76, 0x26, 0x25, 0xc8, 0xa9, 0xbd,
-	0x33, 0x1e, 0x72, 0x70, 0x27, 0xc3, 0x71, 0x49, 0xd2, 0x4e, 0x26, 0x46, 0x3d, 0x49, 0x3b, 0x99,
-	0x12, 0xe2, 0x30, 0x1f, 0x15, 0x0d, 0x53, 0x92, 0x7c, 0x54, 0x4a, 0x20, 0x94, 0xe4, 0xa3, 0x52,
-	0xa3, 0x9e, 0x29, 0xd4, 0x82, 0x82, 0xec, 0x04, 0xa2, 0x84, 0x93, 0x27, 0xd2, 0x87, 0xac, 0x29,
-	0xc3, 0x50, 0x7c, 0xa6, 0x4f, 0x20, 0x4f, 0xa1, 0xe8, 0x4c, 0x32, 0xb6, 0x64, 0x76, 0x36, 0xed,
-	0xb1, 0xcf, 0xe8, 0x39, 0xcc, 0xf0, 0xd6, 0x17, 0x4a, 0x28, 0x6a, 0x85, 0x1a, 0x74, 0xb5, 0xf3,
-	0xe9, 0x08, 0x3e, 0xbb, 0xcf, 0xf9, 0x3f, 0x1a, 0x13, 0x5d, 0x2d, 0x74, 0x29, 0xf9, 0xbf, 0xb4,
-	0x84, 0x9b, 0x68, 0xb5, 0xcb, 0x23, 0xb0, 0x82, 0xe6, 0x11, 0x49, 0xa8, 0xae, 0x8e, 0xcc, 0x8a,
-	0xd3, 0xcd, 0x23, 0x39, 0xef, 0xe6, 0x86, 0x1f, 0xcf, 0xcb, 0x93, 0x0c, 0x3f, 0xb5, 0x1a, 0x92,
-	0x64, 0xf8, 0xe9, 0xa9, 0xbe, 0x32, 0x85, 0x3c, 0x58, 0x4a, 0xa8, 0xc2, 0xa2, 0x77, 0xd2, 0x5e,
-	0xdc, 0xa4, 0x92, 0x70, 0xed, 0xe6, 0x98, 0xd8, 0xc1, 0xcd, 0x17, 0x8e, 0xec, 0x5c, 0x7a, 0x69,
-	0x32, 0x75, 0xf3, 0x63, 0x6e, 0xeb, 0x00, 0x16, 0x22, 0x11, 0x35, 0x4a, 0x3b, 0x94, 0xe2, 0xe7,
-	0xf1, 0xf5, 0x31, 0x30, 0xe5, 0x4c, 0x6b, 0xff, 0x96, 0x83, 0x39, 0x5e, 0xcb, 0x17, 0xf1, 0xdf,
-	0xa7, 0x00, 0x83, 0x36, 0x1a, 0xba, 0x98, 0xac, 0xfd, 0x50, 0x03, 0xb4, 0x76, 0x69, 0x38, 0x52,
-	0xd0, 0xa4, 0x03, 0x2d, 0x29, 0x74, 0x69, 0x44, 0xc7, 0x2a, 0xd5, 0xa4, 0x13, 0xfa, 0x5a, 0xca,
-	0x14, 0xfa, 0x18, 0x8a, 0x7e, 0xef, 0x03, 0x25, 0xf5, 0x4e, 0x22, 0xcd, 0x9d, 0xda, 0xc5, 0xa1,
-	0x38, 0x41, 0xa9, 0x03, 0x8d, 0x8d, 0x24, 0xa9, 0xe3, 0x0d, 0x94, 0x24, 0xa9, 0x93, 0xba, 0x23,
-	0x03, 0x9d, 0xf0, 0xf2, 0x67, 0xaa, 0x4e, 0x42, 0xd5, 0xe7, 0x54, 0x9d, 0x84, 0x6b, 0xa8, 0xca,
-	0xd4, 0x9a, 0x03, 0x65, 0x9e, 0xeb, 0xc9, 0xdd, 0xd5, 0x60, 0x2e, 0x98, 0x02, 0xa2, 0x54, 0x39,
-	0x43, 0xa9, 0x65, 0xed, 0xca, 0x28, 0x34, 0x39, 0xe3, 0xa3, 0x2b, 0xbf, 0xf9, 0xfa, 0x6c, 0xe6,
-	0x9f, 0xbf, 0x3e, 0x3b, 0xf5, 0xd5, 0x37, 0x67, 0x33, 0xbf, 0xf9, 0xe6, 0x6c, 0xe6, 0x1f, 0xbf,
-	0x39, 0x9b, 0xf9, 0xf7, 0x6f, 0xce, 0x66, 0xfe, 0xf4, 0x3f, 0xce, 0x4e, 0xfd, 0xac, 0x20, 0xc9,
-	0xf7, 0x66, 0xd8, 0xbf, 0x42, 0x7c, 0xef, 0x7f, 0x03, 0x00, 0x00, 0xff, 0xff, 0xb4, 0x3f, 0x1a,
-	0x13, 0xd0, 0x52, 0x00, 0x00,
+	// 5399 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x7c, 0xcd, 0x73, 0x1b, 0x47,
+	0x76, 0xb8, 0xf0, 0x41, 0x12, 0x78, 0x20, 0x40, 0xb0, 0x49, 0x91, 0x10, 0x64, 0x49, 0xd4, 0x48,
+	0xd6, 0x97, 0x2d, 0xca, 0xa2, 0x77, 0x65, 0x4b, 0xb6, 0x65, 0x43, 0x24, 0x25, 0xe1, 0xb7, 0x12,
+	0x88, 0xdf, 0x80, 0x94, 0xec, 0x5d, 0x57, 0xcd, 0x0e, 0x31, 0x4d, 0x70, 0x2c, 0x60, 0x66, 0x3c,
+	0x3d, 0x10, 0xc5, 0xa4, 0x2a, 0xe5, 0xaa, 0x54, 0xe5, 0x90, 0x53, 0xce, 0xb9, 0x65, 0xf7, 0x90,
+	0x43, 0x2e, 0xb9, 0xe4, 0x94, 0x54, 0xa5, 0x92, 0xda, 0xc3, 0x5e, 0xb6, 0x92, 0x53, 0x2a, 0x1f,
+	0x97, 0xd8, 0xc9, 0x29, 0x87, 0x54, 0xfe, 0x83, 0x4d, 0xf5, 0xc7, 0x0c, 0xe6, 0x13, 0x18, 0xd0,
+	0xf2, 0xda, 0x39, 0x61, 0xfa, 0xcd, 0x7b, 0xaf, 0x5f, 0xbf, 0x7e, 0xfd, 0xfa, 0xf5, 0x7b, 0x3d,
+	0x80, 0xa2, 0x6a, 0xe9, 0xeb, 0x96, 0x6d, 0x3a, 0x26, 0xaa, 0xda, 0x43, 0xc3, 0xd1, 0x07, 0x78,
+	0xfd, 0xe5, 0x6d, 0xb5, 0x6f, 0x1d, 0xaa, 0x1b, 0xf5, 0x9b, 0x3d, 0xdd, 0x39, 0x1c, 0xee, 0xaf,
+	0x77, 0xcd, 0xc1, 0xad, 0x9e, 0xd9, 0x33, 0x6f, 0x31, 0xc4, 0xfd, 0xe1, 0x01, 0x6b, 0xb1, 0x06,
+	0x7b, 0xe2, 0x0c, 0xa4, 0x1b, 0x50, 0x79, 0x86, 0x6d, 0xa2, 0x9b, 0x86, 0x8c, 0xbf, 0x1c, 0x62,
+	0xe2, 0xa0, 0x1a, 0xcc, 0xbd, 0xe4, 0x90, 0x5a, 0x66, 0x2d, 0x73, 0xad, 0x28, 0xbb, 0x4d, 0xe9,
+	0xcf, 0x33, 0xb0, 0xe0, 0x21, 0x13, 0xcb, 0x34, 0x08, 0x4e, 0xc6, 0x46, 0x17, 0x61, 0x5e, 0x08,
+	0xa7, 0x18, 0xea, 0x00, 0xd7, 0xb2, 0xec, 0x75, 0x49, 0xc0, 0x5a, 0xea, 0x00, 0xa3, 0xab, 0xb0,
+	0xe0, 0xa2, 0xb8, 0x4c, 0x72, 0x0c, 0xab, 0x22, 0xc0, 0xa2, 0x37, 0xb4, 0x0e, 0x4b, 0x2e, 0xa2,
+	0x6a, 0xe9, 0x1e, 0x72, 0x9e, 0x21, 0x2f, 0x8a, 0x57, 0x0d, 0x4b, 0x17, 0xf8, 0xd2, 0xcf, 0xa0,
+	0xb8, 0xd5, 0xea, 0x6c, 0x9a, 0xc6, 0x81, 0xde, 0xa3, 0x22, 0x12, 0x6c, 0x53, 0x9a, 0x5a, 0x66,
+	0x2d, 0x47, 0x45, 0x14, 0x4d, 0x54, 0x87, 0x02, 0xc1, 0xaa, 0xdd, 0x3d, 0xc4, 0xa4, 0x96, 0x65,
+	0xaf, 0xbc, 0x36, 0xa5, 0x32, 0x2d, 0x47, 0x37, 0x0d, 0x52, 0xcb, 0x71, 0x2a, 0xd1, 0x94, 0x7e,
+	0x91, 0x81, 0x52, 0xdb, 0xb4, 0x9d, 0xa7, 0xaa, 0x65, 0xe9, 0x46, 0x0f, 0xdd, 0x81, 0x02, 0xd3,
+	0x65, 0xd7, 0xec, 0x33, 0x1d, 0x54, 0x36, 0xea, 0xeb, 0xe1, 0x69, 0x59, 0x6f, 0x0b, 0x0c, 0xd9,
+	0xc3, 0x45, 0x6f, 0x42, 0xa5, 0x6b, 0x1a, 0x8e, 0xaa, 0x1b, 0xd8, 0x56, 0x2c, 0xd3, 0x76, 0x98,
+	0x8a, 0x66, 0xe4, 0xb2, 0x07, 0xa5, 0xbd, 0xa0, 0xb3, 0x50, 0x3c, 0x34, 0x89, 0xc3, 0x31, 0x72,
+	0x0c, 0xa3, 0x40, 0x01, 0xec, 0xe5, 0x2a, 0xcc, 0xb1, 0x97, 0xba, 0x25, 0x94, 0x31, 0x4b, 0x9b,
+	0x4d, 0x4b, 0xfa, 0xaf, 0x0c, 0xcc, 0x3c, 0x35, 0x87, 0x86, 0x13, 0xea, 0x46, 0x75, 0x0e, 0xc5,
+	0x44, 0xf9, 0xba, 0x51, 0x9d, 0xc3, 0x51, 0x37, 0x14, 0x83, 0xcf, 0x15, 0xef, 0x86, 0xbe, 0xac,
+	0x43, 0xc1, 0xc6, 0xaa, 0x66, 0x1a, 0xfd, 0x63, 0x26, 0x42, 0x41, 0xf6, 0xda, 0x74, 0x12, 0x09,
+	0xee, 0xeb, 0xc6, 0xf0, 0x95, 0x62, 0xe3, 0xbe, 0xba, 0x8f, 0xfb, 0x4c, 0x94, 0x82, 0x5c, 0x11,
+	0x60, 0x99, 0x43, 0xd1, 0x16, 0x94, 0x2c, 0xdb, 0xb4, 0xd4, 0x9e, 0x4a, 0xf5, 0x58, 0x9b, 0x61,
+	0xaa, 0x92, 0xa2, 0xaa, 0x62, 0x62, 0xb7, 0x47, 0x98, 0xb2, 0x9f, 0x0c, 0x21, 0xc8, 0x33, 0x73,
+	0xd2, 0x98, 0x88, 0xec, 0x59, 0xfa, 0x87, 0x0c, 0x2c, 0x50, 0x83, 0x22, 0x96, 0xda, 0xc5, 0x3b,
+	0x6c, 0x9a, 0xd0, 0x5d, 0x98, 0x33, 0xb0, 0x73, 0x64, 0xda, 0x2f, 0xc4, 0xa4, 0x5c, 0x88, 0xf6,
+	0xe4, 0xd1, 0x3c, 0x35, 0x35, 0x2c, 0xbb, 0xf8, 0xe8, 0x36, 0xe4, 0x2c, 0x5d, 0xab, 0x65, 0xd3,
+	0x91, 0x51, 0x5c, 0x4a, 0xa2, 0x5b, 0xdd, 0x5a, 0x2e, 0x25, 0x89, 0x6e, 0x75, 0xa9, 0xc2, 0x1d,
+	0xd5, 0xee, 0x61, 0x47, 0xd1, 0x35, 0x31, 0x79, 0x05, 0x0e, 0x68, 0x6a, 0x92, 0x04, 0xd0, 0x34,
+	0x9c, 0x3b, 0x3f, 0x7a, 0xa6, 0xf6, 0x87, 0x18, 0x2d, 0xc3, 0xcc, 0x4b, 0xfa, 0xc0, 0x46, 0x92,
+	0x93, 0x79, 0x43, 0xfa, 0x3a, 0x07, 0x67, 0x9f, 0x50, 0x05, 0x77, 0x54, 0x43, 0xdb, 0x37, 0x5f,
+	0x75, 0x70, 0x77, 0x68, 0xeb, 0xce, 0xf1, 0xa6, 0x69, 0x38, 0xf8, 0x95, 0x83, 0x5a, 0xb0, 0x68,
+	0xb8, 0xdd, 0x2a, 0xae, 0x2d, 0x53, 0x0e, 0xa5, 0x8d, 0x8b, 0x63, 0x24, 0xe4, 0xfa, 0x93, 0xab,
+	0x46, 0x10, 0x40, 0xd0, 0xe3, 0xd1, 0x44, 0xbb, 0xdc, 0xb2, 0x8c, 0x5b, 0xcc, 0x78, 0x3b, 0xdb,
+	0x4c, 0x32, 0xc1, 0xcb, 0xb5, 0x04, 0x97, 0xd3, 0x87, 0x40, 0xdd, 0x80, 0xa2, 0x12, 0x65, 0x48,
+	0xb0, 0xcd, 0xb4, 0x56, 0xda, 0x78, 0x23, 0xca, 0x65, 0xa4, 0x02, 0xb9, 0x68, 0x0f, 0x8d, 0x06,
+	0xd9, 0x23, 0xd8, 0x66, 0x5e, 0x43, 0x18, 0x9f, 0x62, 0x9b, 0xa6, 0x73, 0x40, 0x5c, 0x83, 0x73,
+	0xc1, 0x32, 0x83, 0xa2, 0x5b, 0xb0, 0x44, 0x86, 0x96, 0xd5, 0xc7, 0x03, 0x6c, 0x38, 0x6a, 0x5f,
+	0xe9, 0xd9, 0xe6, 0xd0, 0x22, 0xb5, 0x99, 0xb5, 0xdc, 0xb5, 0x9c, 0x8c, 0xfc, 0xaf, 0x1e, 0xb1,
+	0x37, 0xe8, 0x3c, 0x80, 0x65, 0xeb, 0x2f, 0xf5, 0x3e, 0xee, 0x61, 0xad, 0x36, 0xcb, 0x98, 0xfa,
+	0x20, 0xe8, 0x1d, 0x58, 0x26, 0xb8, 0xdb, 0x35, 0x07, 0x96, 0x62, 0xd9, 0xe6, 0x81, 0xde, 0xc7,
+	0x7c, 0xb9, 0xcc, 0xb1, 0xd9, 0x43, 0xe2, 0x5d, 0x9b, 0xbf, 0x62, 0x0b, 0xe7, 0x3e, 0xcc, 0x8b,
+	0x91, 0xb2, 0xce, 0x6b, 0x85, 0x14, 0x43, 0x05, 0x36, 0x54, 0x26, 0x92, 0xf4, 0x8b, 0x2c, 0x9c,
+	0x66, 0x9a, 0x6c, 0x9b, 0x9a, 0x98, 0x66, 0xe1, 0xd5, 0x2e, 0x41, 0xb9, 0xcb, 0x78, 0x2a, 0x96,
+	0x6a, 0x63, 0xc3, 0x11, 0xab, 0x7a, 0x9e, 0x03, 0xdb, 0x0c, 0x86, 0x3e, 0x85, 0x2a, 0x11, 0x56,
+	0xa1, 0x74, 0xb9, 0x59, 0x88, 0x39, 0xbb, 0x19, 0x15, 0x61, 0x8c, 0x2d, 0xc9, 0x0b, 0x24, 0x62,
+	0x5c, 0x73, 0xe4, 0x98, 0x74, 0x9d, 0x3e, 0x77, 0x8f, 0xa5, 0x8d, 0x1f, 0x25, 0x30, 0x0c, 0x0b,
+	0xbe, 0xde, 0xe1, 0x64, 0xdb, 0x86, 0x63, 0x1f, 0xcb, 0x2e, 0x93, 0xfa, 0x3d, 0x98, 0xf7, 0xbf,
+	0x40, 0x55, 0xc8, 0xbd, 0xc0, 0xc7, 0x62, 0x50, 0xf4, 0x71, 0xb4, 0x08, 0xb8, 0x73, 0xe2, 0x8d,
+	0x7b, 0xd9, 0xf7, 0x33, 0x92, 0x0d, 0x68, 0xd4, 0xcb, 0x53, 0xec, 0xa8, 0x9a, 0xea, 0xa8, 0x9e,
+	0xa3, 0xc8, 0x8c, 0x1c, 0x05, 0xe5, 0x3a, 0x14, 0x2b, 0xbb, 0x28, 0xd3, 0x47, 0xf4, 0x06, 0x14,
+	0x3d, 0x43, 0x17, 0x9b, 0xcf, 0x08, 0x40, 0x37, 0x01, 0xd5, 0x71, 0xf0, 0xc0, 0x72, 0x98, 0x89,
+	0x95, 0x65, 0xb7, 0x29, 0xfd, 0x77, 0x1e, 0xaa, 0x91, 0x39, 0xf9, 0x04, 0x0a, 0x03, 0xd1, 0xbd,
+	0x58, 0x68, 0x97, 0x63, 0x76, 0x82, 0x88, 0xa8, 0xb2, 0x47, 0x45, 0x1d, 0x2d, 0x75, 0xba, 0xbe,
+	0x0d, 0xd3, 0x6b, 0xd3, 0x19, 0xef, 0x9b, 0x3d, 0x45, 0xd3, 0x6d, 0xdc, 0x75, 0x4c, 0xfb, 0x58,
+	0x88, 0x3b, 0xdf, 0x37, 0x7b, 0x5b, 0x2e, 0x0c, 0xdd, 0x03, 0xd0, 0x0c, 0x42, 0x27, 0xfb, 0x40,
+	0xef, 0x31, 0xa1, 0x4b, 0x1b, 0x67, 0xa3, 0x42, 0x78, 0xbb, 0xa3, 0x5c, 0xd4, 0x0c, 0x22, 0xc4,
+	0x7f, 0x00, 0x65, 0xba, 0xc9, 0x28, 0x03, 0xbe, 0xb1, 0xf1, 0x95, 0x52, 0xda, 0x38, 0x17, 0x37,
+	0x06, 0x6f, 0xfb, 0x93, 0xe7, 0xad, 0x51, 0x83, 0xa0, 0x87, 0x30, 0xcb, 0xbc, 0x3d, 0xa9, 0xcd,
+	0x32, 0xe2, 0xf5, 0x71, 0x0a, 0x10, 0x16, 0xf1, 0x84, 0x11, 0x70, 0x83, 0x10, 0xd4, 0x68, 0x0f,
+	0x4a, 0xaa, 0x61, 0x98, 0x8e, 0xca, 0x1d, 0xcd, 0x1c, 0x63, 0xf6, 0x6e, 0x0a, 0x66, 0x8d, 0x11,
+	0x15, 0xe7, 0xe8, 0xe7, 0x83, 0x3e, 0x82, 0x19, 0xe6, 0x89, 0xc4, 0x42, 0xbc, 0x9a, 0xd2, 0x68,
+	0x65, 0x4e, 0x55, 0xbf, 0x0b, 0x25, 0x9f, 0xb0, 0xd3, 0x18, 0x69, 0xfd, 0x3e, 0x54, 0xc3, 0xa2,
+	0x4d, 0x65, 0xe4, 0xbf, 0x0f, 0xcb, 0xf2, 0xd0, 0x18, 0x09, 0xe6, 0x86, 0x6b, 0xf7, 0x60, 0x56,
+	0x4c, 0x36, 0xb7, 0x38, 0x69, 0xb2, 0x8e, 0x64, 0x41, 0xe1, 0x8f, 0xbf, 0x0e, 0x55, 0x43, 0xeb,
+	0x63, 0xbb, 0x96, 0x0d, 0xc4, 0x5f, 0x8f, 0x39, 0x54, 0xfa, 0x08, 0x4e, 0x87, 0x3a, 0x17, 0xe1,
+	0xdf, 0x65, 0xa8, 0x58, 0xa6, 0xa6, 0x10, 0x0e, 0xa6, 0x3b, 0x99, 0x70, 0x43, 0x96, 0x87, 0xdb,
+	0xd4, 0x28, 0x79, 0xc7, 0x31, 0xad, 0xa8, 0xf0, 0xe9, 0xc8, 0x6b, 0xb0, 0x12, 0x26, 0xe7, 0xdd,
+	0x4b, 0x1f, 0xc3, 0xaa, 0x8c, 0x07, 0xe6, 0x4b, 0x7c, 0x52, 0xd6, 0x75, 0xa8, 0x45, 0x19, 0x08,
+	0xe6, 0x9f, 0xc1, 0xea, 0x08, 0xda, 0x71, 0x54, 0x67, 0x48, 0xa6, 0x62, 0x2e, 0x62, 0xe3, 0x7d,
+	0x93, 0xf0, 0xe9, 0x2c, 0xc8, 0x6e, 0x53, 0xba, 0xee, 0x67, 0xdd, 0xe2, 0x61, 0x07, 0xef, 0x01,
+	0x55, 0x20, 0xab, 0x5b, 0x82, 0x5d, 0x56, 0xb7, 0xa4, 0xc7, 0x50, 0xf4, 0xb6, 0x66, 0xf4, 0xc1,
+	0x28, 0x28, 0xcd, 0xa6, 0xdd, 0xc8, 0xbd, 0xb8, 0x75, 0x37, 0xb2, 0x95, 0x88, 0x2e, 0x3f, 0x00,
+	0xf0, 0x5c, 0x9e, 0x1b, 0x21, 0x9c, 0x1d, 0xc3, 0x58, 0xf6, 0xa1, 0x4b, 0xff, 0x1a, 0x70, 0x84,
+	0xbe, 0x41, 0x68, 0xde, 0x20, 0xb4, 0x80, 0x63, 0xcc, 0x9e, 0xc8, 0x31, 0xbe, 0x07, 0x33, 0xc4,
+	0x51, 0x1d, 0x2c, 0x42, 0xac, 0x8b, 0xe3, 0xc8, 0xa9, 0x10, 0x58, 0xe6, 0xf8, 0xe8, 0x1c, 0x40,
+	0xd7, 0xc6, 0xaa, 0x83, 0x35, 0x45, 0xe5, 0x5e, 0x3c, 0x27, 0x17, 0x05, 0xa4, 0xe1, 0xa0, 0xcd,
+	0x51, 0x98, 0x38, 0xc3, 0x04, 0xbb, 0x3e, 0x8e, 0x73, 0x60, 0xaa, 0x46, 0x01, 0xa3, 0xe7, 0x55,
+	0x66, 0x53, 0x7a, 0x15, 0xc1, 0x80, 0x53, 0xf9, 0x7c, 0xe6, 0xdc, 0x64, 0x9f, 0xc9, 0x49, 0xd3,
+	0xf8, 0xcc, 0xc2, 0x64, 0x9f, 0x29, 0x98, 0x8d, 0xf5, 0x99, 0xdf, 0xa7, 0xd3, 0xfb, 0x97, 0x0c,
+	0xd4, 0xa2, 0x6b, 0x50, 0xf8, 0x9e, 0x7b, 0x30, 0x4b, 0x18, 0x24, 0x8d, 0xe7, 0x13, 0xb4, 0x82,
+	0x02, 0x3d, 0x86, 0xbc, 0x6e, 0x1c, 0x98, 0xb5, 0x6c, 0x52, 0xec, 0x92, 0xd4, 0xeb, 0x7a, 0xd3,
+	0x38, 0x30, 0xb9, 0x92, 0x18, 0x87, 0xfa, 0x7b, 0x50, 0xf4, 0x40, 0x53, 0x8d, 0x6d, 0x07, 0x96,
+	0x43, 0x26, 0xcb, 0x83, 0x7d, 0xcf, 0xd2, 0x33, 0xd3, 0x59, 0xba, 0xf4, 0x55, 0xd6, 0xbf, 0x12,
+	0x1f, 0xea, 0x7d, 0x07, 0xdb, 0x91, 0x95, 0xf8, 0xa1, 0xcb, 0x9d, 0x2f, 0xc3, 0x2b, 0x13, 0xb9,
+	0xf3, 0x98, 0x54, 0x2c, 0xa6, 0xcf, 0xa1, 0xc2, 0x6c, 0x4d, 0x21, 0xb8, 0xcf, 0x02, 0x0e, 0x11,
+	0xfc, 0xfd, 0x78, 0x1c, 0x1b, 0x2e, 0x09, 0xb7, 0xd8, 0x8e, 0xa0, 0xe3, 0x1a, 0x2c, 0xf7, 0xfd,
+	0xb0, 0xfa, 0x27, 0x80, 0xa2, 0x48, 0x53, 0xe9, 0xb4, 0x43, 0x5d, 0x1c, 0x71, 0x46, 0x7d, 0xfb,
+	0x76, 0xc9, 0x03, 0x26, 0x46, 0x1a, 0x5b, 0xe1, 0x02, 0xcb, 0x82, 0x42, 0xfa, 0x55, 0x0e, 0x60,
+	0xf4, 0xf2, 0xff, 0x90, 0x6f, 0xfb, 0xc4, 0xf3, 0x2b, 0x3c, 0x90, 0xbb, 0x36, 0x8e, 0x71, 0xac,
+	0x47, 0xd9, 0x09, 0x7a, 0x14, 0x1e, 0xd2, 0xdd, 0x1c, 0xcb, 0xe6, 0x07, 0xeb, 0x4b, 0x9e, 0xc0,
+	0x4a, 0xd8, 0x36, 0x84, 0x23, 0xd9, 0x80, 0x19, 0xdd, 0xc1, 0x03, 0x9e, 0x1e, 0x8a, 0x3d, 0x9d,
+	0xf9, 0x88, 0x38, 0xaa, 0x74, 0x11, 0x8a, 0xcd, 0x81, 0xda, 0xc3, 0x1d, 0x0b, 0x77, 0x69, 0xa7,
+	0x3a, 0x6d, 0x08, 0x41, 0x78, 0x43, 0xda, 0x80, 0xc2, 0x4f, 0xf0, 0x31, 0x5f, 0xd4, 0x29, 0x05,
+	0x95, 0xfe, 0xac, 0x08, 0xab, 0x6c, 0xaf, 0xd8, 0x74, 0x93, 0x33, 0x32, 0x26, 0xe6, 0xd0, 0xee,
+	0x62, 0xc2, 0x66, 0xdb, 0x1a, 0x2a, 0x16, 0xb6, 0x75, 0x53, 0x13, 0xa9, 0x80, 0x62, 0xd7, 0x1a,
+	0xb6, 0x19, 0x80, 0xe6, 0x13, 0xe8, 0xeb, 0x2f, 0x87, 0xa6, 0x30, 0xc4, 0x9c, 0x5c, 0xe8, 0x5a,
+	0xc3, 0xff, 0x4f, 0xdb, 0x2e, 0x2d, 0x39, 0x54, 0x6d, 0x4c, 0x6a, 0x39, 0x8f, 0xb6, 0xc3, 0x00,
+	0xe8, 0x36, 0x9c, 0x1e, 0xe0, 0x81, 0x69, 0x1f, 0x2b, 0x7d, 0x7d, 0xa0, 0x3b, 0x8a, 0x6e, 0x28,
+	0xfb, 0xc7, 0x0e, 0x26, 0xc2, 0xa6, 0x10, 0x7f, 0xf9, 0x84, 0xbe, 0x6b, 0x1a, 0x0f, 0xe8, 0x1b,
+	0x24, 0x41, 0xd9, 0x34, 0x07, 0x0a, 0xe9, 0x9a, 0x36, 0x56, 0x54, 0xed, 0x0b, 0xb6, 0x7d, 0xe6,
+	0xe4, 0x92, 0x69, 0x0e, 0x3a, 0x14, 0xd6, 0xd0, 0xbe, 0x40, 0x17, 0xa0, 0xd4, 0xb5, 0x86, 0x04,
+	0x3b, 0x0a, 0xfd, 0x61, 0xbb, 0x63, 0x51, 0x06, 0x0e, 0xda, 0xb4, 0x86, 0xc4, 0x87, 0x30, 0xa0,
+	0xfa, 0x9f, 0xf3, 0x23, 0x3c, 0xc5, 0x03, 0x82, 0x9e, 0x03, 0x68, 0x3a, 0x79, 0x21, 0x46, 0xa5,
+	0xb1, 0xf9, 0x79, 0x3f, 0x61, 0x7b, 0x8d, 0xaa, 0x6c, 0x7d, 0x4b, 0x27, 0x2f, 0x98, 0x02, 0xb8,
+	0x29, 0x16, 0x35, 0xb7, 0x4d, 0xb3, 0x93, 0xfb, 0xfd, 0x17, 0xba, 0xa9, 0x1c, 0x61, 0xbd, 0x77,
+	0xe8, 0xd4, 0x30, 0x3b, 0xde, 0x95, 0x18, 0xec, 0x39, 0x03, 0xa1, 0x16, 0x2c, 0xf9, 0x51, 0x14,
+	0x0d, 0xbf, 0xd4, 0xbb, 0xb8, 0x76, 0xc0, 0x84, 0x38, 0x1f, 0x15, 0x82, 0x93, 0x6d, 0x31, 0x2c,
+	0x79, 0xd1, 0xc7, 0x89, 0x83, 0x50, 0x07, 0x4e, 0x73, 0x7e, 0x9c, 0x91, 0x42, 0xb3, 0x15, 0xca,
+	0xbe, 0x45, 0x6a, 0x3d, 0xc6, 0x71, 0x2d, 0xca, 0x71, 0xf7, 0xd0, 0x36, 0x1d, 0xa7, 0x8f, 0x05,
+	0x4f, 0xc4, 0xc8, 0x45, 0x03, 0xab, 0xda, 0x03, 0x8b, 0xee, 0xf9, 0x2b, 0x01, 0xa6, 0x47, 0xb6,
+	0xee, 0x60, 0xc6, 0xf5, 0x30, 0x25, 0xd7, 0x25, 0x1f, 0xd7, 0xe7, 0x94, 0x3a, 0x8e, 0x2d, 0x93,
+	0xb5, 0xb9, 0x63, 0x91, 0x9a, 0x7e, 0x02, 0xb6, 0x54, 0x58, 0x4a, 0x8c, 0x9e, 0xc3, 0x6a, 0x8c,
+	0xb4, 0x8c, 0xef, 0x17, 0x29, 0xf9, 0x2e, 0x87, 0xc5, 0x65, 0x8c, 0x2f, 0x41, 0xf9, 0x05, 0xb6,
+	0x0d, 0xdc, 0x57, 0xb8, 0xa9, 0xd6, 0x5e, 0x30, 0x6b, 0x9c, 0xe7, 0xc0, 0xa7, 0x0c, 0x86, 0x6e,
+	0x82, 0x30, 0x64, 0xc5, 0xc6, 0x34, 0x05, 0xcc, 0xf3, 0x90, 0x7d, 0x86, 0xb9, 0xc8, 0xdf, 0xc8,
+	0xa3, 0x17, 0xa8, 0x09, 0x02, 0xa8, 0x90, 0x23, 0x76, 0xbc, 0xc5, 0x84, 0xd4, 0x06, 0x29, 0x12,
+	0x38, 0x55, 0x4e, 0xd6, 0xf1, 0xa8, 0xd0, 0x06, 0xcc, 0x0d, 0xd9, 0xca, 0x22, 0x35, 0x83, 0x8d,
+	0xb3, 0x16, 0x65, 0xb0, 0xc7, 0x10, 0x64, 0x17, 0x91, 0x2e, 0x59, 0x4b, 0xd7, 0x08, 0x5f, 0x91,
+	0x35, 0x93, 0x2f, 0x59, 0x0a, 0x61, 0xcb, 0x10, 0xdd, 0x83, 0xba, 0x4f, 0xba, 0xf0, 0xba, 0xb5,
+	0x18, 0xfa, 0xca, 0x48, 0x10, 0xff, 0xda, 0xad, 0x7f, 0x08, 0x95, 0xe0, 0xca, 0x98, 0xca, 0x91,
+	0xde, 0x83, 0xf9, 0x80, 0x5d, 0x23, 0xc8, 0xfb, 0xd2, 0xca, 0xec, 0x19, 0xad, 0xc0, 0x2c, 0xc7,
+	0x61, 0xe4, 0x65, 0x59, 0xb4, 0xa4, 0xf7, 0xa1, 0x12, 0x9c, 0xcf, 0x58, 0x6a, 0x04, 0x79, 0xdb,
+	0x8d, 0x51, 0xf2, 0x32, 0x7b, 0x96, 0xb6, 0x60, 0x96, 0x6b, 0x28, 0x36, 0xb1, 0x83, 0x20, 0x7f,
+	0xa8, 0xda, 0x9a, 0xf0, 0x7b, 0xec, 0x99, 0xc2, 0x88, 0x79, 0xe0, 0x08, 0x6f, 0xc7, 0x9e, 0x25,
+	0x15, 0xca, 0x81, 0xd4, 0x24, 0x45, 0x62, 0x39, 0x48, 0xc1, 0x8c, 0x3e, 0xb3, 0xee, 0xcd, 0xbe,
+	0x3b, 0x72, 0xf6, 0x4c, 0x61, 0xce, 0xb1, 0xe5, 0xa6, 0x88, 0xd8, 0x33, 0x55, 0x51, 0x1f, 0xbf,
+	0x14, 0xf9, 0xee, 0xa2, 0xcc, 0x1b, 0x92, 0x06, 0xb0, 0xa9, 0x5a, 0xea, 0xbe, 0xde, 0xd7, 0x9d,
+	0x63, 0x74, 0x1d, 0xaa, 0xaa, 0xa6, 0x29, 0x5d, 0x17, 0xa2, 0x63, 0xb7, 0x0a, 0xb1, 0xa0, 0x6a,
+	0xda, 0xa6, 0x0f, 0x8c, 0xde, 0x82, 0x45, 0xcd, 0x36, 0xad, 0x20, 0x2e, 0x2f, 0x4b, 0x54, 0xe9,
+	0x0b, 0x3f, 0xb2, 0xf4, 0x9f, 0x33, 0x70, 0x2e, 0xe8, 0xf5, 0xc2, 0xe9, 0xdf, 0x4f, 0x60, 0x3e,
+	0xd4, 0x6b, 0x82, 0xe5, 0x8e, 0xa4, 0x95, 0x03, 0x14, 0xa1, 0x74, 0x68, 0x36, 0x92, 0x0e, 0x8d,
+	0x4d, 0x30, 0xe7, 0x5e, 0x6b, 0x82, 0x39, 0xff, 0x5a, 0x12, 0xcc, 0x33, 0xd3, 0x25, 0x98, 0xaf,
+	0xc0, 0x82, 0x8f, 0x9a, 0xd9, 0x1a, 0xdf, 0xba, 0xca, 0x1e, 0x8e, 0xe1, 0x96, 0xaf, 0x42, 0x89,
+	0xe8, 0xb9, 0x69, 0x12, 0xd1, 0x85, 0xc4, 0x44, 0x34, 0xb5, 0x1a, 0xcb, 0x52, 0xed, 0x81, 0x69,
+	0xbb, 0x99, 0xe6, 0x5a, 0x91, 0x89, 0xb0, 0xe0, 0xc2, 0x45, 0x96, 0x39, 0x31, 0x27, 0x0d, 0x89,
+	0x39, 0xe9, 0x35, 0x98, 0x37, 0x4c, 0xc5, 0xc0, 0x47, 0x0a, 0x9d, 0x4b, 0x52, 0x2b, 0xf1, 0x89,
+	0x35, 0xcc, 0x16, 0x3e, 0x6a, 0x53, 0x48, 0x24, 0x6b, 0x3d, 0x3f, 0x5d, 0xd6, 0x9a, 0x6e, 0xae,
+	0x03, 0x95, 0xbc, 0xc0, 0x1a, 0x13, 0x85, 0xd4, 0xca, 0xcc, 0x88, 0x4b, 0x1c, 0x46, 0x65, 0x20,
+	0xb4, 0x2a, 0xe5, 0xe9, 0x8e, 0x23, 0x55, 0x18, 0x52, 0xd9, 0x85, 0x32, 0x34, 0xe9, 0xaf, 0x33,
+	0xb0, 0x1c, 0x34, 0x73, 0x91, 0xab, 0x7c, 0x04, 0x45, 0xdb, 0xdd, 0xe6, 0x6b, 0x99, 0xa4, 0x93,
+	0x7b, 0x42, 0x5c, 0x20, 0x8f, 0x68, 0xd1, 0x4f, 0x13, 0x53, 0xe4, 0xb7, 0x26, 0xf1, 0x9b, 0x94,
+	0x24, 0x97, 0x9a, 0x70, 0xe1, 0xb9, 0x6e, 0x68, 0xe6, 0x11, 0x49, 0x5c, 0xa5, 0x31, 0xb6, 0x96,
+	0x89, 0xb1, 0x35, 0xe9, 0xef, 0x32, 0xb0, 0x12, 0xe6, 0x25, 0x54, 0xd1, 0x8c, 0xaa, 0xe2, 0xad,
+	0x98, 0xe8, 0x24, 0x44, 0x1c, 0xab, 0x8c, 0xcf, 0x13, 0x95, 0x71, 0x7b, 0x32, 0xc7, 0x89, 0xea,
+	0xf8, 0x8b, 0x0c, 0x9c, 0x49, 0x14, 0x23, 0x14, 0xa2, 0x66, 0xc2, 0x21, 0xaa, 0x08, 0x6f, 0xbb,
+	0xe6, 0xd0, 0x70, 0x7c, 0xe1, 0xed, 0x26, 0x6d, 0x8b, 0x38, 0x52, 0x19, 0xa8, 0xaf, 0xf4, 0xc1,
+	0x70, 0x20, 0x3c, 0x3e, 0x65, 0xf7, 0x94, 0x43, 0x4e, 0x10, 0xe0, 0x4a, 0x0d, 0x58, 0xf4, 0xa4,
+	0x1c, 0x5b, 0x54, 0xf0, 0x15, 0x09, 0xb2, 0xc1, 0x22, 0x81, 0x01, 0xb3, 0x62, 0x97, 0x7b, 0x1d,
+	0x45, 0xd8, 0x35, 0x28, 0x59, 0xd8, 0x1e, 0xe8, 0x84, 0x78, 0x8e, 0xb6, 0x28, 0xfb, 0x41, 0xd2,
+	0x2f, 0xe7, 0x60, 0x21, 0x6c, 0x1d, 0x1f, 0x47, 0x6a, 0x12, 0x97, 0x62, 0xb6, 0x80, 0xf0, 0x40,
+	0x7d, 0xa7, 0xd3, 0xdb, 0xee, 0xe1, 0x26, 0x9b, 0x94, 0x18, 0xf4, 0x0e, 0x42, 0xe2, 0xe4, 0x43,
+	0x35, 0xd2, 0x35, 0x07, 0x03, 0xd5, 0xd0, 0xdc, 0xda, 0xb9, 0x68, 0x52, 0xfd, 0xa9, 0x76, 0x8f,
+	0xaa, 0x9d, 0x82, 0xd9, 0x33, 0x9d, 0x3c, 0x9a, 0x45, 0xd3, 0x0d, 0x56, 0xdb, 0x60, 0xce, 0xba,
+	0x28, 0x83, 0x00, 0x6d, 0xe9, 0x36, 0x5a, 0x87, 0x3c, 0x36, 0x5e, 0xba, 0xc7, 0xcf, 0x98, 0xe2,
+	0xba, 0x7b, 0xcc, 0x92, 0x19, 0x1e, 0xba, 0x05, 0xb3, 0x03, 0x6a, 0x16, 0x6e, 0x3e, 0x6d, 0x35,
+	0xa1, 0xc6, 0x2c, 0x0b, 0x34, 0x1a, 0x9e, 0xf1, 0x80, 0xd4, 0x4d, 0x9a, 0xc5, 0x84, 0x67, 0x22,
+	0xfc, 0x74, 0x11, 0xd1, 0xb6, 0x77, 0xb8, 0x2e, 0x26, 0x9d, 0x8a, 0x43, 0x53, 0x11, 0x7b, 0xc2,
+	0xde, 0x0d, 0x9e, 0xb0, 0x81, 0xf1, 0xda, 0x98, 0xcc, 0x6b, 0x7c, 0x99, 0xe3, 0x0c, 0x14, 0x68,
+	0xa9, 0x88, 0x99, 0x51, 0x89, 0x5f, 0xcb, 0xe8, 0x9b, 0x3d, 0x66, 0x45, 0xcb, 0x34, 0xd9, 0xa0,
+	0xe9, 0x06, 0x73, 0xea, 0x05, 0x99, 0x37, 0xe8, 0xe2, 0x63, 0x0f, 0x8a, 0x69, 0x74, 0x71, 0xad,
+	0xcc, 0x5e, 0x15, 0x19, 0x64, 0xc7, 0xe8, 0xb2, 0xe3, 0xab, 0xe3, 0x1c, 0xd7, 0x2a, 0x0c, 0x4e,
+	0x1f, 0x69, 0x1e, 0x89, 0xa7, 0x3c, 0x17, 0x92, 0xf2, 0x48, 0x71, 0x6e, 0xdb, 0xcd, 0x78, 0x3e,
+	0x80, 0xb9, 0x23, 0xee, 0x08, 0x6a, 0xd5, 0xb5, 0x4c, 0x7c, 0x6a, 0x22, 0xde, 0xdb, 0xc9, 0x2e,
+	0x21, 0xdd, 0x64, 0x0c, 0xec, 0xd0, 0x3d, 0xcc, 0xa4, 0x4e, 0x86, 0x5d, 0x08, 0xc8, 0xc9, 0x25,
+	0x03, 0x3b, 0x6d, 0x01, 0xa2, 0x6a, 0x60, 0x07, 0x47, 0x9a, 0xa0, 0xc7, 0x5c, 0x0d, 0xac, 0xdd,
+	0xd4, 0xbe, 0xcf, 0x44, 0xc4, 0xaf, 0x32, 0xb0, 0xb2, 0xc9, 0x92, 0x34, 0x3e, 0x2f, 0x38, 0x4d,
+	0x5d, 0xe1, 0xae, 0x57, 0xf2, 0x49, 0x2c, 0x02, 0x84, 0xb5, 0x26, 0x08, 0x50, 0x13, 0x2a, 0x2e,
+	0x73, 0xc1, 0x22, 0x97, 0xba, 0x6a, 0x54, 0x26, 0xfe, 0xa6, 0xf4, 0x21, 0xac, 0x46, 0x46, 0x21,
+	0x12, 0x2a, 0x17, 0x61, 0x7e, 0xe4, 0xed, 0xbc, 0x41, 0x94, 0x3c, 0x58, 0x53, 0x93, 0xee, 0xd1,
+	0x92, 0x90, 0x6a, 0x3b, 0x11, 0x15, 0xa4, 0xa0, 0x65, 0xf5, 0xa0, 0x20, 0xad, 0x28, 0xd9, 0x74,
+	0x60, 0x99, 0x56, 0x8a, 0x4e, 0xc0, 0x94, 0xfa, 0x2c, 0x3a, 0x7e, 0x73, 0xe8, 0xee, 0x2e, 0x6e,
+	0x53, 0x5a, 0x85, 0xd3, 0x21, 0xa6, 0xa2, 0xb7, 0x0f, 0x60, 0x85, 0x17, 0x8f, 0x4e, 0x32, 0x88,
+	0x33, 0xb0, 0x1a, 0x21, 0x16, 0x7c, 0x9f, 0xc2, 0xd2, 0x68, 0x53, 0x1d, 0x25, 0x86, 0xef, 0x04,
+	0x13, 0xc3, 0x6b, 0x63, 0x66, 0x3d, 0x90, 0x17, 0xfe, 0x65, 0xd6, 0xb7, 0x2b, 0x24, 0xa4, 0x85,
+	0x3f, 0x08, 0xa6, 0x85, 0xdf, 0x9c, 0xc4, 0x3b, 0x90, 0x15, 0x8e, 0x5a, 0x6d, 0x2e, 0xc6, 0x6a,
+	0x7f, 0x16, 0xc9, 0x1d, 0xe7, 0x93, 0x92, 0xef, 0x21, 0x69, 0x7f, 0x27, 0xa9, 0x63, 0x99, 0xa7,
+	0x8e, 0xbd, 0xae, 0xbd, 0x5a, 0xdf, 0xdd, 0x50, 0xea, 0xf8, 0xe2, 0x44, 0x79, 0xbd, 0xcc, 0xf1,
+	0x5f, 0xe5, 0xa1, 0xe8, 0xbd, 0x8b, 0xe8, 0x3c, 0xaa, 0xb6, 0x6c, 0x8c, 0xda, 0xfc, 0xfb, 0x77,
+	0xee, 0x5b, 0xed, 0xdf, 0xf9, 0xd4, 0xfb, 0xf7, 0x59, 0x28, 0xb2, 0x07, 0xc5, 0xc6, 0x07, 0x62,
+	0x3f, 0x2e, 0x30, 0x80, 0x8c, 0x0f, 0x46, 0x66, 0x38, 0x3b, 0x95, 0x19, 0x86, 0x92, 0xd5, 0x73,
+	0xe1, 0x64, 0xf5, 0xc7, 0xde, 0x7e, 0xca, 0xb7, 0xe0, 0xab, 0x63, 0xf8, 0xc6, 0xee, 0xa4, 0xad,
+	0xe0, 0x4e, 0xca, 0x77, 0xe5, 0xb7, 0xc7, 0x71, 0xf9, 0xc1, 0xa6, 0xaa, 0xf7, 0x78, 0xaa, 0xda,
+	0x6f, 0x8b, 0xc2, 0xb3, 0x7e, 0x00, 0xe0, 0x39, 0x11, 0x37, 0x5f, 0x7d, 0x76, 0xcc, 0x18, 0x65,
+	0x1f, 0x3a, 0x65, 0x1b, 0x98, 0x9a, 0x21, 0x49, 0xef, 0xaf, 0xc6, 0x14, 0xb3, 0xff, 0xb2, 0x00,
+	0x0b, 0x21, 0xbe, 0x11, 0x5b, 0xff, 0x38, 0x52, 0x24, 0x99, 0xd2, 0x8a, 0xef, 0x04, 0x6b, 0x24,
+	0x27, 0xb4, 0xba, 0x48, 0x89, 0x84, 0xc5, 0x3d, 0xaa, 0x2d, 0x5e, 0xf3, 0x14, 0x76, 0x51, 0x40,
+	0x1a, 0xec, 0x5c, 0x71, 0xa0, 0x1b, 0x3a, 0x39, 0xe4, 0xef, 0x67, 0xd9, 0x7b, 0x70, 0x41, 0x0d,
+	0x76, 0x39, 0x13, 0xbf, 0xd2, 0x1d, 0xa5, 0x6b, 0x6a, 0x98, 0xd9, 0xf4, 0x8c, 0x5c, 0xa0, 0x80,
+	0x4d, 0x53, 0xc3, 0xa3, 0x95, 0x57, 0x38, 0xd9, 0xca, 0x2b, 0x86, 0x56, 0xde, 0x0a, 0xcc, 0xda,
+	0x58, 0x25, 0xa6, 0x21, 0x0e, 0xf7, 0xa2, 0x45, 0xa7, 0x66, 0x80, 0x09, 0xa1, 0x3d, 0x89, 0x60,
+	0x4f, 0x34, 0x7d, 0x41, 0xea, 0xfc, 0xc4, 0x20, 0x75, 0x4c, 0x61, 0x39, 0x14, 0xa4, 0x96, 0x27,
+	0x06, 0xa9, 0x69, 0xea, 0xca, 0xbe, 0x30, 0xbd, 0x92, 0x2e, 0x4c, 0xf7, 0x47, 0xb5, 0x0b, 0xc1,
+	0xa8, 0xf6, 0x31, 0xcc, 0xbd, 0x34, 0xfb, 0xc3, 0x01, 0x26, 0x35, 0x2d, 0xa9, 0x86, 0x1e, 0x96,
+	0xee, 0x19, 0x27, 0x10, 0x17, 0xd1, 0x04, 0x79, 0x30, 0xb1, 0x80, 0xbf, 0x45, 0x62, 0xc1, 0x1f,
+	0x7c, 0x1e, 0x04, 0x82, 0x4f, 0xef, 0x40, 0xd3, 0x4b, 0x77, 0xa0, 0xf9, 0x1e, 0x5d, 0x51, 0x7d,
+	0x17, 0xe6, 0xfd, 0x7a, 0x8a, 0xa1, 0x5d, 0xf7, 0xd3, 0xc6, 0x1e, 0x9d, 0x38, 0x03, 0xbf, 0x83,
+	0x2b, 0xc0, 0x2c, 0x07, 0x4a, 0xff, 0x94, 0x81, 0xd5, 0x88, 0x53, 0x12, 0xce, 0xee, 0x6e, 0xa8,
+	0xc0, 0x7f, 0x71, 0xe2, 0x9c, 0x7a, 0xf5, 0xfd, 0x47, 0x81, 0xfa, 0xfe, 0xbb, 0x93, 0x09, 0x5f,
+	0x7b, 0x79, 0xff, 0x6f, 0xb2, 0x70, 0x61, 0xcf, 0xd2, 0x42, 0xf1, 0xb1, 0x30, 0x93, 0xf4, 0x6e,
+	0xf7, 0x63, 0xf7, 0x9c, 0x95, 0x9d, 0xd6, 0x14, 0x39, 0x1d, 0xfa, 0x12, 0xaa, 0xc4, 0xc2, 0x5d,
+	0xc5, 0xbf, 0x80, 0xf9, 0x12, 0x79, 0x18, 0x53, 0x83, 0x18, 0x2f, 0xf0, 0x3a, 0x75, 0x55, 0x91,
+	0x45, 0xbd, 0x40, 0x82, 0xd0, 0xfa, 0x03, 0x58, 0x8e, 0x43, 0x9c, 0x4a, 0x7d, 0x12, 0xac, 0x25,
+	0x0b, 0x23, 0xe2, 0xe4, 0x9f, 0xc3, 0xc2, 0xf6, 0x2b, 0xdc, 0xed, 0x1c, 0x1b, 0xdd, 0x29, 0x34,
+	0x5a, 0x85, 0x5c, 0x77, 0xa0, 0x89, 0xc4, 0x3a, 0x7d, 0xf4, 0x87, 0xfe, 0xb9, 0x60, 0xe8, 0xaf,
+	0x40, 0x75, 0xd4, 0x83, 0xb0, 0xca, 0x15, 0x6a, 0x95, 0x1a, 0x45, 0xa6, 0xcc, 0xe7, 0x65, 0xd1,
+	0x12, 0x70, 0x6c, 0xf3, 0x3b, 0x74, 0x1c, 0x8e, 0x6d, 0x3b, 0xb8, 0x45, 0xe4, 0x82, 0x5b, 0x84,
+	0xf4, 0xa7, 0x19, 0x28, 0xd1, 0x1e, 0xbe, 0x95, 0xfc, 0xe2, 0x74, 0x9e, 0x1b, 0x9d, 0xce, 0xbd,
+	0x43, 0x7e, 0xde, 0x7f, 0xc8, 0x1f, 0x49, 0x3e, 0xc3, 0xc0, 0x51, 0xc9, 0x67, 0x3d, 0x38, 0xb6,
+	0x6d, 0x69, 0x0d, 0xe6, 0xb9, 0x6c, 0x62, 0xe4, 0xf4, 0xf6, 0xac, 0xdd, 0x77, 0xe7, 0x6f, 0x68,
+	0xf7, 0xa5, 0x3f, 0xce, 0x40, 0xb9, 0xe1, 0x38, 0x6a, 0xf7, 0x70, 0x8a, 0x01, 0x78, 0xc2, 0x65,
+	0xfd, 0xc2, 0x45, 0x07, 0x31, 0x12, 0x37, 0x9f, 0x20, 0xee, 0x4c, 0x40, 0x5c, 0x09, 0x2a, 0xae,
+	0x2c, 0x89, 0x02, 0xb7, 0xe8, 0x55, 0x61, 0xdb, 0x79, 0x68, 0xda, 0x47, 0xaa, 0xad, 0x4d, 0x77,
+	0xec, 0xa6, 0x95, 0x2a, 0xfe, 0x95, 0x46, 0xee, 0xda, 0x8c, 0xcc, 0x9e, 0xa5, 0xab, 0xb0, 0x14,
+	0xe0, 0x97, 0xd8, 0xf1, 0x27, 0x50, 0x62, 0x9b, 0xbd, 0x38, 0x7f, 0xdd, 0xf6, 0xdf, 0x18, 0x48,
+	0x15, 0x1a, 0x48, 0xff, 0x0f, 0x16, 0x69, 0x50, 0xc8, 0xe0, 0x9e, 0x07, 0xf9, 0x71, 0xe8, 0x70,
+	0x72, 0x2e, 0x81, 0x51, 0xe8, 0x60, 0xf2, 0x9b, 0x2c, 0xcc, 0x30, 0x78, 0x24, 0x50, 0x3b, 0x4b,
+	0xb7, 0x3f, 0xcb, 0x54, 0x1c, 0xb5, 0xe7, 0x7d, 0x13, 0x43, 0x01, 0xbb, 0x6a, 0x8f, 0xa5, 0x5c,
+	0xd8, 0x4b, 0x4d, 0xef, 0x61, 0xe2, 0xb8, 0x1f, 0xc6, 0x94, 0x28, 0x6c, 0x8b, 0x83, 0x58, 0xd1,
+	0x4d, 0xff, 0x3d, 0x7e, 0xd8, 0xc8, 0xcb, 0xec, 0x19, 0xad, 0xf3, 0x5b, 0xd7, 0x69, 0xaa, 0x30,
+	0x14, 0x91, 0x5e, 0x82, 0x0e, 0x15, 0x5e, 0xbc, 0x36, 0xba, 0x1f, 0xde, 0xe8, 0x2f, 0x27, 0x8c,
+	0x38, 0x7e, 0x7b, 0xff, 0x8e, 0xf6, 0xb3, 0x6d, 0x40, 0xfe, 0xb9, 0x11, 0x56, 0x70, 0x0b, 0x66,
+	0xd9, 0xd4, 0xb9, 0x81, 0xfa, 0x6a, 0x82, 0xa8, 0xb2, 0x40, 0x93, 0x54, 0x40, 0x7c, 0xda, 0x03,
+	0xc1, 0xf9, 0xf4, 0xb6, 0x32, 0x26, 0x58, 0xff, 0xfb, 0x0c, 0x2c, 0x05, 0xfa, 0x10, 0xb2, 0xde,
+	0x0c, 0x76, 0x92, 0x28, 0xaa, 0xe8, 0x60, 0x33, 0xb0, 0xbf, 0xde, 0x4a, 0x12, 0xe9, 0x3b, 0xda,
+	0x5b, 0x7f, 0x93, 0x01, 0x68, 0x0c, 0x9d, 0x43, 0x91, 0xe2, 0xf6, 0xdb, 0x4b, 0x26, 0x64, 0x2f,
+	0x75, 0x28, 0x58, 0x2a, 0x21, 0x47, 0xa6, 0xed, 0x1e, 0xaf, 0xbd, 0x36, 0x4b, 0x46, 0x0f, 0x9d,
+	0x43, 0xb7, 0xa6, 0x4b, 0x9f, 0x69, 0xa2, 0x9e, 0x7f, 0x1d, 0xa6, 0xa8, 0x9a, 0x66, 0xd3, 0x8a,
+	0x3f, 0x2f, 0xee, 0x96, 0x39, 0xb4, 0xc1, 0x81, 0x14, 0x4d, 0xd7, 0xb0, 0xe1, 0xd0, 0x42, 0x89,
+	0x63, 0xbe, 0xc0, 0x86, 0x38, 0x26, 0x97, 0x5d, 0xe8, 0x2e, 0x05, 0xf2, 0x2a, 0x57, 0x4f, 0x27,
+	0x8e, 0xed, 0xa2, 0xb9, 0x85, 0x44, 0x01, 0x65, 0x68, 0x74, 0x52, 0xaa, 0xed, 0x61, 0xbf, 0xcf,
+	0x55, 0x7c, 0xf2, 0x69, 0x7f, 0x47, 0x0c, 0x28, 0x9b, 0xb4, 0xd2, 0x46, 0x4a, 0x13, 0xc3, 0x7d,
+	0x8d, 0xf9, 0xc0, 0x77, 0x60, 0xd1, 0x37, 0x06, 0x61, 0x56, 0x81, 0xf3, 0x4c, 0x26, 0x78, 0x9e,
+	0x91, 0x1e, 0x01, 0xe2, 0x29, 0xb0, 0x6f, 0x39, 0x6e, 0xe9, 0x34, 0x2c, 0x05, 0x18, 0x89, 0xf8,
+	0xe0, 0x06, 0x94, 0xc5, 0x85, 0x5d, 0x61, 0x28, 0x67, 0xa0, 0x40, 0xfd, 0x7c, 0x57, 0xd7, 0xdc,
+	0x82, 0xff, 0x9c, 0x65, 0x6a, 0x9b, 0xba, 0x66, 0x4b, 0xcf, 0xa1, 0x2c, 0xf3, 0x7e, 0x04, 0xee,
+	0x43, 0xa8, 0x88, 0xeb, 0xbd, 0x4a, 0xe0, 0x7e, 0x7d, 0xdc, 0xc7, 0x5d, 0xfe, 0x4e, 0xe4, 0xb2,
+	0xe1, 0x6f, 0x4a, 0x1a, 0xd4, 0x79, 0x20, 0x13, 0x60, 0xef, 0x0e, 0xf6, 0x21, 0xb8, 0x57, 0xed,
+	0x27, 0xf6, 0x12, 0xa4, 0x2f, 0xdb, 0xfe, 0xa6, 0x74, 0x0e, 0xce, 0xc6, 0xf6, 0x22, 0x34, 0x61,
+	0x41, 0x75, 0xf4, 0x42, 0xd3, 0xdd, 0x9b, 0x0f, 0xec, 0x46, 0x43, 0xc6, 0x77, 0xa3, 0x61, 0xc5,
+	0x8b, 0xb8, 0xb3, 0xee, 0xd6, 0x4a, 0x5b, 0xbe, 0x93, 0x67, 0x2e, 0xe9, 0xe4, 0x99, 0x0f, 0x9c,
+	0x3c, 0xa5, 0x8e, 0xa7, 0x4f, 0x91, 0x11, 0x78, 0xc0, 0x32, 0x17, 0xbc, 0x6f, 0xd7, 0x21, 0x4a,
+	0xe3, 0x46, 0xc9, 0x51, 0x65, 0x1f, 0x95, 0x74, 0x1d, 0xca, 0x41, 0xd7, 0xe8, 0xf3, 0x73, 0x99,
+	0x88, 0x9f, 0xab, 0x84, 0x5c, 0xdc, 0x7b, 0xa1, 0xe3, 0x44, 0xb2, 0x8e, 0x43, 0x87, 0x89, 0xfb,
+	0x01, 0x67, 0x77, 0x23, 0x4a, 0xf6, 0x5d, 0xf9, 0xb9, 0x65, 0xb1, 0x1f, 0x3c, 0x24, 0x94, 0x5e,
+	0x0c, 0x5a, 0xba, 0x04, 0xa5, 0xbd, 0xa4, 0x8f, 0x03, 0xf3, 0x82, 0x5c, 0xba, 0x03, 0xcb, 0x0f,
+	0xf5, 0x3e, 0x26, 0xc7, 0xc4, 0xc1, 0x83, 0x26, 0x73, 0x4a, 0x07, 0x3a, 0xb6, 0xe9, 0x9d, 0x0e,
+	0x76, 0x9a, 0xb6, 0x4c, 0xdd, 0xfb, 0x66, 0xcc, 0x07, 0xa1, 0xdf, 0x8d, 0x2e, 0x8c, 0x08, 0xf7,
+	0x58, 0x16, 0xe1, 0x0d, 0x28, 0xd2, 0xf1, 0x12, 0x47, 0x1d, 0x58, 0x6e, 0x61, 0xd6, 0x03, 0xd0,
+	0xd4, 0xf1, 0x01, 0x71, 0xb3, 0x97, 0xb1, 0x95, 0xa0, 0x38, 0x41, 0xe4, 0xfc, 0x01, 0x69, 0xd2,
+	0xeb, 0xc8, 0x30, 0x24, 0x58, 0x13, 0xc5, 0xd8, 0x5c, 0x52, 0x0c, 0xb3, 0xe7, 0xbf, 0xa8, 0x41,
+	0x09, 0xf8, 0x1d, 0xc4, 0xfb, 0x50, 0xd2, 0x0d, 0x53, 0xc3, 0xac, 0x78, 0xae, 0xd5, 0xf2, 0x69,
+	0xc8, 0x81, 0x53, 0xec, 0x11, 0xac, 0x49, 0x18, 0x96, 0x02, 0xfa, 0x15, 0x86, 0xd2, 0x82, 0x45,
+	0xee, 0xb4, 0x0e, 0x3c, 0xc1, 0x5d, 0x8b, 0xbd, 0x38, 0x6e, 0x74, 0x4c, 0x5b, 0x72, 0x55, 0x17,
+	0x01, 0x97, 0x4b, 0x4a, 0x3f, 0xbc, 0x08, 0x1c, 0x37, 0x5f, 0x4f, 0xda, 0xad, 0x1d, 0xca, 0xe6,
+	0x8d, 0x0c, 0x5d, 0xe4, 0xca, 0x5c, 0x3b, 0x9f, 0x94, 0x2b, 0x23, 0x3c, 0x57, 0x46, 0xa4, 0x21,
+	0x9c, 0x09, 0xa4, 0x1d, 0x03, 0xb2, 0xde, 0x0f, 0x45, 0x9a, 0x57, 0x26, 0x71, 0x0d, 0x86, 0x9c,
+	0x63, 0x06, 0xf2, 0x3f, 0x19, 0x58, 0x8e, 0x23, 0x3d, 0x61, 0xc2, 0xfc, 0xe7, 0x09, 0x77, 0xd4,
+	0xef, 0xa6, 0x13, 0xf8, 0x77, 0x52, 0x6c, 0xd8, 0x85, 0x7a, 0x9c, 0xa6, 0xa3, 0xf3, 0x97, 0x9b,
+	0x66, 0xfe, 0xfe, 0x28, 0xe7, 0x2b, 0x1c, 0x35, 0x1c, 0xc7, 0xd6, 0xf7, 0x87, 0x74, 0x99, 0xbc,
+	0xf6, 0x64, 0x6c, 0xd3, 0x4b, 0x2b, 0x72, 0xd5, 0xde, 0x1e, 0x43, 0x3e, 0x92, 0x23, 0x36, 0xb5,
+	0xf8, 0x69, 0x30, 0xb5, 0xc8, 0x4b, 0x42, 0x77, 0xd2, 0xf1, 0xfb, 0xc1, 0xe6, 0xef, 0x7f, 0x9b,
+	0x85, 0x4a, 0x70, 0x8a, 0xd0, 0x36, 0x80, 0xea, 0x49, 0x5e, 0xcb, 0x4c, 0xac, 0xb2, 0x8d, 0x86,
+	0x29, 0xfb, 0x08, 0xd1, 0xdb, 0x90, 0xeb, 0x5a, 0x43, 0x31, 0x6b, 0x31, 0x89, 0xc3, 0x4d, 0x6b,
+	0xc8, 0xbd, 0x10, 0x45, 0xa3, 0xa7, 0x43, 0x71, 0x1d, 0x36, 0xd1, 0xb3, 0xf2, 0xab, 0xb1, 0x9c,
+	0x46, 0x20, 0xa3, 0xc7, 0x50, 0xa1, 0x17, 0x73, 0xd5, 0xfd, 0x3e, 0x56, 0xfa, 0xea, 0x31, 0xb6,
+	0x85, 0x67, 0x4d, 0xe1, 0xfc, 0xca, 0x2e, 0xe1, 0x13, 0x4a, 0xe7, 0xed, 0x9c, 0x33, 0x49, 0x3b,
+	0x67, 0x50, 0x4b, 0xaf, 0x6f, 0xe7, 0xfc, 0x03, 0x28, 0xb8, 0xaa, 0x98, 0xb0, 0x7d, 0xed, 0xc2,
+	0xea, 0x90, 0xa2, 0x29, 0xec, 0x22, 0xbb, 0xa1, 0x1a, 0xa6, 0x42, 0x30, 0x8d, 0x39, 0xdc, 0x4f,
+	0xec, 0x26, 0xec, 0x27, 0xcb, 0x8c, 0x7a, 0xd3, 0xb4, 0x71, 0x4b, 0x35, 0xcc, 0x0e, 0x27, 0x95,
+	0xfe, 0x36, 0x03, 0x25, 0x9f, 0x6a, 0x27, 0xc8, 0xd0, 0x84, 0x45, 0xf7, 0x06, 0x0c, 0xbd, 0x0b,
+	0xcf, 0x37, 0xc3, 0x54, 0xbd, 0x2f, 0x08, 0xba, 0x0e, 0x76, 0xf8, 0x96, 0xf8, 0x08, 0xaa, 0xec,
+	0x3e, 0x30, 0x1f, 0x13, 0xe7, 0xa4, 0xa5, 0xe1, 0x54, 0xa1, 0x64, 0x4c, 0x58, 0xc6, 0x48, 0xba,
+	0x0f, 0x67, 0x64, 0x6c, 0x5a, 0xd8, 0xf0, 0xa6, 0xe8, 0x89, 0xd9, 0x9b, 0xa2, 0xbe, 0xfd, 0x06,
+	0xd4, 0xe3, 0xe8, 0x45, 0x40, 0x7a, 0x0f, 0x4e, 0xb7, 0xd5, 0x21, 0xc1, 0x27, 0x2c, 0xff, 0x87,
+	0x69, 0x05, 0xd7, 0x0f, 0x61, 0x75, 0xcf, 0xb0, 0x4e, 0xca, 0xb7, 0x0e, 0xb5, 0x28, 0xb5, 0xe0,
+	0x7c, 0xc7, 0x3d, 0x61, 0x88, 0xb3, 0xbf, 0xe0, 0x7a, 0x01, 0x4a, 0x3c, 0xb1, 0xa0, 0xf8, 0x0e,
+	0x9f, 0xc0, 0x41, 0xf4, 0xbe, 0xab, 0xb4, 0x02, 0xcb, 0x41, 0x3a, 0xc1, 0xef, 0xbe, 0xb8, 0xc2,
+	0x70, 0xd2, 0xef, 0x56, 0xcf, 0xc0, 0x6a, 0x84, 0x9e, 0xb3, 0xbe, 0x71, 0x05, 0x0a, 0xee, 0x9f,
+	0x8d, 0xa0, 0x39, 0xc8, 0xed, 0x6e, 0xb6, 0xab, 0xa7, 0xe8, 0xc3, 0xde, 0x56, 0xbb, 0x9a, 0x41,
+	0x05, 0xc8, 0x77, 0x36, 0x77, 0xdb, 0xd5, 0xec, 0x8d, 0x01, 0x54, 0xc3, 0xff, 0xb4, 0x81, 0x56,
+	0x61, 0xa9, 0x2d, 0xef, 0xb4, 0x1b, 0x8f, 0x1a, 0xbb, 0xcd, 0x9d, 0x96, 0xd2, 0x96, 0x9b, 0xcf,
+	0x1a, 0xbb, 0xdb, 0xd5, 0x53, 0xe8, 0x22, 0x9c, 0xf3, 0xbf, 0x78, 0xbc, 0xd3, 0xd9, 0x55, 0x76,
+	0x77, 0x94, 0xcd, 0x9d, 0xd6, 0x6e, 0xa3, 0xd9, 0xda, 0x96, 0xab, 0x19, 0x74, 0x0e, 0xce, 0xf8,
+	0x51, 0x1e, 0x34, 0xb7, 0x9a, 0xf2, 0xf6, 0x26, 0x7d, 0x6e, 0x3c, 0xa9, 0x66, 0x6f, 0x7c, 0x04,
+	0xe5, 0xc0, 0x9f, 0x60, 0x50, 0x91, 0xda, 0x3b, 0x5b, 0xd5, 0x53, 0xa8, 0x0c, 0x45, 0x3f, 0x9f,
+	0x02, 0xe4, 0x5b, 0x3b, 0x5b, 0xdb, 0xd5, 0x2c, 0x02, 0x98, 0xdd, 0x6d, 0xc8, 0x8f, 0xb6, 0x77,
+	0xab, 0xb9, 0x1b, 0x6d, 0x58, 0x08, 0x7d, 0x04, 0x85, 0x16, 0xa1, 0xdc, 0x69, 0xb4, 0xb6, 0x1e,
+	0xec, 0x7c, 0xaa, 0xc8, 0xdb, 0x8d, 0xad, 0xcf, 0xaa, 0xa7, 0xd0, 0x32, 0x54, 0x5d, 0x50, 0x6b,
+	0x67, 0x97, 0x43, 0x33, 0x21, 0xe8, 0xc3, 0x9d, 0xbd, 0xd6, 0x56, 0x55, 0xbb, 0xf1, 0x55, 0x26,
+	0xe4, 0xa4, 0x31, 0x3a, 0x0d, 0x8b, 0x9e, 0x24, 0xca, 0xa6, 0xbc, 0xdd, 0xd8, 0xdd, 0xa6, 0x02,
+	0x06, 0xc0, 0xf2, 0x5e, 0xab, 0xd5, 0x6c, 0x3d, 0xe2, 0x6c, 0x47, 0xe0, 0xed, 0x4f, 0x9b, 0x14,
+	0x39, 0x1b, 0x44, 0xde, 0x6b, 0xfd, 0xa4, 0xb5, 0xf3, 0xbc, 0x55, 0xcd, 0xa1, 0x25, 0x58, 0x18,
+	0x81, 0xdb, 0x8d, 0xbd, 0xce, 0x76, 0x35, 0xbf, 0xf1, 0xdb, 0x25, 0xa8, 0xb8, 0x47, 0x0e, 0x6c,
+	0xb3, 0x8b, 0x82, 0x6d, 0x98, 0x73, 0xff, 0xf4, 0x26, 0x66, 0xdf, 0x0f, 0xfe, 0x55, 0x4f, 0xfd,
+	0xe2, 0x18, 0x0c, 0x61, 0x68, 0xa7, 0xd0, 0x3e, 0x3b, 0x89, 0x8d, 0x94, 0x87, 0xae, 0xc4, 0x9e,
+	0x7b, 0x22, 0x96, 0x58, 0xbf, 0x3a, 0x11, 0xcf, 0xeb, 0x03, 0x43, 0x25, 0xf8, 0x85, 0x36, 0xba,
+	0x1a, 0x77, 0x4a, 0x8a, 0xf9, 0x04, 0xbc, 0x7e, 0x6d, 0x32, 0xa2, 0xd7, 0xcd, 0x0b, 0xa8, 0x86,
+	0xbf, 0xd6, 0x46, 0x31, 0x15, 0x91, 0x84, 0x4f, 0xc2, 0xeb, 0x37, 0xd2, 0xa0, 0xfa, 0x3b, 0x8b,
+	0x7c, 0xd7, 0x7c, 0x3d, 0xcd, 0x87, 0xa2, 0x89, 0x9d, 0x25, 0x7d, 0x53, 0xca, 0x15, 0x18, 0xfc,
+	0x38, 0x0d, 0xc5, 0x7e, 0x44, 0x4c, 0x9c, 0x54, 0x0a, 0x8c, 0xff, 0xce, 0x4d, 0x3a, 0x85, 0x0e,
+	0x61, 0x21, 0x74, 0x67, 0x0b, 0xc5, 0x90, 0xc7, 0x5f, 0x4e, 0xab, 0x5f, 0x4f, 0x81, 0x19, 0xb4,
+	0x08, 0xff, 0x1d, 0xad, 0x78, 0x8b, 0x88, 0xb9, 0x01, 0x56, 0xbf, 0x36, 0x19, 0xd1, 0x6f, 0xdc,
+	0x81, 0xbb, 0x59, 0x71, 0xc6, 0x1d, 0x77, 0x23, 0xac, 0x7e, 0x75, 0x22, 0x9e, 0x5f, 0x69, 0xa1,
+	0x9b, 0x5a, 0x71, 0x4a, 0x8b, 0xbf, 0x09, 0x56, 0xbf, 0x9e, 0x02, 0x33, 0x6c, 0x05, 0xde, 0x2b,
+	0x92, 0x64, 0x05, 0x91, 0x5b, 0x4a, 0xf5, 0x6b, 0x93, 0x11, 0x03, 0x56, 0x10, 0xba, 0xaf, 0x71,
+	0x2d, 0x45, 0x85, 0x34, 0xd9, 0x0a, 0xe2, 0x6b, 0xa9, 0xd2, 0x29, 0xf4, 0x87, 0x19, 0xa8, 0x25,
+	0x95, 0xf1, 0xd0, 0xed, 0xa9, 0xeb, 0x8f, 0xf5, 0x8d, 0x69, 0x48, 0x3c, 0x29, 0xbe, 0x04, 0x14,
+	0x0d, 0x45, 0xd0, 0x5b, 0x71, 0x33, 0x93, 0x10, 0xf0, 0xd4, 0xdf, 0x4e, 0x87, 0xec, 0x9f, 0xc9,
+	0x60, 0x8c, 0x12, 0x37, 0x93, 0xb1, 0x11, 0x50, 0xfd, 0xda, 0x64, 0x44, 0xbf, 0x8f, 0x0a, 0x87,
+	0x2c, 0x71, 0x3e, 0x2a, 0x21, 0x28, 0xaa, 0xdf, 0x48, 0x83, 0xea, 0x75, 0xd6, 0x81, 0x82, 0x5b,
+	0x0c, 0x45, 0x31, 0x3b, 0x4f, 0xa8, 0x14, 0x5b, 0x97, 0xc6, 0xa1, 0x78, 0x4c, 0x1f, 0x41, 0x9e,
+	0x42, 0xd1, 0xb9, 0x78, 0x6c, 0x97, 0xd9, 0xf9, 0xa4, 0xd7, 0x1e, 0xa3, 0xa7, 0x30, 0xcb, 0xab,
+	0x7f, 0x28, 0x26, 0xaf, 0x17, 0xa8, 0x51, 0xd6, 0xd7, 0x92, 0x11, 0x3c, 0x76, 0x9f, 0x43, 0xc9,
+	0x57, 0xd8, 0x43, 0x97, 0xe3, 0xff, 0x03, 0x27, 0x58, 0x47, 0xac, 0xbf, 0x39, 0x01, 0xcb, 0x6f,
+	0x1e, 0xa1, 0xf3, 0xe1, 0xd5, 0x89, 0x87, 0xfc, 0x64, 0xf3, 0x88, 0x4f, 0x23, 0x70, 0xc3, 0x8f,
+	0xa6, 0x19, 0xe2, 0x0c, 0x3f, 0x31, 0xed, 0x53, 0x7f, 0x3b, 0x1d, 0xb2, 0xd7, 0xa5, 0x03, 0x4b,
+	0x31, 0x89, 0x68, 0xf4, 0x76, 0xd2, 0xc2, 0x8d, 0xcb, 0x8a, 0xd7, 0x6f, 0xa6, 0xc4, 0xf6, 0x4f,
+	0xbe, 0x70, 0x64, 0x17, 0x92, 0xb3, 0xb3, 0x89, 0x93, 0x1f, 0x71, 0x5b, 0x87, 0xb0, 0x10, 0x8a,
+	0xae, 0x51, 0xd2, 0xa6, 0x14, 0xdd, 0x8f, 0xaf, 0xa7, 0xc0, 0x74, 0x7b, 0xda, 0xf8, 0xb7, 0x1c,
+	0xcc, 0xf3, 0x72, 0x86, 0x88, 0xff, 0x3e, 0x03, 0x18, 0x55, 0x12, 0xd1, 0xa5, 0x78, 0xed, 0x07,
+	0x6a, 0xc0, 0xf5, 0xcb, 0xe3, 0x91, 0xfc, 0x26, 0xed, 0xab, 0xca, 0xa1, 0xcb, 0x13, 0x8a, 0x76,
+	0x89, 0x26, 0x1d, 0x53, 0xda, 0x93, 0x4e, 0xa1, 0x67, 0x50, 0xf4, 0xca, 0x3f, 0x28, 0xae, 0x7c,
+	0x14, 0xaa, 0x6f, 0xd5, 0x2f, 0x8d, 0xc5, 0xf1, 0x4b, 0xed, 0xab, 0xed, 0xc4, 0x49, 0x1d, 0xad,
+	0x21, 0xd5, 0xdf, 0x9c, 0x80, 0x15, 0xd1, 0x09, 0xcf, 0x00, 0x27, 0xea, 0x24, 0x90, 0x80, 0xaf,
+	0xbf, 0x39, 0x01, 0xcb, 0x9b, 0x5d, 0x0b, 0xca, 0xfc, 0xdc, 0xe7, 0xce, 0xae, 0x02, 0xf3, 0xfe,
+	0xe3, 0x20, 0x4a, 0x94, 0x33, 0x70, 0xcc, 0xac, 0x5f, 0x99, 0x84, 0xe6, 0xf6, 0xf8, 0xe0, 0xca,
+	0xaf, 0xbf, 0x3e, 0x9f, 0xf9, 0xe7, 0xaf, 0xcf, 0x9f, 0xfa, 0xea, 0x9b, 0xf3, 0x99, 0x5f, 0x7f,
+	0x73, 0x3e, 0xf3, 0x8f, 0xdf, 0x9c, 0xcf, 0xfc, 0xfb, 0x37, 0xe7, 0x33, 0x7f, 0xf2, 0x1f, 0xe7,
+	0x4f, 0xfd, 0xb4, 0xe0, 0x92, 0xef, 0xcf, 0xb2, 0x7f, 0xa1, 0x7c, 0xf7, 0x7f, 0x07, 0x00, 0xab,
+	0x71, 0xe3, 0xc9, 0x4b, 0x54, 0x00, 0x00,
 }
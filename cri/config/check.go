@@ -0,0 +1,180 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/alibaba/pouch/pkg/reference"
+)
+
+const (
+	// maxStatsCollectPeriod is the period (in time.Second) beyond which the stats
+	// reported to kubelet are too stale for the eviction and the autoscaling.
+	maxStatsCollectPeriod = 300
+
+	// defaultRegistryHost is the registry of the images without domain.
+	defaultRegistryHost = "registry-1.docker.io"
+)
+
+// Problem is a problem of the cri config found by Check.
+type Problem struct {
+	// Field is the flag of the config, e.g. sandbox-image.
+	Field string
+	// Message tells what is wrong and how to fix it.
+	Message string
+	// Warning is true if pouchd could still serve kubelet with the problem.
+	Warning bool
+}
+
+// String returns the problem in the form of "ERROR: field: message".
+func (p Problem) String() string {
+	level := "ERROR"
+	if p.Warning {
+		level = "WARNING"
+	}
+	return fmt.Sprintf("%s: %s: %s", level, p.Field, p.Message)
+}
+
+// Check validates the cri config against the host before pouchd starts serving
+// kubelet, runtimes are the runtime handlers configured in daemon.
+func (c *Config) Check(runtimes []string) []Problem {
+	var problems []Problem
+	addError := func(field, format string, args ...interface{}) {
+		problems = append(problems, Problem{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+	addWarning := func(field, format string, args ...interface{}) {
+		problems = append(problems, Problem{Field: field, Message: fmt.Sprintf(format, args...), Warning: true})
+	}
+
+	// the sandbox image must be pulled before the first pod runs, unless the
+	// built-in pause is used.
+	if c.BuiltinPause != "" {
+		if err := checkExecutable(c.BuiltinPause); err != nil {
+			addError("cri-builtin-pause", "%v, build it by make build-pause", err)
+		}
+	} else if named, err := reference.Parse(c.SandboxImage); err != nil {
+		addError("sandbox-image", "invalid image %q: %v", c.SandboxImage, err)
+	} else if host := registryHost(named.Name()); host != "" {
+		if _, err := net.LookupHost(host); err != nil {
+			addWarning("sandbox-image", "registry %s of image %s is not resolvable: %v, the image must be loaded in advance", host, c.SandboxImage, err)
+		}
+	}
+
+	// the holders of sandboxes are started from the built-in pause.
+	if c.HostNetworkHolder && c.BuiltinPause == "" {
+		addError("cri-host-network-holder", "the holder is started from the built-in pause, set cri-builtin-pause")
+	}
+
+	// the cni plugins may be installed by a daemonset later, but the
+	// directories should be there.
+	if err := checkDir(c.NetworkPluginBinDir); err != nil {
+		addError("cni-bin-dir", "%v, create it or point the flag to the directory of cni plugin binaries", err)
+	}
+	if err := checkDir(c.NetworkPluginConfDir); err != nil {
+		addError("cni-conf-dir", "%v, create it or point the flag to the directory of cni configurations", err)
+	} else if !hasCNIConfig(c.NetworkPluginConfDir) {
+		addWarning("cni-conf-dir", "no cni configuration in %s, the node is not ready until one is placed", c.NetworkPluginConfDir)
+	}
+
+	if !c.StreamServerReusePort {
+		addr := net.JoinHostPort(c.StreamServerAddress, c.StreamServerPort)
+		if l, err := net.Listen("tcp", addr); err != nil {
+			addError("stream-server-port", "stream server could not listen on %s: %v, choose a free port or set --stream-server-reuse-port", addr, err)
+		} else {
+			l.Close()
+		}
+	}
+
+	if c.EnableCriStatsCollect {
+		if c.CriStatsCollectPeriod <= 0 {
+			addError("cri-stats-collect-period", "period %ds must be positive", c.CriStatsCollectPeriod)
+		} else if c.CriStatsCollectPeriod > maxStatsCollectPeriod {
+			addWarning("cri-stats-collect-period", "period %ds is longer than %ds, the stats reported to kubelet are stale", c.CriStatsCollectPeriod, maxStatsCollectPeriod)
+		}
+	}
+
+	known := make(map[string]bool, len(runtimes))
+	for _, r := range runtimes {
+		known[r] = true
+	}
+	checkHandler := func(field, entry, handler string) {
+		if !known[handler] {
+			addError(field, "runtime handler %q of %q is unknown, add it by --add-runtime or the runtimes in config file", handler, entry)
+		}
+	}
+	for _, e := range c.RuntimeSnapshotters {
+		checkHandler("cri-runtime-snapshotters", e, strings.TrimSpace(strings.SplitN(e, "=", 2)[0]))
+	}
+	for _, e := range c.RuntimeOverheads {
+		checkHandler("cri-runtime-overheads", e, strings.TrimSpace(strings.SplitN(e, ":", 2)[0]))
+	}
+	for _, p := range c.AllowedNetworkDevices {
+		if _, err := filepath.Match(p, ""); err != nil {
+			addError("allowed-network-devices", "invalid pattern %q: %v", p, err)
+		}
+	}
+	for _, e := range c.WarmImages {
+		if parts := strings.SplitN(e, "=", 2); len(parts) == 2 {
+			checkHandler("cri-warm-images", e, strings.TrimSpace(parts[0]))
+		}
+	}
+	return problems
+}
+
+// registryHost returns the host of registry of the image name, or empty if the
+// registry has a port or is localhost, which is not looked up.
+func registryHost(name string) string {
+	parts := strings.SplitN(name, "/", 2)
+	if len(parts) == 1 || (!strings.ContainsAny(parts[0], ".:") && parts[0] != "localhost") {
+		return defaultRegistryHost
+	}
+	if strings.Contains(parts[0], ":") || parts[0] == "localhost" {
+		return ""
+	}
+	return parts[0]
+}
+
+// checkDir returns error if the path is not an existing directory.
+func checkDir(dir string) error {
+	fi, err := os.Stat(dir)
+	if err != nil {
+		return err
+	}
+	if !fi.IsDir() {
+		return fmt.Errorf("%s is not a directory", dir)
+	}
+	return nil
+}
+
+// checkExecutable returns error if the path is not an executable file.
+func checkExecutable(path string) error {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if !fi.Mode().IsRegular() || fi.Mode().Perm()&0111 == 0 {
+		return fmt.Errorf("%s is not an executable file", path)
+	}
+	return nil
+}
+
+// hasCNIConfig returns whether there is any cni configuration in the directory.
+func hasCNIConfig(dir string) bool {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return false
+	}
+	for _, f := range files {
+		switch filepath.Ext(f.Name()) {
+		case ".conf", ".conflist", ".json":
+			if !f.IsDir() {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+package config
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheck(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cri-config-check")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	binDir := filepath.Join(dir, "bin")
+	confDir := filepath.Join(dir, "net.d")
+	assert.NoError(t, os.MkdirAll(binDir, 0755))
+	assert.NoError(t, os.MkdirAll(confDir, 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(confDir, "10-bridge.conflist"), []byte("{}"), 0644))
+
+	cfg := &Config{
+		SandboxImage:          "localhost:5000/pause:3.1",
+		NetworkPluginBinDir:   binDir,
+		NetworkPluginConfDir:  confDir,
+		StreamServerAddress:   "127.0.0.1",
+		StreamServerPort:      "0",
+		EnableCriStatsCollect: true,
+		CriStatsCollectPeriod: 10,
+		RuntimeSnapshotters:   []string{"kata=devmapper"},
+		RuntimeOverheads:      []string{"kata:memory=128m"},
+		WarmImages:            []string{"busybox:latest", "kata=busybox:latest"},
+		AllowedNetworkDevices: []string{"ens1f0v*"},
+	}
+	assert.Empty(t, cfg.Check([]string{"runc", "kata"}))
+
+	// the port of stream server is taken.
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer l.Close()
+	cfg.StreamServerPort = strconv.Itoa(l.Addr().(*net.TCPAddr).Port)
+
+	cfg.SandboxImage = "pause image"
+	cfg.NetworkPluginBinDir = filepath.Join(dir, "missing")
+	cfg.CriStatsCollectPeriod = 0
+	cfg.AllowedNetworkDevices = []string{"ens1f0v["}
+	assert.NoError(t, os.Remove(filepath.Join(confDir, "10-bridge.conflist")))
+
+	var fields, warnings []string
+	for _, p := range cfg.Check([]string{"runc"}) {
+		if p.Warning {
+			warnings = append(warnings, p.Field)
+		} else {
+			fields = append(fields, p.Field)
+		}
+	}
+	assert.Equal(t, []string{
+		"sandbox-image",
+		"cni-bin-dir",
+		"stream-server-port",
+		"cri-stats-collect-period",
+		"cri-runtime-snapshotters",
+		"cri-runtime-overheads",
+		"allowed-network-devices",
+		"cri-warm-images",
+	}, fields)
+	assert.Equal(t, []string{"cni-conf-dir"}, warnings)
+
+	// the stream server shares the port with pouchd.
+	cfg.StreamServerReusePort = true
+	for _, p := range cfg.Check([]string{"runc", "kata"}) {
+		assert.NotEqual(t, "stream-server-port", p.Field)
+	}
+
+	// the holders of sandboxes are started from the built-in pause.
+	cfg.HostNetworkHolder = true
+	fields = nil
+	for _, p := range cfg.Check([]string{"runc"}) {
+		fields = append(fields, p.Field)
+	}
+	assert.Contains(t, fields, "cri-host-network-holder")
+
+	// the sandbox image is not used by built-in pause.
+	cfg.BuiltinPause = filepath.Join(binDir, "pouch-pause")
+	assert.NoError(t, ioutil.WriteFile(cfg.BuiltinPause, []byte("pause"), 0644))
+	fields = nil
+	for _, p := range cfg.Check([]string{"runc"}) {
+		fields = append(fields, p.Field)
+	}
+	assert.Contains(t, fields, "cri-builtin-pause")
+	assert.NotContains(t, fields, "sandbox-image")
+
+	assert.NoError(t, os.Chmod(cfg.BuiltinPause, 0755))
+	for _, p := range cfg.Check([]string{"runc"}) {
+		assert.NotEqual(t, "cri-builtin-pause", p.Field)
+		assert.NotEqual(t, "cri-host-network-holder", p.Field)
+	}
+}
+
+func TestRegistryHost(t *testing.T) {
+	for name, host := range map[string]string{
+		"pause":                               defaultRegistryHost,
+		"google-containers/pause":             defaultRegistryHost,
+		"registry.example.com/pause":          "registry.example.com",
+		"registry.example.com:5000/pause":     "",
+		"localhost/google-containers/pause":   "",
+		"registry.cn-hangzhou.aliyuncs.com/a": "registry.cn-hangzhou.aliyuncs.com",
+	} {
+		assert.Equal(t, host, registryHost(name), name)
+	}
+}
@@ -9,22 +9,183 @@ const (
 type Config struct {
 	// Listen is the listening address which servers CRI.
 	Listen string `json:"listen,omitempty"`
+	// TCPListen is the tcp address which also serves CRI protected by mutual TLS, e.g. "0.0.0.0:10011", empty means disabled.
+	TCPListen string `json:"cri-tcp-listen,omitempty"`
+	// TLSCert is the certificate file of the tcp address of CRI.
+	TLSCert string `json:"cri-tlscert,omitempty"`
+	// TLSKey is the key file of the tcp address of CRI.
+	TLSKey string `json:"cri-tlskey,omitempty"`
+	// TLSCACert is the CA file verifying the client certificates on the tcp address of CRI.
+	TLSCACert string `json:"cri-tlscacert,omitempty"`
+	// TLSAllowedCNs are the common names of client certificates allowed on the tcp address of CRI, empty means all the verified ones.
+	TLSAllowedCNs []string `json:"cri-tls-allowed-cns,omitempty"`
 	// NetworkPluginBinDir is the directory in which the binaries for the plugin is kept.
 	NetworkPluginBinDir string `json:"network-plugin-bin-dir,omitempty"`
 	// NetworkPluginConfDir is the directory in which the admin places a CNI conf.
 	NetworkPluginConfDir string `json:"network-plugin-conf-dir,omitempty"`
 	// SandboxImage is the image used by sandbox container.
 	SandboxImage string `json:"sandbox-image,omitempty"`
+	// BuiltinPause is the path of the static pause binary backing the sandboxes instead of SandboxImage.
+	BuiltinPause string `json:"cri-builtin-pause,omitempty"`
+	// HostNetworkHolder holds the sandboxes of the pods in host namespaces by the process of built-in pause.
+	HostNetworkHolder bool `json:"cri-host-network-holder,omitempty"`
+	// PullOnCreate pulls the image missing on CreateContainer with the credentials the pod pulled it with.
+	PullOnCreate bool `json:"cri-pull-on-create,omitempty"`
+	// PrefetchBandwidth is the bandwidth (in bytes per second) of the images prefetched in background, e.g. 10m, empty means no limit.
+	PrefetchBandwidth string `json:"cri-prefetch-bandwidth,omitempty"`
+	// WarmImages are the images kept on the node, which are prefetched again once they are removed, e.g. by the image gc.
+	WarmImages []string `json:"cri-warm-images,omitempty"`
+	// WarmImagesInterval is the time duration (in time.Second) the missing warm images are checked at.
+	WarmImagesInterval int `json:"cri-warm-images-interval,omitempty"`
+	// EnsureMountPropagation remounts the mounts of root and home dir rshared on startup if they are not shared.
+	EnsureMountPropagation bool `json:"cri-ensure-mount-propagation,omitempty"`
 	// CriVersion is the cri version
 	CriVersion string `json:"cri-version,omitempty"`
+	// StreamServerAddress is the address which cri stream server is listening on, empty means a proper one chosen by pouchd.
+	StreamServerAddress string `json:"stream-server-address,omitempty"`
 	// StreamServerPort is the port which cri stream server is listening on.
 	StreamServerPort string `json:"stream-server-port,omitempty"`
+	// StreamServerBaseURL is the base url of the streaming urls returned, e.g. the address of NAT or reverse proxy,
+	// empty means the one built from the listening address.
+	StreamServerBaseURL string `json:"stream-server-base-url,omitempty"`
+	// StreamServerBehindProxy specify whether cri stream server is behind a reverse proxy, which takes
+	// the client address, scheme and path prefix from the X-Forwarded headers.
+	StreamServerBehindProxy bool `json:"stream-server-behind-proxy,omitempty"`
+	// StreamIdleTimeout is the time duration (in time.Second) after which an idle stream connection is closed, 0 means the default.
+	StreamIdleTimeout int `json:"stream-idle-timeout,omitempty"`
 	// StreamServerReusePort specify whether cri stream server share port with pouchd.
 	StreamServerReusePort bool `json:"stream-server-reuse-port,omitempty"`
 	// CriStatsCollectPeriod specify the time duration (in time.Second) cri collect stats from containerd.
 	CriStatsCollectPeriod int `json:"cri-stats-collect-period,omitempty"`
 	// EnableCriStatsCollect specify whether cri collect stats from containerd.
 	EnableCriStatsCollect bool `json:"enable-cri-stats-collect,omitempty"`
+	// CriStatsStaleness is the time duration (in time.Millisecond) within which the collected metrics of containers are reused, 0 means no reuse.
+	CriStatsStaleness int `json:"cri-stats-staleness,omitempty"`
+	// CriStatsCacheTTL is the time duration (in time.Millisecond) the responses of ListContainerStats are cached, 0 means no cache.
+	CriStatsCacheTTL int `json:"cri-stats-cache-ttl,omitempty"`
 	// RuntimeConfigFile is a file to make the runtime config persistent.
 	RuntimeConfigFile string `json:"runtime-config-file"`
+	// NetPriorityDevice is the host device on which the traffic of sandboxes is classified by net priority.
+	NetPriorityDevice string `json:"net-priority-device,omitempty"`
+	// NetPriorityBandwidth is the bandwidth (in Mbit/s) of the net priority device.
+	NetPriorityBandwidth uint64 `json:"net-priority-bandwidth,omitempty"`
+	// NetPriorityShares are the bandwidth shares of net priority classes, in the form of "priority=share".
+	NetPriorityShares []string `json:"net-priority-shares,omitempty"`
+	// EnableConntrackCleanup specify whether to remove the conntrack entries of sandbox on teardown.
+	EnableConntrackCleanup bool `json:"enable-conntrack-cleanup,omitempty"`
+	// EnableNetworkPolicy specify whether to enforce the network policy in the annotations of sandbox.
+	EnableNetworkPolicy bool `json:"enable-network-policy,omitempty"`
+	// AllowedNetworkDevices are the patterns of host network devices allowed to be moved into sandboxes.
+	AllowedNetworkDevices []string `json:"allowed-network-devices,omitempty"`
+	// MaxRecvMsgSize is the max message size (in bytes) the cri grpc server could receive.
+	MaxRecvMsgSize int `json:"cri-max-recv-msg-size,omitempty"`
+	// MaxSendMsgSize is the max message size (in bytes) the cri grpc server could send.
+	MaxSendMsgSize int `json:"cri-max-send-msg-size,omitempty"`
+	// MaxConcurrentStreams is the max number of concurrent streams of each cri grpc connection, 0 means no limit.
+	MaxConcurrentStreams uint32 `json:"cri-max-concurrent-streams,omitempty"`
+	// KeepaliveTime is the time duration (in time.Second) after which the cri grpc server pings an idle connection, 0 means the default of grpc.
+	KeepaliveTime int `json:"cri-keepalive-time,omitempty"`
+	// KeepaliveTimeout is the time duration (in time.Second) the cri grpc server waits for the ping ack before closing the connection, 0 means the default of grpc.
+	KeepaliveTimeout int `json:"cri-keepalive-timeout,omitempty"`
+	// DefaultUlimits are the default ulimits of containers, in the form of "name=soft[:hard]".
+	DefaultUlimits []string `json:"cri-default-ulimits,omitempty"`
+	// DefaultCapabilities are the default capabilities of containers, empty means the default ones of pouch.
+	DefaultCapabilities []string `json:"cri-default-capabilities,omitempty"`
+	// DefaultMaskedPaths are the default masked paths of containers, empty means the default ones of pouch.
+	DefaultMaskedPaths []string `json:"cri-default-masked-paths,omitempty"`
+	// DefaultReadonlyPaths are the default readonly paths of containers, empty means the default ones of pouch.
+	DefaultReadonlyPaths []string `json:"cri-default-readonly-paths,omitempty"`
+	// DefaultMounts are the mounts injected into all the containers unless overridden, in the form of "hostPath:containerPath[:ro]".
+	DefaultMounts []string `json:"cri-default-mounts,omitempty"`
+	// EnvInjections are the env injected into the containers matching the selectors, which are only set in config file.
+	EnvInjections []EnvInjection `json:"cri-env-injections,omitempty"`
+	// MethodConcurrency are the max numbers of concurrent requests of cri methods, in the form of "method=limit".
+	MethodConcurrency []string `json:"cri-method-concurrency,omitempty"`
+	// DefaultStopTimeout is the time duration (in time.Second) the containers are given to stop before being killed when the sandbox is stopped.
+	DefaultStopTimeout int `json:"cri-default-stop-timeout,omitempty"`
+	// SwapBehavior is the default swap behavior of containers without swap limit, LimitedSwap or UnlimitedSwap, empty means twice the memory limit.
+	SwapBehavior string `json:"cri-swap-behavior,omitempty"`
+	// RdtQoSClasses are the Intel RDT classes of service of containers in the pods of QoS classes, in the form of "qos=class".
+	RdtQoSClasses []string `json:"cri-rdt-qos-classes,omitempty"`
+	// EnableCPUSetManager specify whether to assign the cpuset of containers by the NUMA topology hints and exclusive cpus in annotations.
+	EnableCPUSetManager bool `json:"cri-enable-cpuset-manager,omitempty"`
+	// ReservedCPUs are the cpus never assigned to containers by the cpuset manager, e.g. "0-1".
+	ReservedCPUs string `json:"cri-reserved-cpus,omitempty"`
+	// TeardownConcurrency is the max number of containers stopped or removed concurrently in sandbox teardown.
+	TeardownConcurrency int `json:"cri-teardown-concurrency,omitempty"`
+	// ShutdownTimeout is the time duration (in time.Second) to wait for the in-flight cri requests to finish when pouchd is shut down.
+	ShutdownTimeout int `json:"cri-shutdown-timeout,omitempty"`
+	// SandboxDirQuota is the project quota of the directory of each sandbox, empty means no quota.
+	SandboxDirQuota string `json:"cri-sandbox-dir-quota,omitempty"`
+	// AuditLogPath is the path of the audit log of the mutating cri requests, empty means no audit log.
+	AuditLogPath string `json:"cri-audit-log-path,omitempty"`
+	// AuditLogMaxSize is the size the audit log is rotated at, e.g. 100m.
+	AuditLogMaxSize string `json:"cri-audit-log-max-size,omitempty"`
+	// AuditLogMaxFiles is the max number of audit log files kept, including the current one.
+	AuditLogMaxFiles int `json:"cri-audit-log-max-files,omitempty"`
+	// AuthzPolicyFile is the path of the json policy authorizing the mutating cri requests.
+	AuthzPolicyFile string `json:"cri-authz-policy-file,omitempty"`
+	// AuthzWebhook is the url of the webhook authorizing the mutating cri requests.
+	AuthzWebhook string `json:"cri-authz-webhook,omitempty"`
+	// AuthzWebhookTimeout is the time duration (in time.Second) to wait for the response of authorization webhook.
+	AuthzWebhookTimeout int `json:"cri-authz-webhook-timeout,omitempty"`
+	// AuthzWebhookFailClosed specify whether to reject the mutating cri requests if the authorization webhook fails.
+	AuthzWebhookFailClosed bool `json:"cri-authz-webhook-fail-closed,omitempty"`
+	// DebugDumpMethods are the cri methods whose payloads are dumped into the log with the secrets redacted, "*" means all.
+	DebugDumpMethods []string `json:"cri-debug-dump-methods,omitempty"`
+	// DebugDumpRate is the max number of debug dumps written per second.
+	DebugDumpRate int `json:"cri-debug-dump-rate,omitempty"`
+	// DisallowPrivileged specify whether to reject all the privileged containers.
+	DisallowPrivileged bool `json:"cri-disallow-privileged,omitempty"`
+	// PrivilegedNamespaces are the namespaces of pods allowed to run privileged containers.
+	PrivilegedNamespaces []string `json:"cri-privileged-namespaces,omitempty"`
+	// PrivilegedAnnotations are the annotations of pods allowed to run privileged containers, in the form of "key=value".
+	PrivilegedAnnotations []string `json:"cri-privileged-annotations,omitempty"`
+	// EnableLxcfs specify whether to enable lxcfs for the pods without the annotation io.kubernetes.lxcfs.enabled.
+	EnableLxcfs bool `json:"cri-enable-lxcfs,omitempty"`
+	// RuntimeOverheads are the overheads of runtime handlers reported in the sandbox status, in the form of "handler:resource=quantity".
+	RuntimeOverheads []string `json:"cri-runtime-overheads,omitempty"`
+	// RuntimeSnapshotters are the snapshotters of runtime handlers, in the form of "handler=snapshotter".
+	RuntimeSnapshotters []string `json:"cri-runtime-snapshotters,omitempty"`
+	// PassthroughAnnotations are the annotations of pods and containers copied into the OCI spec annotations, e.g. "io.katacontainers.*".
+	PassthroughAnnotations []string `json:"cri-passthrough-annotations,omitempty"`
+	// CNIArgsAnnotations are the annotations of pods appended to the CNI_ARGS, in the form of "annotation=arg".
+	CNIArgsAnnotations []string `json:"cri-cni-args-annotations,omitempty"`
+	// CNICapabilityAnnotations are the annotations of pods passed as the capability args of CNI plugins, in the form of "annotation=capability".
+	CNICapabilityAnnotations []string `json:"cri-cni-caps-annotations,omitempty"`
+}
+
+// Reload updates the fields which could be changed without restarting pouchd
+// with the ones set in the new config, the ones omitted are reset to the defaults.
+// It returns the names of the fields changed.
+func (c *Config) Reload(newCfg, defaults *Config) []string {
+	var changed []string
+
+	sandboxImage := newCfg.SandboxImage
+	if sandboxImage == "" {
+		sandboxImage = defaults.SandboxImage
+	}
+	if sandboxImage != c.SandboxImage {
+		c.SandboxImage = sandboxImage
+		changed = append(changed, "sandbox-image")
+	}
+
+	period := newCfg.CriStatsCollectPeriod
+	if period <= 0 {
+		period = defaults.CriStatsCollectPeriod
+	}
+	if period != c.CriStatsCollectPeriod {
+		c.CriStatsCollectPeriod = period
+		changed = append(changed, "cri-stats-collect-period")
+	}
+
+	timeout := newCfg.StreamIdleTimeout
+	if timeout <= 0 {
+		timeout = defaults.StreamIdleTimeout
+	}
+	if timeout != c.StreamIdleTimeout {
+		c.StreamIdleTimeout = timeout
+		changed = append(changed, "stream-idle-timeout")
+	}
+	return changed
 }
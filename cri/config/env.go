@@ -0,0 +1,47 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// EnvInjection is the env injected into the cri containers matching the
+// selectors, e.g. HTTP_PROXY and NO_PROXY of the egress proxy.
+type EnvInjection struct {
+	// Env are the env injected in the form of key=value, the ones set by the
+	// container are kept.
+	Env []string `json:"env"`
+	// Labels are the labels of pod or container the containers must have.
+	Labels map[string]string `json:"labels,omitempty"`
+	// Annotations are the annotations of pod or container the containers must have.
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// Validate validates the env of injection.
+func (e *EnvInjection) Validate() error {
+	if len(e.Env) == 0 {
+		return fmt.Errorf("env of env injection cannot be empty")
+	}
+	for _, env := range e.Env {
+		if parts := strings.SplitN(env, "=", 2); len(parts) != 2 || parts[0] == "" {
+			return fmt.Errorf("invalid env %q of env injection, should be key=value", env)
+		}
+	}
+	return nil
+}
+
+// Match returns whether the container with the labels and annotations, which
+// are the ones of container merged with the ones of pod, matches the selectors.
+func (e *EnvInjection) Match(labels, annotations map[string]string) bool {
+	for k, v := range e.Labels {
+		if value, ok := labels[k]; !ok || value != v {
+			return false
+		}
+	}
+	for k, v := range e.Annotations {
+		if value, ok := annotations[k]; !ok || value != v {
+			return false
+		}
+	}
+	return true
+}
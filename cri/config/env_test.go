@@ -0,0 +1,14 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnvInjectionValidate(t *testing.T) {
+	assert.NoError(t, (&EnvInjection{Env: []string{"HTTP_PROXY=http://proxy:3128", "EMPTY="}}).Validate())
+	assert.Error(t, (&EnvInjection{}).Validate())
+	assert.Error(t, (&EnvInjection{Env: []string{"HTTP_PROXY"}}).Validate())
+	assert.Error(t, (&EnvInjection{Env: []string{"=value"}}).Validate())
+}
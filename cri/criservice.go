@@ -6,13 +6,14 @@ import (
 	"github.com/alibaba/pouch/cri/stream"
 	criv1alpha2 "github.com/alibaba/pouch/cri/v1alpha2"
 	"github.com/alibaba/pouch/daemon/config"
+	"github.com/alibaba/pouch/daemon/events"
 	"github.com/alibaba/pouch/daemon/mgr"
 	"github.com/alibaba/pouch/hookplugins"
 	"github.com/alibaba/pouch/pkg/log"
 )
 
 // RunCriService start cri service if pouchd is specified with --enable-cri.
-func RunCriService(daemonconfig *config.Config, containerMgr mgr.ContainerMgr, imageMgr mgr.ImageMgr, volumeMgr mgr.VolumeMgr, criPlugin hookplugins.CriPlugin, streamRouterCh chan stream.Router, stopCh chan error, readyCh chan bool) {
+func RunCriService(daemonconfig *config.Config, containerMgr mgr.ContainerMgr, imageMgr mgr.ImageMgr, volumeMgr mgr.VolumeMgr, criPlugin hookplugins.CriPlugin, eventsService *events.Events, streamRouterCh chan stream.Router, criMgrCh chan criv1alpha2.CriMgr, stopCh chan error, readyCh chan bool) {
 	var err error
 
 	defer func() {
@@ -22,25 +23,28 @@ func RunCriService(daemonconfig *config.Config, containerMgr mgr.ContainerMgr, i
 	if !daemonconfig.IsCriEnabled {
 		// the CriService has been disabled, so send Ready and empty Stream Router
 		streamRouterCh <- nil
+		criMgrCh <- nil
 		readyCh <- true
 		return
 	}
 	switch daemonconfig.CriConfig.CriVersion {
 	case "v1alpha2":
-		err = runv1alpha2(daemonconfig, containerMgr, imageMgr, volumeMgr, criPlugin, streamRouterCh, readyCh)
+		err = runv1alpha2(daemonconfig, containerMgr, imageMgr, volumeMgr, criPlugin, eventsService, streamRouterCh, criMgrCh, readyCh)
 	default:
 		streamRouterCh <- nil
+		criMgrCh <- nil
 		readyCh <- false
 		err = fmt.Errorf("failed to start CRI service: invalid CRI version %s, expected to be v1alpha2", daemonconfig.CriConfig.CriVersion)
 	}
 }
 
 // Start CRI service with CRI version: v1alpha2
-func runv1alpha2(daemonconfig *config.Config, containerMgr mgr.ContainerMgr, imageMgr mgr.ImageMgr, volumeMgr mgr.VolumeMgr, criPlugin hookplugins.CriPlugin, streamRouterCh chan stream.Router, readyCh chan bool) error {
+func runv1alpha2(daemonconfig *config.Config, containerMgr mgr.ContainerMgr, imageMgr mgr.ImageMgr, volumeMgr mgr.VolumeMgr, criPlugin hookplugins.CriPlugin, eventsService *events.Events, streamRouterCh chan stream.Router, criMgrCh chan criv1alpha2.CriMgr, readyCh chan bool) error {
 	log.With(nil).Infof("Start CRI service with CRI version: v1alpha2")
-	criMgr, err := criv1alpha2.NewCriManager(daemonconfig, containerMgr, imageMgr, volumeMgr, criPlugin)
+	criMgr, err := criv1alpha2.NewCriManager(daemonconfig, containerMgr, imageMgr, volumeMgr, criPlugin, eventsService)
 	if err != nil {
 		streamRouterCh <- nil
+		criMgrCh <- nil
 		readyCh <- false
 		return fmt.Errorf("failed to get CriManager with error: %v", err)
 	}
@@ -48,6 +52,7 @@ func runv1alpha2(daemonconfig *config.Config, containerMgr mgr.ContainerMgr, ima
 	service, err := criv1alpha2.NewService(daemonconfig, criMgr)
 	if err != nil {
 		streamRouterCh <- nil
+		criMgrCh <- nil
 		readyCh <- false
 		return fmt.Errorf("failed to start CRI service with error: %v", err)
 	}
@@ -65,6 +70,7 @@ func runv1alpha2(daemonconfig *config.Config, containerMgr mgr.ContainerMgr, ima
 		}()
 		streamRouterCh <- nil
 	}
+	criMgrCh <- criMgr
 
 	go func() {
 		errChan <- service.Serve()
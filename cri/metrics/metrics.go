@@ -5,6 +5,7 @@ import (
 
 	"github.com/alibaba/pouch/pkg/utils/metrics"
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 func init() {
@@ -67,6 +68,31 @@ var (
 
 	// RuntimeActionsTimer records the time cost of each runtime action.
 	RuntimeActionsTimer = metrics.NewLabelTimer(subsystemCRI, "runtime_actions", "The number of seconds it takes to process each runtime action", "action")
+
+	// StreamSessionsGauge records the number of active stream sessions.
+	StreamSessionsGauge = metrics.NewLabelGauge(subsystemCRI, "stream_active_sessions", "The number of active stream sessions", "type")
+
+	// StreamSessionsCounter records the number of stream sessions.
+	StreamSessionsCounter = metrics.NewLabelCounter(subsystemCRI, "stream_sessions_counter", "The number of stream sessions", "type")
+
+	// StreamBytesCounter records the bytes transferred by stream sessions, the direction is
+	// "in" for the bytes from client to container, and "out" for the reverse.
+	StreamBytesCounter = metrics.NewLabelCounter(subsystemCRI, "stream_bytes_counter", "The bytes transferred by stream sessions", "type", "direction")
+
+	// StreamSessionTimer records the duration of stream sessions, from 1 second to about 3 days.
+	StreamSessionTimer = metrics.NewLabelTimerWithBuckets(subsystemCRI, "stream_session", "The number of seconds each stream session lasts", prometheus.ExponentialBuckets(1, 4, 10), "type")
+
+	// ThrottleQueuedGauge records the number of requests queued by the method throttle.
+	ThrottleQueuedGauge = metrics.NewLabelGauge(subsystemCRI, "throttle_queued_requests", "The number of requests queued by the method throttle", "method")
+
+	// ThrottleRejectedCounter records the number of requests canceled while queued by the method throttle.
+	ThrottleRejectedCounter = metrics.NewLabelCounter(subsystemCRI, "throttle_rejected_requests_counter", "The number of requests canceled while queued by the method throttle", "method")
+
+	// ThrottleWaitTimer records the time requests wait in queue of the method throttle.
+	ThrottleWaitTimer = metrics.NewLabelTimer(subsystemCRI, "throttle_wait", "The number of seconds each request waits in queue of the method throttle", "method")
+
+	// StatsCacheCounter records the number of lookups of the stats cache, the result is "hit" or "miss".
+	StatsCacheCounter = metrics.NewLabelCounter(subsystemCRI, "stats_cache_counter", "The number of lookups of the stats cache", "result")
 )
 
 var registerMetrics sync.Once
@@ -93,6 +119,14 @@ func Register() {
 		registry.MustRegister(RuntimeActionsCounter)
 		registry.MustRegister(RuntimeSuccessActionsCounter)
 		registry.MustRegister(RuntimeActionsTimer)
+		registry.MustRegister(StreamSessionsGauge)
+		registry.MustRegister(StreamSessionsCounter)
+		registry.MustRegister(StreamBytesCounter)
+		registry.MustRegister(StreamSessionTimer)
+		registry.MustRegister(ThrottleQueuedGauge)
+		registry.MustRegister(ThrottleRejectedCounter)
+		registry.MustRegister(ThrottleWaitTimer)
+		registry.MustRegister(StatsCacheCounter)
 		registry.MustRegister(GRPCMetrics)
 	})
 }
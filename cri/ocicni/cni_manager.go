@@ -9,22 +9,43 @@ import (
 	"sync"
 
 	"github.com/alibaba/pouch/cri/config"
+	"github.com/alibaba/pouch/pkg/kmutex"
 	"github.com/alibaba/pouch/pkg/log"
 
-	cnicurrent "github.com/containernetworking/cni/pkg/types/current"
+	"github.com/containernetworking/cni/libcni"
+	"github.com/containernetworking/plugins/pkg/ns"
 	"github.com/cri-o/ocicni/pkg/ocicni"
 	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
 )
 
 // CniManager is an implementation of interface CniMgr.
 type CniManager struct {
+	// RWMutex is held for reading across the CNI calls, so that the networks are
+	// never replaced while a pod is being attached to or detached from them.
 	sync.RWMutex
-	// plugin is used to setup and teardown network when run/stop pod sandbox.
-	plugin ocicni.CNIPlugin
+	// networks are the CNI networks to setup and teardown when run/stop pod sandbox.
+	networks *cniNetworks
+	// podLocks serialize the CNI calls of the same sandbox.
+	podLocks *kmutex.KMutex
+	// cniConfig invokes the CNI plugins in the binary directory.
+	cniConfig *libcni.CNIConfig
 	// runtimeConfigFile is a file to make the runtime config persistent.
 	runtimeConfigFile string
 	// defaultRuntimeConfig is configuration specific to the default pod network interface.
-	defaultRuntimeConfig ocicni.RuntimeConfig
+	defaultRuntimeConfig RuntimeConfig
+	// networkPluginConfDir is the directory in which the admin places a CNI conf.
+	networkPluginConfDir string
+	// networkPluginBinDir is the directory in which the binaries for the plugin is kept.
+	networkPluginBinDir string
+	// netPriority classifies the traffic of sandboxes by net priority, nil if disabled.
+	netPriority *netPriorityClasses
+	// conntrackCleanup specify whether to remove the conntrack entries of sandbox on teardown.
+	conntrackCleanup bool
+	// networkPolicy specify whether to enforce the network policy of sandbox in annotations.
+	networkPolicy bool
+	// ipProviders report the ips of the pods allocated by other network backends.
+	ipProviders []IPProvider
 }
 
 // NewCniManager initializes a brand new cni manager.
@@ -38,7 +59,7 @@ func NewCniManager(cfg *config.Config) (CniMgr, error) {
 	}
 
 	// load runtime config
-	runtimeConfig := ocicni.RuntimeConfig{}
+	runtimeConfig := RuntimeConfig{}
 
 	data, err := ioutil.ReadFile(cfg.RuntimeConfigFile)
 	if err != nil && !os.IsNotExist(err) {
@@ -52,72 +73,104 @@ func NewCniManager(cfg *config.Config) (CniMgr, error) {
 		}
 	}
 
-	// If defaultNetName is empty, a network name will be automatically selected be used as the default CNI network.
-	plugin, err := ocicni.InitCNI("", networkPluginConfDir, networkPluginBinDir)
+	// The network loaded first is used as the default CNI network.
+	networks, err := loadNetworks(networkPluginConfDir)
 	if err != nil {
 		return nil, err
 	}
 
-	return &CniManager{
-		plugin:               plugin,
+	c := &CniManager{
+		networks:             networks,
+		podLocks:             kmutex.New(),
+		cniConfig:            libcni.NewCNIConfig([]string{networkPluginBinDir}, nil),
 		defaultRuntimeConfig: runtimeConfig,
 		runtimeConfigFile:    cfg.RuntimeConfigFile,
-	}, nil
+		networkPluginConfDir: networkPluginConfDir,
+		networkPluginBinDir:  networkPluginBinDir,
+		conntrackCleanup:     cfg.EnableConntrackCleanup,
+		networkPolicy:        cfg.EnableNetworkPolicy,
+		ipProviders:          []IPProvider{passthruIPProvider{}},
+	}
+
+	if cfg.NetPriorityDevice != "" {
+		shares, err := ParseNetPriorityShares(cfg.NetPriorityShares)
+		if err != nil {
+			return nil, err
+		}
+		if c.netPriority, err = newNetPriorityClasses(cfg.NetPriorityDevice, cfg.NetPriorityBandwidth, shares); err != nil {
+			return nil, err
+		}
+	}
+
+	// Watch the CNI configuration directory, so that deploying the CNI
+	// configuration after pouchd has started takes effect without restart.
+	if err := c.watchConfDir(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// getNetworks returns the CNI networks currently in use.
+func (c *CniManager) getNetworks() *cniNetworks {
+	c.RLock()
+	defer c.RUnlock()
+	return c.networks
 }
 
 // Name returns the plugin's name. This will be used when searching
 // for a plugin by name, e.g.
 func (c *CniManager) Name() string {
-	return c.plugin.Name()
+	return ocicni.CNIPluginName
 }
 
 // GetDefaultNetworkName returns the name of the plugin's default network.
 func (c *CniManager) GetDefaultNetworkName() string {
-	return c.plugin.GetDefaultNetworkName()
+	return c.getNetworks().defaultNetName
 }
 
 // SetUpPodNetwork is the method called after the sandbox container of the
 // pod has been created but before the other containers of the pod
-// are launched.
-func (c *CniManager) SetUpPodNetwork(podNetwork *ocicni.PodNetwork) error {
+// are launched. It returns the results of CNI ADD for every attached network.
+func (c *CniManager) SetUpPodNetwork(podNetwork *PodNetwork) ([]*NetworkResult, error) {
+	c.podLocks.Lock(podNetwork.ID)
+	defer c.podLocks.Unlock(podNetwork.ID)
 	c.RLock()
-	c.updateDefaultRuntimeConfig(podNetwork)
-	c.RUnlock()
-
-	_, err := c.plugin.SetUpPod(*podNetwork)
+	defer c.RUnlock()
 
-	defer func() {
-		if err != nil {
-			// Teardown network if an error returned.
-			err := c.plugin.TearDownPod(*podNetwork)
-			if err != nil {
-				log.With(nil).Errorf("failed to destroy network for sandbox %q: %v", podNetwork.ID, err)
-			}
-		}
-	}()
+	c.updateDefaultRuntimeConfig(podNetwork)
+	networks, err := c.networks.podNetworks(podNetwork)
+	if err != nil {
+		return nil, fmt.Errorf("failed to setup network for sandbox %q: %v", podNetwork.ID, err)
+	}
 
+	results, err := c.addToNetworks(networks, podNetwork)
 	if err != nil {
-		return fmt.Errorf("failed to setup network for sandbox %q: %v", podNetwork.ID, err)
+		// Teardown network if an error returned.
+		if err := c.deleteFromNetworks(networks, podNetwork, results); err != nil {
+			log.With(nil).Errorf("failed to destroy network for sandbox %q: %v", podNetwork.ID, err)
+		}
+		return nil, fmt.Errorf("failed to setup network for sandbox %q: %v", podNetwork.ID, err)
 	}
 
-	return nil
+	return results, nil
 }
 
 // updateDefaultRuntimeConfig set some config of the pod default network interface.
 // only set podCIDR now.
-func (c *CniManager) updateDefaultRuntimeConfig(podNetwork *ocicni.PodNetwork) {
+func (c *CniManager) updateDefaultRuntimeConfig(podNetwork *PodNetwork) {
 	if len(c.defaultRuntimeConfig.IpRanges) == 0 {
 		return
 	}
 
 	if podNetwork.RuntimeConfig == nil {
-		podNetwork.RuntimeConfig = make(map[string]ocicni.RuntimeConfig)
+		podNetwork.RuntimeConfig = make(map[string]RuntimeConfig)
 	}
 
-	defaultNetworkName := c.GetDefaultNetworkName()
+	defaultNetworkName := c.networks.defaultNetName
 
 	if _, exist := podNetwork.RuntimeConfig[defaultNetworkName]; !exist {
-		podNetwork.RuntimeConfig[defaultNetworkName] = ocicni.RuntimeConfig{}
+		podNetwork.RuntimeConfig[defaultNetworkName] = RuntimeConfig{}
 	}
 
 	if len(podNetwork.RuntimeConfig[defaultNetworkName].IpRanges) == 0 {
@@ -128,14 +181,31 @@ func (c *CniManager) updateDefaultRuntimeConfig(podNetwork *ocicni.PodNetwork) {
 }
 
 // TearDownPodNetwork is the method called before a pod's sandbox container will be deleted.
-func (c *CniManager) TearDownPodNetwork(podNetwork *ocicni.PodNetwork) error {
+// The prevResults are the results of CNI ADD which will be passed to CNI DEL.
+func (c *CniManager) TearDownPodNetwork(podNetwork *PodNetwork, prevResults []*NetworkResult) error {
+	// detach from the networks attached to, even if the default network changed.
+	if len(podNetwork.Networks) == 0 {
+		for _, r := range prevResults {
+			if r != nil && r.Network != "" {
+				podNetwork.Networks = append(podNetwork.Networks, r.Network)
+			}
+		}
+	}
+
+	c.podLocks.Lock(podNetwork.ID)
+	defer c.podLocks.Unlock(podNetwork.ID)
 	c.RLock()
+	defer c.RUnlock()
+
 	c.updateDefaultRuntimeConfig(podNetwork)
-	c.RUnlock()
+	networks, err := c.networks.podNetworks(podNetwork)
+	if err != nil {
+		return errors.Wrapf(err, "failed to destroy network for sandbox %q", podNetwork.ID)
+	}
 
 	// perform the teardown network operation whatever to
 	// give CNI Plugin a chance to perform some operations
-	err := c.plugin.TearDownPod(*podNetwork)
+	err = c.deleteFromNetworks(networks, podNetwork, prevResults)
 	if err == nil {
 		return nil
 	}
@@ -152,38 +222,44 @@ func (c *CniManager) TearDownPodNetwork(podNetwork *ocicni.PodNetwork) error {
 }
 
 // GetPodNetworkStatus is the method called to obtain the ipv4 or ipv6 addresses of the pod sandbox.
+// The address is read from the interface of the default network, i.e. eth0.
 func (c *CniManager) GetPodNetworkStatus(netnsPath string) (string, error) {
-	// TODO: we need more validation tests.
-	podNetwork := ocicni.PodNetwork{
-		NetNS: netnsPath,
-	}
-
-	var err error
-	results, err := c.plugin.GetPodNetworkStatus(podNetwork)
+	var ip net.IP
+	err := ns.WithNetNSPath(netnsPath, func(ns.NetNS) error {
+		link, err := netlink.LinkByName("eth0")
+		if err != nil {
+			return err
+		}
+		for _, family := range []int{netlink.FAMILY_V4, netlink.FAMILY_V6} {
+			addrs, err := netlink.AddrList(link, family)
+			if err != nil {
+				return err
+			}
+			for _, addr := range addrs {
+				if addr.Scope == int(netlink.SCOPE_UNIVERSE) {
+					ip = addr.IP
+					return nil
+				}
+			}
+		}
+		return nil
+	})
 	if err != nil {
 		return "", fmt.Errorf("failed to get pod network status: %v", err)
 	}
 
-	if len(results) == 0 {
-		return "", fmt.Errorf("failed to get pod network status for nil result")
-	}
-
-	result, ok := results[0].(*cnicurrent.Result)
-	if !ok {
-		return "", fmt.Errorf("failed to get pod network status for wrong result: %+v", results[0])
-	}
-
-	if len(result.IPs) == 0 {
+	if ip == nil {
 		return "", fmt.Errorf("failed to get pod network status for nil IP")
 	}
-
-	ip := result.IPs[0].Address.IP.String()
-	return ip, nil
+	return ip.String(), nil
 }
 
 // Status returns error if the network plugin is in error state.
 func (c *CniManager) Status() error {
-	return c.plugin.Status()
+	if c.GetDefaultNetworkName() == "" {
+		return errMissingDefaultNetwork
+	}
+	return nil
 }
 
 const (
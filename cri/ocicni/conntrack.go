@@ -0,0 +1,81 @@
+package ocicni
+
+import (
+	"net"
+
+	"github.com/alibaba/pouch/pkg/log"
+
+	"github.com/cri-o/ocicni/pkg/ocicni"
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+)
+
+// podConntrackFilter matches the conntrack entries of a pod sandbox.
+type podConntrackFilter struct {
+	// ips are the addresses of the pod.
+	ips []net.IP
+	// udpHostPorts are the udp host ports of the pod.
+	udpHostPorts map[uint16]bool
+}
+
+// newPodConntrackFilter builds the filter from the network results and port mappings of pod.
+func newPodConntrackFilter(results []*NetworkResult, portMappings []ocicni.PortMapping) *podConntrackFilter {
+	f := &podConntrackFilter{
+		udpHostPorts: make(map[uint16]bool),
+	}
+	for _, r := range results {
+		if r == nil || r.Result == nil {
+			continue
+		}
+		for _, ip := range r.Result.IPs {
+			if ip != nil && ip.Address.IP != nil {
+				f.ips = append(f.ips, ip.Address.IP)
+			}
+		}
+	}
+	for _, pm := range portMappings {
+		if pm.Protocol == "udp" && pm.HostPort > 0 {
+			f.udpHostPorts[uint16(pm.HostPort)] = true
+		}
+	}
+	return f
+}
+
+// MatchConntrackFlow returns true if the flow is from or to the pod, or if it is
+// destined to the udp host port of pod. The udp flows to the host port are kept
+// alive by the continuous traffic of client, which would never hit the port
+// mapping of the next pod using the host port if they are not removed.
+func (f *podConntrackFilter) MatchConntrackFlow(flow *netlink.ConntrackFlow) bool {
+	for _, ip := range f.ips {
+		if ip.Equal(flow.Forward.SrcIP) || ip.Equal(flow.Forward.DstIP) ||
+			ip.Equal(flow.Reverse.SrcIP) || ip.Equal(flow.Reverse.DstIP) {
+			return true
+		}
+	}
+	return flow.Forward.Protocol == netlink.UDP_PROTO && f.udpHostPorts[flow.Forward.DstPort]
+}
+
+// FlushConntrack removes the conntrack entries of pod sandbox, so that the stale
+// entries would not blackhole the traffic when the addresses or host ports are reused.
+// It's a no-op if the conntrack cleanup is not enabled.
+func (c *CniManager) FlushConntrack(results []*NetworkResult, portMappings []ocicni.PortMapping) error {
+	if !c.conntrackCleanup {
+		return nil
+	}
+
+	filter := newPodConntrackFilter(results, portMappings)
+	if len(filter.ips) == 0 && len(filter.udpHostPorts) == 0 {
+		return nil
+	}
+
+	for _, family := range []int{netlink.FAMILY_V4, netlink.FAMILY_V6} {
+		n, err := netlink.ConntrackDeleteFilter(netlink.ConntrackTable, netlink.InetFamily(family), filter)
+		if err != nil {
+			return errors.Wrap(err, "failed to delete conntrack entries")
+		}
+		if n > 0 {
+			log.With(nil).Debugf("deleted %d conntrack entries of addresses %v", n, filter.ips)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package ocicni
+
+import (
+	"net"
+	"testing"
+
+	"github.com/cri-o/ocicni/pkg/ocicni"
+	"github.com/stretchr/testify/assert"
+	"github.com/vishvananda/netlink"
+)
+
+func TestPodConntrackFilter(t *testing.T) {
+	filter := newPodConntrackFilter(
+		[]*NetworkResult{{Network: "default", Result: newTestResult("10.0.0.2/24")}},
+		[]ocicni.PortMapping{
+			{HostPort: 5353, ContainerPort: 53, Protocol: "udp"},
+			{HostPort: 8080, ContainerPort: 80, Protocol: "tcp"},
+		},
+	)
+
+	newFlow := func(proto uint8, src, dst string, dport uint16, rsrc, rdst string) *netlink.ConntrackFlow {
+		flow := &netlink.ConntrackFlow{}
+		flow.Forward.Protocol = proto
+		flow.Forward.SrcIP = net.ParseIP(src)
+		flow.Forward.DstIP = net.ParseIP(dst)
+		flow.Forward.DstPort = dport
+		flow.Reverse.Protocol = proto
+		flow.Reverse.SrcIP = net.ParseIP(rsrc)
+		flow.Reverse.DstIP = net.ParseIP(rdst)
+		return flow
+	}
+
+	for _, tc := range []struct {
+		flow     *netlink.ConntrackFlow
+		expected bool
+	}{
+		// from pod.
+		{newFlow(netlink.TCP_PROTO, "10.0.0.2", "8.8.8.8", 443, "8.8.8.8", "192.168.0.1"), true},
+		// to pod via dnat.
+		{newFlow(netlink.TCP_PROTO, "1.1.1.1", "192.168.0.1", 8080, "10.0.0.2", "1.1.1.1"), true},
+		// udp host port not yet dnat to pod.
+		{newFlow(netlink.UDP_PROTO, "1.1.1.1", "192.168.0.1", 5353, "192.168.0.1", "1.1.1.1"), true},
+		// tcp host port is left.
+		{newFlow(netlink.TCP_PROTO, "1.1.1.1", "192.168.0.1", 8080, "192.168.0.1", "1.1.1.1"), false},
+		// unrelated.
+		{newFlow(netlink.UDP_PROTO, "10.0.0.3", "8.8.8.8", 53, "8.8.8.8", "10.0.0.3"), false},
+	} {
+		assert.Equal(t, tc.expected, filter.MatchConntrackFlow(tc.flow), "flow %s", tc.flow)
+	}
+}
@@ -0,0 +1,102 @@
+package ocicni
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+)
+
+// NetworkDevice is a host network device, such as a SR-IOV VF or a host NIC,
+// which is moved into the network namespace of sandbox.
+type NetworkDevice struct {
+	// HostIfName is the name of device on host.
+	HostIfName string `json:"hostIfName"`
+	// ContainerIfName is the name of device inside the sandbox.
+	ContainerIfName string `json:"containerIfName"`
+}
+
+// AttachDevice moves the host network device into the network namespace,
+// renames it to the container interface name and sets it up.
+func (c *CniManager) AttachDevice(netnsPath string, device *NetworkDevice) error {
+	link, err := netlink.LinkByName(device.HostIfName)
+	if err != nil {
+		return errors.Wrapf(err, "failed to find device %s", device.HostIfName)
+	}
+
+	netns, err := ns.GetNS(netnsPath)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get netns %s", netnsPath)
+	}
+	defer netns.Close()
+
+	if err := netlink.LinkSetDown(link); err != nil {
+		return errors.Wrapf(err, "failed to set device %s down", device.HostIfName)
+	}
+	if err := netlink.LinkSetNsFd(link, int(netns.Fd())); err != nil {
+		// the device stays on host, restore it up if it was.
+		if link.Attrs().Flags&net.FlagUp != 0 {
+			if err := netlink.LinkSetUp(link); err != nil {
+				return errors.Wrapf(err, "failed to set device %s up again", device.HostIfName)
+			}
+		}
+		return errors.Wrapf(err, "failed to move device %s into netns %s", device.HostIfName, netnsPath)
+	}
+
+	return netns.Do(func(hostNS ns.NetNS) error {
+		link, err := netlink.LinkByName(device.HostIfName)
+		if err != nil {
+			return errors.Wrapf(err, "failed to find device %s in netns %s", device.HostIfName, netnsPath)
+		}
+
+		if device.ContainerIfName != "" && device.ContainerIfName != device.HostIfName {
+			if err := netlink.LinkSetName(link, device.ContainerIfName); err != nil {
+				// move the device back to host if failed to rename it.
+				if err := netlink.LinkSetNsFd(link, int(hostNS.Fd())); err != nil {
+					return errors.Wrapf(err, "failed to restore device %s to host", device.HostIfName)
+				}
+				return errors.Wrapf(err, "failed to rename device %s to %s", device.HostIfName, device.ContainerIfName)
+			}
+		}
+
+		return netlink.LinkSetUp(link)
+	})
+}
+
+// DetachDevice moves the network device inside the network namespace back to host,
+// and restores its name on host.
+func (c *CniManager) DetachDevice(netnsPath string, device *NetworkDevice) error {
+	ifName := device.ContainerIfName
+	if ifName == "" {
+		ifName = device.HostIfName
+	}
+
+	err := ns.WithNetNSPath(netnsPath, func(hostNS ns.NetNS) error {
+		link, err := netlink.LinkByName(ifName)
+		if err != nil {
+			return errors.Wrapf(err, "failed to find device %s in netns %s", ifName, netnsPath)
+		}
+
+		if err := netlink.LinkSetDown(link); err != nil {
+			return errors.Wrapf(err, "failed to set device %s down", ifName)
+		}
+		if ifName != device.HostIfName {
+			if err := netlink.LinkSetName(link, device.HostIfName); err != nil {
+				return errors.Wrapf(err, "failed to rename device %s to %s", ifName, device.HostIfName)
+			}
+		}
+
+		return netlink.LinkSetNsFd(link, int(hostNS.Fd()))
+	})
+	if err != nil {
+		// the device has been returned to host if the netns is gone.
+		if _, ok := err.(ns.NSPathNotExistErr); ok {
+			return nil
+		}
+		return fmt.Errorf("failed to restore device %s to host: %v", device.HostIfName, err)
+	}
+
+	return nil
+}
@@ -14,18 +14,30 @@ type CniMgr interface {
 
 	// SetUpPodNetwork is the method called after the sandbox container of the
 	// pod has been created but before the other containers of the pod
-	// are launched.
-	SetUpPodNetwork(podNetwork *ocicni.PodNetwork) error
+	// are launched. It returns the results of CNI ADD for every attached network.
+	SetUpPodNetwork(podNetwork *PodNetwork) ([]*NetworkResult, error)
 
 	// TearDownPodNetwork is the method called before a pod's sandbox container will be deleted.
-	TearDownPodNetwork(podNetwork *ocicni.PodNetwork) error
+	// The prevResults are the results of CNI ADD which will be passed to CNI DEL.
+	TearDownPodNetwork(podNetwork *PodNetwork, prevResults []*NetworkResult) error
 
 	// GetPodNetworkStatus is the method called to obtain the ipv4 or ipv6 addresses of the pod sandbox.
 	GetPodNetworkStatus(netnsPath string) (string, error)
 
+	// RegisterIPProvider registers the provider of the pod ips allocated by other network backends.
+	RegisterIPProvider(provider IPProvider) error
+
+	// ProviderPodIP returns the pod ip reported by the first provider managing the sandbox,
+	// ok is false if none of the providers manages it.
+	ProviderPodIP(pod *PodIPRequest) (ip string, ok bool, err error)
+
 	// Status returns error if the network plugin is in error state.
 	Status() error
 
+	// NetworkInfos returns the CNI networks in the configuration directory with
+	// their plugin types, versions and checksums.
+	NetworkInfos() ([]*NetworkInfo, error)
+
 	// Event handle the changes of CNI.
 	Event(subject string, detail interface{}) error
 
@@ -33,6 +45,9 @@ type CniMgr interface {
 	// namespace path, without switching to it
 	NewNetNS() (string, error)
 
+	// ListNetNS returns the paths of the persistent network namespaces created by NewNetNS.
+	ListNetNS() ([]string, error)
+
 	// RemoveNetNS unmounts the network namespace
 	RemoveNetNS(path string) error
 
@@ -43,4 +58,26 @@ type CniMgr interface {
 	// RecoverNetNS recreate a persistent network namespace if the ns is not exists.
 	// Otherwise, do nothing.
 	RecoverNetNS(path string) error
+
+	// AdoptNetNS makes the network namespace of the given path persistent, and
+	// returns the path of the persistent one.
+	AdoptNetNS(path string) (string, error)
+
+	// AttachDevice moves the host network device into the network namespace.
+	AttachDevice(netnsPath string, device *NetworkDevice) error
+
+	// DetachDevice moves the network device inside the network namespace back to host.
+	DetachDevice(netnsPath string, device *NetworkDevice) error
+
+	// SetNetPriority classifies the traffic of the sandbox according to the net priority.
+	SetNetPriority(results []*NetworkResult, priority int64) error
+
+	// ClearNetPriority removes the classification of the traffic of the sandbox.
+	ClearNetPriority(results []*NetworkResult) error
+
+	// FlushConntrack removes the conntrack entries of the pod addresses and udp host ports.
+	FlushConntrack(results []*NetworkResult, portMappings []ocicni.PortMapping) error
+
+	// ApplyNetworkPolicy programs the network policy of sandbox in the network namespace.
+	ApplyNetworkPolicy(netnsPath string, policy *NetworkPolicy) error
 }
@@ -0,0 +1,82 @@
+package ocicni
+
+import (
+	"fmt"
+
+	anno "github.com/alibaba/pouch/cri/annotations"
+)
+
+// IPProvider reports the ips of the pods allocated by the network backends
+// other than the CNI plugins, e.g. ENI or the agents of VPC CNI, which are
+// reported in the status of sandbox instead of the address in its netns.
+type IPProvider interface {
+	// Name returns the unique name of the provider.
+	Name() string
+
+	// PodIP returns the ip of the sandbox, ok is false if the sandbox is not
+	// managed by the provider.
+	PodIP(pod *PodIPRequest) (ip string, ok bool, err error)
+}
+
+// PodIPRequest describes the sandbox whose ip is queried.
+type PodIPRequest struct {
+	// ID is the id of the sandbox.
+	ID string
+	// Annotations are the annotations of the sandbox.
+	Annotations map[string]string
+	// NetNS is the path of the network namespace, empty in the network of node.
+	NetNS string
+	// Results are the persisted results of CNI ADD.
+	Results []*NetworkResult
+}
+
+// RegisterIPProvider registers the provider of pod ips, the providers are
+// queried in the order of registration.
+func (c *CniManager) RegisterIPProvider(provider IPProvider) error {
+	c.Lock()
+	defer c.Unlock()
+
+	for _, p := range c.ipProviders {
+		if p.Name() == provider.Name() {
+			return fmt.Errorf("ip provider %q is already registered", provider.Name())
+		}
+	}
+	c.ipProviders = append(c.ipProviders, provider)
+	return nil
+}
+
+// ProviderPodIP returns the ip of the sandbox reported by the first provider
+// managing it, ok is false if none of the providers manages the sandbox.
+func (c *CniManager) ProviderPodIP(pod *PodIPRequest) (string, bool, error) {
+	c.RLock()
+	providers := c.ipProviders
+	c.RUnlock()
+
+	for _, p := range providers {
+		ip, ok, err := p.PodIP(pod)
+		if err != nil {
+			return "", true, fmt.Errorf("failed to get ip of sandbox %q from provider %s: %v", pod.ID, p.Name(), err)
+		}
+		if ok {
+			return ip, true, nil
+		}
+	}
+	return "", false, nil
+}
+
+// passthruIPProvider reports the ip of the interface passed through to the VM
+// of the sandbox, which is specified in the annotations.
+type passthruIPProvider struct{}
+
+// Name returns the name of passthru provider.
+func (passthruIPProvider) Name() string {
+	return "vm-passthru"
+}
+
+// PodIP returns the ip in the annotation if the interface is passed through.
+func (passthruIPProvider) PodIP(pod *PodIPRequest) (string, bool, error) {
+	if pod.Annotations[anno.PassthruKey] != "true" {
+		return "", false, nil
+	}
+	return pod.Annotations[anno.PassthruIP], true, nil
+}
@@ -0,0 +1,61 @@
+package ocicni
+
+import (
+	"fmt"
+	"testing"
+
+	anno "github.com/alibaba/pouch/cri/annotations"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeIPProvider manages the sandboxes with the ips in the map.
+type fakeIPProvider struct {
+	name string
+	ips  map[string]string
+	err  error
+}
+
+func (f *fakeIPProvider) Name() string {
+	return f.name
+}
+
+func (f *fakeIPProvider) PodIP(pod *PodIPRequest) (string, bool, error) {
+	if f.err != nil {
+		return "", false, f.err
+	}
+	ip, ok := f.ips[pod.ID]
+	return ip, ok, nil
+}
+
+func TestProviderPodIP(t *testing.T) {
+	c := &CniManager{ipProviders: []IPProvider{passthruIPProvider{}}}
+
+	eni := &fakeIPProvider{name: "eni", ips: map[string]string{"s1": "192.168.0.10"}}
+	assert.NoError(t, c.RegisterIPProvider(eni))
+	assert.Error(t, c.RegisterIPProvider(&fakeIPProvider{name: "eni"}))
+
+	ip, ok, err := c.ProviderPodIP(&PodIPRequest{ID: "s1"})
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "192.168.0.10", ip)
+
+	// the providers are queried in the order of registration.
+	ip, ok, err = c.ProviderPodIP(&PodIPRequest{ID: "s1", Annotations: map[string]string{
+		anno.PassthruKey: "true",
+		anno.PassthruIP:  "10.0.0.10",
+	}})
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "10.0.0.10", ip)
+
+	// the sandbox is not managed by any provider.
+	_, ok, err = c.ProviderPodIP(&PodIPRequest{ID: "s2", Annotations: map[string]string{anno.PassthruKey: "false"}})
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	eni.err = fmt.Errorf("agent is down")
+	_, ok, err = c.ProviderPodIP(&PodIPRequest{ID: "s1"})
+	assert.Error(t, err)
+	assert.True(t, ok)
+}
@@ -3,6 +3,7 @@ package ocicni
 import (
 	"crypto/rand"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path"
 	"runtime"
@@ -16,12 +17,34 @@ import (
 
 const nsRunDir = "/var/run/netns"
 
+// nsNamePrefix is the prefix of the names of network namespaces created for sandboxes.
+const nsNamePrefix = "cni-"
+
 // NewNetNS creates a new persistent network namespace and returns the
 // namespace path, without switching to it
 func (c *CniManager) NewNetNS() (string, error) {
 	return createNS("")
 }
 
+// ListNetNS returns the paths of the persistent network namespaces created by NewNetNS.
+func (c *CniManager) ListNetNS() ([]string, error) {
+	entries, err := ioutil.ReadDir(nsRunDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to read netns dir")
+	}
+
+	var paths []string
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), nsNamePrefix) {
+			paths = append(paths, path.Join(nsRunDir, entry.Name()))
+		}
+	}
+	return paths, nil
+}
+
 // RemoveNetNS unmounts the network namespace
 func (c *CniManager) RemoveNetNS(path string) error {
 	if _, err := os.Stat(path); err != nil {
@@ -80,6 +103,45 @@ func (c *CniManager) RecoverNetNS(path string) error {
 	return err
 }
 
+// AdoptNetNS bind mounts the network namespace of the given path, e.g. /proc/$pid/ns/net,
+// onto a new persistent network namespace, so that it outlives the process.
+func (c *CniManager) AdoptNetNS(path string) (res string, err error) {
+	if _, err := ns.GetNS(path); err != nil {
+		return "", errors.Wrapf(err, "failed to get netns path %s", path)
+	}
+
+	if err = os.MkdirAll(nsRunDir, 0755); err != nil {
+		return "", err
+	}
+	nsPath, err := newNSPath()
+	if err != nil {
+		return "", err
+	}
+
+	mountPointFd, err := os.Create(nsPath)
+	if err != nil {
+		return "", err
+	}
+	mountPointFd.Close()
+
+	if err := unix.Mount(path, nsPath, "none", unix.MS_BIND, ""); err != nil {
+		os.RemoveAll(nsPath)
+		return "", errors.Wrapf(err, "failed to bind mount netns %s onto %s", path, nsPath)
+	}
+	return nsPath, nil
+}
+
+// newNSPath generates a random path of persistent network namespace.
+func newNSPath() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Reader.Read(b); err != nil {
+		return "", errors.Wrap(err, "failed to generate random netns name")
+	}
+
+	nsName := fmt.Sprintf(nsNamePrefix+"%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+	return path.Join(nsRunDir, nsName), nil
+}
+
 // getCurrentThreadNetNSPath copied from pkg/ns
 func getCurrentThreadNetNSPath() string {
 	// /proc/self/ns/net returns the namespace of the main thread, not
@@ -99,13 +161,9 @@ func createNS(nsPath string) (res string, err error) {
 
 	// if the ns path is not given, create an empty file
 	if nsPath == "" {
-		b := make([]byte, 16)
-		if _, err := rand.Reader.Read(b); err != nil {
-			return "", errors.Wrap(err, "failed to generate random netns name")
+		if nsPath, err = newNSPath(); err != nil {
+			return "", err
 		}
-
-		nsName := fmt.Sprintf("cni-%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
-		nsPath = path.Join(nsRunDir, nsName)
 	}
 
 	if _, err := os.Stat(nsPath); err != nil {
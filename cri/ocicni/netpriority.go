@@ -0,0 +1,305 @@
+package ocicni
+
+import (
+	"fmt"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+)
+
+const (
+	// netPriorityQdiscMajor is the major number of the htb qdisc handle on the classified device.
+	netPriorityQdiscMajor = 1
+	// netPriorityClassMinorBase is the minor number of the class for the lowest priority,
+	// the classes of higher priorities follow it in order.
+	netPriorityClassMinorBase = 10
+	// netPriorityChain is the chain of mangle table classifying the forwarded traffic of sandboxes.
+	netPriorityChain = "POUCH-NET-PRIORITY"
+)
+
+// defaultRootQdiscs are the root qdiscs set up by kernel or distributions, which
+// are safe to be replaced by the htb qdisc of net priority.
+var defaultRootQdiscs = map[string]bool{
+	"pfifo_fast": true,
+	"fq_codel":   true,
+	"fq":         true,
+	"mq":         true,
+	"noqueue":    true,
+}
+
+// iptables runs iptables with the args, it's a var for testing.
+var iptables = func(args ...string) ([]byte, error) {
+	return exec.Command("iptables", append([]string{"-w"}, args...)...).CombinedOutput()
+}
+
+// netPriorityClasses classifies the traffic of sandboxes on the host device into
+// htb classes according to the NetPriority of containers. The traffic is classified
+// by the host side veth of sandbox it comes from, so that it's still matched after
+// being masqueraded, and the classes are set on the forwarded packets by iptables
+// CLASSIFY, which htb picks the class by.
+type netPriorityClasses struct {
+	// link is the device on which the traffic is classified.
+	link netlink.Link
+	// priorities are the configured priorities in ascending order, the first one is always 0.
+	priorities []int64
+}
+
+// ParseNetPriorityShares parses the bandwidth shares of the net priority classes,
+// each of which is in the form of "priority=share".
+func ParseNetPriorityShares(shares []string) (map[int64]uint64, error) {
+	result := make(map[int64]uint64)
+	for _, s := range shares {
+		parts := strings.SplitN(s, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid net priority share %q, should be priority=share", s)
+		}
+
+		priority, err := strconv.ParseInt(strings.TrimSpace(parts[0]), 10, 64)
+		if err != nil || priority < 0 {
+			return nil, fmt.Errorf("invalid priority in net priority share %q", s)
+		}
+		share, err := strconv.ParseUint(strings.TrimSpace(parts[1]), 10, 64)
+		if err != nil || share == 0 {
+			return nil, fmt.Errorf("invalid share in net priority share %q", s)
+		}
+		if _, exists := result[priority]; exists {
+			return nil, fmt.Errorf("duplicated priority %d in net priority shares", priority)
+		}
+		result[priority] = share
+	}
+	return result, nil
+}
+
+// newNetPriorityClasses sets up the htb qdisc and classes on the device. Every configured
+// priority gets a class whose guaranteed rate is its share of the bandwidth (in Mbit/s),
+// and all classes could borrow up to the whole bandwidth. The unclassified traffic goes
+// to the class of priority 0. The root qdisc on the device is only replaced if it's the
+// default one or the one set up before, never the one set up by others.
+func newNetPriorityClasses(device string, bandwidth uint64, shares map[int64]uint64) (*netPriorityClasses, error) {
+	if bandwidth == 0 {
+		return nil, fmt.Errorf("bandwidth of net priority device %s should be > 0", device)
+	}
+
+	link, err := netlink.LinkByName(device)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find net priority device %s", device)
+	}
+	if err := checkRootQdisc(link); err != nil {
+		return nil, err
+	}
+
+	if _, exists := shares[0]; !exists {
+		shares[0] = 1
+	}
+	var total uint64
+	priorities := make([]int64, 0, len(shares))
+	for priority, share := range shares {
+		priorities = append(priorities, priority)
+		total += share
+	}
+	sort.Slice(priorities, func(i, j int) bool { return priorities[i] < priorities[j] })
+
+	n := &netPriorityClasses{
+		link:       link,
+		priorities: priorities,
+	}
+
+	rate := bandwidth * 1000 * 1000
+	qdisc := netlink.NewHtb(netlink.QdiscAttrs{
+		LinkIndex: link.Attrs().Index,
+		Handle:    netlink.MakeHandle(netPriorityQdiscMajor, 0),
+		Parent:    netlink.HANDLE_ROOT,
+	})
+	qdisc.Defcls = netPriorityClassMinorBase
+	if err := netlink.QdiscReplace(qdisc); err != nil {
+		return nil, errors.Wrapf(err, "failed to setup htb qdisc on device %s", device)
+	}
+
+	root := netlink.NewHtbClass(netlink.ClassAttrs{
+		LinkIndex: link.Attrs().Index,
+		Handle:    netlink.MakeHandle(netPriorityQdiscMajor, 1),
+		Parent:    qdisc.Handle,
+	}, netlink.HtbClassAttrs{Rate: rate, Ceil: rate})
+	if err := netlink.ClassReplace(root); err != nil {
+		return nil, errors.Wrapf(err, "failed to setup htb root class on device %s", device)
+	}
+
+	for i, priority := range priorities {
+		class := netlink.NewHtbClass(netlink.ClassAttrs{
+			LinkIndex: link.Attrs().Index,
+			Handle:    n.classID(i),
+			Parent:    root.Handle,
+		}, netlink.HtbClassAttrs{Rate: rate * shares[priority] / total, Ceil: rate})
+		if err := netlink.ClassReplace(class); err != nil {
+			return nil, errors.Wrapf(err, "failed to setup htb class of net priority %d on device %s", priority, device)
+		}
+	}
+
+	if err := ensureNetPriorityChain(); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// checkRootQdisc returns error if the root qdisc of link is set up by others.
+func checkRootQdisc(link netlink.Link) error {
+	qdiscs, err := netlink.QdiscList(link)
+	if err != nil {
+		return errors.Wrapf(err, "failed to list qdiscs on device %s", link.Attrs().Name)
+	}
+	for _, q := range qdiscs {
+		if q.Attrs().Parent != netlink.HANDLE_ROOT {
+			continue
+		}
+		if q.Type() == "htb" && q.Attrs().Handle == netlink.MakeHandle(netPriorityQdiscMajor, 0) {
+			return nil
+		}
+		if !defaultRootQdiscs[q.Type()] {
+			return fmt.Errorf("device %s has root qdisc %s set up by others, refuse to replace it", link.Attrs().Name, q.Type())
+		}
+	}
+	return nil
+}
+
+// ensureNetPriorityChain creates the chain classifying the traffic of sandboxes,
+// and jumps to it from FORWARD of mangle table, in which the class set is kept
+// until the packet leaves the device.
+func ensureNetPriorityChain() error {
+	if out, err := iptables("-t", "mangle", "-N", netPriorityChain); err != nil && !strings.Contains(string(out), "exist") {
+		return errors.Wrapf(err, "failed to create chain %s: %s", netPriorityChain, out)
+	}
+	if _, err := iptables("-t", "mangle", "-C", "FORWARD", "-j", netPriorityChain); err == nil {
+		return nil
+	}
+	if out, err := iptables("-t", "mangle", "-I", "FORWARD", "-j", netPriorityChain); err != nil {
+		return errors.Wrapf(err, "failed to jump to chain %s: %s", netPriorityChain, out)
+	}
+	return nil
+}
+
+// classID returns the handle of the i-th class.
+func (n *netPriorityClasses) classID(i int) uint32 {
+	return netlink.MakeHandle(netPriorityQdiscMajor, uint16(netPriorityClassMinorBase+i))
+}
+
+// classOf returns the index of the class the priority belongs to, which is the
+// highest configured priority not greater than it.
+func (n *netPriorityClasses) classOf(priority int64) int {
+	i := sort.Search(len(n.priorities), func(i int) bool { return n.priorities[i] > priority })
+	if i == 0 {
+		return 0
+	}
+	return i - 1
+}
+
+// classifyRule returns the rule setting the class of the traffic from the veth.
+func classifyRule(veth string, classID uint32) []string {
+	return []string{"-i", veth, "-j", "CLASSIFY", "--set-class",
+		fmt.Sprintf("%x:%x", classID>>16, classID&0xffff)}
+}
+
+// vethRules returns the rules of veth in the listing of iptables -S.
+func vethRules(listing, veth string) [][]string {
+	var rules [][]string
+	for _, line := range strings.Split(listing, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 4 || fields[0] != "-A" || fields[1] != netPriorityChain {
+			continue
+		}
+		for i := 2; i+1 < len(fields); i++ {
+			if fields[i] == "-i" && fields[i+1] == veth {
+				rules = append(rules, fields[2:])
+				break
+			}
+		}
+	}
+	return rules
+}
+
+// classify directs the traffic from the veth into the class of priority.
+func (n *netPriorityClasses) classify(veth string, priority int64) error {
+	if err := n.unclassify(veth); err != nil {
+		return err
+	}
+
+	args := append([]string{"-t", "mangle", "-A", netPriorityChain}, classifyRule(veth, n.classID(n.classOf(priority)))...)
+	if out, err := iptables(args...); err != nil {
+		return errors.Wrapf(err, "failed to classify traffic from %s: %s", veth, out)
+	}
+	return nil
+}
+
+// unclassify removes the rules classifying the traffic from the veth.
+func (n *netPriorityClasses) unclassify(veth string) error {
+	out, err := iptables("-t", "mangle", "-S", netPriorityChain)
+	if err != nil {
+		return errors.Wrapf(err, "failed to list chain %s: %s", netPriorityChain, out)
+	}
+	for _, rule := range vethRules(string(out), veth) {
+		args := append([]string{"-t", "mangle", "-D", netPriorityChain}, rule...)
+		if out, err := iptables(args...); err != nil {
+			return errors.Wrapf(err, "failed to unclassify traffic from %s: %s", veth, out)
+		}
+	}
+	return nil
+}
+
+// hostInterfaces returns the names of interfaces on host in the network results,
+// e.g. the host side veths and the bridges.
+func hostInterfaces(results []*NetworkResult) []string {
+	var names []string
+	for _, r := range results {
+		if r == nil || r.Result == nil {
+			continue
+		}
+		for _, iface := range r.Result.Interfaces {
+			if iface != nil && iface.Name != "" && iface.Sandbox == "" {
+				names = append(names, iface.Name)
+			}
+		}
+	}
+	return names
+}
+
+// SetNetPriority classifies the traffic of the sandbox according to the priority.
+// It's a no-op if the net priority device is not configured.
+func (c *CniManager) SetNetPriority(results []*NetworkResult, priority int64) error {
+	if c.netPriority == nil {
+		return nil
+	}
+
+	classified := false
+	for _, name := range hostInterfaces(results) {
+		// the bridge is shared by sandboxes, only the veth is of the sandbox.
+		link, err := netlink.LinkByName(name)
+		if err != nil || link.Type() != "veth" {
+			continue
+		}
+		if err := c.netPriority.classify(name, priority); err != nil {
+			return err
+		}
+		classified = true
+	}
+	if !classified {
+		return fmt.Errorf("no host side veth of sandbox is found to classify its traffic")
+	}
+	return nil
+}
+
+// ClearNetPriority removes the classification of the traffic of the sandbox. The
+// veth may have been removed with the network, so the rules are matched by name.
+func (c *CniManager) ClearNetPriority(results []*NetworkResult) error {
+	if c.netPriority == nil {
+		return nil
+	}
+	for _, name := range hostInterfaces(results) {
+		if err := c.netPriority.unclassify(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package ocicni
+
+import (
+	"strings"
+	"testing"
+
+	cnicurrent "github.com/containernetworking/cni/pkg/types/current"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseNetPriorityShares(t *testing.T) {
+	for _, tc := range []struct {
+		shares   []string
+		expected map[int64]uint64
+		hasError bool
+	}{
+		{shares: nil, expected: map[int64]uint64{}},
+		{shares: []string{"0=1", "5=3", " 10 = 6 "}, expected: map[int64]uint64{0: 1, 5: 3, 10: 6}},
+		{shares: []string{"5"}, hasError: true},
+		{shares: []string{"-1=3"}, hasError: true},
+		{shares: []string{"5=0"}, hasError: true},
+		{shares: []string{"5=a"}, hasError: true},
+		{shares: []string{"5=1", "5=2"}, hasError: true},
+	} {
+		shares, err := ParseNetPriorityShares(tc.shares)
+		if tc.hasError {
+			assert.Error(t, err, "shares %v", tc.shares)
+			continue
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, tc.expected, shares)
+	}
+}
+
+func TestNetPriorityClassOf(t *testing.T) {
+	n := &netPriorityClasses{priorities: []int64{0, 5, 10}}
+	for priority, expected := range map[int64]int{
+		0:   0,
+		4:   0,
+		5:   1,
+		9:   1,
+		10:  2,
+		100: 2,
+	} {
+		assert.Equal(t, expected, n.classOf(priority), "priority %d", priority)
+	}
+}
+
+func TestNetPriorityClassify(t *testing.T) {
+	defer func(f func(args ...string) ([]byte, error)) { iptables = f }(iptables)
+	var calls []string
+	listing := "-N POUCH-NET-PRIORITY\n" +
+		"-A POUCH-NET-PRIORITY -i veth1 -j CLASSIFY --set-class 0001:000a\n" +
+		"-A POUCH-NET-PRIORITY -i veth2 -j CLASSIFY --set-class 0001:000b\n"
+	iptables = func(args ...string) ([]byte, error) {
+		calls = append(calls, strings.Join(args, " "))
+		if args[2] == "-S" {
+			return []byte(listing), nil
+		}
+		return nil, nil
+	}
+
+	n := &netPriorityClasses{priorities: []int64{0, 5, 10}}
+	assert.NoError(t, n.classify("veth1", 7))
+	assert.Equal(t, []string{
+		"-t mangle -S POUCH-NET-PRIORITY",
+		"-t mangle -D POUCH-NET-PRIORITY -i veth1 -j CLASSIFY --set-class 0001:000a",
+		"-t mangle -A POUCH-NET-PRIORITY -i veth1 -j CLASSIFY --set-class 1:b",
+	}, calls)
+
+	// the rules of other veths are kept.
+	calls = nil
+	assert.NoError(t, n.unclassify("veth3"))
+	assert.Equal(t, []string{"-t mangle -S POUCH-NET-PRIORITY"}, calls)
+}
+
+func TestHostInterfaces(t *testing.T) {
+	results := []*NetworkResult{
+		{Network: "default", Result: &cnicurrent.Result{Interfaces: []*cnicurrent.Interface{
+			{Name: "cni0"},
+			{Name: "veth1"},
+			{Name: "eth0", Sandbox: "/var/run/netns/cni-1"},
+		}}},
+		{Network: "none"},
+	}
+	assert.Equal(t, []string{"cni0", "veth1"}, hostInterfaces(results))
+}
@@ -0,0 +1,316 @@
+package ocicni
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"path/filepath"
+	"sort"
+
+	"github.com/alibaba/pouch/pkg/log"
+
+	"github.com/containernetworking/cni/libcni"
+	cnicurrent "github.com/containernetworking/cni/pkg/types/current"
+	"github.com/containernetworking/cni/pkg/version"
+	"github.com/cri-o/ocicni/pkg/ocicni"
+)
+
+// errMissingDefaultNetwork is returned if no CNI network is loaded.
+var errMissingDefaultNetwork = errors.New("missing CNI default network")
+
+// cniCacheDir is the directory in which libcni caches the results of CNI ADD,
+// it's only changed by tests.
+var cniCacheDir = libcni.CacheDir
+
+// PodNetwork is the pod sandbox attached to or detached from the CNI networks.
+type PodNetwork struct {
+	// Name is the name of pod.
+	Name string
+	// Namespace is the namespace of pod.
+	Namespace string
+	// ID is the id of the sandbox container.
+	ID string
+	// NetNS is the path of the network namespace of sandbox.
+	NetNS string
+	// Networks are the CNI networks to attach to, the default network if empty.
+	Networks []string
+	// RuntimeConfig is the runtime config of each network, keyed by the network name.
+	RuntimeConfig map[string]RuntimeConfig
+}
+
+// RuntimeConfig is the runtime config of a CNI network, passed to the plugins
+// as CNI_ARGS and capability args.
+type RuntimeConfig struct {
+	// IP is the static IP address assigned to the interface, e.g. by host-local.
+	IP string
+	// MAC is the static MAC address assigned to the interface.
+	MAC string
+	// PortMappings is the port mapping of the sandbox.
+	PortMappings []ocicni.PortMapping
+	// Bandwidth is the bandwidth limiting of the pod.
+	Bandwidth *ocicni.BandwidthConfig
+	// IpRanges is the ip range gather which is used for address allocation.
+	IpRanges [][]ocicni.IpRange
+	// Args are the extra key-value pairs appended to CNI_ARGS.
+	Args [][2]string
+	// CapabilityArgs are the extra capability args passed to the plugins
+	// declaring the capabilities, the ones set above take precedence.
+	CapabilityArgs map[string]interface{}
+}
+
+// cniNetworks are the CNI networks loaded from the configuration directory,
+// they are never changed but replaced as a whole once reloaded.
+type cniNetworks struct {
+	// networks are the networks keyed by name.
+	networks map[string]*libcni.NetworkConfigList
+	// defaultNetName is the name of the network loaded first.
+	defaultNetName string
+}
+
+// loopbackNetwork is the network of the loopback interface of every sandbox.
+var loopbackNetwork = func() *libcni.NetworkConfigList {
+	confList, err := libcni.ConfListFromBytes([]byte(`{
+  "cniVersion": "0.2.0",
+  "name": "cni-loopback",
+  "plugins": [{"type": "loopback"}]
+}`))
+	if err != nil {
+		// the hardcoded configuration is always valid.
+		panic(err)
+	}
+	return confList
+}()
+
+// loadNetworks loads the CNI networks in the configuration directory, the files
+// failing to be loaded are skipped.
+func loadNetworks(confDir string) (*cniNetworks, error) {
+	files, err := libcni.ConfFiles(confDir, []string{".conf", ".conflist", ".json"})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+
+	n := &cniNetworks{networks: make(map[string]*libcni.NetworkConfigList)}
+	for _, file := range files {
+		confList, _, err := loadConfList(file)
+		if err != nil {
+			log.With(nil).Warnf("failed to load cni configuration %s: %v", file, err)
+			continue
+		}
+		if confList.Name == "" {
+			confList.Name = filepath.Base(file)
+		}
+		// the networks with the same name are loaded only once.
+		if _, ok := n.networks[confList.Name]; ok {
+			continue
+		}
+
+		log.With(nil).Infof("found cni network %s (type=%v) at %s", confList.Name, confList.Plugins[0].Network.Type, file)
+		n.networks[confList.Name] = confList
+		if n.defaultNetName == "" {
+			n.defaultNetName = confList.Name
+		}
+	}
+	return n, nil
+}
+
+// podNetworks returns the networks the pod is attached to.
+func (n *cniNetworks) podNetworks(podNetwork *PodNetwork) ([]*libcni.NetworkConfigList, error) {
+	names := podNetwork.Networks
+	if len(names) == 0 {
+		if n.defaultNetName == "" {
+			return nil, errMissingDefaultNetwork
+		}
+		names = []string{n.defaultNetName}
+	}
+
+	var networks []*libcni.NetworkConfigList
+	for _, name := range names {
+		network, ok := n.networks[name]
+		if !ok {
+			return nil, fmt.Errorf("cni network %q not found", name)
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
+
+// addToNetworks attaches the pod to the loopback and its networks in order,
+// the interfaces are named eth0, eth1 and so on. The results of the networks
+// attached are returned even if it fails.
+func (c *CniManager) addToNetworks(networks []*libcni.NetworkConfigList, podNetwork *PodNetwork) ([]*NetworkResult, error) {
+	rt, err := buildRuntimeConf(podNetwork, "lo", RuntimeConfig{})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := c.cniConfig.AddNetworkList(context.Background(), loopbackNetwork, rt); err != nil {
+		return nil, fmt.Errorf("failed to add loopback network: %v", err)
+	}
+
+	var results []*NetworkResult
+	for i, network := range networks {
+		ifName := fmt.Sprintf("eth%d", i)
+		rt, err := buildRuntimeConf(podNetwork, ifName, podNetwork.RuntimeConfig[network.Name])
+		if err != nil {
+			return results, err
+		}
+
+		log.With(nil).Infof("add sandbox %q to cni network %s (type=%v)", podNetwork.ID, network.Name, network.Plugins[0].Network.Type)
+		res, err := c.cniConfig.AddNetworkList(context.Background(), network, rt)
+		if err != nil {
+			return results, fmt.Errorf("failed to add cni network %q: %v", network.Name, err)
+		}
+		result, err := cnicurrent.NewResultFromResult(res)
+		if err != nil {
+			return results, fmt.Errorf("failed to convert cni result of network %q: %v", network.Name, err)
+		}
+		results = append(results, &NetworkResult{Network: network.Name, IfName: resultIfName(result, ifName), Result: result})
+	}
+	return results, nil
+}
+
+// resultIfName returns the name of interface inside the sandbox in the result,
+// or the one requested if the result has no such interface.
+func resultIfName(result *cnicurrent.Result, ifName string) string {
+	for _, intf := range result.Interfaces {
+		if intf != nil && intf.Sandbox != "" && intf.Name != "" {
+			return intf.Name
+		}
+	}
+	return ifName
+}
+
+// deleteFromNetworks detaches the pod from its networks and the loopback. The
+// interface of each network and the prevResult passed to CNI DEL are taken from
+// the results of CNI ADD if found.
+func (c *CniManager) deleteFromNetworks(networks []*libcni.NetworkConfigList, podNetwork *PodNetwork, prevResults []*NetworkResult) error {
+	for i, network := range networks {
+		ifName := fmt.Sprintf("eth%d", i)
+		prev := findNetworkResult(prevResults, network.Name)
+		if prev != nil && prev.IfName != "" {
+			ifName = prev.IfName
+		}
+		rt, err := buildRuntimeConf(podNetwork, ifName, podNetwork.RuntimeConfig[network.Name])
+		if err != nil {
+			return err
+		}
+
+		log.With(nil).Infof("delete sandbox %q from cni network %s (type=%v)", podNetwork.ID, network.Name, network.Plugins[0].Network.Type)
+		if err := c.delNetworkList(network, rt, prev); err != nil {
+			return fmt.Errorf("failed to delete cni network %q: %v", network.Name, err)
+		}
+	}
+
+	rt, err := buildRuntimeConf(podNetwork, "lo", RuntimeConfig{})
+	if err != nil {
+		return err
+	}
+	if err := c.cniConfig.DelNetworkList(context.Background(), loopbackNetwork, rt); err != nil {
+		return fmt.Errorf("failed to delete loopback network: %v", err)
+	}
+	return nil
+}
+
+// delNetworkList runs CNI DEL of the plugins in the network in reverse order,
+// with the result of CNI ADD passed as prevResult if the spec version requires.
+// The result cached by libcni is preferred by it, and removed after the DEL.
+func (c *CniManager) delNetworkList(network *libcni.NetworkConfigList, rt *libcni.RuntimeConf, prev *NetworkResult) error {
+	var prevResult interface{}
+	if prev != nil && prev.Result != nil {
+		gtet, err := version.GreaterThanOrEqualTo(network.CNIVersion, "0.4.0")
+		if err != nil {
+			return err
+		}
+		if gtet {
+			if prevResult, err = prev.Result.GetAsVersion(network.CNIVersion); err != nil {
+				return fmt.Errorf("failed to convert the result to version %s: %v", network.CNIVersion, err)
+			}
+		}
+	}
+
+	for i := len(network.Plugins) - 1; i >= 0; i-- {
+		inject := map[string]interface{}{
+			"name":       network.Name,
+			"cniVersion": network.CNIVersion,
+		}
+		if prevResult != nil {
+			inject["prevResult"] = prevResult
+		}
+		conf, err := libcni.InjectConf(network.Plugins[i], inject)
+		if err != nil {
+			return err
+		}
+		if err := c.cniConfig.DelNetwork(context.Background(), conf, rt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// findNetworkResult returns the result of the network, nil if not found.
+func findNetworkResult(results []*NetworkResult, network string) *NetworkResult {
+	for _, r := range results {
+		if r != nil && r.Network == network {
+			return r
+		}
+	}
+	return nil
+}
+
+// buildRuntimeConf builds the runtime conf of the interface of pod in a network.
+func buildRuntimeConf(podNetwork *PodNetwork, ifName string, runtimeConfig RuntimeConfig) (*libcni.RuntimeConf, error) {
+	rt := &libcni.RuntimeConf{
+		ContainerID: podNetwork.ID,
+		NetNS:       podNetwork.NetNS,
+		IfName:      ifName,
+		CacheDir:    cniCacheDir,
+		Args: [][2]string{
+			{"IgnoreUnknown", "1"},
+			{"K8S_POD_NAMESPACE", podNetwork.Namespace},
+			{"K8S_POD_NAME", podNetwork.Name},
+			{"K8S_POD_INFRA_CONTAINER_ID", podNetwork.ID},
+		},
+		CapabilityArgs: map[string]interface{}{},
+	}
+
+	// the static IP and MAC are passed both in CNI_ARGS and the capability
+	// args, since the plugins support either of them.
+	if ip := runtimeConfig.IP; ip != "" {
+		if net.ParseIP(ip) == nil {
+			return nil, fmt.Errorf("unable to parse IP address %q", ip)
+		}
+		rt.Args = append(rt.Args, [2]string{"IP", ip})
+		rt.CapabilityArgs["ips"] = []string{ip}
+	}
+	if mac := runtimeConfig.MAC; mac != "" {
+		if _, err := net.ParseMAC(mac); err != nil {
+			return nil, fmt.Errorf("unable to parse MAC address %q: %v", mac, err)
+		}
+		rt.Args = append(rt.Args, [2]string{"MAC", mac})
+		rt.CapabilityArgs["mac"] = mac
+	}
+
+	if len(runtimeConfig.PortMappings) != 0 {
+		rt.CapabilityArgs["portMappings"] = runtimeConfig.PortMappings
+	}
+	if runtimeConfig.Bandwidth != nil {
+		rt.CapabilityArgs["bandwidth"] = map[string]uint64{
+			"ingressRate":  runtimeConfig.Bandwidth.IngressRate,
+			"ingressBurst": runtimeConfig.Bandwidth.IngressBurst,
+			"egressRate":   runtimeConfig.Bandwidth.EgressRate,
+			"egressBurst":  runtimeConfig.Bandwidth.EgressBurst,
+		}
+	}
+	if len(runtimeConfig.IpRanges) > 0 {
+		rt.CapabilityArgs["ipRanges"] = runtimeConfig.IpRanges
+	}
+
+	rt.Args = append(rt.Args, runtimeConfig.Args...)
+	for k, v := range runtimeConfig.CapabilityArgs {
+		if _, ok := rt.CapabilityArgs[k]; !ok {
+			rt.CapabilityArgs[k] = v
+		}
+	}
+	return rt, nil
+}
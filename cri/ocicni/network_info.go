@@ -0,0 +1,147 @@
+package ocicni
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/containernetworking/cni/libcni"
+)
+
+// versionProbeTimeout is the time to wait for the VERSION of a CNI plugin.
+const versionProbeTimeout = 5 * time.Second
+
+// NetworkInfo is the CNI network loaded from the configuration directory.
+type NetworkInfo struct {
+	// Name is the name of CNI network.
+	Name string `json:"name"`
+	// File is the path of the configuration file.
+	File string `json:"file"`
+	// Checksum is the sha256 checksum of the configuration file.
+	Checksum string `json:"checksum"`
+	// CNIVersion is the CNI spec version of the configuration.
+	CNIVersion string `json:"cniVersion,omitempty"`
+	// Default is true if the network is the default pod network.
+	Default bool `json:"default,omitempty"`
+	// Plugins are the plugins of the network in order.
+	Plugins []*PluginInfo `json:"plugins,omitempty"`
+	// Error is the error of loading the configuration file, the file is
+	// skipped by the plugin if set.
+	Error string `json:"error,omitempty"`
+}
+
+// PluginInfo is a plugin of CNI network.
+type PluginInfo struct {
+	// Type is the type of plugin, i.e. the name of plugin binary.
+	Type string `json:"type"`
+	// SupportedVersions are the CNI spec versions reported by the VERSION of plugin.
+	SupportedVersions []string `json:"supportedVersions,omitempty"`
+	// Error is the error of VERSION, e.g. the binary is not found.
+	Error string `json:"error,omitempty"`
+}
+
+// NetworkInfos returns the CNI networks in the configuration directory in the
+// order the plugin loads them, with the versions of their plugins probed.
+func (c *CniManager) NetworkInfos() ([]*NetworkInfo, error) {
+	files, err := libcni.ConfFiles(c.networkPluginConfDir, []string{".conf", ".conflist", ".json"})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+
+	cniConfig := libcni.NewCNIConfig([]string{c.networkPluginBinDir}, nil)
+	probes := make(map[string]*PluginInfo)
+
+	var infos []*NetworkInfo
+	defaultMarked := false
+	for _, file := range files {
+		info := loadNetworkInfo(file)
+		infos = append(infos, info)
+		if info.Error != "" {
+			continue
+		}
+
+		// the first network loaded is the default one.
+		if !defaultMarked {
+			info.Default, defaultMarked = true, true
+		}
+		for i, p := range info.Plugins {
+			if probes[p.Type] == nil {
+				probes[p.Type] = probePluginVersion(cniConfig, p.Type)
+			}
+			info.Plugins[i] = probes[p.Type]
+		}
+	}
+	return infos, nil
+}
+
+// loadNetworkInfo loads the network in the configuration file like the plugin.
+func loadNetworkInfo(file string) *NetworkInfo {
+	info := &NetworkInfo{File: file}
+
+	confList, data, err := loadConfList(file)
+	if data != nil {
+		info.Checksum = fmt.Sprintf("sha256:%x", sha256.Sum256(data))
+	}
+	if err != nil {
+		info.Error = err.Error()
+		return info
+	}
+
+	info.Name, info.CNIVersion = confList.Name, confList.CNIVersion
+	if info.Name == "" {
+		info.Name = filepath.Base(file)
+	}
+	for _, p := range confList.Plugins {
+		info.Plugins = append(info.Plugins, &PluginInfo{Type: p.Network.Type})
+	}
+	return info
+}
+
+// loadConfList loads the network in the configuration file, the single network
+// configuration is converted to the list. The content of file is returned as
+// long as it is read.
+func loadConfList(file string) (*libcni.NetworkConfigList, []byte, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var confList *libcni.NetworkConfigList
+	if strings.HasSuffix(file, ".conflist") {
+		confList, err = libcni.ConfListFromBytes(data)
+	} else {
+		var conf *libcni.NetworkConfig
+		if conf, err = libcni.ConfFromBytes(data); err == nil {
+			confList, err = libcni.ConfListFromConf(conf)
+		}
+	}
+	if err != nil {
+		return nil, data, err
+	}
+	if len(confList.Plugins) == 0 {
+		return nil, data, fmt.Errorf("no plugins in the network")
+	}
+	return confList, data, nil
+}
+
+// probePluginVersion runs the VERSION command of the plugin.
+func probePluginVersion(cniConfig *libcni.CNIConfig, pluginType string) *PluginInfo {
+	info := &PluginInfo{Type: pluginType}
+
+	ctx, cancel := context.WithTimeout(context.Background(), versionProbeTimeout)
+	defer cancel()
+
+	version, err := cniConfig.GetVersionInfo(ctx, pluginType)
+	if err != nil {
+		info.Error = err.Error()
+		return info
+	}
+	info.SupportedVersions = version.SupportedVersions()
+	return info
+}
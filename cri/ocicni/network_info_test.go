@@ -0,0 +1,61 @@
+package ocicni
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetworkInfos(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cni-network-info")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	confDir, binDir := filepath.Join(dir, "net.d"), filepath.Join(dir, "bin")
+	assert.NoError(t, os.MkdirAll(confDir, 0755))
+	assert.NoError(t, os.MkdirAll(binDir, 0755))
+
+	conflist := []byte(`{"cniVersion": "0.4.0", "name": "pod", "plugins": [{"type": "bridge"}, {"type": "portmap"}]}`)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(confDir, "10-pod.conflist"), conflist, 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(confDir, "20-secondary.conf"),
+		[]byte(`{"cniVersion": "0.3.1", "name": "secondary", "type": "bridge"}`), 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(confDir, "05-broken.json"), []byte(`{"name": "broken"}`), 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(confDir, "README"), []byte("ignored"), 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(binDir, "bridge"),
+		[]byte("#!/bin/sh\necho '{\"cniVersion\": \"0.4.0\", \"supportedVersions\": [\"0.3.1\", \"0.4.0\"]}'\n"), 0755))
+
+	c := &CniManager{networkPluginConfDir: confDir, networkPluginBinDir: binDir}
+	infos, err := c.NetworkInfos()
+	assert.NoError(t, err)
+	assert.Len(t, infos, 3)
+
+	// the file failing to be loaded is reported but never the default.
+	assert.Equal(t, filepath.Join(confDir, "05-broken.json"), infos[0].File)
+	assert.Contains(t, infos[0].Error, "missing 'type'")
+	assert.False(t, infos[0].Default)
+
+	assert.Equal(t, "pod", infos[1].Name)
+	assert.Equal(t, "0.4.0", infos[1].CNIVersion)
+	assert.Equal(t, fmt.Sprintf("sha256:%x", sha256.Sum256(conflist)), infos[1].Checksum)
+	assert.True(t, infos[1].Default)
+	assert.Len(t, infos[1].Plugins, 2)
+	assert.Equal(t, &PluginInfo{Type: "bridge", SupportedVersions: []string{"0.3.1", "0.4.0"}}, infos[1].Plugins[0])
+	assert.Equal(t, "portmap", infos[1].Plugins[1].Type)
+	assert.Empty(t, infos[1].Plugins[1].SupportedVersions)
+	assert.NotEmpty(t, infos[1].Plugins[1].Error)
+
+	assert.Equal(t, "secondary", infos[2].Name)
+	assert.False(t, infos[2].Default)
+	assert.Equal(t, infos[1].Plugins[0], infos[2].Plugins[0])
+
+	// the missing configuration directory has no networks.
+	c.networkPluginConfDir = filepath.Join(dir, "missing")
+	infos, err = c.NetworkInfos()
+	assert.NoError(t, err)
+	assert.Empty(t, infos)
+}
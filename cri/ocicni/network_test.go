@@ -0,0 +1,202 @@
+package ocicni
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alibaba/pouch/pkg/kmutex"
+
+	"github.com/containernetworking/cni/libcni"
+	"github.com/cri-o/ocicni/pkg/ocicni"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadNetworks(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cni-network")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "05-broken.json"), []byte(`{"name": "broken"}`), 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "10-pod.conflist"),
+		[]byte(`{"cniVersion": "0.4.0", "name": "pod", "plugins": [{"type": "bridge"}, {"type": "portmap"}]}`), 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "20-secondary.conf"),
+		[]byte(`{"cniVersion": "0.3.1", "name": "secondary", "type": "bridge"}`), 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "30-pod.conf"),
+		[]byte(`{"cniVersion": "0.3.1", "name": "pod", "type": "macvlan"}`), 0644))
+
+	n, err := loadNetworks(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, "pod", n.defaultNetName)
+	assert.Len(t, n.networks, 2)
+	// the network of the same name loaded first is used.
+	assert.Len(t, n.networks["pod"].Plugins, 2)
+
+	networks, err := n.podNetworks(&PodNetwork{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"pod"}, []string{networks[0].Name})
+
+	networks, err = n.podNetworks(&PodNetwork{Networks: []string{"pod", "secondary"}})
+	assert.NoError(t, err)
+	assert.Len(t, networks, 2)
+	assert.Equal(t, "secondary", networks[1].Name)
+
+	_, err = n.podNetworks(&PodNetwork{Networks: []string{"missing"}})
+	assert.Error(t, err)
+
+	n, err = loadNetworks(filepath.Join(dir, "missing"))
+	assert.NoError(t, err)
+	_, err = n.podNetworks(&PodNetwork{})
+	assert.Equal(t, errMissingDefaultNetwork, err)
+}
+
+func TestBuildRuntimeConf(t *testing.T) {
+	podNetwork := &PodNetwork{Name: "nginx", Namespace: "default", ID: "sandbox1", NetNS: "/var/run/netns/cni-1"}
+
+	rt, err := buildRuntimeConf(podNetwork, "eth0", RuntimeConfig{
+		IP:             "10.0.0.5",
+		MAC:            "02:42:0a:00:00:05",
+		PortMappings:   []ocicni.PortMapping{{HostPort: 8080, ContainerPort: 80, Protocol: "tcp"}},
+		Args:           [][2]string{{"SUBNET", "blue"}},
+		CapabilityArgs: map[string]interface{}{"mac": "02:42:0a:00:00:06", "dns": "cluster"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "sandbox1", rt.ContainerID)
+	assert.Equal(t, "/var/run/netns/cni-1", rt.NetNS)
+	assert.Equal(t, "eth0", rt.IfName)
+	assert.Equal(t, [][2]string{
+		{"IgnoreUnknown", "1"},
+		{"K8S_POD_NAMESPACE", "default"},
+		{"K8S_POD_NAME", "nginx"},
+		{"K8S_POD_INFRA_CONTAINER_ID", "sandbox1"},
+		{"IP", "10.0.0.5"},
+		{"MAC", "02:42:0a:00:00:05"},
+		{"SUBNET", "blue"},
+	}, rt.Args)
+	assert.Equal(t, []string{"10.0.0.5"}, rt.CapabilityArgs["ips"])
+	// the extra capability args never override the ones of runtime config.
+	assert.Equal(t, "02:42:0a:00:00:05", rt.CapabilityArgs["mac"])
+	assert.Equal(t, "cluster", rt.CapabilityArgs["dns"])
+	assert.Len(t, rt.CapabilityArgs["portMappings"], 1)
+
+	_, err = buildRuntimeConf(podNetwork, "eth0", RuntimeConfig{IP: "10.0.0"})
+	assert.Error(t, err)
+	_, err = buildRuntimeConf(podNetwork, "eth0", RuntimeConfig{MAC: "02:42"})
+	assert.Error(t, err)
+}
+
+func TestSetUpTearDownPodNetwork(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cni-network")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	defer func(dir string) { cniCacheDir = dir }(cniCacheDir)
+	cniCacheDir = filepath.Join(dir, "cache")
+	confDir, binDir, logFile := filepath.Join(dir, "net.d"), filepath.Join(dir, "bin"), filepath.Join(dir, "log")
+	assert.NoError(t, os.MkdirAll(confDir, 0755))
+	assert.NoError(t, os.MkdirAll(binDir, 0755))
+
+	// the plugins log the commands with their args and configurations.
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(binDir, "fake"), []byte(`#!/bin/sh
+echo "$CNI_COMMAND $CNI_IFNAME $CNI_ARGS $(cat)" >> `+logFile+`
+if [ "$CNI_COMMAND" = ADD ]; then
+	echo '{"cniVersion": "0.4.0", "interfaces": [{"name": "net1", "sandbox": "/var/run/netns/cni-1"}], "ips": [{"version": "4", "address": "10.0.0.2/24", "interface": 0}]}'
+fi
+`), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(binDir, "loopback"), []byte(`#!/bin/sh
+[ "$CNI_COMMAND" = ADD ] && echo '{"cniVersion": "0.2.0"}'
+exit 0
+`), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(confDir, "10-pod.conflist"),
+		[]byte(`{"cniVersion": "0.4.0", "name": "pod", "plugins": [{"type": "fake", "capabilities": {"mac": true}}]}`), 0644))
+
+	networks, err := loadNetworks(confDir)
+	assert.NoError(t, err)
+	c := &CniManager{networks: networks, podLocks: kmutex.New(), networkPluginConfDir: confDir, cniConfig: libcni.NewCNIConfig([]string{binDir}, nil)}
+
+	podNetwork := &PodNetwork{
+		Name: "nginx", Namespace: "default", ID: "sandbox1", NetNS: "/var/run/netns/cni-1",
+		RuntimeConfig: map[string]RuntimeConfig{"pod": {MAC: "02:42:0a:00:00:05"}},
+	}
+	results, err := c.SetUpPodNetwork(podNetwork)
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+	assert.Equal(t, "pod", results[0].Network)
+	// the name of interface is taken from the result.
+	assert.Equal(t, "net1", results[0].IfName)
+	assert.Equal(t, "10.0.0.2", PodIP(results))
+
+	data, err := ioutil.ReadFile(logFile)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(data), "ADD eth0 "), string(data))
+	assert.Contains(t, string(data), "MAC=02:42:0a:00:00:05")
+	assert.Contains(t, string(data), `"runtimeConfig":{"mac":"02:42:0a:00:00:05"}`)
+
+	// the default network changes and the result cached by libcni is lost.
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(confDir, "00-other.conf"),
+		[]byte(`{"cniVersion": "0.4.0", "name": "other", "type": "fake"}`), 0644))
+	assert.NoError(t, c.reloadNetworks())
+	assert.Equal(t, "other", c.GetDefaultNetworkName())
+	assert.NoError(t, os.RemoveAll(cniCacheDir))
+	assert.NoError(t, os.Remove(logFile))
+
+	assert.NoError(t, c.TearDownPodNetwork(&PodNetwork{
+		Name: "nginx", Namespace: "default", ID: "sandbox1", NetNS: "/var/run/netns/cni-1",
+	}, results))
+	data, err = ioutil.ReadFile(logFile)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(data), "DEL net1 "), string(data))
+	assert.Contains(t, string(data), `"name":"pod"`)
+	assert.Contains(t, string(data), `"prevResult":{`)
+	assert.Contains(t, string(data), "10.0.0.2/24")
+	assert.Equal(t, 1, strings.Count(string(data), "\n"))
+}
+
+func TestReloadNetworksWaitsForCNICalls(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cni-network")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	defer func(dir string) { cniCacheDir = dir }(cniCacheDir)
+	cniCacheDir = filepath.Join(dir, "cache")
+	confDir, binDir, started := filepath.Join(dir, "net.d"), filepath.Join(dir, "bin"), filepath.Join(dir, "started")
+	assert.NoError(t, os.MkdirAll(confDir, 0755))
+	assert.NoError(t, os.MkdirAll(binDir, 0755))
+
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(binDir, "slow"), []byte(`#!/bin/sh
+cat > /dev/null
+touch `+started+`
+sleep 0.5
+echo '{"cniVersion": "0.4.0"}'
+`), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(binDir, "loopback"), []byte("#!/bin/sh\necho '{\"cniVersion\": \"0.2.0\"}'\n"), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(confDir, "10-pod.conf"),
+		[]byte(`{"cniVersion": "0.4.0", "name": "pod", "type": "slow"}`), 0644))
+
+	networks, err := loadNetworks(confDir)
+	assert.NoError(t, err)
+	c := &CniManager{networks: networks, podLocks: kmutex.New(), networkPluginConfDir: confDir, cniConfig: libcni.NewCNIConfig([]string{binDir}, nil)}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := c.SetUpPodNetwork(&PodNetwork{ID: "sandbox1", NetNS: "/var/run/netns/cni-1"})
+		done <- err
+	}()
+	for i := 0; i < 100; i++ {
+		if _, err := os.Stat(started); err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// the networks are replaced only after the CNI ADD in flight is done.
+	assert.NoError(t, os.Remove(filepath.Join(confDir, "10-pod.conf")))
+	start := time.Now()
+	assert.NoError(t, c.reloadNetworks())
+	assert.True(t, time.Since(start) > 200*time.Millisecond, "networks are reloaded during the CNI call")
+	assert.NoError(t, <-done)
+	assert.Equal(t, "", c.GetDefaultNetworkName())
+}
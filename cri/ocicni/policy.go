@@ -0,0 +1,106 @@
+package ocicni
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"os/exec"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/pkg/errors"
+)
+
+const (
+	// policyIngressChain is the iptables chain holding the ingress rules of sandbox.
+	policyIngressChain = "POUCH-INGRESS"
+	// policyEgressChain is the iptables chain holding the egress rules of sandbox.
+	policyEgressChain = "POUCH-EGRESS"
+)
+
+// PolicyRule is a rule of the pod network policy, which allows or denies the traffic
+// from (ingress) or to (egress) the peers in CIDR.
+type PolicyRule struct {
+	// Allow specify whether the matched traffic is accepted or dropped.
+	Allow bool
+	// CIDR is the address range of peers.
+	CIDR *net.IPNet
+	// Protocol is the protocol of traffic, e.g. tcp or udp. Empty for all protocols.
+	Protocol string
+	// Port is the port of the sandbox (ingress) or of the peers (egress). Zero for all ports.
+	Port int
+}
+
+// NetworkPolicy is the network policy of pod sandbox. The rules are matched in order,
+// and the traffic matching no rule is accepted.
+type NetworkPolicy struct {
+	Ingress []*PolicyRule
+	Egress  []*PolicyRule
+}
+
+// iptablesRules renders the policy into the input of iptables-restore. The loopback
+// traffic and the replies of accepted connections are always accepted.
+func (p *NetworkPolicy) iptablesRules() string {
+	buf := &bytes.Buffer{}
+	fmt.Fprintln(buf, "*filter")
+	fmt.Fprintln(buf, ":INPUT ACCEPT [0:0]")
+	fmt.Fprintln(buf, ":FORWARD ACCEPT [0:0]")
+	fmt.Fprintln(buf, ":OUTPUT ACCEPT [0:0]")
+	fmt.Fprintf(buf, ":%s - [0:0]\n", policyIngressChain)
+	fmt.Fprintf(buf, ":%s - [0:0]\n", policyEgressChain)
+	fmt.Fprintf(buf, "-A INPUT -j %s\n", policyIngressChain)
+	fmt.Fprintf(buf, "-A OUTPUT -j %s\n", policyEgressChain)
+
+	for _, chain := range []struct {
+		name  string
+		iface string
+		peer  string
+		rules []*PolicyRule
+	}{
+		{policyIngressChain, "-i", "-s", p.Ingress},
+		{policyEgressChain, "-o", "-d", p.Egress},
+	} {
+		if len(chain.rules) == 0 {
+			continue
+		}
+		fmt.Fprintf(buf, "-A %s %s lo -j ACCEPT\n", chain.name, chain.iface)
+		fmt.Fprintf(buf, "-A %s -m conntrack --ctstate RELATED,ESTABLISHED -j ACCEPT\n", chain.name)
+		for _, rule := range chain.rules {
+			fmt.Fprintf(buf, "-A %s %s %s", chain.name, chain.peer, rule.CIDR.String())
+			if rule.Protocol != "" {
+				fmt.Fprintf(buf, " -p %s", rule.Protocol)
+				if rule.Port > 0 {
+					fmt.Fprintf(buf, " --dport %d", rule.Port)
+				}
+			}
+			target := "DROP"
+			if rule.Allow {
+				target = "ACCEPT"
+			}
+			fmt.Fprintf(buf, " -j %s\n", target)
+		}
+	}
+
+	fmt.Fprintln(buf, "COMMIT")
+	return buf.String()
+}
+
+// ApplyNetworkPolicy programs the iptables rules of the policy in the network namespace.
+// The filter table of the namespace is owned by the sandbox and replaced as a whole,
+// so applying the same policy again is harmless. It's a no-op if the policy enforcer
+// is not enabled.
+func (c *CniManager) ApplyNetworkPolicy(netnsPath string, policy *NetworkPolicy) error {
+	if !c.networkPolicy || policy == nil {
+		return nil
+	}
+
+	rules := policy.iptablesRules()
+	return ns.WithNetNSPath(netnsPath, func(_ ns.NetNS) error {
+		// the command is forked from the locked thread, so it runs in the netns.
+		cmd := exec.Command("iptables-restore")
+		cmd.Stdin = bytes.NewBufferString(rules)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return errors.Wrapf(err, "failed to apply network policy in netns %s: %s", netnsPath, out)
+		}
+		return nil
+	})
+}
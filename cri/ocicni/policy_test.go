@@ -0,0 +1,45 @@
+package ocicni
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetworkPolicyIptablesRules(t *testing.T) {
+	_, cluster, _ := net.ParseCIDR("10.0.0.0/8")
+	_, any, _ := net.ParseCIDR("0.0.0.0/0")
+
+	policy := &NetworkPolicy{
+		Ingress: []*PolicyRule{
+			{Allow: true, CIDR: cluster, Protocol: "tcp", Port: 80},
+			{Allow: false, CIDR: any},
+		},
+	}
+
+	expected := `*filter
+:INPUT ACCEPT [0:0]
+:FORWARD ACCEPT [0:0]
+:OUTPUT ACCEPT [0:0]
+:POUCH-INGRESS - [0:0]
+:POUCH-EGRESS - [0:0]
+-A INPUT -j POUCH-INGRESS
+-A OUTPUT -j POUCH-EGRESS
+-A POUCH-INGRESS -i lo -j ACCEPT
+-A POUCH-INGRESS -m conntrack --ctstate RELATED,ESTABLISHED -j ACCEPT
+-A POUCH-INGRESS -s 10.0.0.0/8 -p tcp --dport 80 -j ACCEPT
+-A POUCH-INGRESS -s 0.0.0.0/0 -j DROP
+COMMIT
+`
+	assert.Equal(t, expected, policy.iptablesRules())
+
+	policy = &NetworkPolicy{
+		Egress: []*PolicyRule{
+			{Allow: false, CIDR: cluster, Protocol: "udp"},
+		},
+	}
+	assert.Contains(t, policy.iptablesRules(), "-A POUCH-EGRESS -o lo -j ACCEPT\n")
+	assert.Contains(t, policy.iptablesRules(), "-A POUCH-EGRESS -d 10.0.0.0/8 -p udp -j DROP\n")
+	assert.NotContains(t, policy.iptablesRules(), "-A POUCH-INGRESS -i lo")
+}
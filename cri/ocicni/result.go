@@ -0,0 +1,47 @@
+package ocicni
+
+import (
+	cnicurrent "github.com/containernetworking/cni/pkg/types/current"
+)
+
+// NetworkResult is the result of attaching a pod sandbox to a CNI network.
+type NetworkResult struct {
+	// Network is the name of CNI network.
+	Network string `json:"network"`
+	// IfName is the name of interface inside the sandbox.
+	IfName string `json:"ifName"`
+	// Result is the result of CNI ADD, including interfaces, IPs and routes.
+	Result *cnicurrent.Result `json:"result"`
+}
+
+// PodIP returns the first IP address in the network results.
+func PodIP(results []*NetworkResult) string {
+	for _, r := range results {
+		if r == nil || r.Result == nil {
+			continue
+		}
+		for _, ip := range r.Result.IPs {
+			if ip != nil && ip.Address.IP != nil {
+				return ip.Address.IP.String()
+			}
+		}
+	}
+	return ""
+}
+
+// PodMAC returns the MAC address of the interface inside the sandbox in the
+// result of the first network.
+func PodMAC(results []*NetworkResult) string {
+	for _, r := range results {
+		if r == nil || r.Result == nil {
+			continue
+		}
+		for _, intf := range r.Result.Interfaces {
+			if intf != nil && intf.Sandbox != "" && intf.Name == r.IfName {
+				return intf.Mac
+			}
+		}
+		return ""
+	}
+	return ""
+}
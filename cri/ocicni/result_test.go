@@ -0,0 +1,43 @@
+package ocicni
+
+import (
+	"net"
+	"testing"
+
+	cnicurrent "github.com/containernetworking/cni/pkg/types/current"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestResult(cidr string) *cnicurrent.Result {
+	ip, ipNet, _ := net.ParseCIDR(cidr)
+	ipNet.IP = ip
+	return &cnicurrent.Result{
+		CNIVersion: "0.4.0",
+		IPs: []*cnicurrent.IPConfig{
+			{
+				Version: "4",
+				Address: *ipNet,
+			},
+		},
+	}
+}
+
+func TestPodIP(t *testing.T) {
+	assert.Equal(t, "", PodIP(nil))
+	assert.Equal(t, "", PodIP([]*NetworkResult{{Network: "default"}}))
+	assert.Equal(t, "10.0.0.2", PodIP([]*NetworkResult{
+		{Network: "default", Result: newTestResult("10.0.0.2/24")},
+		{Network: "secondary", Result: newTestResult("10.1.0.2/24")},
+	}))
+}
+
+func TestPodMAC(t *testing.T) {
+	result := newTestResult("10.0.0.2/24")
+	result.Interfaces = []*cnicurrent.Interface{
+		{Name: "veth1", Mac: "02:42:0a:00:00:01"},
+		{Name: "eth0", Mac: "02:42:0a:00:00:02", Sandbox: "/var/run/netns/cni-1"},
+	}
+	assert.Equal(t, "", PodMAC(nil))
+	assert.Equal(t, "", PodMAC([]*NetworkResult{{Network: "default", IfName: "eth1", Result: result}}))
+	assert.Equal(t, "02:42:0a:00:00:02", PodMAC([]*NetworkResult{{Network: "default", IfName: "eth0", Result: result}}))
+}
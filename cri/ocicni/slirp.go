@@ -0,0 +1,269 @@
+package ocicni
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/alibaba/pouch/pkg/log"
+	"github.com/alibaba/pouch/pkg/system"
+
+	cnicurrent "github.com/containernetworking/cni/pkg/types/current"
+	"github.com/cri-o/ocicni/pkg/ocicni"
+)
+
+const (
+	// SlirpNetworkName is the name of network the pods are connected to by slirp4netns.
+	SlirpNetworkName = "slirp4netns"
+
+	// slirpIfName is the name of tap device in the sandbox.
+	slirpIfName = "eth0"
+	// slirpMTU is the mtu of tap device, the larger one gets the better throughput.
+	slirpMTU = 65520
+	// slirpReadyTimeout is the time to wait for slirp4netns to configure the tap device.
+	slirpReadyTimeout = 10 * time.Second
+)
+
+// slirpManager connects the pods to the network by slirp4netns in rootless mode,
+// in which the CNI plugins could not create the veth pairs and bridges without
+// the root of host. The network namespaces are still managed by the CniMgr.
+type slirpManager struct {
+	CniMgr
+	// binary is the path of slirp4netns binary.
+	binary string
+	// stateDir is the directory of the pid files and api sockets of slirp4netns.
+	stateDir string
+}
+
+// NewSlirpManager wraps the cni manager to connect the pods by slirp4netns. The
+// sandboxes and slirp4netns run in the user namespace of pouchd, which must not
+// be the initial one, otherwise the containers would run as the root of host.
+func NewSlirpManager(cni CniMgr, stateDir string) (CniMgr, error) {
+	uidMap, err := ioutil.ReadFile("/proc/self/uid_map")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read uid map: %v", err)
+	}
+	if isInitialUserNS(string(uidMap)) {
+		return nil, fmt.Errorf("rootless mode requires pouchd to run in a user namespace, e.g. by rootlesskit")
+	}
+
+	binary, err := exec.LookPath("slirp4netns")
+	if err != nil {
+		return nil, fmt.Errorf("failed to find slirp4netns: %v", err)
+	}
+	if err := os.MkdirAll(stateDir, 0700); err != nil {
+		return nil, err
+	}
+	return &slirpManager{CniMgr: cni, binary: binary, stateDir: stateDir}, nil
+}
+
+// Name returns the name of slirp4netns.
+func (s *slirpManager) Name() string {
+	return SlirpNetworkName
+}
+
+// GetDefaultNetworkName returns the name of slirp4netns.
+func (s *slirpManager) GetDefaultNetworkName() string {
+	return SlirpNetworkName
+}
+
+// Status returns nil since slirp4netns needs no configuration.
+func (s *slirpManager) Status() error {
+	return nil
+}
+
+func (s *slirpManager) pidFile(id string) string {
+	return filepath.Join(s.stateDir, id+".pid")
+}
+
+func (s *slirpManager) apiSocket(id string) string {
+	return filepath.Join(s.stateDir, id+".sock")
+}
+
+// SetUpPodNetwork starts slirp4netns to connect the network namespace of
+// sandbox and forwards the host ports of the port mappings.
+func (s *slirpManager) SetUpPodNetwork(podNetwork *PodNetwork) (_ []*NetworkResult, retErr error) {
+	ready, w, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	defer ready.Close()
+
+	cmd := exec.Command(s.binary,
+		"--configure",
+		"--mtu="+strconv.Itoa(slirpMTU),
+		"--disable-host-loopback",
+		"--ready-fd=3",
+		"--api-socket="+s.apiSocket(podNetwork.ID),
+		"--netns-type=path",
+		podNetwork.NetNS,
+		slirpIfName,
+	)
+	cmd.ExtraFiles = []*os.File{w}
+	// slirp4netns should survive the restart of pouchd like the sandbox.
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	err = cmd.Start()
+	w.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start slirp4netns for sandbox %q: %v", podNetwork.ID, err)
+	}
+	go cmd.Wait()
+
+	defer func() {
+		if retErr != nil {
+			if err := s.TearDownPodNetwork(podNetwork, nil); err != nil {
+				log.With(nil).Errorf("failed to stop slirp4netns for sandbox %q: %v", podNetwork.ID, err)
+			}
+		}
+	}()
+
+	// the start time is recorded with the pid, so that the pid reused by another
+	// process is never killed.
+	stat, err := system.GetProcessStat("/proc", cmd.Process.Pid)
+	if err != nil {
+		cmd.Process.Kill()
+		return nil, err
+	}
+	if err := ioutil.WriteFile(s.pidFile(podNetwork.ID), []byte(fmt.Sprintf("%d %d", cmd.Process.Pid, stat.StartTime)), 0600); err != nil {
+		cmd.Process.Kill()
+		return nil, err
+	}
+
+	if err := waitSlirpReady(ready, slirpReadyTimeout); err != nil {
+		return nil, fmt.Errorf("failed to wait for slirp4netns of sandbox %q: %v", podNetwork.ID, err)
+	}
+
+	for _, rc := range podNetwork.RuntimeConfig {
+		for _, pm := range rc.PortMappings {
+			if err := addSlirpHostForward(s.apiSocket(podNetwork.ID), pm); err != nil {
+				return nil, fmt.Errorf("failed to forward host port %d of sandbox %q: %v", pm.HostPort, podNetwork.ID, err)
+			}
+		}
+	}
+
+	return []*NetworkResult{slirpResult()}, nil
+}
+
+// waitSlirpReady waits for slirp4netns to write into the ready fd.
+func waitSlirpReady(ready *os.File, timeout time.Duration) error {
+	errCh := make(chan error, 1)
+	go func() {
+		buf := make([]byte, 1)
+		_, err := ready.Read(buf)
+		errCh <- err
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-time.After(timeout):
+		return fmt.Errorf("timeout after %s", timeout)
+	}
+}
+
+// slirpResult returns the network result of the sandbox connected by slirp4netns.
+// Every sandbox has a dedicated slirp4netns which assigns the same address, that
+// is not reachable out of the sandbox, so no address of sandbox is reported and
+// the sandbox is only reachable through the forwarded host ports.
+func slirpResult() *NetworkResult {
+	return &NetworkResult{
+		Network: SlirpNetworkName,
+		IfName:  slirpIfName,
+		Result: &cnicurrent.Result{
+			CNIVersion: cnicurrent.ImplementedSpecVersion,
+			Interfaces: []*cnicurrent.Interface{{Name: slirpIfName}},
+		},
+	}
+}
+
+// isInitialUserNS returns true if the uid map is the one of the initial user namespace.
+func isInitialUserNS(uidMap string) bool {
+	fields := strings.Fields(uidMap)
+	return len(fields) == 3 && fields[0] == "0" && fields[1] == "0" && fields[2] == "4294967295"
+}
+
+// slirpRequest is the request of the api of slirp4netns.
+type slirpRequest struct {
+	Execute   string                 `json:"execute"`
+	Arguments map[string]interface{} `json:"arguments,omitempty"`
+}
+
+// addSlirpHostForward forwards the host port to the sandbox by the api of slirp4netns.
+func addSlirpHostForward(socket string, pm ocicni.PortMapping) error {
+	proto := strings.ToLower(pm.Protocol)
+	if proto == "" {
+		proto = "tcp"
+	}
+	hostIP := pm.HostIP
+	if hostIP == "" {
+		hostIP = "0.0.0.0"
+	}
+
+	conn, err := net.Dial("unix", socket)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if err := json.NewEncoder(conn).Encode(&slirpRequest{
+		Execute: "add_hostfwd",
+		Arguments: map[string]interface{}{
+			"proto":      proto,
+			"host_addr":  hostIP,
+			"host_port":  pm.HostPort,
+			"guest_port": pm.ContainerPort,
+		},
+	}); err != nil {
+		return err
+	}
+	conn.(*net.UnixConn).CloseWrite()
+
+	var resp map[string]interface{}
+	if err := json.NewDecoder(conn).Decode(&resp); err != nil {
+		return err
+	}
+	if e, ok := resp["error"]; ok {
+		return fmt.Errorf("%v", e)
+	}
+	return nil
+}
+
+// TearDownPodNetwork stops the slirp4netns of sandbox.
+func (s *slirpManager) TearDownPodNetwork(podNetwork *PodNetwork, prevResults []*NetworkResult) error {
+	defer os.Remove(s.apiSocket(podNetwork.ID))
+
+	data, err := ioutil.ReadFile(s.pidFile(podNetwork.ID))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	var pid int
+	var startTime uint64
+	if _, err := fmt.Sscanf(string(data), "%d %d", &pid, &startTime); err != nil {
+		return fmt.Errorf("invalid pid file of slirp4netns for sandbox %q: %v", podNetwork.ID, err)
+	}
+	// the process exited if its pid is gone or reused by another process.
+	if stat, err := system.GetProcessStat("/proc", pid); err == nil && stat.StartTime == startTime {
+		if err := syscall.Kill(pid, syscall.SIGTERM); err != nil && err != syscall.ESRCH {
+			return fmt.Errorf("failed to stop slirp4netns %d for sandbox %q: %v", pid, podNetwork.ID, err)
+		}
+	}
+	return os.Remove(s.pidFile(podNetwork.ID))
+}
+
+// GetPodNetworkStatus returns no address since the address assigned by slirp4netns
+// is the same in all the sandboxes.
+func (s *slirpManager) GetPodNetworkStatus(netnsPath string) (string, error) {
+	return "", nil
+}
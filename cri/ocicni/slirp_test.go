@@ -0,0 +1,113 @@
+package ocicni
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"testing"
+
+	"github.com/alibaba/pouch/pkg/system"
+
+	"github.com/cri-o/ocicni/pkg/ocicni"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlirpResult(t *testing.T) {
+	result := slirpResult()
+	assert.Equal(t, SlirpNetworkName, result.Network)
+	assert.Equal(t, "eth0", result.IfName)
+	// the address shared by all the sandboxes is never reported.
+	assert.Empty(t, result.Result.IPs)
+	assert.Equal(t, "", PodIP([]*NetworkResult{result}))
+}
+
+func TestIsInitialUserNS(t *testing.T) {
+	assert.True(t, isInitialUserNS("         0          0 4294967295\n"))
+	assert.False(t, isInitialUserNS("         0       1000          1\n         1     100000      65536\n"))
+}
+
+func TestAddSlirpHostForward(t *testing.T) {
+	dir, err := ioutil.TempDir("", "slirp")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	socket := filepath.Join(dir, "api.sock")
+	l, err := net.Listen("unix", socket)
+	assert.NoError(t, err)
+	defer l.Close()
+
+	requests := make(chan slirpRequest, 2)
+	go func() {
+		for i := 0; i < 2; i++ {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			var req slirpRequest
+			json.NewDecoder(conn).Decode(&req)
+			requests <- req
+			if req.Arguments["host_port"].(float64) == 8080 {
+				conn.Write([]byte(`{"return": {"id": 1}}`))
+			} else {
+				conn.Write([]byte(`{"error": {"desc": "bad request"}}`))
+			}
+			conn.Close()
+		}
+	}()
+
+	assert.NoError(t, addSlirpHostForward(socket, ocicni.PortMapping{HostPort: 8080, ContainerPort: 80}))
+	req := <-requests
+	assert.Equal(t, "add_hostfwd", req.Execute)
+	assert.Equal(t, map[string]interface{}{
+		"proto":      "tcp",
+		"host_addr":  "0.0.0.0",
+		"host_port":  float64(8080),
+		"guest_port": float64(80),
+	}, req.Arguments)
+
+	assert.Error(t, addSlirpHostForward(socket, ocicni.PortMapping{HostPort: 53, ContainerPort: 53, Protocol: "UDP"}))
+	req = <-requests
+	assert.Equal(t, "udp", req.Arguments["proto"])
+}
+
+func TestSlirpTearDownPodNetwork(t *testing.T) {
+	dir, err := ioutil.TempDir("", "slirp")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	s := &slirpManager{stateDir: dir}
+
+	// nothing to stop if slirp4netns is not started.
+	assert.NoError(t, s.TearDownPodNetwork(&PodNetwork{ID: "s1"}, nil))
+
+	// the exited slirp4netns is ignored.
+	assert.NoError(t, ioutil.WriteFile(s.pidFile("s2"), []byte(strconv.Itoa(1<<22+1)+" 100"), 0600))
+	assert.NoError(t, s.TearDownPodNetwork(&PodNetwork{ID: "s2"}, nil))
+	_, err = os.Stat(s.pidFile("s2"))
+	assert.True(t, os.IsNotExist(err))
+
+	// the pid reused by another process is never killed.
+	cmd := exec.Command("sleep", "10")
+	assert.NoError(t, cmd.Start())
+	defer cmd.Process.Kill()
+	stat, err := system.GetProcessStat("/proc", cmd.Process.Pid)
+	assert.NoError(t, err)
+	assert.NoError(t, ioutil.WriteFile(s.pidFile("s3"), []byte(fmt.Sprintf("%d %d", cmd.Process.Pid, stat.StartTime+1)), 0600))
+	assert.NoError(t, s.TearDownPodNetwork(&PodNetwork{ID: "s3"}, nil))
+	assert.NoError(t, cmd.Process.Signal(syscall.Signal(0)))
+
+	// the slirp4netns started is stopped.
+	assert.NoError(t, ioutil.WriteFile(s.pidFile("s4"), []byte(fmt.Sprintf("%d %d", cmd.Process.Pid, stat.StartTime)), 0600))
+	assert.NoError(t, s.TearDownPodNetwork(&PodNetwork{ID: "s4"}, nil))
+	assert.Error(t, cmd.Wait())
+
+	ip, err := s.GetPodNetworkStatus("/var/run/netns/s1")
+	assert.NoError(t, err)
+	assert.Equal(t, "", ip)
+}
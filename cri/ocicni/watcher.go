@@ -0,0 +1,87 @@
+package ocicni
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/alibaba/pouch/pkg/log"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// confDirReloadDelay is the time to wait for more changes in the CNI configuration
+// directory before reloading, since deploying a configuration usually consists of
+// several file system events, e.g. create, write and rename.
+const confDirReloadDelay = 500 * time.Millisecond
+
+// watchConfDir starts to monitor the CNI configuration directory and reloads the
+// network configuration whenever a configuration file appears, changes or disappears.
+func (c *CniManager) watchConfDir() error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create watcher for cni conf dir %s: %v", c.networkPluginConfDir, err)
+	}
+
+	if err := watcher.Add(c.networkPluginConfDir); err != nil {
+		watcher.Close()
+		return fmt.Errorf("failed to watch cni conf dir %s: %v", c.networkPluginConfDir, err)
+	}
+
+	go c.monitorConfDir(watcher)
+	return nil
+}
+
+// monitorConfDir handles the events of CNI configuration directory until the watcher fails.
+func (c *CniManager) monitorConfDir(watcher *fsnotify.Watcher) {
+	defer watcher.Close()
+
+	var reload <-chan time.Time
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if event.Op&fsnotify.Chmod == event.Op {
+				continue
+			}
+			log.With(nil).Debugf("receive cni conf dir event %v", event)
+			// coalesce the burst of events into one reload.
+			reload = time.After(confDirReloadDelay)
+		case <-reload:
+			reload = nil
+			if err := c.reloadNetworks(); err != nil {
+				log.With(nil).Errorf("failed to reload cni configuration in %s: %v", c.networkPluginConfDir, err)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			log.With(nil).Errorf("failed to watch cni conf dir %s: %v", c.networkPluginConfDir, err)
+		}
+	}
+}
+
+// reloadNetworks loads the CNI configuration again and atomically replaces the networks in use,
+// after the CNI calls in flight are done.
+func (c *CniManager) reloadNetworks() error {
+	networks, err := loadNetworks(c.networkPluginConfDir)
+	if err != nil {
+		return err
+	}
+
+	c.Lock()
+	old := c.networks
+	c.networks = networks
+	c.Unlock()
+
+	switch {
+	case old.defaultNetName == "" && networks.defaultNetName != "":
+		log.With(nil).Infof("cni network becomes ready with default network %q", networks.defaultNetName)
+	case old.defaultNetName != "" && networks.defaultNetName == "":
+		log.With(nil).Warnf("cni network becomes not ready: %v", errMissingDefaultNetwork)
+	default:
+		log.With(nil).Infof("cni configuration reloaded, default network is %q", networks.defaultNetName)
+	}
+	return nil
+}
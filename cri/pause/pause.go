@@ -0,0 +1,38 @@
+// Command pouch-pause is the tiny init backing the sandboxes in the built-in
+// pause mode of cri, which holds the namespaces of pod and reaps the zombies
+// reparented to it. It's built statically so that it runs in an empty rootfs.
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+func main() {
+	if os.Getpid() != 1 {
+		fmt.Fprintln(os.Stderr, "Warning: pause should be the first process")
+	}
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM, syscall.SIGCHLD)
+	for sig := range sigs {
+		if sig != syscall.SIGCHLD {
+			fmt.Fprintf(os.Stderr, "Shutting down, got signal: %v\n", sig)
+			os.Exit(0)
+		}
+		reap()
+	}
+}
+
+// reap waits for all the exited children without blocking.
+func reap() {
+	for {
+		var status syscall.WaitStatus
+		pid, err := syscall.Wait4(-1, &status, syscall.WNOHANG, nil)
+		if pid <= 0 || err != nil {
+			return
+		}
+	}
+}
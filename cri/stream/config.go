@@ -38,6 +38,10 @@ type Config struct {
 	// If empty, the baseURL will be constructed from the serve address.
 	BaseURL *url.URL
 
+	// BehindProxy means the server is behind a reverse proxy, the X-Forwarded
+	// headers of requests are trusted.
+	BehindProxy bool
+
 	// StreamIdleTimeout is how long to leave idle connections open for.
 	StreamIdleTimeout time.Duration
 	// StreamCreationTimeout is how long to wait for clients to create streams. Only used for SPDY streaming.
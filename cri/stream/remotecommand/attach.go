@@ -5,12 +5,14 @@ import (
 	"fmt"
 	"net/http"
 	"time"
+
+	apitypes "github.com/alibaba/pouch/apis/types"
 )
 
 // Attacher knows how to attach a running container in a pod.
 type Attacher interface {
 	// Attach attaches to the running container in the pod.
-	Attach(ctx context.Context, containerID string, streamOpts *Options, streams *Streams) error
+	Attach(ctx context.Context, containerID string, resizeChan <-chan apitypes.ResizeOptions, streamOpts *Options, streams *Streams) error
 }
 
 // ServeAttach handles requests to attach to a container. After creating/receiving the required
@@ -23,7 +25,7 @@ func ServeAttach(ctx context.Context, w http.ResponseWriter, req *http.Request,
 	}
 	defer streamCtx.conn.Close()
 
-	err := attacher.Attach(ctx, container, streamOpts, &Streams{
+	err := attacher.Attach(ctx, container, streamCtx.resizeChan, streamOpts, &Streams{
 		StdinStream:  streamCtx.stdinStream,
 		StdoutStream: streamCtx.stdoutStream,
 		StderrStream: streamCtx.stderrStream,
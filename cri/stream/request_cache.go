@@ -19,7 +19,7 @@ var (
 	TokenLen = 8
 )
 
-// RequestCache caches streaming (exec/attach/port-forward) requests and generates a single-use
+// RequestCache caches streaming (exec/attach/port-forward/copy) requests and generates a single-use
 // random token for their retrieval. The requestCache is used for building streaming URLs without
 // the need to encode every request parameter in the URL.
 type RequestCache struct {
@@ -31,7 +31,7 @@ type RequestCache struct {
 	lock sync.Mutex
 }
 
-// Request representing an *ExecRequest, *AttachRequest, or *PortForwardRequest Type.
+// Request representing an *ExecRequest, *AttachRequest, *PortForwardRequest or *CopyRequest Type.
 type Request interface{}
 
 type cacheEntry struct {
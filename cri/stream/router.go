@@ -12,4 +12,5 @@ type Router interface {
 	ServeExec(w http.ResponseWriter, r *http.Request)
 	ServeAttach(w http.ResponseWriter, r *http.Request)
 	ServePortForward(w http.ResponseWriter, r *http.Request)
+	ServeCopy(w http.ResponseWriter, r *http.Request)
 }
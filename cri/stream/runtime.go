@@ -7,6 +7,7 @@ import (
 	"io"
 	"os/exec"
 	"strings"
+	"time"
 
 	apitypes "github.com/alibaba/pouch/apis/types"
 	"github.com/alibaba/pouch/cri/stream/remotecommand"
@@ -21,12 +22,30 @@ type Runtime interface {
 	Exec(ctx context.Context, containerID string, cmd []string, resizeChan <-chan apitypes.ResizeOptions, streamOpts *remotecommand.Options, streams *remotecommand.Streams) (uint32, error)
 
 	// Attach attaches to pod.
-	Attach(ctx context.Context, containerID string, streamOpts *remotecommand.Options, streams *remotecommand.Streams) error
+	Attach(ctx context.Context, containerID string, resizeChan <-chan apitypes.ResizeOptions, streamOpts *remotecommand.Options, streams *remotecommand.Streams) error
 
 	// PortForward forward port to pod.
 	PortForward(ctx context.Context, name string, port int32, stream io.ReadWriteCloser) error
+
+	// CopyFromContainer returns the tar archive of the path in container.
+	CopyFromContainer(ctx context.Context, containerID string, path string) (io.ReadCloser, error)
+
+	// CopyToContainer extracts the tar archive into the path in container.
+	CopyToContainer(ctx context.Context, containerID string, path string, opts *CopyOptions, content io.Reader) error
 }
 
+// CopyOptions are the options to copy files into container.
+type CopyOptions struct {
+	// CopyUIDGID sets the owner of the files extracted to the user and group of container.
+	CopyUIDGID bool
+	// NoOverwriteDirNonDir forbids replacing a directory with a non-directory and vice versa.
+	NoOverwriteDirNonDir bool
+}
+
+// initialTTYSizeTimeout is the time to wait for the initial size of tty from
+// the client before the exec process is started.
+var initialTTYSizeTimeout = 200 * time.Millisecond
+
 type streamRuntime struct {
 	containerMgr mgr.ContainerMgr
 }
@@ -51,6 +70,16 @@ func (s *streamRuntime) Exec(ctx context.Context, containerID string, cmd []stri
 		return 0, fmt.Errorf("failed to create exec for container %q: %v", containerID, err)
 	}
 
+	// the size received before the exec process is started is taken as the
+	// initial one, so that the first prompt is not wrapped by the default size.
+	if createConfig.Tty {
+		if size, ok := initialTTYSize(resizeChan, initialTTYSizeTimeout); ok {
+			if err := s.containerMgr.ResizeExec(ctx, execid, size); err != nil {
+				log.With(ctx).Warnf("failed to set initial console size of process %q for container %q: %v", execid, containerID, err)
+			}
+		}
+	}
+
 	handleResizing(containerID, execid, resizeChan, func(size apitypes.ResizeOptions) {
 		err := s.containerMgr.ResizeExec(ctx, execid, size)
 		if err != nil {
@@ -79,6 +108,30 @@ func (s *streamRuntime) Exec(ctx context.Context, containerID string, cmd []stri
 	return uint32(ei.ExitCode), nil
 }
 
+// initialTTYSize waits for the first valid size of tty from the resize channel,
+// the clients send it once the streams are created if the terminal is used.
+func initialTTYSize(resizeChan <-chan apitypes.ResizeOptions, timeout time.Duration) (apitypes.ResizeOptions, bool) {
+	if resizeChan == nil {
+		return apitypes.ResizeOptions{}, false
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		select {
+		case size, ok := <-resizeChan:
+			if !ok {
+				return apitypes.ResizeOptions{}, false
+			}
+			if size.Height > 0 && size.Width > 0 {
+				return size, true
+			}
+		case <-timer.C:
+			return apitypes.ResizeOptions{}, false
+		}
+	}
+}
+
 // handleResizing spawns a goroutine that processes the resize channel, calling resizeFunc for each
 // remotecommand.TerminalSize received from the channel. The resize channel must be closed elsewhere to stop the
 // goroutine.
@@ -101,16 +154,26 @@ func handleResizing(containerID, execID string, resizeChan <-chan apitypes.Resiz
 }
 
 // Attach attaches to a running container.
-func (s *streamRuntime) Attach(ctx context.Context, containerID string, streamOpts *remotecommand.Options, streams *remotecommand.Streams) error {
-	// TODO(fuweid): could we close stdin after stop attach?
+func (s *streamRuntime) Attach(ctx context.Context, containerID string, resizeChan <-chan apitypes.ResizeOptions, streamOpts *remotecommand.Options, streams *remotecommand.Streams) error {
+	// propagates the resize events to the tty of container.
+	handleResizing(containerID, "", resizeChan, func(size apitypes.ResizeOptions) {
+		if err := s.containerMgr.Resize(ctx, containerID, size); err != nil {
+			log.With(ctx).Errorf("failed to resize console of container %q: %v", containerID, err)
+		}
+	})
+
+	// the stdin of container is closed with the client's one only if it is
+	// created with stdin_once, otherwise the output is still streamed after
+	// the client closes its stdin, which matches the dockershim.
 	attachCfg := &pkgstreams.AttachConfig{
-		UseStdin:  streamOpts.Stdin,
-		Stdin:     streams.StdinStream,
-		UseStdout: streamOpts.Stdout,
-		Stdout:    streams.StdoutStream,
-		UseStderr: streamOpts.Stderr,
-		Stderr:    streams.StderrStream,
-		Terminal:  streamOpts.TTY,
+		UseStdin:       streamOpts.Stdin,
+		Stdin:          streams.StdinStream,
+		UseStdout:      streamOpts.Stdout,
+		Stdout:         streams.StdoutStream,
+		UseStderr:      streamOpts.Stderr,
+		Stderr:         streams.StderrStream,
+		Terminal:       streamOpts.TTY,
+		HonorStdinOnce: true,
 	}
 	if err := s.containerMgr.AttachContainerIO(ctx, containerID, attachCfg); err != nil {
 		return fmt.Errorf("failed to attach to container %q: %v", containerID, err)
@@ -180,3 +243,20 @@ func (s *streamRuntime) PortForward(ctx context.Context, id string, port int32,
 
 	return nil
 }
+
+// CopyFromContainer returns the tar archive of the path in container.
+func (s *streamRuntime) CopyFromContainer(ctx context.Context, containerID string, path string) (io.ReadCloser, error) {
+	content, _, err := s.containerMgr.ArchivePath(ctx, containerID, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy %q from container %q: %v", path, containerID, err)
+	}
+	return content, nil
+}
+
+// CopyToContainer extracts the tar archive into the path in container.
+func (s *streamRuntime) CopyToContainer(ctx context.Context, containerID string, path string, opts *CopyOptions, content io.Reader) error {
+	if err := s.containerMgr.ExtractToDir(ctx, containerID, path, opts.CopyUIDGID, opts.NoOverwriteDirNonDir, content); err != nil {
+		return fmt.Errorf("failed to copy to %q of container %q: %v", path, containerID, err)
+	}
+	return nil
+}
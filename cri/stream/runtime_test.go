@@ -0,0 +1,71 @@
+package stream
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	apitypes "github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/cri/stream/remotecommand"
+	"github.com/alibaba/pouch/daemon/mgr"
+	pkgstreams "github.com/alibaba/pouch/pkg/streams"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInitialTTYSize(t *testing.T) {
+	_, ok := initialTTYSize(nil, time.Second)
+	assert.False(t, ok)
+
+	// the invalid sizes are skipped.
+	ch := make(chan apitypes.ResizeOptions, 2)
+	ch <- apitypes.ResizeOptions{}
+	ch <- apitypes.ResizeOptions{Height: 24, Width: 80}
+	size, ok := initialTTYSize(ch, time.Second)
+	assert.True(t, ok)
+	assert.Equal(t, apitypes.ResizeOptions{Height: 24, Width: 80}, size)
+
+	// no size is sent by the client.
+	_, ok = initialTTYSize(make(chan apitypes.ResizeOptions), 10*time.Millisecond)
+	assert.False(t, ok)
+
+	close(ch)
+	_, ok = initialTTYSize(ch, time.Second)
+	assert.False(t, ok)
+}
+
+type fakeAttachMgr struct {
+	mgr.ContainerMgr
+	resized chan apitypes.ResizeOptions
+	cfg     *pkgstreams.AttachConfig
+}
+
+func (m *fakeAttachMgr) Resize(ctx context.Context, name string, opts apitypes.ResizeOptions) error {
+	m.resized <- opts
+	return nil
+}
+
+func (m *fakeAttachMgr) AttachContainerIO(ctx context.Context, name string, cfg *pkgstreams.AttachConfig) error {
+	m.cfg = cfg
+	return nil
+}
+
+func TestAttachResize(t *testing.T) {
+	m := &fakeAttachMgr{resized: make(chan apitypes.ResizeOptions, 1)}
+	runtime := NewStreamRuntime(m)
+
+	resizeChan := make(chan apitypes.ResizeOptions)
+	defer close(resizeChan)
+	assert.NoError(t, runtime.Attach(context.Background(), "c1", resizeChan, &remotecommand.Options{TTY: true}, &remotecommand.Streams{}))
+
+	resizeChan <- apitypes.ResizeOptions{Height: 40, Width: 120}
+	select {
+	case size := <-m.resized:
+		assert.Equal(t, apitypes.ResizeOptions{Height: 40, Width: 120}, size)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the console of container is not resized")
+	}
+
+	// the stdin of container is closed with the client's one only if stdin_once.
+	assert.True(t, m.cfg.HonorStdinOnce)
+}
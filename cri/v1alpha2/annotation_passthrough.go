@@ -0,0 +1,61 @@
+package v1alpha2
+
+import (
+	"fmt"
+	"strings"
+)
+
+// parsePassthroughAnnotations validates the patterns of annotations passed
+// through to the OCI spec, which are either the exact keys or the prefixes
+// ending with "*", e.g. io.katacontainers.*.
+func parsePassthroughAnnotations(patterns []string) ([]string, error) {
+	var result []string
+	for _, p := range patterns {
+		p = strings.TrimSpace(p)
+		if p == "" || p == "*" || strings.Contains(strings.TrimSuffix(p, "*"), "*") {
+			return nil, fmt.Errorf("invalid passthrough annotation %q, should be a key or a prefix ending with *", p)
+		}
+		result = append(result, p)
+	}
+	return result, nil
+}
+
+// matchPassthroughAnnotation returns whether the annotation key matches any pattern.
+func matchPassthroughAnnotation(patterns []string, key string) bool {
+	for _, p := range patterns {
+		if strings.HasSuffix(p, "*") {
+			if strings.HasPrefix(key, strings.TrimSuffix(p, "*")) {
+				return true
+			}
+		} else if p == key {
+			return true
+		}
+	}
+	return false
+}
+
+// passthroughAnnotations copies the annotations matched by the patterns into
+// the spec annotations, so that the runtime specific tuning, e.g. the ones of
+// kata containers, reaches the shim. The later annotations take precedence,
+// while the spec annotations set by cri are never overridden.
+func passthroughAnnotations(patterns []string, specAnnotation map[string]string, annotations ...map[string]string) {
+	if len(patterns) == 0 {
+		return
+	}
+
+	reserved := make(map[string]struct{}, len(specAnnotation))
+	for k := range specAnnotation {
+		reserved[k] = struct{}{}
+	}
+
+	for _, a := range annotations {
+		for k, v := range a {
+			if _, ok := reserved[k]; ok {
+				continue
+			}
+			if matchPassthroughAnnotation(patterns, k) {
+				specAnnotation[k] = v
+			}
+		}
+	}
+}
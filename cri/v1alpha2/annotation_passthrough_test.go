@@ -0,0 +1,48 @@
+package v1alpha2
+
+import (
+	"testing"
+
+	anno "github.com/alibaba/pouch/cri/annotations"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePassthroughAnnotations(t *testing.T) {
+	patterns, err := parsePassthroughAnnotations([]string{"io.katacontainers.*", " foo "})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"io.katacontainers.*", "foo"}, patterns)
+
+	for _, p := range []string{"", "*", "io.*.foo", "io.**"} {
+		_, err := parsePassthroughAnnotations([]string{p})
+		assert.Error(t, err, p)
+	}
+}
+
+func TestPassthroughAnnotations(t *testing.T) {
+	patterns := []string{"io.katacontainers.*", "foo"}
+	podAnnotations := map[string]string{
+		"io.katacontainers.config.hypervisor.default_vcpus":      "2",
+		"io.katacontainers.config.hypervisor.enable_mem_hotplug": "true",
+		"foo":          "pod",
+		"foobar":       "pod",
+		anno.SandboxID: "fake",
+	}
+	containerAnnotations := map[string]string{
+		"io.katacontainers.config.hypervisor.default_vcpus": "4",
+	}
+
+	specAnnotation := map[string]string{anno.SandboxID: "s1"}
+	passthroughAnnotations(patterns, specAnnotation, podAnnotations, containerAnnotations)
+	assert.Equal(t, map[string]string{
+		anno.SandboxID: "s1",
+		"io.katacontainers.config.hypervisor.default_vcpus":      "4",
+		"io.katacontainers.config.hypervisor.enable_mem_hotplug": "true",
+		"foo": "pod",
+	}, specAnnotation)
+
+	// nothing is passed through without patterns.
+	specAnnotation = map[string]string{}
+	passthroughAnnotations(nil, specAnnotation, podAnnotations)
+	assert.Empty(t, specAnnotation)
+}
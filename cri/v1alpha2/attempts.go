@@ -0,0 +1,79 @@
+package v1alpha2
+
+import (
+	"sync"
+
+	"github.com/alibaba/pouch/daemon/mgr"
+	"github.com/alibaba/pouch/pkg/log"
+
+	"golang.org/x/net/context"
+)
+
+// attemptCounter keeps the latest attempt of each container name in sandboxes.
+// kubelet increases the attempt every time it recreates the container, so the
+// latest attempt is the number of restarts of the container.
+type attemptCounter struct {
+	sync.Mutex
+	// attempts are indexed by sandbox id and container name.
+	attempts map[string]map[string]uint32
+}
+
+// newAttemptCounter creates an empty attempt counter.
+func newAttemptCounter() *attemptCounter {
+	return &attemptCounter{
+		attempts: make(map[string]map[string]uint32),
+	}
+}
+
+// observe records the attempt of container, the older attempt is ignored.
+func (a *attemptCounter) observe(sandboxID, name string, attempt uint32) {
+	a.Lock()
+	defer a.Unlock()
+
+	names, ok := a.attempts[sandboxID]
+	if !ok {
+		names = make(map[string]uint32)
+		a.attempts[sandboxID] = names
+	}
+	if latest, ok := names[name]; !ok || attempt > latest {
+		names[name] = attempt
+	}
+}
+
+// latest returns the latest attempt of container, and whether it's observed.
+func (a *attemptCounter) latest(sandboxID, name string) (uint32, bool) {
+	a.Lock()
+	defer a.Unlock()
+
+	attempt, ok := a.attempts[sandboxID][name]
+	return attempt, ok
+}
+
+// removeSandbox forgets the attempts of containers in the sandbox.
+func (a *attemptCounter) removeSandbox(sandboxID string) {
+	a.Lock()
+	defer a.Unlock()
+
+	delete(a.attempts, sandboxID)
+}
+
+// restoreAttempts rebuilds the attempt counter from the existing containers.
+func (c *CriManager) restoreAttempts(ctx context.Context) error {
+	containers, err := c.ContainerMgr.List(ctx, &mgr.ContainerListOption{
+		All:    true,
+		Labels: map[string]string{containerTypeLabelKey: containerTypeLabelContainer},
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, container := range containers {
+		metadata, err := containerMetadata(container)
+		if err != nil {
+			log.With(ctx).Warnf("failed to get metadata of container %q: %v", container.ID, err)
+			continue
+		}
+		c.attempts.observe(container.Config.Labels[sandboxIDLabelKey], metadata.Name, metadata.Attempt)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package v1alpha2
+
+import (
+	"testing"
+
+	apitypes "github.com/alibaba/pouch/apis/types"
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+	"github.com/alibaba/pouch/daemon/mgr"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttemptCounter(t *testing.T) {
+	a := newAttemptCounter()
+
+	_, ok := a.latest("s1", "nginx")
+	assert.False(t, ok)
+
+	a.observe("s1", "nginx", 2)
+	a.observe("s1", "nginx", 1)
+	a.observe("s1", "sidecar", 0)
+	a.observe("s2", "nginx", 5)
+
+	attempt, ok := a.latest("s1", "nginx")
+	assert.True(t, ok)
+	assert.Equal(t, uint32(2), attempt)
+
+	attempt, ok = a.latest("s1", "sidecar")
+	assert.True(t, ok)
+	assert.Equal(t, uint32(0), attempt)
+
+	a.removeSandbox("s1")
+	_, ok = a.latest("s1", "nginx")
+	assert.False(t, ok)
+
+	attempt, _ = a.latest("s2", "nginx")
+	assert.Equal(t, uint32(5), attempt)
+}
+
+func TestContainerMetadata(t *testing.T) {
+	// the metadata in labels takes precedence over the name.
+	c := &mgr.Container{
+		Name: "k8s_nginx_pod_default_uid_1",
+		Config: &apitypes.ContainerConfig{Labels: map[string]string{
+			metadataNameLabelKey:    "nginx_proxy",
+			metadataAttemptLabelKey: "3",
+		}},
+	}
+	metadata, err := containerMetadata(c)
+	assert.NoError(t, err)
+	assert.Equal(t, &runtime.ContainerMetadata{Name: "nginx_proxy", Attempt: 3}, metadata)
+
+	c.Config.Labels = map[string]string{}
+	metadata, err = containerMetadata(c)
+	assert.NoError(t, err)
+	assert.Equal(t, &runtime.ContainerMetadata{Name: "nginx", Attempt: 1}, metadata)
+
+	c.Config.Labels = map[string]string{metadataNameLabelKey: "nginx", metadataAttemptLabelKey: "x"}
+	_, err = containerMetadata(c)
+	assert.Error(t, err)
+}
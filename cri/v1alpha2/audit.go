@@ -0,0 +1,245 @@
+package v1alpha2
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+	"github.com/alibaba/pouch/daemon/logger"
+	"github.com/alibaba/pouch/daemon/logger/jsonfile"
+	"github.com/alibaba/pouch/pkg/grpc/interceptor"
+	"github.com/alibaba/pouch/pkg/log"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	runtimeHandlerField = "RuntimeHandler"
+	cmdField            = "Cmd"
+)
+
+// auditMethods are the mutating cri methods recorded in the audit log besides
+// the ones published as the events, which run commands in or connect to the
+// containers, or change the runtime.
+var auditMethods = map[string]bool{
+	"ExecSync":            true,
+	"Exec":                true,
+	"Attach":              true,
+	"PortForward":         true,
+	"ReopenContainerLog":  true,
+	"UpdateRuntimeConfig": true,
+}
+
+// isAuditMethod returns whether the cri method should be recorded in the audit log.
+func isAuditMethod(method string) bool {
+	return auditMethods[method] || criEventMethods[method]
+}
+
+// peerCred is the credentials of the peer process on the unix socket.
+type peerCred struct {
+	PID int32  `json:"pid"`
+	UID uint32 `json:"uid"`
+	GID uint32 `json:"gid"`
+}
+
+// auditCaller is the identity of the caller of cri request.
+type auditCaller struct {
+	Addr string    `json:"addr,omitempty"`
+	Cred *peerCred `json:"cred,omitempty"`
+}
+
+// auditRecord is a line of the audit log of the cri request.
+type auditRecord struct {
+	Time      time.Time         `json:"time"`
+	RequestID string            `json:"requestID,omitempty"`
+	Method    string            `json:"method"`
+	Caller    auditCaller       `json:"caller"`
+	Request   map[string]string `json:"request,omitempty"`
+	Code      string            `json:"code"`
+	Error     string            `json:"error,omitempty"`
+	Latency   float64           `json:"latencySeconds"`
+}
+
+// auditLogger writes the audit records as the json lines into the file,
+// which is rotated by the size.
+type auditLogger struct {
+	file *jsonfile.JSONLogFile
+}
+
+// newAuditLogger creates the audit logger writing into the file, which is
+// rotated once it reaches maxSize and at most maxFiles files are kept.
+func newAuditLogger(file, maxSize string, maxFiles int) (*auditLogger, error) {
+	if !filepath.IsAbs(file) {
+		return nil, fmt.Errorf("path %q of audit log must be absolute", file)
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0700); err != nil {
+		return nil, err
+	}
+
+	f, err := jsonfile.NewJSONLogFile(file, 0600, map[string]string{
+		"max-size": maxSize,
+		"max-file": strconv.Itoa(maxFiles),
+	}, func(msg *logger.LogMessage) ([]byte, error) {
+		return msg.Line, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log %s: %v", file, err)
+	}
+	return &auditLogger{file: f}, nil
+}
+
+// write appends the record into the audit log.
+func (a *auditLogger) write(record *auditRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return a.file.WriteLogMessage(&logger.LogMessage{Line: append(line, '\n')})
+}
+
+// close closes the file of audit log.
+func (a *auditLogger) close() error {
+	return a.file.Close()
+}
+
+// auditUnaryServerInterceptor records the mutating cri requests in the audit
+// log with the caller, the summary of request, the result and the latency.
+func auditUnaryServerInterceptor(criMgr CriMgr) grpc.UnaryServerInterceptor {
+	c, _ := criMgr.(*CriManager)
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		method := path.Base(info.FullMethod)
+		if c == nil || c.auditLog == nil || !isAuditMethod(method) {
+			return handler(ctx, req)
+		}
+
+		start := time.Now()
+		// the pod metadata is gone once the sandbox is removed, so it's got
+		// before the request is handled.
+		fields := c.contextRequestFields(ctx, req)
+		resp, err := handler(ctx, req)
+
+		record := &auditRecord{
+			Time:    start.UTC(),
+			Method:  method,
+			Caller:  auditCallerFromContext(ctx),
+			Request: auditRequestSummary(req, fields),
+			Code:    status.Code(err).String(),
+			Latency: time.Since(start).Seconds(),
+		}
+		record.RequestID, _ = interceptor.RequestIDFromContext(ctx)
+		if err != nil {
+			record.Error = err.Error()
+		}
+		if werr := c.auditLog.write(record); werr != nil {
+			log.With(ctx).Errorf("failed to write audit log of %s: %v", method, werr)
+		}
+		return resp, err
+	}
+}
+
+// auditRequestSummary returns the summary of the cri request, which is the
+// attributes of event, and the runtime handler or the command if any.
+func auditRequestSummary(req interface{}, fields map[string]interface{}) map[string]string {
+	summary := requestAttributes(req, fields)
+	switch r := req.(type) {
+	case *runtime.RunPodSandboxRequest:
+		summary[runtimeHandlerField] = r.GetRuntimeHandler()
+	case *runtime.ExecSyncRequest:
+		summary[cmdField] = strings.Join(r.GetCmd(), " ")
+	case *runtime.ExecRequest:
+		summary[cmdField] = strings.Join(r.GetCmd(), " ")
+	}
+	return summary
+}
+
+// auditCallerFromContext returns the caller of the request, the credentials
+// are only known on the unix socket.
+func auditCallerFromContext(ctx context.Context) auditCaller {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return auditCaller{}
+	}
+
+	caller := auditCaller{Addr: p.Addr.String()}
+	if addr, ok := p.Addr.(*peerCredAddr); ok {
+		caller.Addr = addr.Addr.String()
+		caller.Cred = addr.cred
+	}
+	return caller
+}
+
+// peerCredAddr is the remote address of the unix socket connection carrying
+// the credentials of peer process.
+type peerCredAddr struct {
+	net.Addr
+	cred *peerCred
+}
+
+// peerCredConn is the unix socket connection with the credentials of peer.
+type peerCredConn struct {
+	net.Conn
+	addr *peerCredAddr
+}
+
+// RemoteAddr returns the remote address with the credentials of peer.
+func (c *peerCredConn) RemoteAddr() net.Addr {
+	return c.addr
+}
+
+// peerCredListener gets the credentials of the peers of the accepted unix
+// socket connections, which are passed to grpc as the remote addresses.
+type peerCredListener struct {
+	net.Listener
+}
+
+// Accept accepts the connection and gets the credentials of peer.
+func (l *peerCredListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+
+	uc, ok := conn.(*net.UnixConn)
+	if !ok {
+		return conn, nil
+	}
+	cred, err := unixPeerCred(uc)
+	if err != nil {
+		log.With(nil).Warnf("failed to get credentials of cri client: %v", err)
+		return conn, nil
+	}
+	return &peerCredConn{Conn: conn, addr: &peerCredAddr{Addr: conn.RemoteAddr(), cred: cred}}, nil
+}
+
+// unixPeerCred returns the credentials of the peer of unix socket connection.
+func unixPeerCred(conn *net.UnixConn) (*peerCred, error) {
+	raw, err := conn.SyscallConn()
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		ucred *syscall.Ucred
+		uerr  error
+	)
+	if err := raw.Control(func(fd uintptr) {
+		ucred, uerr = syscall.GetsockoptUcred(int(fd), syscall.SOL_SOCKET, syscall.SO_PEERCRED)
+	}); err != nil {
+		return nil, err
+	}
+	if uerr != nil {
+		return nil, uerr
+	}
+	return &peerCred{PID: ucred.Pid, UID: ucred.Uid, GID: ucred.Gid}, nil
+}
@@ -0,0 +1,114 @@
+package v1alpha2
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestAuditUnaryServerInterceptor(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cri-audit")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "audit", "cri.log")
+	auditLog, err := newAuditLogger(file, "100m", 2)
+	assert.NoError(t, err)
+	c := &CriManager{auditLog: auditLog}
+	interceptor := auditUnaryServerInterceptor(c)
+
+	cred := &peerCred{PID: 42, UID: 0, GID: 0}
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &peerCredAddr{Addr: &net.UnixAddr{Net: "unix"}, cred: cred}})
+	for _, tc := range []struct {
+		method string
+		req    interface{}
+		err    error
+	}{
+		{
+			method: "ExecSync",
+			req:    &runtime.ExecSyncRequest{ContainerId: "container1", Cmd: []string{"cat", "/etc/hosts"}},
+		},
+		{
+			method: "RemoveImage",
+			req:    &runtime.RemoveImageRequest{Image: &runtime.ImageSpec{Image: "busybox:latest"}},
+			err:    status.Errorf(codes.NotFound, "image not found"),
+		},
+		{
+			method: "ListContainers",
+			req:    &runtime.ListContainersRequest{},
+		},
+	} {
+		info := &grpc.UnaryServerInfo{FullMethod: "/runtime.v1alpha2.RuntimeService/" + tc.method}
+		_, err := interceptor(ctx, tc.req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, tc.err
+		})
+		assert.Equal(t, tc.err, err)
+	}
+	assert.NoError(t, auditLog.close())
+
+	f, err := os.Open(file)
+	assert.NoError(t, err)
+	defer f.Close()
+	info, err := f.Stat()
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	var records []auditRecord
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var record auditRecord
+		assert.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+		records = append(records, record)
+	}
+	assert.NoError(t, scanner.Err())
+
+	// the read-only requests are not recorded.
+	assert.Len(t, records, 2)
+	assert.Equal(t, "ExecSync", records[0].Method)
+	assert.Equal(t, cred, records[0].Caller.Cred)
+	assert.Equal(t, map[string]string{containerIDField: "container1", cmdField: "cat /etc/hosts"}, records[0].Request)
+	assert.Equal(t, "OK", records[0].Code)
+	assert.Equal(t, "", records[0].Error)
+
+	assert.Equal(t, "RemoveImage", records[1].Method)
+	assert.Equal(t, map[string]string{imageField: "busybox:latest"}, records[1].Request)
+	assert.Equal(t, "NotFound", records[1].Code)
+	assert.Contains(t, records[1].Error, "image not found")
+}
+
+func TestPeerCredListener(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cri-peer-cred")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	l, err := net.Listen("unix", filepath.Join(dir, "cri.sock"))
+	assert.NoError(t, err)
+	l = &peerCredListener{Listener: l}
+	defer l.Close()
+
+	client, err := net.Dial("unix", filepath.Join(dir, "cri.sock"))
+	assert.NoError(t, err)
+	defer client.Close()
+
+	conn, err := l.Accept()
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	addr, ok := conn.RemoteAddr().(*peerCredAddr)
+	if assert.True(t, ok) {
+		assert.Equal(t, &peerCred{PID: int32(os.Getpid()), UID: uint32(os.Getuid()), GID: uint32(os.Getgid())}, addr.cred)
+	}
+}
@@ -0,0 +1,275 @@
+package v1alpha2
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+	criconfig "github.com/alibaba/pouch/cri/config"
+	"github.com/alibaba/pouch/pkg/log"
+	"github.com/alibaba/pouch/pkg/utils"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// authzRequest is the mutating cri request to authorize, which is sent to the
+// authorization webhook as json.
+type authzRequest struct {
+	Method string      `json:"method"`
+	Caller auditCaller `json:"caller"`
+	// Attributes are the summary of request, e.g. the pod, the image and the command.
+	Attributes map[string]string `json:"attributes,omitempty"`
+	// Privileged is whether the container or the sandbox is privileged, or
+	// escapes the isolation of the others by the added capabilities, the
+	// namespaces of host or the host paths mounted.
+	Privileged bool `json:"privileged"`
+}
+
+// authzResponse is the decision of the authorization webhook.
+type authzResponse struct {
+	Allowed bool   `json:"allowed"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+// authzRule is a rule of the authorization policy, which matches the request
+// by all of its conditions, the empty ones match all the requests.
+type authzRule struct {
+	// Methods are the cri methods, e.g. CreateContainer and ExecSync.
+	Methods []string `json:"methods,omitempty"`
+	// Namespaces are the namespaces of pods.
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Privileged matches the privileged requests if true, or the unprivileged ones if false.
+	Privileged *bool `json:"privileged,omitempty"`
+	// Images are the regular expressions of images, e.g. "^registry.example.com/".
+	Images []string `json:"images,omitempty"`
+	// Allow is the decision of the matched requests, they are denied if false.
+	Allow bool `json:"allow"`
+	// Reason is the reason of the decision returned to the caller.
+	Reason string `json:"reason,omitempty"`
+
+	images []*regexp.Regexp
+}
+
+// match returns whether the rule matches the request.
+func (r *authzRule) match(req *authzRequest) bool {
+	if len(r.Methods) != 0 && !utils.StringInSlice(r.Methods, req.Method) {
+		return false
+	}
+	if len(r.Namespaces) != 0 && !utils.StringInSlice(r.Namespaces, req.Attributes[podNamespaceField]) {
+		return false
+	}
+	if r.Privileged != nil && *r.Privileged != req.Privileged {
+		return false
+	}
+	if len(r.images) != 0 {
+		image, ok := req.Attributes[imageField]
+		if !ok {
+			return false
+		}
+		for _, re := range r.images {
+			if re.MatchString(image) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// authzPolicy is the local authorization policy, the first rule matching the
+// request decides it, and the requests matching none are allowed.
+type authzPolicy struct {
+	Rules []*authzRule `json:"rules"`
+}
+
+// loadAuthzPolicy loads the authorization policy from the json file.
+func loadAuthzPolicy(file string) (*authzPolicy, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &authzPolicy{}
+	if err := json.Unmarshal(data, p); err != nil {
+		return nil, fmt.Errorf("invalid authorization policy %s: %v", file, err)
+	}
+	for i, rule := range p.Rules {
+		for _, image := range rule.Images {
+			re, err := regexp.Compile(image)
+			if err != nil {
+				return nil, fmt.Errorf("invalid image %q of rule %d in authorization policy %s: %v", image, i, file, err)
+			}
+			rule.images = append(rule.images, re)
+		}
+	}
+	return p, nil
+}
+
+// authorize returns whether the request is allowed by the policy, and the
+// reason of the matched rule.
+func (p *authzPolicy) authorize(req *authzRequest) (bool, string) {
+	for _, rule := range p.Rules {
+		if rule.match(req) {
+			return rule.Allow, rule.Reason
+		}
+	}
+	return true, ""
+}
+
+// authzFailOpenMethods are the methods allowed even if the authorization webhook
+// fails in fail-closed mode, otherwise the outage of webhook kills the pods by the
+// failed exec probes and blocks the teardown of pods.
+var authzFailOpenMethods = map[string]bool{
+	"ExecSync":         true,
+	"StopPodSandbox":   true,
+	"RemovePodSandbox": true,
+	"StopContainer":    true,
+	"RemoveContainer":  true,
+}
+
+// kubeletPodsDir is the directory of the volumes of pods managed by kubelet, the
+// mounts out of it are the host paths.
+const kubeletPodsDir = "/var/lib/kubelet/pods/"
+
+// authorizer authorizes the mutating cri requests by the local policy and the
+// webhook, the request is allowed only if both of them allow it.
+type authorizer struct {
+	policy  *authzPolicy
+	webhook string
+	client  *http.Client
+	// failClosed rejects the requests if the webhook fails, except the ones of
+	// authzFailOpenMethods, they are allowed if false.
+	failClosed bool
+}
+
+// newAuthorizer creates the authorizer from the cri config, nil if neither the
+// policy nor the webhook is configured.
+func newAuthorizer(cfg *criconfig.Config) (*authorizer, error) {
+	if cfg.AuthzPolicyFile == "" && cfg.AuthzWebhook == "" {
+		return nil, nil
+	}
+
+	a := &authorizer{
+		webhook:    cfg.AuthzWebhook,
+		client:     &http.Client{Timeout: time.Duration(cfg.AuthzWebhookTimeout) * time.Second},
+		failClosed: cfg.AuthzWebhookFailClosed,
+	}
+	if cfg.AuthzPolicyFile != "" {
+		p, err := loadAuthzPolicy(cfg.AuthzPolicyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load authorization policy of cri: %v", err)
+		}
+		a.policy = p
+	}
+	return a, nil
+}
+
+// authorize returns whether the request is allowed, and the reason if not.
+func (a *authorizer) authorize(ctx context.Context, req *authzRequest) (bool, string, error) {
+	if a.policy != nil {
+		if allowed, reason := a.policy.authorize(req); !allowed {
+			return false, reason, nil
+		}
+	}
+	if a.webhook == "" {
+		return true, "", nil
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return false, "", err
+	}
+	httpReq, err := http.NewRequest(http.MethodPost, a.webhook, bytes.NewReader(body))
+	if err != nil {
+		return false, "", err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := a.client.Do(httpReq.WithContext(ctx))
+	if err != nil {
+		return false, "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, "", fmt.Errorf("authorization webhook returns %s", resp.Status)
+	}
+	var decision authzResponse
+	if err := json.NewDecoder(resp.Body).Decode(&decision); err != nil {
+		return false, "", fmt.Errorf("invalid response of authorization webhook: %v", err)
+	}
+	return decision.Allowed, decision.Reason, nil
+}
+
+// requestPrivileged returns whether the cri request creates the privileged
+// container or sandbox, or the one with the capabilities added, the namespaces
+// of host or the host paths mounted, which escape the isolation as well.
+func requestPrivileged(req interface{}) bool {
+	switch r := req.(type) {
+	case *runtime.RunPodSandboxRequest:
+		sc := r.GetConfig().GetLinux().GetSecurityContext()
+		return sc.GetPrivileged() || hostNamespaces(sc.GetNamespaceOptions())
+	case *runtime.CreateContainerRequest:
+		sc := r.GetConfig().GetLinux().GetSecurityContext()
+		if sc.GetPrivileged() || len(sc.GetCapabilities().GetAddCapabilities()) != 0 || hostNamespaces(sc.GetNamespaceOptions()) {
+			return true
+		}
+		for _, m := range r.GetConfig().GetMounts() {
+			if !strings.HasPrefix(path.Clean(m.GetHostPath())+"/", kubeletPodsDir) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hostNamespaces returns whether any of the namespaces is the one of host.
+func hostNamespaces(opts *runtime.NamespaceOption) bool {
+	return opts.GetNetwork() == runtime.NamespaceMode_NODE ||
+		opts.GetPid() == runtime.NamespaceMode_NODE ||
+		opts.GetIpc() == runtime.NamespaceMode_NODE
+}
+
+// authzUnaryServerInterceptor authorizes the mutating cri requests before they
+// are handled, the denied ones are rejected with PermissionDenied and the
+// reason of policy.
+func authzUnaryServerInterceptor(criMgr CriMgr) grpc.UnaryServerInterceptor {
+	c, _ := criMgr.(*CriManager)
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		method := path.Base(info.FullMethod)
+		if c == nil || c.authorizer == nil || !isAuditMethod(method) {
+			return handler(ctx, req)
+		}
+
+		areq := &authzRequest{
+			Method:     method,
+			Caller:     auditCallerFromContext(ctx),
+			Attributes: auditRequestSummary(req, c.contextRequestFields(ctx, req)),
+			Privileged: requestPrivileged(req),
+		}
+		allowed, reason, err := c.authorizer.authorize(ctx, areq)
+		if err != nil {
+			if !c.authorizer.failClosed || authzFailOpenMethods[method] {
+				log.With(ctx).Warnf("failed to authorize %s, allow it: %v", method, err)
+				return handler(ctx, req)
+			}
+			// fail closed, the request is retried by kubelet.
+			return nil, status.Errorf(codes.Unavailable, "failed to authorize %s: %v", method, err)
+		}
+		if !allowed {
+			log.With(ctx).Warnf("%s is denied by the authorization policy: %s", method, reason)
+			return nil, status.Errorf(codes.PermissionDenied, "%s is denied by the authorization policy: %s", method, reason)
+		}
+		return handler(ctx, req)
+	}
+}
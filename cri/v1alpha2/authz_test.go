@@ -0,0 +1,212 @@
+package v1alpha2
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	apitypes "github.com/alibaba/pouch/apis/types"
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+	criconfig "github.com/alibaba/pouch/cri/config"
+	metatypes "github.com/alibaba/pouch/cri/v1alpha2/types"
+	"github.com/alibaba/pouch/daemon/mgr"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const testAuthzPolicy = `{
+	"rules": [
+		{"methods": ["CreateContainer"], "privileged": true, "reason": "privileged containers are denied"},
+		{"methods": ["ExecSync", "Exec"], "namespaces": ["kube-system"], "reason": "exec into kube-system pods is denied"},
+		{"methods": ["PullImage"], "images": ["^registry.example.com/"], "allow": true},
+		{"methods": ["PullImage"], "reason": "unapproved registry"}
+	]
+}`
+
+func TestAuthzUnaryServerInterceptor(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cri-authz")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	policyFile := filepath.Join(dir, "policy.json")
+	assert.NoError(t, ioutil.WriteFile(policyFile, []byte(testAuthzPolicy), 0600))
+
+	store, err := newSandboxStore(dir)
+	assert.NoError(t, err)
+	defer store.Shutdown()
+	assert.NoError(t, store.Put(&metatypes.SandboxMeta{
+		ID:     "sandbox1",
+		Config: &runtime.PodSandboxConfig{Metadata: &runtime.PodSandboxMetadata{Name: "dns", Namespace: "kube-system"}},
+	}))
+
+	authz, err := newAuthorizer(&criconfig.Config{AuthzPolicyFile: policyFile})
+	assert.NoError(t, err)
+	// the namespace of pod is got by the sandbox of container.
+	containerMgr := &ephemeralContainerMgr{containers: map[string]*mgr.Container{
+		"container1": {ID: "container1", Config: &apitypes.ContainerConfig{Labels: map[string]string{sandboxIDLabelKey: "sandbox1"}}},
+		"container2": {ID: "container2", Config: &apitypes.ContainerConfig{}},
+	}}
+	c := &CriManager{ContainerMgr: containerMgr, SandboxStore: store, authorizer: authz}
+	interceptor := authzUnaryServerInterceptor(c)
+
+	for _, tc := range []struct {
+		method string
+		req    interface{}
+		reason string
+	}{
+		{
+			method: "CreateContainer",
+			req: &runtime.CreateContainerRequest{Config: &runtime.ContainerConfig{
+				Linux: &runtime.LinuxContainerConfig{SecurityContext: &runtime.LinuxContainerSecurityContext{Privileged: true}},
+			}},
+			reason: "privileged containers are denied",
+		},
+		{
+			method: "CreateContainer",
+			req:    &runtime.CreateContainerRequest{Config: &runtime.ContainerConfig{}},
+		},
+		{
+			method: "ExecSync",
+			req:    &runtime.ExecSyncRequest{ContainerId: "container1", Cmd: []string{"sh"}},
+			reason: "exec into kube-system pods is denied",
+		},
+		{
+			method: "ExecSync",
+			req:    &runtime.ExecSyncRequest{ContainerId: "container2", Cmd: []string{"sh"}},
+		},
+		{
+			method: "PullImage",
+			req:    &runtime.PullImageRequest{Image: &runtime.ImageSpec{Image: "registry.example.com/app:v1"}},
+		},
+		{
+			method: "PullImage",
+			req:    &runtime.PullImageRequest{Image: &runtime.ImageSpec{Image: "docker.io/library/busybox"}},
+			reason: "unapproved registry",
+		},
+		{
+			method: "ListContainers",
+			req:    &runtime.ListContainersRequest{},
+		},
+	} {
+		handled := false
+		info := &grpc.UnaryServerInfo{FullMethod: "/runtime.v1alpha2.RuntimeService/" + tc.method}
+		_, err := interceptor(context.Background(), tc.req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			handled = true
+			return nil, nil
+		})
+		if tc.reason == "" {
+			assert.NoError(t, err, tc.method)
+			assert.True(t, handled, tc.method)
+			continue
+		}
+		assert.Equal(t, codes.PermissionDenied, status.Code(err), tc.method)
+		assert.Contains(t, err.Error(), tc.reason)
+		assert.False(t, handled, tc.method)
+	}
+}
+
+func TestAuthzWebhook(t *testing.T) {
+	var got authzRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		if got.Privileged {
+			json.NewEncoder(w).Encode(&authzResponse{Reason: "no privileged sandbox"})
+			return
+		}
+		json.NewEncoder(w).Encode(&authzResponse{Allowed: true})
+	}))
+	defer server.Close()
+
+	authz, err := newAuthorizer(&criconfig.Config{AuthzWebhook: server.URL, AuthzWebhookTimeout: 5})
+	assert.NoError(t, err)
+
+	allowed, reason, err := authz.authorize(context.Background(), &authzRequest{Method: "RunPodSandbox", Privileged: true})
+	assert.NoError(t, err)
+	assert.False(t, allowed)
+	assert.Equal(t, "no privileged sandbox", reason)
+	assert.Equal(t, "RunPodSandbox", got.Method)
+
+	allowed, _, err = authz.authorize(context.Background(), &authzRequest{Method: "RunPodSandbox"})
+	assert.NoError(t, err)
+	assert.True(t, allowed)
+
+	server.Close()
+	_, _, err = authz.authorize(context.Background(), &authzRequest{Method: "RunPodSandbox"})
+	assert.Error(t, err)
+}
+
+func TestAuthzWebhookFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	for _, failClosed := range []bool{false, true} {
+		authz, err := newAuthorizer(&criconfig.Config{AuthzWebhook: server.URL, AuthzWebhookTimeout: 1, AuthzWebhookFailClosed: failClosed})
+		assert.NoError(t, err)
+		interceptor := authzUnaryServerInterceptor(&CriManager{ContainerMgr: &ephemeralContainerMgr{}, authorizer: authz})
+
+		for _, tc := range []struct {
+			method string
+			req    interface{}
+		}{
+			{method: "ExecSync", req: &runtime.ExecSyncRequest{ContainerId: "container1"}},
+			{method: "StopPodSandbox", req: &runtime.StopPodSandboxRequest{PodSandboxId: "sandbox1"}},
+			{method: "RemoveContainer", req: &runtime.RemoveContainerRequest{ContainerId: "container1"}},
+			{method: "CreateContainer", req: &runtime.CreateContainerRequest{Config: &runtime.ContainerConfig{}}},
+		} {
+			handled := false
+			info := &grpc.UnaryServerInfo{FullMethod: "/runtime.v1alpha2.RuntimeService/" + tc.method}
+			_, err := interceptor(context.Background(), tc.req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+				handled = true
+				return nil, nil
+			})
+			// only the requests other than the probes and teardown are rejected in fail-closed mode.
+			if failClosed && !authzFailOpenMethods[tc.method] {
+				assert.Equal(t, codes.Unavailable, status.Code(err), tc.method)
+				assert.False(t, handled, tc.method)
+				continue
+			}
+			assert.NoError(t, err, tc.method)
+			assert.True(t, handled, tc.method)
+		}
+	}
+}
+
+func TestRequestPrivileged(t *testing.T) {
+	container := func(sc *runtime.LinuxContainerSecurityContext, mounts ...*runtime.Mount) *runtime.CreateContainerRequest {
+		return &runtime.CreateContainerRequest{Config: &runtime.ContainerConfig{
+			Linux:  &runtime.LinuxContainerConfig{SecurityContext: sc},
+			Mounts: mounts,
+		}}
+	}
+	hostNetwork := &runtime.NamespaceOption{Network: runtime.NamespaceMode_NODE}
+
+	for _, tc := range []struct {
+		req        interface{}
+		privileged bool
+	}{
+		{req: container(nil), privileged: false},
+		{req: container(&runtime.LinuxContainerSecurityContext{Privileged: true}), privileged: true},
+		{req: container(&runtime.LinuxContainerSecurityContext{Capabilities: &runtime.Capability{AddCapabilities: []string{"SYS_ADMIN"}}}), privileged: true},
+		{req: container(&runtime.LinuxContainerSecurityContext{Capabilities: &runtime.Capability{DropCapabilities: []string{"ALL"}}}), privileged: false},
+		{req: container(&runtime.LinuxContainerSecurityContext{NamespaceOptions: hostNetwork}), privileged: true},
+		{req: container(&runtime.LinuxContainerSecurityContext{NamespaceOptions: &runtime.NamespaceOption{Pid: runtime.NamespaceMode_POD}}), privileged: false},
+		{req: container(nil, &runtime.Mount{HostPath: "/var/lib/kubelet/pods/uid/volumes/kubernetes.io~empty-dir/data"}), privileged: false},
+		{req: container(nil, &runtime.Mount{HostPath: "/var/lib/kubelet/pods/../../../etc"}), privileged: true},
+		{req: container(nil, &runtime.Mount{HostPath: "/etc"}), privileged: true},
+		{req: &runtime.RunPodSandboxRequest{Config: &runtime.PodSandboxConfig{Linux: &runtime.LinuxPodSandboxConfig{
+			SecurityContext: &runtime.LinuxSandboxSecurityContext{NamespaceOptions: hostNetwork},
+		}}}, privileged: true},
+		{req: &runtime.RunPodSandboxRequest{Config: &runtime.PodSandboxConfig{}}, privileged: false},
+		{req: &runtime.ExecSyncRequest{}, privileged: false},
+	} {
+		assert.Equal(t, tc.privileged, requestPrivileged(tc.req), "%+v", tc.req)
+	}
+}
@@ -0,0 +1,144 @@
+package v1alpha2
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	goruntime "runtime"
+
+	"github.com/alibaba/pouch/pkg/log"
+
+	digest "github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+const (
+	// builtinPauseImageName is the name of the image built from the built-in
+	// pause binary, which is never pulled since the registry is localhost.
+	builtinPauseImageName = "localhost/pouch-builtin-pause"
+
+	// builtinPausePath is the path of the pause binary in the sandbox.
+	builtinPausePath = "/pause"
+)
+
+// builtinPause backs the sandboxes by the static pause binary on the host
+// instead of the sandbox image, the binary is imported as a single layer image
+// into the local image store, so that no image is pulled for pods.
+type builtinPause struct {
+	// binary is the path of pause binary on the host.
+	binary string
+	// image is the reference of the image, tagged with the digest of binary
+	// so that a new image is imported once pouchd restarts with a new binary.
+	image string
+}
+
+// newBuiltinPause validates the pause binary and returns its image.
+func newBuiltinPause(binary string) (*builtinPause, error) {
+	content, err := ioutil.ReadFile(binary)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read built-in pause binary: %v", err)
+	}
+	if len(content) == 0 {
+		return nil, fmt.Errorf("built-in pause binary %s is empty", binary)
+	}
+
+	sum := sha256.Sum256(content)
+	return &builtinPause{
+		binary: binary,
+		image:  fmt.Sprintf("%s:%s", builtinPauseImageName, hex.EncodeToString(sum[:])[:12]),
+	}, nil
+}
+
+// archive returns the docker archive of the image, which has the pause binary
+// as its only file and entrypoint.
+func (p *builtinPause) archive() ([]byte, error) {
+	content, err := ioutil.ReadFile(p.binary)
+	if err != nil {
+		return nil, err
+	}
+
+	var layer bytes.Buffer
+	lw := tar.NewWriter(&layer)
+	if err := writeTarFile(lw, builtinPausePath[1:], 0755, content); err != nil {
+		return nil, err
+	}
+	if err := lw.Close(); err != nil {
+		return nil, err
+	}
+	layerDigest := digest.FromBytes(layer.Bytes())
+
+	config, err := json.Marshal(ocispec.Image{
+		Architecture: goruntime.GOARCH,
+		OS:           "linux",
+		Config:       ocispec.ImageConfig{Entrypoint: []string{builtinPausePath}},
+		RootFS: ocispec.RootFS{
+			Type:    "layers",
+			DiffIDs: []digest.Digest{layerDigest},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	configFile := digest.FromBytes(config).Hex() + ".json"
+	layerFile := layerDigest.Hex() + "/layer.tar"
+
+	manifest, err := json.Marshal([]map[string]interface{}{{
+		"Config":   configFile,
+		"RepoTags": []string{p.image},
+		"Layers":   []string{layerFile},
+	}})
+	if err != nil {
+		return nil, err
+	}
+
+	var archive bytes.Buffer
+	tw := tar.NewWriter(&archive)
+	for _, f := range []struct {
+		name    string
+		content []byte
+	}{
+		{name: configFile, content: config},
+		{name: layerFile, content: layer.Bytes()},
+		{name: "manifest.json", content: manifest},
+	} {
+		if err := writeTarFile(tw, f.name, 0644, f.content); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	return archive.Bytes(), nil
+}
+
+// writeTarFile writes the regular file into the tar.
+func writeTarFile(tw *tar.Writer, name string, mode int64, content []byte) error {
+	if err := tw.WriteHeader(&tar.Header{
+		Name:     name,
+		Mode:     mode,
+		Size:     int64(len(content)),
+		Typeflag: tar.TypeReg,
+	}); err != nil {
+		return err
+	}
+	_, err := tw.Write(content)
+	return err
+}
+
+// ensureBuiltinPauseImage imports the image of built-in pause if it's missing.
+func (c *CriManager) ensureBuiltinPauseImage(ctx context.Context) error {
+	archive, err := c.builtinPause.archive()
+	if err != nil {
+		return fmt.Errorf("failed to build image of built-in pause %s: %v", c.builtinPause.binary, err)
+	}
+	if err := c.ImageMgr.LoadImage(ctx, "", ioutil.NopCloser(bytes.NewReader(archive))); err != nil {
+		return fmt.Errorf("failed to import image of built-in pause %s: %v", c.builtinPause.binary, err)
+	}
+	log.With(ctx).Infof("image %s of built-in pause %s is imported", c.builtinPause.image, c.builtinPause.binary)
+	return nil
+}
@@ -0,0 +1,104 @@
+package v1alpha2
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alibaba/pouch/daemon/mgr"
+	"github.com/alibaba/pouch/pkg/errtypes"
+	"github.com/alibaba/pouch/pkg/reference"
+
+	digest "github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeLoadImageMgr records the archives loaded and finds no image.
+type fakeLoadImageMgr struct {
+	mgr.ImageMgr
+	loaded [][]byte
+}
+
+func (f *fakeLoadImageMgr) CheckReference(ctx context.Context, idOrRef string) (digest.Digest, reference.Named, reference.Named, error) {
+	return "", nil, nil, errors.Wrap(errtypes.ErrNotfound, idOrRef)
+}
+
+func (f *fakeLoadImageMgr) LoadImage(ctx context.Context, imageName string, tarstream io.ReadCloser) error {
+	content, err := ioutil.ReadAll(tarstream)
+	f.loaded = append(f.loaded, content)
+	return err
+}
+
+// readTar returns the files in the tar by name.
+func readTar(t *testing.T, content []byte) (map[string][]byte, map[string]int64) {
+	files, modes := map[string][]byte{}, map[string]int64{}
+	tr := tar.NewReader(bytes.NewReader(content))
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		assert.NoError(t, err)
+		data, err := ioutil.ReadAll(tr)
+		assert.NoError(t, err)
+		files[hdr.Name], modes[hdr.Name] = data, hdr.Mode
+	}
+	return files, modes
+}
+
+func TestBuiltinPause(t *testing.T) {
+	dir, err := ioutil.TempDir("", "builtin-pause")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	binary := filepath.Join(dir, "pouch-pause")
+	_, err = newBuiltinPause(binary)
+	assert.Error(t, err)
+	assert.NoError(t, ioutil.WriteFile(binary, nil, 0755))
+	_, err = newBuiltinPause(binary)
+	assert.Error(t, err)
+
+	assert.NoError(t, ioutil.WriteFile(binary, []byte("pause"), 0755))
+	p, err := newBuiltinPause(binary)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(p.image, builtinPauseImageName+":"))
+	assert.Len(t, strings.TrimPrefix(p.image, builtinPauseImageName+":"), 12)
+
+	content, err := p.archive()
+	assert.NoError(t, err)
+	files, _ := readTar(t, content)
+
+	var manifest []struct {
+		Config   string
+		RepoTags []string
+		Layers   []string
+	}
+	assert.NoError(t, json.Unmarshal(files["manifest.json"], &manifest))
+	assert.Len(t, manifest, 1)
+	assert.Equal(t, []string{p.image}, manifest[0].RepoTags)
+
+	var config ocispec.Image
+	assert.NoError(t, json.Unmarshal(files[manifest[0].Config], &config))
+	assert.Equal(t, []string{builtinPausePath}, config.Config.Entrypoint)
+
+	assert.Len(t, manifest[0].Layers, 1)
+	layer, modes := readTar(t, files[manifest[0].Layers[0]])
+	assert.Equal(t, []byte("pause"), layer["pause"])
+	assert.Equal(t, int64(0755), modes["pause"])
+
+	// the image is imported instead of pulled.
+	imageMgr := &fakeLoadImageMgr{}
+	c := &CriManager{ImageMgr: imageMgr, builtinPause: p}
+	assert.Equal(t, p.image, c.sandboxImage())
+	assert.NoError(t, c.ensureSandboxImageExists(context.Background(), c.sandboxImage()))
+	assert.Equal(t, [][]byte{content}, imageMgr.loaded)
+}
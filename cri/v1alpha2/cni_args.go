@@ -0,0 +1,123 @@
+package v1alpha2
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	cni "github.com/alibaba/pouch/cri/ocicni"
+)
+
+var (
+	// reservedCNIArgs are the CNI_ARGS set by pouchd itself.
+	reservedCNIArgs = map[string]bool{
+		"IgnoreUnknown":              true,
+		"K8S_POD_NAMESPACE":          true,
+		"K8S_POD_NAME":               true,
+		"K8S_POD_INFRA_CONTAINER_ID": true,
+		"IP":                         true,
+		"MAC":                        true,
+	}
+
+	// reservedCNICapabilities are the capability args set by pouchd itself.
+	reservedCNICapabilities = map[string]bool{
+		"portMappings": true,
+		"bandwidth":    true,
+		"ipRanges":     true,
+		"ips":          true,
+		"mac":          true,
+	}
+)
+
+// cniArgsAnnotations are the allowed annotations of pods passed to the network
+// plugins, e.g. to select the subnet or vlan by the extended IPAM.
+type cniArgsAnnotations struct {
+	// args maps the annotations to the keys of CNI_ARGS.
+	args map[string]string
+	// capabilities maps the annotations to the capability args.
+	capabilities map[string]string
+}
+
+// parseCNIArgsAnnotations parses the annotations in the form of "annotation=arg"
+// and "annotation=capability", nil is returned if none is configured.
+func parseCNIArgsAnnotations(args, capabilities []string) (*cniArgsAnnotations, error) {
+	if len(args) == 0 && len(capabilities) == 0 {
+		return nil, nil
+	}
+
+	parse := func(entries []string, reserved map[string]bool) (map[string]string, error) {
+		result := make(map[string]string, len(entries))
+		for _, e := range entries {
+			parts := strings.SplitN(e, "=", 2)
+			if len(parts) != 2 {
+				return nil, fmt.Errorf("invalid %q, should be in the form of annotation=name", e)
+			}
+			annotation, name := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+			if annotation == "" || name == "" || strings.ContainsAny(name, "=;") {
+				return nil, fmt.Errorf("invalid %q, should be in the form of annotation=name", e)
+			}
+			if reserved[name] {
+				return nil, fmt.Errorf("invalid %q, %s is set by pouchd", e, name)
+			}
+			result[annotation] = name
+		}
+		return result, nil
+	}
+
+	a := &cniArgsAnnotations{}
+	var err error
+	if a.args, err = parse(args, reservedCNIArgs); err != nil {
+		return nil, err
+	}
+	if a.capabilities, err = parse(capabilities, reservedCNICapabilities); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// apply adds the args of the annotations to the runtime config of CNI network.
+// The values could not be passed are skipped, and the first error is returned.
+func (a *cniArgsAnnotations) apply(annotations map[string]string, runtimeConfig *cni.RuntimeConfig) error {
+	if a == nil {
+		return nil
+	}
+
+	var firstErr error
+	for _, k := range sortedKeys(annotations) {
+		v := annotations[k]
+		if arg, ok := a.args[k]; ok {
+			if strings.ContainsAny(v, "=;") {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("invalid value %q of annotation %s, CNI_ARGS should not contain = or ;", v, k)
+				}
+			} else {
+				runtimeConfig.Args = append(runtimeConfig.Args, [2]string{arg, v})
+			}
+		}
+
+		if capability, ok := a.capabilities[k]; ok {
+			if runtimeConfig.CapabilityArgs == nil {
+				runtimeConfig.CapabilityArgs = make(map[string]interface{})
+			}
+			// the structured capability args are in json, e.g. {"id": 100}.
+			var value interface{}
+			if err := json.Unmarshal([]byte(v), &value); err != nil {
+				value = v
+			}
+			runtimeConfig.CapabilityArgs[capability] = value
+		}
+	}
+	return firstErr
+}
+
+// sortedKeys returns the sorted keys of map, so that the args are passed in
+// the same order on CNI ADD and DEL.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
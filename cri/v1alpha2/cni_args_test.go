@@ -0,0 +1,69 @@
+package v1alpha2
+
+import (
+	"testing"
+
+	cni "github.com/alibaba/pouch/cri/ocicni"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCNIArgsAnnotations(t *testing.T) {
+	a, err := parseCNIArgsAnnotations(nil, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, a)
+
+	a, err = parseCNIArgsAnnotations([]string{"example.com/subnet=SUBNET"}, []string{" example.com/vlan = vlan "})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"example.com/subnet": "SUBNET"}, a.args)
+	assert.Equal(t, map[string]string{"example.com/vlan": "vlan"}, a.capabilities)
+
+	for _, tc := range []struct {
+		args, capabilities []string
+	}{
+		{args: []string{"example.com/subnet"}},
+		{args: []string{"=SUBNET"}},
+		{args: []string{"example.com/subnet=SUB;NET"}},
+		{args: []string{"example.com/ip=IP"}},
+		{capabilities: []string{"example.com/ports=portMappings"}},
+	} {
+		_, err := parseCNIArgsAnnotations(tc.args, tc.capabilities)
+		assert.Error(t, err, "%v", tc)
+	}
+}
+
+func TestApplyCNIArgsAnnotations(t *testing.T) {
+	a, err := parseCNIArgsAnnotations(
+		[]string{"example.com/subnet=SUBNET", "example.com/zone=ZONE"},
+		[]string{"example.com/vlan=vlan", "example.com/tag=tag"},
+	)
+	assert.NoError(t, err)
+
+	runtimeConfig := cni.RuntimeConfig{}
+	assert.NoError(t, a.apply(map[string]string{
+		"example.com/zone":   "z1",
+		"example.com/subnet": "s1",
+		"example.com/vlan":   `{"id": 100}`,
+		"example.com/tag":    "blue",
+		"example.com/other":  "ignored",
+	}, &runtimeConfig))
+	assert.Equal(t, [][2]string{{"SUBNET", "s1"}, {"ZONE", "z1"}}, runtimeConfig.Args)
+	assert.Equal(t, map[string]interface{}{
+		"vlan": map[string]interface{}{"id": float64(100)},
+		"tag":  "blue",
+	}, runtimeConfig.CapabilityArgs)
+
+	// the invalid values are skipped.
+	runtimeConfig = cni.RuntimeConfig{}
+	assert.Error(t, a.apply(map[string]string{
+		"example.com/subnet": "s1;IP=10.0.0.1",
+		"example.com/zone":   "z1",
+	}, &runtimeConfig))
+	assert.Equal(t, [][2]string{{"ZONE", "z1"}}, runtimeConfig.Args)
+
+	// nothing is passed if not configured.
+	var none *cniArgsAnnotations
+	runtimeConfig = cni.RuntimeConfig{}
+	assert.NoError(t, none.apply(map[string]string{"example.com/subnet": "s1"}, &runtimeConfig))
+	assert.Empty(t, runtimeConfig.Args)
+}
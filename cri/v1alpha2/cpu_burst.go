@@ -0,0 +1,58 @@
+package v1alpha2
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+
+	anno "github.com/alibaba/pouch/cri/annotations"
+	"github.com/alibaba/pouch/daemon/mgr"
+)
+
+// containerCPUBurst parses the cpu burst (in microseconds) of container from
+// the annotations, -1 means not specified.
+func containerCPUBurst(annotations map[string]string) (int64, error) {
+	v, ok := annotations[anno.CPUBurstExtendAnnotation]
+	if !ok {
+		return -1, nil
+	}
+
+	burst, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || burst < 0 {
+		return -1, fmt.Errorf("invalid %s %q: must be a non-negative integer", anno.CPUBurstExtendAnnotation, v)
+	}
+	return burst, nil
+}
+
+// cpuBurstCgroupFile returns the cgroup file of the cpu burst, which is
+// cpu.max.burst on cgroup v2 and cpu.cfs_burst_us on cgroup v1.
+func cpuBurstCgroupFile() string {
+	if isCgroup2UnifiedMode() {
+		return "cpu.max.burst"
+	}
+	return "cpu.cfs_burst_us"
+}
+
+// setCPUBurst writes the cpu burst into the cgroup.
+func setCPUBurst(path string, burst int64) error {
+	return ioutil.WriteFile(filepath.Join(path, cpuBurstCgroupFile()), []byte(strconv.FormatInt(burst, 10)), 0644)
+}
+
+// applyContainerCPUBurst sets the cpu burst of the running container if specified,
+// so that the container could accumulate the unused quota to absorb the spikes.
+func applyContainerCPUBurst(container *mgr.Container) error {
+	burst, err := containerCPUBurst(containerAnnotations(container))
+	if err != nil || burst < 0 {
+		return err
+	}
+
+	path, err := processCgroupPath(container.State.Pid, "cpu")
+	if err == nil {
+		err = setCPUBurst(path, burst)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to set cpu burst of container %q: %v", container.ID, err)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package v1alpha2
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	apitypes "github.com/alibaba/pouch/apis/types"
+	anno "github.com/alibaba/pouch/cri/annotations"
+	"github.com/alibaba/pouch/daemon/mgr"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_containerCPUBurst(t *testing.T) {
+	for _, tt := range []struct {
+		annotations map[string]string
+		want        int64
+		wantErr     bool
+	}{
+		{annotations: nil, want: -1},
+		{annotations: map[string]string{anno.CPUBurstExtendAnnotation: "0"}, want: 0},
+		{annotations: map[string]string{anno.CPUBurstExtendAnnotation: "50000"}, want: 50000},
+		{annotations: map[string]string{anno.CPUBurstExtendAnnotation: "-1"}, wantErr: true},
+		{annotations: map[string]string{anno.CPUBurstExtendAnnotation: "50ms"}, wantErr: true},
+	} {
+		got, err := containerCPUBurst(tt.annotations)
+		if tt.wantErr {
+			assert.Error(t, err, "annotations %v", tt.annotations)
+			continue
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, tt.want, got)
+	}
+}
+
+func Test_applyContainerCPUBurst(t *testing.T) {
+	root, err := ioutil.TempDir("", "cpu-burst")
+	assert.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	defer func(v1Root, v2Root, proc string, isUnified func() bool) {
+		cgroupRoot, unifiedCgroupRoot, procRoot, isCgroup2UnifiedMode = v1Root, v2Root, proc, isUnified
+	}(cgroupRoot, unifiedCgroupRoot, procRoot, isCgroup2UnifiedMode)
+	cgroupRoot = filepath.Join(root, "cgroup")
+	unifiedCgroupRoot = filepath.Join(root, "cgroup", "unified")
+	procRoot = filepath.Join(root, "proc")
+
+	// the co-mounted hierarchy is linked to the one of controller name on host.
+	v1Path := filepath.Join(cgroupRoot, "cpu", "kubepods", "c1")
+	v2Path := filepath.Join(unifiedCgroupRoot, "kubepods", "c1")
+	assert.NoError(t, os.MkdirAll(v1Path, 0755))
+	assert.NoError(t, os.MkdirAll(v2Path, 0755))
+	assert.NoError(t, os.MkdirAll(filepath.Join(procRoot, "100"), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(procRoot, "100", "cgroup"),
+		[]byte("4:cpu,cpuacct:/kubepods/c1\n3:memory:/kubepods/c1\n0::/kubepods/c1\n"), 0644))
+
+	container := &mgr.Container{
+		ID: "c1",
+		Config: &apitypes.ContainerConfig{
+			Labels: makeLabels(nil, map[string]string{anno.CPUBurstExtendAnnotation: "10000"}),
+		},
+		State: &apitypes.ContainerState{Pid: 100},
+	}
+
+	isCgroup2UnifiedMode = func() bool { return false }
+	assert.NoError(t, applyContainerCPUBurst(container))
+	got, err := ioutil.ReadFile(filepath.Join(v1Path, "cpu.cfs_burst_us"))
+	assert.NoError(t, err)
+	assert.Equal(t, "10000", string(got))
+
+	// the annotations updated take precedence.
+	container.Config.SpecAnnotation = map[string]string{anno.CPUBurstExtendAnnotation: "20000"}
+	isCgroup2UnifiedMode = func() bool { return true }
+	assert.NoError(t, applyContainerCPUBurst(container))
+	got, err = ioutil.ReadFile(filepath.Join(v2Path, "cpu.max.burst"))
+	assert.NoError(t, err)
+	assert.Equal(t, "20000", string(got))
+
+	// nothing is done without the annotation.
+	container.Config.Labels = nil
+	container.Config.SpecAnnotation = nil
+	container.State.Pid = 0
+	assert.NoError(t, applyContainerCPUBurst(container))
+}
+
+func Test_containerCgroupFilesCPUBurst(t *testing.T) {
+	defer func(isUnified func() bool) { isCgroup2UnifiedMode = isUnified }(isCgroup2UnifiedMode)
+	annotations := map[string]string{anno.CPUBurstExtendAnnotation: "10000"}
+
+	isCgroup2UnifiedMode = func() bool { return false }
+	files, err := containerCgroupFiles(annotations)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{mgr.CgroupFileSpecAnnotationPrefix + "cpu.cfs_burst_us": "10000"}, files)
+
+	isCgroup2UnifiedMode = func() bool { return true }
+	files, err = containerCgroupFiles(annotations)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{mgr.CgroupFileSpecAnnotationPrefix + "cpu.max.burst": "10000"}, files)
+
+	_, err = containerCgroupFiles(map[string]string{anno.CPUBurstExtendAnnotation: "1ms"})
+	assert.Error(t, err)
+}
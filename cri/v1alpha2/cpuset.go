@@ -0,0 +1,435 @@
+package v1alpha2
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	apitypes "github.com/alibaba/pouch/apis/types"
+	anno "github.com/alibaba/pouch/cri/annotations"
+	"github.com/alibaba/pouch/daemon/mgr"
+	"github.com/alibaba/pouch/pkg/log"
+	"github.com/alibaba/pouch/pkg/randomid"
+)
+
+const (
+	// cpusetPoolLabelKey is the internal label of the containers whose cpuset
+	// is assigned by the cpuset manager, the value is the pool of cpus.
+	cpusetPoolLabelKey       = "io.kubernetes.pouch.cpuset-pool"
+	cpusetPoolLabelExclusive = "exclusive"
+	cpusetPoolLabelShared    = "shared"
+)
+
+var (
+	// nodeRoot is the directory of the NUMA nodes in sysfs.
+	nodeRoot = "/sys/devices/system/node"
+	// cpuOnlineFile lists the online cpus, which are taken as the ones of
+	// NUMA node 0 on the hosts without NUMA.
+	cpuOnlineFile = "/sys/devices/system/cpu/online"
+)
+
+// parseCPUList parses the list of cpus or NUMA nodes in the format of
+// cpuset, e.g. "0-3,8,10-11".
+func parseCPUList(s string) ([]int, error) {
+	var ids []int
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return ids, nil
+	}
+
+	seen := make(map[int]bool)
+	for _, r := range strings.Split(s, ",") {
+		bounds := strings.SplitN(strings.TrimSpace(r), "-", 2)
+		start, err := strconv.Atoi(bounds[0])
+		if err != nil || start < 0 {
+			return nil, fmt.Errorf("invalid cpu list %q", s)
+		}
+		end := start
+		if len(bounds) == 2 {
+			if end, err = strconv.Atoi(bounds[1]); err != nil || end < start {
+				return nil, fmt.Errorf("invalid cpu list %q", s)
+			}
+		}
+		for id := start; id <= end; id++ {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	sort.Ints(ids)
+	return ids, nil
+}
+
+// formatCPUList formats the cpus or NUMA nodes in the format of cpuset.
+func formatCPUList(ids []int) string {
+	sorted := append([]int(nil), ids...)
+	sort.Ints(sorted)
+
+	var ranges []string
+	for i := 0; i < len(sorted); {
+		j := i
+		for j+1 < len(sorted) && sorted[j+1] == sorted[j]+1 {
+			j++
+		}
+		if i == j {
+			ranges = append(ranges, strconv.Itoa(sorted[i]))
+		} else {
+			ranges = append(ranges, fmt.Sprintf("%d-%d", sorted[i], sorted[j]))
+		}
+		i = j + 1
+	}
+	return strings.Join(ranges, ",")
+}
+
+// readNUMATopology returns the cpus of each NUMA node of the host.
+func readNUMATopology() (map[int][]int, error) {
+	topology := make(map[int][]int)
+	dirs, err := filepath.Glob(filepath.Join(nodeRoot, "node[0-9]*"))
+	if err != nil {
+		return nil, err
+	}
+	for _, dir := range dirs {
+		node, err := strconv.Atoi(strings.TrimPrefix(filepath.Base(dir), "node"))
+		if err != nil {
+			continue
+		}
+		data, err := ioutil.ReadFile(filepath.Join(dir, "cpulist"))
+		if err != nil {
+			return nil, err
+		}
+		if topology[node], err = parseCPUList(string(data)); err != nil {
+			return nil, err
+		}
+	}
+	if len(topology) > 0 {
+		return topology, nil
+	}
+
+	data, err := ioutil.ReadFile(cpuOnlineFile)
+	if err != nil {
+		return nil, err
+	}
+	cpus, err := parseCPUList(string(data))
+	if err != nil {
+		return nil, err
+	}
+	return map[int][]int{0: cpus}, nil
+}
+
+// cpusetManager assigns the exclusive cpus to the containers requesting them,
+// the cpus are taken from the NUMA nodes hinted in the annotations or from
+// the fewest nodes if not hinted, and the other containers managed share
+// the cpus left.
+type cpusetManager struct {
+	sync.Mutex
+	// topology maps the NUMA node to its cpus which could be assigned.
+	topology map[int][]int
+	// assigned maps the container ID to its exclusive cpus, the ID is
+	// generated before the container is created.
+	assigned map[string][]int
+}
+
+// newCpusetManager creates a cpuset manager with the cpus of host except the reserved ones.
+func newCpusetManager(reservedCPUs string) (*cpusetManager, error) {
+	topology, err := readNUMATopology()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read NUMA topology: %v", err)
+	}
+
+	reserved, err := parseCPUList(reservedCPUs)
+	if err != nil {
+		return nil, err
+	}
+	for _, cpu := range reserved {
+		for node, cpus := range topology {
+			topology[node] = removeCPU(cpus, cpu)
+		}
+	}
+
+	return &cpusetManager{
+		topology: topology,
+		assigned: make(map[string][]int),
+	}, nil
+}
+
+func removeCPU(cpus []int, cpu int) []int {
+	var left []int
+	for _, c := range cpus {
+		if c != cpu {
+			left = append(left, c)
+		}
+	}
+	return left
+}
+
+// freeLocked returns the cpus not assigned of each NUMA node.
+func (m *cpusetManager) freeLocked() map[int][]int {
+	used := make(map[int]bool)
+	for _, cpus := range m.assigned {
+		for _, cpu := range cpus {
+			used[cpu] = true
+		}
+	}
+
+	free := make(map[int][]int)
+	for node, cpus := range m.topology {
+		free[node] = []int{}
+		for _, cpu := range cpus {
+			if !used[cpu] {
+				free[node] = append(free[node], cpu)
+			}
+		}
+	}
+	return free
+}
+
+// allocate assigns count exclusive cpus to the owner, and returns the cpus and
+// their NUMA nodes. The cpus are taken from the hinted nodes in order, or from
+// the node fitting best, or from the nodes with the most free cpus if none fits.
+// At least one cpu is left for the shared pool.
+func (m *cpusetManager) allocate(owner string, count int, hint []int) ([]int, []int, error) {
+	m.Lock()
+	defer m.Unlock()
+
+	free := m.freeLocked()
+	total := 0
+	for _, cpus := range free {
+		total += len(cpus)
+	}
+	if count >= total {
+		return nil, nil, fmt.Errorf("not enough free cpus: %d requested, %d available with one kept for the shared pool", count, total-1)
+	}
+
+	nodes := hint
+	if len(nodes) == 0 {
+		for node := range free {
+			nodes = append(nodes, node)
+		}
+		sort.Slice(nodes, func(i, j int) bool {
+			if len(free[nodes[i]]) != len(free[nodes[j]]) {
+				return len(free[nodes[i]]) > len(free[nodes[j]])
+			}
+			return nodes[i] < nodes[j]
+		})
+		// prefer the single node with the fewest free cpus which fits.
+		for i := len(nodes) - 1; i >= 0; i-- {
+			if len(free[nodes[i]]) >= count {
+				nodes = []int{nodes[i]}
+				break
+			}
+		}
+	}
+
+	var cpus, mems []int
+	for _, node := range nodes {
+		if _, ok := free[node]; !ok {
+			return nil, nil, fmt.Errorf("NUMA node %d does not exist", node)
+		}
+		if len(cpus) == count {
+			break
+		}
+		n := count - len(cpus)
+		if n > len(free[node]) {
+			n = len(free[node])
+		}
+		if n > 0 {
+			cpus = append(cpus, free[node][:n]...)
+			mems = append(mems, node)
+		}
+	}
+	if len(cpus) < count {
+		return nil, nil, fmt.Errorf("not enough free cpus on NUMA nodes %s: %d requested, %d available",
+			formatCPUList(nodes), count, len(cpus))
+	}
+
+	m.assigned[owner] = cpus
+	return cpus, mems, nil
+}
+
+// release frees the cpus assigned to the owner, and returns whether any is freed.
+func (m *cpusetManager) release(owner string) bool {
+	if m == nil {
+		return false
+	}
+
+	m.Lock()
+	defer m.Unlock()
+
+	_, ok := m.assigned[owner]
+	delete(m.assigned, owner)
+	return ok
+}
+
+// shared returns the cpus not assigned exclusively on the nodes, empty nodes means all nodes.
+func (m *cpusetManager) shared(nodes []int) ([]int, error) {
+	m.Lock()
+	defer m.Unlock()
+
+	free := m.freeLocked()
+	if len(nodes) == 0 {
+		for node := range free {
+			nodes = append(nodes, node)
+		}
+	}
+
+	var cpus []int
+	for _, node := range nodes {
+		c, ok := free[node]
+		if !ok {
+			return nil, fmt.Errorf("NUMA node %d does not exist", node)
+		}
+		cpus = append(cpus, c...)
+	}
+	if len(cpus) == 0 {
+		return nil, fmt.Errorf("no shared cpus left on NUMA nodes %s", formatCPUList(nodes))
+	}
+	sort.Ints(cpus)
+	return cpus, nil
+}
+
+// restore assigns the exclusive cpus of the containers created before restart.
+func (m *cpusetManager) restore(containers []*mgr.Container) {
+	m.Lock()
+	defer m.Unlock()
+
+	for _, container := range containers {
+		if container.Config.Labels[cpusetPoolLabelKey] != cpusetPoolLabelExclusive {
+			continue
+		}
+		cpus, err := parseCPUList(container.HostConfig.CpusetCpus)
+		if err != nil {
+			log.With(nil).Warnf("failed to restore exclusive cpus of container %q: %v", container.ID, err)
+			continue
+		}
+		m.assigned[container.ID] = cpus
+	}
+}
+
+// containerCPUHints parses the exclusive cpus and the NUMA nodes hinted in the annotations of container.
+func containerCPUHints(annotations map[string]string) (int, []int, error) {
+	var (
+		count int
+		nodes []int
+		err   error
+	)
+	if v, ok := annotations[anno.ExclusiveCPUsExtendAnnotation]; ok {
+		if count, err = strconv.Atoi(v); err != nil || count <= 0 {
+			return 0, nil, fmt.Errorf("invalid %s %q: must be a positive integer", anno.ExclusiveCPUsExtendAnnotation, v)
+		}
+	}
+	if v, ok := annotations[anno.NUMANodesExtendAnnotation]; ok {
+		if nodes, err = parseCPUList(v); err != nil || len(nodes) == 0 {
+			return 0, nil, fmt.Errorf("invalid %s %q: must be a list of NUMA nodes, e.g. 0-1", anno.NUMANodesExtendAnnotation, v)
+		}
+	}
+	return count, nodes, nil
+}
+
+// assignCpuset sets the cpuset of the container to be created if the cpuset
+// manager is enabled and the cpuset is not specified in the resources. The
+// exclusive cpus are held by the ID of container, which is generated here if
+// not specified.
+func (c *CriManager) assignCpuset(createConfig *apitypes.ContainerCreateConfig, annotations map[string]string) error {
+	if c.cpusetManager == nil || createConfig.HostConfig.CpusetCpus != "" {
+		return nil
+	}
+
+	count, nodes, err := containerCPUHints(annotations)
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		if createConfig.SpecificID == "" {
+			createConfig.SpecificID = randomid.Generate()
+		}
+		cpus, mems, err := c.cpusetManager.allocate(createConfig.SpecificID, count, nodes)
+		if err != nil {
+			return fmt.Errorf("failed to allocate exclusive cpus: %v", err)
+		}
+		createConfig.HostConfig.CpusetCpus = formatCPUList(cpus)
+		createConfig.HostConfig.CpusetMems = formatCPUList(mems)
+		createConfig.Labels[cpusetPoolLabelKey] = cpusetPoolLabelExclusive
+		return nil
+	}
+
+	cpus, err := c.cpusetManager.shared(nodes)
+	if err != nil {
+		return err
+	}
+	createConfig.HostConfig.CpusetCpus = formatCPUList(cpus)
+	if len(nodes) > 0 {
+		createConfig.HostConfig.CpusetMems = formatCPUList(nodes)
+	}
+	createConfig.Labels[cpusetPoolLabelKey] = cpusetPoolLabelShared
+	return nil
+}
+
+// reconcileSharedCpusets updates the cpuset of the containers in the shared
+// pool after the exclusive cpus are assigned or freed.
+func (c *CriManager) reconcileSharedCpusets(ctx context.Context) {
+	if c.cpusetManager == nil {
+		return
+	}
+
+	containers, err := c.ContainerMgr.List(ctx, &mgr.ContainerListOption{
+		All:    true,
+		Labels: map[string]string{cpusetPoolLabelKey: cpusetPoolLabelShared},
+	})
+	if err != nil {
+		log.With(ctx).Warnf("failed to list containers in shared cpuset pool: %v", err)
+		return
+	}
+
+	for _, container := range containers {
+		_, nodes, err := containerCPUHints(containerAnnotations(container))
+		if err != nil {
+			log.With(ctx).Warnf("failed to get NUMA hints of container %q: %v", container.ID, err)
+			continue
+		}
+		cpus, err := c.cpusetManager.shared(nodes)
+		if err != nil {
+			log.With(ctx).Warnf("failed to get shared cpus of container %q: %v", container.ID, err)
+			continue
+		}
+
+		cpuset := formatCPUList(cpus)
+		if cpuset == container.HostConfig.CpusetCpus {
+			continue
+		}
+		if err := c.ContainerMgr.Update(ctx, container.ID, &apitypes.UpdateConfig{
+			Resources: apitypes.Resources{CpusetCpus: cpuset},
+		}); err != nil {
+			log.With(ctx).Warnf("failed to update shared cpuset of container %q: %v", container.ID, err)
+			continue
+		}
+		if err := c.reapplyHotplugDevices(ctx, container.ID); err != nil {
+			log.With(ctx).Warnf("failed to apply hot-plugged devices of container %q: %v", container.ID, err)
+		}
+	}
+}
+
+// restoreCpusets restores the exclusive cpus assigned before restart, and
+// reconciles the shared pool with them.
+func (c *CriManager) restoreCpusets(ctx context.Context) error {
+	if c.cpusetManager == nil {
+		return nil
+	}
+
+	containers, err := c.ContainerMgr.List(ctx, &mgr.ContainerListOption{
+		All:    true,
+		Labels: map[string]string{cpusetPoolLabelKey: cpusetPoolLabelExclusive},
+	})
+	if err != nil {
+		return err
+	}
+	c.cpusetManager.restore(containers)
+	c.reconcileSharedCpusets(ctx)
+	return nil
+}
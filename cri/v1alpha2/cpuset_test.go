@@ -0,0 +1,157 @@
+package v1alpha2
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	apitypes "github.com/alibaba/pouch/apis/types"
+	anno "github.com/alibaba/pouch/cri/annotations"
+	"github.com/alibaba/pouch/daemon/mgr"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseCPUList(t *testing.T) {
+	for s, want := range map[string][]int{
+		"":             nil,
+		"0":            {0},
+		"0-3,8":        {0, 1, 2, 3, 8},
+		" 10-11,2,2\n": {2, 10, 11},
+	} {
+		got, err := parseCPUList(s)
+		assert.NoError(t, err)
+		assert.Equal(t, want, got, "cpu list %q", s)
+	}
+
+	for _, s := range []string{"a", "3-1", "-1", "0,,1"} {
+		_, err := parseCPUList(s)
+		assert.Error(t, err, "cpu list %q", s)
+	}
+
+	assert.Equal(t, "0-3,8,10-11", formatCPUList([]int{10, 0, 1, 2, 3, 8, 11}))
+	assert.Equal(t, "", formatCPUList(nil))
+}
+
+func Test_cpusetManager(t *testing.T) {
+	root, err := ioutil.TempDir("", "cpuset")
+	assert.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	defer func(node string) { nodeRoot = node }(nodeRoot)
+	nodeRoot = root
+	for node, cpus := range map[string]string{"node0": "0-3", "node1": "4-7"} {
+		assert.NoError(t, os.MkdirAll(filepath.Join(root, node), 0755))
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(root, node, "cpulist"), []byte(cpus+"\n"), 0644))
+	}
+
+	m, err := newCpusetManager("0")
+	assert.NoError(t, err)
+	assert.Equal(t, map[int][]int{0: {1, 2, 3}, 1: {4, 5, 6, 7}}, m.topology)
+
+	// the node fitting best is preferred.
+	cpus, mems, err := m.allocate("a", 2, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, cpus)
+	assert.Equal(t, []int{0}, mems)
+
+	_, _, err = m.allocate("d", 1, []int{2})
+	assert.Error(t, err)
+
+	// the hinted nodes are taken in order.
+	cpus, mems, err = m.allocate("c", 3, []int{0, 1})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{3, 4, 5}, cpus)
+	assert.Equal(t, []int{0, 1}, mems)
+
+	// the hinted node is honored.
+	cpus, mems, err = m.allocate("b", 1, []int{1})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{6}, cpus)
+	assert.Equal(t, []int{1}, mems)
+
+	// the last shared cpu is never assigned exclusively.
+	_, _, err = m.allocate("d", 1, nil)
+	assert.Error(t, err)
+	shared, err := m.shared(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{7}, shared)
+
+	assert.True(t, m.release("c"))
+	assert.False(t, m.release("c"))
+
+	shared, err = m.shared([]int{1})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{4, 5, 7}, shared)
+
+	// the exclusive cpus are restored from the containers.
+	m.restore([]*mgr.Container{{
+		ID:         "c2",
+		Config:     &apitypes.ContainerConfig{Labels: map[string]string{cpusetPoolLabelKey: cpusetPoolLabelExclusive}},
+		HostConfig: &apitypes.HostConfig{Resources: apitypes.Resources{CpusetCpus: "4-5"}},
+	}})
+	shared, err = m.shared(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{3, 7}, shared)
+
+	// nil manager does nothing.
+	var nilManager *cpusetManager
+	assert.False(t, nilManager.release("a"))
+}
+
+func Test_assignCpuset(t *testing.T) {
+	c := &CriManager{cpusetManager: &cpusetManager{
+		topology: map[int][]int{0: {0, 1}, 1: {2, 3}},
+		assigned: make(map[string][]int),
+	}}
+	newConfig := func() *apitypes.ContainerCreateConfig {
+		return &apitypes.ContainerCreateConfig{
+			ContainerConfig: apitypes.ContainerConfig{Labels: map[string]string{}},
+			HostConfig:      &apitypes.HostConfig{},
+		}
+	}
+
+	config := newConfig()
+	assert.NoError(t, c.assignCpuset(config, map[string]string{
+		anno.ExclusiveCPUsExtendAnnotation: "1",
+		anno.NUMANodesExtendAnnotation:     "1",
+	}))
+	// the exclusive cpus are held by the ID generated for the container.
+	assert.Len(t, config.SpecificID, 64)
+	assert.Equal(t, []int{2}, c.cpusetManager.assigned[config.SpecificID])
+	assert.Equal(t, "2", config.HostConfig.CpusetCpus)
+	assert.Equal(t, "1", config.HostConfig.CpusetMems)
+	assert.Equal(t, cpusetPoolLabelExclusive, config.Labels[cpusetPoolLabelKey])
+
+	config = newConfig()
+	assert.NoError(t, c.assignCpuset(config, map[string]string{anno.NUMANodesExtendAnnotation: "1"}))
+	assert.Equal(t, "3", config.HostConfig.CpusetCpus)
+	assert.Equal(t, "1", config.HostConfig.CpusetMems)
+	assert.Equal(t, cpusetPoolLabelShared, config.Labels[cpusetPoolLabelKey])
+
+	config = newConfig()
+	assert.NoError(t, c.assignCpuset(config, nil))
+	assert.Equal(t, "0-1,3", config.HostConfig.CpusetCpus)
+	assert.Equal(t, "", config.HostConfig.CpusetMems)
+
+	// the cpuset specified is kept.
+	config = newConfig()
+	config.HostConfig.CpusetCpus = "0"
+	assert.NoError(t, c.assignCpuset(config, map[string]string{anno.ExclusiveCPUsExtendAnnotation: "1"}))
+	assert.Equal(t, "0", config.HostConfig.CpusetCpus)
+	assert.Empty(t, config.Labels)
+
+	for _, annotations := range []map[string]string{
+		{anno.ExclusiveCPUsExtendAnnotation: "0"},
+		{anno.NUMANodesExtendAnnotation: "x"},
+		{anno.ExclusiveCPUsExtendAnnotation: "3"},
+	} {
+		assert.Error(t, c.assignCpuset(newConfig(), annotations), "annotations %v", annotations)
+	}
+
+	// the cpuset manager is disabled.
+	config = newConfig()
+	assert.NoError(t, (&CriManager{}).assignCpuset(config, map[string]string{anno.ExclusiveCPUsExtendAnnotation: "1"}))
+	assert.Equal(t, "", config.HostConfig.CpusetCpus)
+}
@@ -5,26 +5,33 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path"
 	"path/filepath"
-	"reflect"
 	goruntime "runtime"
+	"strconv"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/alibaba/pouch/apis/filters"
 	apitypes "github.com/alibaba/pouch/apis/types"
 	anno "github.com/alibaba/pouch/cri/annotations"
 	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+	criconfig "github.com/alibaba/pouch/cri/config"
 	"github.com/alibaba/pouch/cri/metrics"
 	cni "github.com/alibaba/pouch/cri/ocicni"
 	"github.com/alibaba/pouch/cri/stream"
 	metatypes "github.com/alibaba/pouch/cri/v1alpha2/types"
 	"github.com/alibaba/pouch/ctrd"
 	"github.com/alibaba/pouch/daemon/config"
+	"github.com/alibaba/pouch/daemon/events"
 	"github.com/alibaba/pouch/daemon/mgr"
 	"github.com/alibaba/pouch/hookplugins"
+	"github.com/alibaba/pouch/lxcfs"
 	"github.com/alibaba/pouch/pkg/errtypes"
+	"github.com/alibaba/pouch/pkg/kmutex"
 	"github.com/alibaba/pouch/pkg/log"
 	"github.com/alibaba/pouch/pkg/meta"
 	"github.com/alibaba/pouch/pkg/reference"
@@ -54,6 +61,11 @@ const (
 	sandboxIDLabelKey           = "io.kubernetes.sandbox.id"
 	containerLogPathLabelKey    = "io.kubernetes.container.logpath"
 
+	// Internal pouch labels keeping the metadata of containers and sandboxes,
+	// which are more reliable than parsing the names.
+	metadataNameLabelKey    = "io.kubernetes.pouch.metadata.name"
+	metadataAttemptLabelKey = "io.kubernetes.pouch.metadata.attempt"
+
 	// sandboxContainerName is a string to include in the pouch container so
 	// that users can easily identify the sandboxes.
 	sandboxContainerName = "POD"
@@ -66,16 +78,18 @@ const (
 	// resolvConfPath is the abs path of resolv.conf on host or container.
 	resolvConfPath = "/etc/resolv.conf"
 
+	// hostnamePath is the abs path of hostname in container.
+	hostnamePath = "/etc/hostname"
+
 	// snapshotPlugin implements a snapshotter.
 	snapshotPlugin = "io.containerd.snapshotter.v1"
 
 	// networkNotReadyReason is the reason reported when network is not ready.
 	networkNotReadyReason = "NetworkPluginNotReady"
-)
 
-var (
-	// Default timeout for stopping container.
-	defaultStopTimeout = int64(10)
+	// sandboxOOMScoreAdj is the oom score adjustment of sandbox container, which is the same
+	// as the one kubelet uses for the infra container, so that it's nearly never killed by OOM.
+	sandboxOOMScoreAdj = -998
 )
 
 // CriMgr as an interface defines all operations against CRI.
@@ -94,6 +108,60 @@ type CriMgr interface {
 
 	// StreamStart returns the router of Stream Server.
 	StreamRouter() stream.Router
+
+	// Fsck cross-checks and optionally repairs the sandbox meta, containers, netns and directories.
+	Fsck(ctx context.Context, repair bool) (*metatypes.FsckReport, error)
+
+	// CheckpointPodSandbox checkpoints the sandbox with its containers into an archive.
+	CheckpointPodSandbox(ctx context.Context, id string, options *metatypes.PodCheckpointOptions) (string, error)
+
+	// RestorePodSandbox restores a sandbox with its containers from the archive of checkpoint.
+	RestorePodSandbox(ctx context.Context, name string) (string, error)
+
+	// ImportDockershim imports the sandboxes and containers created by dockershim.
+	ImportDockershim(ctx context.Context, apply bool) (*metatypes.DockershimImportReport, error)
+
+	// CopyContainer returns the URL of stream server to copy files from or to the container.
+	CopyContainer(ctx context.Context, r *metatypes.CopyRequest) (*metatypes.CopyResponse, error)
+
+	// CreateEphemeralContainer runs a short-lived container in the namespaces of an existing sandbox.
+	CreateEphemeralContainer(ctx context.Context, r *metatypes.EphemeralContainerRequest) (*metatypes.EphemeralContainerResponse, error)
+
+	// DiagnoseSandbox writes a gzipped tar archive gathering everything about the sandbox.
+	DiagnoseSandbox(ctx context.Context, id string, w io.Writer) error
+
+	// SelfTest runs a subset of the critest scenarios against the cri manager and reports the results.
+	SelfTest(ctx context.Context, options *metatypes.SelfTestOptions) (*metatypes.SelfTestReport, error)
+
+	// SetDraining turns on or off the drain mode, in which no new sandboxes or containers are accepted.
+	SetDraining(draining bool)
+
+	// Draining returns whether the node is draining.
+	Draining() bool
+
+	// SetDebugDumpMethods sets the cri methods whose payloads are dumped into the log, empty turns the dump off.
+	SetDebugDumpMethods(methods []string)
+
+	// DebugDumpMethods returns the cri methods whose payloads are dumped.
+	DebugDumpMethods() []string
+
+	// PrefetchImages queues the images to pull in background by their priorities.
+	PrefetchImages(ctx context.Context, r *metatypes.PrefetchRequest) (*metatypes.PrefetchReport, error)
+
+	// PrefetchReport reports the images queued, being pulled and recently prefetched.
+	PrefetchReport() *metatypes.PrefetchReport
+
+	// AttachDevice attaches the device of host to the running container.
+	AttachDevice(ctx context.Context, r *metatypes.DeviceRequest) (*metatypes.DeviceResponse, error)
+
+	// DetachDevice detaches the device of host from the running container.
+	DetachDevice(ctx context.Context, r *metatypes.DeviceRequest) (*metatypes.DeviceResponse, error)
+
+	// Shutdown waits for the in-flight cri requests to finish and flushes the stores.
+	Shutdown() error
+
+	// ReloadConfig applies the reloadable fields of cri config to the running cri manager.
+	ReloadConfig(cfg criconfig.Config)
 }
 
 // CriManager is an implementation of interface CriMgr.
@@ -110,24 +178,163 @@ type CriManager struct {
 	// SandboxBaseDir is the directory used to store sandbox files like /etc/hosts, /etc/resolv.conf, etc.
 	SandboxBaseDir string
 
+	// podCheckpointDir is the directory in which the archives of sandbox checkpoints are kept.
+	podCheckpointDir string
+
 	// SandboxImage is the image used by sandbox container.
 	SandboxImage string
 
+	// builtinPause backs the sandboxes by the pause binary instead of SandboxImage, nil if disabled.
+	builtinPause *builtinPause
+
+	// hostNetworkHolder holds the sandboxes of the pods in host namespaces by the
+	// process of built-in pause instead of the sandbox containers.
+	hostNetworkHolder bool
+
+	// imagePulls deduplicates the concurrent pulls of the same image.
+	imagePulls *imagePulls
+
+	// hotplugLock serializes the updates of the devices hot-plugged into containers.
+	hotplugLock sync.Mutex
+
+	// pullOnCreate pulls the image missing on CreateContainer by the reference and
+	// credentials in podImagePulls, which the pods pulled the images with.
+	pullOnCreate  bool
+	podImagePulls *podImagePulls
+
+	// imagePrefetcher pulls the images queued by the prefetch api and the
+	// missing warm images in background.
+	imagePrefetcher *imagePrefetcher
+
+	// defaultUlimits are the default ulimits of containers.
+	defaultUlimits []*apitypes.Ulimit
+
+	// defaultCapabilities are the default capabilities of containers,
+	// empty means the default ones of pouch.
+	defaultCapabilities []string
+
+	// defaultMaskedPaths and defaultReadonlyPaths are the default masked paths and
+	// readonly paths of containers, empty means the default ones of pouch.
+	defaultMaskedPaths   []string
+	defaultReadonlyPaths []string
+
+	// envInjections are the env injected into the containers matching the selectors.
+	envInjections []criconfig.EnvInjection
+
+	// defaultMounts are the mounts injected into all the containers unless overridden.
+	defaultMounts []*runtime.Mount
+
+	// privilegedPolicy decides whether the privileged containers are allowed.
+	privilegedPolicy *privilegedPolicy
+
+	// runtimeOverheads are the overheads of runtime handlers reported in the sandbox status.
+	runtimeOverheads map[string]metatypes.PodOverhead
+
+	// eventsService publishes the events of the cri requests.
+	eventsService *events.Events
+
+	// runtimeSnapshotters are the snapshotters of runtime handlers, in which the
+	// images of pods are unpacked and the rootfs of containers are prepared.
+	runtimeSnapshotters map[string]string
+
+	// passthroughAnnotations are the patterns of annotations copied into the OCI spec annotations.
+	passthroughAnnotations []string
+
+	// allowedNetworkDevices are the patterns of host network devices allowed to be moved into sandboxes.
+	allowedNetworkDevices []string
+
+	// cniArgs are the annotations of pods passed to the network plugins.
+	cniArgs *cniArgsAnnotations
+
+	// healthChecker runs the health checks of containers configured in annotations.
+	healthChecker *healthChecker
+
+	// attempts keeps the latest attempt of each container name in sandboxes.
+	attempts *attemptCounter
+
+	// names are the names of sandboxes and containers being created.
+	names *nameReservations
+
+	// defaultStopTimeout is the default time duration (in time.Second) the containers
+	// are given to stop before being killed when the sandbox is stopped.
+	defaultStopTimeout int64
+
+	// swapBehavior is the default swap behavior of containers without swap limit.
+	swapBehavior string
+
+	// rdtQoSClasses maps the QoS classes of pods to the RDT classes of service of containers.
+	rdtQoSClasses map[string]string
+
+	// cpusetManager assigns the cpuset of containers, nil means disabled.
+	cpusetManager *cpusetManager
+
+	// teardownConcurrency is the max number of containers stopped or removed
+	// concurrently in sandbox teardown.
+	teardownConcurrency int
+
+	// sandboxCache caches the sandboxes served by ListPodSandbox.
+	sandboxCache *sandboxCache
+
+	// imageRefCache caches the image references of containers served by ContainerStatus.
+	imageRefCache *imageRefCache
+
+	// metricsCollector collects the metrics of containers from containerd in batches.
+	metricsCollector *metricsCollector
+
+	// statsCache caches the responses of ListContainerStats, nil means no cache.
+	statsCache *statsCache
+
+	// draining is non-zero when the node is draining, in which no new sandboxes
+	// or containers are accepted.
+	draining int32
+
+	// requests tracks the in-flight cri requests to wait for on shutdown.
+	requests requestTracker
+
+	// workers are the background workers stopped on shutdown.
+	workers workerGroup
+
+	// shutdownTimeout is the time duration to wait for the in-flight requests on shutdown.
+	shutdownTimeout time.Duration
+
+	// sandboxDirQuota is the project quota of the directory of each sandbox.
+	sandboxDirQuota string
+
+	// auditLog records the mutating cri requests, nil means no audit log.
+	auditLog *auditLogger
+
+	// authorizer authorizes the mutating cri requests, nil means all allowed.
+	authorizer *authorizer
+
+	// debugDumper dumps the payloads of the selected cri methods into the log.
+	debugDumper *debugDumper
+
+	// configLock protects the fields which could be reloaded.
+	configLock sync.RWMutex
+
 	// SandboxStore stores the configuration of sandboxes.
 	SandboxStore *meta.Store
 
 	// SnapshotStore stores information of all snapshots.
 	SnapshotStore *mgr.SnapshotStore
 
-	// imageFSPath is the path to image filesystem.
-	imageFSPath string
+	// snapshotsSyncer syncs the snapshot stats into SnapshotStore periodically.
+	snapshotsSyncer *mgr.SnapshotsSyncer
+
+	// NetworkTeardownStore stores the failed network teardowns which should be retried.
+	NetworkTeardownStore *meta.Store
+	// teardownLocks serializes the retries of network teardown of each sandbox.
+	teardownLocks *kmutex.KMutex
+
+	// imageFSRootDir is the root dir of containerd holding the image filesystems of snapshotters.
+	imageFSRootDir string
 
 	// DaemonConfig is the config of daemon
 	DaemonConfig *config.Config
 }
 
 // NewCriManager creates a brand new cri manager.
-func NewCriManager(config *config.Config, ctrMgr mgr.ContainerMgr, imgMgr mgr.ImageMgr, volumeMgr mgr.VolumeMgr, criPlugin hookplugins.CriPlugin) (CriMgr, error) {
+func NewCriManager(config *config.Config, ctrMgr mgr.ContainerMgr, imgMgr mgr.ImageMgr, volumeMgr mgr.VolumeMgr, criPlugin hookplugins.CriPlugin, eventsService *events.Events) (CriMgr, error) {
 	streamCfg, err := toStreamConfig(config)
 	if err != nil {
 		return nil, err
@@ -147,39 +354,219 @@ func NewCriManager(config *config.Config, ctrMgr mgr.ContainerMgr, imgMgr mgr.Im
 		SandboxImage:   config.CriConfig.SandboxImage,
 		SnapshotStore:  mgr.NewSnapshotStore(),
 		DaemonConfig:   config,
+
+		podCheckpointDir:     path.Join(config.HomeDir, "pod-checkpoints"),
+		defaultMaskedPaths:   config.CriConfig.DefaultMaskedPaths,
+		defaultReadonlyPaths: config.CriConfig.DefaultReadonlyPaths,
+		names:                newNameReservations(),
+		imagePulls:           newImagePulls(),
+		teardownLocks:        kmutex.New(),
+	}
+	if config.CriConfig.BuiltinPause != "" {
+		if c.builtinPause, err = newBuiltinPause(config.CriConfig.BuiltinPause); err != nil {
+			return nil, err
+		}
+	}
+	if config.CriConfig.HostNetworkHolder {
+		if c.builtinPause == nil {
+			return nil, fmt.Errorf("cri-host-network-holder requires cri-builtin-pause")
+		}
+		c.hostNetworkHolder = true
+	}
+	if config.CriConfig.PullOnCreate {
+		c.pullOnCreate = true
+		c.podImagePulls = newPodImagePulls()
 	}
+
+	// the bidirectional mount propagation silently fails on the mounts not shared.
+	c.ensureMountPropagation(config.CriConfig.EnsureMountPropagation)
+
+	c.imagePrefetcher, err = c.newCriImagePrefetcher(config.CriConfig.PrefetchBandwidth, config.CriConfig.WarmImages, time.Duration(config.CriConfig.WarmImagesInterval)*time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create image prefetcher: %v", err)
+	}
+
 	c.CniMgr, err = cni.NewCniManager(&config.CriConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create cni manager: %v", err)
 	}
+	if err := registerIPProviderPlugins(c.CniMgr, hookplugins.GetIPProviderPlugins()); err != nil {
+		return nil, err
+	}
+	// the CNI plugins could not create the network devices without the root of host.
+	if config.Rootless {
+		c.CniMgr, err = cni.NewSlirpManager(c.CniMgr, path.Join(config.HomeDir, "slirp4netns"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create slirp4netns manager: %v", err)
+		}
+	}
 
-	c.SandboxStore, err = meta.NewStore(meta.Config{
-		Driver:  "local",
-		BaseDir: path.Join(config.HomeDir, "sandboxes-meta"),
-		Buckets: []meta.Bucket{
-			{
-				Name: meta.MetaJSONFile,
-				Type: reflect.TypeOf(metatypes.SandboxMeta{}),
-			},
-		},
-	})
+	c.defaultUlimits, err = parseUlimits(config.CriConfig.DefaultUlimits)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse default ulimits of cri containers: %v", err)
+	}
+
+	c.defaultCapabilities, err = parseDefaultCapabilities(config.CriConfig.DefaultCapabilities)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse default capabilities of cri containers: %v", err)
+	}
+
+	if err := validateSwapBehavior(config.CriConfig.SwapBehavior); err != nil {
+		return nil, err
+	}
+	c.swapBehavior = config.CriConfig.SwapBehavior
+
+	c.rdtQoSClasses, err = parseRdtQoSClasses(config.CriConfig.RdtQoSClasses)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse rdt qos classes of cri containers: %v", err)
+	}
+
+	c.teardownConcurrency = config.CriConfig.TeardownConcurrency
+	c.defaultStopTimeout = int64(config.CriConfig.DefaultStopTimeout)
+	c.shutdownTimeout = time.Duration(config.CriConfig.ShutdownTimeout) * time.Second
+	if err := validateSandboxDirQuota(config.CriConfig.SandboxDirQuota); err != nil {
+		return nil, err
+	}
+	c.sandboxDirQuota = config.CriConfig.SandboxDirQuota
+	if config.CriConfig.AuditLogPath != "" {
+		c.auditLog, err = newAuditLogger(config.CriConfig.AuditLogPath, config.CriConfig.AuditLogMaxSize, config.CriConfig.AuditLogMaxFiles)
+		if err != nil {
+			return nil, err
+		}
+	}
+	c.authorizer, err = newAuthorizer(&config.CriConfig)
+	if err != nil {
+		return nil, err
+	}
+	c.debugDumper = newDebugDumper(config.CriConfig.DebugDumpMethods, config.CriConfig.DebugDumpRate)
+	c.metricsCollector = newMetricsCollector(time.Duration(config.CriConfig.CriStatsStaleness)*time.Millisecond, ctrMgr.BatchStats)
+	c.statsCache = newStatsCache(time.Duration(config.CriConfig.CriStatsCacheTTL) * time.Millisecond)
+
+	for i := range config.CriConfig.EnvInjections {
+		if err := config.CriConfig.EnvInjections[i].Validate(); err != nil {
+			return nil, fmt.Errorf("failed to validate env injections of cri containers: %v", err)
+		}
+	}
+	c.envInjections = config.CriConfig.EnvInjections
+
+	c.defaultMounts, err = parseDefaultMounts(config.CriConfig.DefaultMounts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse default mounts of cri containers: %v", err)
+	}
+
+	c.privilegedPolicy, err = newPrivilegedPolicy(&config.CriConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create privileged policy of cri containers: %v", err)
+	}
+
+	if config.CriConfig.EnableLxcfs && !config.IsLxcfsEnabled {
+		return nil, fmt.Errorf("lxcfs of cri pods could not be enabled without --enable-lxcfs")
+	}
+
+	c.runtimeOverheads, err = parseRuntimeOverheads(config.CriConfig.RuntimeOverheads)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse runtime overheads of cri sandboxes: %v", err)
+	}
+
+	c.runtimeSnapshotters, err = parseRuntimeSnapshotters(config.CriConfig.RuntimeSnapshotters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse runtime snapshotters of cri sandboxes: %v", err)
+	}
+	for handler := range c.runtimeSnapshotters {
+		if _, exist := config.Runtimes[handler]; !exist {
+			return nil, fmt.Errorf("runtime handler %q of snapshotter is not configured in daemon", handler)
+		}
+	}
+
+	c.passthroughAnnotations, err = parsePassthroughAnnotations(config.CriConfig.PassthroughAnnotations)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse passthrough annotations of cri containers: %v", err)
+	}
+
+	c.allowedNetworkDevices = config.CriConfig.AllowedNetworkDevices
+
+	c.cniArgs, err = parseCNIArgsAnnotations(config.CriConfig.CNIArgsAnnotations, config.CriConfig.CNICapabilityAnnotations)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse cni args annotations: %v", err)
+	}
+
+	c.SandboxStore, err = newSandboxStore(config.HomeDir)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create sandbox meta store: %v", err)
 	}
+	if err := c.restoreSelinuxLabels(context.Background()); err != nil {
+		log.With(nil).Warnf("failed to restore selinux labels of sandboxes: %v", err)
+	}
+
+	c.NetworkTeardownStore, err = newNetworkTeardownStore(config.HomeDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create network teardown store: %v", err)
+	}
+	c.startNetworkTeardownWorker()
+
+	if config.CriConfig.EnableCPUSetManager {
+		c.cpusetManager, err = newCpusetManager(config.CriConfig.ReservedCPUs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create cpuset manager: %v", err)
+		}
+		if err := c.restoreCpusets(context.Background()); err != nil {
+			log.With(nil).Warnf("failed to restore cpusets of containers: %v", err)
+		}
+	}
 
-	c.imageFSPath = imageFSPath(path.Join(config.HomeDir, "containerd/root"), ctrd.CurrentSnapshotterName(context.TODO()))
-	log.With(nil).Infof("Get image filesystem path %q", c.imageFSPath)
+	c.attempts = newAttemptCounter()
+	if err := c.restoreAttempts(context.Background()); err != nil {
+		log.With(nil).Warnf("failed to restore attempts of containers: %v", err)
+	}
+
+	if eventsService != nil {
+		c.eventsService = eventsService
+
+		c.sandboxCache = newSandboxCache()
+		c.workers.run(func(ctx context.Context) {
+			watchEvents(ctx, eventsService, apitypes.EventTypeContainer, c.sandboxCache.reset, c.sandboxCache.handleEvent)
+		})
+
+		c.imageRefCache = newImageRefCache()
+		c.workers.run(func(ctx context.Context) {
+			watchEvents(ctx, eventsService, apitypes.EventTypeImage, c.imageRefCache.reset, c.imageRefCache.handleEvent)
+		})
+	}
+
+	if lxcfs.IsLxcfsEnabled {
+		c.workers.run(c.watchLxcfs)
+	}
+
+	c.healthChecker = newHealthChecker(c)
+	if err := c.restoreHealthChecks(context.Background()); err != nil {
+		log.With(nil).Warnf("failed to restore health checks of containers: %v", err)
+	}
+
+	if err := c.cleanupPartialSandboxes(context.Background()); err != nil {
+		log.With(nil).Warnf("failed to clean up partially created sandboxes: %v", err)
+	}
+
+	c.imagePrefetcher.start(&c.workers)
+
+	c.imageFSRootDir = path.Join(config.HomeDir, "containerd/root")
+	log.With(nil).Infof("Get image filesystem path %q", imageFSPath(c.imageFSRootDir, ctrd.CurrentSnapshotterName(context.TODO())))
 
 	if config.CriConfig.EnableCriStatsCollect {
 		period := config.CriConfig.CriStatsCollectPeriod
 		if period <= 0 {
 			return nil, fmt.Errorf("cri stats collect period should > 0")
 		}
-		snapshotsSyncer := ctrMgr.NewSnapshotsSyncer(
+		// Load the snapshot stats persisted before restart, and reconcile them
+		// with containerd in background.
+		c.SnapshotStore, err = mgr.NewPersistentSnapshotStore(path.Join(config.HomeDir, "snapshots-meta"))
+		if err != nil {
+			return nil, err
+		}
+		c.snapshotsSyncer = ctrMgr.NewSnapshotsSyncer(
 			c.SnapshotStore,
 			time.Duration(period)*time.Second,
 		)
-		snapshotsSyncer.Start()
+		c.snapshotsSyncer.Start()
 	} else {
 		log.With(nil).Infof("disable cri to collect stats from containerd periodically")
 	}
@@ -218,28 +605,56 @@ func (c *CriManager) RunPodSandbox(ctx context.Context, r *runtime.RunPodSandbox
 		metrics.PodActionsTimer.WithLabelValues(label).Observe(time.Since(start).Seconds())
 	}(time.Now())
 
+	if err := c.checkDraining("RunPodSandbox"); err != nil {
+		return nil, err
+	}
+
 	config := r.GetConfig()
 
 	if config.GetMetadata() == nil {
 		return nil, fmt.Errorf("sandbox metadata required")
 	}
 
-	// Step 1: Prepare image for the sandbox.
-	image := c.SandboxImage
-
-	// Make sure the sandbox image exists.
-	err := c.ensureSandboxImageExists(ctx, image)
-	if err != nil {
+	// Reserve the sandbox name to reject the concurrent requests of the same pod attempt.
+	sandboxName := makeSandboxName(config)
+	if err := c.names.reserve(sandboxName); err != nil {
 		return nil, err
 	}
+	defer c.names.release(sandboxName)
+
+	// The sandbox of the pod in the namespaces of host is held by a lightweight
+	// process instead of the sandbox container, if enabled.
+	holder := c.usesSandboxHolder(config, r.GetRuntimeHandler())
 
 	// prepare the sandboxID and store it.
 	id, err := c.generateSandboxID(ctx)
 	if err != nil {
 		return nil, err
 	}
+	// the phase and the resources of sandbox are journaled in the meta, so that
+	// the sandbox left partially created by a crash is cleaned up on startup.
+	// The netns is created before the meta is stored, so that it's journaled
+	// with no extra write.
 	sandboxMeta := &metatypes.SandboxMeta{
-		ID: id,
+		ID:            id,
+		SchemaVersion: sandboxMetaSchemaVersion,
+		Config:        config,
+		Phase:         metatypes.SandboxPhaseCreating,
+	}
+	// If it is in host network, no need to configure the network of sandbox.
+	withNetwork := sandboxNetworkMode(config) != runtime.NamespaceMode_NODE
+	if withNetwork {
+		sandboxMeta.NetNS, err = c.CniMgr.NewNetNS()
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			if retErr != nil {
+				if err := c.CniMgr.RemoveNetNS(sandboxMeta.NetNS); err != nil {
+					log.With(ctx).Errorf("failed to remove net ns for sandbox %q: %v", id, err)
+				}
+			}
+		}()
 	}
 	if err := c.SandboxStore.Put(sandboxMeta); err != nil {
 		return nil, err
@@ -256,31 +671,79 @@ func (c *CriManager) RunPodSandbox(ctx context.Context, r *runtime.RunPodSandbox
 		}
 	}()
 
+	// Step 1: Prepare image for the sandbox.
+	image := c.sandboxImage()
+
+	// Make sure the sandbox image exists, which is done in parallel with the
+	// network setup and waited before the sandbox container is created. It is
+	// waited if running sandbox failed before, so that it's not left behind.
+	imageStep := startParallelStep(func() error {
+		if holder {
+			return nil
+		}
+		return c.ensureSandboxImageExists(ctx, image)
+	})
+	defer func() {
+		if retErr != nil {
+			if err := imageStep.wait(); err != nil {
+				log.With(ctx).Warnf("failed to ensure the image of sandbox %q: %v", id, err)
+			}
+		}
+	}()
+
+	// The sandbox directory is set up in parallel with the network, and it is
+	// removed after the setup is done if running sandbox failed.
+	sandboxRootDir := path.Join(c.SandboxBaseDir, id)
+	sandboxDirStep := startParallelStep(func() error {
+		return c.setupSandboxDir(id, sandboxRootDir, config)
+	})
+	defer func() {
+		if retErr != nil {
+			sandboxDirStep.wait()
+			if err := os.RemoveAll(sandboxRootDir); err != nil {
+				log.With(ctx).Errorf("failed to clean up the directory of sandbox %q: %v", id, err)
+			}
+			if err := releaseSandboxDirQuota(id); err != nil {
+				log.With(ctx).Errorf("failed to release the quota id of sandbox %q: %v", id, err)
+			}
+		}
+	}()
+
 	// Step 2: Setup networking for the sandbox.
 
-	// If it is in host network, no need to configure the network of sandbox.
-	if sandboxNetworkMode(config) != runtime.NamespaceMode_NODE {
-		sandboxMeta.NetNS, err = c.CniMgr.NewNetNS()
+	if withNetwork {
+		sandboxMeta.NetworkResults, err = c.setupPodNetwork(id, sandboxMeta.NetNS, config)
 		if err != nil {
 			return nil, err
 		}
 		defer func() {
 			if retErr != nil {
-				if err := c.CniMgr.RemoveNetNS(sandboxMeta.NetNS); err != nil {
-					log.With(ctx).Errorf("failed to remove net ns for sandbox %q: %v", id, err)
+				if err := c.teardownNetwork(id, sandboxMeta.NetNS, config, sandboxMeta.NetworkResults); err != nil {
+					log.With(ctx).Errorf("failed to teardown pod network for sandbox %q: %v", id, err)
 				}
 			}
 		}()
-		if err := c.setupPodNetwork(id, sandboxMeta.NetNS, config); err != nil {
+
+		// Move the host network devices requested into the sandbox.
+		if err := c.attachNetworkDevices(sandboxMeta, config); err != nil {
 			return nil, err
 		}
 		defer func() {
 			if retErr != nil {
-				if err := c.teardownNetwork(id, sandboxMeta.NetNS, config); err != nil {
-					log.With(ctx).Errorf("failed to teardown pod network for sandbox %q: %v", id, err)
+				if err := c.detachNetworkDevices(sandboxMeta); err != nil {
+					log.With(ctx).Errorf("failed to restore network devices of sandbox %q: %v", id, err)
 				}
 			}
 		}()
+
+		// Enforce the network policy requested in the sandbox netns.
+		if err := c.applyNetworkPolicy(sandboxMeta, config); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := sandboxDirStep.wait(); err != nil {
+		return nil, err
 	}
 
 	// Step 3: Create the sandbox container.
@@ -295,59 +758,98 @@ func (c *CriManager) RunPodSandbox(ctx context.Context, r *runtime.RunPodSandbox
 		return nil, err
 	}
 
-	createConfig, err := makeSandboxPouchConfig(config, sandboxMeta, image)
-
-	if err != nil {
-		return nil, fmt.Errorf("failed to make sandbox pouch config for pod %q: %v", config.GetMetadata().GetName(), err)
+	// limits the number of processes of the whole pod.
+	if err := c.applyPodPidsLimit(sandboxMeta, config); err != nil {
+		return nil, err
 	}
-	createConfig.SpecificID = id
 
-	sandboxName := makeSandboxName(config)
-
-	_, err = c.ContainerMgr.Create(ctx, sandboxName, createConfig)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create a sandbox for pod %q: %v", config.Metadata.Name, err)
+	// sets the memory QoS of the whole pod.
+	if err := c.applyPodMemoryQoS(sandboxMeta, config); err != nil {
+		return nil, err
 	}
 
-	sandboxMeta.Config = config
-	if err := c.SandboxStore.Put(sandboxMeta); err != nil {
+	// records the overhead of runtime handler.
+	c.applyPodOverhead(sandboxMeta)
+
+	// allocates the SELinux labels with the unique MCS categories for the pod.
+	if err := c.allocateSelinuxLabels(sandboxMeta, config); err != nil {
 		return nil, err
 	}
-
-	// If running sandbox failed, clean up the container.
 	defer func() {
 		if retErr != nil {
-			if err := c.ContainerMgr.Remove(ctx, id, &apitypes.ContainerRemoveOptions{Volumes: true, Force: true}); err != nil {
-				removeContainerErr = true
-				log.With(ctx).Errorf("failed to remove container when running sandbox failed %q: %v", id, err)
-			}
+			releaseSelinuxLabels(sandboxMeta)
 		}
 	}()
 
-	// Step 4: Start the sandbox container.
-	err = c.ContainerMgr.Start(ctx, id, &apitypes.ContainerStartOptions{})
-	if err != nil {
-		return nil, fmt.Errorf("failed to start sandbox container for pod %q: %v", config.GetMetadata().GetName(), err)
-	}
+	if holder {
+		if err := c.startSandboxHolder(ctx, sandboxMeta); err != nil {
+			return nil, err
+		}
+		defer func() {
+			if retErr != nil {
+				if err := stopSandboxHolder(sandboxMeta.Holder, 0); err != nil {
+					log.With(ctx).Errorf("failed to stop holder of sandbox %q: %v", id, err)
+				}
+			}
+		}()
+	} else {
+		if err := imageStep.wait(); err != nil {
+			return nil, err
+		}
 
-	sandboxRootDir := path.Join(c.SandboxBaseDir, id)
-	err = os.MkdirAll(sandboxRootDir, 0755)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create sandbox root directory: %v", err)
-	}
-	defer func() {
-		// If running sandbox failed, clean up the sandbox directory.
-		if retErr != nil {
-			if err := os.RemoveAll(sandboxRootDir); err != nil {
-				log.With(ctx).Errorf("failed to clean up the directory of sandbox %q: %v", id, err)
+		createConfig, err := makeSandboxPouchConfig(config, sandboxMeta, image)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to make sandbox pouch config for pod %q: %v", config.GetMetadata().GetName(), err)
+		}
+		createConfig.SpecificID = id
+		c.applyRootlessConfig(createConfig)
+		applySelinuxLevel(sandboxMeta, createConfig.HostConfig)
+		passthroughAnnotations(c.passthroughAnnotations, createConfig.SpecAnnotation, config.GetAnnotations())
+
+		// call cri plugin to update the sandbox create config
+		if c.CriPlugin != nil {
+			if err := c.CriPlugin.PreRunPodSandbox(ctx, createConfig, sandboxMeta); err != nil {
+				return nil, err
 			}
 		}
-	}()
 
-	// Setup sandbox file /etc/resolv.conf.
-	err = setupSandboxFiles(sandboxRootDir, config)
-	if err != nil {
-		return nil, fmt.Errorf("failed to setup sandbox files: %v", err)
+		// the rootfs of sandbox is prepared in the snapshotter of runtime handler.
+		_, err = c.ContainerMgr.Create(c.withRuntimeSnapshotter(ctx, sandboxMeta.Runtime), sandboxName, createConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create a sandbox for pod %q: %v", config.Metadata.Name, err)
+		}
+
+		// If running sandbox failed, clean up the container.
+		defer func() {
+			if retErr != nil {
+				if err := c.ContainerMgr.Remove(ctx, id, &apitypes.ContainerRemoveOptions{Volumes: true, Force: true}); err != nil {
+					removeContainerErr = true
+					log.With(ctx).Errorf("failed to remove container when running sandbox failed %q: %v", id, err)
+				}
+			}
+		}()
+
+		// Step 4: Start the sandbox container.
+		err = c.ContainerMgr.Start(ctx, id, &apitypes.ContainerStartOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to start sandbox container for pod %q: %v", config.GetMetadata().GetName(), err)
+		}
+		if sharesPidNamespace(config) {
+			if err := c.checkSandboxReaper(ctx, id); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := relabelSandboxFiles(ctx, sandboxMeta, sandboxRootDir); err != nil {
+		return nil, err
+	}
+
+	// the fields of sandbox applied above are stored together with the phase.
+	sandboxMeta.Phase = metatypes.SandboxPhaseReady
+	if err := c.SandboxStore.Put(sandboxMeta); err != nil {
+		return nil, err
 	}
 
 	metrics.PodSuccessActionsCounter.WithLabelValues(label).Inc()
@@ -367,16 +869,30 @@ func (c *CriManager) StartPodSandbox(ctx context.Context, r *runtime.StartPodSan
 
 	podSandboxID := r.GetPodSandboxId()
 
+	res, err := c.SandboxStore.Get(podSandboxID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get metadata of %q from SandboxStore: %v", podSandboxID, err)
+	}
+	sandboxMeta := res.(*metatypes.SandboxMeta)
+
+	// the sandbox held by the holder has no network to recover.
+	if sandboxMeta.Holder != nil {
+		if err := c.restartSandboxHolder(ctx, sandboxMeta); err != nil {
+			return nil, err
+		}
+		metrics.PodSuccessActionsCounter.WithLabelValues(label).Inc()
+		return &runtime.StartPodSandboxResponse{}, nil
+	}
+
 	sandbox, err := c.ContainerMgr.Get(ctx, podSandboxID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get container %q: %v", podSandboxID, err)
 	}
 
-	res, err := c.SandboxStore.Get(podSandboxID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get metadata of %q from SandboxStore: %v", podSandboxID, err)
+	// The pending network teardown must be done before the network is setup again.
+	if err := c.flushNetworkTeardown(podSandboxID); err != nil {
+		return nil, fmt.Errorf("failed to teardown the previous network of sandbox %q: %v", podSandboxID, err)
 	}
-	sandboxMeta := res.(*metatypes.SandboxMeta)
 
 	if mgr.IsNetNS(sandbox.HostConfig.NetworkMode) {
 		ip, _ := c.CniMgr.GetPodNetworkStatus(sandboxMeta.NetNS)
@@ -393,16 +909,31 @@ func (c *CriManager) StartPodSandbox(ctx context.Context, r *runtime.StartPodSan
 				}
 			}()
 
-			if err = c.setupPodNetwork(podSandboxID, sandboxMeta.NetNS, sandboxMeta.Config); err != nil {
+			if sandboxMeta.NetworkResults, err = c.setupPodNetwork(podSandboxID, sandboxMeta.NetNS, sandboxMeta.Config); err != nil {
 				return nil, err
 			}
 			defer func() {
 				if retErr != nil {
-					if err := c.teardownNetwork(podSandboxID, sandboxMeta.NetNS, sandboxMeta.Config); err != nil {
+					if err := c.teardownNetwork(podSandboxID, sandboxMeta.NetNS, sandboxMeta.Config, sandboxMeta.NetworkResults); err != nil {
 						log.With(ctx).Errorf("failed to teardown pod network for sandbox %q: %v", podSandboxID, err)
 					}
 				}
 			}()
+
+			if err := c.attachNetworkDevices(sandboxMeta, sandboxMeta.Config); err != nil {
+				return nil, err
+			}
+			defer func() {
+				if retErr != nil {
+					if err := c.detachNetworkDevices(sandboxMeta); err != nil {
+						log.With(ctx).Errorf("failed to restore network devices of sandbox %q: %v", podSandboxID, err)
+					}
+				}
+			}()
+
+			if err := c.applyNetworkPolicy(sandboxMeta, sandboxMeta.Config); err != nil {
+				return nil, err
+			}
 		}
 	}
 
@@ -413,26 +944,46 @@ func (c *CriManager) StartPodSandbox(ctx context.Context, r *runtime.StartPodSan
 	}
 	defer func() {
 		if retErr != nil {
-			stopErr := c.ContainerMgr.Stop(ctx, podSandboxID, defaultStopTimeout)
+			stopErr := c.ContainerMgr.Stop(ctx, podSandboxID, c.sandboxStopTimeout(sandboxMeta))
 			if stopErr != nil {
 				log.With(ctx).Errorf("failed to stop sandbox %q: %v", podSandboxID, stopErr)
 			}
 		}
 	}()
+	if sharesPidNamespace(sandboxMeta.Config) {
+		if err := c.checkSandboxReaper(ctx, podSandboxID); err != nil {
+			return nil, err
+		}
+	}
 
 	// legacy container using /proc/$pid/ns/net as the sandbox netns.
 	if mgr.IsNone(sandbox.HostConfig.NetworkMode) {
-		if err = c.setupPodNetwork(podSandboxID, containerNetns(sandbox), sandboxMeta.Config); err != nil {
+		if sandboxMeta.NetworkResults, err = c.setupPodNetwork(podSandboxID, containerNetns(sandbox), sandboxMeta.Config); err != nil {
 			return nil, err
 		}
 	}
 
-	// Setup sandbox file /etc/resolv.conf again to ensure resolv.conf is right
+	// Classify the traffic of sandbox again since the pod ip may change.
+	if sandboxMeta.NetPriority > 0 {
+		if err := c.CniMgr.SetNetPriority(sandboxMeta.NetworkResults, sandboxMeta.NetPriority); err != nil {
+			log.With(ctx).Warnf("failed to set net priority %d of sandbox %q: %v", sandboxMeta.NetPriority, podSandboxID, err)
+		}
+	}
+
+	// Persist the results of CNI ADD.
+	if err := c.SandboxStore.Put(sandboxMeta); err != nil {
+		return nil, err
+	}
+
+	// Setup sandbox files again to ensure resolv.conf and hostname are right
 	sandboxRootDir := path.Join(c.SandboxBaseDir, sandbox.ID)
 	err = setupSandboxFiles(sandboxRootDir, sandboxMeta.Config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to setup sandbox files: %v", err)
 	}
+	if err := relabelSandboxFiles(ctx, sandboxMeta, sandboxRootDir); err != nil {
+		return nil, err
+	}
 
 	metrics.PodSuccessActionsCounter.WithLabelValues(label).Inc()
 
@@ -462,11 +1013,16 @@ func (c *CriManager) StopPodSandbox(ctx context.Context, r *runtime.StopPodSandb
 	}
 	sandboxMeta := res.(*metatypes.SandboxMeta)
 
-	opts := &mgr.ContainerListOption{All: true}
-	filter := func(c *mgr.Container) bool {
-		return c.Config.Labels[sandboxIDLabelKey] == podSandboxID
+	if c.CriPlugin != nil {
+		if err := c.CriPlugin.PreStopPodSandbox(ctx, sandboxMeta); err != nil {
+			return nil, err
+		}
+	}
+
+	opts := &mgr.ContainerListOption{
+		All:    true,
+		Labels: map[string]string{sandboxIDLabelKey: podSandboxID},
 	}
-	opts.FilterFunc = filter
 
 	containers, err := c.ContainerMgr.List(ctx, opts)
 	if err != nil {
@@ -474,16 +1030,20 @@ func (c *CriManager) StopPodSandbox(ctx context.Context, r *runtime.StopPodSandb
 	}
 
 	// Stop all containers in the sandbox.
-	for _, container := range containers {
-		err = c.ContainerMgr.Stop(ctx, container.ID, defaultStopTimeout)
-		if err != nil {
+	stopTimeout := c.sandboxStopTimeout(sandboxMeta)
+	err = forEachContainer(containers, c.teardownConcurrency, func(container *mgr.Container) error {
+		if err := c.ContainerMgr.Stop(ctx, container.ID, stopTimeout); err != nil {
 			if errtypes.IsNotfound(err) {
 				log.With(ctx).Warningf("container %q of sandbox %q not found", container.ID, podSandboxID)
-				continue
+				return nil
 			}
-			return nil, fmt.Errorf("failed to stop container %q of sandbox %q: %v", container.ID, podSandboxID, err)
+			return fmt.Errorf("failed to stop container %q: %v", container.ID, err)
 		}
 		log.With(ctx).Infof("success to stop container %q of sandbox %q", container.ID, podSandboxID)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to stop containers of sandbox %q: %v", podSandboxID, err)
 	}
 
 	// Teardown network of the legacy dockershim style pod, if it is not in host network mode.
@@ -492,13 +1052,21 @@ func (c *CriManager) StopPodSandbox(ctx context.Context, r *runtime.StopPodSandb
 		if err != nil {
 			return nil, err
 		}
-		if err = c.teardownNetwork(podSandboxID, containerNetns(container), sandboxMeta.Config); err != nil {
-			return nil, fmt.Errorf("failed to teardown network of sandbox %s, ns path %s: %v", podSandboxID, sandboxMeta.NetNS, err)
+		netnsPath := containerNetns(container)
+		if err = c.teardownNetwork(podSandboxID, netnsPath, sandboxMeta.Config, sandboxMeta.NetworkResults); err != nil {
+			log.With(ctx).Warnf("failed to teardown network of sandbox %s, ns path %s, will retry later: %v", podSandboxID, netnsPath, err)
+			if err := c.enqueueNetworkTeardown(podSandboxID, netnsPath, false, sandboxMeta, err); err != nil {
+				return nil, err
+			}
 		}
 	}
 
-	// Stop the sandbox container.
-	err = c.ContainerMgr.Stop(ctx, podSandboxID, defaultStopTimeout)
+	// Stop the sandbox container, or the holder of sandbox.
+	if sandboxMeta.Holder != nil {
+		err = stopSandboxHolder(sandboxMeta.Holder, stopTimeout)
+	} else {
+		err = c.ContainerMgr.Stop(ctx, podSandboxID, stopTimeout)
+	}
 	// if the sandbox container has been removed by 'pouch rm', treat this situation as success
 	// in order to teardown the network.
 	if err != nil {
@@ -511,14 +1079,48 @@ func (c *CriManager) StopPodSandbox(ctx context.Context, r *runtime.StopPodSandb
 
 	// After container stop, no one refer the net namespace, do the clean up job.
 	if sandboxNetworkMode(sandboxMeta.Config) != runtime.NamespaceMode_NODE && sandboxMeta.NetNS != "" {
-		if err := c.teardownNetwork(podSandboxID, sandboxMeta.NetNS, sandboxMeta.Config); err != nil {
-			return nil, fmt.Errorf("failed to teardown network of sandbox %s, ns path %s: %v", podSandboxID, sandboxMeta.NetNS, err)
+		// Restore the network devices to host before the net namespace is removed,
+		// the ones failing to be restored are kept and retried by the next stop,
+		// otherwise they would be orphaned in the net namespace removed.
+		if len(sandboxMeta.NetworkDevices) > 0 {
+			detachErr := c.detachNetworkDevices(sandboxMeta)
+			if err := c.SandboxStore.Put(sandboxMeta); err != nil {
+				return nil, err
+			}
+			if detachErr != nil {
+				return nil, detachErr
+			}
+		}
+
+		// If the teardown fails, record it and let the background worker retry it,
+		// the net namespace will be removed after the teardown succeeds.
+		if err := c.teardownNetwork(podSandboxID, sandboxMeta.NetNS, sandboxMeta.Config, sandboxMeta.NetworkResults); err != nil {
+			log.With(ctx).Warnf("failed to teardown network of sandbox %s, ns path %s, will retry later: %v", podSandboxID, sandboxMeta.NetNS, err)
+			if err := c.enqueueNetworkTeardown(podSandboxID, sandboxMeta.NetNS, true, sandboxMeta, err); err != nil {
+				return nil, err
+			}
+		} else {
+			if err := c.CniMgr.CloseNetNS(sandboxMeta.NetNS); err != nil {
+				return nil, fmt.Errorf("failed to close net ns %s of sandbox %q: %v", sandboxMeta.NetNS, podSandboxID, err)
+			}
+			if err := c.CniMgr.RemoveNetNS(sandboxMeta.NetNS); err != nil {
+				return nil, fmt.Errorf("failed to remove net ns %s of sandbox %q: %v", sandboxMeta.NetNS, podSandboxID, err)
+			}
+		}
+	}
+
+	// The network has been torn down, or will be torn down by the background worker.
+	if len(sandboxMeta.NetworkResults) > 0 {
+		if err := c.CniMgr.ClearNetPriority(sandboxMeta.NetworkResults); err != nil {
+			log.With(ctx).Warnf("failed to clear net priority of sandbox %q: %v", podSandboxID, err)
 		}
-		if err := c.CniMgr.CloseNetNS(sandboxMeta.NetNS); err != nil {
-			return nil, fmt.Errorf("failed to close net ns %s of sandbox %q: %v", sandboxMeta.NetNS, podSandboxID, err)
+		if err := c.CniMgr.FlushConntrack(sandboxMeta.NetworkResults, toCNIPortMappings(sandboxMeta.Config.GetPortMappings())); err != nil {
+			log.With(ctx).Warnf("failed to flush conntrack entries of sandbox %q: %v", podSandboxID, err)
 		}
-		if err := c.CniMgr.RemoveNetNS(sandboxMeta.NetNS); err != nil {
-			return nil, fmt.Errorf("failed to remove net ns %s of sandbox %q: %v", sandboxMeta.NetNS, podSandboxID, err)
+
+		sandboxMeta.NetworkResults = nil
+		if err := c.SandboxStore.Put(sandboxMeta); err != nil {
+			return nil, err
 		}
 	}
 
@@ -538,11 +1140,10 @@ func (c *CriManager) RemovePodSandbox(ctx context.Context, r *runtime.RemovePodS
 
 	podSandboxID := r.GetPodSandboxId()
 
-	opts := &mgr.ContainerListOption{All: true}
-	filter := func(c *mgr.Container) bool {
-		return c.Config.Labels[sandboxIDLabelKey] == podSandboxID
+	opts := &mgr.ContainerListOption{
+		All:    true,
+		Labels: map[string]string{sandboxIDLabelKey: podSandboxID},
 	}
-	opts.FilterFunc = filter
 
 	containers, err := c.ContainerMgr.List(ctx, opts)
 	if err != nil {
@@ -550,20 +1151,39 @@ func (c *CriManager) RemovePodSandbox(ctx context.Context, r *runtime.RemovePodS
 	}
 
 	// Remove all containers in the sandbox.
-	for _, container := range containers {
+	var cpusReleased int32
+	err = forEachContainer(containers, c.teardownConcurrency, func(container *mgr.Container) error {
+		c.healthChecker.stop(container.ID)
 		if err := c.ContainerMgr.Remove(ctx, container.ID, &apitypes.ContainerRemoveOptions{Volumes: true, Force: true}); err != nil {
 			if errtypes.IsNotfound(err) {
 				log.With(ctx).Warningf("container %q of sandbox %q not found", container.ID, podSandboxID)
-				continue
+				return nil
 			}
-			return nil, fmt.Errorf("failed to remove container %q of sandbox %q: %v", container.ID, podSandboxID, err)
+			return fmt.Errorf("failed to remove container %q: %v", container.ID, err)
+		}
+		c.imageRefCache.remove(container.ID)
+		c.metricsCollector.forget(container.ID)
+		if c.cpusetManager.release(container.ID) {
+			atomic.StoreInt32(&cpusReleased, 1)
 		}
 
 		log.With(ctx).Infof("success to remove container %q of sandbox %q", container.ID, podSandboxID)
+		return nil
+	})
+	// The shared cpus are extended by the exclusive ones freed.
+	if atomic.LoadInt32(&cpusReleased) != 0 {
+		c.reconcileSharedCpusets(ctx)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove containers of sandbox %q: %v", podSandboxID, err)
 	}
 
-	// Remove the sandbox container.
-	if err := c.ContainerMgr.Remove(ctx, podSandboxID, &apitypes.ContainerRemoveOptions{Volumes: true, Force: true}); err != nil {
+	// Remove the sandbox container, or kill the holder of sandbox which has no container.
+	if res, err := c.SandboxStore.Get(podSandboxID); err == nil && res.(*metatypes.SandboxMeta).Holder != nil {
+		if err := stopSandboxHolder(res.(*metatypes.SandboxMeta).Holder, 0); err != nil {
+			return nil, fmt.Errorf("failed to kill holder of sandbox %q: %v", podSandboxID, err)
+		}
+	} else if err := c.ContainerMgr.Remove(ctx, podSandboxID, &apitypes.ContainerRemoveOptions{Volumes: true, Force: true}); err != nil {
 		if errtypes.IsNotfound(err) {
 			log.With(ctx).Warningf("sandbox container %q not found", podSandboxID)
 		} else {
@@ -571,16 +1191,33 @@ func (c *CriManager) RemovePodSandbox(ctx context.Context, r *runtime.RemovePodS
 		}
 	}
 
-	// Cleanup the sandbox root directory.
+	// Cleanup the sandbox root directory, the volumes must not be removed
+	// through the subpaths still mounted.
 	sandboxRootDir := path.Join(c.SandboxBaseDir, podSandboxID)
+	if err := removeSandboxSubPaths(sandboxRootDir); err != nil {
+		return nil, fmt.Errorf("failed to remove subpaths of sandbox %q: %v", podSandboxID, err)
+	}
 
 	if err := os.RemoveAll(sandboxRootDir); err != nil {
 		return nil, fmt.Errorf("failed to remove root directory %q: %v", sandboxRootDir, err)
 	}
+	if err := releaseSandboxDirQuota(podSandboxID); err != nil {
+		return nil, fmt.Errorf("failed to release quota id of sandbox %q: %v", podSandboxID, err)
+	}
+
+	// the meta may be gone if the sandbox has been removed.
+	var uid string
+	if res, err := c.SandboxStore.Get(podSandboxID); err == nil {
+		sandboxMeta := res.(*metatypes.SandboxMeta)
+		uid = sandboxMeta.Config.GetMetadata().GetUid()
+		releaseSelinuxLabels(sandboxMeta)
+	}
 
 	if err := c.SandboxStore.Remove(podSandboxID); err != nil {
 		return nil, fmt.Errorf("failed to remove meta %q: %v", sandboxRootDir, err)
 	}
+	c.forgetPodImagePulls(uid)
+	c.attempts.removeSandbox(podSandboxID)
 
 	metrics.PodSuccessActionsCounter.WithLabelValues(label).Inc()
 
@@ -606,45 +1243,71 @@ func (c *CriManager) PodSandboxStatus(ctx context.Context, r *runtime.PodSandbox
 	// partially created sandbox.
 	// kubelet won't call this method because the partially created sandbox
 	// are removed from ListPodSandbox interface.
-	if sandboxMeta.Config == nil {
+	if isPartialSandbox(sandboxMeta) {
 		return nil, fmt.Errorf("failed to get status of partially sandbox %q: %v", podSandboxID, err)
 	}
 
-	sandbox, err := c.ContainerMgr.Get(ctx, podSandboxID)
-	if err != nil {
-		if errtypes.IsNotfound(err) {
-			return &runtime.PodSandboxStatusResponse{
-				Status: &runtime.PodSandboxStatus{
-					Id:        podSandboxID,
-					State:     runtime.PodSandboxState_SANDBOX_NOTFOUND,
-					Metadata:  sandboxMeta.Config.Metadata,
-					CreatedAt: 1,
-				},
-			}, nil
+	var (
+		sandbox             *mgr.Container
+		createdAt           int64
+		state               runtime.PodSandboxState
+		labels, annotations map[string]string
+	)
+	if sandboxMeta.Holder != nil {
+		// the sandbox held by the holder has no container.
+		createdAt = sandboxMeta.Holder.Created
+		state = holderSandboxState(sandboxMeta.Holder)
+		labels, annotations = sandboxMeta.Config.GetLabels(), sandboxMeta.Config.GetAnnotations()
+	} else {
+		sandbox, err = c.ContainerMgr.Get(ctx, podSandboxID)
+		if err != nil {
+			if errtypes.IsNotfound(err) {
+				return &runtime.PodSandboxStatusResponse{
+					Status: &runtime.PodSandboxStatus{
+						Id:        podSandboxID,
+						State:     runtime.PodSandboxState_SANDBOX_NOTFOUND,
+						Metadata:  sandboxMeta.Config.Metadata,
+						CreatedAt: 1,
+					},
+				}, nil
+			}
+			return nil, fmt.Errorf("failed to get status of sandbox %q: %v", podSandboxID, err)
 		}
-		return nil, fmt.Errorf("failed to get status of sandbox %q: %v", podSandboxID, err)
-	}
 
-	// Parse the timestamps.
-	createdAt, err := toCriTimestamp(sandbox.Created)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse timestamp for sandbox %q: %v", podSandboxID, err)
-	}
+		// Parse the timestamps.
+		createdAt, err = toCriTimestamp(sandbox.Created)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse timestamp for sandbox %q: %v", podSandboxID, err)
+		}
 
-	// Translate container to sandbox state.
-	state := runtime.PodSandboxState_SANDBOX_NOTREADY
-	if sandbox.State.Status == apitypes.StatusRunning {
-		state = runtime.PodSandboxState_SANDBOX_READY
-	}
+		// Translate container to sandbox state.
+		state = runtime.PodSandboxState_SANDBOX_NOTREADY
+		if sandbox.State.Status == apitypes.StatusRunning {
+			state = runtime.PodSandboxState_SANDBOX_READY
+		}
 
-	labels, annotations := extractLabels(sandbox.Config.Labels)
+		labels, annotations = extractLabels(sandbox.Config.Labels)
+	}
 
 	nsOpts := sandboxMeta.Config.GetLinux().GetSecurityContext().GetNamespaceOptions()
 	hostNet := nsOpts.GetNetwork() == runtime.NamespaceMode_NODE
 
-	var ip string
+	// The ip allocated by other network backends takes precedence.
+	ip, provided, err := c.CniMgr.ProviderPodIP(&cni.PodIPRequest{
+		ID:          podSandboxID,
+		Annotations: annotations,
+		NetNS:       sandboxMeta.NetNS,
+		Results:     sandboxMeta.NetworkResults,
+	})
+	if err != nil {
+		log.With(ctx).Warnf("failed to get ip of sandbox %q: %v", podSandboxID, err)
+	}
 	// No need to get ip for host network mode.
-	if !hostNet {
+	// Prefer the persisted results of CNI ADD, only query the netns if there is no result.
+	if !provided && !hostNet && state == runtime.PodSandboxState_SANDBOX_READY {
+		ip = cni.PodIP(sandboxMeta.NetworkResults)
+	}
+	if !provided && !hostNet && ip == "" {
 		ip, err = c.CniMgr.GetPodNetworkStatus(containerNetns(sandbox))
 		if err != nil {
 			// Maybe the pod has been stopped.
@@ -652,10 +1315,6 @@ func (c *CriManager) PodSandboxStatus(ctx context.Context, r *runtime.PodSandbox
 		}
 	}
 
-	if v, exist := annotations[anno.PassthruKey]; exist && v == "true" {
-		ip = annotations[anno.PassthruIP]
-	}
-
 	status := &runtime.PodSandboxStatus{
 		Id:          podSandboxID,
 		State:       state,
@@ -675,9 +1334,29 @@ func (c *CriManager) PodSandboxStatus(ctx context.Context, r *runtime.PodSandbox
 		},
 	}
 
+	var info map[string]string
+	if r.GetVerbose() {
+		useSystemd := c.DaemonConfig != nil && c.DaemonConfig.UseSystemd()
+		info, err = podPressureInfo(sandboxMeta.Config.GetLinux().GetCgroupParent(), useSystemd)
+		if err != nil {
+			log.With(ctx).Warnf("failed to get pressure of sandbox %q: %v", podSandboxID, err)
+		}
+
+		overhead, err := podOverheadInfo(sandboxMeta)
+		if err != nil {
+			log.With(ctx).Warnf("failed to get overhead of sandbox %q: %v", podSandboxID, err)
+		}
+		for k, v := range overhead {
+			if info == nil {
+				info = make(map[string]string)
+			}
+			info[k] = v
+		}
+	}
+
 	metrics.PodSuccessActionsCounter.WithLabelValues(label).Inc()
 
-	return &runtime.PodSandboxStatusResponse{Status: status}, nil
+	return &runtime.PodSandboxStatusResponse{Status: status, Info: info}, nil
 }
 
 // ListPodSandbox returns a list of Sandbox.
@@ -695,11 +1374,23 @@ func (c *CriManager) ListPodSandbox(ctx context.Context, r *runtime.ListPodSandb
 
 	sandboxes := make([]*runtime.PodSandbox, 0, len(sandboxMap))
 	for id, metadata := range sandboxMap {
+		// the state of sandbox held by the holder is not derived from the events.
+		if sm, ok := metadata.(*metatypes.SandboxMeta); ok && sm.Holder != nil && !isPartialSandbox(sm) {
+			sandboxes = append(sandboxes, holderPodSandbox(sm))
+			continue
+		}
+
+		cached, version := c.sandboxCache.get(id)
+		if cached != nil {
+			sandboxes = append(sandboxes, cached)
+			continue
+		}
+
 		s, err := c.ContainerMgr.Get(ctx, id)
 		// metadata exists but container not found
 		if err != nil {
 			sm, ok := metadata.(*metatypes.SandboxMeta)
-			if !ok || sm == nil || sm.Config == nil {
+			if !ok || sm == nil || isPartialSandbox(sm) {
 				// partially created sandbox.
 				continue
 			}
@@ -719,7 +1410,7 @@ func (c *CriManager) ListPodSandbox(ctx context.Context, r *runtime.ListPodSandb
 			log.With(ctx).Warningf("failed to parse state of sandbox %q: %v", id, err)
 			continue
 		}
-		sandboxes = append(sandboxes, sandbox)
+		sandboxes = append(sandboxes, c.sandboxCache.put(sandbox, version))
 	}
 
 	result := filterCRISandboxes(sandboxes, r.GetFilter())
@@ -730,13 +1421,17 @@ func (c *CriManager) ListPodSandbox(ctx context.Context, r *runtime.ListPodSandb
 }
 
 // CreateContainer creates a new container in the given PodSandbox.
-func (c *CriManager) CreateContainer(ctx context.Context, r *runtime.CreateContainerRequest) (*runtime.CreateContainerResponse, error) {
+func (c *CriManager) CreateContainer(ctx context.Context, r *runtime.CreateContainerRequest) (_ *runtime.CreateContainerResponse, retErr error) {
 	label := util_metrics.ActionCreateLabel
 	defer func(start time.Time) {
 		metrics.ContainerActionsCounter.WithLabelValues(label).Inc()
 		metrics.ContainerActionsTimer.WithLabelValues(label).Observe(time.Since(start).Seconds())
 	}(time.Now())
 
+	if err := c.checkDraining("CreateContainer"); err != nil {
+		return nil, err
+	}
+
 	config := r.GetConfig()
 	if config.GetMetadata() == nil {
 		return nil, fmt.Errorf("container metadata required")
@@ -745,24 +1440,36 @@ func (c *CriManager) CreateContainer(ctx context.Context, r *runtime.CreateConta
 	sandboxConfig := r.GetSandboxConfig()
 	podSandboxID := r.GetPodSandboxId()
 
-	// get sandbox
-	sandbox, err := c.ContainerMgr.Get(ctx, podSandboxID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get sandbox %q: %v", podSandboxID, err)
+	// Reserve the container name to reject the concurrent requests of the same container attempt.
+	containerName := makeContainerName(sandboxConfig, config)
+	if err := c.names.reserve(containerName); err != nil {
+		return nil, err
 	}
+	defer c.names.release(containerName)
 
 	res, err := c.SandboxStore.Get(podSandboxID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get metadata of %q from SandboxStore: %v", podSandboxID, err)
 	}
 	sandboxMeta := res.(*metatypes.SandboxMeta)
-	sandboxMeta.NetNS = containerNetns(sandbox)
+
+	// get sandbox, the one held by the holder has no container and netns.
+	if sandboxMeta.Holder == nil {
+		sandbox, err := c.ContainerMgr.Get(ctx, podSandboxID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get sandbox %q: %v", podSandboxID, err)
+		}
+		sandboxMeta.NetNS = containerNetns(sandbox)
+	}
 
 	labels := makeLabels(config.GetLabels(), config.GetAnnotations())
 	// Apply the container type label.
 	labels[containerTypeLabelKey] = containerTypeLabelContainer
 	// Write the sandbox ID in the labels.
 	labels[sandboxIDLabelKey] = podSandboxID
+	// Write the metadata in the labels.
+	labels[metadataNameLabelKey] = config.GetMetadata().GetName()
+	labels[metadataAttemptLabelKey] = strconv.FormatUint(uint64(config.GetMetadata().GetAttempt()), 10)
 	// Get container log.
 	var logPath string
 	if config.GetLogPath() != "" {
@@ -778,6 +1485,20 @@ func (c *CriManager) CreateContainer(ctx context.Context, r *runtime.CreateConta
 	specAnnotation[anno.CRIOSandboxID] = podSandboxID
 	specAnnotation[anno.SandboxID] = podSandboxID
 
+	// Mount the subpaths of volumes in place of the volumes.
+	sandboxRootDir := path.Join(c.SandboxBaseDir, podSandboxID)
+	mounts, err := applySubPaths(sandboxRootDir, containerName, config.GetMounts(), config.GetAnnotations())
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if retErr != nil {
+			if err := removeSubPaths(containerSubPathsDir(sandboxRootDir, containerName)); err != nil {
+				log.With(ctx).Errorf("failed to remove subpaths of container %q: %v", containerName, err)
+			}
+		}
+	}()
+
 	resources := r.GetConfig().GetLinux().GetResources()
 	createConfig := &apitypes.ContainerCreateConfig{
 		ContainerConfig: apitypes.ContainerConfig{
@@ -797,21 +1518,53 @@ func (c *CriManager) CreateContainer(ctx context.Context, r *runtime.CreateConta
 			QuotaID:        config.GetQuotaId(),
 		},
 		HostConfig: &apitypes.HostConfig{
-			Binds:     generateMountBindings(config.GetMounts()),
+			Binds:     generateMountBindings(mounts),
 			Resources: parseResourcesFromCRI(resources),
 		},
 		NetworkingConfig: &apitypes.NetworkingConfig{},
 	}
+	// the swap behavior applies to the containers without swap limit.
+	createConfig.HostConfig.MemorySwap = memorySwapLimit(resources, c.swapBehavior)
+	// the annotations of container take precedence over the ones of pod.
+	passthroughAnnotations(c.passthroughAnnotations, specAnnotation, sandboxConfig.GetAnnotations(), config.GetAnnotations())
+
+	// Validate the target container before its pid namespace is joined.
+	if err := c.validateTargetNamespace(ctx, podSandboxID, config.GetLinux().GetSecurityContext().GetNamespaceOptions()); err != nil {
+		return nil, err
+	}
 
 	err = c.updateCreateConfig(createConfig, config, sandboxConfig, sandboxMeta)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := c.applyContainerRdtClass(createConfig.HostConfig, config.GetAnnotations(), sandboxConfig); err != nil {
+		return nil, err
+	}
+
+	if createConfig.HostConfig.Privileged {
+		if err := c.privilegedPolicy.check(ctx, config, sandboxConfig); err != nil {
+			return nil, err
+		}
+	}
+
+	// Validate the health check before the container is created.
+	if _, err := parseHealthCheckConfig(config.GetAnnotations()); err != nil {
+		return nil, err
+	}
+
 	// Bindings to overwrite the container's /etc/resolv.conf, /etc/hosts etc.
-	sandboxRootDir := path.Join(c.SandboxBaseDir, podSandboxID)
 	createConfig.HostConfig.Binds = append(createConfig.HostConfig.Binds, generateContainerMounts(sandboxRootDir)...)
 
+	// Apply the timezone before the default mounts, so that its zoneinfo overrides the default /etc/localtime.
+	if err := applyTimezone(createConfig, config, sandboxConfig); err != nil {
+		return nil, err
+	}
+
+	// Inject the default mounts which are neither overridden nor excluded.
+	defaultMounts := defaultMountsFor(c.defaultMounts, createConfig.HostConfig.Binds, sandboxConfig.GetAnnotations())
+	createConfig.HostConfig.Binds = append(createConfig.HostConfig.Binds, generateMountBindings(defaultMounts)...)
+
 	var devices []*apitypes.DeviceMapping
 	for _, device := range config.GetDevices() {
 		devices = append(devices, &apitypes.DeviceMapping{
@@ -822,7 +1575,8 @@ func (c *CriManager) CreateContainer(ctx context.Context, r *runtime.CreateConta
 	}
 	createConfig.HostConfig.Resources.Devices = devices
 
-	containerName := makeContainerName(sandboxConfig, config)
+	// Inject the env of node, e.g. the proxy, before the cri plugin updates the create config.
+	injectEnv(c.envInjections, createConfig, config, sandboxConfig)
 
 	// call cri plugin to update create config
 	if c.CriPlugin != nil {
@@ -831,20 +1585,52 @@ func (c *CriManager) CreateContainer(ctx context.Context, r *runtime.CreateConta
 		}
 	}
 
-	createResp, err := c.ContainerMgr.Create(ctx, containerName, createConfig)
+	// Pull the image missing, e.g. removed by the image gc after kubelet pulled it.
+	if c.pullOnCreate {
+		if err := c.ensureContainerImage(ctx, sandboxConfig.GetMetadata().GetUid(), sandboxMeta.Runtime, createConfig.Image); err != nil {
+			return nil, err
+		}
+	}
+
+	// Classify the traffic of sandbox by the net priority of container.
+	if config.GetNetPriority() > 0 && sandboxNetworkMode(sandboxConfig) != runtime.NamespaceMode_NODE {
+		rollback, err := c.applyNetPriority(sandboxMeta, config.GetNetPriority())
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			if retErr != nil {
+				rollback()
+			}
+		}()
+	}
+
+	// The exclusive cpus are held by the ID of container to be created.
+	if err := c.assignCpuset(createConfig, config.GetAnnotations()); err != nil {
+		return nil, fmt.Errorf("failed to assign cpuset of container %q: %v", containerName, err)
+	}
+
+	// the rootfs of container is prepared in the snapshotter of runtime handler
+	// of sandbox, the image is unpacked into it if not yet.
+	createResp, err := c.ContainerMgr.Create(c.withRuntimeSnapshotter(ctx, sandboxMeta.Runtime), containerName, createConfig)
 	if err != nil {
+		if createConfig.SpecificID != "" {
+			c.cpusetManager.release(createConfig.SpecificID)
+		}
 		return nil, fmt.Errorf("failed to create container for sandbox %q: %v", podSandboxID, err)
 	}
 
 	containerID := createResp.ID
+	c.attempts.observe(podSandboxID, config.GetMetadata().GetName(), config.GetMetadata().GetAttempt())
 
 	defer func() {
 		// If the container failed to be created, clean up the container.
-		if err != nil {
+		if retErr != nil {
 			removeErr := c.ContainerMgr.Remove(ctx, containerID, &apitypes.ContainerRemoveOptions{Volumes: true, Force: true})
 			if removeErr != nil {
 				log.With(ctx).Errorf("failed to remove the container when creating container failed: %v", removeErr)
 			}
+			c.cpusetManager.release(containerID)
 		}
 	}()
 
@@ -854,6 +1640,18 @@ func (c *CriManager) CreateContainer(ctx context.Context, r *runtime.CreateConta
 		}
 	}
 
+	// Validate the username against the /etc/passwd of image.
+	if username := config.GetLinux().GetSecurityContext().GetRunAsUsername(); username != "" {
+		if err = c.validateRunAsUsername(ctx, containerID, username); err != nil {
+			return nil, err
+		}
+	}
+
+	// The shared cpus are shrunk by the exclusive ones.
+	if createConfig.Labels[cpusetPoolLabelKey] == cpusetPoolLabelExclusive {
+		c.reconcileSharedCpusets(ctx)
+	}
+
 	metrics.ContainerSuccessActionsCounter.WithLabelValues(label).Inc()
 
 	return &runtime.CreateContainerResponse{ContainerId: containerID}, nil
@@ -874,6 +1672,19 @@ func (c *CriManager) StartContainer(ctx context.Context, r *runtime.StartContain
 		return nil, fmt.Errorf("failed to start container %q: %v", containerID, err)
 	}
 
+	if c.CriPlugin != nil {
+		if err := c.CriPlugin.PostStartContainer(ctx, containerID, c.containerSandboxMeta(ctx, containerID)); err != nil {
+			log.With(ctx).Warnf("failed to run post start hook of container %q: %v", containerID, err)
+		}
+	}
+
+	if container, err := c.ContainerMgr.Get(ctx, containerID); err == nil {
+		if err := verifyMountOptions(container); err != nil {
+			log.With(ctx).Warnf("failed to verify mount options of container %q: %v", containerID, err)
+		}
+		c.startHealthCheck(ctx, container)
+	}
+
 	metrics.ContainerSuccessActionsCounter.WithLabelValues(label).Inc()
 
 	return &runtime.StartContainerResponse{}, nil
@@ -889,6 +1700,9 @@ func (c *CriManager) StopContainer(ctx context.Context, r *runtime.StopContainer
 
 	containerID := r.GetContainerId()
 
+	// the stopped container should not be restarted by health check.
+	c.healthChecker.stop(containerID)
+
 	err := c.ContainerMgr.Stop(ctx, containerID, r.GetTimeout())
 	if err != nil {
 		return nil, fmt.Errorf("failed to stop container %q: %v", containerID, err)
@@ -909,9 +1723,41 @@ func (c *CriManager) RemoveContainer(ctx context.Context, r *runtime.RemoveConta
 
 	containerID := r.GetContainerId()
 
+	// get the sandbox meta before the container is removed.
+	var sandboxMeta *metatypes.SandboxMeta
+	if c.CriPlugin != nil {
+		sandboxMeta = c.containerSandboxMeta(ctx, containerID)
+	}
+
+	c.healthChecker.stop(containerID)
+
+	// get the subpaths of container before it is removed.
+	subPaths := c.containerSubPaths(ctx, containerID)
+
 	if err := c.ContainerMgr.Remove(ctx, containerID, &apitypes.ContainerRemoveOptions{Volumes: true, Force: true}); err != nil {
 		return nil, fmt.Errorf("failed to remove container %q: %v", containerID, err)
 	}
+	c.imageRefCache.remove(containerID)
+	c.metricsCollector.forget(containerID)
+
+	// the container has been removed, so the subpaths failed to be removed are
+	// left to the removal of sandbox rather than failing the retries of kubelet.
+	if subPaths != "" {
+		if err := removeSubPaths(subPaths); err != nil {
+			log.With(ctx).Warnf("failed to remove subpaths of container %q: %v", containerID, err)
+		}
+	}
+
+	// The shared cpus are extended by the exclusive ones freed.
+	if c.cpusetManager.release(containerID) {
+		c.reconcileSharedCpusets(ctx)
+	}
+
+	if c.CriPlugin != nil {
+		if err := c.CriPlugin.PostRemoveContainer(ctx, containerID, sandboxMeta); err != nil {
+			log.With(ctx).Warnf("failed to run post remove hook of container %q: %v", containerID, err)
+		}
+	}
 
 	metrics.ContainerSuccessActionsCounter.WithLabelValues(label).Inc()
 
@@ -926,13 +1772,15 @@ func (c *CriManager) ListContainers(ctx context.Context, r *runtime.ListContaine
 		metrics.ContainerActionsTimer.WithLabelValues(label).Observe(time.Since(start).Seconds())
 	}(time.Now())
 
-	opts := &mgr.ContainerListOption{All: true}
-	filter := func(c *mgr.Container) bool {
-		return c.Config.Labels[containerTypeLabelKey] == containerTypeLabelContainer
+	// Filter *only* (non-sandbox) containers.
+	opts := &mgr.ContainerListOption{
+		All:    true,
+		Labels: map[string]string{containerTypeLabelKey: containerTypeLabelContainer},
+	}
+	if id := r.GetFilter().GetPodSandboxId(); id != "" {
+		opts.Labels[sandboxIDLabelKey] = id
 	}
-	opts.FilterFunc = filter
 
-	// Filter *only* (non-sandbox) containers.
 	containerList, err := c.ContainerMgr.List(ctx, opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list container: %v", err)
@@ -999,25 +1847,16 @@ func (c *CriManager) ContainerStatus(ctx context.Context, r *runtime.ContainerSt
 
 	state, reason := toCriContainerState(container.State)
 
-	metadata, err := parseContainerName(container.Name)
+	metadata, err := containerMetadata(container)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get container status of %q: %v", id, err)
 	}
 
 	labels, annotations := extractLabels(container.Config.Labels)
 
-	// FIXME(fuwei): if user repush image with the same reference, the image
-	// ID will be changed. For now, pouch daemon will remove the old image ID
-	// so that CRI fails to fetch the running container. Before upgrade
-	// pouch daemon image manager, we use reference to get image instead of
-	// id.
-	imageInfo, err := c.ImageMgr.GetImage(ctx, container.Config.Image)
+	imageRef, err := c.containerImageRef(ctx, container)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get image %s: %v", container.Config.Image, err)
-	}
-	imageRef := imageInfo.ID
-	if len(imageInfo.RepoDigests) > 0 {
-		imageRef = imageInfo.RepoDigests[0]
+		return nil, err
 	}
 
 	logPath := labels[containerLogPathLabelKey]
@@ -1046,9 +1885,34 @@ func (c *CriManager) ContainerStatus(ctx context.Context, r *runtime.ContainerSt
 		Envs:        parseEnvsFromPouch(container.Config.Env),
 	}
 
+	// the processes of container are limited by the pod cgroup as well.
+	if res, err := c.SandboxStore.Get(container.Config.Labels[sandboxIDLabelKey]); err == nil {
+		if sandboxMeta, ok := res.(*metatypes.SandboxMeta); ok {
+			status.Resources.PidsLimit = effectivePidsLimit(status.Resources.PidsLimit, sandboxMeta.PidsLimit)
+		}
+	}
+
+	resp := &runtime.ContainerStatusResponse{Status: status}
+	if r.GetVerbose() {
+		resp.Info = make(map[string]string)
+		// the restart count is the latest attempt of the container name in the sandbox.
+		restartCount, ok := c.attempts.latest(container.Config.Labels[sandboxIDLabelKey], metadata.Name)
+		if !ok || restartCount < metadata.Attempt {
+			restartCount = metadata.Attempt
+		}
+		resp.Info["restartCount"] = strconv.FormatUint(uint64(restartCount), 10)
+		if health := c.healthChecker.state(container.ID); health != nil {
+			healthByt, err := json.Marshal(health)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal health state of container %q: %v", id, err)
+			}
+			resp.Info["health"] = string(healthByt)
+		}
+	}
+
 	metrics.ContainerSuccessActionsCounter.WithLabelValues(label).Inc()
 
-	return &runtime.ContainerStatusResponse{Status: status}, nil
+	return resp, nil
 }
 
 // ContainerStats returns stats of the container. If the container does not
@@ -1067,10 +1931,18 @@ func (c *CriManager) ContainerStats(ctx context.Context, r *runtime.ContainerSta
 		return nil, fmt.Errorf("failed to get container %q with error: %v", containerID, err)
 	}
 
-	cs, err := c.getContainerMetrics(ctx, container)
+	containerMetrics, err := c.metricsCollector.get(ctx, []string{container.ID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stats of container %q: %v", container.ID, err)
+	}
+
+	cs, err := c.getContainerMetrics(ctx, container, containerMetrics[container.ID])
 	if err != nil {
 		return nil, fmt.Errorf("failed to decode container metrics: %v", err)
 	}
+	if r.GetVerbose() {
+		cs.Info = containerStatsInfo(ctx, container)
+	}
 
 	metrics.ContainerSuccessActionsCounter.WithLabelValues(label).Inc()
 
@@ -1085,18 +1957,32 @@ func (c *CriManager) ListContainerStats(ctx context.Context, r *runtime.ListCont
 		metrics.ContainerActionsTimer.WithLabelValues(label).Observe(time.Since(start).Seconds())
 	}(time.Now())
 
-	opts := &mgr.ContainerListOption{All: true}
-	filter := func(c *mgr.Container) bool {
-		if c.Config.Labels[containerTypeLabelKey] != containerTypeLabelContainer {
-			return false
-		}
+	// the verbose responses are cached apart from the others.
+	result, err := c.statsCache.get(r.String(), func() (*runtime.ListContainerStatsResponse, error) {
+		return c.listContainerStats(ctx, r)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	metrics.ContainerSuccessActionsCounter.WithLabelValues(label).Inc()
+
+	return result, nil
+}
 
+// listContainerStats collects the stats of the containers matching the filter.
+func (c *CriManager) listContainerStats(ctx context.Context, r *runtime.ListContainerStatsRequest) (*runtime.ListContainerStatsResponse, error) {
+	opts := &mgr.ContainerListOption{
+		All:    true,
+		Labels: map[string]string{containerTypeLabelKey: containerTypeLabelContainer},
+	}
+	if id := r.GetFilter().GetPodSandboxId(); id != "" {
+		opts.Labels[sandboxIDLabelKey] = id
+	}
+	filter := func(c *mgr.Container) bool {
 		if r.GetFilter().GetId() != "" && c.ID != r.GetFilter().GetId() {
 			return false
 		}
-		if r.GetFilter().GetPodSandboxId() != "" && c.Config.Labels[sandboxIDLabelKey] != r.GetFilter().GetPodSandboxId() {
-			return false
-		}
 		if r.GetFilter().GetLabelSelector() != nil &&
 			!utils.MatchLabelSelector(r.GetFilter().GetLabelSelector(), c.Config.Labels) {
 			return false
@@ -1110,19 +1996,29 @@ func (c *CriManager) ListContainerStats(ctx context.Context, r *runtime.ListCont
 		return nil, fmt.Errorf("failed to list containers: %v", err)
 	}
 
+	ids := make([]string, 0, len(containers))
+	for _, container := range containers {
+		ids = append(ids, container.ID)
+	}
+	containerMetrics, err := c.metricsCollector.get(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stats of containers: %v", err)
+	}
+
 	result := &runtime.ListContainerStatsResponse{}
 	for _, container := range containers {
-		cs, err := c.getContainerMetrics(ctx, container)
+		cs, err := c.getContainerMetrics(ctx, container, containerMetrics[container.ID])
 		if err != nil {
 			log.With(ctx).Warnf("failed to decode metrics of container %q: %v", container.ID, err)
 			continue
 		}
+		if r.GetVerbose() {
+			cs.Info = containerStatsInfo(ctx, container)
+		}
 
 		result.Stats = append(result.Stats, cs)
 	}
 
-	metrics.ContainerSuccessActionsCounter.WithLabelValues(label).Inc()
-
 	return result, nil
 }
 
@@ -1151,16 +2047,69 @@ func (c *CriManager) UpdateContainerResources(ctx context.Context, r *runtime.Up
 		DiskQuota:      resources.GetDiskQuota(),
 		SpecAnnotation: r.GetSpecAnnotations(),
 	}
+	updateConfig.Resources.MemorySwap = memorySwapLimit(resources, c.swapBehavior)
 
 	err = applyContainerConfigByAnnotation(updateConfig.SpecAnnotation, nil, nil, updateConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to apply annotation to update config: %v", err)
 	}
+	// the cgroup files are kept in the spec annotations for the next start.
+	files, err := containerCgroupFiles(updateConfig.SpecAnnotation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply annotation to update config: %v", err)
+	}
+	for k, v := range files {
+		updateConfig.SpecAnnotation[k] = v
+	}
+
+	// The pause is an action rather than a config, which is not kept in the annotations.
+	paused, err := pausedAnnotation(updateConfig.SpecAnnotation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply annotation to update config: %v", err)
+	}
+	if paused != nil {
+		delete(updateConfig.SpecAnnotation, anno.PausedExtendAnnotation)
+	}
+	upgradeConfig, err := upgradeAnnotation(updateConfig.SpecAnnotation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply annotation to update config: %v", err)
+	}
 
 	err = c.ContainerMgr.Update(ctx, containerID, updateConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update resource for container %q: %v", containerID, err)
 	}
+	// the update resets the devices cgroup from the spec.
+	if err := c.reapplyHotplugDevices(ctx, containerID); err != nil {
+		return nil, fmt.Errorf("failed to apply hot-plugged devices of container %q: %v", containerID, err)
+	}
+
+	// the container is upgraded with the updated resources.
+	if upgradeConfig != nil {
+		if err := c.upgradeContainer(ctx, containerID, upgradeConfig); err != nil {
+			return nil, err
+		}
+	}
+
+	container, err = c.ContainerMgr.Get(ctx, containerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get container %q: %v", containerID, err)
+	}
+	// the memory QoS and cpu burst of the stopped container are set by the prestart hook when it is started again.
+	if container.IsRunningOrPaused() {
+		if err := applyContainerMemoryQoS(container); err != nil {
+			return nil, err
+		}
+		if err := applyContainerCPUBurst(container); err != nil {
+			return nil, err
+		}
+	}
+
+	if paused != nil {
+		if err := c.setContainerPaused(ctx, container, *paused); err != nil {
+			return nil, err
+		}
+	}
 
 	metrics.ContainerSuccessActionsCounter.WithLabelValues(label).Inc()
 
@@ -1249,6 +2198,18 @@ func (c *CriManager) PortForward(ctx context.Context, r *runtime.PortForwardRequ
 	return c.StreamServer.GetPortForward(r)
 }
 
+// CopyContainer prepares a streaming endpoint to copy files from or to the container, and returns the address.
+func (c *CriManager) CopyContainer(ctx context.Context, r *metatypes.CopyRequest) (*metatypes.CopyResponse, error) {
+	container, err := c.ContainerMgr.Get(ctx, r.ContainerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get container %q: %v", r.ContainerID, err)
+	}
+
+	req := *r
+	req.ContainerID = container.ID
+	return c.StreamServer.GetCopy(&req)
+}
+
 // UpdateRuntimeConfig updates the runtime config. Currently only handles podCIDR updates.
 func (c *CriManager) UpdateRuntimeConfig(ctx context.Context, r *runtime.UpdateRuntimeConfigRequest) (*runtime.UpdateRuntimeConfigResponse, error) {
 	podCIDR := r.GetRuntimeConfig().GetNetworkConfig().GetPodCidr()
@@ -1310,6 +2271,25 @@ func (c *CriManager) Status(ctx context.Context, r *runtime.StatusRequest) (*run
 		resp.Info["golang"] = string(versionByt)
 		resp.Info["daemon-config"] = string(configByt)
 
+		propagationByt, err := json.Marshal(checkMountPropagation(c.propagationPaths(), false))
+		if err != nil {
+			return nil, err
+		}
+		resp.Info["mount-propagation"] = string(propagationByt)
+
+		// the networks are reported even if failing to be loaded, so that
+		// the network plugin not ready could be diagnosed.
+		networks, err := c.CniMgr.NetworkInfos()
+		if err != nil {
+			resp.Info["cni-networks-error"] = err.Error()
+		} else {
+			networksByt, err := json.Marshal(networks)
+			if err != nil {
+				return nil, err
+			}
+			resp.Info["cni-networks"] = string(networksByt)
+		}
+
 		// TODO return more info
 	}
 
@@ -1406,17 +2386,10 @@ func (c *CriManager) PullImage(ctx context.Context, r *runtime.PullImageRequest)
 		metrics.ImageActionsTimer.WithLabelValues(label).Observe(time.Since(start).Seconds())
 	}(time.Now())
 
-	authConfig := &apitypes.AuthConfig{}
-	if auth := r.GetAuth(); auth != nil {
-		authConfig.Auth = auth.GetAuth()
-		authConfig.Username = auth.GetUsername()
-		authConfig.Password = auth.GetPassword()
-		authConfig.ServerAddress = auth.GetServerAddress()
-		authConfig.IdentityToken = auth.GetIdentityToken()
-		authConfig.RegistryToken = auth.GetRegistryToken()
-	}
+	authConfig := toAuthConfig(r.GetAuth())
 
-	if err := c.ImageMgr.PullImage(ctx, imageRef, authConfig, bytes.NewBuffer([]byte{})); err != nil {
+	// unpack the image into the snapshotter of runtime handler of the pod.
+	if err := c.pullImage(ctx, c.podRuntimeHandler(r.GetSandboxConfig()), imageRef, authConfig, bytes.NewBuffer([]byte{})); err != nil {
 		return nil, err
 	}
 
@@ -1424,6 +2397,9 @@ func (c *CriManager) PullImage(ctx context.Context, r *runtime.PullImageRequest)
 	if err != nil {
 		return nil, err
 	}
+	// the image may be pulled again by the reference on CreateContainer, which
+	// refers to it by the id returned.
+	c.podImagePulls.remember(r.GetSandboxConfig().GetMetadata().GetUid(), imageRef, imageInfo.ID, authConfig)
 
 	metrics.ImageSuccessActionsCounter.WithLabelValues(label).Inc()
 
@@ -1448,6 +2424,12 @@ func (c *CriManager) RemoveImage(ctx context.Context, r *runtime.RemoveImageRequ
 		return nil, err
 	}
 
+	// the warm image removed, e.g. by the image gc, is prefetched again
+	// unless it was prefetched within the interval of warm images.
+	if c.imagePrefetcher != nil && len(c.imagePrefetcher.warm) > 0 {
+		c.workers.run(c.imagePrefetcher.reconcileWarm)
+	}
+
 	metrics.ImageSuccessActionsCounter.WithLabelValues(label).Inc()
 
 	return &runtime.RemoveImageResponse{}, nil
@@ -1461,30 +2443,11 @@ func (c *CriManager) ImageFsInfo(ctx context.Context, r *runtime.ImageFsInfoRequ
 		metrics.ImageActionsTimer.WithLabelValues(label).Observe(time.Since(start).Seconds())
 	}(time.Now())
 
-	snapshots := c.SnapshotStore.List()
-	timestamp := time.Now().UnixNano()
-	var usedBytes, inodesUsed uint64
-	for _, sn := range snapshots {
-		// Use the oldest timestamp as the timestamp of imagefs info.
-		if sn.Timestamp < timestamp {
-			timestamp = sn.Timestamp
-		}
-		usedBytes += sn.Size
-		inodesUsed += sn.Inodes
-	}
+	usages := imageFsUsages(c.SnapshotStore.List(), c.imageFSRootDir, ctrd.CurrentSnapshotterName(ctx))
 
 	metrics.ImageSuccessActionsCounter.WithLabelValues(label).Inc()
 
-	return &runtime.ImageFsInfoResponse{
-		ImageFilesystems: []*runtime.FilesystemUsage{
-			{
-				Timestamp:  timestamp,
-				FsId:       &runtime.FilesystemIdentifier{Mountpoint: c.imageFSPath},
-				UsedBytes:  &runtime.UInt64Value{Value: usedBytes},
-				InodesUsed: &runtime.UInt64Value{Value: inodesUsed},
-			},
-		},
-	}, nil
+	return &runtime.ImageFsInfoResponse{ImageFilesystems: usages}, nil
 }
 
 // RemoveVolume removes the volume.
@@ -17,22 +17,35 @@ import (
 	apitypes "github.com/alibaba/pouch/apis/types"
 	anno "github.com/alibaba/pouch/cri/annotations"
 	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+	cni "github.com/alibaba/pouch/cri/ocicni"
 	"github.com/alibaba/pouch/cri/stream"
 	metatypes "github.com/alibaba/pouch/cri/v1alpha2/types"
 	"github.com/alibaba/pouch/daemon/config"
 	"github.com/alibaba/pouch/daemon/mgr"
 	"github.com/alibaba/pouch/pkg/errtypes"
+	"github.com/alibaba/pouch/pkg/log"
+	"github.com/alibaba/pouch/pkg/meta"
 	"github.com/alibaba/pouch/pkg/netutils"
 	"github.com/alibaba/pouch/pkg/randomid"
+	"github.com/alibaba/pouch/pkg/user"
 	"github.com/alibaba/pouch/pkg/utils"
+	"github.com/alibaba/pouch/pkg/utils/signal"
 
 	"github.com/cri-o/ocicni/pkg/ocicni"
+	"github.com/docker/docker/daemon/caps"
+	units "github.com/docker/go-units"
 	"github.com/go-openapi/strfmt"
 	"golang.org/x/net/context"
 )
 
 func toStreamConfig(cfg *config.Config) (stream.Config, error) {
-	var address string
+	streamCfg := stream.DefaultConfig
+	if cfg.CriConfig.StreamIdleTimeout > 0 {
+		streamCfg.StreamIdleTimeout = time.Duration(cfg.CriConfig.StreamIdleTimeout) * time.Second
+	}
+	streamCfg.BehindProxy = cfg.CriConfig.StreamServerBehindProxy
+
+	address := cfg.CriConfig.StreamServerAddress
 	port := cfg.CriConfig.StreamServerPort
 	// If stream server reuse the pouchd's port, extract the ip and port from pouchd's listening addresses.
 	if cfg.CriConfig.StreamServerReusePort {
@@ -45,26 +58,51 @@ func toStreamConfig(cfg *config.Config) (stream.Config, error) {
 	// If the reused pouchd's port is https, the url that stream server return should be with https scheme.
 	reuseHTTPSPort := cfg.CriConfig.StreamServerReusePort && cfg.TLS.Key != "" && cfg.TLS.Cert != ""
 
-	ip := net.ParseIP(address)
-	// If the address is "" or "0.0.0.0", choose a proper one by ourselves.
-	if ip == nil || ip.IsUnspecified() {
+	// The stream server listens on all the interfaces if "0.0.0.0" is specified,
+	// while the address in urls is a proper one chosen by ourselves.
+	listenAddress := address
+	if ip := net.ParseIP(address); address == "" || (ip != nil && ip.IsUnspecified()) {
 		a, err := netutils.ChooseBindAddress(nil)
 		if err != nil {
 			return stream.Config{}, fmt.Errorf("failed to get stream server address: %v", err)
 		}
 		address = a.String()
+		if listenAddress == "" || cfg.CriConfig.StreamServerReusePort {
+			listenAddress = address
+		}
 	}
 
-	streamCfg := stream.DefaultConfig
-	streamCfg.Address = net.JoinHostPort(address, port)
+	streamCfg.Address = net.JoinHostPort(listenAddress, port)
 	streamCfg.BaseURL = &url.URL{
 		Scheme: "http",
-		Host:   streamCfg.Address,
+		Host:   net.JoinHostPort(address, port),
 	}
 	if reuseHTTPSPort {
 		streamCfg.BaseURL.Scheme = "https"
 	}
 
+	return withStreamBaseURL(streamCfg, cfg.CriConfig.StreamServerBaseURL)
+}
+
+// withStreamBaseURL replaces the base url of streaming urls with the advertised
+// one, e.g. the address of NAT or reverse proxy, if specified.
+func withStreamBaseURL(streamCfg stream.Config, baseURL string) (stream.Config, error) {
+	if baseURL == "" {
+		return streamCfg, nil
+	}
+
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return stream.Config{}, fmt.Errorf("invalid stream server base url %q: %v", baseURL, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return stream.Config{}, fmt.Errorf("invalid stream server base url %q: should be an absolute http or https url", baseURL)
+	}
+	// the streaming urls are resolved under the path of base url.
+	if !strings.HasSuffix(u.Path, "/") {
+		u.Path += "/"
+	}
+	streamCfg.BaseURL = u
 	return streamCfg, nil
 }
 
@@ -139,6 +177,10 @@ func extractLabels(input map[string]string) (map[string]string, map[string]strin
 		for _, internalKey := range []string{
 			containerTypeLabelKey,
 			sandboxIDLabelKey,
+			metadataNameLabelKey,
+			metadataAttemptLabelKey,
+			cpusetPoolLabelKey,
+			hotplugDevicesLabelKey,
 		} {
 			if k == internalKey {
 				internal = true
@@ -160,8 +202,8 @@ func extractLabels(input map[string]string) (map[string]string, map[string]strin
 	return labels, annotations
 }
 
-// generateContainerMounts sets up necessary container mounts including /dev/shm, /etc/hosts
-// and /etc/resolv.conf.
+// generateContainerMounts sets up necessary container mounts including /etc/resolv.conf
+// and /etc/hostname.
 func generateContainerMounts(sandboxRootDir string) []string {
 	// TODO: more attr and check whether these bindings is included in cri mounts.
 	result := []string{}
@@ -169,6 +211,13 @@ func generateContainerMounts(sandboxRootDir string) []string {
 	containerPath := resolvConfPath
 	result = append(result, fmt.Sprintf("%s:%s", hostPath, containerPath))
 
+	// the hostname is missing in the sandboxes created without hostname or by
+	// the old pouchd, whose containers use the hostname of sandbox container.
+	hostPath = path.Join(sandboxRootDir, "hostname")
+	if _, err := os.Stat(hostPath); err == nil {
+		result = append(result, fmt.Sprintf("%s:%s", hostPath, hostnamePath))
+	}
+
 	return result
 }
 
@@ -183,6 +232,8 @@ func generateMountBindings(mounts []*runtime.Mount) []string {
 		if m.SelinuxRelabel {
 			attrs = append(attrs, "Z")
 		}
+		// keep the hardening of the mount of host path, e.g. the tmpfs of secrets.
+		attrs = append(attrs, sourceMountOptions(m.HostPath)...)
 		switch m.Propagation {
 		case runtime.MountPropagation_PROPAGATION_PRIVATE:
 			// noop, default mode is private.
@@ -217,23 +268,26 @@ func makeSandboxName(c *runtime.PodSandboxConfig) string {
 func parseSandboxName(name string) (*runtime.PodSandboxMetadata, error) {
 	format := fmt.Sprintf("%s_%s_${sandbox name}_${sandbox namespace}_${sandbox uid}_${sandbox attempt}", kubePrefix, sandboxContainerName)
 
+	// the sandbox name may contain the delimiter, while the namespace, uid
+	// and attempt could not, so the parts are parsed from the end.
 	parts := strings.Split(name, nameDelimiter)
-	if len(parts) != 6 {
+	if len(parts) < 6 {
 		return nil, fmt.Errorf("failed to parse sandbox name: %q, which should be %s", name, format)
 	}
 	if parts[0] != kubePrefix {
 		return nil, fmt.Errorf("sandbox container is not managed by kubernetes: %q", name)
 	}
 
-	attempt, err := parseUint32(parts[5])
+	n := len(parts)
+	attempt, err := parseUint32(parts[n-1])
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse the attempt times in sandbox name: %q: %v", name, err)
 	}
 
 	return &runtime.PodSandboxMetadata{
-		Name:      parts[2],
-		Namespace: parts[3],
-		Uid:       parts[4],
+		Name:      strings.Join(parts[2:n-3], nameDelimiter),
+		Namespace: parts[n-3],
+		Uid:       parts[n-2],
 		Attempt:   attempt,
 	}, nil
 }
@@ -298,7 +352,8 @@ func applySandboxLinuxOptions(hc *apitypes.HostConfig, lc *runtime.LinuxPodSandb
 	return nil
 }
 
-// applySandboxRuntimeHandler applies the runtime of container specified by the caller.
+// applySandboxRuntimeHandler applies the runtime of container specified by the caller,
+// the meta of sandbox is stored by the caller.
 func (c *CriManager) applySandboxRuntimeHandler(sandboxMeta *metatypes.SandboxMeta, runtimehandler string, annotations map[string]string) error {
 	if runtimehandler == "" {
 		// apply the annotation of io.kubernetes.runtime which specify the runtime of container.
@@ -309,33 +364,82 @@ func (c *CriManager) applySandboxRuntimeHandler(sandboxMeta *metatypes.SandboxMe
 		}
 		runtimehandler = rt
 	}
+
+	// all the containers of sandbox are created by the shim of runtime handler,
+	// so reject the unknown one before creating any container.
+	if _, exist := c.DaemonConfig.Runtimes[runtimehandler]; !exist {
+		return fmt.Errorf("runtime handler %q is not configured in daemon", runtimehandler)
+	}
 	sandboxMeta.Runtime = runtimehandler
-	return c.SandboxStore.Put(sandboxMeta)
+	return nil
 }
 
-// applySandboxAnnotations applies the annotations extended.
+// applySandboxAnnotations applies the annotations extended, the meta of sandbox
+// is stored by the caller.
 func (c *CriManager) applySandboxAnnotations(sandboxMeta *metatypes.SandboxMeta, annotations map[string]string) error {
 	// apply the annotation of io.kubernetes.lxcfs.enabled
-	// which specify whether to enable lxcfs for a container.
+	// which specify whether to enable lxcfs for a container,
+	// the default one of daemon is used if not specified.
+	if c.DaemonConfig != nil && c.DaemonConfig.CriConfig.EnableLxcfs {
+		sandboxMeta.LxcfsEnabled = true
+	}
 	if lxcfsEnabled, ok := annotations[anno.LxcfsEnabled]; ok {
 		enableLxcfs, err := strconv.ParseBool(lxcfsEnabled)
 		if err != nil {
 			return err
 		}
 		sandboxMeta.LxcfsEnabled = enableLxcfs
-		if err := c.SandboxStore.Put(sandboxMeta); err != nil {
-			return err
+	}
+
+	// apply the annotation of io.alibaba.pouch.resources.pod-pids-limit
+	// which limits the number of processes of the whole pod.
+	if pidsLimit, ok := annotations[anno.PodPidsLimitExtendAnnotation]; ok {
+		pl, err := strconv.ParseInt(pidsLimit, 10, 64)
+		if err != nil {
+			return fmt.Errorf("failed to parse resources.pod-pids-limit: %v", err)
 		}
+		sandboxMeta.PidsLimit = pl
+	}
+
+	// apply the annotations of io.alibaba.pouch.resources.pod-memory-min/low/high
+	// which set the memory QoS of the pod cgroup.
+	memoryQoS, err := podMemoryQoS(annotations)
+	if err != nil {
+		return err
+	}
+	if !memoryQoS.IsEmpty() {
+		sandboxMeta.MemoryQoS = memoryQoS
+	}
+
+	// apply the annotation of io.alibaba.pouch.stop-timeout
+	// which overrides the stop timeout of the containers when the pod is stopped.
+	if stopTimeout, ok := annotations[anno.StopTimeoutExtendAnnotation]; ok {
+		timeout, err := strconv.ParseInt(stopTimeout, 10, 64)
+		if err != nil || timeout < 0 {
+			return fmt.Errorf("invalid stop-timeout %q: must be a non-negative integer", stopTimeout)
+		}
+		sandboxMeta.StopTimeout = timeout
 	}
 	return nil
 }
 
+// sandboxStopTimeout returns the stop timeout of the containers in sandbox.
+func (c *CriManager) sandboxStopTimeout(sandboxMeta *metatypes.SandboxMeta) int64 {
+	if sandboxMeta != nil && sandboxMeta.StopTimeout > 0 {
+		return sandboxMeta.StopTimeout
+	}
+	return c.defaultStopTimeout
+}
+
 // makeSandboxPouchConfig returns apitypes.ContainerCreateConfig based on runtime.PodSandboxConfig.
 func makeSandboxPouchConfig(config *runtime.PodSandboxConfig, sandboxMeta *metatypes.SandboxMeta, image string) (*apitypes.ContainerCreateConfig, error) {
 	// Merge annotations and labels because pouch supports only labels.
 	labels := makeLabels(config.GetLabels(), config.GetAnnotations())
 	// Apply a label to distinguish sandboxes from regular containers.
 	labels[containerTypeLabelKey] = containerTypeLabelSandbox
+	// Write the metadata in the labels.
+	labels[metadataNameLabelKey] = config.GetMetadata().GetName()
+	labels[metadataAttemptLabelKey] = strconv.FormatUint(uint64(config.GetMetadata().GetAttempt()), 10)
 
 	specAnnotation := make(map[string]string)
 	specAnnotation[anno.CRIOContainerType] = anno.ContainerTypeSandbox
@@ -345,6 +449,7 @@ func makeSandboxPouchConfig(config *runtime.PodSandboxConfig, sandboxMeta *metat
 
 	if sandboxMeta.NetNS == "" {
 		hc.NetworkMode = namespaceModeHost
+		hc.UTSMode = namespaceModeHost
 	} else {
 		hc.NetworkMode = fmt.Sprintf("netns:%s", sandboxMeta.NetNS)
 	}
@@ -371,6 +476,7 @@ func makeSandboxPouchConfig(config *runtime.PodSandboxConfig, sandboxMeta *metat
 	}
 	// Apply resource options.
 	hc.CgroupParent = config.GetLinux().GetCgroupParent()
+	hc.OomScoreAdj = sandboxOOMScoreAdj
 
 	return createConfig, nil
 }
@@ -386,7 +492,7 @@ func toCriSandboxState(status apitypes.Status) runtime.PodSandboxState {
 
 func toCriSandbox(c *mgr.Container) (*runtime.PodSandbox, error) {
 	state := toCriSandboxState(c.State.Status)
-	metadata, err := parseSandboxName(c.Name)
+	metadata, err := sandboxMetadata(c)
 	if err != nil {
 		return nil, err
 	}
@@ -505,39 +611,328 @@ func setupSandboxFiles(sandboxRootDir string, config *runtime.PodSandboxConfig)
 		}
 	}
 
+	// Maintain a hostname for the sandbox, which is bind-mounted into all
+	// the containers sharing the uts namespace of sandbox.
+	hostname, err := sandboxHostname(config)
+	if err != nil {
+		return err
+	}
+	if hostname != "" {
+		hostnameFile := path.Join(sandboxRootDir, "hostname")
+		if err := ioutil.WriteFile(hostnameFile, []byte(hostname+"\n"), 0644); err != nil {
+			return fmt.Errorf("failed to write hostname to %q: %v", hostnameFile, err)
+		}
+	}
+
 	return nil
 }
 
-// setupPodNetwork sets up the network of PodSandbox
-// and do nothing when networkNamespaceMode equals runtime.NamespaceMode_NODE.
-func (c *CriManager) setupPodNetwork(id, netnsPath string, config *runtime.PodSandboxConfig) error {
-	return c.CniMgr.SetUpPodNetwork(&ocicni.PodNetwork{
+// sandboxHostname returns the hostname of sandbox, which is the one of node if
+// the sandbox uses the network of node without hostname specified.
+func sandboxHostname(config *runtime.PodSandboxConfig) (string, error) {
+	if hostname := config.GetHostname(); hostname != "" {
+		return hostname, nil
+	}
+	if config.GetLinux().GetSecurityContext().GetNamespaceOptions().GetNetwork() != runtime.NamespaceMode_NODE {
+		return "", nil
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		return "", fmt.Errorf("failed to get hostname of node: %v", err)
+	}
+	return hostname, nil
+}
+
+// setupPodNetwork sets up the network of PodSandbox and returns the results of CNI ADD,
+// do nothing when networkNamespaceMode equals runtime.NamespaceMode_NODE.
+func (c *CriManager) setupPodNetwork(id, netnsPath string, config *runtime.PodSandboxConfig) ([]*cni.NetworkResult, error) {
+	runtimeConfig := cni.RuntimeConfig{
+		PortMappings: toCNIPortMappings(config.GetPortMappings()),
+	}
+	if err := applyStaticNetworkAnnotations(config.GetAnnotations(), &runtimeConfig); err != nil {
+		return nil, err
+	}
+	if err := c.cniArgs.apply(config.GetAnnotations(), &runtimeConfig); err != nil {
+		return nil, err
+	}
+
+	results, err := c.CniMgr.SetUpPodNetwork(&cni.PodNetwork{
 		Name:      config.GetMetadata().GetName(),
 		Namespace: config.GetMetadata().GetNamespace(),
 		ID:        id,
 		NetNS:     netnsPath,
-		RuntimeConfig: map[string]ocicni.RuntimeConfig{
-			c.CniMgr.GetDefaultNetworkName(): {
-				PortMappings: toCNIPortMappings(config.GetPortMappings()),
-			},
+		RuntimeConfig: map[string]cni.RuntimeConfig{
+			c.CniMgr.GetDefaultNetworkName(): runtimeConfig,
 		},
 	})
+	if err != nil && (runtimeConfig.IP != "" || runtimeConfig.MAC != "") {
+		// make it clear that the IPAM may reject the requested address.
+		return nil, fmt.Errorf("failed to assign static address (ip %q, mac %q) to sandbox %q: %v", runtimeConfig.IP, runtimeConfig.MAC, id, err)
+	}
+	return results, err
+}
+
+// applyStaticNetworkAnnotations applies the static IP and MAC requested by annotations
+// to the runtime config of CNI network.
+func applyStaticNetworkAnnotations(annotations map[string]string, runtimeConfig *cni.RuntimeConfig) error {
+	if ip, ok := annotations[anno.StaticIPAnnotation]; ok {
+		if net.ParseIP(ip) == nil {
+			return fmt.Errorf("invalid static ip %q in annotation %s", ip, anno.StaticIPAnnotation)
+		}
+		runtimeConfig.IP = ip
+	}
+
+	if mac, ok := annotations[anno.StaticMACAnnotation]; ok {
+		if _, err := net.ParseMAC(mac); err != nil {
+			return fmt.Errorf("invalid static mac %q in annotation %s: %v", mac, anno.StaticMACAnnotation, err)
+		}
+		runtimeConfig.MAC = mac
+	}
+
+	return nil
 }
 
-// teardownNetwork teardown the network of PodSandbox.
+// teardownNetwork teardown the network of PodSandbox, the prevResults are passed to CNI DEL.
 // and do nothing when networkNamespaceMode equals runtime.NamespaceMode_NODE.
-func (c *CriManager) teardownNetwork(id, netnsPath string, config *runtime.PodSandboxConfig) error {
-	return c.CniMgr.TearDownPodNetwork(&ocicni.PodNetwork{
+func (c *CriManager) teardownNetwork(id, netnsPath string, config *runtime.PodSandboxConfig, prevResults []*cni.NetworkResult) error {
+	runtimeConfig := cni.RuntimeConfig{
+		PortMappings: toCNIPortMappings(config.GetPortMappings()),
+	}
+	// the args are passed to CNI DEL as well, e.g. to release the address from
+	// the subnet selected, the invalid ones rejected by CNI ADD are dropped.
+	c.cniArgs.apply(config.GetAnnotations(), &runtimeConfig)
+
+	return c.CniMgr.TearDownPodNetwork(&cni.PodNetwork{
 		Name:      config.GetMetadata().GetName(),
 		Namespace: config.GetMetadata().GetNamespace(),
 		ID:        id,
 		NetNS:     netnsPath,
-		RuntimeConfig: map[string]ocicni.RuntimeConfig{
-			c.CniMgr.GetDefaultNetworkName(): {
-				PortMappings: toCNIPortMappings(config.GetPortMappings()),
-			},
+		RuntimeConfig: map[string]cni.RuntimeConfig{
+			c.CniMgr.GetDefaultNetworkName(): runtimeConfig,
 		},
-	})
+	}, prevResults)
+}
+
+// parseNetworkDevices parses the host network devices requested by annotation.
+func parseNetworkDevices(annotations map[string]string) ([]*cni.NetworkDevice, error) {
+	value, ok := annotations[anno.NetworkDevicesAnnotation]
+	if !ok || strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+
+	var devices []*cni.NetworkDevice
+	for _, item := range strings.Split(value, ",") {
+		parts := strings.Split(strings.TrimSpace(item), ":")
+		if len(parts) > 2 || parts[0] == "" || (len(parts) == 2 && parts[1] == "") {
+			return nil, fmt.Errorf("invalid network device %q in annotation %s, expected hostIfName[:containerIfName]", item, anno.NetworkDevicesAnnotation)
+		}
+
+		device := &cni.NetworkDevice{HostIfName: parts[0]}
+		if len(parts) == 2 {
+			device.ContainerIfName = parts[1]
+		}
+		devices = append(devices, device)
+	}
+	return devices, nil
+}
+
+// networkDeviceAllowed returns true if the host network device matches any of the
+// allowed patterns, so that the pods could never take the devices of host, e.g. the
+// uplink, which are not dedicated to them.
+func networkDeviceAllowed(patterns []string, name string) bool {
+	for _, p := range patterns {
+		if matched, _ := filepath.Match(p, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// attachNetworkDevices moves the host network devices requested by annotation
+// into the network namespace of sandbox, and records them in the sandbox meta.
+func (c *CriManager) attachNetworkDevices(sandboxMeta *metatypes.SandboxMeta, config *runtime.PodSandboxConfig) (retErr error) {
+	devices, err := parseNetworkDevices(config.GetAnnotations())
+	if err != nil || len(devices) == 0 {
+		return err
+	}
+	for _, device := range devices {
+		if !networkDeviceAllowed(c.allowedNetworkDevices, device.HostIfName) {
+			return fmt.Errorf("network device %s is not allowed to be moved into sandbox %q, allow it by --allowed-network-devices", device.HostIfName, sandboxMeta.ID)
+		}
+	}
+
+	defer func() {
+		if retErr != nil {
+			if err := c.detachNetworkDevices(sandboxMeta); err != nil {
+				log.With(nil).Errorf("failed to detach network devices from sandbox %q: %v", sandboxMeta.ID, err)
+			}
+		}
+	}()
+
+	for _, device := range devices {
+		if err := c.CniMgr.AttachDevice(sandboxMeta.NetNS, device); err != nil {
+			return fmt.Errorf("failed to attach network device %s to sandbox %q: %v", device.HostIfName, sandboxMeta.ID, err)
+		}
+		sandboxMeta.NetworkDevices = append(sandboxMeta.NetworkDevices, device)
+	}
+	return nil
+}
+
+// detachNetworkDevices restores the network devices of sandbox to host. The devices
+// failing to be detached are kept in the sandbox meta to be retried.
+func (c *CriManager) detachNetworkDevices(sandboxMeta *metatypes.SandboxMeta) error {
+	var failed []*cni.NetworkDevice
+	var errs []string
+	for _, device := range sandboxMeta.NetworkDevices {
+		if err := c.CniMgr.DetachDevice(sandboxMeta.NetNS, device); err != nil {
+			failed = append(failed, device)
+			errs = append(errs, fmt.Sprintf("%s: %v", device.HostIfName, err))
+		}
+	}
+	sandboxMeta.NetworkDevices = failed
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to detach network devices from sandbox %q: %s", sandboxMeta.ID, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// parsePolicyRules parses the network policy rules in the format of "allow|deny:cidr[:protocol[:port]]".
+func parsePolicyRules(key, value string) ([]*cni.PolicyRule, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+
+	var rules []*cni.PolicyRule
+	for _, item := range strings.Split(value, ",") {
+		parts := strings.Split(strings.TrimSpace(item), ":")
+		if len(parts) < 2 || len(parts) > 4 {
+			return nil, fmt.Errorf("invalid rule %q in annotation %s, expected allow|deny:cidr[:protocol[:port]]", item, key)
+		}
+
+		rule := &cni.PolicyRule{}
+		switch parts[0] {
+		case "allow":
+			rule.Allow = true
+		case "deny":
+		default:
+			return nil, fmt.Errorf("invalid action %q of rule %q in annotation %s", parts[0], item, key)
+		}
+
+		cidr := parts[1]
+		if !strings.Contains(cidr, "/") {
+			cidr += "/32"
+		}
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil || ipNet.IP.To4() == nil {
+			return nil, fmt.Errorf("invalid IPv4 cidr %q of rule %q in annotation %s", parts[1], item, key)
+		}
+		rule.CIDR = ipNet
+
+		if len(parts) > 2 {
+			rule.Protocol = strings.ToLower(parts[2])
+			if rule.Protocol != "tcp" && rule.Protocol != "udp" && rule.Protocol != "sctp" {
+				return nil, fmt.Errorf("invalid protocol %q of rule %q in annotation %s", parts[2], item, key)
+			}
+		}
+		if len(parts) > 3 {
+			port, err := strconv.Atoi(parts[3])
+			if err != nil || port <= 0 || port > 65535 {
+				return nil, fmt.Errorf("invalid port %q of rule %q in annotation %s", parts[3], item, key)
+			}
+			rule.Port = port
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// parseNetworkPolicy parses the network policy of sandbox requested by annotations.
+func parseNetworkPolicy(annotations map[string]string) (*cni.NetworkPolicy, error) {
+	ingress, err := parsePolicyRules(anno.NetworkPolicyIngressAnnotation, annotations[anno.NetworkPolicyIngressAnnotation])
+	if err != nil {
+		return nil, err
+	}
+	egress, err := parsePolicyRules(anno.NetworkPolicyEgressAnnotation, annotations[anno.NetworkPolicyEgressAnnotation])
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ingress) == 0 && len(egress) == 0 {
+		return nil, nil
+	}
+	return &cni.NetworkPolicy{Ingress: ingress, Egress: egress}, nil
+}
+
+// applyNetworkPolicy enforces the network policy requested by annotations in the
+// network namespace of sandbox.
+func (c *CriManager) applyNetworkPolicy(sandboxMeta *metatypes.SandboxMeta, config *runtime.PodSandboxConfig) error {
+	policy, err := parseNetworkPolicy(config.GetAnnotations())
+	if err != nil || policy == nil {
+		return err
+	}
+
+	if err := c.CniMgr.ApplyNetworkPolicy(sandboxMeta.NetNS, policy); err != nil {
+		return fmt.Errorf("failed to apply network policy of sandbox %q: %v", sandboxMeta.ID, err)
+	}
+	return nil
+}
+
+// containerSandboxMeta returns the meta of sandbox the container belongs to, nil
+// if either the container or the sandbox is not found.
+func (c *CriManager) containerSandboxMeta(ctx context.Context, containerID string) *metatypes.SandboxMeta {
+	container, err := c.ContainerMgr.Get(ctx, containerID)
+	if err != nil || container.Config == nil {
+		return nil
+	}
+
+	res, err := c.SandboxStore.Get(container.Config.Labels[sandboxIDLabelKey])
+	if err != nil {
+		return nil
+	}
+	sandboxMeta, _ := res.(*metatypes.SandboxMeta)
+	return sandboxMeta
+}
+
+// applyNetPriority raises the net priority of sandbox to the given priority of container,
+// since the containers of a pod share the network of sandbox. The traffic is classified
+// outside the transaction of store, and the returned rollback restores the previous
+// priority if the container fails to be created.
+func (c *CriManager) applyNetPriority(sandboxMeta *metatypes.SandboxMeta, priority int64) (func(), error) {
+	podSandboxID, prev := sandboxMeta.ID, sandboxMeta.NetPriority
+	if priority <= prev {
+		return func() {}, nil
+	}
+
+	if err := c.CniMgr.SetNetPriority(sandboxMeta.NetworkResults, priority); err != nil {
+		return nil, fmt.Errorf("failed to set net priority %d of sandbox %q: %v", priority, podSandboxID, err)
+	}
+	setPriority := func(from, to int64) error {
+		return c.SandboxStore.Update(podSandboxID, func(obj meta.Object) error {
+			if m := obj.(*metatypes.SandboxMeta); m.NetPriority == from {
+				m.NetPriority = to
+			}
+			return nil
+		})
+	}
+	rollback := func() {
+		var err error
+		if prev > 0 {
+			err = c.CniMgr.SetNetPriority(sandboxMeta.NetworkResults, prev)
+		} else {
+			err = c.CniMgr.ClearNetPriority(sandboxMeta.NetworkResults)
+		}
+		if err == nil {
+			err = setPriority(priority, prev)
+		}
+		if err != nil {
+			log.With(nil).Warnf("failed to restore net priority %d of sandbox %q: %v", prev, podSandboxID, err)
+		}
+	}
+	if err := setPriority(prev, priority); err != nil {
+		rollback()
+		return nil, err
+	}
+	return rollback, nil
 }
 
 func sandboxNetworkMode(config *runtime.PodSandboxConfig) runtime.NamespaceMode {
@@ -579,6 +974,55 @@ func parseContainerName(name string) (*runtime.ContainerMetadata, error) {
 	}, nil
 }
 
+// metadataFromLabels returns the name and attempt kept in the labels, ok is false if
+// the labels are missing, e.g. the container is created by the older pouchd.
+func metadataFromLabels(labels map[string]string) (name string, attempt uint32, ok bool, err error) {
+	name, nameOk := labels[metadataNameLabelKey]
+	attemptStr, attemptOk := labels[metadataAttemptLabelKey]
+	if !nameOk || !attemptOk {
+		return "", 0, false, nil
+	}
+
+	attempt, err = parseUint32(attemptStr)
+	if err != nil {
+		return "", 0, false, fmt.Errorf("failed to parse the attempt times %q in labels: %v", attemptStr, err)
+	}
+	return name, attempt, true, nil
+}
+
+// containerMetadata returns the metadata of container from its labels, or from its name
+// if the labels are missing.
+func containerMetadata(c *mgr.Container) (*runtime.ContainerMetadata, error) {
+	name, attempt, ok, err := metadataFromLabels(c.Config.Labels)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return parseContainerName(c.Name)
+	}
+	return &runtime.ContainerMetadata{Name: name, Attempt: attempt}, nil
+}
+
+// sandboxMetadata returns the metadata of sandbox from its labels, or from its name
+// if the labels are missing.
+func sandboxMetadata(c *mgr.Container) (*runtime.PodSandboxMetadata, error) {
+	// the namespace and uid of sandbox are parsed from the name anyway.
+	metadata, err := parseSandboxName(c.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	name, attempt, ok, err := metadataFromLabels(c.Config.Labels)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		metadata.Name = name
+		metadata.Attempt = attempt
+	}
+	return metadata, nil
+}
+
 // modifyContainerNamespaceOptions apply namespace options for container.
 func modifyContainerNamespaceOptions(nsOpts *runtime.NamespaceOption, podSandboxID string, hostConfig *apitypes.HostConfig) {
 	sandboxNSMode := fmt.Sprintf("container:%v", podSandboxID)
@@ -587,6 +1031,7 @@ func modifyContainerNamespaceOptions(nsOpts *runtime.NamespaceOption, podSandbox
 		hostConfig.PidMode = sandboxNSMode
 		hostConfig.IpcMode = sandboxNSMode
 		hostConfig.NetworkMode = sandboxNSMode
+		hostConfig.UTSMode = sandboxNSMode
 		return
 	}
 
@@ -606,6 +1051,11 @@ func modifyContainerNamespaceOptions(nsOpts *runtime.NamespaceOption, podSandbox
 			hostMode: nsOpts.GetNetwork() == runtime.NamespaceMode_NODE,
 			nsMode:   &hostConfig.NetworkMode,
 		},
+		{
+			// the hostname is shared with the network, as the one in sandbox.
+			hostMode: nsOpts.GetNetwork() == runtime.NamespaceMode_NODE,
+			nsMode:   &hostConfig.UTSMode,
+		},
 	} {
 		if n.hostMode {
 			*n.nsMode = namespaceModeHost
@@ -613,6 +1063,10 @@ func modifyContainerNamespaceOptions(nsOpts *runtime.NamespaceOption, podSandbox
 			if n.nsMode == &hostConfig.PidMode && nsOpts.GetPid() == runtime.NamespaceMode_CONTAINER {
 				continue
 			}
+			if n.nsMode == &hostConfig.PidMode && nsOpts.GetPid() == runtime.NamespaceMode_TARGET {
+				*n.nsMode = fmt.Sprintf("container:%v", nsOpts.GetTargetId())
+				continue
+			}
 			*n.nsMode = sandboxNSMode
 		}
 	}
@@ -689,6 +1143,57 @@ func getSeccompSecurityOpts(sc *runtime.LinuxContainerSecurityContext) ([]string
 	return []string{fmt.Sprintf("seccomp=%s", profile)}, nil
 }
 
+// normalizeCapabilities converts the capabilities into the format of pouch, which
+// is in upper case without the "CAP_" prefix, e.g. "cap_net_admin" into "NET_ADMIN".
+func normalizeCapabilities(capabilities []string) []string {
+	if len(capabilities) == 0 {
+		return nil
+	}
+
+	result := make([]string, 0, len(capabilities))
+	for _, capability := range capabilities {
+		capability = strings.ToUpper(strings.TrimSpace(capability))
+		result = append(result, strings.TrimPrefix(capability, "CAP_"))
+	}
+	return result
+}
+
+// parseDefaultCapabilities validates the default capabilities of cri containers,
+// and returns them in the format of pouch. "ALL" is expanded into all capabilities.
+func parseDefaultCapabilities(capabilities []string) ([]string, error) {
+	if len(capabilities) == 0 {
+		return nil, nil
+	}
+
+	// tweak capabilities from nothing validates the capabilities.
+	result, err := caps.TweakCapabilities(nil, normalizeCapabilities(capabilities), nil)
+	if err != nil {
+		return nil, err
+	}
+	return normalizeCapabilities(result), nil
+}
+
+// applyDefaultCapabilities replaces the default capabilities of pouch with the
+// ones of cri containers, by dropping all capabilities and adding the tweaked ones.
+func applyDefaultCapabilities(defaults []string, hostConfig *apitypes.HostConfig) error {
+	if len(defaults) == 0 || hostConfig.Privileged {
+		return nil
+	}
+
+	basics := make([]string, 0, len(defaults))
+	for _, capability := range defaults {
+		basics = append(basics, "CAP_"+capability)
+	}
+	capabilities, err := caps.TweakCapabilities(basics, hostConfig.CapAdd, hostConfig.CapDrop)
+	if err != nil {
+		return err
+	}
+
+	hostConfig.CapAdd = normalizeCapabilities(capabilities)
+	hostConfig.CapDrop = []string{"ALL"}
+	return nil
+}
+
 // modifyHostConfig applies security context config to pouch's HostConfig.
 func modifyHostConfig(sc *runtime.LinuxContainerSecurityContext, hostConfig *apitypes.HostConfig) error {
 	if sc == nil {
@@ -706,8 +1211,8 @@ func modifyHostConfig(sc *runtime.LinuxContainerSecurityContext, hostConfig *api
 	hostConfig.Privileged = sc.Privileged
 	hostConfig.ReadonlyRootfs = sc.ReadonlyRootfs
 	if sc.GetCapabilities() != nil {
-		hostConfig.CapAdd = sc.GetCapabilities().GetAddCapabilities()
-		hostConfig.CapDrop = sc.GetCapabilities().GetDropCapabilities()
+		hostConfig.CapAdd = normalizeCapabilities(sc.GetCapabilities().GetAddCapabilities())
+		hostConfig.CapDrop = normalizeCapabilities(sc.GetCapabilities().GetDropCapabilities())
 	}
 
 	// Apply seccomp options.
@@ -743,6 +1248,21 @@ func modifyHostConfig(sc *runtime.LinuxContainerSecurityContext, hostConfig *api
 	return nil
 }
 
+// applyDefaultMaskedAndReadonlyPaths applies the default masked paths and readonly
+// paths to the container if the security context specifies none of them.
+func applyDefaultMaskedAndReadonlyPaths(hostConfig *apitypes.HostConfig, maskedPaths, readonlyPaths []string) {
+	if hostConfig.Privileged {
+		return
+	}
+
+	if len(hostConfig.MaskedPaths) == 0 && len(maskedPaths) > 0 {
+		hostConfig.MaskedPaths = append([]string{}, maskedPaths...)
+	}
+	if len(hostConfig.ReadonlyPaths) == 0 && len(readonlyPaths) > 0 {
+		hostConfig.ReadonlyPaths = append([]string{}, readonlyPaths...)
+	}
+}
+
 // modifyContainerConfig applies container security context config to pouch's Config.
 func modifyContainerConfig(sc *runtime.LinuxContainerSecurityContext, config *apitypes.ContainerConfig) error {
 	if sc == nil {
@@ -777,6 +1297,74 @@ func modifyContainerConfig(sc *runtime.LinuxContainerSecurityContext, config *ap
 	return nil
 }
 
+// inheritSandboxSecurityContext returns the container config whose security context inherits
+// the user and group from the sandbox if they are not specified, and the supplemental groups
+// of sandbox. The config of request is not modified.
+func inheritSandboxSecurityContext(lc *runtime.LinuxContainerConfig, psc *runtime.LinuxSandboxSecurityContext) *runtime.LinuxContainerConfig {
+	if psc == nil {
+		return lc
+	}
+
+	newLc := &runtime.LinuxContainerConfig{}
+	if lc != nil {
+		*newLc = *lc
+	}
+	sc := &runtime.LinuxContainerSecurityContext{}
+	if newLc.SecurityContext != nil {
+		*sc = *newLc.SecurityContext
+	}
+
+	if sc.RunAsUser == nil && sc.RunAsUsername == "" {
+		sc.RunAsUser = psc.RunAsUser
+		if sc.RunAsGroup == nil {
+			sc.RunAsGroup = psc.RunAsGroup
+		}
+	}
+
+	groups := append([]int64{}, sc.SupplementalGroups...)
+	for _, g := range psc.SupplementalGroups {
+		found := false
+		for _, existing := range groups {
+			if existing == g {
+				found = true
+				break
+			}
+		}
+		if !found {
+			groups = append(groups, g)
+		}
+	}
+	if len(groups) > 0 {
+		sc.SupplementalGroups = groups
+	}
+
+	newLc.SecurityContext = sc
+	return newLc
+}
+
+// validateRunAsUsername checks whether the username exists in the /etc/passwd of container.
+// It's skipped if the passwd file could not be found in the rootfs of container.
+func (c *CriManager) validateRunAsUsername(ctx context.Context, containerID, username string) error {
+	container, err := c.ContainerMgr.Get(ctx, containerID)
+	if err != nil {
+		return err
+	}
+
+	passwdPath := container.GetSpecificBasePath(user.PasswdFile)
+	if passwdPath == "" {
+		return nil
+	}
+
+	exists, err := user.HasUser(passwdPath, username)
+	if err != nil {
+		return fmt.Errorf("failed to validate username %q: %v", username, err)
+	}
+	if !exists {
+		return fmt.Errorf("username %q is not found in %s of image %s", username, user.PasswdFile, container.Config.Image)
+	}
+	return nil
+}
+
 // applyContainerSecurityContext updates pouch container options according to security context.
 func applyContainerSecurityContext(lc *runtime.LinuxContainerConfig, podSandboxID string, config *apitypes.ContainerConfig, hc *apitypes.HostConfig) error {
 	sc := lc.GetSecurityContext()
@@ -810,18 +1398,47 @@ func (c *CriManager) updateCreateConfig(createConfig *apitypes.ContainerCreateCo
 		createConfig.HostConfig.Resources.Memory = resources.GetMemoryLimitInBytes()
 		createConfig.HostConfig.Resources.CpusetCpus = resources.GetCpusetCpus()
 		createConfig.HostConfig.Resources.CpusetMems = resources.GetCpusetMems()
+		createConfig.HostConfig.OomScoreAdj = resources.GetOomScoreAdj()
 	}
+	c.applyRootlessConfig(createConfig)
 
-	// Apply security context.
-	if err := applyContainerSecurityContext(config.GetLinux(), sandboxMeta.ID, &createConfig.ContainerConfig, createConfig.HostConfig); err != nil {
+	// Apply ulimits, the ones in resources override the default ones of daemon.
+	if ulimits := mergeUlimits(c.defaultUlimits, parseUlimitFromCRI(resources.GetUlimits())); len(ulimits) > 0 {
+		createConfig.HostConfig.Ulimits = ulimits
+	}
+
+	// Apply security context, the user and groups are inherited from the sandbox.
+	lc := inheritSandboxSecurityContext(config.GetLinux(), sandboxConfig.GetLinux().GetSecurityContext())
+	if err := applyContainerSecurityContext(lc, sandboxMeta.ID, &createConfig.ContainerConfig, createConfig.HostConfig); err != nil {
 		return fmt.Errorf("failed to apply container security context for container %q: %v", config.GetMetadata().GetName(), err)
 	}
+	applySelinuxLevel(sandboxMeta, createConfig.HostConfig)
+
+	// Apply the default masked paths and readonly paths of cri containers.
+	applyDefaultMaskedAndReadonlyPaths(createConfig.HostConfig, c.defaultMaskedPaths, c.defaultReadonlyPaths)
+
+	// Apply the default capabilities of cri containers.
+	if err := applyDefaultCapabilities(c.defaultCapabilities, createConfig.HostConfig); err != nil {
+		return fmt.Errorf("failed to apply capabilities for container %q: %v", config.GetMetadata().GetName(), err)
+	}
 
 	if len(config.GetAnnotations()) > 0 {
 		// Apply container config by annotation
 		if err := applyContainerConfigByAnnotation(config.GetAnnotations(), &createConfig.ContainerConfig, createConfig.HostConfig, nil); err != nil {
 			return fmt.Errorf("failed to apply container annotation for container %q: %v", config.Metadata.Name, err)
 		}
+
+		// the memory QoS and cpu burst are set to the container cgroup before the container is started.
+		files, err := containerCgroupFiles(config.GetAnnotations())
+		if err != nil {
+			return fmt.Errorf("failed to apply container annotation for container %q: %v", config.Metadata.Name, err)
+		}
+		if len(files) > 0 && createConfig.SpecAnnotation == nil {
+			createConfig.SpecAnnotation = make(map[string]string)
+		}
+		for k, v := range files {
+			createConfig.SpecAnnotation[k] = v
+		}
 	}
 
 	// Apply cgroupsParent derived from the sandbox config.
@@ -864,7 +1481,7 @@ func toCriContainerState(state *apitypes.ContainerState) (criState runtime.Conta
 
 func toCriContainer(c *mgr.Container) (*runtime.Container, error) {
 	state, _ := toCriContainerState(c.State)
-	metadata, err := parseContainerName(c.Name)
+	metadata, err := containerMetadata(c)
 	if err != nil {
 		return nil, err
 	}
@@ -967,7 +1584,12 @@ func (c *CriManager) ensureSandboxImageExists(ctx context.Context, imageRef stri
 		return nil
 	}
 	if errtypes.IsNotfound(err) {
-		err = c.ImageMgr.PullImage(ctx, imageRef, nil, bytes.NewBuffer([]byte{}))
+		// the image of built-in pause is imported instead, e.g. on a fresh
+		// node or after it is removed by the image gc of kubelet.
+		if c.builtinPause != nil && imageRef == c.builtinPause.image {
+			return c.ensureBuiltinPauseImage(ctx)
+		}
+		err = c.pullImage(ctx, "", imageRef, nil, bytes.NewBuffer([]byte{}))
 		if err != nil {
 			return fmt.Errorf("failed to pull sandbox image %q: %v", imageRef, err)
 		}
@@ -1008,15 +1630,16 @@ func parseUserFromImageUser(id string) string {
 	return id
 }
 
-func (c *CriManager) getContainerMetrics(ctx context.Context, meta *mgr.Container) (*runtime.ContainerStats, error) {
+func (c *CriManager) getContainerMetrics(ctx context.Context, meta *mgr.Container, containerMetrics *mgr.ContainerMetrics) (*runtime.ContainerStats, error) {
 	var usedBytes, inodesUsed uint64
 
-	metadata, err := parseContainerName(meta.Name)
+	metadata, err := containerMetadata(meta)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get metadata of container %q: %v", meta.ID, err)
 	}
 
-	sn, err := c.SnapshotStore.Get(meta.SnapshotID)
+	snapshotter := containerSnapshotter(ctx, meta)
+	sn, err := c.SnapshotStore.Get(snapshotter, meta.SnapshotID)
 	if err == nil {
 		usedBytes = sn.Size
 		inodesUsed = sn.Inodes
@@ -1026,7 +1649,7 @@ func (c *CriManager) getContainerMetrics(ctx context.Context, meta *mgr.Containe
 	cs.WritableLayer = &runtime.FilesystemUsage{
 		Timestamp: sn.Timestamp,
 		FsId: &runtime.FilesystemIdentifier{
-			Mountpoint: c.imageFSPath,
+			Mountpoint: imageFSPath(c.imageFSRootDir, snapshotter),
 		},
 		UsedBytes:  &runtime.UInt64Value{Value: usedBytes},
 		InodesUsed: &runtime.UInt64Value{Value: inodesUsed},
@@ -1040,12 +1663,24 @@ func (c *CriManager) getContainerMetrics(ctx context.Context, meta *mgr.Containe
 		Annotations: annotations,
 	}
 
-	metricsMeta, metrics, err := c.ContainerMgr.Stats(ctx, meta.ID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get stats of container %q: %v", meta.ID, err)
-	}
-
-	if metricsMeta != nil {
+	// the metrics of not-running container are absent.
+	if containerMetrics != nil && containerMetrics.MetricsV2 != nil {
+		metricsMeta, metrics := containerMetrics.Meta, containerMetrics.MetricsV2
+		if metrics.CPU != nil {
+			cs.Cpu = &runtime.CpuUsage{
+				Timestamp:            metricsMeta.Timestamp.UnixNano(),
+				UsageCoreNanoSeconds: &runtime.UInt64Value{Value: metrics.CPU.UsageUsec * 1000},
+			}
+		}
+		if metrics.Memory != nil {
+			cs.Memory = &runtime.MemoryUsage{
+				Timestamp:       metricsMeta.Timestamp.UnixNano(),
+				WorkingSetBytes: &runtime.UInt64Value{Value: metrics.Memory.WorkingSet()},
+				SwapUsageBytes:  &runtime.UInt64Value{Value: metrics.Memory.SwapUsage},
+			}
+		}
+	} else if containerMetrics != nil {
+		metricsMeta, metrics := containerMetrics.Meta, containerMetrics.Metrics
 		if metrics.CPU != nil && metrics.CPU.Usage != nil {
 			cs.Cpu = &runtime.CpuUsage{
 				Timestamp:            metricsMeta.Timestamp.UnixNano(),
@@ -1057,6 +1692,10 @@ func (c *CriManager) getContainerMetrics(ctx context.Context, meta *mgr.Containe
 				Timestamp:       metricsMeta.Timestamp.UnixNano(),
 				WorkingSetBytes: &runtime.UInt64Value{Value: metrics.Memory.Usage.Usage},
 			}
+			// the usage of memsw is the usage of memory + swap in cgroup v1.
+			if metrics.Memory.Swap != nil && metrics.Memory.Swap.Usage > metrics.Memory.Usage.Usage {
+				cs.Memory.SwapUsageBytes = &runtime.UInt64Value{Value: metrics.Memory.Swap.Usage - metrics.Memory.Usage.Usage}
+			}
 		}
 	}
 
@@ -1082,6 +1721,7 @@ func parseResourcesFromCRI(runtimeResources *runtime.LinuxContainerResources) ap
 		CPUQuota:             runtimeResources.GetCpuQuota(),
 		CPUShares:            runtimeResources.GetCpuShares(),
 		Memory:               runtimeResources.GetMemoryLimitInBytes(),
+		MemorySwap:           runtimeResources.GetMemorySwapLimitInBytes(),
 		CpusetCpus:           runtimeResources.GetCpusetCpus(),
 		CpusetMems:           runtimeResources.GetCpusetMems(),
 		BlkioWeight:          uint16(runtimeResources.GetBlkioWeight()),
@@ -1094,6 +1734,7 @@ func parseResourcesFromCRI(runtimeResources *runtime.LinuxContainerResources) ap
 		MemoryReservation:    runtimeResources.GetMemoryReservation(),
 		MemorySwappiness:     memorySwappiness,
 		Ulimits:              parseUlimitFromCRI(runtimeResources.GetUlimits()),
+		PidsLimit:            runtimeResources.GetPidsLimit(),
 	}
 }
 
@@ -1105,23 +1746,25 @@ func parseResourcesFromPouch(apitypesResources apitypes.Resources, diskQuota map
 	}
 
 	return &runtime.LinuxContainerResources{
-		CpuPeriod:             apitypesResources.CPUPeriod,
-		CpuQuota:              apitypesResources.CPUQuota,
-		CpuShares:             apitypesResources.CPUShares,
-		MemoryLimitInBytes:    apitypesResources.Memory,
-		CpusetCpus:            apitypesResources.CpusetCpus,
-		CpusetMems:            apitypesResources.CpusetMems,
-		BlkioWeight:           uint32(apitypesResources.BlkioWeight),
-		BlkioWeightDevice:     parseWeightDeviceFromPouch(apitypesResources.BlkioWeightDevice),
-		BlkioDeviceReadBps:    parseThrottleDeviceFromPouch(apitypesResources.BlkioDeviceReadBps),
-		BlkioDeviceWriteBps:   parseThrottleDeviceFromPouch(apitypesResources.BlkioDeviceWriteBps),
-		BlkioDeviceRead_IOps:  parseThrottleDeviceFromPouch(apitypesResources.BlkioDeviceReadIOps),
-		BlkioDeviceWrite_IOps: parseThrottleDeviceFromPouch(apitypesResources.BlkioDeviceWriteIOps),
-		KernelMemory:          apitypesResources.KernelMemory,
-		MemoryReservation:     apitypesResources.MemoryReservation,
-		MemorySwappiness:      memorySwappiness,
-		Ulimits:               parseUlimitFromPouch(apitypesResources.Ulimits),
-		DiskQuota:             diskQuota,
+		CpuPeriod:              apitypesResources.CPUPeriod,
+		CpuQuota:               apitypesResources.CPUQuota,
+		CpuShares:              apitypesResources.CPUShares,
+		MemoryLimitInBytes:     apitypesResources.Memory,
+		MemorySwapLimitInBytes: apitypesResources.MemorySwap,
+		CpusetCpus:             apitypesResources.CpusetCpus,
+		CpusetMems:             apitypesResources.CpusetMems,
+		BlkioWeight:            uint32(apitypesResources.BlkioWeight),
+		BlkioWeightDevice:      parseWeightDeviceFromPouch(apitypesResources.BlkioWeightDevice),
+		BlkioDeviceReadBps:     parseThrottleDeviceFromPouch(apitypesResources.BlkioDeviceReadBps),
+		BlkioDeviceWriteBps:    parseThrottleDeviceFromPouch(apitypesResources.BlkioDeviceWriteBps),
+		BlkioDeviceRead_IOps:   parseThrottleDeviceFromPouch(apitypesResources.BlkioDeviceReadIOps),
+		BlkioDeviceWrite_IOps:  parseThrottleDeviceFromPouch(apitypesResources.BlkioDeviceWriteIOps),
+		KernelMemory:           apitypesResources.KernelMemory,
+		MemoryReservation:      apitypesResources.MemoryReservation,
+		MemorySwappiness:       memorySwappiness,
+		Ulimits:                parseUlimitFromPouch(apitypesResources.Ulimits),
+		PidsLimit:              apitypesResources.PidsLimit,
+		DiskQuota:              diskQuota,
 	}
 }
 
@@ -1193,6 +1836,40 @@ func parseUlimitFromPouch(apitypesUlimits []*apitypes.Ulimit) (ulimits []*runtim
 	return
 }
 
+// parseUlimits parses the ulimits in the form of "name=soft[:hard]".
+func parseUlimits(entries []string) ([]*apitypes.Ulimit, error) {
+	var ulimits []*apitypes.Ulimit
+	for _, e := range entries {
+		ul, err := units.ParseUlimit(strings.TrimSpace(e))
+		if err != nil {
+			return nil, err
+		}
+		ulimits = append(ulimits, &apitypes.Ulimit{
+			Name: ul.Name,
+			Soft: ul.Soft,
+			Hard: ul.Hard,
+		})
+	}
+	return ulimits, nil
+}
+
+// mergeUlimits returns the ulimits in base overridden by the ones of the same name in overrides.
+func mergeUlimits(base, overrides []*apitypes.Ulimit) []*apitypes.Ulimit {
+	ulimits := make([]*apitypes.Ulimit, 0, len(base)+len(overrides))
+	index := make(map[string]int)
+	for _, uls := range [][]*apitypes.Ulimit{base, overrides} {
+		for _, ul := range uls {
+			if i, exists := index[ul.Name]; exists {
+				ulimits[i] = ul
+				continue
+			}
+			index[ul.Name] = len(ulimits)
+			ulimits = append(ulimits, ul)
+		}
+	}
+	return ulimits
+}
+
 // parseVolumesFromPouch parse Volumes from map[string]interface{} to map[string]*runtime.Volume
 func parseVolumesFromPouch(containerVolumes map[string]interface{}) map[string]*runtime.Volume {
 	volumes := make(map[string]*runtime.Volume)
@@ -1271,5 +1948,22 @@ func applyContainerConfigByAnnotation(annotations map[string]string, config *api
 		}
 	}
 
+	// ulimits could not be updated on a running container.
+	if ulimits, ok := annotations[anno.UlimitsExtendAnnotation]; ok && hc != nil {
+		uls, err := parseUlimits(strings.Split(ulimits, ","))
+		if err != nil {
+			return fmt.Errorf("failed to parse resources.ulimits: %v", err)
+		}
+		hc.Ulimits = mergeUlimits(hc.Ulimits, uls)
+	}
+
+	// stop signal overrides the STOPSIGNAL of image, which could not be updated.
+	if stopSignal, ok := annotations[anno.StopSignalExtendAnnotation]; ok && config != nil {
+		if _, err := signal.ParseSignal(stopSignal); err != nil {
+			return fmt.Errorf("failed to parse stop-signal: %v", err)
+		}
+		config.StopSignal = stopSignal
+	}
+
 	return nil
 }
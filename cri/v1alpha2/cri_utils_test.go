@@ -2,6 +2,9 @@ package v1alpha2
 
 import (
 	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
 	"reflect"
 	"strconv"
 	"strings"
@@ -11,6 +14,8 @@ import (
 	apitypes "github.com/alibaba/pouch/apis/types"
 	anno "github.com/alibaba/pouch/cri/annotations"
 	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+	cni "github.com/alibaba/pouch/cri/ocicni"
+	metatypes "github.com/alibaba/pouch/cri/v1alpha2/types"
 	"github.com/alibaba/pouch/daemon/mgr"
 	"github.com/alibaba/pouch/pkg/utils"
 
@@ -106,6 +111,7 @@ var (
 		BlkioDeviceReadBps: apitypesThrottleDevicesSlice,
 		MemorySwappiness:   &memorySwappiness,
 		Ulimits:            apitypesUlimitsSlice,
+		PidsLimit:          100,
 	}
 	linuxContainerResources = runtime.LinuxContainerResources{
 		CpuPeriod:          1000,
@@ -120,6 +126,7 @@ var (
 		BlkioDeviceReadBps: runtimeThrottleDevicesSlice,
 		MemorySwappiness:   &runtime.Int64Value{Value: 1000},
 		Ulimits:            runtimeUlimitsSlice,
+		PidsLimit:          100,
 	}
 )
 
@@ -365,6 +372,17 @@ func Test_parseSandboxName(t *testing.T) {
 			want:    &runtime.PodSandboxMetadata{Name: "PodSandbox", Namespace: "a", Uid: "e2f34", Attempt: uint32(3)},
 			wantErr: false,
 		},
+		{
+			name:    "sandboxNameWithDelimiter",
+			args:    args{kubePrefix + nameDelimiter + sandboxContainerName + nameDelimiter + "Pod" + nameDelimiter + "Sandbox" + nameDelimiter + "a" + nameDelimiter + "e2f34" + nameDelimiter + "1"},
+			want:    &runtime.PodSandboxMetadata{Name: "Pod_Sandbox", Namespace: "a", Uid: "e2f34", Attempt: uint32(1)},
+			wantErr: false,
+		},
+		{
+			name:    "invalidSandboxName",
+			args:    args{kubePrefix + nameDelimiter + sandboxContainerName + nameDelimiter + "a" + nameDelimiter + "e2f34" + nameDelimiter + "1"},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -623,7 +641,7 @@ func Test_modifyContainerNamespaceOptions(t *testing.T) {
 				podSandboxID: "fakeSandBoxID",
 				hostConfig:   &apitypes.HostConfig{PidMode: "host", IpcMode: "host", NetworkMode: "host"},
 			},
-			want: apitypes.HostConfig{PidMode: "host", IpcMode: "container:fakeSandBoxID", NetworkMode: "host"},
+			want: apitypes.HostConfig{PidMode: "host", IpcMode: "container:fakeSandBoxID", NetworkMode: "host", UTSMode: "host"},
 		},
 		{
 			name: "nil test",
@@ -632,7 +650,16 @@ func Test_modifyContainerNamespaceOptions(t *testing.T) {
 				podSandboxID: "fakeSandBoxID",
 				hostConfig:   &apitypes.HostConfig{PidMode: "host", IpcMode: "host", NetworkMode: "host"},
 			},
-			want: apitypes.HostConfig{PidMode: "container:fakeSandBoxID", IpcMode: "container:fakeSandBoxID", NetworkMode: "container:fakeSandBoxID"},
+			want: apitypes.HostConfig{PidMode: "container:fakeSandBoxID", IpcMode: "container:fakeSandBoxID", NetworkMode: "container:fakeSandBoxID", UTSMode: "container:fakeSandBoxID"},
+		},
+		{
+			name: "target test",
+			args: args{
+				nsOpts:       &runtime.NamespaceOption{Pid: runtime.NamespaceMode_TARGET, TargetId: "fakeTargetID"},
+				podSandboxID: "fakeSandBoxID",
+				hostConfig:   &apitypes.HostConfig{},
+			},
+			want: apitypes.HostConfig{PidMode: "container:fakeTargetID", IpcMode: "container:fakeSandBoxID", NetworkMode: "container:fakeSandBoxID", UTSMode: "container:fakeSandBoxID"},
 		},
 	}
 	for _, tt := range tests {
@@ -670,8 +697,8 @@ func Test_modifyHostConfig(t *testing.T) {
 					Privileged:         true,
 					ReadonlyRootfs:     true,
 					Capabilities: &runtime.Capability{
-						AddCapabilities:  []string{"fooAdd1", "fooAdd2"},
-						DropCapabilities: []string{"fooDrop1", "fooDrop2"},
+						AddCapabilities:  []string{"net_admin", "CAP_SYS_PTRACE"},
+						DropCapabilities: []string{"cap_mknod", "NET_RAW"},
 					},
 					SeccompProfilePath: mgr.ProfileDockerDefault,
 					ApparmorProfile:    mgr.ProfileRuntimeDefault,
@@ -683,8 +710,8 @@ func Test_modifyHostConfig(t *testing.T) {
 				GroupAdd:       groupAdd,
 				Privileged:     true,
 				ReadonlyRootfs: true,
-				CapAdd:         []string{"fooAdd1", "fooAdd2"},
-				CapDrop:        []string{"fooDrop1", "fooDrop2"},
+				CapAdd:         []string{"NET_ADMIN", "SYS_PTRACE"},
+				CapDrop:        []string{"MKNOD", "NET_RAW"},
 				SecurityOpt:    []string{"no-new-privileges"},
 			},
 			wantErr: nil,
@@ -696,8 +723,8 @@ func Test_modifyHostConfig(t *testing.T) {
 					Privileged:     true,
 					ReadonlyRootfs: true,
 					Capabilities: &runtime.Capability{
-						AddCapabilities:  []string{"fooAdd1", "fooAdd2"},
-						DropCapabilities: []string{"fooDrop1", "fooDrop2"},
+						AddCapabilities:  []string{"net_admin", "CAP_SYS_PTRACE"},
+						DropCapabilities: []string{"cap_mknod", "NET_RAW"},
 					},
 					SeccompProfilePath: mgr.ProfileDockerDefault,
 					ApparmorProfile:    mgr.ProfileRuntimeDefault,
@@ -710,8 +737,8 @@ func Test_modifyHostConfig(t *testing.T) {
 			wantHostConfig: &apitypes.HostConfig{
 				Privileged:     true,
 				ReadonlyRootfs: true,
-				CapAdd:         []string{"fooAdd1", "fooAdd2"},
-				CapDrop:        []string{"fooDrop1", "fooDrop2"},
+				CapAdd:         []string{"NET_ADMIN", "SYS_PTRACE"},
+				CapDrop:        []string{"MKNOD", "NET_RAW"},
 				SecurityOpt:    []string{"no-new-privileges"},
 			},
 			wantErr: nil,
@@ -723,8 +750,8 @@ func Test_modifyHostConfig(t *testing.T) {
 					Privileged:     false,
 					ReadonlyRootfs: true,
 					Capabilities: &runtime.Capability{
-						AddCapabilities:  []string{"fooAdd1", "fooAdd2"},
-						DropCapabilities: []string{"fooDrop1", "fooDrop2"},
+						AddCapabilities:  []string{"net_admin", "CAP_SYS_PTRACE"},
+						DropCapabilities: []string{"cap_mknod", "NET_RAW"},
 					},
 					SeccompProfilePath: mgr.ProfileDockerDefault,
 					ApparmorProfile:    mgr.ProfileRuntimeDefault,
@@ -737,8 +764,8 @@ func Test_modifyHostConfig(t *testing.T) {
 			wantHostConfig: &apitypes.HostConfig{
 				Privileged:     false,
 				ReadonlyRootfs: true,
-				CapAdd:         []string{"fooAdd1", "fooAdd2"},
-				CapDrop:        []string{"fooDrop1", "fooDrop2"},
+				CapAdd:         []string{"NET_ADMIN", "SYS_PTRACE"},
+				CapDrop:        []string{"MKNOD", "NET_RAW"},
 				SecurityOpt:    []string{"no-new-privileges"},
 				ReadonlyPaths:  []string{"/test/readyonly/path"},
 				MaskedPaths:    []string{"/test/masked/path"},
@@ -774,8 +801,8 @@ func Test_modifyHostConfig(t *testing.T) {
 					Privileged:         true,
 					ReadonlyRootfs:     true,
 					Capabilities: &runtime.Capability{
-						AddCapabilities:  []string{"fooAdd1", "fooAdd2"},
-						DropCapabilities: []string{"fooDrop1", "fooDrop2"},
+						AddCapabilities:  []string{"net_admin", "CAP_SYS_PTRACE"},
+						DropCapabilities: []string{"cap_mknod", "NET_RAW"},
 					},
 					SeccompProfilePath: "foo",
 					ApparmorProfile:    mgr.ProfileRuntimeDefault,
@@ -787,8 +814,8 @@ func Test_modifyHostConfig(t *testing.T) {
 				GroupAdd:       groupAdd,
 				Privileged:     true,
 				ReadonlyRootfs: true,
-				CapAdd:         []string{"fooAdd1", "fooAdd2"},
-				CapDrop:        []string{"fooDrop1", "fooDrop2"},
+				CapAdd:         []string{"NET_ADMIN", "SYS_PTRACE"},
+				CapDrop:        []string{"MKNOD", "NET_RAW"},
 			},
 			wantErr: fmt.Errorf("failed to generate seccomp security options: %v", fmt.Errorf("undefault profile %q should prefix with %q", "foo", mgr.ProfileNamePrefix)),
 		},
@@ -800,8 +827,8 @@ func Test_modifyHostConfig(t *testing.T) {
 					Privileged:         true,
 					ReadonlyRootfs:     true,
 					Capabilities: &runtime.Capability{
-						AddCapabilities:  []string{"fooAdd1", "fooAdd2"},
-						DropCapabilities: []string{"fooDrop1", "fooDrop2"},
+						AddCapabilities:  []string{"net_admin", "CAP_SYS_PTRACE"},
+						DropCapabilities: []string{"cap_mknod", "NET_RAW"},
 					},
 					SeccompProfilePath: mgr.ProfileDockerDefault,
 					ApparmorProfile:    "foo",
@@ -813,8 +840,8 @@ func Test_modifyHostConfig(t *testing.T) {
 				GroupAdd:       groupAdd,
 				Privileged:     true,
 				ReadonlyRootfs: true,
-				CapAdd:         []string{"fooAdd1", "fooAdd2"},
-				CapDrop:        []string{"fooDrop1", "fooDrop2"},
+				CapAdd:         []string{"NET_ADMIN", "SYS_PTRACE"},
+				CapDrop:        []string{"MKNOD", "NET_RAW"},
 			},
 			wantErr: fmt.Errorf("failed to generate appArmor security options: %v", fmt.Errorf("undefault profile name should prefix with %q", mgr.ProfileNamePrefix)),
 		},
@@ -826,8 +853,8 @@ func Test_modifyHostConfig(t *testing.T) {
 					Privileged:         true,
 					ReadonlyRootfs:     true,
 					Capabilities: &runtime.Capability{
-						AddCapabilities:  []string{"fooAdd1", "fooAdd2"},
-						DropCapabilities: []string{"fooDrop1", "fooDrop2"},
+						AddCapabilities:  []string{"net_admin", "CAP_SYS_PTRACE"},
+						DropCapabilities: []string{"cap_mknod", "NET_RAW"},
 					},
 					SeccompProfilePath: mgr.ProfileDockerDefault,
 					ApparmorProfile:    mgr.ProfileRuntimeDefault,
@@ -839,8 +866,8 @@ func Test_modifyHostConfig(t *testing.T) {
 				GroupAdd:       groupAdd,
 				Privileged:     true,
 				ReadonlyRootfs: true,
-				CapAdd:         []string{"fooAdd1", "fooAdd2"},
-				CapDrop:        []string{"fooDrop1", "fooDrop2"},
+				CapAdd:         []string{"NET_ADMIN", "SYS_PTRACE"},
+				CapDrop:        []string{"MKNOD", "NET_RAW"},
 			},
 			wantErr: nil,
 		},
@@ -1751,6 +1778,41 @@ func Test_generateMountBindings(t *testing.T) {
 	}
 }
 
+func Test_setupSandboxFilesHostname(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sandbox-files")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// the containers use the hostname of sandbox container if it is not generated.
+	config := &runtime.PodSandboxConfig{}
+	assert.NoError(t, setupSandboxFiles(dir, config))
+	assert.Equal(t, []string{dir + "/resolv.conf:/etc/resolv.conf"}, generateContainerMounts(dir))
+
+	config.Hostname = "pod-hostname"
+	assert.NoError(t, setupSandboxFiles(dir, config))
+	content, err := ioutil.ReadFile(dir + "/hostname")
+	assert.NoError(t, err)
+	assert.Equal(t, "pod-hostname\n", string(content))
+	assert.Equal(t, []string{
+		dir + "/resolv.conf:/etc/resolv.conf",
+		dir + "/hostname:/etc/hostname",
+	}, generateContainerMounts(dir))
+
+	// the sandbox in the network of node uses the hostname of node by default.
+	config.Hostname = ""
+	config.Linux = &runtime.LinuxPodSandboxConfig{
+		SecurityContext: &runtime.LinuxSandboxSecurityContext{
+			NamespaceOptions: &runtime.NamespaceOption{Network: runtime.NamespaceMode_NODE},
+		},
+	}
+	assert.NoError(t, setupSandboxFiles(dir, config))
+	content, err = ioutil.ReadFile(dir + "/hostname")
+	assert.NoError(t, err)
+	nodeHostname, err := os.Hostname()
+	assert.NoError(t, err)
+	assert.Equal(t, nodeHostname+"\n", string(content))
+}
+
 func Test_modifySandboxNamespaceOptions(t *testing.T) {
 	type args struct {
 		nsOpts     *runtime.NamespaceOption
@@ -1951,6 +2013,49 @@ func Test_applyContainerConfigByAnnotation(t *testing.T) {
 			},
 			errMsg: "failed to parse resources.pids-limit",
 		},
+		{
+			name: "normalUlimitsTest",
+			annotation: map[string]string{
+				anno.UlimitsExtendAnnotation: "nofile=1024:2048, nproc=512",
+			},
+			checkFn: func(config *apitypes.ContainerConfig, hc *apitypes.HostConfig, uc *apitypes.UpdateConfig) bool {
+				return reflect.DeepEqual(hc.Ulimits, []*apitypes.Ulimit{
+					{Name: "nofile", Soft: 1024, Hard: 2048},
+					{Name: "nproc", Soft: 512, Hard: 512},
+				})
+			},
+			errMsg: "",
+		},
+		{
+			name: "errorUlimitsTest",
+			annotation: map[string]string{
+				anno.UlimitsExtendAnnotation: "nofile",
+			},
+			checkFn: func(config *apitypes.ContainerConfig, hc *apitypes.HostConfig, uc *apitypes.UpdateConfig) bool {
+				return false
+			},
+			errMsg: "failed to parse resources.ulimits",
+		},
+		{
+			name: "normalStopSignalTest",
+			annotation: map[string]string{
+				anno.StopSignalExtendAnnotation: "SIGQUIT",
+			},
+			checkFn: func(config *apitypes.ContainerConfig, hc *apitypes.HostConfig, uc *apitypes.UpdateConfig) bool {
+				return config.StopSignal == "SIGQUIT"
+			},
+			errMsg: "",
+		},
+		{
+			name: "errorStopSignalTest",
+			annotation: map[string]string{
+				anno.StopSignalExtendAnnotation: "SIGFOO",
+			},
+			checkFn: func(config *apitypes.ContainerConfig, hc *apitypes.HostConfig, uc *apitypes.UpdateConfig) bool {
+				return false
+			},
+			errMsg: "failed to parse stop-signal",
+		},
 	}
 
 	for _, tt := range tests {
@@ -1974,3 +2079,388 @@ func Test_applyContainerConfigByAnnotation(t *testing.T) {
 		})
 	}
 }
+
+func Test_applyStaticNetworkAnnotations(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		wantIP      string
+		wantMAC     string
+		errMsg      string
+	}{
+		{
+			name:        "no annotations",
+			annotations: map[string]string{},
+		},
+		{
+			name: "static ip and mac",
+			annotations: map[string]string{
+				anno.StaticIPAnnotation:  "10.0.0.10",
+				anno.StaticMACAnnotation: "02:42:ac:11:00:02",
+			},
+			wantIP:  "10.0.0.10",
+			wantMAC: "02:42:ac:11:00:02",
+		},
+		{
+			name: "invalid ip",
+			annotations: map[string]string{
+				anno.StaticIPAnnotation: "10.0.0.300",
+			},
+			errMsg: "invalid static ip",
+		},
+		{
+			name: "invalid mac",
+			annotations: map[string]string{
+				anno.StaticMACAnnotation: "02:42:ac",
+			},
+			errMsg: "invalid static mac",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runtimeConfig := &cni.RuntimeConfig{}
+			err := applyStaticNetworkAnnotations(tt.annotations, runtimeConfig)
+			if tt.errMsg != "" {
+				assert.NotNil(t, err)
+				if err != nil {
+					assert.Contains(t, err.Error(), tt.errMsg)
+				}
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.wantIP, runtimeConfig.IP)
+			assert.Equal(t, tt.wantMAC, runtimeConfig.MAC)
+		})
+	}
+}
+
+func Test_parseNetworkDevices(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        []*cni.NetworkDevice
+		wantErr     bool
+	}{
+		{
+			name:        "no annotation",
+			annotations: map[string]string{},
+		},
+		{
+			name: "devices with and without container name",
+			annotations: map[string]string{
+				anno.NetworkDevicesAnnotation: "ens1f0v2:net1, ens1f0v3",
+			},
+			want: []*cni.NetworkDevice{
+				{HostIfName: "ens1f0v2", ContainerIfName: "net1"},
+				{HostIfName: "ens1f0v3"},
+			},
+		},
+		{
+			name: "empty container name",
+			annotations: map[string]string{
+				anno.NetworkDevicesAnnotation: "ens1f0v2:",
+			},
+			wantErr: true,
+		},
+		{
+			name: "too many parts",
+			annotations: map[string]string{
+				anno.NetworkDevicesAnnotation: "ens1f0v2:net1:net2",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseNetworkDevices(tt.annotations)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseNetworkDevices() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseNetworkDevices() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// fakeDeviceCniMgr moves the network devices by the errors of device names.
+type fakeDeviceCniMgr struct {
+	cni.CniMgr
+	errs     map[string]error
+	attached []string
+}
+
+func (f *fakeDeviceCniMgr) AttachDevice(netnsPath string, device *cni.NetworkDevice) error {
+	if err := f.errs[device.HostIfName]; err != nil {
+		return err
+	}
+	f.attached = append(f.attached, device.HostIfName)
+	return nil
+}
+
+func (f *fakeDeviceCniMgr) DetachDevice(netnsPath string, device *cni.NetworkDevice) error {
+	return f.errs[device.HostIfName]
+}
+
+func TestAttachNetworkDevices(t *testing.T) {
+	fake := &fakeDeviceCniMgr{errs: map[string]error{}}
+	c := &CriManager{CniMgr: fake, allowedNetworkDevices: []string{"ens1f0v*"}}
+	config := &runtime.PodSandboxConfig{Annotations: map[string]string{
+		anno.NetworkDevicesAnnotation: "ens1f0v2:net1,eth0",
+	}}
+
+	// the device not allowed, e.g. the uplink, is rejected before any is moved.
+	sandboxMeta := &metatypes.SandboxMeta{ID: "s1"}
+	err := c.attachNetworkDevices(sandboxMeta, config)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "eth0 is not allowed")
+	assert.Empty(t, fake.attached)
+
+	c.allowedNetworkDevices = nil
+	assert.Error(t, c.attachNetworkDevices(sandboxMeta, &runtime.PodSandboxConfig{Annotations: map[string]string{
+		anno.NetworkDevicesAnnotation: "ens1f0v2",
+	}}))
+
+	c.allowedNetworkDevices = []string{"ens1f0v*", "eth0"}
+	assert.NoError(t, c.attachNetworkDevices(sandboxMeta, config))
+	assert.Equal(t, []string{"ens1f0v2", "eth0"}, fake.attached)
+	assert.Len(t, sandboxMeta.NetworkDevices, 2)
+}
+
+func TestDetachNetworkDevices(t *testing.T) {
+	fake := &fakeDeviceCniMgr{errs: map[string]error{"ens1f0v3": fmt.Errorf("busy")}}
+	c := &CriManager{CniMgr: fake}
+	sandboxMeta := &metatypes.SandboxMeta{ID: "s1", NetworkDevices: []*cni.NetworkDevice{
+		{HostIfName: "ens1f0v2", ContainerIfName: "net1"},
+		{HostIfName: "ens1f0v3", ContainerIfName: "net2"},
+	}}
+
+	// the device failing to be detached is kept to be retried.
+	err := c.detachNetworkDevices(sandboxMeta)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "ens1f0v3: busy")
+	assert.Equal(t, []*cni.NetworkDevice{{HostIfName: "ens1f0v3", ContainerIfName: "net2"}}, sandboxMeta.NetworkDevices)
+
+	delete(fake.errs, "ens1f0v3")
+	assert.NoError(t, c.detachNetworkDevices(sandboxMeta))
+	assert.Empty(t, sandboxMeta.NetworkDevices)
+}
+
+func Test_parseNetworkPolicy(t *testing.T) {
+	_, cluster, _ := net.ParseCIDR("10.0.0.0/8")
+	_, host, _ := net.ParseCIDR("192.168.1.1/32")
+	_, any, _ := net.ParseCIDR("0.0.0.0/0")
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        *cni.NetworkPolicy
+		wantErr     bool
+	}{
+		{
+			name:        "no annotation",
+			annotations: map[string]string{},
+		},
+		{
+			name: "ingress and egress rules",
+			annotations: map[string]string{
+				anno.NetworkPolicyIngressAnnotation: "allow:10.0.0.0/8:tcp:80, deny:0.0.0.0/0",
+				anno.NetworkPolicyEgressAnnotation:  "deny:192.168.1.1:UDP",
+			},
+			want: &cni.NetworkPolicy{
+				Ingress: []*cni.PolicyRule{
+					{Allow: true, CIDR: cluster, Protocol: "tcp", Port: 80},
+					{Allow: false, CIDR: any},
+				},
+				Egress: []*cni.PolicyRule{
+					{Allow: false, CIDR: host, Protocol: "udp"},
+				},
+			},
+		},
+		{
+			name: "invalid action",
+			annotations: map[string]string{
+				anno.NetworkPolicyIngressAnnotation: "accept:10.0.0.0/8",
+			},
+			wantErr: true,
+		},
+		{
+			name: "ipv6 cidr",
+			annotations: map[string]string{
+				anno.NetworkPolicyEgressAnnotation: "deny:fd00::/8",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid port",
+			annotations: map[string]string{
+				anno.NetworkPolicyIngressAnnotation: "allow:10.0.0.0/8:tcp:70000",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseNetworkPolicy(tt.annotations)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseNetworkPolicy() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseNetworkPolicy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_mergeUlimits(t *testing.T) {
+	base := []*apitypes.Ulimit{
+		{Name: "nofile", Soft: 1024, Hard: 1024},
+		{Name: "nproc", Soft: 512, Hard: 512},
+	}
+	overrides := []*apitypes.Ulimit{
+		{Name: "core", Soft: 0, Hard: 0},
+		{Name: "nofile", Soft: 65536, Hard: 65536},
+	}
+
+	assert.Equal(t, []*apitypes.Ulimit{
+		{Name: "nofile", Soft: 65536, Hard: 65536},
+		{Name: "nproc", Soft: 512, Hard: 512},
+		{Name: "core", Soft: 0, Hard: 0},
+	}, mergeUlimits(base, overrides))
+	assert.Empty(t, mergeUlimits(nil, nil))
+}
+
+func Test_makeSandboxPouchConfigOOMScoreAdj(t *testing.T) {
+	config := &runtime.PodSandboxConfig{
+		Metadata: &runtime.PodSandboxMetadata{Name: "nginx", Namespace: "default", Uid: "uid1"},
+	}
+	createConfig, err := makeSandboxPouchConfig(config, &metatypes.SandboxMeta{ID: "sandbox1"}, "pause:3.0")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(sandboxOOMScoreAdj), createConfig.HostConfig.OomScoreAdj)
+}
+
+func Test_parseDefaultCapabilities(t *testing.T) {
+	capabilities, err := parseDefaultCapabilities([]string{"chown", "CAP_KILL"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"CHOWN", "KILL"}, capabilities)
+
+	capabilities, err = parseDefaultCapabilities([]string{"ALL"})
+	assert.NoError(t, err)
+	assert.Contains(t, capabilities, "SYS_ADMIN")
+
+	capabilities, err = parseDefaultCapabilities(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, capabilities)
+
+	_, err = parseDefaultCapabilities([]string{"FOO"})
+	assert.Error(t, err)
+}
+
+func Test_applyDefaultCapabilities(t *testing.T) {
+	defaults := []string{"CHOWN", "KILL", "NET_RAW"}
+
+	hc := &apitypes.HostConfig{CapAdd: []string{"NET_ADMIN"}, CapDrop: []string{"NET_RAW"}}
+	assert.NoError(t, applyDefaultCapabilities(defaults, hc))
+	assert.Equal(t, []string{"CHOWN", "KILL", "NET_ADMIN"}, hc.CapAdd)
+	assert.Equal(t, []string{"ALL"}, hc.CapDrop)
+
+	hc = &apitypes.HostConfig{CapDrop: []string{"ALL"}}
+	assert.NoError(t, applyDefaultCapabilities(defaults, hc))
+	assert.Empty(t, hc.CapAdd)
+	assert.Equal(t, []string{"ALL"}, hc.CapDrop)
+
+	hc = &apitypes.HostConfig{CapAdd: []string{"ALL"}}
+	assert.NoError(t, applyDefaultCapabilities(defaults, hc))
+	assert.Contains(t, hc.CapAdd, "SYS_ADMIN")
+
+	// privileged containers and the ones without defaults are untouched.
+	hc = &apitypes.HostConfig{Privileged: true, CapAdd: []string{"NET_ADMIN"}}
+	assert.NoError(t, applyDefaultCapabilities(defaults, hc))
+	assert.Equal(t, []string{"NET_ADMIN"}, hc.CapAdd)
+	assert.Empty(t, hc.CapDrop)
+
+	hc = &apitypes.HostConfig{CapAdd: []string{"NET_ADMIN"}}
+	assert.NoError(t, applyDefaultCapabilities(nil, hc))
+	assert.Equal(t, []string{"NET_ADMIN"}, hc.CapAdd)
+
+	hc = &apitypes.HostConfig{CapAdd: []string{"FOO"}}
+	assert.Error(t, applyDefaultCapabilities(defaults, hc))
+}
+
+func Test_applyDefaultMaskedAndReadonlyPaths(t *testing.T) {
+	maskedPaths := []string{"/proc/kcore"}
+	readonlyPaths := []string{"/proc/sys"}
+
+	hc := &apitypes.HostConfig{ReadonlyPaths: []string{"/proc/bus"}}
+	applyDefaultMaskedAndReadonlyPaths(hc, maskedPaths, readonlyPaths)
+	assert.Equal(t, []string{"/proc/kcore"}, hc.MaskedPaths)
+	assert.Equal(t, []string{"/proc/bus"}, hc.ReadonlyPaths)
+
+	hc = &apitypes.HostConfig{}
+	applyDefaultMaskedAndReadonlyPaths(hc, nil, nil)
+	assert.Empty(t, hc.MaskedPaths)
+	assert.Empty(t, hc.ReadonlyPaths)
+
+	// privileged containers have no masked paths and readonly paths.
+	hc = &apitypes.HostConfig{Privileged: true}
+	applyDefaultMaskedAndReadonlyPaths(hc, maskedPaths, readonlyPaths)
+	assert.Empty(t, hc.MaskedPaths)
+	assert.Empty(t, hc.ReadonlyPaths)
+}
+
+func Test_inheritSandboxSecurityContext(t *testing.T) {
+	psc := &runtime.LinuxSandboxSecurityContext{
+		RunAsUser:          &runtime.Int64Value{Value: 1000},
+		RunAsGroup:         &runtime.Int64Value{Value: 2000},
+		SupplementalGroups: []int64{1, 2},
+	}
+
+	// the user, group and supplemental groups are inherited from the sandbox.
+	lc := inheritSandboxSecurityContext(&runtime.LinuxContainerConfig{
+		SecurityContext: &runtime.LinuxContainerSecurityContext{SupplementalGroups: []int64{2, 3}},
+	}, psc)
+	assert.Equal(t, int64(1000), lc.GetSecurityContext().GetRunAsUser().GetValue())
+	assert.Equal(t, int64(2000), lc.GetSecurityContext().GetRunAsGroup().GetValue())
+	assert.Equal(t, []int64{2, 3, 1}, lc.GetSecurityContext().GetSupplementalGroups())
+
+	// the user of container takes precedence.
+	sc := &runtime.LinuxContainerSecurityContext{RunAsUsername: "nginx"}
+	lc = inheritSandboxSecurityContext(&runtime.LinuxContainerConfig{SecurityContext: sc}, psc)
+	assert.Nil(t, lc.GetSecurityContext().GetRunAsUser())
+	assert.Nil(t, lc.GetSecurityContext().GetRunAsGroup())
+	assert.Equal(t, "nginx", lc.GetSecurityContext().GetRunAsUsername())
+	assert.Empty(t, sc.GetSupplementalGroups())
+
+	config := &apitypes.ContainerConfig{}
+	assert.NoError(t, modifyContainerConfig(inheritSandboxSecurityContext(nil, psc).GetSecurityContext(), config))
+	assert.Equal(t, "1000:2000", config.User)
+
+	lc = &runtime.LinuxContainerConfig{}
+	assert.Equal(t, lc, inheritSandboxSecurityContext(lc, nil))
+}
+
+func Test_sandboxStopTimeout(t *testing.T) {
+	homeDir, err := ioutil.TempDir("", "stop-timeout")
+	assert.NoError(t, err)
+	defer os.RemoveAll(homeDir)
+
+	store, err := newSandboxStore(homeDir)
+	assert.NoError(t, err)
+	defer store.Shutdown()
+
+	c := &CriManager{SandboxStore: store, defaultStopTimeout: 10}
+
+	sandboxMeta := &metatypes.SandboxMeta{ID: "sandbox1"}
+	assert.NoError(t, c.applySandboxAnnotations(sandboxMeta, nil))
+	assert.Equal(t, int64(10), c.sandboxStopTimeout(sandboxMeta))
+	assert.Equal(t, int64(10), c.sandboxStopTimeout(nil))
+
+	assert.NoError(t, c.applySandboxAnnotations(sandboxMeta, map[string]string{anno.StopTimeoutExtendAnnotation: "60"}))
+	assert.Equal(t, int64(60), c.sandboxStopTimeout(sandboxMeta))
+
+	for _, invalid := range []string{"-1", "1m", ""} {
+		assert.Error(t, c.applySandboxAnnotations(&metatypes.SandboxMeta{ID: "sandbox2"}, map[string]string{anno.StopTimeoutExtendAnnotation: invalid}))
+	}
+}
@@ -0,0 +1,130 @@
+package v1alpha2
+
+import (
+	"context"
+	"path"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/alibaba/pouch/pkg/grpc/interceptor"
+	"github.com/alibaba/pouch/pkg/log"
+
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+)
+
+const (
+	// allDumpMethods selects all the cri methods to dump.
+	allDumpMethods = "*"
+)
+
+// debugDumper dumps the full payloads of the selected cri methods into the log
+// with the secrets redacted, which is turned on or off at runtime to
+// troubleshoot the disagreements between kubelet and pouchd.
+type debugDumper struct {
+	mu      sync.RWMutex
+	methods map[string]bool
+
+	// limiter limits the rate of dumps, the requests over the limit are not dumped.
+	limiter *rate.Limiter
+	dropped uint64
+}
+
+// newDebugDumper creates the dumper of the methods, at most limit dumps are
+// written per second.
+func newDebugDumper(methods []string, limit int) *debugDumper {
+	if limit <= 0 {
+		limit = 1
+	}
+	d := &debugDumper{limiter: rate.NewLimiter(rate.Limit(limit), limit)}
+	d.setMethods(methods)
+	return d
+}
+
+// setMethods replaces the methods to dump, empty turns the dump off.
+func (d *debugDumper) setMethods(methods []string) {
+	selected := make(map[string]bool, len(methods))
+	for _, m := range methods {
+		if m != "" {
+			selected[m] = true
+		}
+	}
+
+	d.mu.Lock()
+	d.methods = selected
+	d.mu.Unlock()
+}
+
+// getMethods returns the sorted methods to dump.
+func (d *debugDumper) getMethods() []string {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	methods := make([]string, 0, len(d.methods))
+	for m := range d.methods {
+		methods = append(methods, m)
+	}
+	sort.Strings(methods)
+	return methods
+}
+
+// selected returns whether the method is dumped.
+func (d *debugDumper) selected(method string) bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.methods[method] || d.methods[allDumpMethods]
+}
+
+// dump writes the redacted request and response of the method into the log.
+func (d *debugDumper) dump(ctx context.Context, method string, req, resp interface{}, err error) {
+	if !d.limiter.Allow() {
+		atomic.AddUint64(&d.dropped, 1)
+		return
+	}
+
+	fields := map[string]interface{}{
+		"grpc.method":           method,
+		"grpc.request.content":  interceptor.RedactPayload(req),
+		"grpc.response.content": interceptor.RedactPayload(resp),
+	}
+	if dropped := atomic.SwapUint64(&d.dropped, 0); dropped != 0 {
+		fields["dump.dropped"] = dropped
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+	}
+	log.WithFields(ctx, fields).Infof("cri debug dump of %s", method)
+}
+
+// debugDumpUnaryServerInterceptor dumps the requests and responses of the
+// methods selected in the debug dump.
+func debugDumpUnaryServerInterceptor(criMgr CriMgr) grpc.UnaryServerInterceptor {
+	c, _ := criMgr.(*CriManager)
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		method := path.Base(info.FullMethod)
+		if c == nil || c.debugDumper == nil || !c.debugDumper.selected(method) {
+			return handler(ctx, req)
+		}
+
+		resp, err := handler(ctx, req)
+		c.debugDumper.dump(ctx, method, req, resp, err)
+		return resp, err
+	}
+}
+
+// SetDebugDumpMethods sets the cri methods whose payloads are dumped into the
+// log, "*" means all the methods and empty turns the dump off.
+func (c *CriManager) SetDebugDumpMethods(methods []string) {
+	c.debugDumper.setMethods(methods)
+	if len(methods) == 0 {
+		log.With(nil).Infof("cri debug dump is turned off")
+	} else {
+		log.With(nil).Infof("cri debug dump is turned on for %v", methods)
+	}
+}
+
+// DebugDumpMethods returns the cri methods whose payloads are dumped.
+func (c *CriManager) DebugDumpMethods() []string {
+	return c.debugDumper.getMethods()
+}
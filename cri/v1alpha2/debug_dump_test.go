@@ -0,0 +1,47 @@
+package v1alpha2
+
+import (
+	"context"
+	"testing"
+
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+func TestDebugDumpUnaryServerInterceptor(t *testing.T) {
+	c := &CriManager{debugDumper: newDebugDumper(nil, 1)}
+	interceptor := debugDumpUnaryServerInterceptor(c)
+	call := func(method string) {
+		info := &grpc.UnaryServerInfo{FullMethod: "/runtime.v1alpha2.RuntimeService/" + method}
+		resp, err := interceptor(context.Background(), &runtime.VersionRequest{}, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return &runtime.VersionResponse{}, nil
+		})
+		assert.NoError(t, err)
+		assert.NotNil(t, resp)
+	}
+
+	// the dump is off by default.
+	assert.Empty(t, c.DebugDumpMethods())
+	assert.False(t, c.debugDumper.selected("Version"))
+
+	c.SetDebugDumpMethods([]string{"Version", "PullImage"})
+	assert.Equal(t, []string{"PullImage", "Version"}, c.DebugDumpMethods())
+	assert.True(t, c.debugDumper.selected("Version"))
+	assert.False(t, c.debugDumper.selected("ListContainers"))
+
+	// the dumps over the rate are dropped and counted.
+	call("Version")
+	call("Version")
+	assert.Equal(t, uint64(1), c.debugDumper.dropped)
+	call("ListContainers")
+	assert.Equal(t, uint64(1), c.debugDumper.dropped)
+
+	c.SetDebugDumpMethods([]string{allDumpMethods})
+	assert.True(t, c.debugDumper.selected("ListContainers"))
+
+	c.SetDebugDumpMethods(nil)
+	assert.Empty(t, c.DebugDumpMethods())
+	assert.False(t, c.debugDumper.selected("Version"))
+}
@@ -0,0 +1,78 @@
+package v1alpha2
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	anno "github.com/alibaba/pouch/cri/annotations"
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+)
+
+// parseDefaultMounts parses the default mounts of containers, each of them is
+// in the form of "hostPath:containerPath[:ro]", e.g. "/etc/localtime:/etc/localtime:ro".
+func parseDefaultMounts(entries []string) ([]*runtime.Mount, error) {
+	var mounts []*runtime.Mount
+	for _, e := range entries {
+		parts := strings.Split(e, ":")
+		if len(parts) < 2 || len(parts) > 3 {
+			return nil, fmt.Errorf("invalid default mount %q, should be hostPath:containerPath[:ro]", e)
+		}
+
+		m := &runtime.Mount{HostPath: parts[0], ContainerPath: parts[1]}
+		if !path.IsAbs(m.HostPath) || !path.IsAbs(m.ContainerPath) {
+			return nil, fmt.Errorf("invalid default mount %q, the paths must be absolute", e)
+		}
+		if len(parts) == 3 {
+			if parts[2] != "ro" {
+				return nil, fmt.Errorf("invalid mode %q of default mount %q, should be ro", parts[2], e)
+			}
+			m.Readonly = true
+		}
+		mounts = append(mounts, m)
+	}
+	return mounts, nil
+}
+
+// bindDestination returns the container path of the bind.
+func bindDestination(bind string) string {
+	parts := strings.Split(bind, ":")
+	if len(parts) < 2 {
+		return ""
+	}
+	return path.Clean(parts[1])
+}
+
+// defaultMountsFor returns the default mounts injected into the container,
+// the ones overridden by the binds of container and the ones excluded by
+// the annotation of pod are skipped.
+func defaultMountsFor(defaults []*runtime.Mount, binds []string, podAnnotations map[string]string) []*runtime.Mount {
+	if len(defaults) == 0 {
+		return nil
+	}
+
+	skipped := make(map[string]struct{})
+	for _, b := range binds {
+		skipped[bindDestination(b)] = struct{}{}
+	}
+	if exclude, ok := podAnnotations[anno.DefaultMountsExcludeAnnotation]; ok {
+		for _, p := range strings.Split(exclude, ",") {
+			p = strings.TrimSpace(p)
+			if p == "*" {
+				return nil
+			}
+			if p != "" {
+				skipped[path.Clean(p)] = struct{}{}
+			}
+		}
+	}
+
+	var mounts []*runtime.Mount
+	for _, m := range defaults {
+		if _, ok := skipped[path.Clean(m.ContainerPath)]; ok {
+			continue
+		}
+		mounts = append(mounts, m)
+	}
+	return mounts
+}
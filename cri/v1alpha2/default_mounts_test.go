@@ -0,0 +1,45 @@
+package v1alpha2
+
+import (
+	"testing"
+
+	anno "github.com/alibaba/pouch/cri/annotations"
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseDefaultMounts(t *testing.T) {
+	mounts, err := parseDefaultMounts([]string{"/etc/localtime:/etc/localtime:ro", "/etc/pki:/etc/pki"})
+	assert.NoError(t, err)
+	assert.Equal(t, []*runtime.Mount{
+		{HostPath: "/etc/localtime", ContainerPath: "/etc/localtime", Readonly: true},
+		{HostPath: "/etc/pki", ContainerPath: "/etc/pki"},
+	}, mounts)
+
+	for _, e := range []string{"/etc/localtime", "etc:/etc", "/etc:etc", "/a:/b:rw", "/a:/b:ro:z"} {
+		_, err := parseDefaultMounts([]string{e})
+		assert.Error(t, err, e)
+	}
+}
+
+func Test_defaultMountsFor(t *testing.T) {
+	defaults := []*runtime.Mount{
+		{HostPath: "/etc/localtime", ContainerPath: "/etc/localtime", Readonly: true},
+		{HostPath: "/etc/pki", ContainerPath: "/etc/pki"},
+		{HostPath: "/etc/ssl/certs", ContainerPath: "/etc/ssl/certs"},
+	}
+
+	// the mounts of container override the default ones.
+	mounts := defaultMountsFor(defaults, []string{"/data/tz:/etc/localtime/:ro"}, nil)
+	assert.Equal(t, defaults[1:], mounts)
+
+	// the pod excludes the default ones.
+	mounts = defaultMountsFor(defaults, nil, map[string]string{anno.DefaultMountsExcludeAnnotation: "/etc/pki, /etc/ssl/certs"})
+	assert.Equal(t, defaults[:1], mounts)
+	mounts = defaultMountsFor(defaults, nil, map[string]string{anno.DefaultMountsExcludeAnnotation: "*"})
+	assert.Empty(t, mounts)
+
+	assert.Empty(t, defaultMountsFor(nil, nil, nil))
+	assert.Equal(t, []string{"/etc/localtime:/etc/localtime:ro"}, generateMountBindings(defaults[:1]))
+}
@@ -0,0 +1,392 @@
+package v1alpha2
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	apitypes "github.com/alibaba/pouch/apis/types"
+	metatypes "github.com/alibaba/pouch/cri/v1alpha2/types"
+	"github.com/alibaba/pouch/daemon/mgr"
+	"github.com/alibaba/pouch/pkg/errtypes"
+	"github.com/alibaba/pouch/pkg/log"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+)
+
+// hostDevice is a block or character device on the host.
+type hostDevice struct {
+	typ   string
+	major int64
+	minor int64
+	mode  uint32
+	uid   int
+	gid   int
+}
+
+// statHostDevice returns the device of the path on the host.
+func statHostDevice(hostPath string) (*hostDevice, error) {
+	var st unix.Stat_t
+	if err := unix.Stat(hostPath, &st); err != nil {
+		return nil, err
+	}
+
+	dev := &hostDevice{
+		major: int64(unix.Major(uint64(st.Rdev))),
+		minor: int64(unix.Minor(uint64(st.Rdev))),
+		mode:  st.Mode,
+		uid:   int(st.Uid),
+		gid:   int(st.Gid),
+	}
+	switch st.Mode & unix.S_IFMT {
+	case unix.S_IFBLK:
+		dev.typ = "b"
+	case unix.S_IFCHR:
+		dev.typ = "c"
+	default:
+		return nil, fmt.Errorf("%s is not a block or character device", hostPath)
+	}
+	return dev, nil
+}
+
+// rule returns the rule of device in the devices cgroup.
+func (d *hostDevice) rule(permissions string) string {
+	return fmt.Sprintf("%s %d:%d %s", d.typ, d.major, d.minor, permissions)
+}
+
+// validateDeviceRequest validates the request and fills in the defaults.
+func validateDeviceRequest(r *metatypes.DeviceRequest) error {
+	if r.HostPath == "" || !filepath.IsAbs(r.HostPath) {
+		return errors.Wrapf(errtypes.ErrInvalidParam, "the host path %q of device should be absolute", r.HostPath)
+	}
+	if r.ContainerPath == "" {
+		r.ContainerPath = r.HostPath
+	}
+	if !filepath.IsAbs(r.ContainerPath) {
+		return errors.Wrapf(errtypes.ErrInvalidParam, "the container path %q of device should be absolute", r.ContainerPath)
+	}
+	r.ContainerPath = filepath.Clean(r.ContainerPath)
+	if r.ContainerPath == "/" {
+		return errors.Wrapf(errtypes.ErrInvalidParam, "the container path of device should not be /")
+	}
+
+	if r.Permissions == "" {
+		r.Permissions = "rwm"
+	}
+	for _, p := range r.Permissions {
+		if !strings.ContainsRune("rwm", p) || strings.Count(r.Permissions, string(p)) > 1 {
+			return errors.Wrapf(errtypes.ErrInvalidParam, "invalid permissions %q of device, should be any of r, w and m", r.Permissions)
+		}
+	}
+	return nil
+}
+
+// runningContainer returns the container which should be running.
+func (c *CriManager) runningContainer(ctx context.Context, containerID string) (*mgr.Container, error) {
+	container, err := c.ContainerMgr.Get(ctx, containerID)
+	if err != nil {
+		return nil, err
+	}
+	if container.State == nil || !container.State.Running || container.State.Pid <= 0 {
+		return nil, errors.Wrapf(errtypes.ErrPreCheckFailed, "container %q is not running", containerID)
+	}
+	return container, nil
+}
+
+// hotplugDevicesLabelKey is the internal label of the devices hot-plugged into
+// the container, the value is the json of the devices.
+const hotplugDevicesLabelKey = "io.kubernetes.pouch.hotplug-devices"
+
+// hotplugDevice is a device hot-plugged into the container.
+type hotplugDevice struct {
+	HostPath      string `json:"hostPath"`
+	ContainerPath string `json:"containerPath"`
+	Permissions   string `json:"permissions"`
+	// StartedAt is the start time of the container which the device is
+	// attached to, since the device is gone once the container restarts.
+	StartedAt string `json:"startedAt"`
+}
+
+// hotplugDevices returns the devices hot-plugged into the current run of the
+// container, the ones recorded before the container restarts are ignored.
+func hotplugDevices(container *mgr.Container) ([]hotplugDevice, error) {
+	var recorded []hotplugDevice
+	if v := container.Config.Labels[hotplugDevicesLabelKey]; v != "" {
+		if err := json.Unmarshal([]byte(v), &recorded); err != nil {
+			return nil, fmt.Errorf("failed to parse hot-plugged devices of container %q: %v", container.ID, err)
+		}
+	}
+
+	var devices []hotplugDevice
+	for _, d := range recorded {
+		if container.State != nil && d.StartedAt == container.State.StartedAt {
+			devices = append(devices, d)
+		}
+	}
+	return devices, nil
+}
+
+// setHotplugDevices records the devices hot-plugged into the container. The
+// update of container resets the devices cgroup from the spec, the devices are
+// applied again after it.
+func (c *CriManager) setHotplugDevices(ctx context.Context, containerID string, devices []hotplugDevice) error {
+	value := ""
+	if len(devices) > 0 {
+		data, err := json.Marshal(devices)
+		if err != nil {
+			return err
+		}
+		value = string(data)
+	}
+	return c.ContainerMgr.Update(ctx, containerID, &apitypes.UpdateConfig{Label: []string{hotplugDevicesLabelKey + "=" + value}})
+}
+
+// reapplyHotplugDevices allows the devices hot-plugged into the running container
+// in its devices cgroup again, and creates their nodes if missing. It should be
+// called after the container is updated, which resets the devices cgroup from
+// the spec and revokes the devices hot-plugged.
+func (c *CriManager) reapplyHotplugDevices(ctx context.Context, containerID string) error {
+	container, err := c.ContainerMgr.Get(ctx, containerID)
+	if err != nil {
+		return err
+	}
+	if container.State == nil || !container.State.Running || container.State.Pid <= 0 {
+		return nil
+	}
+	devices, err := hotplugDevices(container)
+	if err != nil {
+		return err
+	}
+
+	root := filepath.Join(procRoot, strconv.FormatInt(container.State.Pid, 10), "root")
+	for _, d := range devices {
+		dev, err := statHostDevice(d.HostPath)
+		if err != nil {
+			return fmt.Errorf("failed to stat device %s: %v", d.HostPath, err)
+		}
+		if err := setDeviceCgroup(container.State.Pid, dev.rule(d.Permissions), true); err != nil {
+			return fmt.Errorf("failed to allow device %s in container %q: %v", d.HostPath, containerID, err)
+		}
+		if err := createDeviceNode(root, d.ContainerPath, dev); err != nil {
+			return fmt.Errorf("failed to create device %s in container %q: %v", d.ContainerPath, containerID, err)
+		}
+	}
+	return nil
+}
+
+// AttachDevice attaches the device of host to the running container, by
+// allowing it in the devices cgroup of container and creating its node in
+// the container. The device is recorded in the container with its start
+// time, and is applied again once the container is updated. It is not kept
+// once the container restarts.
+func (c *CriManager) AttachDevice(ctx context.Context, r *metatypes.DeviceRequest) (*metatypes.DeviceResponse, error) {
+	if err := validateDeviceRequest(r); err != nil {
+		return nil, err
+	}
+	dev, err := statHostDevice(r.HostPath)
+	if err != nil {
+		return nil, errors.Wrapf(errtypes.ErrInvalidParam, "failed to stat device %s: %v", r.HostPath, err)
+	}
+
+	c.hotplugLock.Lock()
+	defer c.hotplugLock.Unlock()
+
+	container, err := c.runningContainer(ctx, r.ContainerID)
+	if err != nil {
+		return nil, err
+	}
+	devices, err := hotplugDevices(container)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range devices {
+		if d.ContainerPath == r.ContainerPath {
+			return nil, errors.Wrapf(errtypes.ErrAlreadyExisted, "device %s is already attached to container %q at %s", d.HostPath, r.ContainerID, r.ContainerPath)
+		}
+	}
+
+	// the device is recorded before it is applied, since the update of
+	// container resets the devices cgroup.
+	attached := append(devices, hotplugDevice{
+		HostPath:      r.HostPath,
+		ContainerPath: r.ContainerPath,
+		Permissions:   r.Permissions,
+		StartedAt:     container.State.StartedAt,
+	})
+	if err := c.setHotplugDevices(ctx, r.ContainerID, attached); err != nil {
+		return nil, fmt.Errorf("failed to record device %s in container %q: %v", r.HostPath, r.ContainerID, err)
+	}
+	if err := c.reapplyHotplugDevices(ctx, r.ContainerID); err != nil {
+		if err := setDeviceCgroup(container.State.Pid, dev.rule(r.Permissions), false); err != nil {
+			log.With(ctx).Warnf("failed to deny device %s in container %q: %v", r.HostPath, r.ContainerID, err)
+		}
+		if err := c.setHotplugDevices(ctx, r.ContainerID, devices); err != nil {
+			log.With(ctx).Warnf("failed to remove the record of device %s in container %q: %v", r.HostPath, r.ContainerID, err)
+		}
+		return nil, err
+	}
+
+	log.With(ctx).Infof("device %s is attached to container %q at %s", r.HostPath, r.ContainerID, r.ContainerPath)
+	return deviceResponse(dev, r), nil
+}
+
+// DetachDevice removes the node of device from the running container and
+// denies it in the devices cgroup of container. Only the devices attached by
+// AttachDevice could be detached.
+func (c *CriManager) DetachDevice(ctx context.Context, r *metatypes.DeviceRequest) (*metatypes.DeviceResponse, error) {
+	if err := validateDeviceRequest(r); err != nil {
+		return nil, err
+	}
+	dev, err := statHostDevice(r.HostPath)
+	if err != nil {
+		return nil, errors.Wrapf(errtypes.ErrInvalidParam, "failed to stat device %s: %v", r.HostPath, err)
+	}
+
+	c.hotplugLock.Lock()
+	defer c.hotplugLock.Unlock()
+
+	container, err := c.runningContainer(ctx, r.ContainerID)
+	if err != nil {
+		return nil, err
+	}
+	devices, err := hotplugDevices(container)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		left  []hotplugDevice
+		found *hotplugDevice
+	)
+	for i, d := range devices {
+		if d.HostPath == r.HostPath && d.ContainerPath == r.ContainerPath {
+			found = &devices[i]
+			continue
+		}
+		left = append(left, d)
+	}
+	if found == nil {
+		return nil, errors.Wrapf(errtypes.ErrNotfound, "device %s is not attached to container %q at %s", r.HostPath, r.ContainerID, r.ContainerPath)
+	}
+	// the device is denied with the permissions it is attached with.
+	r.Permissions = found.Permissions
+
+	root := filepath.Join(procRoot, strconv.FormatInt(container.State.Pid, 10), "root")
+	if err := removeDeviceNode(root, r.ContainerPath, dev); err != nil {
+		return nil, fmt.Errorf("failed to remove device %s from container %q: %v", r.ContainerPath, r.ContainerID, err)
+	}
+	if err := setDeviceCgroup(container.State.Pid, dev.rule(r.Permissions), false); err != nil {
+		return nil, fmt.Errorf("failed to deny device %s in container %q: %v", r.HostPath, r.ContainerID, err)
+	}
+
+	if err := c.setHotplugDevices(ctx, r.ContainerID, left); err != nil {
+		return nil, fmt.Errorf("failed to remove the record of device %s in container %q: %v", r.HostPath, r.ContainerID, err)
+	}
+	if err := c.reapplyHotplugDevices(ctx, r.ContainerID); err != nil {
+		return nil, err
+	}
+
+	log.With(ctx).Infof("device %s is detached from container %q", r.HostPath, r.ContainerID)
+	return deviceResponse(dev, r), nil
+}
+
+func deviceResponse(dev *hostDevice, r *metatypes.DeviceRequest) *metatypes.DeviceResponse {
+	return &metatypes.DeviceResponse{
+		Type:          dev.typ,
+		Major:         dev.major,
+		Minor:         dev.minor,
+		ContainerPath: r.ContainerPath,
+		Permissions:   r.Permissions,
+	}
+}
+
+// setDeviceCgroup allows or denies the device in the devices cgroup of the
+// process. The devices of cgroup v2 are controlled by the bpf program attached
+// by runtime, which could not be updated here.
+func setDeviceCgroup(pid int64, rule string, allow bool) error {
+	if isCgroup2UnifiedMode() {
+		return fmt.Errorf("hot-plug of devices is not supported on cgroup v2")
+	}
+
+	cgroupPath, err := processCgroupPath(pid, "devices")
+	if err != nil {
+		return err
+	}
+	file := "devices.deny"
+	if allow {
+		file = "devices.allow"
+	}
+	return ioutil.WriteFile(filepath.Join(cgroupPath, file), []byte(rule), 0644)
+}
+
+// createDeviceNode creates the node of device in the root of container, with
+// the mode and owner of the one on host. The path in container is resolved
+// without following symlinks, which may point to the host through the root.
+// The existing node of the same device is kept.
+func createDeviceNode(root, containerPath string, dev *hostDevice) error {
+	dir, name := path.Split(strings.TrimPrefix(containerPath, "/"))
+	dir = strings.TrimSuffix(dir, "/")
+	if dir == "" {
+		dir = "."
+	}
+	if err := safeMkdirAll(root, dir); err != nil {
+		return err
+	}
+	fd, err := safeOpen(root, dir)
+	if err != nil {
+		return err
+	}
+	defer unix.Close(fd)
+
+	var st unix.Stat_t
+	if err := unix.Fstatat(fd, name, &st, unix.AT_SYMLINK_NOFOLLOW); err == nil {
+		if st.Mode&unix.S_IFMT == dev.mode&unix.S_IFMT && int64(unix.Major(uint64(st.Rdev))) == dev.major && int64(unix.Minor(uint64(st.Rdev))) == dev.minor {
+			return nil
+		}
+		return fmt.Errorf("%s exists in container and is not the device", containerPath)
+	}
+
+	if err := unix.Mknodat(fd, name, dev.mode, int(unix.Mkdev(uint32(dev.major), uint32(dev.minor)))); err != nil {
+		return err
+	}
+	// the umask is not applied to the mode of host.
+	if err := unix.Fchmodat(fd, name, dev.mode&0777, 0); err != nil {
+		return err
+	}
+	return unix.Fchownat(fd, name, dev.uid, dev.gid, unix.AT_SYMLINK_NOFOLLOW)
+}
+
+// removeDeviceNode removes the node of device from the root of container, the
+// node missing or of another device is left.
+func removeDeviceNode(root, containerPath string, dev *hostDevice) error {
+	dir, name := path.Split(strings.TrimPrefix(containerPath, "/"))
+	dir = strings.TrimSuffix(dir, "/")
+	if dir == "" {
+		dir = "."
+	}
+	fd, err := safeOpen(root, dir)
+	if err != nil {
+		if err == unix.ENOENT {
+			return nil
+		}
+		return err
+	}
+	defer unix.Close(fd)
+
+	var st unix.Stat_t
+	if err := unix.Fstatat(fd, name, &st, unix.AT_SYMLINK_NOFOLLOW); err != nil {
+		if err == unix.ENOENT {
+			return nil
+		}
+		return err
+	}
+	if st.Mode&unix.S_IFMT != dev.mode&unix.S_IFMT || int64(unix.Major(uint64(st.Rdev))) != dev.major || int64(unix.Minor(uint64(st.Rdev))) != dev.minor {
+		return fmt.Errorf("%s in container is not the device", containerPath)
+	}
+	return unix.Unlinkat(fd, name, 0)
+}
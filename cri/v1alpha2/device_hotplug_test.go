@@ -0,0 +1,197 @@
+package v1alpha2
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	apitypes "github.com/alibaba/pouch/apis/types"
+	metatypes "github.com/alibaba/pouch/cri/v1alpha2/types"
+	"github.com/alibaba/pouch/daemon/mgr"
+	"github.com/alibaba/pouch/pkg/errtypes"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateDeviceRequest(t *testing.T) {
+	r := &metatypes.DeviceRequest{HostPath: "/dev/sdb"}
+	assert.NoError(t, validateDeviceRequest(r))
+	assert.Equal(t, "/dev/sdb", r.ContainerPath)
+	assert.Equal(t, "rwm", r.Permissions)
+
+	r = &metatypes.DeviceRequest{HostPath: "/dev/nvidia0", ContainerPath: "/dev/gpu/../nvidia0/", Permissions: "rw"}
+	assert.NoError(t, validateDeviceRequest(r))
+	assert.Equal(t, "/dev/nvidia0", r.ContainerPath)
+	assert.Equal(t, "rw", r.Permissions)
+
+	for _, r := range []*metatypes.DeviceRequest{
+		{},
+		{HostPath: "dev/sdb"},
+		{HostPath: "/dev/sdb", ContainerPath: "dev/sdb"},
+		{HostPath: "/dev/sdb", ContainerPath: "/dev/.."},
+		{HostPath: "/dev/sdb", Permissions: "rx"},
+		{HostPath: "/dev/sdb", Permissions: "rr"},
+	} {
+		assert.Error(t, validateDeviceRequest(r), r)
+	}
+}
+
+func TestStatHostDevice(t *testing.T) {
+	dev, err := statHostDevice("/dev/null")
+	if err != nil {
+		t.Skipf("/dev/null could not be stated: %v", err)
+	}
+	assert.Equal(t, "c 1:3 rw", dev.rule("rw"))
+
+	_, err = statHostDevice("/dev")
+	assert.Error(t, err)
+}
+
+func TestSetDeviceCgroup(t *testing.T) {
+	root, err := ioutil.TempDir("", "cri-device-hotplug")
+	assert.NoError(t, err)
+	defer os.RemoveAll(root)
+	defer func(cgroup, proc string, unified func() bool) {
+		cgroupRoot, procRoot, isCgroup2UnifiedMode = cgroup, proc, unified
+	}(cgroupRoot, procRoot, isCgroup2UnifiedMode)
+	cgroupRoot = filepath.Join(root, "cgroup")
+	procRoot = filepath.Join(root, "proc")
+	isCgroup2UnifiedMode = func() bool { return false }
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(procRoot, "100"), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(procRoot, "100", "cgroup"),
+		[]byte("4:devices:/kubepods/pod1/c1\n3:memory:/kubepods/pod1/c1\n"), 0644))
+	devicesDir := filepath.Join(cgroupRoot, "devices", "kubepods", "pod1", "c1")
+	assert.NoError(t, os.MkdirAll(devicesDir, 0755))
+
+	assert.NoError(t, setDeviceCgroup(100, "b 8:16 rwm", true))
+	allow, err := ioutil.ReadFile(filepath.Join(devicesDir, "devices.allow"))
+	assert.NoError(t, err)
+	assert.Equal(t, "b 8:16 rwm", string(allow))
+
+	assert.NoError(t, setDeviceCgroup(100, "b 8:16 rwm", false))
+	deny, err := ioutil.ReadFile(filepath.Join(devicesDir, "devices.deny"))
+	assert.NoError(t, err)
+	assert.Equal(t, "b 8:16 rwm", string(deny))
+
+	isCgroup2UnifiedMode = func() bool { return true }
+	assert.Error(t, setDeviceCgroup(100, "b 8:16 rwm", true))
+}
+
+func TestDeviceNode(t *testing.T) {
+	dev, err := statHostDevice("/dev/null")
+	if err != nil {
+		t.Skipf("/dev/null could not be stated: %v", err)
+	}
+
+	root, err := ioutil.TempDir("", "cri-device-hotplug")
+	assert.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	if err := createDeviceNode(root, "/dev/hotplug/null", dev); err != nil {
+		if strings.Contains(err.Error(), "operation not permitted") {
+			t.Skipf("device node could not be created: %v", err)
+		}
+		t.Fatal(err)
+	}
+	node := filepath.Join(root, "dev", "hotplug", "null")
+	fi, err := os.Lstat(node)
+	assert.NoError(t, err)
+	assert.True(t, fi.Mode()&os.ModeCharDevice != 0)
+
+	// the node of the same device is kept.
+	assert.NoError(t, createDeviceNode(root, "/dev/hotplug/null", dev))
+
+	// the file which is not the device is never replaced or removed.
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(root, "dev", "file"), nil, 0644))
+	assert.Error(t, createDeviceNode(root, "/dev/file", dev))
+	assert.Error(t, removeDeviceNode(root, "/dev/file", dev))
+
+	// the symlink out of root is never followed.
+	assert.NoError(t, os.Symlink("/dev", filepath.Join(root, "link")))
+	assert.Error(t, createDeviceNode(root, "/link/hotplug-null", dev))
+	_, err = os.Lstat("/dev/hotplug-null")
+	assert.True(t, os.IsNotExist(err))
+
+	assert.NoError(t, removeDeviceNode(root, "/dev/hotplug/null", dev))
+	_, err = os.Lstat(node)
+	assert.True(t, os.IsNotExist(err))
+	assert.NoError(t, removeDeviceNode(root, "/dev/hotplug/null", dev))
+	assert.NoError(t, removeDeviceNode(root, "/missing/null", dev))
+}
+
+func TestHotplugDevices(t *testing.T) {
+	dev, err := statHostDevice("/dev/null")
+	if err != nil {
+		t.Skipf("/dev/null could not be stated: %v", err)
+	}
+
+	root, err := ioutil.TempDir("", "cri-device-hotplug")
+	assert.NoError(t, err)
+	defer os.RemoveAll(root)
+	defer func(cgroup, proc string, unified func() bool) {
+		cgroupRoot, procRoot, isCgroup2UnifiedMode = cgroup, proc, unified
+	}(cgroupRoot, procRoot, isCgroup2UnifiedMode)
+	cgroupRoot = filepath.Join(root, "cgroup")
+	procRoot = filepath.Join(root, "proc")
+	isCgroup2UnifiedMode = func() bool { return false }
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(procRoot, "100", "root"), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(procRoot, "100", "cgroup"), []byte("4:devices:/kubepods/pod1/c1\n"), 0644))
+	devicesDir := filepath.Join(cgroupRoot, "devices", "kubepods", "pod1", "c1")
+	assert.NoError(t, os.MkdirAll(devicesDir, 0755))
+	if err := createDeviceNode(filepath.Join(procRoot, "100", "root"), "/dev/probe", dev); err != nil {
+		t.Skipf("device node could not be created: %v", err)
+	}
+
+	ctrMgr := &ephemeralContainerMgr{containers: map[string]*mgr.Container{
+		"c1": {
+			ID:     "c1",
+			Config: &apitypes.ContainerConfig{Labels: map[string]string{}},
+			State:  &apitypes.ContainerState{Running: true, Pid: 100, StartedAt: "2020-01-01T00:00:00Z"},
+		},
+	}}
+	c := &CriManager{ContainerMgr: ctrMgr}
+
+	// the default devices are never detached.
+	_, err = c.DetachDevice(context.Background(), &metatypes.DeviceRequest{ContainerID: "c1", HostPath: "/dev/null"})
+	assert.True(t, errtypes.IsNotfound(err), err)
+
+	_, err = c.AttachDevice(context.Background(), &metatypes.DeviceRequest{ContainerID: "c1", HostPath: "/dev/null", ContainerPath: "/dev/hotplug", Permissions: "rw"})
+	assert.NoError(t, err)
+	devices, err := hotplugDevices(ctrMgr.containers["c1"])
+	assert.NoError(t, err)
+	assert.Equal(t, []hotplugDevice{{HostPath: "/dev/null", ContainerPath: "/dev/hotplug", Permissions: "rw", StartedAt: "2020-01-01T00:00:00Z"}}, devices)
+	_, err = c.AttachDevice(context.Background(), &metatypes.DeviceRequest{ContainerID: "c1", HostPath: "/dev/null", ContainerPath: "/dev/hotplug"})
+	assert.Error(t, err)
+
+	// the devices are allowed again after the update of container resets the cgroup.
+	assert.NoError(t, os.Remove(filepath.Join(devicesDir, "devices.allow")))
+	assert.NoError(t, c.reapplyHotplugDevices(context.Background(), "c1"))
+	allow, err := ioutil.ReadFile(filepath.Join(devicesDir, "devices.allow"))
+	assert.NoError(t, err)
+	assert.Equal(t, "c 1:3 rw", string(allow))
+
+	// the device is denied with the permissions attached with.
+	_, err = c.DetachDevice(context.Background(), &metatypes.DeviceRequest{ContainerID: "c1", HostPath: "/dev/null", ContainerPath: "/dev/hotplug"})
+	assert.NoError(t, err)
+	deny, err := ioutil.ReadFile(filepath.Join(devicesDir, "devices.deny"))
+	assert.NoError(t, err)
+	assert.Equal(t, "c 1:3 rw", string(deny))
+	assert.Empty(t, ctrMgr.containers["c1"].Config.Labels)
+	_, err = os.Lstat(filepath.Join(procRoot, "100", "root", "dev", "hotplug"))
+	assert.True(t, os.IsNotExist(err))
+
+	// the devices attached are gone once the container restarts, which could be attached again.
+	_, err = c.AttachDevice(context.Background(), &metatypes.DeviceRequest{ContainerID: "c1", HostPath: "/dev/null", ContainerPath: "/dev/hotplug"})
+	assert.NoError(t, err)
+	ctrMgr.containers["c1"].State.StartedAt = "2020-01-02T00:00:00Z"
+	devices, err = hotplugDevices(ctrMgr.containers["c1"])
+	assert.NoError(t, err)
+	assert.Empty(t, devices)
+	_, err = c.AttachDevice(context.Background(), &metatypes.DeviceRequest{ContainerID: "c1", HostPath: "/dev/null", ContainerPath: "/dev/hotplug"})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,229 @@
+package v1alpha2
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+	metatypes "github.com/alibaba/pouch/cri/v1alpha2/types"
+	"github.com/alibaba/pouch/daemon/mgr"
+
+	"golang.org/x/net/context"
+)
+
+const (
+	// diagnosticsLogTailSize is the size of the tail of container log in the bundle.
+	diagnosticsLogTailSize = 1024 * 1024
+	// diagnosticsErrorsFile lists the items failed to be gathered into the bundle.
+	diagnosticsErrorsFile = "errors.txt"
+)
+
+var (
+	// runInNetns runs the command in the network namespace and returns the
+	// output, which is replaced in tests.
+	runInNetns = func(netns string, args ...string) ([]byte, error) {
+		var stderr bytes.Buffer
+		cmd := exec.Command("nsenter", append([]string{"--net=" + netns, "--"}, args...)...)
+		cmd.Stderr = &stderr
+		out, err := cmd.Output()
+		if err != nil {
+			return nil, fmt.Errorf("%v, stderr: %q", err, stderr.String())
+		}
+		return out, nil
+	}
+
+	// netnsDiagnostics are the commands dumped from the network namespace of sandbox.
+	netnsDiagnostics = map[string][]string{
+		"addr.txt":  {"ip", "addr", "show"},
+		"route.txt": {"ip", "route", "show", "table", "all"},
+		"rule.txt":  {"ip", "rule", "show"},
+		"neigh.txt": {"ip", "neigh", "show"},
+	}
+)
+
+// diagnosticsBundle writes the files into the gzipped tar archive, the failures
+// of gathering the items are collected instead of aborting the bundle.
+type diagnosticsBundle struct {
+	tw     *tar.Writer
+	errors []string
+}
+
+func (b *diagnosticsBundle) addFile(name string, data []byte) error {
+	if err := b.tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    int64(len(data)),
+		ModTime: time.Now(),
+	}); err != nil {
+		return err
+	}
+	_, err := b.tw.Write(data)
+	return err
+}
+
+func (b *diagnosticsBundle) addJSON(name string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		b.addError(name, err)
+		return nil
+	}
+	return b.addFile(name, data)
+}
+
+func (b *diagnosticsBundle) addError(item string, err error) {
+	b.errors = append(b.errors, fmt.Sprintf("%s: %v", item, err))
+}
+
+// readFileTail reads at most size bytes from the end of file.
+func readFileTail(file string, size int64) ([]byte, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if fi.Size() > size {
+		if _, err := f.Seek(-size, io.SeekEnd); err != nil {
+			return nil, err
+		}
+	}
+	return ioutil.ReadAll(f)
+}
+
+// DiagnoseSandbox writes a gzipped tar archive gathering everything about the
+// sandbox for the support tickets, which are the sandbox meta including the CNI
+// results, the sandbox files, the status, OCI spec and recent logs of its
+// containers, and the addresses and routes in its network namespace.
+func (c *CriManager) DiagnoseSandbox(ctx context.Context, id string, w io.Writer) error {
+	res, err := c.SandboxStore.Get(id)
+	if err != nil {
+		return fmt.Errorf("failed to get metadata of %q from SandboxStore: %v", id, err)
+	}
+	sandboxMeta := res.(*metatypes.SandboxMeta)
+
+	containers, err := c.ContainerMgr.List(ctx, &mgr.ContainerListOption{
+		All:    true,
+		Labels: map[string]string{sandboxIDLabelKey: sandboxMeta.ID},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get the containers belong to sandbox %q: %v", sandboxMeta.ID, err)
+	}
+
+	gw := gzip.NewWriter(w)
+	b := &diagnosticsBundle{tw: tar.NewWriter(gw)}
+
+	if err := c.writeSandboxDiagnostics(ctx, b, sandboxMeta, containers); err != nil {
+		return err
+	}
+	if len(b.errors) > 0 {
+		if err := b.addFile(diagnosticsErrorsFile, []byte(strings.Join(b.errors, "\n")+"\n")); err != nil {
+			return err
+		}
+	}
+
+	if err := b.tw.Close(); err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+func (c *CriManager) writeSandboxDiagnostics(ctx context.Context, b *diagnosticsBundle, sandboxMeta *metatypes.SandboxMeta, containers []*mgr.Container) error {
+	if err := b.addJSON("sandbox.json", sandboxMeta); err != nil {
+		return err
+	}
+
+	// the sandbox files, e.g. resolv.conf and hosts, bound into the containers.
+	sandboxRootDir := path.Join(c.SandboxBaseDir, sandboxMeta.ID)
+	if files, err := ioutil.ReadDir(sandboxRootDir); err != nil {
+		b.addError("sandbox files", err)
+	} else {
+		for _, fi := range files {
+			if !fi.Mode().IsRegular() {
+				continue
+			}
+			data, err := readFileTail(filepath.Join(sandboxRootDir, fi.Name()), diagnosticsLogTailSize)
+			if err != nil {
+				b.addError("sandbox file "+fi.Name(), err)
+				continue
+			}
+			if err := b.addFile(path.Join("sandbox", fi.Name()), data); err != nil {
+				return err
+			}
+		}
+	}
+
+	var sandbox *mgr.Container
+	for _, container := range containers {
+		if container.ID == sandboxMeta.ID {
+			sandbox = container
+		}
+
+		dir := path.Join("containers", container.ID)
+		if err := b.addJSON(path.Join(dir, "status.json"), container); err != nil {
+			return err
+		}
+
+		if container.BaseFS != "" {
+			spec, err := ioutil.ReadFile(filepath.Join(filepath.Dir(container.BaseFS), "config.json"))
+			if err != nil {
+				b.addError("oci spec of "+container.ID, err)
+			} else if err := b.addFile(path.Join(dir, "config.json"), spec); err != nil {
+				return err
+			}
+		}
+
+		if logPath := container.Config.Labels[containerLogPathLabelKey]; logPath != "" {
+			data, err := readFileTail(logPath, diagnosticsLogTailSize)
+			if err != nil {
+				b.addError("log of "+container.ID, err)
+			} else if err := b.addFile(path.Join(dir, "container.log"), data); err != nil {
+				return err
+			}
+		}
+	}
+
+	if sandboxNetworkMode(sandboxMeta.Config) == runtime.NamespaceMode_NODE {
+		return nil
+	}
+	netns := sandboxMeta.NetNS
+	if netns == "" && sandbox != nil && sandbox.State != nil {
+		netns = containerNetns(sandbox)
+	}
+	if netns == "" {
+		b.addError("netns", fmt.Errorf("no network namespace of running sandbox"))
+		return nil
+	}
+
+	names := make([]string, 0, len(netnsDiagnostics))
+	for name := range netnsDiagnostics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		out, err := runInNetns(netns, netnsDiagnostics[name]...)
+		if err != nil {
+			b.addError("netns "+name, err)
+			continue
+		}
+		if err := b.addFile(path.Join("netns", name), out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
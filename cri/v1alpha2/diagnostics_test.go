@@ -0,0 +1,128 @@
+package v1alpha2
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	apitypes "github.com/alibaba/pouch/apis/types"
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+	metatypes "github.com/alibaba/pouch/cri/v1alpha2/types"
+	"github.com/alibaba/pouch/daemon/mgr"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// diagnosticsContainerLister lists the containers of sandbox.
+type diagnosticsContainerLister struct {
+	mgr.ContainerMgr
+	containers []*mgr.Container
+}
+
+func (l *diagnosticsContainerLister) List(ctx context.Context, option *mgr.ContainerListOption) ([]*mgr.Container, error) {
+	return l.containers, nil
+}
+
+// readBundle returns the contents of the files in the gzipped tar archive.
+func readBundle(t *testing.T, data []byte) map[string]string {
+	gr, err := gzip.NewReader(bytes.NewReader(data))
+	assert.NoError(t, err)
+	tr := tar.NewReader(gr)
+
+	files := make(map[string]string)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		assert.NoError(t, err)
+		content, err := ioutil.ReadAll(tr)
+		assert.NoError(t, err)
+		files[hdr.Name] = string(content)
+	}
+	return files
+}
+
+func TestReadFileTail(t *testing.T) {
+	f, err := ioutil.TempFile("", "tail")
+	assert.NoError(t, err)
+	defer os.Remove(f.Name())
+	f.WriteString("0123456789")
+	f.Close()
+
+	data, err := readFileTail(f.Name(), 4)
+	assert.NoError(t, err)
+	assert.Equal(t, "6789", string(data))
+
+	data, err = readFileTail(f.Name(), 100)
+	assert.NoError(t, err)
+	assert.Equal(t, "0123456789", string(data))
+}
+
+func TestDiagnoseSandbox(t *testing.T) {
+	homeDir, err := ioutil.TempDir("", "diagnostics")
+	assert.NoError(t, err)
+	defer os.RemoveAll(homeDir)
+
+	store, err := newSandboxStore(homeDir)
+	assert.NoError(t, err)
+	defer store.Shutdown()
+	assert.NoError(t, store.Put(&metatypes.SandboxMeta{
+		ID:     "s1",
+		Config: &runtime.PodSandboxConfig{Metadata: &runtime.PodSandboxMetadata{Name: "pod"}},
+		NetNS:  "/var/run/netns/cni-1",
+	}))
+
+	sandboxBaseDir := filepath.Join(homeDir, "sandboxes")
+	assert.NoError(t, os.MkdirAll(filepath.Join(sandboxBaseDir, "s1"), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(sandboxBaseDir, "s1", "resolv.conf"), []byte("nameserver 10.0.0.10\n"), 0644))
+
+	bundleDir := filepath.Join(homeDir, "bundles", "c1")
+	assert.NoError(t, os.MkdirAll(filepath.Join(bundleDir, "rootfs"), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(bundleDir, "config.json"), []byte(`{"ociVersion":"1.0.1"}`), 0644))
+	logPath := filepath.Join(homeDir, "c1.log")
+	assert.NoError(t, ioutil.WriteFile(logPath, []byte("hello\n"), 0644))
+
+	lister := &diagnosticsContainerLister{containers: []*mgr.Container{
+		{ID: "s1", Config: &apitypes.ContainerConfig{}, State: &apitypes.ContainerState{Pid: 1}},
+		{
+			ID:     "c1",
+			BaseFS: filepath.Join(bundleDir, "rootfs"),
+			Config: &apitypes.ContainerConfig{Labels: map[string]string{containerLogPathLabelKey: logPath}},
+		},
+	}}
+	c := &CriManager{ContainerMgr: lister, SandboxStore: store, SandboxBaseDir: sandboxBaseDir}
+
+	oldRunInNetns := runInNetns
+	defer func() { runInNetns = oldRunInNetns }()
+	runInNetns = func(netns string, args ...string) ([]byte, error) {
+		if args[1] == "neigh" {
+			return nil, fmt.Errorf("no ip")
+		}
+		return []byte(netns + " " + args[1]), nil
+	}
+
+	assert.Error(t, c.DiagnoseSandbox(context.Background(), "s2", &bytes.Buffer{}))
+
+	var buf bytes.Buffer
+	assert.NoError(t, c.DiagnoseSandbox(context.Background(), "s1", &buf))
+	files := readBundle(t, buf.Bytes())
+
+	assert.Contains(t, files["sandbox.json"], `"NetNS": "/var/run/netns/cni-1"`)
+	assert.Equal(t, "nameserver 10.0.0.10\n", files["sandbox/resolv.conf"])
+	assert.Contains(t, files, "containers/s1/status.json")
+	assert.Contains(t, files["containers/c1/status.json"], `"Id": "c1"`)
+	assert.Equal(t, `{"ociVersion":"1.0.1"}`, files["containers/c1/config.json"])
+	assert.Equal(t, "hello\n", files["containers/c1/container.log"])
+	assert.Equal(t, "/var/run/netns/cni-1 addr", files["netns/addr.txt"])
+	assert.Equal(t, "/var/run/netns/cni-1 route", files["netns/route.txt"])
+	assert.NotContains(t, files, "netns/neigh.txt")
+	assert.Equal(t, "netns neigh.txt: no ip\n", files[diagnosticsErrorsFile])
+}
@@ -0,0 +1,314 @@
+package v1alpha2
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	apitypes "github.com/alibaba/pouch/apis/types"
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+	metatypes "github.com/alibaba/pouch/cri/v1alpha2/types"
+	"github.com/alibaba/pouch/daemon/mgr"
+	"github.com/alibaba/pouch/pkg/log"
+)
+
+const (
+	// dockershimTypeLabelKey is the label of dockershim to identify whether a
+	// container is a sandbox or a regular container.
+	dockershimTypeLabelKey       = "io.kubernetes.docker.type"
+	dockershimTypeLabelSandbox   = "podsandbox"
+	dockershimTypeLabelContainer = "container"
+)
+
+// dockershimCheckpointDir is the directory in which dockershim keeps the checkpoints of sandboxes.
+var dockershimCheckpointDir = "/var/lib/dockershim/sandbox"
+
+// dockershimCheckpoint is the checkpoint of sandbox kept by dockershim, which
+// is the only place the port mappings of sandbox are recorded.
+type dockershimCheckpoint struct {
+	Data struct {
+		PortMappings []struct {
+			Protocol      string `json:"protocol,omitempty"`
+			ContainerPort int32  `json:"container_port,omitempty"`
+			HostPort      int32  `json:"host_port,omitempty"`
+			HostIP        string `json:"host_ip,omitempty"`
+		} `json:"port_mappings,omitempty"`
+		HostNetwork bool `json:"host_network,omitempty"`
+	} `json:"data,omitempty"`
+}
+
+// readDockershimCheckpoint reads the checkpoint of sandbox, nil is returned if there is none.
+func readDockershimCheckpoint(id string) (*dockershimCheckpoint, error) {
+	data, err := ioutil.ReadFile(filepath.Join(dockershimCheckpointDir, id))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	checkpoint := &dockershimCheckpoint{}
+	if err := json.Unmarshal(data, checkpoint); err != nil {
+		return nil, fmt.Errorf("failed to parse checkpoint of sandbox %q: %v", id, err)
+	}
+	return checkpoint, nil
+}
+
+// ImportDockershim finds the sandboxes and containers created by dockershim through
+// the docker compatible API of pouchd, and imports them into the cri manager if apply
+// is true, so that the node could be migrated without restarting the pods. The
+// containers are labeled with the labels of cri manager, the sandbox meta is
+// synthesized and the network namespace of the running sandbox is adopted.
+// Importing is expected to be done when kubelet is stopped.
+func (c *CriManager) ImportDockershim(ctx context.Context, apply bool) (*metatypes.DockershimImportReport, error) {
+	containers, err := c.ContainerMgr.List(ctx, &mgr.ContainerListOption{
+		All: true,
+		FilterFunc: func(container *mgr.Container) bool {
+			_, ok := container.Config.Labels[dockershimTypeLabelKey]
+			return ok
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers created by dockershim: %v", err)
+	}
+
+	sandboxes := make(map[string]*mgr.Container)
+	children := make(map[string][]*mgr.Container)
+	for _, container := range containers {
+		if container.Config.Labels[dockershimTypeLabelKey] == dockershimTypeLabelSandbox {
+			sandboxes[container.ID] = container
+			continue
+		}
+		id := container.Config.Labels[sandboxIDLabelKey]
+		children[id] = append(children[id], container)
+	}
+
+	report := &metatypes.DockershimImportReport{Sandboxes: []*metatypes.DockershimSandbox{}}
+	for id, containers := range children {
+		if _, ok := sandboxes[id]; ok {
+			continue
+		}
+		item := &metatypes.DockershimSandbox{ID: id, Error: "sandbox of the containers is not found"}
+		for _, container := range containers {
+			item.Containers = append(item.Containers, container.ID)
+		}
+		report.Sandboxes = append(report.Sandboxes, item)
+	}
+
+	for id, sandbox := range sandboxes {
+		item := &metatypes.DockershimSandbox{ID: id, Containers: []string{}}
+		for _, container := range children[id] {
+			item.Containers = append(item.Containers, container.ID)
+		}
+		report.Sandboxes = append(report.Sandboxes, item)
+
+		if !apply {
+			continue
+		}
+		if item.NetNS, err = c.importDockershimSandbox(ctx, sandbox, children[id]); err != nil {
+			item.Error = err.Error()
+			log.With(ctx).Warnf("failed to import sandbox %q created by dockershim: %v", id, err)
+			continue
+		}
+		item.Imported = true
+	}
+
+	sort.Slice(report.Sandboxes, func(i, j int) bool {
+		return report.Sandboxes[i].ID < report.Sandboxes[j].ID
+	})
+	return report, nil
+}
+
+// importDockershimSandbox imports the sandbox created by dockershim with its
+// containers, and returns the network namespace adopted.
+func (c *CriManager) importDockershimSandbox(ctx context.Context, sandbox *mgr.Container, containers []*mgr.Container) (netns string, retErr error) {
+	checkpoint, err := readDockershimCheckpoint(sandbox.ID)
+	if err != nil {
+		return "", err
+	}
+	config, err := dockershimSandboxConfig(sandbox, checkpoint)
+	if err != nil {
+		return "", err
+	}
+
+	sandboxMeta := &metatypes.SandboxMeta{
+		ID:            sandbox.ID,
+		SchemaVersion: sandboxMetaSchemaVersion,
+		Config:        config,
+		Runtime:       sandbox.HostConfig.Runtime,
+		LxcfsEnabled:  sandbox.HostConfig.EnableLxcfs,
+		Phase:         metatypes.SandboxPhaseReady,
+	}
+
+	// the network namespace of dockershim is bound to the sandbox container, it's made
+	// persistent to be independent from the sandbox container as the ones of cri manager.
+	// The stopped sandbox is left as the legacy dockershim style one.
+	if sandboxNetworkMode(config) != runtime.NamespaceMode_NODE && sandbox.IsRunning() {
+		if netns, err = c.CniMgr.AdoptNetNS(containerNetns(sandbox)); err != nil {
+			return "", fmt.Errorf("failed to adopt netns of sandbox: %v", err)
+		}
+		sandboxMeta.NetNS = netns
+		defer func() {
+			if retErr != nil {
+				if err := c.CniMgr.RemoveNetNS(netns); err != nil {
+					log.With(ctx).Errorf("failed to remove netns %s when importing sandbox %q failed: %v", netns, sandbox.ID, err)
+				}
+			}
+		}()
+	}
+
+	// the labels of all are generated before any is relabeled, and the ones
+	// relabeled are restored if importing fails, so that they are still found
+	// as the ones of dockershim on retry.
+	relabels := make([][]string, len(containers))
+	rollbacks := make([][]string, len(containers))
+	for i, container := range containers {
+		if relabels[i], err = dockershimContainerLabels(container); err != nil {
+			return "", err
+		}
+		rollbacks[i] = originalLabels(container, relabels[i])
+	}
+	sandboxLabels, err := dockershimContainerLabels(sandbox)
+	if err != nil {
+		return "", err
+	}
+
+	relabeled := 0
+	defer func() {
+		if retErr == nil {
+			return
+		}
+		for i := 0; i < relabeled; i++ {
+			if err := c.ContainerMgr.Update(ctx, containers[i].ID, &apitypes.UpdateConfig{Label: rollbacks[i]}); err != nil {
+				log.With(ctx).Errorf("failed to restore labels of container %q when importing sandbox %q failed: %v", containers[i].ID, sandbox.ID, err)
+			}
+		}
+	}()
+	for i, container := range containers {
+		if err := c.ContainerMgr.Update(ctx, container.ID, &apitypes.UpdateConfig{Label: relabels[i]}); err != nil {
+			return "", fmt.Errorf("failed to label container %q: %v", container.ID, err)
+		}
+		relabeled++
+	}
+
+	if err := c.SandboxStore.Put(sandboxMeta); err != nil {
+		return "", fmt.Errorf("failed to put meta of sandbox: %v", err)
+	}
+
+	// the sandbox is labeled at last, so that it's listed only if all are imported.
+	if err := c.ContainerMgr.Update(ctx, sandbox.ID, &apitypes.UpdateConfig{Label: sandboxLabels}); err != nil {
+		if err := c.SandboxStore.Remove(sandbox.ID); err != nil {
+			log.With(ctx).Errorf("failed to remove meta of sandbox %q when importing it failed: %v", sandbox.ID, err)
+		}
+		return "", fmt.Errorf("failed to label sandbox: %v", err)
+	}
+	return netns, nil
+}
+
+// originalLabels returns the labels to restore the ones of container changed
+// by the labels given, the label missing before is removed.
+func originalLabels(container *mgr.Container, labels []string) []string {
+	var original []string
+	for _, label := range labels {
+		k := strings.SplitN(label, "=", 2)[0]
+		original = append(original, k+"="+container.Config.Labels[k])
+	}
+	return original
+}
+
+// dockershimContainerLabels returns the labels to update the container created
+// by dockershim with the labels of cri manager, the label of dockershim is removed.
+func dockershimContainerLabels(container *mgr.Container) ([]string, error) {
+	var (
+		containerType string
+		name          string
+		attempt       uint32
+	)
+	switch container.Config.Labels[dockershimTypeLabelKey] {
+	case dockershimTypeLabelSandbox:
+		metadata, err := parseSandboxName(strings.TrimPrefix(container.Name, "/"))
+		if err != nil {
+			return nil, err
+		}
+		containerType, name, attempt = containerTypeLabelSandbox, metadata.Name, metadata.Attempt
+	case dockershimTypeLabelContainer:
+		metadata, err := parseContainerName(strings.TrimPrefix(container.Name, "/"))
+		if err != nil {
+			return nil, err
+		}
+		containerType, name, attempt = containerTypeLabelContainer, metadata.Name, metadata.Attempt
+	default:
+		return nil, fmt.Errorf("unknown dockershim type %q of container %q", container.Config.Labels[dockershimTypeLabelKey], container.ID)
+	}
+
+	return []string{
+		// the label with empty value is removed.
+		dockershimTypeLabelKey + "=",
+		containerTypeLabelKey + "=" + containerType,
+		metadataNameLabelKey + "=" + name,
+		metadataAttemptLabelKey + "=" + strconv.FormatUint(uint64(attempt), 10),
+	}, nil
+}
+
+// dockershimSandboxConfig synthesizes the CRI config of the sandbox created by dockershim.
+func dockershimSandboxConfig(sandbox *mgr.Container, checkpoint *dockershimCheckpoint) (*runtime.PodSandboxConfig, error) {
+	metadata, err := parseSandboxName(strings.TrimPrefix(sandbox.Name, "/"))
+	if err != nil {
+		return nil, err
+	}
+
+	labels, annotations := extractLabels(sandbox.Config.Labels)
+	delete(labels, dockershimTypeLabelKey)
+
+	namespaceMode := func(mode string) runtime.NamespaceMode {
+		if mode == namespaceModeHost {
+			return runtime.NamespaceMode_NODE
+		}
+		return runtime.NamespaceMode_POD
+	}
+	networkMode := namespaceMode(sandbox.HostConfig.NetworkMode)
+
+	var portMappings []*runtime.PortMapping
+	if checkpoint != nil {
+		if checkpoint.Data.HostNetwork {
+			networkMode = runtime.NamespaceMode_NODE
+		}
+		for _, pm := range checkpoint.Data.PortMappings {
+			protocol := runtime.Protocol_TCP
+			if p, ok := runtime.Protocol_value[strings.ToUpper(pm.Protocol)]; ok {
+				protocol = runtime.Protocol(p)
+			}
+			portMappings = append(portMappings, &runtime.PortMapping{
+				Protocol:      protocol,
+				ContainerPort: pm.ContainerPort,
+				HostPort:      pm.HostPort,
+				HostIp:        pm.HostIP,
+			})
+		}
+	}
+
+	return &runtime.PodSandboxConfig{
+		Metadata:     metadata,
+		Hostname:     string(sandbox.Config.Hostname),
+		PortMappings: portMappings,
+		Labels:       labels,
+		Annotations:  annotations,
+		Linux: &runtime.LinuxPodSandboxConfig{
+			CgroupParent: sandbox.HostConfig.CgroupParent,
+			SecurityContext: &runtime.LinuxSandboxSecurityContext{
+				NamespaceOptions: &runtime.NamespaceOption{
+					Network: networkMode,
+					Pid:     namespaceMode(sandbox.HostConfig.PidMode),
+					Ipc:     namespaceMode(sandbox.HostConfig.IpcMode),
+				},
+				Privileged: sandbox.HostConfig.Privileged,
+			},
+		},
+	}, nil
+}
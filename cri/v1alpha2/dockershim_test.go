@@ -0,0 +1,142 @@
+package v1alpha2
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	apitypes "github.com/alibaba/pouch/apis/types"
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+	"github.com/alibaba/pouch/daemon/mgr"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_dockershimContainerLabels(t *testing.T) {
+	sandbox := &mgr.Container{
+		ID:     "s1",
+		Name:   "k8s_POD_nginx_default_uid1_2",
+		Config: &apitypes.ContainerConfig{Labels: map[string]string{dockershimTypeLabelKey: dockershimTypeLabelSandbox}},
+	}
+	labels, err := dockershimContainerLabels(sandbox)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		dockershimTypeLabelKey + "=",
+		containerTypeLabelKey + "=" + containerTypeLabelSandbox,
+		metadataNameLabelKey + "=nginx",
+		metadataAttemptLabelKey + "=2",
+	}, labels)
+
+	container := &mgr.Container{
+		ID:     "c1",
+		Name:   "/k8s_app_nginx_default_uid1_0",
+		Config: &apitypes.ContainerConfig{Labels: map[string]string{dockershimTypeLabelKey: dockershimTypeLabelContainer}},
+	}
+	labels, err = dockershimContainerLabels(container)
+	assert.NoError(t, err)
+	assert.Equal(t, containerTypeLabelKey+"="+containerTypeLabelContainer, labels[1])
+	assert.Equal(t, metadataNameLabelKey+"=app", labels[2])
+
+	container.Config.Labels[dockershimTypeLabelKey] = "unknown"
+	_, err = dockershimContainerLabels(container)
+	assert.Error(t, err)
+}
+
+func Test_dockershimSandboxConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dockershim")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	defer func(old string) { dockershimCheckpointDir = old }(dockershimCheckpointDir)
+	dockershimCheckpointDir = dir
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "s1"), []byte(
+		`{"version":"v1","name":"nginx","namespace":"default","data":{"port_mappings":[{"protocol":"UDP","container_port":53,"host_port":1053}]},"checksum":1}`), 0644))
+
+	checkpoint, err := readDockershimCheckpoint("s1")
+	assert.NoError(t, err)
+	checkpointAbsent, err := readDockershimCheckpoint("s2")
+	assert.NoError(t, err)
+	assert.Nil(t, checkpointAbsent)
+
+	sandbox := &mgr.Container{
+		ID:   "s1",
+		Name: "k8s_POD_nginx_default_uid1_0",
+		Config: &apitypes.ContainerConfig{
+			Hostname: "nginx",
+			Labels: map[string]string{
+				dockershimTypeLabelKey:      dockershimTypeLabelSandbox,
+				"app":                       "nginx",
+				annotationPrefix + "anno.k": "v",
+			},
+		},
+		HostConfig: &apitypes.HostConfig{
+			CgroupParent: "/kubepods/poduid1",
+			PidMode:      namespaceModeHost,
+		},
+	}
+	config, err := dockershimSandboxConfig(sandbox, checkpoint)
+	assert.NoError(t, err)
+	assert.Equal(t, &runtime.PodSandboxConfig{
+		Metadata:     &runtime.PodSandboxMetadata{Name: "nginx", Namespace: "default", Uid: "uid1"},
+		Hostname:     "nginx",
+		PortMappings: []*runtime.PortMapping{{Protocol: runtime.Protocol_UDP, ContainerPort: 53, HostPort: 1053}},
+		Labels:       map[string]string{"app": "nginx"},
+		Annotations:  map[string]string{"anno.k": "v"},
+		Linux: &runtime.LinuxPodSandboxConfig{
+			CgroupParent: "/kubepods/poduid1",
+			SecurityContext: &runtime.LinuxSandboxSecurityContext{
+				NamespaceOptions: &runtime.NamespaceOption{
+					Network: runtime.NamespaceMode_POD,
+					Pid:     runtime.NamespaceMode_NODE,
+					Ipc:     runtime.NamespaceMode_POD,
+				},
+			},
+		},
+	}, config)
+}
+
+func TestImportDockershimSandboxRollback(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dockershim")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	defer func(old string) { dockershimCheckpointDir = old }(dockershimCheckpointDir)
+	dockershimCheckpointDir = dir
+
+	newContainer := func(id, name, typ string) *mgr.Container {
+		return &mgr.Container{
+			ID:         id,
+			Name:       name,
+			Config:     &apitypes.ContainerConfig{Labels: map[string]string{dockershimTypeLabelKey: typ, sandboxIDLabelKey: "s1"}},
+			HostConfig: &apitypes.HostConfig{},
+			State:      &apitypes.ContainerState{},
+		}
+	}
+	sandbox := newContainer("s1", "k8s_POD_nginx_default_uid1_0", dockershimTypeLabelSandbox)
+	c1 := newContainer("c1", "k8s_app_nginx_default_uid1_0", dockershimTypeLabelContainer)
+	c2 := newContainer("c2", "k8s_sidecar_nginx_default_uid1_0", dockershimTypeLabelContainer)
+	ctrMgr := &ephemeralContainerMgr{
+		containers: map[string]*mgr.Container{"s1": sandbox, "c1": c1, "c2": c2},
+		updateErrs: map[string]error{"c2": errors.New("disk full")},
+	}
+	store, err := newSandboxStore(dir)
+	assert.NoError(t, err)
+	c := &CriManager{ContainerMgr: ctrMgr, SandboxStore: store}
+
+	// the container relabeled is restored, so that it is found as the one of dockershim on retry.
+	_, err = c.importDockershimSandbox(context.Background(), sandbox, []*mgr.Container{c1, c2})
+	assert.Error(t, err)
+	assert.Equal(t, map[string]string{dockershimTypeLabelKey: dockershimTypeLabelContainer, sandboxIDLabelKey: "s1"}, c1.Config.Labels)
+	_, err = store.Get("s1")
+	assert.Error(t, err)
+
+	delete(ctrMgr.updateErrs, "c2")
+	_, err = c.importDockershimSandbox(context.Background(), sandbox, []*mgr.Container{c1, c2})
+	assert.NoError(t, err)
+	assert.Equal(t, containerTypeLabelContainer, c2.Config.Labels[containerTypeLabelKey])
+	assert.Equal(t, containerTypeLabelSandbox, sandbox.Config.Labels[containerTypeLabelKey])
+	_, err = store.Get("s1")
+	assert.NoError(t, err)
+}
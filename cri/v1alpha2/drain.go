@@ -0,0 +1,43 @@
+package v1alpha2
+
+import (
+	"sync/atomic"
+
+	"github.com/alibaba/pouch/pkg/log"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SetDraining turns on or off the drain mode. The maintenance workflows drain
+// the node at the runtime level, in which the new sandboxes and containers are
+// rejected while the existing ones could still be stopped, removed and inspected.
+func (c *CriManager) SetDraining(draining bool) {
+	var v int32
+	if draining {
+		v = 1
+	}
+	if atomic.SwapInt32(&c.draining, v) == v {
+		return
+	}
+
+	if draining {
+		log.With(nil).Infof("node is draining, new sandboxes and containers are rejected")
+	} else {
+		log.With(nil).Infof("node is not draining any more")
+	}
+}
+
+// Draining returns whether the node is draining.
+func (c *CriManager) Draining() bool {
+	return atomic.LoadInt32(&c.draining) != 0
+}
+
+// checkDraining returns the error with code Unavailable if the node is
+// draining, so that kubelet could tell it from the failures of the method.
+func (c *CriManager) checkDraining(method string) error {
+	if !c.Draining() {
+		return nil
+	}
+	return status.Errorf(codes.Unavailable, "node is draining, %s is rejected", method)
+}
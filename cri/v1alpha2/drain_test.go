@@ -0,0 +1,30 @@
+package v1alpha2
+
+import (
+	"context"
+	"testing"
+
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestDraining(t *testing.T) {
+	c := &CriManager{}
+	assert.False(t, c.Draining())
+	assert.NoError(t, c.checkDraining("RunPodSandbox"))
+
+	c.SetDraining(true)
+	assert.True(t, c.Draining())
+
+	_, err := c.RunPodSandbox(context.Background(), &runtime.RunPodSandboxRequest{})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	_, err = c.CreateContainer(context.Background(), &runtime.CreateContainerRequest{})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+
+	c.SetDraining(false)
+	assert.False(t, c.Draining())
+	assert.NoError(t, c.checkDraining("CreateContainer"))
+}
@@ -0,0 +1,51 @@
+package v1alpha2
+
+import (
+	"strings"
+
+	apitypes "github.com/alibaba/pouch/apis/types"
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+	criconfig "github.com/alibaba/pouch/cri/config"
+)
+
+// mergeMaps returns the map merged from the maps, the later ones take precedence.
+func mergeMaps(maps ...map[string]string) map[string]string {
+	result := make(map[string]string)
+	for _, m := range maps {
+		for k, v := range m {
+			result[k] = v
+		}
+	}
+	return result
+}
+
+// injectEnv appends the env of the injections matching the labels and
+// annotations of container or pod, the env set by the container and the
+// earlier injections are kept.
+func injectEnv(injections []criconfig.EnvInjection, createConfig *apitypes.ContainerCreateConfig, config *runtime.ContainerConfig, sandboxConfig *runtime.PodSandboxConfig) {
+	if len(injections) == 0 {
+		return
+	}
+
+	labels := mergeMaps(sandboxConfig.GetLabels(), config.GetLabels())
+	annotations := mergeMaps(sandboxConfig.GetAnnotations(), config.GetAnnotations())
+
+	keys := make(map[string]struct{}, len(createConfig.Env))
+	for _, env := range createConfig.Env {
+		keys[strings.SplitN(env, "=", 2)[0]] = struct{}{}
+	}
+
+	for _, injection := range injections {
+		if !injection.Match(labels, annotations) {
+			continue
+		}
+		for _, env := range injection.Env {
+			key := strings.SplitN(env, "=", 2)[0]
+			if _, ok := keys[key]; ok {
+				continue
+			}
+			keys[key] = struct{}{}
+			createConfig.Env = append(createConfig.Env, env)
+		}
+	}
+}
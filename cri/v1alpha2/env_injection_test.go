@@ -0,0 +1,41 @@
+package v1alpha2
+
+import (
+	"testing"
+
+	apitypes "github.com/alibaba/pouch/apis/types"
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+	criconfig "github.com/alibaba/pouch/cri/config"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_injectEnv(t *testing.T) {
+	injections := []criconfig.EnvInjection{
+		{
+			Env:    []string{"HTTP_PROXY=http://proxy:3128", "NO_PROXY=.svc,.cluster.local"},
+			Labels: map[string]string{"egress": "proxy"},
+		},
+		{
+			Env:         []string{"HTTP_PROXY=http://other:3128", "LANG=C.UTF-8"},
+			Annotations: map[string]string{"tenant": "a"},
+		},
+	}
+
+	// the labels of container override the ones of pod, and the env of
+	// container and the earlier injections are kept.
+	createConfig := &apitypes.ContainerCreateConfig{ContainerConfig: apitypes.ContainerConfig{Env: []string{"NO_PROXY=localhost"}}}
+	injectEnv(injections, createConfig,
+		&runtime.ContainerConfig{Labels: map[string]string{"egress": "proxy"}},
+		&runtime.PodSandboxConfig{Labels: map[string]string{"egress": "direct"}, Annotations: map[string]string{"tenant": "a"}})
+	assert.Equal(t, []string{"NO_PROXY=localhost", "HTTP_PROXY=http://proxy:3128", "LANG=C.UTF-8"}, createConfig.Env)
+
+	// nothing is injected into the containers not matching.
+	createConfig = &apitypes.ContainerCreateConfig{}
+	injectEnv(injections, createConfig, &runtime.ContainerConfig{}, &runtime.PodSandboxConfig{Labels: map[string]string{"egress": "direct"}})
+	assert.Empty(t, createConfig.Env)
+
+	// the injection without selectors matches all the containers.
+	injectEnv([]criconfig.EnvInjection{{Env: []string{"A=B"}}}, createConfig, &runtime.ContainerConfig{}, &runtime.PodSandboxConfig{})
+	assert.Equal(t, []string{"A=B"}, createConfig.Env)
+}
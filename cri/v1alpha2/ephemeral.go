@@ -0,0 +1,150 @@
+package v1alpha2
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+
+	apitypes "github.com/alibaba/pouch/apis/types"
+	anno "github.com/alibaba/pouch/cri/annotations"
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+	metatypes "github.com/alibaba/pouch/cri/v1alpha2/types"
+	"github.com/alibaba/pouch/pkg/log"
+
+	"golang.org/x/net/context"
+)
+
+// containerTypeLabelEphemeral is the type of the ephemeral containers, which
+// are not listed to kubelet so that they are not killed as unknown containers.
+const containerTypeLabelEphemeral = "ephemeral"
+
+// ephemeralContainerConfig returns the cri config of the ephemeral container.
+func ephemeralContainerConfig(r *metatypes.EphemeralContainerRequest) *runtime.ContainerConfig {
+	return &runtime.ContainerConfig{
+		Metadata:   &runtime.ContainerMetadata{Name: r.Name},
+		Image:      &runtime.ImageSpec{Image: r.Image},
+		Command:    r.Command,
+		Args:       r.Args,
+		WorkingDir: r.WorkingDir,
+		Stdin:      r.Stdin,
+		StdinOnce:  r.StdinOnce,
+		Tty:        r.Tty,
+	}
+}
+
+// ephemeralCreateConfig returns the create config of the ephemeral container,
+// which joins the pid namespace of the target container if specified.
+func (c *CriManager) ephemeralCreateConfig(r *metatypes.EphemeralContainerRequest, sandboxMeta *metatypes.SandboxMeta, targetID string) (*apitypes.ContainerCreateConfig, error) {
+	config, sandboxConfig := ephemeralContainerConfig(r), sandboxMeta.Config
+
+	labels := map[string]string{
+		containerTypeLabelKey:   containerTypeLabelEphemeral,
+		sandboxIDLabelKey:       sandboxMeta.ID,
+		metadataNameLabelKey:    r.Name,
+		metadataAttemptLabelKey: "0",
+	}
+	if sandboxConfig.GetLogDirectory() != "" {
+		labels[containerLogPathLabelKey] = filepath.Join(sandboxConfig.GetLogDirectory(), r.Name, "0.log")
+	}
+
+	// the runtime of VM-based sandbox places the container into the VM by the annotations.
+	specAnnotation := map[string]string{
+		anno.CRIOContainerType: anno.ContainerTypeContainer,
+		anno.ContainerType:     anno.ContainerTypeContainer,
+		anno.CRIOSandboxName:   sandboxMeta.ID,
+		anno.CRIOSandboxID:     sandboxMeta.ID,
+		anno.SandboxID:         sandboxMeta.ID,
+	}
+
+	createConfig := &apitypes.ContainerCreateConfig{
+		ContainerConfig: apitypes.ContainerConfig{
+			Entrypoint:     config.GetCommand(),
+			Cmd:            config.GetArgs(),
+			Env:            r.Env,
+			Image:          config.GetImage().GetImage(),
+			WorkingDir:     config.GetWorkingDir(),
+			Labels:         labels,
+			OpenStdin:      config.GetStdin(),
+			StdinOnce:      config.GetStdinOnce(),
+			Tty:            config.GetTty(),
+			SpecAnnotation: specAnnotation,
+		},
+		HostConfig:       &apitypes.HostConfig{},
+		NetworkingConfig: &apitypes.NetworkingConfig{},
+	}
+	if err := c.updateCreateConfig(createConfig, config, sandboxConfig, sandboxMeta); err != nil {
+		return nil, err
+	}
+	if targetID != "" {
+		createConfig.HostConfig.PidMode = fmt.Sprintf("container:%v", targetID)
+	}
+
+	sandboxRootDir := path.Join(c.SandboxBaseDir, sandboxMeta.ID)
+	createConfig.HostConfig.Binds = append(createConfig.HostConfig.Binds, generateContainerMounts(sandboxRootDir)...)
+	return createConfig, nil
+}
+
+// CreateEphemeralContainer creates and starts a short-lived container with its
+// own image in the namespaces of an existing sandbox, e.g. to debug the pod
+// with the tools missing in the images of the pod. The container is run by the
+// runtime of sandbox, so that it is placed into the VM of VM-based sandbox,
+// and is removed together with the sandbox.
+func (c *CriManager) CreateEphemeralContainer(ctx context.Context, r *metatypes.EphemeralContainerRequest) (_ *metatypes.EphemeralContainerResponse, retErr error) {
+	if r.Name == "" || r.Image == "" {
+		return nil, fmt.Errorf("name and image of ephemeral container should be specified")
+	}
+
+	sandbox, err := c.ContainerMgr.Get(ctx, r.PodSandboxID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sandbox %q: %v", r.PodSandboxID, err)
+	}
+	if sandbox.State == nil || !sandbox.State.Running {
+		return nil, fmt.Errorf("sandbox %q is not running", sandbox.ID)
+	}
+
+	res, err := c.SandboxStore.Get(sandbox.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get metadata of %q from SandboxStore: %v", sandbox.ID, err)
+	}
+	sandboxMeta := res.(*metatypes.SandboxMeta)
+
+	var targetID string
+	if r.TargetContainerID != "" {
+		if targetID, err = c.targetContainerID(ctx, sandbox.ID, r.TargetContainerID); err != nil {
+			return nil, err
+		}
+	}
+
+	createConfig, err := c.ephemeralCreateConfig(r, sandboxMeta, targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	containerName := makeContainerName(sandboxMeta.Config, ephemeralContainerConfig(r))
+	createResp, err := c.ContainerMgr.Create(ctx, containerName, createConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ephemeral container for sandbox %q: %v", sandbox.ID, err)
+	}
+	containerID := createResp.ID
+
+	defer func() {
+		if retErr != nil {
+			if err := c.ContainerMgr.Remove(ctx, containerID, &apitypes.ContainerRemoveOptions{Volumes: true, Force: true}); err != nil {
+				log.With(ctx).Errorf("failed to remove the ephemeral container %q: %v", containerID, err)
+			}
+		}
+	}()
+
+	if logPath := createConfig.Labels[containerLogPathLabelKey]; logPath != "" {
+		if err := c.ContainerMgr.AttachCRILog(ctx, containerID, logPath); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := c.ContainerMgr.Start(ctx, containerID, &apitypes.ContainerStartOptions{}); err != nil {
+		return nil, fmt.Errorf("failed to start ephemeral container %q: %v", containerID, err)
+	}
+	log.With(ctx).Infof("success to run ephemeral container %q in sandbox %q", containerID, sandbox.ID)
+
+	return &metatypes.EphemeralContainerResponse{ContainerID: containerID}, nil
+}
@@ -0,0 +1,153 @@
+package v1alpha2
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/alibaba/pouch/apis/opts"
+	apitypes "github.com/alibaba/pouch/apis/types"
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+	metatypes "github.com/alibaba/pouch/cri/v1alpha2/types"
+	"github.com/alibaba/pouch/daemon/mgr"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ephemeralContainerMgr records the containers created, started and removed.
+type ephemeralContainerMgr struct {
+	mgr.ContainerMgr
+	containers map[string]*mgr.Container
+	created    *apitypes.ContainerCreateConfig
+	startErr   error
+	updateErrs map[string]error
+	calls      []string
+}
+
+func (m *ephemeralContainerMgr) Get(ctx context.Context, name string) (*mgr.Container, error) {
+	if c, ok := m.containers[name]; ok {
+		return c, nil
+	}
+	return nil, fmt.Errorf("container %q not found", name)
+}
+
+func (m *ephemeralContainerMgr) Create(ctx context.Context, name string, config *apitypes.ContainerCreateConfig) (*apitypes.ContainerCreateResp, error) {
+	m.created = config
+	m.calls = append(m.calls, "create "+name)
+	return &apitypes.ContainerCreateResp{ID: "e1"}, nil
+}
+
+func (m *ephemeralContainerMgr) AttachCRILog(ctx context.Context, name, logPath string) error {
+	m.calls = append(m.calls, "log "+logPath)
+	return nil
+}
+
+func (m *ephemeralContainerMgr) Start(ctx context.Context, name string, options *apitypes.ContainerStartOptions) error {
+	m.calls = append(m.calls, "start "+name)
+	return m.startErr
+}
+
+func (m *ephemeralContainerMgr) Remove(ctx context.Context, name string, options *apitypes.ContainerRemoveOptions) error {
+	m.calls = append(m.calls, "remove "+name)
+	return nil
+}
+
+func (m *ephemeralContainerMgr) Upgrade(ctx context.Context, name string, config *apitypes.ContainerUpgradeConfig) error {
+	m.calls = append(m.calls, "upgrade "+name)
+	return nil
+}
+
+func (m *ephemeralContainerMgr) Update(ctx context.Context, name string, config *apitypes.UpdateConfig) error {
+	m.calls = append(m.calls, "update "+name)
+	if err := m.updateErrs[name]; err != nil {
+		return err
+	}
+	c, ok := m.containers[name]
+	if !ok {
+		return fmt.Errorf("container %q not found", name)
+	}
+	for k, v := range opts.ParseLabels(config.Label) {
+		if v == "" {
+			delete(c.Config.Labels, k)
+		} else {
+			c.Config.Labels[k] = v
+		}
+	}
+	return nil
+}
+
+func TestCreateEphemeralContainer(t *testing.T) {
+	homeDir, err := ioutil.TempDir("", "ephemeral")
+	assert.NoError(t, err)
+	defer os.RemoveAll(homeDir)
+
+	store, err := newSandboxStore(homeDir)
+	assert.NoError(t, err)
+	defer store.Shutdown()
+	assert.NoError(t, store.Put(&metatypes.SandboxMeta{
+		ID:      "s1",
+		Runtime: "kata",
+		Config: &runtime.PodSandboxConfig{
+			Metadata:     &runtime.PodSandboxMetadata{Name: "pod", Namespace: "default", Uid: "uid"},
+			LogDirectory: "/var/log/pods/uid",
+			Linux:        &runtime.LinuxPodSandboxConfig{CgroupParent: "/kubepods/pod1"},
+		},
+	}))
+
+	running := &apitypes.ContainerState{Running: true}
+	containerMgr := &ephemeralContainerMgr{containers: map[string]*mgr.Container{
+		"s1": {ID: "s1", State: running},
+		"s2": {ID: "s2", State: &apitypes.ContainerState{}},
+		"c1": {ID: "c1", State: running, Config: &apitypes.ContainerConfig{Labels: map[string]string{sandboxIDLabelKey: "s1"}}},
+		"c2": {ID: "c2", State: running, Config: &apitypes.ContainerConfig{Labels: map[string]string{sandboxIDLabelKey: "s2"}}},
+	}}
+	c := &CriManager{ContainerMgr: containerMgr, SandboxStore: store, SandboxBaseDir: "/var/lib/pouch-cri/sandboxes"}
+	ctx := context.Background()
+
+	_, err = c.CreateEphemeralContainer(ctx, &metatypes.EphemeralContainerRequest{PodSandboxID: "s1", Name: "debugger"})
+	assert.Error(t, err)
+	_, err = c.CreateEphemeralContainer(ctx, &metatypes.EphemeralContainerRequest{PodSandboxID: "s2", Name: "debugger", Image: "busybox"})
+	assert.Error(t, err)
+	// the target container should belong to the sandbox.
+	_, err = c.CreateEphemeralContainer(ctx, &metatypes.EphemeralContainerRequest{PodSandboxID: "s1", Name: "debugger", Image: "busybox", TargetContainerID: "c2"})
+	assert.Error(t, err)
+
+	resp, err := c.CreateEphemeralContainer(ctx, &metatypes.EphemeralContainerRequest{
+		PodSandboxID:      "s1",
+		Name:              "debugger",
+		Image:             "busybox",
+		TargetContainerID: "c1",
+		Command:           []string{"sh"},
+		Stdin:             true,
+		Tty:               true,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "e1", resp.ContainerID)
+	assert.Equal(t, []string{
+		"create k8s_debugger_pod_default_uid_0",
+		"log /var/log/pods/uid/debugger/0.log",
+		"start e1",
+	}, containerMgr.calls)
+
+	created := containerMgr.created
+	assert.Equal(t, containerTypeLabelEphemeral, created.Labels[containerTypeLabelKey])
+	assert.Equal(t, "s1", created.Labels[sandboxIDLabelKey])
+	assert.Equal(t, []string{"sh"}, created.Entrypoint)
+	assert.True(t, created.OpenStdin)
+	assert.True(t, created.Tty)
+	assert.Equal(t, "kata", created.HostConfig.Runtime)
+	assert.Equal(t, "/kubepods/pod1", created.HostConfig.CgroupParent)
+	assert.Equal(t, "container:s1", created.HostConfig.NetworkMode)
+	assert.Equal(t, "container:s1", created.HostConfig.IpcMode)
+	assert.Equal(t, "container:c1", created.HostConfig.PidMode)
+	assert.Contains(t, created.HostConfig.Binds, "/var/lib/pouch-cri/sandboxes/s1/resolv.conf:/etc/resolv.conf")
+
+	// the container is removed if it fails to start.
+	containerMgr.calls, containerMgr.startErr = nil, fmt.Errorf("start failed")
+	_, err = c.CreateEphemeralContainer(ctx, &metatypes.EphemeralContainerRequest{PodSandboxID: "s1", Name: "debugger", Image: "busybox"})
+	assert.Error(t, err)
+	assert.Equal(t, "container:s1", containerMgr.created.HostConfig.PidMode)
+	assert.Equal(t, "remove e1", containerMgr.calls[len(containerMgr.calls)-1])
+}
@@ -0,0 +1,142 @@
+package v1alpha2
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/alibaba/pouch/apis/filters"
+	apitypes "github.com/alibaba/pouch/apis/types"
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+	"github.com/alibaba/pouch/daemon/events"
+	"github.com/alibaba/pouch/pkg/log"
+
+	"google.golang.org/grpc"
+)
+
+const (
+	imageField         = "Image"
+	containerNameField = "ContainerName"
+)
+
+// criEventMethods are the cri methods published as the events once they
+// succeed, the read-only ones, e.g. the status and list methods, and the
+// frequent probes, e.g. ExecSync, are not.
+var criEventMethods = map[string]bool{
+	"RunPodSandbox":            true,
+	"StartPodSandbox":          true,
+	"StopPodSandbox":           true,
+	"RemovePodSandbox":         true,
+	"CreateContainer":          true,
+	"StartContainer":           true,
+	"StopContainer":            true,
+	"RemoveContainer":          true,
+	"UpdateContainerResources": true,
+	"PullImage":                true,
+	"RemoveImage":              true,
+}
+
+type imageSpecGetter interface {
+	GetImage() *runtime.ImageSpec
+}
+
+// watchEvents calls handle on each event of the type until ctx is done, the
+// subscription is retried if it fails. Since the events may be missed before
+// subscribing, reset is called after every subscription.
+func watchEvents(ctx context.Context, eventsService *events.Events, eventType apitypes.EventType, reset func(), handle func(*apitypes.EventsMessage)) {
+	filter := events.NewFilter(filters.NewArgs(filters.Arg("type", string(eventType))))
+	for {
+		_, evch, errch := eventsService.Subscribe(ctx, time.Time{}, time.Time{}, filter)
+		reset()
+
+	loop:
+		for {
+			select {
+			case ev := <-evch:
+				handle(ev)
+			case err := <-errch:
+				if err != nil {
+					log.With(nil).Warnf("failed to receive %s events, resubscribe: %v", eventType, err)
+				}
+				break loop
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// eventsUnaryServerInterceptor publishes the succeeded cri requests as the
+// events with the pod metadata, so that the activities driven by kubelet are
+// observed by `pouch events` like the ones of the pouch api.
+func eventsUnaryServerInterceptor(criMgr CriMgr) grpc.UnaryServerInterceptor {
+	c, _ := criMgr.(*CriManager)
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		method := path.Base(info.FullMethod)
+		if c == nil || c.eventsService == nil || !criEventMethods[method] {
+			return handler(ctx, req)
+		}
+
+		// the pod metadata is gone once the sandbox is removed, so it's got
+		// before the request is handled.
+		fields := c.contextRequestFields(ctx, req)
+		resp, err := handler(ctx, req)
+		if err == nil {
+			c.publishEvent(ctx, method, req, resp, fields)
+		}
+		return resp, err
+	}
+}
+
+// publishEvent publishes the event of the cri method, the actor is the
+// sandbox, container or image the method acts on.
+func (c *CriManager) publishEvent(ctx context.Context, method string, req, resp interface{}, fields map[string]interface{}) {
+	attributes := requestAttributes(req, fields)
+
+	var id string
+	switch r := resp.(type) {
+	case *runtime.RunPodSandboxResponse:
+		id = r.GetPodSandboxId()
+		attributes[podSandboxIDField] = id
+	case *runtime.CreateContainerResponse:
+		id = r.GetContainerId()
+		attributes[containerIDField] = id
+	case *runtime.PullImageResponse:
+		id = r.GetImageRef()
+	default:
+		if id = attributes[containerIDField]; id == "" {
+			if id = attributes[podSandboxIDField]; id == "" {
+				id = attributes[imageField]
+			}
+		}
+	}
+
+	_ = c.eventsService.Publish(ctx, method, apitypes.EventTypeCri, &apitypes.EventsActor{
+		ID:         id,
+		Attributes: attributes,
+	})
+}
+
+// requestAttributes returns the summary of the cri request, which are the
+// fields of pod and container, and the image and the container name if any.
+func requestAttributes(req interface{}, fields map[string]interface{}) map[string]string {
+	attributes := make(map[string]string, len(fields)+2)
+	for k, v := range fields {
+		attributes[k] = fmt.Sprint(v)
+	}
+	if r, ok := req.(imageSpecGetter); ok && r.GetImage().GetImage() != "" {
+		attributes[imageField] = r.GetImage().GetImage()
+	}
+	if r, ok := req.(*runtime.CreateContainerRequest); ok {
+		attributes[containerNameField] = r.GetConfig().GetMetadata().GetName()
+		if image := r.GetConfig().GetImage().GetImage(); image != "" {
+			attributes[imageField] = image
+		}
+	}
+	return attributes
+}
@@ -0,0 +1,115 @@
+package v1alpha2
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/alibaba/pouch/apis/filters"
+	apitypes "github.com/alibaba/pouch/apis/types"
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+	metatypes "github.com/alibaba/pouch/cri/v1alpha2/types"
+	"github.com/alibaba/pouch/daemon/events"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+func TestEventsUnaryServerInterceptor(t *testing.T) {
+	homeDir, err := ioutil.TempDir("", "cri-events")
+	assert.NoError(t, err)
+	defer os.RemoveAll(homeDir)
+
+	store, err := newSandboxStore(homeDir)
+	assert.NoError(t, err)
+	defer store.Shutdown()
+
+	metadata := &runtime.PodSandboxMetadata{Name: "nginx", Namespace: "default", Uid: "uid1"}
+	assert.NoError(t, store.Put(&metatypes.SandboxMeta{
+		ID:     "sandbox1",
+		Config: &runtime.PodSandboxConfig{Metadata: metadata},
+	}))
+
+	eventsService := events.NewEvents()
+	c := &CriManager{SandboxStore: store, eventsService: eventsService}
+	interceptor := eventsUnaryServerInterceptor(c)
+
+	start := time.Now()
+	for _, tc := range []struct {
+		method string
+		req    interface{}
+		resp   interface{}
+		err    error
+	}{
+		{
+			method: "RunPodSandbox",
+			req:    &runtime.RunPodSandboxRequest{Config: &runtime.PodSandboxConfig{Metadata: metadata}},
+			resp:   &runtime.RunPodSandboxResponse{PodSandboxId: "sandbox1"},
+		},
+		{
+			method: "CreateContainer",
+			req: &runtime.CreateContainerRequest{
+				PodSandboxId:  "sandbox1",
+				Config:        &runtime.ContainerConfig{Metadata: &runtime.ContainerMetadata{Name: "app"}},
+				SandboxConfig: &runtime.PodSandboxConfig{Metadata: metadata},
+			},
+			resp: &runtime.CreateContainerResponse{ContainerId: "container1"},
+		},
+		{
+			method: "StopPodSandbox",
+			req:    &runtime.StopPodSandboxRequest{PodSandboxId: "sandbox1"},
+			resp:   &runtime.StopPodSandboxResponse{},
+		},
+		{
+			method: "PullImage",
+			req:    &runtime.PullImageRequest{Image: &runtime.ImageSpec{Image: "busybox:latest"}},
+			resp:   &runtime.PullImageResponse{ImageRef: "sha256:abc"},
+		},
+		{
+			method: "RemoveImage",
+			req:    &runtime.RemoveImageRequest{Image: &runtime.ImageSpec{Image: "busybox:latest"}},
+			err:    errors.New("failed"),
+		},
+		{
+			method: "PodSandboxStatus",
+			req:    &runtime.PodSandboxStatusRequest{PodSandboxId: "sandbox1"},
+			resp:   &runtime.PodSandboxStatusResponse{},
+		},
+	} {
+		info := &grpc.UnaryServerInfo{FullMethod: "/runtime.v1alpha2.RuntimeService/" + tc.method}
+		_, err := interceptor(context.Background(), tc.req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return tc.resp, tc.err
+		})
+		assert.Equal(t, tc.err, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	filter := events.NewFilter(filters.NewArgs(filters.Arg("type", string(apitypes.EventTypeCri))))
+	msgs, _, _ := eventsService.Subscribe(ctx, start, time.Now(), filter)
+
+	var got []string
+	for _, msg := range msgs {
+		got = append(got, msg.Action+" "+msg.Actor.ID)
+	}
+	// the failed and read-only requests are not published.
+	assert.Equal(t, []string{
+		"RunPodSandbox sandbox1",
+		"CreateContainer container1",
+		"StopPodSandbox sandbox1",
+		"PullImage sha256:abc",
+	}, got)
+
+	assert.Equal(t, map[string]string{
+		podSandboxIDField:  "sandbox1",
+		containerIDField:   "container1",
+		containerNameField: "app",
+		podNameField:       "nginx",
+		podNamespaceField:  "default",
+		podUIDField:        "uid1",
+	}, msgs[1].Actor.Attributes)
+	assert.Equal(t, "busybox:latest", msgs[3].Actor.Attributes[imageField])
+}
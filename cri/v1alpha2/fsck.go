@@ -0,0 +1,161 @@
+package v1alpha2
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"time"
+
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+	metatypes "github.com/alibaba/pouch/cri/v1alpha2/types"
+	"github.com/alibaba/pouch/daemon/mgr"
+	"github.com/alibaba/pouch/pkg/log"
+)
+
+// fsckGracePeriod is the age under which the network namespaces and directories are
+// not treated as orphans, since they may belong to a sandbox being created.
+var fsckGracePeriod = time.Minute
+
+// Fsck cross-checks the sandbox meta, the sandbox containers, the network namespaces
+// and the sandbox directories, and repairs the inconsistencies if repair is true.
+// Repairing is expected to be done when no sandbox is being created or removed.
+func (c *CriManager) Fsck(ctx context.Context, repair bool) (*metatypes.FsckReport, error) {
+	metas, err := c.SandboxStore.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sandbox from SandboxStore: %v", err)
+	}
+
+	containers, err := c.ContainerMgr.List(ctx, &mgr.ContainerListOption{
+		All:    true,
+		Labels: map[string]string{containerTypeLabelKey: containerTypeLabelSandbox},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sandbox containers: %v", err)
+	}
+	sandboxes := make(map[string]*mgr.Container, len(containers))
+	for _, container := range containers {
+		sandboxes[container.ID] = container
+	}
+
+	tasks, err := c.NetworkTeardownStore.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending network teardowns: %v", err)
+	}
+
+	report := &metatypes.FsckReport{Issues: []*metatypes.FsckIssue{}}
+	addIssue := func(issue *metatypes.FsckIssue, repairFunc func() error) {
+		report.Issues = append(report.Issues, issue)
+		if !repair || repairFunc == nil {
+			return
+		}
+		if err := repairFunc(); err != nil {
+			issue.RepairError = err.Error()
+			log.With(ctx).Warnf("failed to repair %s %s%s: %v", issue.Kind, issue.ID, issue.Path, err)
+			return
+		}
+		issue.Repaired = true
+	}
+
+	referencedNetNS := make(map[string]bool)
+	for _, obj := range tasks {
+		referencedNetNS[obj.(*metatypes.NetworkTeardownTask).NetNS] = true
+	}
+
+	for id, obj := range metas {
+		sandboxMeta := obj.(*metatypes.SandboxMeta)
+		if sandboxMeta.NetNS != "" {
+			referencedNetNS[sandboxMeta.NetNS] = true
+		}
+
+		// the sandbox not ready may be still being created by RunPodSandbox,
+		// the partially created ones are cleaned up on the start of pouchd.
+		if sandboxMeta.Phase != metatypes.SandboxPhaseReady {
+			continue
+		}
+
+		// the sandbox held by the holder has no container.
+		if _, ok := sandboxes[id]; !ok && sandboxMeta.Holder == nil {
+			addIssue(&metatypes.FsckIssue{Kind: metatypes.FsckDanglingMeta, ID: id}, func() error {
+				return c.removeBrokenSandbox(ctx, id)
+			})
+			continue
+		}
+
+		// the network namespace is recreated when the sandbox is started again.
+		if sandboxMeta.NetNS != "" {
+			if _, err := os.Stat(sandboxMeta.NetNS); os.IsNotExist(err) {
+				addIssue(&metatypes.FsckIssue{Kind: metatypes.FsckDanglingNetNS, ID: id, Path: sandboxMeta.NetNS}, nil)
+			}
+		}
+	}
+
+	for id := range sandboxes {
+		if _, ok := metas[id]; !ok {
+			addIssue(&metatypes.FsckIssue{Kind: metatypes.FsckOrphanContainer, ID: id}, func() error {
+				return c.removeBrokenSandbox(ctx, id)
+			})
+		}
+	}
+
+	netnsPaths, err := c.CniMgr.ListNetNS()
+	if err != nil {
+		return nil, err
+	}
+	for _, netnsPath := range netnsPaths {
+		if referencedNetNS[netnsPath] || isRecent(netnsPath) {
+			continue
+		}
+		netnsPath := netnsPath
+		addIssue(&metatypes.FsckIssue{Kind: metatypes.FsckOrphanNetNS, Path: netnsPath}, func() error {
+			if err := c.CniMgr.CloseNetNS(netnsPath); err != nil {
+				return err
+			}
+			return c.CniMgr.RemoveNetNS(netnsPath)
+		})
+	}
+
+	dirs, err := ioutil.ReadDir(c.SandboxBaseDir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read sandbox base dir %s: %v", c.SandboxBaseDir, err)
+	}
+	for _, dir := range dirs {
+		if _, ok := metas[dir.Name()]; ok {
+			continue
+		}
+		if _, ok := sandboxes[dir.Name()]; ok {
+			continue
+		}
+		dirPath := path.Join(c.SandboxBaseDir, dir.Name())
+		if isRecent(dirPath) {
+			continue
+		}
+		addIssue(&metatypes.FsckIssue{Kind: metatypes.FsckOrphanDir, ID: dir.Name(), Path: dirPath}, func() error {
+			return os.RemoveAll(dirPath)
+		})
+	}
+
+	return report, nil
+}
+
+// removeBrokenSandbox stops and removes the sandbox whose meta or container is lost,
+// in the same way as kubelet does.
+func (c *CriManager) removeBrokenSandbox(ctx context.Context, id string) error {
+	if _, err := c.SandboxStore.Get(id); err == nil {
+		if _, err := c.StopPodSandbox(ctx, &runtime.StopPodSandboxRequest{PodSandboxId: id}); err != nil {
+			log.With(ctx).Warnf("failed to stop broken sandbox %q: %v", id, err)
+		}
+	}
+	_, err := c.RemovePodSandbox(ctx, &runtime.RemovePodSandboxRequest{PodSandboxId: id})
+	return err
+}
+
+// isRecent returns true if the file is modified within the fsck grace period.
+func isRecent(file string) bool {
+	info, err := os.Stat(file)
+	if err != nil {
+		return false
+	}
+	return time.Since(info.ModTime()) < fsckGracePeriod
+}
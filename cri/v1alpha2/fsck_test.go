@@ -0,0 +1,53 @@
+package v1alpha2
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	cni "github.com/alibaba/pouch/cri/ocicni"
+	metatypes "github.com/alibaba/pouch/cri/v1alpha2/types"
+	"github.com/alibaba/pouch/daemon/mgr"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fsckCniMgr has no network namespaces.
+type fsckCniMgr struct {
+	cni.CniMgr
+}
+
+func (f *fsckCniMgr) ListNetNS() ([]string, error) {
+	return nil, nil
+}
+
+func TestFsckSkipsSandboxesNotReady(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fsck")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	sandboxStore, err := newSandboxStore(dir)
+	assert.NoError(t, err)
+	defer sandboxStore.Shutdown()
+	teardownStore, err := newNetworkTeardownStore(dir)
+	assert.NoError(t, err)
+	defer teardownStore.Shutdown()
+
+	assert.NoError(t, sandboxStore.Put(&metatypes.SandboxMeta{ID: "creating", Phase: metatypes.SandboxPhaseCreating}))
+	assert.NoError(t, sandboxStore.Put(&metatypes.SandboxMeta{ID: "dangling", Phase: metatypes.SandboxPhaseReady}))
+
+	c := &CriManager{
+		ContainerMgr:         &diagnosticsContainerLister{containers: []*mgr.Container{}},
+		CniMgr:               &fsckCniMgr{},
+		SandboxStore:         sandboxStore,
+		NetworkTeardownStore: teardownStore,
+		SandboxBaseDir:       filepath.Join(dir, "sandboxes"),
+	}
+
+	// the sandbox being created has no container yet.
+	report, err := c.Fsck(context.Background(), false)
+	assert.NoError(t, err)
+	assert.Equal(t, []*metatypes.FsckIssue{{Kind: metatypes.FsckDanglingMeta, ID: "dangling"}}, report.Issues)
+}
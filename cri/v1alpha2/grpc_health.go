@@ -0,0 +1,40 @@
+package v1alpha2
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// healthServer reports the serving status of the cri services. The services
+// are not serving while pouchd is shutting down or the node is draining, and
+// the runtime service is also not serving until the network is ready.
+type healthServer struct {
+	criMgr CriMgr
+}
+
+// Check implements healthpb.HealthServer, the empty service is the overall
+// status of the cri services.
+func (s *healthServer) Check(ctx context.Context, r *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	service := r.GetService()
+	if service != "" && service != criRuntimeServiceName && service != criImageServiceName {
+		return nil, status.Errorf(codes.NotFound, "unknown service %q", service)
+	}
+
+	serving := &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}
+	notServing := &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_NOT_SERVING}
+
+	c, ok := s.criMgr.(*CriManager)
+	if !ok {
+		return serving, nil
+	}
+	if c.requests.closing() || c.Draining() {
+		return notServing, nil
+	}
+	if service != criImageServiceName && c.CniMgr != nil && c.CniMgr.Status() != nil {
+		return notServing, nil
+	}
+	return serving, nil
+}
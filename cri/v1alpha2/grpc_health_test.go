@@ -0,0 +1,56 @@
+package v1alpha2
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	cni "github.com/alibaba/pouch/cri/ocicni"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// fakeStatusCniMgr reports the status of network by the error.
+type fakeStatusCniMgr struct {
+	cni.CniMgr
+	err error
+}
+
+func (f *fakeStatusCniMgr) Status() error {
+	return f.err
+}
+
+func TestHealthServer(t *testing.T) {
+	cniMgr := &fakeStatusCniMgr{}
+	c := &CriManager{CniMgr: cniMgr}
+	s := &healthServer{criMgr: c}
+	check := func(service string) healthpb.HealthCheckResponse_ServingStatus {
+		resp, err := s.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		assert.NoError(t, err)
+		return resp.GetStatus()
+	}
+
+	for _, service := range []string{"", criRuntimeServiceName, criImageServiceName} {
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, check(service), service)
+	}
+	_, err := s.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "unknown"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// the image service is still serving while the network is not ready.
+	cniMgr.err = errors.New("missing CNI default network")
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, check(""))
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, check(criRuntimeServiceName))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, check(criImageServiceName))
+	cniMgr.err = nil
+
+	c.SetDraining(true)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, check(criImageServiceName))
+	c.SetDraining(false)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, check(""))
+
+	c.requests.close(0)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, check(""))
+}
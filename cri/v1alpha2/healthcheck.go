@@ -0,0 +1,286 @@
+package v1alpha2
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	anno "github.com/alibaba/pouch/cri/annotations"
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+	"github.com/alibaba/pouch/daemon/mgr"
+	"github.com/alibaba/pouch/pkg/log"
+
+	"golang.org/x/net/context"
+)
+
+const (
+	defaultHealthCheckInterval = 30 * time.Second
+	defaultHealthCheckTimeout  = 30 * time.Second
+	defaultHealthCheckRetries  = 3
+
+	// healthStarting means no health check has succeeded or failed enough times.
+	healthStarting = "starting"
+	// healthHealthy means the last health check succeeded.
+	healthHealthy = "healthy"
+	// healthUnhealthy means the health check failed for retries times consecutively.
+	healthUnhealthy = "unhealthy"
+
+	// healthCheckOutputLimit is the max length of the output of health check kept.
+	healthCheckOutputLimit = 4096
+)
+
+// healthCheckConfig is the health check of container configured in its annotations.
+type healthCheckConfig struct {
+	cmd              []string
+	interval         time.Duration
+	timeout          time.Duration
+	retries          int
+	restartUnhealthy bool
+}
+
+// healthState is the health state of container reported in the verbose info of ContainerStatus.
+type healthState struct {
+	Status        string    `json:"status"`
+	FailingStreak int       `json:"failingStreak"`
+	LastExitCode  int32     `json:"lastExitCode"`
+	LastOutput    string    `json:"lastOutput,omitempty"`
+	LastCheckAt   time.Time `json:"lastCheckAt,omitempty"`
+	Restarts      int       `json:"restarts"`
+}
+
+// parseHealthCheckConfig parses the health check from the annotations of container,
+// it returns nil if no health check is configured.
+func parseHealthCheckConfig(annotations map[string]string) (*healthCheckConfig, error) {
+	command, ok := annotations[anno.HealthCheckExecAnnotation]
+	if !ok || command == "" {
+		return nil, nil
+	}
+
+	cfg := &healthCheckConfig{
+		cmd:      []string{"/bin/sh", "-c", command},
+		interval: defaultHealthCheckInterval,
+		timeout:  defaultHealthCheckTimeout,
+		retries:  defaultHealthCheckRetries,
+	}
+
+	var err error
+	if v, ok := annotations[anno.HealthCheckIntervalAnnotation]; ok {
+		if cfg.interval, err = time.ParseDuration(v); err != nil || cfg.interval <= 0 {
+			return nil, fmt.Errorf("invalid health check interval %q", v)
+		}
+	}
+	if v, ok := annotations[anno.HealthCheckTimeoutAnnotation]; ok {
+		if cfg.timeout, err = time.ParseDuration(v); err != nil || cfg.timeout < time.Second {
+			return nil, fmt.Errorf("invalid health check timeout %q, should be at least 1s", v)
+		}
+	}
+	if v, ok := annotations[anno.HealthCheckRetriesAnnotation]; ok {
+		if cfg.retries, err = strconv.Atoi(v); err != nil || cfg.retries <= 0 {
+			return nil, fmt.Errorf("invalid health check retries %q", v)
+		}
+	}
+	if v, ok := annotations[anno.HealthCheckRestartAnnotation]; ok {
+		if cfg.restartUnhealthy, err = strconv.ParseBool(v); err != nil {
+			return nil, fmt.Errorf("invalid health check restart-unhealthy %q", v)
+		}
+	}
+	return cfg, nil
+}
+
+// healthProbe runs the health check of a container periodically.
+type healthProbe struct {
+	sync.Mutex
+	config *healthCheckConfig
+	state  healthState
+	stopCh chan struct{}
+}
+
+// record updates the health state with the result of a health check, and
+// returns whether the container should be restarted.
+func (p *healthProbe) record(exitCode int32, output string, err error) bool {
+	p.Lock()
+	defer p.Unlock()
+
+	p.state.LastCheckAt = time.Now()
+	p.state.LastExitCode = exitCode
+	if err != nil {
+		output = err.Error()
+	}
+	if len(output) > healthCheckOutputLimit {
+		output = output[:healthCheckOutputLimit]
+	}
+	p.state.LastOutput = output
+
+	if err == nil && exitCode == 0 {
+		p.state.Status = healthHealthy
+		p.state.FailingStreak = 0
+		return false
+	}
+
+	p.state.FailingStreak++
+	if p.state.FailingStreak < p.config.retries {
+		return false
+	}
+	p.state.Status = healthUnhealthy
+	if !p.config.restartUnhealthy {
+		return false
+	}
+
+	p.state.Restarts++
+	p.state.Status = healthStarting
+	p.state.FailingStreak = 0
+	return true
+}
+
+// healthChecker manages the health probes of containers.
+type healthChecker struct {
+	sync.Mutex
+	probes map[string]*healthProbe
+
+	// exec runs the command in the container and returns its exit code and output.
+	exec func(ctx context.Context, id string, cmd []string, timeout time.Duration) (int32, string, error)
+	// restart restarts the unhealthy container.
+	restart func(ctx context.Context, id string) error
+	// running returns whether the container is running.
+	running func(ctx context.Context, id string) bool
+}
+
+// newHealthChecker creates the health checker running the probes by the cri manager.
+func newHealthChecker(c *CriManager) *healthChecker {
+	return &healthChecker{
+		probes: make(map[string]*healthProbe),
+		exec: func(ctx context.Context, id string, cmd []string, timeout time.Duration) (int32, string, error) {
+			resp, err := c.ExecSync(ctx, &runtime.ExecSyncRequest{
+				ContainerId: id,
+				Cmd:         cmd,
+				Timeout:     int64(timeout / time.Second),
+			})
+			if err != nil {
+				return -1, "", err
+			}
+			return resp.ExitCode, string(resp.Stdout) + string(resp.Stderr), nil
+		},
+		restart: func(ctx context.Context, id string) error {
+			return c.ContainerMgr.Restart(ctx, id, 0)
+		},
+		running: func(ctx context.Context, id string) bool {
+			container, err := c.ContainerMgr.Get(ctx, id)
+			return err == nil && container.IsRunning()
+		},
+	}
+}
+
+// start starts the probe of container, the existing one is replaced.
+func (h *healthChecker) start(id string, config *healthCheckConfig) {
+	p := &healthProbe{
+		config: config,
+		state:  healthState{Status: healthStarting},
+		stopCh: make(chan struct{}),
+	}
+
+	h.Lock()
+	if old, ok := h.probes[id]; ok {
+		close(old.stopCh)
+	}
+	h.probes[id] = p
+	h.Unlock()
+
+	go h.run(id, p)
+}
+
+// stop stops the probe of container if any.
+func (h *healthChecker) stop(id string) {
+	if h == nil {
+		return
+	}
+
+	h.Lock()
+	defer h.Unlock()
+	if p, ok := h.probes[id]; ok {
+		close(p.stopCh)
+		delete(h.probes, id)
+	}
+}
+
+// state returns the health state of container, nil if no health check is configured.
+func (h *healthChecker) state(id string) *healthState {
+	if h == nil {
+		return nil
+	}
+
+	h.Lock()
+	p, ok := h.probes[id]
+	h.Unlock()
+	if !ok {
+		return nil
+	}
+
+	p.Lock()
+	defer p.Unlock()
+	state := p.state
+	return &state
+}
+
+// run checks the health of container every interval until the probe is stopped.
+func (h *healthChecker) run(id string, p *healthProbe) {
+	ticker := time.NewTicker(p.config.interval)
+	defer ticker.Stop()
+
+	ctx := log.NewContext(context.Background(), map[string]interface{}{"ContainerID": id})
+	for {
+		select {
+		case <-p.stopCh:
+			return
+		case <-ticker.C:
+		}
+
+		// the stopped container is not checked until it's started again.
+		if !h.running(ctx, id) {
+			continue
+		}
+
+		exitCode, output, err := h.exec(ctx, id, p.config.cmd, p.config.timeout)
+		if !p.record(exitCode, output, err) {
+			continue
+		}
+
+		log.With(ctx).Warnf("restart unhealthy container after %d failed health checks", p.config.retries)
+		if err := h.restart(ctx, id); err != nil {
+			log.With(ctx).Errorf("failed to restart unhealthy container: %v", err)
+		}
+	}
+}
+
+// startHealthCheck starts the health check of container configured in its annotations.
+func (c *CriManager) startHealthCheck(ctx context.Context, container *mgr.Container) {
+	_, annotations := extractLabels(container.Config.Labels)
+	config, err := parseHealthCheckConfig(annotations)
+	if err != nil {
+		log.With(ctx).Warnf("failed to parse health check of container %q: %v", container.ID, err)
+		return
+	}
+	if config == nil {
+		return
+	}
+	c.healthChecker.start(container.ID, config)
+}
+
+// restoreHealthChecks starts the health checks of the running containers after pouchd restarts.
+func (c *CriManager) restoreHealthChecks(ctx context.Context) error {
+	containers, err := c.ContainerMgr.List(ctx, &mgr.ContainerListOption{
+		All:    true,
+		Labels: map[string]string{containerTypeLabelKey: containerTypeLabelContainer},
+		FilterFunc: func(c *mgr.Container) bool {
+			return c.IsRunning()
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, container := range containers {
+		c.startHealthCheck(ctx, container)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package v1alpha2
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	anno "github.com/alibaba/pouch/cri/annotations"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+func TestParseHealthCheckConfig(t *testing.T) {
+	cfg, err := parseHealthCheckConfig(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, cfg)
+
+	cfg, err = parseHealthCheckConfig(map[string]string{
+		anno.HealthCheckExecAnnotation:     "cat /tmp/healthy",
+		anno.HealthCheckIntervalAnnotation: "5s",
+		anno.HealthCheckRetriesAnnotation:  "2",
+		anno.HealthCheckRestartAnnotation:  "true",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &healthCheckConfig{
+		cmd:              []string{"/bin/sh", "-c", "cat /tmp/healthy"},
+		interval:         5 * time.Second,
+		timeout:          defaultHealthCheckTimeout,
+		retries:          2,
+		restartUnhealthy: true,
+	}, cfg)
+
+	for _, annotations := range []map[string]string{
+		{anno.HealthCheckExecAnnotation: "true", anno.HealthCheckIntervalAnnotation: "0s"},
+		{anno.HealthCheckExecAnnotation: "true", anno.HealthCheckTimeoutAnnotation: "100ms"},
+		{anno.HealthCheckExecAnnotation: "true", anno.HealthCheckRetriesAnnotation: "-1"},
+		{anno.HealthCheckExecAnnotation: "true", anno.HealthCheckRestartAnnotation: "yes"},
+	} {
+		_, err := parseHealthCheckConfig(annotations)
+		assert.Error(t, err, "%v", annotations)
+	}
+}
+
+func TestHealthProbeRecord(t *testing.T) {
+	p := &healthProbe{
+		config: &healthCheckConfig{retries: 2, restartUnhealthy: true},
+		state:  healthState{Status: healthStarting},
+	}
+
+	assert.False(t, p.record(0, "ok", nil))
+	assert.Equal(t, healthHealthy, p.state.Status)
+
+	assert.False(t, p.record(1, "failed", nil))
+	assert.Equal(t, healthHealthy, p.state.Status)
+	assert.Equal(t, 1, p.state.FailingStreak)
+
+	// the container is restarted after retries consecutive failures.
+	assert.True(t, p.record(-1, "", fmt.Errorf("exec failed")))
+	assert.Equal(t, healthStarting, p.state.Status)
+	assert.Equal(t, 1, p.state.Restarts)
+	assert.Equal(t, "exec failed", p.state.LastOutput)
+
+	p.config.restartUnhealthy = false
+	p.record(1, "", nil)
+	assert.False(t, p.record(1, "", nil))
+	assert.Equal(t, healthUnhealthy, p.state.Status)
+}
+
+func TestHealthChecker(t *testing.T) {
+	var restarts int32
+	h := &healthChecker{
+		probes: make(map[string]*healthProbe),
+		exec: func(ctx context.Context, id string, cmd []string, timeout time.Duration) (int32, string, error) {
+			return 1, "unhealthy", nil
+		},
+		restart: func(ctx context.Context, id string) error {
+			atomic.AddInt32(&restarts, 1)
+			return nil
+		},
+		running: func(ctx context.Context, id string) bool { return true },
+	}
+
+	h.start("c1", &healthCheckConfig{interval: 10 * time.Millisecond, retries: 1, restartUnhealthy: true})
+	assert.NotNil(t, h.state("c1"))
+	assert.Nil(t, h.state("c2"))
+
+	deadline := time.Now().Add(5 * time.Second)
+	for atomic.LoadInt32(&restarts) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.NotZero(t, atomic.LoadInt32(&restarts))
+
+	h.stop("c1")
+	assert.Nil(t, h.state("c1"))
+
+	var nilChecker *healthChecker
+	nilChecker.stop("c1")
+	assert.Nil(t, nilChecker.state("c1"))
+}
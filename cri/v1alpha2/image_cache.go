@@ -0,0 +1,106 @@
+package v1alpha2
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	apitypes "github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/daemon/mgr"
+)
+
+// imageRefCache caches the image references of containers reported by
+// ContainerStatus, which are dropped by any image event since the images
+// may be pulled, tagged or removed.
+type imageRefCache struct {
+	sync.RWMutex
+	refs map[string]string
+	// version is increased by every reset, the references got before the
+	// reset are not cached.
+	version uint64
+}
+
+func newImageRefCache() *imageRefCache {
+	return &imageRefCache{
+		refs: make(map[string]string),
+	}
+}
+
+// get returns the cached image reference of container and the current version of cache.
+func (ic *imageRefCache) get(id string) (string, bool, uint64) {
+	if ic == nil {
+		return "", false, 0
+	}
+
+	ic.RLock()
+	defer ic.RUnlock()
+
+	ref, ok := ic.refs[id]
+	return ref, ok, ic.version
+}
+
+// put caches the image reference of container if the cache is not reset since the version.
+func (ic *imageRefCache) put(id, ref string, version uint64) {
+	if ic == nil {
+		return
+	}
+
+	ic.Lock()
+	defer ic.Unlock()
+
+	if ic.version == version {
+		ic.refs[id] = ref
+	}
+}
+
+// remove drops the image reference of container.
+func (ic *imageRefCache) remove(id string) {
+	if ic == nil {
+		return
+	}
+
+	ic.Lock()
+	defer ic.Unlock()
+
+	delete(ic.refs, id)
+}
+
+// reset drops all the cached image references.
+func (ic *imageRefCache) reset() {
+	ic.Lock()
+	defer ic.Unlock()
+
+	ic.refs = make(map[string]string)
+	ic.version++
+}
+
+// handleEvent drops all the cached image references on the image event.
+func (ic *imageRefCache) handleEvent(*apitypes.EventsMessage) {
+	ic.reset()
+}
+
+// containerImageRef returns the image reference of container, which is the
+// first repo digest of the image if any, otherwise the image ID.
+func (c *CriManager) containerImageRef(ctx context.Context, container *mgr.Container) (string, error) {
+	ref, ok, version := c.imageRefCache.get(container.ID)
+	if ok {
+		return ref, nil
+	}
+
+	// FIXME(fuwei): if user repush image with the same reference, the image
+	// ID will be changed. For now, pouch daemon will remove the old image ID
+	// so that CRI fails to fetch the running container. Before upgrade
+	// pouch daemon image manager, we use reference to get image instead of
+	// id.
+	imageInfo, err := c.ImageMgr.GetImage(ctx, container.Config.Image)
+	if err != nil {
+		return "", fmt.Errorf("failed to get image %s: %v", container.Config.Image, err)
+	}
+	ref = imageInfo.ID
+	if len(imageInfo.RepoDigests) > 0 {
+		ref = imageInfo.RepoDigests[0]
+	}
+
+	c.imageRefCache.put(container.ID, ref, version)
+	return ref, nil
+}
@@ -0,0 +1,69 @@
+package v1alpha2
+
+import (
+	"context"
+	"testing"
+
+	apitypes "github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/daemon/events"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImageRefCache(t *testing.T) {
+	ic := newImageRefCache()
+
+	_, ok, version := ic.get("c1")
+	assert.False(t, ok)
+	ic.put("c1", "sha256:1", version)
+	ref, ok, _ := ic.get("c1")
+	assert.True(t, ok)
+	assert.Equal(t, "sha256:1", ref)
+
+	// the reference got before the reset is not cached.
+	_, _, version = ic.get("c2")
+	ic.handleEvent(&apitypes.EventsMessage{Action: "pull"})
+	ic.put("c2", "sha256:2", version)
+	_, ok, _ = ic.get("c2")
+	assert.False(t, ok)
+	_, ok, _ = ic.get("c1")
+	assert.False(t, ok)
+
+	_, _, version = ic.get("c1")
+	ic.put("c1", "sha256:1", version)
+	ic.remove("c1")
+	_, ok, _ = ic.get("c1")
+	assert.False(t, ok)
+
+	// nil cache caches nothing.
+	var nilCache *imageRefCache
+	nilCache.put("c1", "sha256:1", 0)
+	nilCache.remove("c1")
+	_, ok, _ = nilCache.get("c1")
+	assert.False(t, ok)
+}
+
+func TestImageRefCacheWatchEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	eventsService := events.NewEvents()
+	ic := newImageRefCache()
+	go watchEvents(ctx, eventsService, apitypes.EventTypeImage, ic.reset, ic.handleEvent)
+
+	// wait for the subscription.
+	assert.True(t, waitFor(func() bool {
+		_, _, version := ic.get("c1")
+		return version > 0
+	}))
+
+	_, _, version := ic.get("c1")
+	ic.put("c1", "sha256:1", version)
+
+	assert.NoError(t, eventsService.Publish(ctx, "tag", apitypes.EventTypeImage, &apitypes.EventsActor{ID: "busybox"}))
+
+	assert.True(t, waitFor(func() bool {
+		_, ok, _ := ic.get("c1")
+		return !ok
+	}))
+}
@@ -0,0 +1,66 @@
+package v1alpha2
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+	"github.com/alibaba/pouch/ctrd"
+	"github.com/alibaba/pouch/daemon/mgr"
+)
+
+// containerSnapshotter returns the snapshotter of the container, which is the
+// current one unless it's chosen by the hook plugin.
+func containerSnapshotter(ctx context.Context, container *mgr.Container) string {
+	if container.Config != nil && container.Config.Snapshotter != "" {
+		return container.Config.Snapshotter
+	}
+	return ctrd.CurrentSnapshotterName(ctx)
+}
+
+// imageFsUsages sums up the usages of the snapshots by snapshotters, one for
+// the filesystem of each snapshotter. The current snapshotter comes first
+// even if it has no snapshot, since kubelet takes the first one as the image
+// filesystem of node.
+func imageFsUsages(snapshots []mgr.Snapshot, rootDir, current string) []*runtime.FilesystemUsage {
+	now := time.Now().UnixNano()
+	newUsage := func(snapshotter string) *runtime.FilesystemUsage {
+		return &runtime.FilesystemUsage{
+			Timestamp:  now,
+			FsId:       &runtime.FilesystemIdentifier{Mountpoint: imageFSPath(rootDir, snapshotter)},
+			UsedBytes:  &runtime.UInt64Value{},
+			InodesUsed: &runtime.UInt64Value{},
+		}
+	}
+
+	usages := map[string]*runtime.FilesystemUsage{current: newUsage(current)}
+	others := []string{}
+	for _, sn := range snapshots {
+		// the snapshots persisted by the old version have no snapshotter.
+		snapshotter := sn.Snapshotter
+		if snapshotter == "" {
+			snapshotter = current
+		}
+
+		usage, ok := usages[snapshotter]
+		if !ok {
+			usage = newUsage(snapshotter)
+			usages[snapshotter] = usage
+			others = append(others, snapshotter)
+		}
+		// Use the oldest timestamp as the timestamp of imagefs info.
+		if sn.Timestamp < usage.Timestamp {
+			usage.Timestamp = sn.Timestamp
+		}
+		usage.UsedBytes.Value += sn.Size
+		usage.InodesUsed.Value += sn.Inodes
+	}
+
+	sort.Strings(others)
+	result := []*runtime.FilesystemUsage{usages[current]}
+	for _, snapshotter := range others {
+		result = append(result, usages[snapshotter])
+	}
+	return result
+}
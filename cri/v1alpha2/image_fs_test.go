@@ -0,0 +1,57 @@
+package v1alpha2
+
+import (
+	"context"
+	"testing"
+
+	apitypes "github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/ctrd"
+	"github.com/alibaba/pouch/daemon/mgr"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImageFsUsages(t *testing.T) {
+	rootDir := "/var/lib/pouch/containerd/root"
+
+	// the current snapshotter is reported even if it has no snapshot.
+	usages := imageFsUsages(nil, rootDir, "overlayfs")
+	assert.Len(t, usages, 1)
+	assert.Equal(t, rootDir+"/io.containerd.snapshotter.v1.overlayfs", usages[0].GetFsId().GetMountpoint())
+	assert.Equal(t, uint64(0), usages[0].GetUsedBytes().GetValue())
+
+	usages = imageFsUsages([]mgr.Snapshot{
+		{Key: "l1", Snapshotter: "stargz", Size: 100, Inodes: 10, Timestamp: 3},
+		{Key: "l1", Snapshotter: "overlayfs", Size: 200, Inodes: 20, Timestamp: 2},
+		{Key: "l2", Snapshotter: "nydus", Size: 300, Inodes: 30, Timestamp: 1},
+		{Key: "c1", Snapshotter: "overlayfs", Size: 400, Inodes: 40, Timestamp: 4},
+		// the snapshot persisted by the old version belongs to the current snapshotter.
+		{Key: "c2", Size: 500, Inodes: 50, Timestamp: 5},
+	}, rootDir, "overlayfs")
+	assert.Len(t, usages, 3)
+
+	for i, expected := range []struct {
+		snapshotter string
+		size        uint64
+		inodes      uint64
+		timestamp   int64
+	}{
+		{"overlayfs", 1100, 110, 2},
+		{"nydus", 300, 30, 1},
+		{"stargz", 100, 10, 3},
+	} {
+		assert.Equal(t, imageFSPath(rootDir, expected.snapshotter), usages[i].GetFsId().GetMountpoint())
+		assert.Equal(t, expected.size, usages[i].GetUsedBytes().GetValue())
+		assert.Equal(t, expected.inodes, usages[i].GetInodesUsed().GetValue())
+		assert.Equal(t, expected.timestamp, usages[i].GetTimestamp())
+	}
+}
+
+func TestContainerSnapshotter(t *testing.T) {
+	ctx := context.Background()
+	container := &mgr.Container{Config: &apitypes.ContainerConfig{}}
+	assert.Equal(t, ctrd.CurrentSnapshotterName(ctx), containerSnapshotter(ctx, container))
+
+	container.Config.Snapshotter = "nydus"
+	assert.Equal(t, "nydus", containerSnapshotter(ctx, container))
+}
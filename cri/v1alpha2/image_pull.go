@@ -0,0 +1,310 @@
+package v1alpha2
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sync"
+
+	apitypes "github.com/alibaba/pouch/apis/types"
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+	metatypes "github.com/alibaba/pouch/cri/v1alpha2/types"
+	"github.com/alibaba/pouch/ctrd"
+	"github.com/alibaba/pouch/pkg/errtypes"
+	"github.com/alibaba/pouch/pkg/log"
+
+	digest "github.com/opencontainers/go-digest"
+)
+
+// imagePulls deduplicates the concurrent pulls of the same image into one pull
+// of containerd, e.g. the sandbox image or the image of daemonset pulled for
+// many pods at once, whose progress is shared by all the callers.
+type imagePulls struct {
+	sync.Mutex
+	pulls map[string]*imagePull
+}
+
+func newImagePulls() *imagePulls {
+	return &imagePulls{pulls: make(map[string]*imagePull)}
+}
+
+// imagePull is a pull in flight.
+type imagePull struct {
+	done   chan struct{}
+	err    error
+	cancel context.CancelFunc
+	// waiters is the number of callers waiting for the pull, which is canceled
+	// once all of them are gone.
+	waiters int
+
+	mu sync.Mutex
+	// progress is the progress written so far, replayed to the late callers.
+	progress bytes.Buffer
+	outs     map[int]io.Writer
+	nextOut  int
+}
+
+// Write writes the progress of pull to all the callers, the one failing to be
+// written is detached.
+func (p *imagePull) Write(b []byte) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.progress.Write(b)
+	for id, out := range p.outs {
+		if _, err := out.Write(b); err != nil {
+			delete(p.outs, id)
+		}
+	}
+	return len(b), nil
+}
+
+// attach writes the progress so far to the caller and the following ones,
+// and returns the id to detach it.
+func (p *imagePull) attach(out io.Writer) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.nextOut++
+	if _, err := out.Write(p.progress.Bytes()); err == nil {
+		p.outs[p.nextOut] = out
+	}
+	return p.nextOut
+}
+
+func (p *imagePull) detach(id int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.outs, id)
+}
+
+// do runs the pull of key, or waits for the one in flight. The pull is not
+// canceled by the caller going away until all the callers are gone.
+func (ps *imagePulls) do(ctx context.Context, key string, out io.Writer, pull func(context.Context, io.Writer) error) error {
+	if ps == nil {
+		return pull(ctx, out)
+	}
+
+	ps.Lock()
+	p, ok := ps.pulls[key]
+	if !ok {
+		pullCtx, cancel := context.WithCancel(context.Background())
+		p = &imagePull{done: make(chan struct{}), cancel: cancel, outs: make(map[int]io.Writer)}
+		ps.pulls[key] = p
+		go func() {
+			p.err = pull(pullCtx, p)
+			ps.forget(key, p)
+			cancel()
+			close(p.done)
+		}()
+	} else {
+		log.With(ctx).Debugf("wait for the pull of image %s in flight", key)
+	}
+	p.waiters++
+	ps.Unlock()
+
+	defer p.detach(p.attach(out))
+
+	select {
+	case <-p.done:
+		return p.err
+	case <-ctx.Done():
+		ps.Lock()
+		p.waiters--
+		if p.waiters == 0 {
+			// the callers coming later start another pull.
+			p.cancel()
+			if ps.pulls[key] == p {
+				delete(ps.pulls, key)
+			}
+		}
+		ps.Unlock()
+		return ctx.Err()
+	}
+}
+
+// forget removes the pull done, unless it has been replaced.
+func (ps *imagePulls) forget(key string, p *imagePull) {
+	ps.Lock()
+	defer ps.Unlock()
+
+	if ps.pulls[key] == p {
+		delete(ps.pulls, key)
+	}
+}
+
+// imagePullKey returns the key deduplicating the pulls, which are only shared
+// by the callers with the same credentials and snapshotter. The empty credentials
+// are the same as none.
+func imagePullKey(handler, ref string, auth *apitypes.AuthConfig) string {
+	key := handler + "/" + ref
+	if auth != nil && *auth != (apitypes.AuthConfig{}) {
+		data, _ := json.Marshal(auth)
+		sum := sha256.Sum256(data)
+		key += "@" + hex.EncodeToString(sum[:8])
+	}
+	return key
+}
+
+// toAuthConfig converts the credentials of cri, nil if none.
+func toAuthConfig(auth *runtime.AuthConfig) *apitypes.AuthConfig {
+	authConfig := &apitypes.AuthConfig{
+		Auth:          auth.GetAuth(),
+		Username:      auth.GetUsername(),
+		Password:      auth.GetPassword(),
+		ServerAddress: auth.GetServerAddress(),
+		IdentityToken: auth.GetIdentityToken(),
+		RegistryToken: auth.GetRegistryToken(),
+	}
+	if *authConfig == (apitypes.AuthConfig{}) {
+		return nil
+	}
+	return authConfig
+}
+
+// pullImage pulls the image into the snapshotter of runtime handler, the
+// concurrent pulls of the same image are deduplicated. The bandwidth of pull
+// is limited by the limiter of caller, e.g. the prefetch, and the pulls limited
+// are only shared by the callers limited, so that the pulls of kubelet are
+// never throttled by joining them.
+func (c *CriManager) pullImage(ctx context.Context, handler, ref string, auth *apitypes.AuthConfig, out io.Writer) error {
+	key := imagePullKey(handler, ref, auth)
+	limiter := ctrd.GetPullBandwidth(ctx)
+	if limiter != nil {
+		key += "#limited"
+	}
+	return c.imagePulls.do(ctx, key, out, func(ctx context.Context, out io.Writer) error {
+		if limiter != nil {
+			ctx = ctrd.WithPullBandwidth(ctx, limiter)
+		}
+		return c.ImageMgr.PullImage(c.withRuntimeSnapshotter(ctx, handler), ref, auth, out)
+	})
+}
+
+// podImagePulls keeps the images pulled for the pods, with the references and
+// credentials they are pulled by, so that the images removed after they are
+// pulled, e.g. by the image gc, are pulled again when the containers are created.
+// Kubelet creates the containers with the image ids PullImage returned, which
+// could only be pulled by the references.
+type podImagePulls struct {
+	sync.RWMutex
+	// pulls maps the uid of pod to the pulls keyed by both the id and the
+	// reference of image.
+	pulls map[string]map[string]*podImagePull
+}
+
+// podImagePull is an image pulled for a pod.
+type podImagePull struct {
+	ref  string
+	auth *apitypes.AuthConfig
+}
+
+func newPodImagePulls() *podImagePulls {
+	return &podImagePulls{pulls: make(map[string]map[string]*podImagePull)}
+}
+
+// remember records the image of id pulled by the reference for the pod.
+func (pp *podImagePulls) remember(uid, ref, id string, auth *apitypes.AuthConfig) {
+	if pp == nil || uid == "" {
+		return
+	}
+
+	pp.Lock()
+	defer pp.Unlock()
+
+	if pp.pulls[uid] == nil {
+		pp.pulls[uid] = make(map[string]*podImagePull)
+	}
+	pull := &podImagePull{ref: ref, auth: auth}
+	pp.pulls[uid][ref] = pull
+	if id != "" {
+		pp.pulls[uid][id] = pull
+	}
+}
+
+// get returns the pull of the image id or reference for the pod, nil if none.
+func (pp *podImagePulls) get(uid, image string) *podImagePull {
+	if pp == nil {
+		return nil
+	}
+
+	pp.RLock()
+	defer pp.RUnlock()
+
+	return pp.pulls[uid][image]
+}
+
+// forget drops the pulls of the pod.
+func (pp *podImagePulls) forget(uid string) {
+	if pp == nil {
+		return
+	}
+
+	pp.Lock()
+	defer pp.Unlock()
+
+	delete(pp.pulls, uid)
+}
+
+// forgetPodImagePulls drops the pulls of the pod once all the sandboxes of it
+// are removed.
+func (c *CriManager) forgetPodImagePulls(uid string) {
+	if c.podImagePulls == nil || uid == "" {
+		return
+	}
+
+	metas, err := c.SandboxStore.List()
+	if err != nil {
+		return
+	}
+	for _, m := range metas {
+		if meta, ok := m.(*metatypes.SandboxMeta); ok && meta.Config.GetMetadata().GetUid() == uid {
+			return
+		}
+	}
+	c.podImagePulls.forget(uid)
+}
+
+// ensureContainerImage pulls the image of container if it's missing, by the
+// reference and credentials the pod pulled it with. The image is either the
+// id or the reference of image.
+func (c *CriManager) ensureContainerImage(ctx context.Context, uid, handler, image string) error {
+	_, _, _, err := c.ImageMgr.CheckReference(ctx, image)
+	if err == nil {
+		return nil
+	}
+	if !errtypes.IsNotfound(err) {
+		return fmt.Errorf("failed to check image %q: %v", image, err)
+	}
+
+	ref, auth := image, (*apitypes.AuthConfig)(nil)
+	if pull := c.podImagePulls.get(uid, image); pull != nil {
+		ref, auth = pull.ref, pull.auth
+	} else if isImageID(image) {
+		return fmt.Errorf("image %q is missing and not pulled by any reference for pod %s", image, uid)
+	}
+
+	log.With(ctx).Infof("image %s of pod %s is missing, pull %s on create", image, uid, ref)
+	if err := c.pullImage(ctx, handler, ref, auth, ioutil.Discard); err != nil {
+		return fmt.Errorf("failed to pull image %q on create: %v", ref, err)
+	}
+	// the reference may point to another image now.
+	if ref != image {
+		if _, _, _, err := c.ImageMgr.CheckReference(ctx, image); err != nil {
+			return fmt.Errorf("image %q is still missing after pulling %q on create: %v", image, ref, err)
+		}
+	}
+	return nil
+}
+
+// isImageID returns whether the image is referred by the id, i.e. sha256:<hex>.
+func isImageID(image string) bool {
+	_, err := digest.Parse(image)
+	return err == nil
+}
@@ -0,0 +1,324 @@
+package v1alpha2
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	apitypes "github.com/alibaba/pouch/apis/types"
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+	metatypes "github.com/alibaba/pouch/cri/v1alpha2/types"
+	"github.com/alibaba/pouch/ctrd"
+	"github.com/alibaba/pouch/daemon/mgr"
+	"github.com/alibaba/pouch/pkg/errtypes"
+	"github.com/alibaba/pouch/pkg/reference"
+
+	digest "github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/time/rate"
+)
+
+// fakePullImageMgr finds no image and records the images pulled.
+type fakePullImageMgr struct {
+	fakeLoadImageMgr
+	mu      sync.Mutex
+	pulled  map[string]*apitypes.AuthConfig
+	limited bool
+}
+
+func (f *fakePullImageMgr) PullImage(ctx context.Context, ref string, authConfig *apitypes.AuthConfig, out io.Writer) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.pulled[ref] = authConfig
+	f.limited = ctrd.GetPullBandwidth(ctx) != nil
+	return nil
+}
+
+func TestImagePullsDedup(t *testing.T) {
+	ps := newImagePulls()
+	started, release := make(chan struct{}), make(chan struct{})
+	pulls := 0
+	pull := func(ctx context.Context, out io.Writer) error {
+		pulls++
+		fmt.Fprint(out, "downloading;")
+		close(started)
+		<-release
+		fmt.Fprint(out, "done;")
+		return fmt.Errorf("unauthorized")
+	}
+
+	var (
+		wg      sync.WaitGroup
+		errs    [2]error
+		outs    [2]bytes.Buffer
+		waiters = make(chan struct{}, 2)
+	)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if i == 1 {
+				<-started
+			}
+			waiters <- struct{}{}
+			errs[i] = ps.do(context.Background(), "k8s.gcr.io/pause:3.1", &outs[i], pull)
+		}(i)
+	}
+	<-waiters
+	<-waiters
+	// the second caller joins the pull in flight.
+	for {
+		ps.Lock()
+		p := ps.pulls["k8s.gcr.io/pause:3.1"]
+		joined := p != nil && p.waiters == 2
+		ps.Unlock()
+		if joined {
+			break
+		}
+	}
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, 1, pulls)
+	for i := range errs {
+		assert.EqualError(t, errs[i], "unauthorized")
+		// the progress so far is replayed to the late caller.
+		assert.Equal(t, "downloading;done;", outs[i].String())
+	}
+	assert.Empty(t, ps.pulls)
+}
+
+func TestImagePullsCancel(t *testing.T) {
+	ps := newImagePulls()
+	canceled := make(chan struct{})
+	pull := func(ctx context.Context, out io.Writer) error {
+		<-ctx.Done()
+		close(canceled)
+		return ctx.Err()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Equal(t, context.Canceled, ps.do(ctx, "busybox", ioutil.Discard, pull))
+
+	// the pull is canceled once all the callers are gone.
+	<-canceled
+	assert.Empty(t, ps.pulls)
+
+	// the pulls are not deduplicated without the cache.
+	var none *imagePulls
+	assert.NoError(t, none.do(context.Background(), "busybox", ioutil.Discard, func(context.Context, io.Writer) error { return nil }))
+}
+
+func TestImagePullKey(t *testing.T) {
+	assert.Equal(t, "/busybox", imagePullKey("", "busybox", nil))
+	assert.NotEqual(t, imagePullKey("runc", "busybox", nil), imagePullKey("kata", "busybox", nil))
+
+	alice := imagePullKey("", "busybox", &apitypes.AuthConfig{Username: "alice"})
+	assert.NotEqual(t, alice, imagePullKey("", "busybox", &apitypes.AuthConfig{Username: "bob"}))
+	assert.Equal(t, alice, imagePullKey("", "busybox", &apitypes.AuthConfig{Username: "alice"}))
+	assert.NotContains(t, imagePullKey("", "busybox", &apitypes.AuthConfig{Password: "secret"}), "secret")
+
+	// the empty credentials of kubelet are the same as none.
+	assert.Equal(t, imagePullKey("", "busybox", nil), imagePullKey("", "busybox", toAuthConfig(&runtime.AuthConfig{})))
+	assert.Nil(t, toAuthConfig(nil))
+}
+
+// blockingPullImageMgr records whether the pulls are limited, and blocks the
+// limited ones until released.
+type blockingPullImageMgr struct {
+	mgr.ImageMgr
+	mu      sync.Mutex
+	limited []bool
+	started chan struct{}
+	release chan struct{}
+}
+
+func (f *blockingPullImageMgr) PullImage(ctx context.Context, ref string, authConfig *apitypes.AuthConfig, out io.Writer) error {
+	limited := ctrd.GetPullBandwidth(ctx) != nil
+	f.mu.Lock()
+	f.limited = append(f.limited, limited)
+	f.mu.Unlock()
+	if limited {
+		close(f.started)
+		<-f.release
+	}
+	return nil
+}
+
+func TestPullImageLimited(t *testing.T) {
+	imageMgr := &blockingPullImageMgr{started: make(chan struct{}), release: make(chan struct{})}
+	c := &CriManager{ImageMgr: imageMgr, imagePulls: newImagePulls()}
+
+	prefetched := make(chan error)
+	go func() {
+		ctx := ctrd.WithPullBandwidth(context.Background(), rate.NewLimiter(rate.Limit(1024), 1024))
+		prefetched <- c.pullImage(ctx, "", "busybox", nil, ioutil.Discard)
+	}()
+	<-imageMgr.started
+
+	// the pull of kubelet does not join the prefetch in flight, which is limited.
+	assert.NoError(t, c.pullImage(context.Background(), "", "busybox", nil, ioutil.Discard))
+	close(imageMgr.release)
+	assert.NoError(t, <-prefetched)
+	assert.Equal(t, []bool{true, false}, imageMgr.limited)
+}
+
+func TestEnsureContainerImage(t *testing.T) {
+	homeDir, err := ioutil.TempDir("", "pull-on-create")
+	assert.NoError(t, err)
+	defer os.RemoveAll(homeDir)
+
+	store, err := newSandboxStore(homeDir)
+	assert.NoError(t, err)
+	defer store.Shutdown()
+
+	imageMgr := &fakePullImageMgr{pulled: make(map[string]*apitypes.AuthConfig)}
+	c := &CriManager{
+		ImageMgr:      imageMgr,
+		SandboxStore:  store,
+		imagePulls:    newImagePulls(),
+		podImagePulls: newPodImagePulls(),
+	}
+
+	auth := &apitypes.AuthConfig{Username: "alice", Password: "secret"}
+	c.podImagePulls.remember("uid1", "registry.example.com/app:v1", "", auth)
+	c.podImagePulls.remember("", "registry.example.com/app:v1", "", auth)
+
+	assert.NoError(t, c.ensureContainerImage(context.Background(), "uid1", "", "registry.example.com/app:v1"))
+	assert.NoError(t, c.ensureContainerImage(context.Background(), "uid2", "", "busybox"))
+	assert.Equal(t, map[string]*apitypes.AuthConfig{
+		"registry.example.com/app:v1": auth,
+		"busybox":                     nil,
+	}, imageMgr.pulled)
+
+	// the image id never pulled by any reference could not be pulled.
+	assert.Error(t, c.ensureContainerImage(context.Background(), "uid2", "", "sha256:"+strings.Repeat("a", 64)))
+
+	// the pulls are kept until all the sandboxes of pod are removed.
+	assert.NoError(t, store.Put(&metatypes.SandboxMeta{
+		ID:     "sandbox1",
+		Config: &runtime.PodSandboxConfig{Metadata: &runtime.PodSandboxMetadata{Uid: "uid1"}},
+	}))
+	c.forgetPodImagePulls("uid1")
+	assert.Equal(t, auth, c.podImagePulls.get("uid1", "registry.example.com/app:v1").auth)
+
+	assert.NoError(t, store.Remove("sandbox1"))
+	c.forgetPodImagePulls("uid1")
+	assert.Nil(t, c.podImagePulls.get("uid1", "registry.example.com/app:v1"))
+}
+
+// fakeRegistryImageMgr pulls the images from the registry, which maps the
+// references to the ids of images, and records the pulls.
+type fakeRegistryImageMgr struct {
+	mgr.ImageMgr
+	mu       sync.Mutex
+	registry map[string]string
+	local    map[string]bool
+	pulls    []string
+	auths    []*apitypes.AuthConfig
+}
+
+func (f *fakeRegistryImageMgr) resolve(idOrRef string) (string, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id := idOrRef
+	if registryID, ok := f.registry[idOrRef]; ok {
+		id = registryID
+	}
+	return id, f.local[id]
+}
+
+func (f *fakeRegistryImageMgr) CheckReference(ctx context.Context, idOrRef string) (digest.Digest, reference.Named, reference.Named, error) {
+	id, ok := f.resolve(idOrRef)
+	if !ok {
+		return "", nil, nil, errors.Wrap(errtypes.ErrNotfound, idOrRef)
+	}
+	return digest.Digest(id), nil, nil, nil
+}
+
+func (f *fakeRegistryImageMgr) GetImage(ctx context.Context, idOrRef string) (*apitypes.ImageInfo, error) {
+	id, ok := f.resolve(idOrRef)
+	if !ok {
+		return nil, errors.Wrap(errtypes.ErrNotfound, idOrRef)
+	}
+	return &apitypes.ImageInfo{ID: id}, nil
+}
+
+func (f *fakeRegistryImageMgr) PullImage(ctx context.Context, ref string, authConfig *apitypes.AuthConfig, out io.Writer) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.pulls, f.auths = append(f.pulls, ref), append(f.auths, authConfig)
+	id, ok := f.registry[ref]
+	if !ok {
+		return fmt.Errorf("manifest of %s unknown", ref)
+	}
+	f.local[id] = true
+	return nil
+}
+
+func TestPullImageThenCreateContainer(t *testing.T) {
+	homeDir, err := ioutil.TempDir("", "pull-on-create")
+	assert.NoError(t, err)
+	defer os.RemoveAll(homeDir)
+
+	store, err := newSandboxStore(homeDir)
+	assert.NoError(t, err)
+	defer store.Shutdown()
+
+	sandboxConfig := &runtime.PodSandboxConfig{Metadata: &runtime.PodSandboxMetadata{Name: "app", Namespace: "default", Uid: "uid1"}}
+	assert.NoError(t, store.Put(&metatypes.SandboxMeta{ID: "s1", Config: sandboxConfig}))
+
+	imageID := "sha256:" + strings.Repeat("a", 64)
+	imageMgr := &fakeRegistryImageMgr{
+		registry: map[string]string{"registry.example.com/app:v1": imageID},
+		local:    make(map[string]bool),
+	}
+	containerMgr := &ephemeralContainerMgr{containers: map[string]*mgr.Container{"s1": {ID: "s1", State: &apitypes.ContainerState{Running: true}}}}
+	c := &CriManager{
+		ContainerMgr:  containerMgr,
+		ImageMgr:      imageMgr,
+		SandboxStore:  store,
+		names:         newNameReservations(),
+		attempts:      newAttemptCounter(),
+		imagePulls:    newImagePulls(),
+		pullOnCreate:  true,
+		podImagePulls: newPodImagePulls(),
+	}
+	ctx := context.Background()
+
+	pullResp, err := c.PullImage(ctx, &runtime.PullImageRequest{
+		Image:         &runtime.ImageSpec{Image: "registry.example.com/app:v1"},
+		Auth:          &runtime.AuthConfig{Username: "alice", Password: "secret"},
+		SandboxConfig: sandboxConfig,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, imageID, pullResp.ImageRef)
+
+	// the image is removed by the image gc before kubelet creates the container
+	// with the image id returned.
+	imageMgr.local[imageID] = false
+	_, err = c.CreateContainer(ctx, &runtime.CreateContainerRequest{
+		PodSandboxId:  "s1",
+		SandboxConfig: sandboxConfig,
+		Config: &runtime.ContainerConfig{
+			Metadata: &runtime.ContainerMetadata{Name: "app"},
+			Image:    &runtime.ImageSpec{Image: pullResp.ImageRef},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, imageID, containerMgr.created.Image)
+
+	// the image is pulled again by the reference with the credentials.
+	assert.Equal(t, []string{"registry.example.com/app:v1", "registry.example.com/app:v1"}, imageMgr.pulls)
+	assert.Equal(t, &apitypes.AuthConfig{Username: "alice", Password: "secret"}, imageMgr.auths[1])
+}
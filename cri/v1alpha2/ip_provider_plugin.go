@@ -0,0 +1,27 @@
+package v1alpha2
+
+import (
+	cni "github.com/alibaba/pouch/cri/ocicni"
+	"github.com/alibaba/pouch/hookplugins"
+)
+
+// pluginIPProvider adapts the ip provider plugin to the provider of cni manager.
+type pluginIPProvider struct {
+	hookplugins.IPProviderPlugin
+}
+
+// PodIP returns the ip of the sandbox reported by the plugin.
+func (p pluginIPProvider) PodIP(pod *cni.PodIPRequest) (string, bool, error) {
+	return p.IPProviderPlugin.PodIP(pod)
+}
+
+// registerIPProviderPlugins registers the ip provider plugins to the cni manager,
+// which are queried after the built-in providers.
+func registerIPProviderPlugins(cniMgr cni.CniMgr, plugins []hookplugins.IPProviderPlugin) error {
+	for _, p := range plugins {
+		if err := cniMgr.RegisterIPProvider(pluginIPProvider{p}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package v1alpha2
+
+import (
+	"testing"
+
+	cni "github.com/alibaba/pouch/cri/ocicni"
+	"github.com/alibaba/pouch/hookplugins"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// eniIPProviderPlugin reports the ips of sandboxes in the map.
+type eniIPProviderPlugin struct {
+	ips map[string]string
+}
+
+func (p *eniIPProviderPlugin) Name() string {
+	return "eni"
+}
+
+func (p *eniIPProviderPlugin) PodIP(res interface{}) (string, bool, error) {
+	ip, ok := p.ips[res.(*cni.PodIPRequest).ID]
+	return ip, ok, nil
+}
+
+// ipProviderCniMgr records the ip providers registered.
+type ipProviderCniMgr struct {
+	cni.CniMgr
+	providers []cni.IPProvider
+}
+
+func (m *ipProviderCniMgr) RegisterIPProvider(provider cni.IPProvider) error {
+	m.providers = append(m.providers, provider)
+	return nil
+}
+
+func TestRegisterIPProviderPlugins(t *testing.T) {
+	cniMgr := &ipProviderCniMgr{}
+	plugin := &eniIPProviderPlugin{ips: map[string]string{"s1": "192.168.0.10"}}
+	assert.NoError(t, registerIPProviderPlugins(cniMgr, []hookplugins.IPProviderPlugin{plugin}))
+	assert.Len(t, cniMgr.providers, 1)
+
+	provider := cniMgr.providers[0]
+	assert.Equal(t, "eni", provider.Name())
+	ip, ok, err := provider.PodIP(&cni.PodIPRequest{ID: "s1"})
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "192.168.0.10", ip)
+
+	_, ok, err = provider.PodIP(&cni.PodIPRequest{ID: "s2"})
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
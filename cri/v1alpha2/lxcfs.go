@@ -0,0 +1,142 @@
+package v1alpha2
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alibaba/pouch/daemon/mgr"
+	"github.com/alibaba/pouch/lxcfs"
+	"github.com/alibaba/pouch/pkg/log"
+
+	"golang.org/x/net/context"
+)
+
+var (
+	// lxcfsCheckInterval is the interval to check whether lxcfs is restarted.
+	lxcfsCheckInterval = 5 * time.Second
+
+	// runNsenter runs the command of nsenter, which is replaced in tests.
+	runNsenter = func(args ...string) error {
+		var stderr bytes.Buffer
+		cmd := exec.Command("nsenter", args...)
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("%v, stderr: %q", err, stderr.String())
+		}
+		return nil
+	}
+)
+
+// lxcfsMountID returns the mount ID of the lxcfs home in the mountinfo of
+// process, empty if lxcfs is not mounted. The ID is changed once lxcfs is
+// restarted and mounted again.
+func lxcfsMountID(pid, home string) (string, error) {
+	f, err := os.Open(filepath.Join(procRoot, pid, "mountinfo"))
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	var id string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// 36 35 0:32 / /var/lib/lxcfs rw,nosuid,nodev,relatime - fuse.lxcfs lxcfs rw,...
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 5 || fields[4] != home {
+			continue
+		}
+		// the last one is the top of the mounts on the same point.
+		id = fields[0]
+	}
+	return id, scanner.Err()
+}
+
+// lxcfsProcFileInContainer returns the path of lxcfs proc file in container,
+// the parent directory of lxcfs home is bound to /var/lib/lxc of containers.
+func lxcfsProcFileInContainer(file string) string {
+	return path.Join("/var/lib/lxc", path.Base(lxcfs.LxcfsHomeDir), "proc", file)
+}
+
+// remountLxcfsInContainer binds the lxcfs proc files of the new lxcfs mount
+// onto the /proc files of the running container again, the stale mounts of
+// the dead lxcfs are lazily unmounted first.
+func remountLxcfsInContainer(container *mgr.Container) error {
+	pid := strconv.FormatInt(container.State.Pid, 10)
+	for _, file := range lxcfs.LxcfsProcFiles {
+		target := "/proc/" + file
+		// the stale mount may have been unmounted, ignore the error.
+		runNsenter("-t", pid, "-m", "--", "umount", "-l", target)
+		if err := runNsenter("-t", pid, "-m", "--", "mount", "--bind", lxcfsProcFileInContainer(file), target); err != nil {
+			return fmt.Errorf("failed to remount %s: %v", target, err)
+		}
+	}
+	return nil
+}
+
+// remountLxcfs remounts the lxcfs proc files in all the running cri containers
+// with lxcfs enabled.
+func (c *CriManager) remountLxcfs(ctx context.Context) error {
+	containers, err := c.ContainerMgr.List(ctx, &mgr.ContainerListOption{
+		Labels: map[string]string{containerTypeLabelKey: containerTypeLabelContainer},
+		FilterFunc: func(container *mgr.Container) bool {
+			return container.HostConfig != nil && container.HostConfig.EnableLxcfs
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, container := range containers {
+		if err := remountLxcfsInContainer(container); err != nil {
+			log.With(ctx).Warnf("failed to remount lxcfs of container %q: %v", container.ID, err)
+			continue
+		}
+		log.With(ctx).Infof("success to remount lxcfs of container %q", container.ID)
+	}
+	return nil
+}
+
+// watchLxcfs remounts the lxcfs proc files in containers once lxcfs is restarted,
+// otherwise the containers read the stale mounts of the dead lxcfs and get
+// "Transport endpoint is not connected".
+func (c *CriManager) watchLxcfs(ctx context.Context) {
+	lastID, err := lxcfsMountID("self", lxcfs.LxcfsHomeDir)
+	if err != nil {
+		log.With(ctx).Warnf("failed to get mount of lxcfs: %v", err)
+	}
+
+	ticker := time.NewTicker(lxcfsCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		id, err := lxcfsMountID("self", lxcfs.LxcfsHomeDir)
+		if err != nil {
+			log.With(ctx).Warnf("failed to get mount of lxcfs: %v", err)
+			continue
+		}
+		// lxcfs is not mounted again yet or not restarted.
+		if id == "" || id == lastID {
+			continue
+		}
+
+		log.With(ctx).Infof("lxcfs is mounted again at %s, remount it in containers", lxcfs.LxcfsHomeDir)
+		if err := c.remountLxcfs(ctx); err != nil {
+			log.With(ctx).Warnf("failed to remount lxcfs in containers: %v", err)
+			continue
+		}
+		lastID = id
+	}
+}
@@ -0,0 +1,89 @@
+package v1alpha2
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	apitypes "github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/daemon/mgr"
+	"github.com/alibaba/pouch/lxcfs"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// lxcfsContainerLister lists the containers filtered by the option.
+type lxcfsContainerLister struct {
+	mgr.ContainerMgr
+	containers []*mgr.Container
+}
+
+func (l *lxcfsContainerLister) List(ctx context.Context, option *mgr.ContainerListOption) ([]*mgr.Container, error) {
+	var result []*mgr.Container
+	for _, c := range l.containers {
+		if option.FilterFunc == nil || option.FilterFunc(c) {
+			result = append(result, c)
+		}
+	}
+	return result, nil
+}
+
+func Test_lxcfsMountID(t *testing.T) {
+	root, err := ioutil.TempDir("", "lxcfs-mount")
+	assert.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	defer func(proc string) { procRoot = proc }(procRoot)
+	procRoot = root
+	assert.NoError(t, os.MkdirAll(filepath.Join(root, "self"), 0755))
+
+	mountinfo := filepath.Join(root, "self", "mountinfo")
+	assert.NoError(t, ioutil.WriteFile(mountinfo, []byte(
+		"22 1 8:1 / / rw,relatime - ext4 /dev/sda1 rw\n"+
+			"36 22 0:32 / /var/lib/lxcfs rw,nosuid,nodev,relatime - fuse.lxcfs lxcfs rw\n"+
+			"48 22 0:40 / /var/lib/lxcfs rw,nosuid,nodev,relatime - fuse.lxcfs lxcfs rw\n"), 0644))
+	id, err := lxcfsMountID("self", "/var/lib/lxcfs")
+	assert.NoError(t, err)
+	assert.Equal(t, "48", id)
+
+	id, err = lxcfsMountID("self", "/var/lib/other")
+	assert.NoError(t, err)
+	assert.Equal(t, "", id)
+}
+
+func Test_remountLxcfs(t *testing.T) {
+	defer func(home string, run func(...string) error) {
+		lxcfs.LxcfsHomeDir, runNsenter = home, run
+	}(lxcfs.LxcfsHomeDir, runNsenter)
+	lxcfs.LxcfsHomeDir = "/var/lib/lxcfs"
+
+	var calls []string
+	runNsenter = func(args ...string) error {
+		call := strings.Join(args, " ")
+		calls = append(calls, call)
+		if strings.Contains(call, "-t 200 ") && strings.Contains(call, "mount --bind") {
+			return fmt.Errorf("no mount binary")
+		}
+		return nil
+	}
+
+	c := &CriManager{ContainerMgr: &lxcfsContainerLister{containers: []*mgr.Container{
+		{ID: "c1", HostConfig: &apitypes.HostConfig{EnableLxcfs: true}, State: &apitypes.ContainerState{Pid: 100}},
+		{ID: "c2", HostConfig: &apitypes.HostConfig{EnableLxcfs: true}, State: &apitypes.ContainerState{Pid: 200}},
+		{ID: "c3", HostConfig: &apitypes.HostConfig{}, State: &apitypes.ContainerState{Pid: 300}},
+	}}}
+	assert.NoError(t, c.remountLxcfs(context.Background()))
+
+	// all the proc files of c1 are remounted, c2 fails at the first one
+	// and c3 without lxcfs is skipped.
+	assert.Len(t, calls, 2*len(lxcfs.LxcfsProcFiles)+2)
+	assert.Equal(t, "-t 100 -m -- umount -l /proc/uptime", calls[0])
+	assert.Equal(t, "-t 100 -m -- mount --bind /var/lib/lxc/lxcfs/proc/uptime /proc/uptime", calls[1])
+	for _, call := range calls {
+		assert.NotContains(t, call, "-t 300 ")
+	}
+}
@@ -0,0 +1,127 @@
+package v1alpha2
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+
+	anno "github.com/alibaba/pouch/cri/annotations"
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+	metatypes "github.com/alibaba/pouch/cri/v1alpha2/types"
+	"github.com/alibaba/pouch/daemon/mgr"
+)
+
+// errMemoryQoSUnsupported is returned if the memory QoS is specified on cgroup v1,
+// which has no memory.min, memory.low and memory.high.
+var errMemoryQoSUnsupported = errors.New("memory QoS is only supported on cgroup v2")
+
+// parseMemoryQoS parses the memory QoS from the annotations of the keys.
+func parseMemoryQoS(annotations map[string]string, minKey, lowKey, highKey string) (metatypes.MemoryQoS, error) {
+	var qos metatypes.MemoryQoS
+	for _, item := range []struct {
+		key   string
+		value *int64
+	}{
+		{minKey, &qos.Min},
+		{lowKey, &qos.Low},
+		{highKey, &qos.High},
+	} {
+		v, ok := annotations[item.key]
+		if !ok {
+			continue
+		}
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n < 0 {
+			return qos, fmt.Errorf("invalid %s %q: must be a non-negative integer", item.key, v)
+		}
+		*item.value = n
+	}
+
+	if qos.Min > 0 && qos.Low > 0 && qos.Min > qos.Low {
+		return qos, fmt.Errorf("memory min %d should not be greater than memory low %d", qos.Min, qos.Low)
+	}
+	if qos.High > 0 && (qos.Min > qos.High || qos.Low > qos.High) {
+		return qos, fmt.Errorf("memory min and low should not be greater than memory high %d", qos.High)
+	}
+	return qos, nil
+}
+
+// containerMemoryQoS parses the memory QoS of container from the annotations.
+func containerMemoryQoS(annotations map[string]string) (metatypes.MemoryQoS, error) {
+	return parseMemoryQoS(annotations, anno.MemoryMinExtendAnnotation, anno.MemoryLowExtendAnnotation, anno.MemoryHighExtendAnnotation)
+}
+
+// podMemoryQoS parses the memory QoS of pod from the annotations.
+func podMemoryQoS(annotations map[string]string) (metatypes.MemoryQoS, error) {
+	return parseMemoryQoS(annotations, anno.PodMemoryMinExtendAnnotation, anno.PodMemoryLowExtendAnnotation, anno.PodMemoryHighExtendAnnotation)
+}
+
+// memoryQoSCgroupFiles returns the cgroup files of the memory QoS specified.
+func memoryQoSCgroupFiles(qos metatypes.MemoryQoS) map[string]int64 {
+	files := make(map[string]int64)
+	for file, value := range map[string]int64{
+		"memory.min":  qos.Min,
+		"memory.low":  qos.Low,
+		"memory.high": qos.High,
+	} {
+		if value != 0 {
+			files[file] = value
+		}
+	}
+	return files
+}
+
+// setMemoryQoS writes the memory QoS specified into the cgroup.
+func setMemoryQoS(path string, qos metatypes.MemoryQoS) error {
+	if !isCgroup2UnifiedMode() {
+		return errMemoryQoSUnsupported
+	}
+
+	for file, value := range memoryQoSCgroupFiles(qos) {
+		if err := ioutil.WriteFile(filepath.Join(path, file), []byte(strconv.FormatInt(value, 10)), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyPodMemoryQoS sets the memory QoS of the pod cgroup if specified.
+func (c *CriManager) applyPodMemoryQoS(sandboxMeta *metatypes.SandboxMeta, config *runtime.PodSandboxConfig) error {
+	if sandboxMeta.MemoryQoS.IsEmpty() {
+		return nil
+	}
+
+	cgroupParent := config.GetLinux().GetCgroupParent()
+	if cgroupParent == "" {
+		return fmt.Errorf("failed to set memory QoS of pod: cgroup parent is not specified")
+	}
+
+	useSystemd := c.DaemonConfig != nil && c.DaemonConfig.UseSystemd()
+	path, err := podCgroupPath(unifiedCgroupRoot, cgroupParent, useSystemd)
+	if err == nil {
+		err = setMemoryQoS(path, sandboxMeta.MemoryQoS)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to set memory QoS of pod cgroup %q: %v", cgroupParent, err)
+	}
+	return nil
+}
+
+// applyContainerMemoryQoS sets the memory QoS of the running container if specified.
+func applyContainerMemoryQoS(container *mgr.Container) error {
+	qos, err := containerMemoryQoS(containerAnnotations(container))
+	if err != nil || qos.IsEmpty() {
+		return err
+	}
+
+	path, err := processCgroupPath(container.State.Pid, "memory")
+	if err == nil {
+		err = setMemoryQoS(path, qos)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to set memory QoS of container %q: %v", container.ID, err)
+	}
+	return nil
+}
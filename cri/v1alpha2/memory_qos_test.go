@@ -0,0 +1,119 @@
+package v1alpha2
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	apitypes "github.com/alibaba/pouch/apis/types"
+	anno "github.com/alibaba/pouch/cri/annotations"
+	metatypes "github.com/alibaba/pouch/cri/v1alpha2/types"
+	"github.com/alibaba/pouch/daemon/mgr"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseMemoryQoS(t *testing.T) {
+	for _, tt := range []struct {
+		annotations map[string]string
+		want        metatypes.MemoryQoS
+		wantErr     bool
+	}{
+		{annotations: nil},
+		{
+			annotations: map[string]string{anno.MemoryMinExtendAnnotation: "1024", anno.MemoryHighExtendAnnotation: "4096"},
+			want:        metatypes.MemoryQoS{Min: 1024, High: 4096},
+		},
+		{annotations: map[string]string{anno.MemoryLowExtendAnnotation: "-1"}, wantErr: true},
+		{annotations: map[string]string{anno.MemoryLowExtendAnnotation: "1Gi"}, wantErr: true},
+		{annotations: map[string]string{anno.MemoryMinExtendAnnotation: "2048", anno.MemoryLowExtendAnnotation: "1024"}, wantErr: true},
+		{annotations: map[string]string{anno.MemoryLowExtendAnnotation: "8192", anno.MemoryHighExtendAnnotation: "4096"}, wantErr: true},
+		// the annotations of pod are not the ones of container.
+		{annotations: map[string]string{anno.PodMemoryMinExtendAnnotation: "1024"}},
+	} {
+		got, err := containerMemoryQoS(tt.annotations)
+		if tt.wantErr {
+			assert.Error(t, err, "annotations %v", tt.annotations)
+			continue
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, tt.want, got)
+	}
+
+	qos, err := podMemoryQoS(map[string]string{anno.PodMemoryLowExtendAnnotation: "1024"})
+	assert.NoError(t, err)
+	assert.Equal(t, metatypes.MemoryQoS{Low: 1024}, qos)
+}
+
+func Test_applyContainerMemoryQoS(t *testing.T) {
+	root, err := ioutil.TempDir("", "memory-qos")
+	assert.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	defer func(cgroupRoot, proc string, isUnified func() bool) {
+		unifiedCgroupRoot, procRoot, isCgroup2UnifiedMode = cgroupRoot, proc, isUnified
+	}(unifiedCgroupRoot, procRoot, isCgroup2UnifiedMode)
+	unifiedCgroupRoot = filepath.Join(root, "cgroup")
+	procRoot = filepath.Join(root, "proc")
+
+	cgroupPath := filepath.Join(unifiedCgroupRoot, "kubepods", "pod1", "c1")
+	assert.NoError(t, os.MkdirAll(cgroupPath, 0755))
+	assert.NoError(t, os.MkdirAll(filepath.Join(procRoot, "100"), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(procRoot, "100", "cgroup"), []byte("0::/kubepods/pod1/c1\n"), 0644))
+
+	container := &mgr.Container{
+		ID: "c1",
+		Config: &apitypes.ContainerConfig{
+			Labels: makeLabels(nil, map[string]string{
+				anno.MemoryMinExtendAnnotation:  "1024",
+				anno.MemoryHighExtendAnnotation: "4096",
+			}),
+			// the annotations updated take precedence.
+			SpecAnnotation: map[string]string{anno.MemoryHighExtendAnnotation: "8192"},
+		},
+		State: &apitypes.ContainerState{Pid: 100},
+	}
+
+	// memory QoS is only supported on cgroup v2.
+	isCgroup2UnifiedMode = func() bool { return false }
+	assert.Error(t, applyContainerMemoryQoS(container))
+
+	isCgroup2UnifiedMode = func() bool { return true }
+	assert.NoError(t, applyContainerMemoryQoS(container))
+	for file, want := range map[string]string{"memory.min": "1024", "memory.high": "8192"} {
+		got, err := ioutil.ReadFile(filepath.Join(cgroupPath, file))
+		assert.NoError(t, err)
+		assert.Equal(t, want, string(got))
+	}
+	_, err = os.Stat(filepath.Join(cgroupPath, "memory.low"))
+	assert.True(t, os.IsNotExist(err))
+
+	// the process not in the unified hierarchy.
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(procRoot, "100", "cgroup"), []byte("4:memory:/kubepods\n"), 0644))
+	assert.Error(t, applyContainerMemoryQoS(container))
+}
+
+func Test_containerCgroupFiles(t *testing.T) {
+	defer func(isUnified func() bool) { isCgroup2UnifiedMode = isUnified }(isCgroup2UnifiedMode)
+	annotations := map[string]string{anno.MemoryMinExtendAnnotation: "1024", anno.MemoryHighExtendAnnotation: "4096"}
+
+	// memory QoS is rejected on cgroup v1.
+	isCgroup2UnifiedMode = func() bool { return false }
+	_, err := containerCgroupFiles(annotations)
+	assert.Error(t, err)
+	files, err := containerCgroupFiles(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, files)
+
+	isCgroup2UnifiedMode = func() bool { return true }
+	files, err = containerCgroupFiles(annotations)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		mgr.CgroupFileSpecAnnotationPrefix + "memory.min":  "1024",
+		mgr.CgroupFileSpecAnnotationPrefix + "memory.high": "4096",
+	}, files)
+
+	_, err = containerCgroupFiles(map[string]string{anno.MemoryMinExtendAnnotation: "-1"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,131 @@
+package v1alpha2
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/alibaba/pouch/daemon/mgr"
+)
+
+// metricsCollector collects the metrics of containers from containerd in
+// batches, the collected metrics are reused within the staleness window, and
+// the containers being collected are not collected again by the concurrent
+// stats requests.
+type metricsCollector struct {
+	mu         sync.Mutex
+	staleness  time.Duration
+	batchStats func(ctx context.Context, ids []string) (map[string]*mgr.ContainerMetrics, error)
+
+	// collected are the metrics collected keyed by the container ID.
+	collected map[string]*collectedMetrics
+	// collecting are the collections in flight keyed by the container ID.
+	collecting map[string]*metricsCollection
+}
+
+// collectedMetrics are the metrics of a container, nil if it's not running.
+type collectedMetrics struct {
+	metrics     *mgr.ContainerMetrics
+	collectedAt time.Time
+}
+
+// metricsCollection is a batch of containers being collected, done is
+// closed once it's finished with err.
+type metricsCollection struct {
+	done chan struct{}
+	err  error
+}
+
+func newMetricsCollector(staleness time.Duration, batchStats func(ctx context.Context, ids []string) (map[string]*mgr.ContainerMetrics, error)) *metricsCollector {
+	return &metricsCollector{
+		staleness:  staleness,
+		batchStats: batchStats,
+		collected:  make(map[string]*collectedMetrics),
+		collecting: make(map[string]*metricsCollection),
+	}
+}
+
+// get returns the metrics of containers keyed by the container ID, the
+// containers which are not running are omitted.
+func (mc *metricsCollector) get(ctx context.Context, ids []string) (map[string]*mgr.ContainerMetrics, error) {
+	if mc.staleness <= 0 {
+		return mc.batchStats(ctx, ids)
+	}
+
+	// the stale containers are collected by this request, or waited for if
+	// they are being collected by others.
+	mc.mu.Lock()
+	var (
+		stale   []string
+		waiting []*metricsCollection
+		own     = &metricsCollection{done: make(chan struct{})}
+	)
+	for _, id := range ids {
+		if m, ok := mc.collected[id]; ok && time.Since(m.collectedAt) <= mc.staleness {
+			continue
+		}
+		if collection, ok := mc.collecting[id]; ok {
+			waiting = append(waiting, collection)
+			continue
+		}
+		mc.collecting[id] = own
+		stale = append(stale, id)
+	}
+	mc.mu.Unlock()
+
+	if len(stale) > 0 {
+		mc.collect(ctx, stale, own)
+		if own.err != nil {
+			return nil, own.err
+		}
+	}
+	for _, collection := range waiting {
+		select {
+		case <-collection.done:
+			if collection.err != nil {
+				return nil, collection.err
+			}
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	result := make(map[string]*mgr.ContainerMetrics, len(ids))
+	for _, id := range ids {
+		if m, ok := mc.collected[id]; ok && m.metrics != nil {
+			result[id] = m.metrics
+		}
+	}
+	return result, nil
+}
+
+// collect collects the metrics of containers and merges them into the
+// collected ones, the failure keeps the ones collected before.
+func (mc *metricsCollector) collect(ctx context.Context, ids []string, collection *metricsCollection) {
+	metrics, err := mc.batchStats(ctx, ids)
+	collectedAt := time.Now()
+
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	for _, id := range ids {
+		delete(mc.collecting, id)
+		if err == nil {
+			mc.collected[id] = &collectedMetrics{metrics: metrics[id], collectedAt: collectedAt}
+		}
+	}
+	collection.err = err
+	close(collection.done)
+}
+
+// forget drops the metrics collected of the container.
+func (mc *metricsCollector) forget(id string) {
+	if mc == nil {
+		return
+	}
+
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	delete(mc.collected, id)
+}
@@ -0,0 +1,136 @@
+package v1alpha2
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alibaba/pouch/daemon/mgr"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricsCollector(t *testing.T) {
+	var calls [][]string
+	batchStats := func(ctx context.Context, ids []string) (map[string]*mgr.ContainerMetrics, error) {
+		calls = append(calls, ids)
+		metrics := make(map[string]*mgr.ContainerMetrics)
+		for _, id := range ids {
+			if id == "err" {
+				return nil, fmt.Errorf("failed")
+			}
+			// c3 is not running.
+			if id != "c3" {
+				metrics[id] = &mgr.ContainerMetrics{}
+			}
+		}
+		return metrics, nil
+	}
+	ctx := context.Background()
+
+	// collect every time without staleness.
+	mc := newMetricsCollector(0, batchStats)
+	metrics, err := mc.get(ctx, []string{"c1", "c2", "c3"})
+	assert.NoError(t, err)
+	assert.Len(t, metrics, 2)
+	_, err = mc.get(ctx, []string{"c1"})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"c1", "c2", "c3"}, {"c1"}}, calls)
+
+	// reuse the collected ones within staleness.
+	calls = nil
+	mc = newMetricsCollector(time.Hour, batchStats)
+	_, err = mc.get(ctx, []string{"c1", "c2", "c3"})
+	assert.NoError(t, err)
+	metrics, err = mc.get(ctx, []string{"c1", "c3"})
+	assert.NoError(t, err)
+	assert.Len(t, metrics, 1)
+	assert.NotNil(t, metrics["c1"])
+	assert.Len(t, calls, 1)
+
+	// only the container not collected is collected, and merged into the others.
+	metrics, err = mc.get(ctx, []string{"c1", "c4"})
+	assert.NoError(t, err)
+	assert.Len(t, metrics, 2)
+	assert.Equal(t, []string{"c4"}, calls[1])
+	metrics, err = mc.get(ctx, []string{"c2"})
+	assert.NoError(t, err)
+	assert.NotNil(t, metrics["c2"])
+	assert.Len(t, calls, 2)
+
+	// collect again if stale.
+	mc.collected["c1"].collectedAt = time.Now().Add(-2 * time.Hour)
+	_, err = mc.get(ctx, []string{"c1", "c2"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"c1"}, calls[2])
+
+	// the failure keeps the last collection.
+	_, err = mc.get(ctx, []string{"err"})
+	assert.Error(t, err)
+	metrics, err = mc.get(ctx, []string{"c1"})
+	assert.NoError(t, err)
+	assert.NotNil(t, metrics["c1"])
+	assert.Len(t, calls, 4)
+
+	// the container removed is collected again.
+	mc.forget("c1")
+	_, err = mc.get(ctx, []string{"c1"})
+	assert.NoError(t, err)
+	assert.Len(t, calls, 5)
+}
+
+func TestMetricsCollectorConcurrent(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		calls int
+	)
+	started, release := make(chan struct{}), make(chan struct{})
+	batchStats := func(ctx context.Context, ids []string) (map[string]*mgr.ContainerMetrics, error) {
+		mu.Lock()
+		calls++
+		mu.Unlock()
+		if len(ids) == 2 {
+			close(started)
+			<-release
+		}
+		metrics := make(map[string]*mgr.ContainerMetrics)
+		for _, id := range ids {
+			metrics[id] = &mgr.ContainerMetrics{}
+		}
+		return metrics, nil
+	}
+	mc := newMetricsCollector(time.Hour, batchStats)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := mc.get(context.Background(), []string{"c1", "c2"})
+		done <- err
+	}()
+	<-started
+
+	// the container not being collected is collected without waiting for the others.
+	metrics, err := mc.get(context.Background(), []string{"c3"})
+	assert.NoError(t, err)
+	assert.NotNil(t, metrics["c3"])
+
+	// the request of the containers being collected waits for the collection.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = mc.get(ctx, []string{"c1"})
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	waited := make(chan error, 1)
+	go func() {
+		metrics, err := mc.get(context.Background(), []string{"c1"})
+		if err == nil && metrics["c1"] == nil {
+			err = fmt.Errorf("no metrics of c1")
+		}
+		waited <- err
+	}()
+	close(release)
+	assert.NoError(t, <-done)
+	assert.NoError(t, <-waited)
+	assert.Equal(t, 2, calls)
+}
@@ -0,0 +1,107 @@
+package v1alpha2
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/alibaba/pouch/daemon/mgr"
+
+	"golang.org/x/sys/unix"
+)
+
+// hardenMountOptions are the security-relevant options of mounts, with the
+// flags of them in the statfs of host path, which are the same as the ones of mount.
+var hardenMountOptions = []struct {
+	option string
+	flag   int64
+}{
+	{option: "nosuid", flag: unix.MS_NOSUID},
+	{option: "nodev", flag: unix.MS_NODEV},
+	{option: "noexec", flag: unix.MS_NOEXEC},
+}
+
+// sourceMountOptions returns the nosuid, nodev and noexec options of the mount
+// of host path, e.g. the tmpfs of secrets, which are kept by the bind mount.
+// Otherwise they are cleared when the bind mount is remounted, e.g. readonly.
+func sourceMountOptions(hostPath string) []string {
+	var st unix.Statfs_t
+	if err := unix.Statfs(hostPath, &st); err != nil {
+		return nil
+	}
+
+	var opts []string
+	for _, o := range hardenMountOptions {
+		if st.Flags&o.flag != 0 {
+			opts = append(opts, o.option)
+		}
+	}
+	return opts
+}
+
+// verifyMountOptions checks that the hardening options of the bind mounts are
+// applied in the mount namespace of container. The mounts not found in it are
+// skipped, e.g. the ones in the VM of sandbox.
+func verifyMountOptions(container *mgr.Container) error {
+	if container.State == nil || container.State.Pid <= 0 {
+		return nil
+	}
+
+	expected := make(map[string][]string)
+	for _, mp := range container.Mounts {
+		var opts []string
+		for _, m := range strings.Split(mp.Mode, ",") {
+			for _, o := range hardenMountOptions {
+				if m == o.option {
+					opts = append(opts, m)
+				}
+			}
+		}
+		if len(opts) > 0 {
+			expected[filepath.Clean(mp.Destination)] = opts
+		}
+	}
+	if len(expected) == 0 {
+		return nil
+	}
+
+	f, err := os.Open(filepath.Join(procRoot, strconv.FormatInt(container.State.Pid, 10), "mountinfo"))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	// the mount point and the per-mount options are the 5th and 6th fields.
+	var missing []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 6 {
+			continue
+		}
+		opts, ok := expected[fields[4]]
+		if !ok {
+			continue
+		}
+		applied := make(map[string]bool)
+		for _, o := range strings.Split(fields[5], ",") {
+			applied[o] = true
+		}
+		for _, o := range opts {
+			if !applied[o] {
+				missing = append(missing, fmt.Sprintf("%s of %s", o, fields[4]))
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("mount options %s are not applied", strings.Join(missing, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package v1alpha2
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	apitypes "github.com/alibaba/pouch/apis/types"
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+	"github.com/alibaba/pouch/daemon/mgr"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/sys/unix"
+)
+
+func TestSourceMountOptions(t *testing.T) {
+	assert.Empty(t, sourceMountOptions("/not/exist"))
+
+	if os.Getuid() != 0 {
+		t.Skip("mount requires root")
+	}
+	dir, err := ioutil.TempDir("", "cri-mount-options")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	if err := unix.Mount("tmpfs", dir, "tmpfs", unix.MS_NOSUID|unix.MS_NODEV|unix.MS_NOEXEC, ""); err != nil {
+		t.Skipf("tmpfs could not be mounted: %v", err)
+	}
+	defer unix.Unmount(dir, unix.MNT_DETACH)
+
+	assert.Equal(t, []string{"nosuid", "nodev", "noexec"}, sourceMountOptions(dir))
+	assert.Equal(t, []string{dir + ":/secret:ro,nosuid,nodev,noexec,rslave"}, generateMountBindings([]*runtime.Mount{{
+		HostPath:      dir,
+		ContainerPath: "/secret",
+		Readonly:      true,
+		Propagation:   runtime.MountPropagation_PROPAGATION_HOST_TO_CONTAINER,
+	}}))
+}
+
+func TestVerifyMountOptions(t *testing.T) {
+	root, err := ioutil.TempDir("", "cri-mount-options")
+	assert.NoError(t, err)
+	defer os.RemoveAll(root)
+	defer func(proc string) { procRoot = proc }(procRoot)
+	procRoot = root
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(root, "100"), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(root, "100", "mountinfo"), []byte(
+		"600 500 0:50 / / rw,relatime - overlay overlay rw\n"+
+			"601 600 0:51 / /secret ro,nosuid,nodev,noexec,relatime - tmpfs tmpfs rw\n"+
+			"602 600 8:1 /data /data ro,relatime - ext4 /dev/sda1 rw\n"), 0644))
+
+	container := &mgr.Container{
+		State: &apitypes.ContainerState{Pid: 100},
+		Mounts: []*apitypes.MountPoint{
+			{Destination: "/secret", Mode: "ro,nosuid,nodev,noexec"},
+			{Destination: "/data", Mode: "ro"},
+			// the mount in the VM of sandbox is not found.
+			{Destination: "/vm", Mode: "nosuid"},
+		},
+	}
+	assert.NoError(t, verifyMountOptions(container))
+
+	container.Mounts[1].Mode = "ro,nodev,noexec"
+	err = verifyMountOptions(container)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "nodev of /data, noexec of /data")
+
+	// the stopped container is not verified.
+	container.State.Pid = 0
+	assert.NoError(t, verifyMountOptions(container))
+}
@@ -0,0 +1,152 @@
+package v1alpha2
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/alibaba/pouch/pkg/log"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	propagationShared  = "shared"
+	propagationSlave   = "slave"
+	propagationPrivate = "private"
+)
+
+// mountPropagation is the propagation of the mount holding a path, which must
+// be shared for the bidirectional mount propagation of containers, otherwise
+// the mounts in containers silently never reach the host.
+type mountPropagation struct {
+	// Path is the path checked.
+	Path string `json:"path"`
+	// Mountpoint is the mount point of the mount holding the path.
+	Mountpoint string `json:"mountpoint"`
+	// Propagation is shared, slave or private.
+	Propagation string `json:"propagation"`
+	// Fixed is true if the mount is remounted rshared.
+	Fixed bool `json:"fixed,omitempty"`
+	// Error is the error of the check or the remount.
+	Error string `json:"error,omitempty"`
+}
+
+// propagationPaths returns the paths whose mounts must be shared, the root and
+// the home dir of daemon holding the volumes and sandboxes.
+func (c *CriManager) propagationPaths() []string {
+	paths := []string{"/"}
+	if c.DaemonConfig != nil && c.DaemonConfig.HomeDir != "" {
+		paths = append(paths, c.DaemonConfig.HomeDir)
+	}
+	return paths
+}
+
+// checkMountPropagation returns the propagation of the mounts holding the
+// paths, the ones not shared are remounted rshared if fix is true.
+func checkMountPropagation(paths []string, fix bool) []*mountPropagation {
+	var results []*mountPropagation
+	for _, p := range paths {
+		result := &mountPropagation{Path: p}
+		results = append(results, result)
+
+		mountpoint, propagation, err := pathPropagation(p)
+		if err != nil {
+			result.Error = err.Error()
+			continue
+		}
+		result.Mountpoint, result.Propagation = mountpoint, propagation
+		if propagation == propagationShared || !fix {
+			continue
+		}
+
+		if err := unix.Mount("", mountpoint, "", unix.MS_SHARED|unix.MS_REC, ""); err != nil {
+			result.Error = fmt.Sprintf("failed to remount %s rshared: %v", mountpoint, err)
+			continue
+		}
+		result.Fixed = true
+		if _, result.Propagation, err = pathPropagation(p); err != nil {
+			result.Error = err.Error()
+		}
+	}
+	return results
+}
+
+// ensureMountPropagation checks the propagation of mounts on startup, and
+// remounts them rshared if fix is true.
+func (c *CriManager) ensureMountPropagation(fix bool) {
+	for _, r := range checkMountPropagation(c.propagationPaths(), fix) {
+		switch {
+		case r.Error != "":
+			log.With(nil).Warnf("failed to ensure mount propagation of %s: %s", r.Path, r.Error)
+		case r.Fixed:
+			log.With(nil).Infof("mount %s of %s is remounted rshared", r.Mountpoint, r.Path)
+		case r.Propagation != propagationShared:
+			log.With(nil).Warnf("mount %s of %s is %s, the bidirectional mount propagation of cri containers would not work, set --cri-ensure-mount-propagation to remount it rshared", r.Mountpoint, r.Path, r.Propagation)
+		}
+	}
+}
+
+// pathPropagation returns the mount point and the propagation of the mount
+// holding the path in the mount namespace of pouchd.
+func pathPropagation(path string) (string, string, error) {
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return "", "", err
+	}
+
+	f, err := os.Open(filepath.Join(procRoot, "self", "mountinfo"))
+	if err != nil {
+		return "", "", err
+	}
+	defer f.Close()
+
+	return mountinfoPropagation(f, resolved)
+}
+
+// mountinfoPropagation returns the mount point and the propagation of the last
+// mount of the longest mount point holding the path in the mountinfo.
+func mountinfoPropagation(r io.Reader, path string) (string, string, error) {
+	var mountpoint, propagation string
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		// the optional fields are between the 6th field and the separator.
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 7 {
+			continue
+		}
+		mp := fields[4]
+		if mp != path && mp != "/" && !strings.HasPrefix(path, mp+"/") {
+			continue
+		}
+		if len(mp) < len(mountpoint) {
+			continue
+		}
+
+		mountpoint, propagation = mp, propagationPrivate
+		for _, opt := range fields[6:] {
+			if opt == "-" {
+				break
+			}
+			if strings.HasPrefix(opt, "shared:") {
+				propagation = propagationShared
+				break
+			}
+			if strings.HasPrefix(opt, "master:") {
+				propagation = propagationSlave
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", "", err
+	}
+
+	if mountpoint == "" {
+		return "", "", fmt.Errorf("no mount holds %s", path)
+	}
+	return mountpoint, propagation, nil
+}
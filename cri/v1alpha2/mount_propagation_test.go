@@ -0,0 +1,65 @@
+package v1alpha2
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testMountinfo = `22 1 8:1 / / rw,relatime shared:1 - ext4 /dev/sda1 rw
+23 22 0:21 / /proc rw,nosuid,nodev,noexec,relatime shared:5 - proc proc rw
+40 22 8:2 / /var/lib/pouch rw,relatime master:12 - ext4 /dev/sda2 rw
+41 22 8:3 / /var/lib/pouch-data rw,relatime - ext4 /dev/sda3 rw
+42 40 8:4 / /var/lib/pouch/volumes rw,relatime shared:30 master:12 - ext4 /dev/sda4 rw
+`
+
+func TestMountinfoPropagation(t *testing.T) {
+	for _, tc := range []struct {
+		path        string
+		mountpoint  string
+		propagation string
+	}{
+		{path: "/", mountpoint: "/", propagation: propagationShared},
+		{path: "/etc", mountpoint: "/", propagation: propagationShared},
+		{path: "/var/lib/pouch", mountpoint: "/var/lib/pouch", propagation: propagationSlave},
+		{path: "/var/lib/pouch/sandboxes", mountpoint: "/var/lib/pouch", propagation: propagationSlave},
+		{path: "/var/lib/pouch-data/x", mountpoint: "/var/lib/pouch-data", propagation: propagationPrivate},
+		{path: "/var/lib/pouch/volumes/v1", mountpoint: "/var/lib/pouch/volumes", propagation: propagationShared},
+	} {
+		mountpoint, propagation, err := mountinfoPropagation(strings.NewReader(testMountinfo), tc.path)
+		assert.NoError(t, err, tc.path)
+		assert.Equal(t, tc.mountpoint, mountpoint, tc.path)
+		assert.Equal(t, tc.propagation, propagation, tc.path)
+	}
+
+	// the mount stacked later on the same mount point wins.
+	mountpoint, propagation, err := mountinfoPropagation(strings.NewReader(testMountinfo+
+		"50 40 8:5 / /var/lib/pouch rw,relatime - ext4 /dev/sda5 rw\n"), "/var/lib/pouch/sandboxes")
+	assert.NoError(t, err)
+	assert.Equal(t, "/var/lib/pouch", mountpoint)
+	assert.Equal(t, propagationPrivate, propagation)
+
+	_, _, err = mountinfoPropagation(strings.NewReader(""), "/")
+	assert.Error(t, err)
+}
+
+func TestCheckMountPropagation(t *testing.T) {
+	root, err := ioutil.TempDir("", "cri-mount-propagation")
+	assert.NoError(t, err)
+	defer os.RemoveAll(root)
+	defer func(proc string) { procRoot = proc }(procRoot)
+	procRoot = root
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(root, "self"), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(root, "self", "mountinfo"), []byte(testMountinfo), 0644))
+
+	results := checkMountPropagation([]string{"/", filepath.Join(root, "missing")}, false)
+	assert.Len(t, results, 2)
+	assert.Equal(t, &mountPropagation{Path: "/", Mountpoint: "/", Propagation: propagationShared}, results[0])
+	assert.NotEmpty(t, results[1].Error)
+	assert.False(t, results[1].Fixed)
+}
@@ -0,0 +1,45 @@
+package v1alpha2
+
+import (
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// nameReservations keeps the names of sandboxes and containers being created,
+// which are built from the k8s name tuple, e.g. name, namespace, uid and attempt.
+// The concurrent requests of the same name, e.g. the retries of kubelet after
+// a timeout, are rejected instead of racing to create the duplicates.
+type nameReservations struct {
+	sync.Mutex
+	names map[string]struct{}
+}
+
+// newNameReservations creates an empty name reservation index.
+func newNameReservations() *nameReservations {
+	return &nameReservations{
+		names: make(map[string]struct{}),
+	}
+}
+
+// reserve reserves the name, AlreadyExists is returned if the name is being
+// reserved by another request.
+func (n *nameReservations) reserve(name string) error {
+	n.Lock()
+	defer n.Unlock()
+
+	if _, ok := n.names[name]; ok {
+		return status.Errorf(codes.AlreadyExists, "name %q is reserved by another request in progress", name)
+	}
+	n.names[name] = struct{}{}
+	return nil
+}
+
+// release releases the name once the request is completed or failed.
+func (n *nameReservations) release(name string) {
+	n.Lock()
+	defer n.Unlock()
+
+	delete(n.names, name)
+}
@@ -0,0 +1,22 @@
+package v1alpha2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestNameReservations(t *testing.T) {
+	names := newNameReservations()
+	assert.NoError(t, names.reserve("k8s_POD_nginx_default_uid_0"))
+	assert.NoError(t, names.reserve("k8s_POD_nginx_default_uid_1"))
+
+	err := names.reserve("k8s_POD_nginx_default_uid_0")
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	// the name could be reserved again once released.
+	names.release("k8s_POD_nginx_default_uid_0")
+	assert.NoError(t, names.reserve("k8s_POD_nginx_default_uid_0"))
+}
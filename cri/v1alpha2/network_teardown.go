@@ -0,0 +1,133 @@
+package v1alpha2
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"reflect"
+	"time"
+
+	metatypes "github.com/alibaba/pouch/cri/v1alpha2/types"
+	"github.com/alibaba/pouch/pkg/log"
+	"github.com/alibaba/pouch/pkg/meta"
+)
+
+// networkTeardownRetryPeriod is the interval of retrying the failed network teardowns.
+var networkTeardownRetryPeriod = 30 * time.Second
+
+// newNetworkTeardownStore creates the store persisting the failed network teardowns.
+func newNetworkTeardownStore(homeDir string) (*meta.Store, error) {
+	return meta.NewStore(meta.Config{
+		Driver:  "local",
+		BaseDir: path.Join(homeDir, "sandboxes-teardown"),
+		Buckets: []meta.Bucket{
+			{
+				Name: meta.MetaJSONFile,
+				Type: reflect.TypeOf(metatypes.NetworkTeardownTask{}),
+			},
+		},
+	})
+}
+
+// enqueueNetworkTeardown records the failed network teardown of sandbox,
+// the background worker will retry it until success.
+func (c *CriManager) enqueueNetworkTeardown(id, netnsPath string, removeNetNS bool, sandboxMeta *metatypes.SandboxMeta, teardownErr error) error {
+	c.teardownLocks.Lock(id)
+	defer c.teardownLocks.Unlock(id)
+
+	task := &metatypes.NetworkTeardownTask{
+		ID:             id,
+		NetNS:          netnsPath,
+		RemoveNetNS:    removeNetNS,
+		Config:         sandboxMeta.Config,
+		NetworkResults: sandboxMeta.NetworkResults,
+		Attempts:       1,
+		LastError:      teardownErr.Error(),
+	}
+	if res, err := c.NetworkTeardownStore.Get(id); err == nil {
+		task.Attempts += res.(*metatypes.NetworkTeardownTask).Attempts
+	}
+
+	if err := c.NetworkTeardownStore.Put(task); err != nil {
+		return fmt.Errorf("failed to record network teardown of sandbox %q: %v", id, err)
+	}
+	return nil
+}
+
+// retryNetworkTeardown tries to teardown the network recorded in the pending
+// task of sandbox, and removes the task once it succeeds. The retries of the
+// same sandbox are serialized, and it's a no-op if there is no pending task,
+// e.g. it's done by another retry.
+func (c *CriManager) retryNetworkTeardown(id string) error {
+	c.teardownLocks.Lock(id)
+	defer c.teardownLocks.Unlock(id)
+
+	res, err := c.NetworkTeardownStore.Get(id)
+	if err != nil {
+		// no pending teardown.
+		return nil
+	}
+	task := res.(*metatypes.NetworkTeardownTask)
+
+	if err := c.teardownNetwork(task.ID, task.NetNS, task.Config, task.NetworkResults); err != nil {
+		task.Attempts++
+		task.LastError = err.Error()
+		if putErr := c.NetworkTeardownStore.Put(task); putErr != nil {
+			log.With(nil).Errorf("failed to update network teardown of sandbox %q: %v", task.ID, putErr)
+		}
+		return fmt.Errorf("attempt %d: %v", task.Attempts, err)
+	}
+
+	if task.RemoveNetNS {
+		if err := c.CniMgr.CloseNetNS(task.NetNS); err != nil {
+			return fmt.Errorf("failed to close net ns %s of sandbox %q: %v", task.NetNS, task.ID, err)
+		}
+		if err := c.CniMgr.RemoveNetNS(task.NetNS); err != nil {
+			return fmt.Errorf("failed to remove net ns %s of sandbox %q: %v", task.NetNS, task.ID, err)
+		}
+	}
+
+	return c.NetworkTeardownStore.Remove(task.ID)
+}
+
+// flushNetworkTeardown retries the pending network teardown of the given sandbox
+// synchronously. It's a no-op if there is no pending teardown.
+func (c *CriManager) flushNetworkTeardown(id string) error {
+	return c.retryNetworkTeardown(id)
+}
+
+// drainNetworkTeardowns retries all the pending network teardowns until ctx is done.
+func (c *CriManager) drainNetworkTeardowns(ctx context.Context) {
+	ids, err := c.NetworkTeardownStore.Keys()
+	if err != nil {
+		log.With(nil).Errorf("failed to list pending network teardowns: %v", err)
+		return
+	}
+
+	for _, id := range ids {
+		if ctx.Err() != nil {
+			return
+		}
+		if err := c.retryNetworkTeardown(id); err != nil {
+			log.With(nil).Warnf("failed to retry network teardown of sandbox %q: %v", id, err)
+			continue
+		}
+		log.With(nil).Infof("success to retry network teardown of sandbox %q", id)
+	}
+}
+
+// startNetworkTeardownWorker drains the pending network teardowns periodically
+// until the workers are stopped.
+func (c *CriManager) startNetworkTeardownWorker() {
+	c.workers.run(func(ctx context.Context) {
+		for {
+			c.drainNetworkTeardowns(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(networkTeardownRetryPeriod):
+			}
+		}
+	})
+}
@@ -0,0 +1,134 @@
+package v1alpha2
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+	cni "github.com/alibaba/pouch/cri/ocicni"
+	metatypes "github.com/alibaba/pouch/cri/v1alpha2/types"
+	"github.com/alibaba/pouch/pkg/kmutex"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// teardownCniMgr counts the network teardowns, and the ones running at once.
+type teardownCniMgr struct {
+	cni.CniMgr
+	sync.Mutex
+	err        error
+	delay      time.Duration
+	calls      int
+	running    int
+	concurrent int
+}
+
+func (f *teardownCniMgr) GetDefaultNetworkName() string {
+	return "pod"
+}
+
+func (f *teardownCniMgr) TearDownPodNetwork(podNetwork *cni.PodNetwork, prevResults []*cni.NetworkResult) error {
+	f.Lock()
+	f.calls++
+	f.running++
+	if f.running > f.concurrent {
+		f.concurrent = f.running
+	}
+	f.Unlock()
+
+	time.Sleep(f.delay)
+
+	f.Lock()
+	defer f.Unlock()
+	f.running--
+	return f.err
+}
+
+func newTeardownTestManager(t *testing.T, cniMgr cni.CniMgr) (*CriManager, func()) {
+	dir, err := ioutil.TempDir("", "network-teardown")
+	assert.NoError(t, err)
+	store, err := newNetworkTeardownStore(dir)
+	assert.NoError(t, err)
+
+	c := &CriManager{CniMgr: cniMgr, NetworkTeardownStore: store, teardownLocks: kmutex.New()}
+	return c, func() { os.RemoveAll(dir) }
+}
+
+func TestRetryNetworkTeardown(t *testing.T) {
+	cniMgr := &teardownCniMgr{err: errors.New("cni plugin not ready")}
+	c, cleanup := newTeardownTestManager(t, cniMgr)
+	defer cleanup()
+
+	sandboxMeta := &metatypes.SandboxMeta{ID: "s1", Config: &runtime.PodSandboxConfig{}}
+	assert.NoError(t, c.enqueueNetworkTeardown("s1", "/var/run/netns/cni-1", false, sandboxMeta, errors.New("timeout")))
+
+	// the failed retry is recorded.
+	assert.Error(t, c.flushNetworkTeardown("s1"))
+	res, err := c.NetworkTeardownStore.Get("s1")
+	assert.NoError(t, err)
+	task := res.(*metatypes.NetworkTeardownTask)
+	assert.Equal(t, 2, task.Attempts)
+	assert.Equal(t, "cni plugin not ready", task.LastError)
+
+	cniMgr.err = nil
+	assert.NoError(t, c.flushNetworkTeardown("s1"))
+	_, err = c.NetworkTeardownStore.Get("s1")
+	assert.Error(t, err)
+
+	// no pending teardown.
+	assert.NoError(t, c.flushNetworkTeardown("s1"))
+	assert.Equal(t, 2, cniMgr.calls)
+}
+
+func TestRetryNetworkTeardownSerialized(t *testing.T) {
+	cniMgr := &teardownCniMgr{delay: 50 * time.Millisecond}
+	c, cleanup := newTeardownTestManager(t, cniMgr)
+	defer cleanup()
+
+	sandboxMeta := &metatypes.SandboxMeta{ID: "s1", Config: &runtime.PodSandboxConfig{}}
+	assert.NoError(t, c.enqueueNetworkTeardown("s1", "/var/run/netns/cni-1", false, sandboxMeta, errors.New("timeout")))
+
+	// the periodic retry and the flush of StopPodSandbox run at once.
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		c.drainNetworkTeardowns(context.Background())
+	}()
+	go func() {
+		defer wg.Done()
+		assert.NoError(t, c.flushNetworkTeardown("s1"))
+	}()
+	wg.Wait()
+
+	// the task done by one of them is skipped by the other.
+	assert.Equal(t, 1, cniMgr.calls)
+	assert.Equal(t, 1, cniMgr.concurrent)
+}
+
+func TestNetworkTeardownWorkerStopped(t *testing.T) {
+	cniMgr := &teardownCniMgr{}
+	c, cleanup := newTeardownTestManager(t, cniMgr)
+	defer cleanup()
+
+	sandboxMeta := &metatypes.SandboxMeta{ID: "s1", Config: &runtime.PodSandboxConfig{}}
+	assert.NoError(t, c.enqueueNetworkTeardown("s1", "/var/run/netns/cni-1", false, sandboxMeta, errors.New("timeout")))
+
+	c.startNetworkTeardownWorker()
+	for i := 0; i < 100; i++ {
+		if _, err := c.NetworkTeardownStore.Get("s1"); err != nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	_, err := c.NetworkTeardownStore.Get("s1")
+	assert.Error(t, err)
+
+	// the worker waiting for the next retry is stopped at once.
+	assert.True(t, c.workers.stop(time.Second))
+}
@@ -0,0 +1,48 @@
+package v1alpha2
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	anno "github.com/alibaba/pouch/cri/annotations"
+	"github.com/alibaba/pouch/daemon/mgr"
+)
+
+// pausedAnnotation parses whether to pause or unpause the container from the
+// annotations of UpdateContainerResources, nil means neither.
+func pausedAnnotation(annotations map[string]string) (*bool, error) {
+	v, ok := annotations[anno.PausedExtendAnnotation]
+	if !ok {
+		return nil, nil
+	}
+
+	paused, err := strconv.ParseBool(v)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s %q: must be true or false", anno.PausedExtendAnnotation, v)
+	}
+	return &paused, nil
+}
+
+// setContainerPaused freezes or thaws the processes of container, nothing
+// is done if the container is already in the state.
+func (c *CriManager) setContainerPaused(ctx context.Context, container *mgr.Container, paused bool) error {
+	if paused == container.State.Paused {
+		return nil
+	}
+
+	if paused {
+		if !container.IsRunning() {
+			return fmt.Errorf("failed to pause container %q: container is not running", container.ID)
+		}
+		if err := c.ContainerMgr.Pause(ctx, container.ID); err != nil {
+			return fmt.Errorf("failed to pause container %q: %v", container.ID, err)
+		}
+		return nil
+	}
+
+	if err := c.ContainerMgr.Unpause(ctx, container.ID); err != nil {
+		return fmt.Errorf("failed to unpause container %q: %v", container.ID, err)
+	}
+	return nil
+}
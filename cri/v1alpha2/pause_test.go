@@ -0,0 +1,66 @@
+package v1alpha2
+
+import (
+	"context"
+	"testing"
+
+	apitypes "github.com/alibaba/pouch/apis/types"
+	anno "github.com/alibaba/pouch/cri/annotations"
+	"github.com/alibaba/pouch/daemon/mgr"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// pauseRecorder records the pauses and unpauses of containers.
+type pauseRecorder struct {
+	mgr.ContainerMgr
+	calls []string
+}
+
+func (p *pauseRecorder) Pause(ctx context.Context, name string) error {
+	p.calls = append(p.calls, "pause "+name)
+	return nil
+}
+
+func (p *pauseRecorder) Unpause(ctx context.Context, name string) error {
+	p.calls = append(p.calls, "unpause "+name)
+	return nil
+}
+
+func Test_pausedAnnotation(t *testing.T) {
+	paused, err := pausedAnnotation(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, paused)
+
+	paused, err = pausedAnnotation(map[string]string{anno.PausedExtendAnnotation: "true"})
+	assert.NoError(t, err)
+	assert.True(t, *paused)
+
+	paused, err = pausedAnnotation(map[string]string{anno.PausedExtendAnnotation: "false"})
+	assert.NoError(t, err)
+	assert.False(t, *paused)
+
+	_, err = pausedAnnotation(map[string]string{anno.PausedExtendAnnotation: "yes"})
+	assert.Error(t, err)
+}
+
+func Test_setContainerPaused(t *testing.T) {
+	recorder := &pauseRecorder{}
+	c := &CriManager{ContainerMgr: recorder}
+	ctx := context.Background()
+
+	running := &mgr.Container{ID: "c1", State: &apitypes.ContainerState{Status: apitypes.StatusRunning, Running: true}}
+	paused := &mgr.Container{ID: "c2", State: &apitypes.ContainerState{Status: apitypes.StatusPaused, Paused: true}}
+	exited := &mgr.Container{ID: "c3", State: &apitypes.ContainerState{Status: apitypes.StatusExited}}
+
+	assert.NoError(t, c.setContainerPaused(ctx, running, true))
+	assert.NoError(t, c.setContainerPaused(ctx, paused, false))
+
+	// nothing is done if the container is already in the state.
+	assert.NoError(t, c.setContainerPaused(ctx, running, false))
+	assert.NoError(t, c.setContainerPaused(ctx, paused, true))
+	assert.NoError(t, c.setContainerPaused(ctx, exited, false))
+
+	assert.Error(t, c.setContainerPaused(ctx, exited, true))
+	assert.Equal(t, []string{"pause c1", "unpause c2"}, recorder.calls)
+}
@@ -0,0 +1,122 @@
+package v1alpha2
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+)
+
+const (
+	// reaperCheckRetries and reaperCheckInterval bound the time for the init
+	// of sandbox to install its SIGCHLD handler after started.
+	reaperCheckRetries  = 10
+	reaperCheckInterval = 200 * time.Millisecond
+)
+
+// procStatusFile returns the status file of the process.
+func procStatusFile(pid int) string {
+	return fmt.Sprintf("/proc/%d/status", pid)
+}
+
+// sharesPidNamespace returns whether the containers of sandbox join the pid
+// namespace of sandbox, the init of sandbox becomes the pid 1 of them.
+func sharesPidNamespace(config *runtime.PodSandboxConfig) bool {
+	return config.GetLinux().GetSecurityContext().GetNamespaceOptions().GetPid() == runtime.NamespaceMode_POD
+}
+
+// reapsZombies returns whether the process reaps its exited children, that is
+// it catches SIGCHLD or ignores it to let the kernel reap them.
+func reapsZombies(statusFile string) (bool, error) {
+	f, err := os.Open(statusFile)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	sigchld := uint64(1) << (uint(syscall.SIGCHLD) - 1)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		parts := strings.SplitN(scanner.Text(), ":", 2)
+		if len(parts) != 2 || (parts[0] != "SigCgt" && parts[0] != "SigIgn") {
+			continue
+		}
+		mask, err := strconv.ParseUint(strings.TrimSpace(parts[1]), 16, 64)
+		if err != nil {
+			return false, fmt.Errorf("invalid %s of %s: %v", parts[0], statusFile, err)
+		}
+		if mask&sigchld != 0 {
+			return true, nil
+		}
+	}
+	return false, scanner.Err()
+}
+
+// checkSandboxReaper checks that the init of sandbox sharing its pid namespace
+// reaps zombies, the exited processes of containers are reparented to it and
+// pile up otherwise, so the sandbox is rejected if it does not. The built-in
+// pause always reaps zombies.
+func (c *CriManager) checkSandboxReaper(ctx context.Context, sandboxID string) error {
+	if c.builtinPause != nil {
+		return nil
+	}
+
+	sandbox, err := c.ContainerMgr.Get(ctx, sandboxID)
+	if err != nil {
+		return err
+	}
+	pid := int(sandbox.State.Pid)
+
+	for i := 0; i < reaperCheckRetries; i++ {
+		reaps, err := reapsZombies(procStatusFile(pid))
+		if err != nil {
+			return fmt.Errorf("failed to check whether init of sandbox %q reaps zombies: %v", sandboxID, err)
+		}
+		if reaps {
+			return nil
+		}
+
+		select {
+		case <-time.After(reaperCheckInterval):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return fmt.Errorf("init of sandbox %q from image %s does not reap zombies in the shared pid namespace, "+
+		"use a sandbox image whose init reaps zombies or the built-in pause", sandboxID, sandbox.Config.Image)
+}
+
+// targetContainerID returns the id of the target container whose pid namespace
+// is joined, the target must be a container of the same sandbox.
+func (c *CriManager) targetContainerID(ctx context.Context, sandboxID, targetID string) (string, error) {
+	if targetID == "" {
+		return "", fmt.Errorf("target container id is required by pid namespace mode %s", runtime.NamespaceMode_TARGET)
+	}
+	target, err := c.ContainerMgr.Get(ctx, targetID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get target container %q: %v", targetID, err)
+	}
+	if target.Config.Labels[sandboxIDLabelKey] != sandboxID {
+		return "", fmt.Errorf("target container %q does not belong to sandbox %q", target.ID, sandboxID)
+	}
+	return target.ID, nil
+}
+
+// validateTargetNamespace validates the target of the namespace options of
+// container, only the pid namespace could target another container.
+func (c *CriManager) validateTargetNamespace(ctx context.Context, sandboxID string, nsOpts *runtime.NamespaceOption) error {
+	if nsOpts.GetNetwork() == runtime.NamespaceMode_TARGET || nsOpts.GetIpc() == runtime.NamespaceMode_TARGET {
+		return fmt.Errorf("namespace mode %s is only supported by pid namespace", runtime.NamespaceMode_TARGET)
+	}
+	if nsOpts.GetPid() != runtime.NamespaceMode_TARGET {
+		return nil
+	}
+	_, err := c.targetContainerID(ctx, sandboxID, nsOpts.GetTargetId())
+	return err
+}
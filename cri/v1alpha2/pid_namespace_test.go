@@ -0,0 +1,111 @@
+package v1alpha2
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	apitypes "github.com/alibaba/pouch/apis/types"
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+	"github.com/alibaba/pouch/daemon/mgr"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSharesPidNamespace(t *testing.T) {
+	withPid := func(mode runtime.NamespaceMode) *runtime.PodSandboxConfig {
+		return &runtime.PodSandboxConfig{
+			Linux: &runtime.LinuxPodSandboxConfig{
+				SecurityContext: &runtime.LinuxSandboxSecurityContext{
+					NamespaceOptions: &runtime.NamespaceOption{Pid: mode},
+				},
+			},
+		}
+	}
+
+	assert.True(t, sharesPidNamespace(&runtime.PodSandboxConfig{}))
+	assert.True(t, sharesPidNamespace(withPid(runtime.NamespaceMode_POD)))
+	assert.False(t, sharesPidNamespace(withPid(runtime.NamespaceMode_CONTAINER)))
+	assert.False(t, sharesPidNamespace(withPid(runtime.NamespaceMode_NODE)))
+}
+
+func TestReapsZombies(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pid-namespace")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	for name, tc := range map[string]struct {
+		status string
+		reaps  bool
+	}{
+		// pause catches SIGINT, SIGTERM and SIGCHLD.
+		"catch":   {status: "Name:\tpause\nSigIgn:\t0000000000000000\nSigCgt:\t0000000000014002\n", reaps: true},
+		"ignore":  {status: "Name:\tsleep\nSigIgn:\t0000000000010000\nSigCgt:\t0000000000000000\n", reaps: true},
+		"default": {status: "Name:\tsleep\nSigIgn:\t0000000000000000\nSigCgt:\t0000000000000000\n", reaps: false},
+	} {
+		statusFile := filepath.Join(dir, name)
+		assert.NoError(t, ioutil.WriteFile(statusFile, []byte(tc.status), 0644))
+		reaps, err := reapsZombies(statusFile)
+		assert.NoError(t, err, name)
+		assert.Equal(t, tc.reaps, reaps, name)
+	}
+
+	_, err = reapsZombies(filepath.Join(dir, "missing"))
+	assert.Error(t, err)
+}
+
+func TestCheckSandboxReaper(t *testing.T) {
+	sleep := exec.Command("sleep", "10")
+	if err := sleep.Start(); err != nil {
+		t.Skipf("sleep could not be started: %v", err)
+	}
+	defer sleep.Wait()
+	defer sleep.Process.Kill()
+
+	// the go runtime catches SIGCHLD, sleep does not.
+	c := &CriManager{ContainerMgr: &ephemeralContainerMgr{containers: map[string]*mgr.Container{
+		"reaper": {
+			ID:     "reaper",
+			Config: &apitypes.ContainerConfig{Image: "pause"},
+			State:  &apitypes.ContainerState{Pid: int64(os.Getpid())},
+		},
+		"sleep": {
+			ID:     "sleep",
+			Config: &apitypes.ContainerConfig{Image: "busybox"},
+			State:  &apitypes.ContainerState{Pid: int64(sleep.Process.Pid)},
+		},
+	}}}
+	assert.NoError(t, c.checkSandboxReaper(context.Background(), "reaper"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*reaperCheckInterval)
+	defer cancel()
+	assert.Error(t, c.checkSandboxReaper(ctx, "sleep"))
+
+	// the built-in pause is not checked.
+	c.builtinPause = &builtinPause{}
+	assert.NoError(t, c.checkSandboxReaper(context.Background(), "sleep"))
+}
+
+func TestValidateTargetNamespace(t *testing.T) {
+	c := &CriManager{ContainerMgr: &ephemeralContainerMgr{containers: map[string]*mgr.Container{
+		"c1": {ID: "c1", Config: &apitypes.ContainerConfig{Labels: map[string]string{sandboxIDLabelKey: "s1"}}},
+		"c2": {ID: "c2", Config: &apitypes.ContainerConfig{Labels: map[string]string{sandboxIDLabelKey: "s2"}}},
+	}}}
+	ctx := context.Background()
+
+	assert.NoError(t, c.validateTargetNamespace(ctx, "s1", nil))
+	assert.NoError(t, c.validateTargetNamespace(ctx, "s1", &runtime.NamespaceOption{Pid: runtime.NamespaceMode_CONTAINER}))
+	assert.NoError(t, c.validateTargetNamespace(ctx, "s1", &runtime.NamespaceOption{Pid: runtime.NamespaceMode_TARGET, TargetId: "c1"}))
+
+	for _, nsOpts := range []*runtime.NamespaceOption{
+		{Pid: runtime.NamespaceMode_TARGET},
+		{Pid: runtime.NamespaceMode_TARGET, TargetId: "c2"},
+		{Pid: runtime.NamespaceMode_TARGET, TargetId: "c3"},
+		{Ipc: runtime.NamespaceMode_TARGET, TargetId: "c1"},
+	} {
+		assert.Error(t, c.validateTargetNamespace(ctx, "s1", nsOpts), nsOpts.String())
+	}
+}
@@ -0,0 +1,203 @@
+package v1alpha2
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+	metatypes "github.com/alibaba/pouch/cri/v1alpha2/types"
+	"github.com/alibaba/pouch/daemon/mgr"
+	"github.com/alibaba/pouch/pkg/system"
+)
+
+var (
+	// cgroupRoot is the directory where the cgroup v1 hierarchies are mounted.
+	cgroupRoot = "/sys/fs/cgroup"
+	// unifiedCgroupRoot is the mount point of cgroup v2 unified hierarchy.
+	unifiedCgroupRoot = system.UnifiedCgroupMountpoint
+	// procRoot is the mount point of procfs.
+	procRoot = "/proc"
+	// isCgroup2UnifiedMode returns whether the host boots with cgroup v2.
+	isCgroup2UnifiedMode = system.IsCgroup2UnifiedMode
+)
+
+// pidsCgroupRoot is the mount point of the pids cgroup hierarchy, which
+// is the unified hierarchy on the hosts with cgroup v2.
+var pidsCgroupRoot = defaultPidsCgroupRoot()
+
+func defaultPidsCgroupRoot() string {
+	if system.IsCgroup2UnifiedMode() {
+		return system.UnifiedCgroupMountpoint
+	}
+	return "/sys/fs/cgroup/pids"
+}
+
+// expandSlice expands the name of systemd slice into its path in the cgroup
+// hierarchy, e.g. "kubepods-besteffort-pod1.slice" is expanded into
+// "/kubepods.slice/kubepods-besteffort.slice/kubepods-besteffort-pod1.slice".
+func expandSlice(slice string) (string, error) {
+	const suffix = ".slice"
+	if len(slice) <= len(suffix) || !strings.HasSuffix(slice, suffix) || strings.Contains(slice, "/") {
+		return "", fmt.Errorf("invalid slice name %q", slice)
+	}
+
+	// the root slice is the top of hierarchy.
+	if slice == "-.slice" {
+		return "/", nil
+	}
+
+	var path, prefix string
+	for _, component := range strings.Split(strings.TrimSuffix(slice, suffix), "-") {
+		if component == "" {
+			return "", fmt.Errorf("invalid slice name %q", slice)
+		}
+		path += "/" + prefix + component + suffix
+		prefix += component + "-"
+	}
+	return path, nil
+}
+
+// podCgroupPath returns the path of pod cgroup in the cgroup hierarchy mounted at root.
+func podCgroupPath(root, cgroupParent string, useSystemd bool) (string, error) {
+	if !useSystemd {
+		return filepath.Join(root, filepath.Clean("/"+cgroupParent)), nil
+	}
+
+	path, err := expandSlice(cgroupParent)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(root, path), nil
+}
+
+// setPodPidsLimit writes the limit into pids.max of the pod cgroup, the
+// limit not greater than 0 means no limit.
+func setPodPidsLimit(cgroupParent string, useSystemd bool, limit int64) error {
+	path, err := podCgroupPath(pidsCgroupRoot, cgroupParent, useSystemd)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(path, 0755); err != nil {
+		return err
+	}
+
+	value := "max"
+	if limit > 0 {
+		value = strconv.FormatInt(limit, 10)
+	}
+	return ioutil.WriteFile(filepath.Join(path, "pids.max"), []byte(value), 0644)
+}
+
+// applyPodPidsLimit limits the number of processes in the pod cgroup
+// if the pids limit of pod is specified.
+func (c *CriManager) applyPodPidsLimit(sandboxMeta *metatypes.SandboxMeta, config *runtime.PodSandboxConfig) error {
+	if sandboxMeta.PidsLimit == 0 {
+		return nil
+	}
+
+	cgroupParent := config.GetLinux().GetCgroupParent()
+	if cgroupParent == "" {
+		return fmt.Errorf("failed to set pids limit of pod: cgroup parent is not specified")
+	}
+
+	useSystemd := c.DaemonConfig != nil && c.DaemonConfig.UseSystemd()
+	if err := setPodPidsLimit(cgroupParent, useSystemd, sandboxMeta.PidsLimit); err != nil {
+		return fmt.Errorf("failed to set pids limit of pod cgroup %q: %v", cgroupParent, err)
+	}
+	return nil
+}
+
+// effectivePidsLimit returns the limit of processes which takes effect on the
+// container, which is the lower one of the limits of container and pod.
+func effectivePidsLimit(containerLimit, podLimit int64) int64 {
+	if podLimit <= 0 {
+		return containerLimit
+	}
+	if containerLimit <= 0 || podLimit < containerLimit {
+		return podLimit
+	}
+	return containerLimit
+}
+
+// processCgroupPath returns the path of cgroup which the process belongs to
+// in the hierarchy of the controller, or in the unified hierarchy on cgroup v2.
+func processCgroupPath(pid int64, controller string) (string, error) {
+	f, err := os.Open(filepath.Join(procRoot, strconv.FormatInt(pid, 10), "cgroup"))
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	unified := isCgroup2UnifiedMode()
+
+	// the entries are in the format of "hierarchy-ID:controller-list:path",
+	// the one of unified hierarchy is in the format of "0::path".
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		parts := strings.SplitN(scanner.Text(), ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		if unified {
+			if parts[0] == "0" && parts[1] == "" {
+				return filepath.Join(unifiedCgroupRoot, parts[2]), nil
+			}
+			continue
+		}
+		for _, c := range strings.Split(parts[1], ",") {
+			if c == controller {
+				return filepath.Join(cgroupRoot, controller, parts[2]), nil
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("cgroup %s of process %d is not found", controller, pid)
+}
+
+// containerAnnotations returns the annotations of container, the ones
+// updated take precedence over the ones the container is created with.
+func containerAnnotations(container *mgr.Container) map[string]string {
+	_, annotations := extractLabels(container.Config.Labels)
+	for k, v := range container.Config.SpecAnnotation {
+		annotations[k] = v
+	}
+	return annotations
+}
+
+// containerCgroupFiles returns the spec annotations of the cgroup files of
+// the annotations of container, which are written into the container cgroup
+// by the prestart hook of pouchd before the user process is started.
+func containerCgroupFiles(annotations map[string]string) (map[string]string, error) {
+	files := make(map[string]string)
+
+	qos, err := containerMemoryQoS(annotations)
+	if err != nil {
+		return nil, err
+	}
+	if !qos.IsEmpty() {
+		if !isCgroup2UnifiedMode() {
+			return nil, errMemoryQoSUnsupported
+		}
+		for file, value := range memoryQoSCgroupFiles(qos) {
+			files[mgr.CgroupFileSpecAnnotationPrefix+file] = strconv.FormatInt(value, 10)
+		}
+	}
+
+	// the cpu burst takes effect with the cpu quota set by runc.
+	burst, err := containerCPUBurst(annotations)
+	if err != nil {
+		return nil, err
+	}
+	if burst >= 0 {
+		files[mgr.CgroupFileSpecAnnotationPrefix+cpuBurstCgroupFile()] = strconv.FormatInt(burst, 10)
+	}
+	return files, nil
+}
@@ -0,0 +1,82 @@
+package v1alpha2
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_expandSlice(t *testing.T) {
+	tests := []struct {
+		slice   string
+		want    string
+		wantErr bool
+	}{
+		{slice: "-.slice", want: "/"},
+		{slice: "kubepods.slice", want: "/kubepods.slice"},
+		{slice: "kubepods-besteffort-pod1.slice", want: "/kubepods.slice/kubepods-besteffort.slice/kubepods-besteffort-pod1.slice"},
+		{slice: "kubepods", wantErr: true},
+		{slice: "kubepods--pod1.slice", wantErr: true},
+		{slice: "/kubepods.slice", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := expandSlice(tt.slice)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("expandSlice(%q) error = %v, wantErr %v", tt.slice, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("expandSlice(%q) = %q, want %q", tt.slice, got, tt.want)
+		}
+	}
+}
+
+func Test_setPodPidsLimit(t *testing.T) {
+	root, err := ioutil.TempDir("", "pids-cgroup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	defer func(old string) { pidsCgroupRoot = old }(pidsCgroupRoot)
+	pidsCgroupRoot = root
+
+	for _, tt := range []struct {
+		cgroupParent string
+		useSystemd   bool
+		limit        int64
+		path         string
+		want         string
+	}{
+		{cgroupParent: "/kubepods/pod1", limit: 100, path: "kubepods/pod1", want: "100"},
+		{cgroupParent: "kubepods-pod2.slice", useSystemd: true, limit: -1, path: "kubepods.slice/kubepods-pod2.slice", want: "max"},
+	} {
+		if err := setPodPidsLimit(tt.cgroupParent, tt.useSystemd, tt.limit); err != nil {
+			t.Fatalf("setPodPidsLimit(%q) error = %v", tt.cgroupParent, err)
+		}
+		got, err := ioutil.ReadFile(filepath.Join(root, tt.path, "pids.max"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != tt.want {
+			t.Errorf("pids.max of %q = %q, want %q", tt.cgroupParent, got, tt.want)
+		}
+	}
+}
+
+func Test_effectivePidsLimit(t *testing.T) {
+	for _, tt := range []struct {
+		containerLimit, podLimit, want int64
+	}{
+		{containerLimit: 100, podLimit: 0, want: 100},
+		{containerLimit: 0, podLimit: 50, want: 50},
+		{containerLimit: -1, podLimit: 50, want: 50},
+		{containerLimit: 100, podLimit: 50, want: 50},
+		{containerLimit: 30, podLimit: 50, want: 30},
+	} {
+		if got := effectivePidsLimit(tt.containerLimit, tt.podLimit); got != tt.want {
+			t.Errorf("effectivePidsLimit(%d, %d) = %d, want %d", tt.containerLimit, tt.podLimit, got, tt.want)
+		}
+	}
+}
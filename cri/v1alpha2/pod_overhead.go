@@ -0,0 +1,94 @@
+package v1alpha2
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	metatypes "github.com/alibaba/pouch/cri/v1alpha2/types"
+
+	"github.com/docker/go-units"
+)
+
+// overheadInfoKey is the key of pod overhead in the verbose info of sandbox status.
+const overheadInfoKey = "overhead"
+
+// parseRuntimeOverheads parses the overheads of runtime handlers, each of them
+// is in the form of "handler:resource=quantity", e.g. "kata:cpu=250m" or
+// "kata:memory=160Mi".
+func parseRuntimeOverheads(entries []string) (map[string]metatypes.PodOverhead, error) {
+	overheads := make(map[string]metatypes.PodOverhead)
+	for _, e := range entries {
+		parts := strings.SplitN(e, ":", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, fmt.Errorf("invalid runtime overhead %q, should be handler:resource=quantity", e)
+		}
+		handler := strings.TrimSpace(parts[0])
+
+		kv := strings.SplitN(parts[1], "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid runtime overhead %q, should be handler:resource=quantity", e)
+		}
+
+		o := overheads[handler]
+		switch resource, quantity := strings.TrimSpace(kv[0]), strings.TrimSpace(kv[1]); resource {
+		case "cpu":
+			milli, err := parseMilliCPU(quantity)
+			if err != nil {
+				return nil, fmt.Errorf("invalid cpu of runtime overhead %q: %v", e, err)
+			}
+			o.CPUMilli = milli
+		case "memory":
+			memory, err := units.RAMInBytes(quantity)
+			if err != nil || memory < 0 {
+				return nil, fmt.Errorf("invalid memory of runtime overhead %q: %v", e, err)
+			}
+			o.Memory = memory
+		default:
+			return nil, fmt.Errorf("invalid resource %q in runtime overhead %q, should be cpu or memory", resource, e)
+		}
+		overheads[handler] = o
+	}
+	return overheads, nil
+}
+
+// parseMilliCPU parses the cpu in the form of millicores, e.g. "250m", or cores, e.g. "0.5".
+func parseMilliCPU(quantity string) (int64, error) {
+	if strings.HasSuffix(quantity, "m") {
+		milli, err := strconv.ParseInt(strings.TrimSuffix(quantity, "m"), 10, 64)
+		if err != nil || milli < 0 {
+			return 0, fmt.Errorf("%q is not a non-negative number of millicores", quantity)
+		}
+		return milli, nil
+	}
+
+	cores, err := strconv.ParseFloat(quantity, 64)
+	if err != nil || cores < 0 || math.IsInf(cores, 0) {
+		return 0, fmt.Errorf("%q is not a non-negative number of cores", quantity)
+	}
+	return int64(math.Ceil(cores * 1000)), nil
+}
+
+// applyPodOverhead records the overhead of runtime handler in the sandbox,
+// which is reported in the status of sandbox. The limits of pod cgroup are
+// left to kubelet, which has added the overhead of RuntimeClass to them.
+func (c *CriManager) applyPodOverhead(sandboxMeta *metatypes.SandboxMeta) {
+	if overhead, ok := c.runtimeOverheads[sandboxMeta.Runtime]; ok {
+		sandboxMeta.Overhead = overhead
+	}
+}
+
+// podOverheadInfo returns the verbose info of the overhead of sandbox.
+func podOverheadInfo(sandboxMeta *metatypes.SandboxMeta) (map[string]string, error) {
+	if sandboxMeta.Overhead.IsEmpty() {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(sandboxMeta.Overhead)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{overheadInfoKey: string(data)}, nil
+}
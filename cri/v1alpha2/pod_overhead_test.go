@@ -0,0 +1,41 @@
+package v1alpha2
+
+import (
+	"testing"
+
+	metatypes "github.com/alibaba/pouch/cri/v1alpha2/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRuntimeOverheads(t *testing.T) {
+	overheads, err := parseRuntimeOverheads([]string{"kata:cpu=250m", "kata:memory=160Mi", "runsc:cpu=0.5"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]metatypes.PodOverhead{
+		"kata":  {CPUMilli: 250, Memory: 160 * 1024 * 1024},
+		"runsc": {CPUMilli: 500},
+	}, overheads)
+
+	for _, e := range []string{"kata", "kata:cpu", ":cpu=1", "kata:disk=1G", "kata:cpu=-1", "kata:cpu=xm", "kata:memory=x"} {
+		_, err := parseRuntimeOverheads([]string{e})
+		assert.Error(t, err, e)
+	}
+}
+
+func TestApplyPodOverhead(t *testing.T) {
+	c := &CriManager{runtimeOverheads: map[string]metatypes.PodOverhead{"kata": {CPUMilli: 250, Memory: 1024}}}
+
+	s1 := &metatypes.SandboxMeta{ID: "s1", Runtime: "kata"}
+	c.applyPodOverhead(s1)
+	info, err := podOverheadInfo(s1)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"cpuMilli":250,"memory":1024}`, info[overheadInfoKey])
+
+	// no overhead for the other runtime handlers.
+	s2 := &metatypes.SandboxMeta{ID: "s2", Runtime: "runc"}
+	c.applyPodOverhead(s2)
+	assert.True(t, s2.Overhead.IsEmpty())
+	info, err = podOverheadInfo(s2)
+	assert.NoError(t, err)
+	assert.Nil(t, info)
+}
@@ -0,0 +1,401 @@
+package v1alpha2
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	apitypes "github.com/alibaba/pouch/apis/types"
+	metatypes "github.com/alibaba/pouch/cri/v1alpha2/types"
+	"github.com/alibaba/pouch/ctrd"
+	"github.com/alibaba/pouch/pkg/errtypes"
+	"github.com/alibaba/pouch/pkg/log"
+
+	"github.com/docker/go-units"
+	"github.com/pkg/errors"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// prefetchHistorySize is the max number of the finished prefetches kept in the report.
+	prefetchHistorySize = 100
+
+	// maxPrefetchBurst is the max number of bytes read from registries at once by the prefetches.
+	maxPrefetchBurst = 1 << 20
+)
+
+// prefetchEntry is an image queued, being pulled or finished.
+type prefetchEntry struct {
+	status metatypes.PrefetchStatus
+	auth   *apitypes.AuthConfig
+	// seq keeps the order of the images of the same priority.
+	seq uint64
+	// index is the index in the queue, -1 if the image is not queued.
+	index int
+}
+
+// prefetchQueue is the heap of queued images, the one of the highest priority
+// and then the earliest queued is popped first.
+type prefetchQueue []*prefetchEntry
+
+func (q prefetchQueue) Len() int { return len(q) }
+
+func (q prefetchQueue) Less(i, j int) bool {
+	if q[i].status.Priority != q[j].status.Priority {
+		return q[i].status.Priority > q[j].status.Priority
+	}
+	return q[i].seq < q[j].seq
+}
+
+func (q prefetchQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *prefetchQueue) Push(x interface{}) {
+	e := x.(*prefetchEntry)
+	e.index = len(*q)
+	*q = append(*q, e)
+}
+
+func (q *prefetchQueue) Pop() interface{} {
+	old := *q
+	e := old[len(old)-1]
+	old[len(old)-1] = nil
+	e.index = -1
+	*q = old[:len(old)-1]
+	return e
+}
+
+// imagePrefetcher pulls the queued images one by one in background, with the
+// bandwidth shared by all of them, so that the images, e.g. of the next release,
+// are on the node before the pods using them are run. It also keeps the warm
+// images on the node, which are queued again once they are missing, but not
+// within the interval of warm images since they are prefetched, so that the
+// ones removed by the image gc under disk pressure are not pulled back at once.
+type imagePrefetcher struct {
+	pull    func(ctx context.Context, handler, ref string, auth *apitypes.AuthConfig) error
+	present func(ctx context.Context, ref string) bool
+
+	// limiter limits the bandwidth of prefetches, nil means no limit.
+	limiter   *rate.Limiter
+	bandwidth int64
+
+	warm         []*metatypes.PrefetchImage
+	warmInterval time.Duration
+
+	mu      sync.Mutex
+	queue   prefetchQueue
+	entries map[string]*prefetchEntry
+	// finished are the keys of the finished prefetches, the oldest first.
+	finished []string
+	seq      uint64
+	wake     chan struct{}
+	// warmPrefetchedAt are the times the warm images are prefetched, keyed
+	// by the key of pull.
+	warmPrefetchedAt map[string]time.Time
+}
+
+// newImagePrefetcher creates the prefetcher with the bandwidth, e.g. 10m, and the warm images.
+func newImagePrefetcher(bandwidth string, warm []*metatypes.PrefetchImage, warmInterval time.Duration, pull func(ctx context.Context, handler, ref string, auth *apitypes.AuthConfig) error, present func(ctx context.Context, ref string) bool) (*imagePrefetcher, error) {
+	p := &imagePrefetcher{
+		pull:         pull,
+		present:      present,
+		warm:         warm,
+		warmInterval: warmInterval,
+		entries:      make(map[string]*prefetchEntry),
+		wake:         make(chan struct{}, 1),
+
+		warmPrefetchedAt: make(map[string]time.Time),
+	}
+	if bandwidth != "" {
+		bw, err := units.RAMInBytes(bandwidth)
+		if err != nil {
+			return nil, fmt.Errorf("invalid prefetch bandwidth %q: %v", bandwidth, err)
+		}
+		if bw <= 0 {
+			return nil, fmt.Errorf("invalid prefetch bandwidth %q: should be positive", bandwidth)
+		}
+		burst := bw
+		if burst > maxPrefetchBurst {
+			burst = maxPrefetchBurst
+		}
+		p.limiter = rate.NewLimiter(rate.Limit(bw), int(burst))
+		p.bandwidth = bw
+	}
+	if len(warm) > 0 && warmInterval <= 0 {
+		return nil, fmt.Errorf("interval %v of warm images should be positive", warmInterval)
+	}
+	return p, nil
+}
+
+// parseWarmImages parses the warm images in the form of [handler=]image.
+func parseWarmImages(entries []string) ([]*metatypes.PrefetchImage, error) {
+	var images []*metatypes.PrefetchImage
+	for _, e := range entries {
+		image := &metatypes.PrefetchImage{Image: strings.TrimSpace(e)}
+		if parts := strings.SplitN(e, "=", 2); len(parts) == 2 {
+			image.RuntimeHandler, image.Image = strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+			if image.RuntimeHandler == "" {
+				return nil, fmt.Errorf("invalid warm image %q, should be [handler=]image", e)
+			}
+		}
+		if image.Image == "" {
+			return nil, fmt.Errorf("invalid warm image %q, should be [handler=]image", e)
+		}
+		images = append(images, image)
+	}
+	return images, nil
+}
+
+// start runs the worker of prefetches and the reconciliation of warm images
+// in the worker group.
+func (p *imagePrefetcher) start(workers *workerGroup) {
+	workers.run(func(ctx context.Context) {
+		for ctx.Err() == nil {
+			e := p.pop()
+			if e == nil {
+				select {
+				case <-p.wake:
+				case <-ctx.Done():
+				}
+				continue
+			}
+			p.finish(e, p.prefetch(ctx, e))
+		}
+	})
+
+	if len(p.warm) > 0 {
+		workers.run(func(ctx context.Context) {
+			for {
+				p.reconcileWarm(ctx)
+
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(p.warmInterval):
+				}
+			}
+		})
+	}
+}
+
+// enqueue queues the images. The image queued already is moved ahead if it is
+// given a higher priority, and the one being pulled is not queued again.
+func (p *imagePrefetcher) enqueue(images []*metatypes.PrefetchImage, warm bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, image := range images {
+		key := imagePullKey(image.RuntimeHandler, image.Image, nil)
+		e, ok := p.entries[key]
+		if ok {
+			switch e.status.State {
+			case metatypes.PrefetchQueued:
+				if image.Priority > e.status.Priority {
+					e.status.Priority = image.Priority
+					heap.Fix(&p.queue, e.index)
+				}
+				if image.Auth != nil {
+					e.auth = image.Auth
+				}
+				e.status.Warm = e.status.Warm || warm
+				continue
+			case metatypes.PrefetchPulling:
+				continue
+			default:
+				p.forgetFinished(key)
+			}
+		}
+
+		p.seq++
+		e = &prefetchEntry{
+			status: metatypes.PrefetchStatus{
+				Image:          image.Image,
+				RuntimeHandler: image.RuntimeHandler,
+				Priority:       image.Priority,
+				Warm:           warm,
+				State:          metatypes.PrefetchQueued,
+				QueuedAt:       time.Now().UnixNano(),
+			},
+			auth: image.Auth,
+			seq:  p.seq,
+		}
+		p.entries[key] = e
+		heap.Push(&p.queue, e)
+	}
+
+	select {
+	case p.wake <- struct{}{}:
+	default:
+	}
+}
+
+// pop takes the image to pull next out of the queue, nil if the queue is empty.
+func (p *imagePrefetcher) pop() *prefetchEntry {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.queue.Len() == 0 {
+		return nil
+	}
+	e := heap.Pop(&p.queue).(*prefetchEntry)
+	e.status.State = metatypes.PrefetchPulling
+	return e
+}
+
+// prefetch pulls the image unless it's present on the node.
+func (p *imagePrefetcher) prefetch(ctx context.Context, e *prefetchEntry) error {
+	if p.present(ctx, e.status.Image) {
+		return nil
+	}
+	if p.limiter != nil {
+		ctx = ctrd.WithPullBandwidth(ctx, p.limiter)
+	}
+
+	log.With(ctx).Infof("prefetch image %s of priority %d", e.status.Image, e.status.Priority)
+	return p.pull(ctx, e.status.RuntimeHandler, e.status.Image, e.auth)
+}
+
+// finish records the result of prefetch, the oldest finished ones are dropped.
+func (p *imagePrefetcher) finish(e *prefetchEntry, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	e.status.State = metatypes.PrefetchDone
+	if err != nil {
+		log.With(nil).Warnf("failed to prefetch image %s: %v", e.status.Image, err)
+		e.status.State = metatypes.PrefetchFailed
+		e.status.Error = err.Error()
+	}
+	e.status.FinishedAt = time.Now().UnixNano()
+	// the credentials are not kept after the pull.
+	e.auth = nil
+
+	key := imagePullKey(e.status.RuntimeHandler, e.status.Image, nil)
+	if e.status.Warm {
+		p.warmPrefetchedAt[key] = time.Now()
+	}
+
+	p.finished = append(p.finished, key)
+	for len(p.finished) > prefetchHistorySize {
+		delete(p.entries, p.finished[0])
+		p.finished = p.finished[1:]
+	}
+}
+
+// forgetFinished removes the key from the finished ones to queue it again.
+func (p *imagePrefetcher) forgetFinished(key string) {
+	for i, k := range p.finished {
+		if k == key {
+			p.finished = append(p.finished[:i], p.finished[i+1:]...)
+			return
+		}
+	}
+}
+
+// reconcileWarm queues the warm images missing on the node, except the ones
+// prefetched within the interval of warm images.
+func (p *imagePrefetcher) reconcileWarm(ctx context.Context) {
+	var missing []*metatypes.PrefetchImage
+	for _, image := range p.warm {
+		p.mu.Lock()
+		prefetchedAt, ok := p.warmPrefetchedAt[imagePullKey(image.RuntimeHandler, image.Image, nil)]
+		p.mu.Unlock()
+		if ok && time.Since(prefetchedAt) < p.warmInterval {
+			continue
+		}
+
+		if !p.present(ctx, image.Image) {
+			missing = append(missing, image)
+		}
+	}
+	if len(missing) > 0 {
+		log.With(ctx).Infof("queue %d missing warm images for prefetch", len(missing))
+		p.enqueue(missing, true)
+	}
+}
+
+// report returns the images being pulled, then the queued ones in the order
+// they are pulled, and then the finished ones, the latest first.
+func (p *imagePrefetcher) report() *metatypes.PrefetchReport {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	report := &metatypes.PrefetchReport{Bandwidth: p.bandwidth, Images: []*metatypes.PrefetchStatus{}}
+	for _, e := range p.entries {
+		if e.status.State == metatypes.PrefetchPulling {
+			status := e.status
+			report.Images = append(report.Images, &status)
+		}
+	}
+
+	queued := make(prefetchQueue, len(p.queue))
+	copy(queued, p.queue)
+	sort.Slice(queued, func(i, j int) bool { return queued.Less(i, j) })
+	for _, e := range queued {
+		status := e.status
+		report.Images = append(report.Images, &status)
+	}
+
+	for i := len(p.finished) - 1; i >= 0; i-- {
+		status := p.entries[p.finished[i]].status
+		report.Images = append(report.Images, &status)
+	}
+	return report
+}
+
+// PrefetchImages queues the images to pull in background by their priorities.
+func (c *CriManager) PrefetchImages(ctx context.Context, r *metatypes.PrefetchRequest) (*metatypes.PrefetchReport, error) {
+	for _, image := range r.Images {
+		if image.Image == "" {
+			return nil, errors.Wrap(errtypes.ErrInvalidParam, "the image to prefetch should be specified")
+		}
+		if image.RuntimeHandler != "" && c.DaemonConfig != nil {
+			if _, ok := c.DaemonConfig.Runtimes[image.RuntimeHandler]; !ok {
+				return nil, errors.Wrapf(errtypes.ErrInvalidParam, "runtime handler %q of image %s is not configured in daemon", image.RuntimeHandler, image.Image)
+			}
+		}
+	}
+
+	c.imagePrefetcher.enqueue(r.Images, false)
+	return c.imagePrefetcher.report(), nil
+}
+
+// PrefetchReport reports the images queued, being pulled and recently prefetched.
+func (c *CriManager) PrefetchReport() *metatypes.PrefetchReport {
+	return c.imagePrefetcher.report()
+}
+
+// newCriImagePrefetcher creates the prefetcher pulling the images into the
+// snapshotters of runtime handlers.
+func (c *CriManager) newCriImagePrefetcher(bandwidth string, warmImages []string, warmInterval time.Duration) (*imagePrefetcher, error) {
+	warm, err := parseWarmImages(warmImages)
+	if err != nil {
+		return nil, err
+	}
+	for _, image := range warm {
+		if image.RuntimeHandler == "" || c.DaemonConfig == nil {
+			continue
+		}
+		if _, ok := c.DaemonConfig.Runtimes[image.RuntimeHandler]; !ok {
+			return nil, fmt.Errorf("runtime handler %q of warm image %s is not configured in daemon", image.RuntimeHandler, image.Image)
+		}
+	}
+
+	return newImagePrefetcher(bandwidth, warm, warmInterval,
+		func(ctx context.Context, handler, ref string, auth *apitypes.AuthConfig) error {
+			return c.pullImage(ctx, handler, ref, auth, ioutil.Discard)
+		},
+		func(ctx context.Context, ref string) bool {
+			_, _, _, err := c.ImageMgr.CheckReference(ctx, ref)
+			return err == nil
+		},
+	)
+}
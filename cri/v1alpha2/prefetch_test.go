@@ -0,0 +1,231 @@
+package v1alpha2
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	apitypes "github.com/alibaba/pouch/apis/types"
+	metatypes "github.com/alibaba/pouch/cri/v1alpha2/types"
+	"github.com/alibaba/pouch/ctrd"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeRegistry records the images pulled, which are present afterwards.
+type fakeRegistry struct {
+	sync.Mutex
+	images  map[string]bool
+	pulled  []string
+	limited bool
+	err     error
+}
+
+func (r *fakeRegistry) pull(ctx context.Context, handler, ref string, auth *apitypes.AuthConfig) error {
+	r.Lock()
+	defer r.Unlock()
+
+	if r.err != nil {
+		return r.err
+	}
+	r.pulled = append(r.pulled, handler+"/"+ref)
+	r.limited = ctrd.GetPullBandwidth(ctx) != nil
+	r.images[ref] = true
+	return nil
+}
+
+func (r *fakeRegistry) present(ctx context.Context, ref string) bool {
+	r.Lock()
+	defer r.Unlock()
+
+	return r.images[ref]
+}
+
+func (r *fakeRegistry) remove(ref string) {
+	r.Lock()
+	defer r.Unlock()
+
+	delete(r.images, ref)
+}
+
+func reportImages(report *metatypes.PrefetchReport) []string {
+	var images []string
+	for _, s := range report.Images {
+		images = append(images, fmt.Sprintf("%s:%d:%s", s.Image, s.Priority, s.State))
+	}
+	return images
+}
+
+func TestNewImagePrefetcher(t *testing.T) {
+	r := &fakeRegistry{images: map[string]bool{}}
+
+	p, err := newImagePrefetcher("", nil, 0, r.pull, r.present)
+	assert.NoError(t, err)
+	assert.Nil(t, p.limiter)
+
+	p, err = newImagePrefetcher("10m", nil, 0, r.pull, r.present)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10*1024*1024), p.bandwidth)
+	assert.Equal(t, maxPrefetchBurst, p.limiter.Burst())
+
+	_, err = newImagePrefetcher("fast", nil, 0, r.pull, r.present)
+	assert.Error(t, err)
+	_, err = newImagePrefetcher("0", nil, 0, r.pull, r.present)
+	assert.Error(t, err)
+
+	// the warm images are checked periodically.
+	_, err = newImagePrefetcher("", []*metatypes.PrefetchImage{{Image: "busybox"}}, 0, r.pull, r.present)
+	assert.Error(t, err)
+}
+
+func TestParseWarmImages(t *testing.T) {
+	images, err := parseWarmImages([]string{"busybox:latest", "kata = registry.example.com/app:v2"})
+	assert.NoError(t, err)
+	assert.Equal(t, []*metatypes.PrefetchImage{
+		{Image: "busybox:latest"},
+		{Image: "registry.example.com/app:v2", RuntimeHandler: "kata"},
+	}, images)
+
+	for _, e := range []string{"", "=busybox", "kata="} {
+		_, err := parseWarmImages([]string{e})
+		assert.Error(t, err, e)
+	}
+}
+
+func TestImagePrefetcherQueue(t *testing.T) {
+	r := &fakeRegistry{images: map[string]bool{}}
+	p, err := newImagePrefetcher("", nil, 0, r.pull, r.present)
+	assert.NoError(t, err)
+
+	p.enqueue([]*metatypes.PrefetchImage{
+		{Image: "a", Priority: 1},
+		{Image: "b", Priority: 5},
+		{Image: "c", Priority: 1},
+	}, false)
+	// the image queued already is moved ahead by a higher priority only.
+	p.enqueue([]*metatypes.PrefetchImage{
+		{Image: "c", Priority: 10},
+		{Image: "b", Priority: 0},
+	}, false)
+	assert.Equal(t, []string{"c:10:queued", "b:5:queued", "a:1:queued"}, reportImages(p.report()))
+
+	e := p.pop()
+	assert.Equal(t, "c", e.status.Image)
+	// the image being pulled is not queued again.
+	p.enqueue([]*metatypes.PrefetchImage{{Image: "c", Priority: 20}}, false)
+	assert.Equal(t, []string{"c:10:pulling", "b:5:queued", "a:1:queued"}, reportImages(p.report()))
+
+	p.finish(e, p.prefetch(context.Background(), e))
+	e = p.pop()
+	p.finish(e, fmt.Errorf("manifest unknown"))
+	assert.Equal(t, []string{"a:1:queued", "b:5:failed", "c:10:done"}, reportImages(p.report()))
+	assert.Equal(t, "manifest unknown", p.report().Images[1].Error)
+
+	// the failed image is queued again.
+	p.enqueue([]*metatypes.PrefetchImage{{Image: "b", Priority: 5}}, false)
+	assert.Equal(t, []string{"b:5:queued", "a:1:queued", "c:10:done"}, reportImages(p.report()))
+
+	for e = p.pop(); e != nil; e = p.pop() {
+		p.finish(e, p.prefetch(context.Background(), e))
+	}
+	assert.Equal(t, []string{"/c", "/b", "/a"}, r.pulled)
+	assert.False(t, r.limited)
+
+	// the present image is not pulled again.
+	p.enqueue([]*metatypes.PrefetchImage{{Image: "a"}}, false)
+	e = p.pop()
+	p.finish(e, p.prefetch(context.Background(), e))
+	assert.Len(t, r.pulled, 3)
+}
+
+func TestImagePrefetcherHistory(t *testing.T) {
+	r := &fakeRegistry{images: map[string]bool{}}
+	p, err := newImagePrefetcher("", nil, 0, r.pull, r.present)
+	assert.NoError(t, err)
+
+	for i := 0; i < prefetchHistorySize+10; i++ {
+		p.enqueue([]*metatypes.PrefetchImage{{Image: fmt.Sprintf("image-%d", i)}}, false)
+		e := p.pop()
+		p.finish(e, nil)
+	}
+
+	report := p.report()
+	assert.Len(t, report.Images, prefetchHistorySize)
+	assert.Equal(t, fmt.Sprintf("image-%d", prefetchHistorySize+9), report.Images[0].Image)
+	assert.Len(t, p.entries, prefetchHistorySize)
+}
+
+func TestImagePrefetcherWarm(t *testing.T) {
+	r := &fakeRegistry{images: map[string]bool{"busybox": true}}
+	warm := []*metatypes.PrefetchImage{{Image: "busybox"}, {Image: "app:v2", RuntimeHandler: "kata"}}
+	p, err := newImagePrefetcher("1m", warm, time.Hour, r.pull, r.present)
+	assert.NoError(t, err)
+	var workers workerGroup
+	defer workers.stop(time.Second)
+	p.start(&workers)
+
+	// the missing warm image is prefetched with the bandwidth limited.
+	waitPrefetched := func(n int) {
+		for i := 0; i < 100; i++ {
+			report := p.report()
+			done := 0
+			for _, s := range report.Images {
+				if s.State == metatypes.PrefetchDone {
+					done++
+				}
+			}
+			if done == n {
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		t.Fatalf("warm images are not prefetched: %v", reportImages(p.report()))
+	}
+	waitPrefetched(1)
+
+	r.Lock()
+	assert.Equal(t, []string{"kata/app:v2"}, r.pulled)
+	assert.True(t, r.limited)
+	r.Unlock()
+	assert.True(t, p.report().Images[0].Warm)
+
+	// the warm image removed by the image gc is prefetched again.
+	r.remove("busybox")
+	p.reconcileWarm(context.Background())
+	waitPrefetched(2)
+
+	r.Lock()
+	assert.Equal(t, []string{"kata/app:v2", "/busybox"}, r.pulled)
+	r.Unlock()
+
+	// the warm image prefetched within the interval is not pulled back at once.
+	r.remove("app:v2")
+	p.reconcileWarm(context.Background())
+	assert.Equal(t, 0, p.queue.Len())
+
+	p.mu.Lock()
+	p.warmPrefetchedAt[imagePullKey("kata", "app:v2", nil)] = time.Now().Add(-2 * time.Hour)
+	p.mu.Unlock()
+	p.reconcileWarm(context.Background())
+	waitPrefetched(2)
+	r.Lock()
+	assert.Equal(t, []string{"kata/app:v2", "/busybox", "kata/app:v2"}, r.pulled)
+	r.Unlock()
+}
+
+func TestCriImagePrefetcherPull(t *testing.T) {
+	imageMgr := &fakePullImageMgr{pulled: make(map[string]*apitypes.AuthConfig)}
+	c := &CriManager{ImageMgr: imageMgr, imagePulls: newImagePulls()}
+	p, err := c.newCriImagePrefetcher("1m", nil, 0)
+	assert.NoError(t, err)
+
+	// the prefetch is deduplicated with the other pulls, with the bandwidth limited.
+	e := &prefetchEntry{status: metatypes.PrefetchStatus{Image: "busybox"}}
+	assert.NoError(t, p.prefetch(context.Background(), e))
+	imageMgr.mu.Lock()
+	defer imageMgr.mu.Unlock()
+	assert.Contains(t, imageMgr.pulled, "busybox")
+	assert.True(t, imageMgr.limited)
+}
@@ -0,0 +1,92 @@
+package v1alpha2
+
+import (
+	"fmt"
+	"strings"
+
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+	criconfig "github.com/alibaba/pouch/cri/config"
+	"github.com/alibaba/pouch/pkg/log"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// privilegedPolicy decides whether the privileged containers are allowed.
+type privilegedPolicy struct {
+	// disallowed rejects all the privileged containers.
+	disallowed bool
+	// namespaces are the namespaces of pods allowed to run privileged containers.
+	namespaces map[string]struct{}
+	// annotations are the annotations of pods allowed to run privileged containers.
+	annotations map[string]string
+}
+
+// newPrivilegedPolicy creates the privileged policy from the cri config.
+func newPrivilegedPolicy(cfg *criconfig.Config) (*privilegedPolicy, error) {
+	p := &privilegedPolicy{
+		disallowed:  cfg.DisallowPrivileged,
+		namespaces:  make(map[string]struct{}),
+		annotations: make(map[string]string),
+	}
+
+	for _, ns := range cfg.PrivilegedNamespaces {
+		p.namespaces[ns] = struct{}{}
+	}
+
+	for _, a := range cfg.PrivilegedAnnotations {
+		parts := strings.SplitN(a, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("invalid privileged annotation %q, should be key=value", a)
+		}
+		p.annotations[parts[0]] = parts[1]
+	}
+	return p, nil
+}
+
+// allowed returns whether the pod is allowed to run privileged containers,
+// and the reason if not.
+func (p *privilegedPolicy) allowed(sandboxConfig *runtime.PodSandboxConfig) (bool, string) {
+	if p.disallowed {
+		return false, "privileged containers are disallowed"
+	}
+
+	// no allowlist means all the pods are allowed.
+	if len(p.namespaces) == 0 && len(p.annotations) == 0 {
+		return true, ""
+	}
+
+	if _, ok := p.namespaces[sandboxConfig.GetMetadata().GetNamespace()]; ok {
+		return true, ""
+	}
+	for k, v := range sandboxConfig.GetAnnotations() {
+		if allowed, ok := p.annotations[k]; ok && allowed == v {
+			return true, ""
+		}
+	}
+	return false, "neither the namespace nor the annotations of pod are allowed to run privileged containers"
+}
+
+// check returns PermissionDenied error if the privileged container is not
+// allowed in the pod, and records the rejection in the audit log.
+func (p *privilegedPolicy) check(ctx context.Context, config *runtime.ContainerConfig, sandboxConfig *runtime.PodSandboxConfig) error {
+	if p == nil {
+		return nil
+	}
+
+	ok, reason := p.allowed(sandboxConfig)
+	if ok {
+		return nil
+	}
+
+	log.WithFields(ctx, map[string]interface{}{
+		"audit":        "privileged",
+		"PodName":      sandboxConfig.GetMetadata().GetName(),
+		"PodNamespace": sandboxConfig.GetMetadata().GetNamespace(),
+		"Container":    config.GetMetadata().GetName(),
+	}).Warnf("rejected privileged container: %s", reason)
+
+	return status.Errorf(codes.PermissionDenied, "failed to create privileged container %q in pod %q: %s",
+		config.GetMetadata().GetName(), sandboxConfig.GetMetadata().GetName(), reason)
+}
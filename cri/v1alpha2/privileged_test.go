@@ -0,0 +1,71 @@
+package v1alpha2
+
+import (
+	"testing"
+
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+	criconfig "github.com/alibaba/pouch/cri/config"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPrivilegedPolicy(t *testing.T) {
+	container := &runtime.ContainerConfig{Metadata: &runtime.ContainerMetadata{Name: "c"}}
+	pod := func(namespace string, annotations map[string]string) *runtime.PodSandboxConfig {
+		return &runtime.PodSandboxConfig{
+			Metadata:    &runtime.PodSandboxMetadata{Name: "p", Namespace: namespace},
+			Annotations: annotations,
+		}
+	}
+
+	for _, tt := range []struct {
+		name    string
+		cfg     criconfig.Config
+		pod     *runtime.PodSandboxConfig
+		allowed bool
+	}{
+		{
+			name:    "no policy",
+			pod:     pod("default", nil),
+			allowed: true,
+		},
+		{
+			name: "disallowed",
+			cfg:  criconfig.Config{DisallowPrivileged: true, PrivilegedNamespaces: []string{"kube-system"}},
+			pod:  pod("kube-system", nil),
+		},
+		{
+			name:    "allowed namespace",
+			cfg:     criconfig.Config{PrivilegedNamespaces: []string{"kube-system"}},
+			pod:     pod("kube-system", nil),
+			allowed: true,
+		},
+		{
+			name:    "allowed annotation",
+			cfg:     criconfig.Config{PrivilegedNamespaces: []string{"kube-system"}, PrivilegedAnnotations: []string{"privileged=true"}},
+			pod:     pod("default", map[string]string{"privileged": "true"}),
+			allowed: true,
+		},
+		{
+			name: "not allowed",
+			cfg:  criconfig.Config{PrivilegedNamespaces: []string{"kube-system"}, PrivilegedAnnotations: []string{"privileged=true"}},
+			pod:  pod("default", map[string]string{"privileged": "false"}),
+		},
+	} {
+		p, err := newPrivilegedPolicy(&tt.cfg)
+		assert.NoError(t, err, tt.name)
+
+		err = p.check(context.Background(), container, tt.pod)
+		if tt.allowed {
+			assert.NoError(t, err, tt.name)
+		} else {
+			assert.Equal(t, codes.PermissionDenied, status.Code(err), tt.name)
+		}
+	}
+
+	_, err := newPrivilegedPolicy(&criconfig.Config{PrivilegedAnnotations: []string{"privileged"}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,80 @@
+package v1alpha2
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/alibaba/pouch/daemon/mgr"
+)
+
+// processesInfoKey is the key of the process count in the verbose info of container stats.
+const processesInfoKey = "processes"
+
+// ProcessCount is the summarized process count of container, which is useful
+// to find the leak of processes without listing them.
+type ProcessCount struct {
+	// Current is the number of processes and threads in the container.
+	Current uint64 `json:"current"`
+	// Limit is the limit of the number of processes and threads, 0 means unlimited.
+	Limit uint64 `json:"limit,omitempty"`
+}
+
+// readCgroupUint reads the unsigned integer in the cgroup file, "max" is read as 0.
+func readCgroupUint(path string) (uint64, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+
+	v := strings.TrimSpace(string(data))
+	if v == "max" {
+		return 0, nil
+	}
+	return strconv.ParseUint(v, 10, 64)
+}
+
+// containerProcessCount returns the process count of the running container from
+// its pids cgroup, nil is returned if the pids controller is not enabled.
+func containerProcessCount(container *mgr.Container) (*ProcessCount, error) {
+	if !container.IsRunningOrPaused() {
+		return nil, nil
+	}
+
+	path, err := processCgroupPath(container.State.Pid, "pids")
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := readCgroupUint(filepath.Join(path, "pids.current"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	// the root cgroup has no pids.max.
+	limit, err := readCgroupUint(filepath.Join(path, "pids.max"))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	return &ProcessCount{Current: current, Limit: limit}, nil
+}
+
+// processesInfo returns the verbose info of the process count of container.
+func processesInfo(container *mgr.Container) (map[string]string, error) {
+	count, err := containerProcessCount(container)
+	if err != nil || count == nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(count)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{processesInfoKey: string(data)}, nil
+}
@@ -0,0 +1,57 @@
+package v1alpha2
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	apitypes "github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/daemon/mgr"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_processesInfo(t *testing.T) {
+	root, err := ioutil.TempDir("", "processes")
+	assert.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	defer func(cgroup, proc string, isUnified func() bool) {
+		cgroupRoot, procRoot, isCgroup2UnifiedMode = cgroup, proc, isUnified
+	}(cgroupRoot, procRoot, isCgroup2UnifiedMode)
+	cgroupRoot = filepath.Join(root, "cgroup")
+	procRoot = filepath.Join(root, "proc")
+	isCgroup2UnifiedMode = func() bool { return false }
+
+	cgroupPath := filepath.Join(cgroupRoot, "pids", "kubepods", "c1")
+	assert.NoError(t, os.MkdirAll(cgroupPath, 0755))
+	assert.NoError(t, os.MkdirAll(filepath.Join(procRoot, "100"), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(procRoot, "100", "cgroup"), []byte("5:pids:/kubepods/c1\n4:memory:/kubepods/c1\n"), 0644))
+
+	container := &mgr.Container{
+		ID:    "c1",
+		State: &apitypes.ContainerState{Pid: 100, Status: apitypes.StatusRunning, Running: true},
+	}
+
+	// the pids controller is not enabled.
+	info, err := processesInfo(container)
+	assert.NoError(t, err)
+	assert.Nil(t, info)
+
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(cgroupPath, "pids.current"), []byte("12\n"), 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(cgroupPath, "pids.max"), []byte("max\n"), 0644))
+	info, err = processesInfo(container)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{processesInfoKey: `{"current":12}`}, info)
+
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(cgroupPath, "pids.max"), []byte("1024\n"), 0644))
+	info, err = processesInfo(container)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{processesInfoKey: `{"current":12,"limit":1024}`}, info)
+
+	// nothing is reported for the stopped container.
+	info, err = processesInfo(&mgr.Container{ID: "c2", State: &apitypes.ContainerState{Status: apitypes.StatusExited}})
+	assert.NoError(t, err)
+	assert.Nil(t, info)
+}
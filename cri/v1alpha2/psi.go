@@ -0,0 +1,182 @@
+package v1alpha2
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/alibaba/pouch/daemon/mgr"
+	"github.com/alibaba/pouch/pkg/log"
+)
+
+// pressureInfoKey is the key of the pressure stall information in the verbose
+// info of container stats and pod sandbox status.
+const pressureInfoKey = "pressure"
+
+// PSIData is the pressure stall information of the tasks in a cgroup stalled
+// on a resource, the averages are the share of time in percentage in the last
+// 10, 60 and 300 seconds, and the total is the time in microseconds.
+type PSIData struct {
+	Avg10  float64 `json:"avg10"`
+	Avg60  float64 `json:"avg60"`
+	Avg300 float64 `json:"avg300"`
+	Total  uint64  `json:"total"`
+}
+
+// PSIStats is the pressure stall information of a resource, some means at least
+// some tasks are stalled, and full means all the non-idle tasks are stalled.
+type PSIStats struct {
+	Some *PSIData `json:"some,omitempty"`
+	Full *PSIData `json:"full,omitempty"`
+}
+
+// Pressure is the pressure stall information of cpu, memory and io of a cgroup.
+type Pressure struct {
+	CPU    *PSIStats `json:"cpu,omitempty"`
+	Memory *PSIStats `json:"memory,omitempty"`
+	IO     *PSIStats `json:"io,omitempty"`
+}
+
+// readPSIStats parses the pressure file of cgroup, which is in the format of
+// "some avg10=0.00 avg60=0.00 avg300=0.00 total=0", the line of full is
+// absent in the cpu.pressure of the old kernels.
+func readPSIStats(file string) (*PSIStats, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	stats := &PSIStats{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		data := &PSIData{}
+		for _, field := range fields[1:] {
+			kv := strings.SplitN(field, "=", 2)
+			if len(kv) != 2 {
+				return nil, fmt.Errorf("invalid pressure %q in %s", field, file)
+			}
+
+			var err error
+			switch kv[0] {
+			case "avg10":
+				data.Avg10, err = strconv.ParseFloat(kv[1], 64)
+			case "avg60":
+				data.Avg60, err = strconv.ParseFloat(kv[1], 64)
+			case "avg300":
+				data.Avg300, err = strconv.ParseFloat(kv[1], 64)
+			case "total":
+				data.Total, err = strconv.ParseUint(kv[1], 10, 64)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("invalid pressure %q in %s: %v", field, file, err)
+			}
+		}
+
+		switch fields[0] {
+		case "some":
+			stats.Some = data
+		case "full":
+			stats.Full = data
+		}
+	}
+	return stats, scanner.Err()
+}
+
+// cgroupPressure reads the pressure stall information of the cgroup v2 path,
+// the ones not supported by the kernel are omitted.
+func cgroupPressure(path string) (*Pressure, error) {
+	p := &Pressure{}
+	for file, stats := range map[string]**PSIStats{
+		"cpu.pressure":    &p.CPU,
+		"memory.pressure": &p.Memory,
+		"io.pressure":     &p.IO,
+	} {
+		s, err := readPSIStats(filepath.Join(path, file))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		*stats = s
+	}
+	return p, nil
+}
+
+// pressureInfo returns the verbose info of the pressure stall information of
+// the cgroup v2 path, nothing is returned on cgroup v1 since PSI is only
+// accounted per cgroup in the unified hierarchy.
+func pressureInfo(path string) (map[string]string, error) {
+	p, err := cgroupPressure(path)
+	if err != nil {
+		return nil, err
+	}
+	if p.CPU == nil && p.Memory == nil && p.IO == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{pressureInfoKey: string(data)}, nil
+}
+
+// containerPressureInfo returns the verbose info of the pressure stall information of the running container.
+func containerPressureInfo(container *mgr.Container) (map[string]string, error) {
+	if !isCgroup2UnifiedMode() || !container.IsRunning() {
+		return nil, nil
+	}
+
+	path, err := processCgroupPath(container.State.Pid, "")
+	if err != nil {
+		return nil, err
+	}
+	return pressureInfo(path)
+}
+
+// podPressureInfo returns the verbose info of the pressure stall information of the pod cgroup.
+func podPressureInfo(cgroupParent string, useSystemd bool) (map[string]string, error) {
+	if !isCgroup2UnifiedMode() || cgroupParent == "" {
+		return nil, nil
+	}
+
+	path, err := podCgroupPath(unifiedCgroupRoot, cgroupParent, useSystemd)
+	if err != nil {
+		return nil, err
+	}
+	return pressureInfo(path)
+}
+
+// containerStatsInfo returns the verbose info of container stats, the failures
+// of reading the pressure and process count are only logged since the stats
+// are still useful.
+func containerStatsInfo(ctx context.Context, container *mgr.Container) map[string]string {
+	info, err := containerPressureInfo(container)
+	if err != nil {
+		log.With(ctx).Warnf("failed to get pressure of container %q: %v", container.ID, err)
+	}
+
+	processes, err := processesInfo(container)
+	if err != nil {
+		log.With(ctx).Warnf("failed to get process count of container %q: %v", container.ID, err)
+	}
+	for k, v := range processes {
+		if info == nil {
+			info = make(map[string]string)
+		}
+		info[k] = v
+	}
+	return info
+}
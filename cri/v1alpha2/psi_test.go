@@ -0,0 +1,93 @@
+package v1alpha2
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	apitypes "github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/daemon/mgr"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_readPSIStats(t *testing.T) {
+	dir, err := ioutil.TempDir("", "psi")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "memory.pressure")
+	assert.NoError(t, ioutil.WriteFile(file, []byte(
+		"some avg10=1.50 avg60=0.25 avg300=0.00 total=12345\nfull avg10=0.50 avg60=0.00 avg300=0.00 total=678\n"), 0644))
+	stats, err := readPSIStats(file)
+	assert.NoError(t, err)
+	assert.Equal(t, &PSIStats{
+		Some: &PSIData{Avg10: 1.5, Avg60: 0.25, Total: 12345},
+		Full: &PSIData{Avg10: 0.5, Total: 678},
+	}, stats)
+
+	// the line of full is absent in the cpu.pressure of the old kernels.
+	assert.NoError(t, ioutil.WriteFile(file, []byte("some avg10=0.00 avg60=0.00 avg300=0.00 total=1\n"), 0644))
+	stats, err = readPSIStats(file)
+	assert.NoError(t, err)
+	assert.Equal(t, &PSIStats{Some: &PSIData{Total: 1}}, stats)
+
+	assert.NoError(t, ioutil.WriteFile(file, []byte("some avg10=high\n"), 0644))
+	_, err = readPSIStats(file)
+	assert.Error(t, err)
+}
+
+func Test_containerPressureInfo(t *testing.T) {
+	root, err := ioutil.TempDir("", "psi")
+	assert.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	defer func(cgroupRoot, proc string, isUnified func() bool) {
+		unifiedCgroupRoot, procRoot, isCgroup2UnifiedMode = cgroupRoot, proc, isUnified
+	}(unifiedCgroupRoot, procRoot, isCgroup2UnifiedMode)
+	unifiedCgroupRoot = filepath.Join(root, "cgroup")
+	procRoot = filepath.Join(root, "proc")
+
+	cgroupPath := filepath.Join(unifiedCgroupRoot, "kubepods", "pod1", "c1")
+	assert.NoError(t, os.MkdirAll(cgroupPath, 0755))
+	assert.NoError(t, os.MkdirAll(filepath.Join(procRoot, "100"), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(procRoot, "100", "cgroup"), []byte("0::/kubepods/pod1/c1\n"), 0644))
+	for _, file := range []string{"cpu.pressure", "io.pressure"} {
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(cgroupPath, file), []byte("some avg10=2.00 avg60=1.00 avg300=0.50 total=100\n"), 0644))
+	}
+
+	container := &mgr.Container{
+		ID:    "c1",
+		State: &apitypes.ContainerState{Pid: 100, Status: apitypes.StatusRunning, Running: true},
+	}
+
+	// PSI is not accounted per cgroup on cgroup v1.
+	isCgroup2UnifiedMode = func() bool { return false }
+	info, err := containerPressureInfo(container)
+	assert.NoError(t, err)
+	assert.Nil(t, info)
+
+	isCgroup2UnifiedMode = func() bool { return true }
+	info, err = containerPressureInfo(container)
+	assert.NoError(t, err)
+	var p Pressure
+	assert.NoError(t, json.Unmarshal([]byte(info[pressureInfoKey]), &p))
+	assert.Equal(t, Pressure{
+		CPU: &PSIStats{Some: &PSIData{Avg10: 2, Avg60: 1, Avg300: 0.5, Total: 100}},
+		IO:  &PSIStats{Some: &PSIData{Avg10: 2, Avg60: 1, Avg300: 0.5, Total: 100}},
+	}, p)
+
+	// the pod cgroup.
+	podPath := filepath.Dir(cgroupPath)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(podPath, "memory.pressure"), []byte("some avg10=0.00 avg60=0.00 avg300=0.00 total=5\n"), 0644))
+	info, err = podPressureInfo("/kubepods/pod1", false)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"memory":{"some":{"avg10":0,"avg60":0,"avg300":0,"total":5}}}`, info[pressureInfoKey])
+
+	// no pressure is supported by the kernel.
+	info, err = podPressureInfo("/kubepods", false)
+	assert.NoError(t, err)
+	assert.Nil(t, info)
+}
@@ -0,0 +1,179 @@
+package v1alpha2
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	apitypes "github.com/alibaba/pouch/apis/types"
+	anno "github.com/alibaba/pouch/cri/annotations"
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+)
+
+const (
+	qosClassGuaranteed = "Guaranteed"
+	qosClassBurstable  = "Burstable"
+	qosClassBestEffort = "BestEffort"
+)
+
+// resctrlRoot is the mount point of resctrl filesystem, in which each
+// directory is a class of service of Intel RDT.
+var resctrlRoot = "/sys/fs/resctrl"
+
+// parseRdtQoSClasses parses the RDT classes of QoS classes of pods, each of
+// them is in the form of "qos=class", e.g. "Guaranteed=gold".
+func parseRdtQoSClasses(entries []string) (map[string]string, error) {
+	classes := make(map[string]string)
+	for _, e := range entries {
+		parts := strings.SplitN(e, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid rdt qos class %q, should be qos=class", e)
+		}
+
+		qos, class := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+		switch qos {
+		case qosClassGuaranteed, qosClassBurstable, qosClassBestEffort:
+		default:
+			return nil, fmt.Errorf("invalid qos class %q in rdt qos class %q, should be %s, %s or %s",
+				qos, e, qosClassGuaranteed, qosClassBurstable, qosClassBestEffort)
+		}
+		if err := validateRdtClassName(class); err != nil {
+			return nil, err
+		}
+		if _, exists := classes[qos]; exists {
+			return nil, fmt.Errorf("duplicated qos class %s in rdt qos classes", qos)
+		}
+		classes[qos] = class
+	}
+	return classes, nil
+}
+
+func validateRdtClassName(class string) error {
+	if class == "" || class == "." || class == ".." || strings.Contains(class, "/") {
+		return fmt.Errorf("invalid rdt class %q", class)
+	}
+	return nil
+}
+
+// podQoSClass returns the QoS class of pod from the cgroup parent set by kubelet
+// in the sandbox config, which is <root>/kubepods[/burstable|/besteffort]/pod<uid>
+// of cgroupfs, or kubepods[-burstable|-besteffort]-pod<uid>.slice of systemd,
+// empty means the pod is not the one of kubelet.
+func podQoSClass(sandboxConfig *runtime.PodSandboxConfig) string {
+	cgroupParent := sandboxConfig.GetLinux().GetCgroupParent()
+	if cgroupParent == "" {
+		return ""
+	}
+
+	var parts []string
+	if strings.HasSuffix(cgroupParent, ".slice") {
+		// the ancestors are in the name of slice, e.g. kubepods-besteffort-pod1.slice.
+		parts = strings.Split(strings.TrimSuffix(path.Base(cgroupParent), ".slice"), "-")
+	} else {
+		parts = strings.Split(strings.Trim(path.Clean(cgroupParent), "/"), "/")
+	}
+
+	n := len(parts)
+	if n < 2 || !strings.HasPrefix(parts[n-1], "pod") {
+		return ""
+	}
+	if parts[n-2] == "kubepods" {
+		return qosClassGuaranteed
+	}
+	if n >= 3 && parts[n-3] == "kubepods" {
+		switch parts[n-2] {
+		case "burstable":
+			return qosClassBurstable
+		case "besteffort":
+			return qosClassBestEffort
+		}
+	}
+	return ""
+}
+
+// rdtClass returns the RDT class of container, the one in the annotations takes
+// precedence over the one of the QoS class of pod, empty means no class.
+func (c *CriManager) rdtClass(annotations map[string]string, sandboxConfig *runtime.PodSandboxConfig) (string, error) {
+	if class, ok := annotations[anno.RdtClassExtendAnnotation]; ok {
+		if err := validateRdtClassName(class); err != nil {
+			return "", err
+		}
+		return class, nil
+	}
+	return c.rdtQoSClasses[podQoSClass(sandboxConfig)], nil
+}
+
+// resctrlMounted returns whether the resctrl filesystem is mounted at resctrlRoot.
+func resctrlMounted() (bool, error) {
+	f, err := os.Open(filepath.Join(procRoot, "mounts"))
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) >= 3 && fields[1] == resctrlRoot && fields[2] == "resctrl" {
+			return true, nil
+		}
+	}
+	return false, scanner.Err()
+}
+
+// validateRdtClass checks the class of service is created in the mounted resctrl.
+func validateRdtClass(class string) error {
+	mounted, err := resctrlMounted()
+	if err != nil {
+		return fmt.Errorf("failed to check whether resctrl is mounted: %v", err)
+	}
+	if !mounted {
+		return fmt.Errorf("failed to assign rdt class %q: resctrl is not mounted at %s", class, resctrlRoot)
+	}
+
+	if _, err := os.Stat(filepath.Join(resctrlRoot, class, "tasks")); err != nil {
+		return fmt.Errorf("failed to assign rdt class %q: %v", class, err)
+	}
+	return nil
+}
+
+// rdtL3Schema returns the L3 cache schema of the class of service, e.g.
+// "L3:0=ff;1=ff".
+func rdtL3Schema(class string) (string, error) {
+	if err := validateRdtClass(class); err != nil {
+		return "", err
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(resctrlRoot, class, "schemata"))
+	if err != nil {
+		return "", fmt.Errorf("failed to read schemata of rdt class %q: %v", class, err)
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if line = strings.Replace(line, " ", "", -1); strings.HasPrefix(line, "L3:") {
+			return line, nil
+		}
+	}
+	return "", fmt.Errorf("rdt class %q has no L3 cache schema", class)
+}
+
+// applyContainerRdtClass sets the L3 cache schema of the class of service of
+// container in its spec, so that runc places the container in the resctrl
+// group of the schema before the user process is started. Since the OCI spec
+// has no class id yet, each container has its own group of the same schema.
+func (c *CriManager) applyContainerRdtClass(hostConfig *apitypes.HostConfig, annotations map[string]string, sandboxConfig *runtime.PodSandboxConfig) error {
+	class, err := c.rdtClass(annotations, sandboxConfig)
+	if err != nil || class == "" {
+		return err
+	}
+
+	schema, err := rdtL3Schema(class)
+	if err != nil {
+		return fmt.Errorf("failed to assign container to rdt class %q: %v", class, err)
+	}
+	hostConfig.IntelRdtL3Cbm = schema
+	return nil
+}
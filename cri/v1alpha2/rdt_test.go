@@ -0,0 +1,102 @@
+package v1alpha2
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	apitypes "github.com/alibaba/pouch/apis/types"
+	anno "github.com/alibaba/pouch/cri/annotations"
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseRdtQoSClasses(t *testing.T) {
+	classes, err := parseRdtQoSClasses([]string{"Guaranteed=gold", " BestEffort = bronze "})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{qosClassGuaranteed: "gold", qosClassBestEffort: "bronze"}, classes)
+
+	for _, entries := range [][]string{
+		{"gold"},
+		{"Unknown=gold"},
+		{"Guaranteed="},
+		{"Guaranteed=../gold"},
+		{"Guaranteed=gold", "Guaranteed=silver"},
+	} {
+		_, err := parseRdtQoSClasses(entries)
+		assert.Error(t, err, "entries %v", entries)
+	}
+}
+
+func Test_podQoSClass(t *testing.T) {
+	for cgroupParent, want := range map[string]string{
+		"":                              "",
+		"/kubepods/besteffort/pod1":     qosClassBestEffort,
+		"/kubepods/burstable/pod1/":     qosClassBurstable,
+		"kubepods-burstable-pod1.slice": qosClassBurstable,
+		"/kubepods.slice/kubepods-besteffort.slice/kubepods-besteffort-pod1.slice": qosClassBestEffort,
+		"/kubepods/pod1":                            qosClassGuaranteed,
+		"kubepods-pod1.slice":                       qosClassGuaranteed,
+		"/system.slice/docker-pod1.scope":           "",
+		"/besteffort-pods/kubepods/besteffort-pod1": "",
+		"/kubepods/burstable":                       "",
+	} {
+		sandboxConfig := &runtime.PodSandboxConfig{Linux: &runtime.LinuxPodSandboxConfig{CgroupParent: cgroupParent}}
+		assert.Equal(t, want, podQoSClass(sandboxConfig), "cgroup parent %q", cgroupParent)
+	}
+	assert.Equal(t, "", podQoSClass(nil))
+}
+
+func Test_applyContainerRdtClass(t *testing.T) {
+	root, err := ioutil.TempDir("", "rdt")
+	assert.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	defer func(resctrl, proc string) {
+		resctrlRoot, procRoot = resctrl, proc
+	}(resctrlRoot, procRoot)
+	resctrlRoot = filepath.Join(root, "resctrl")
+	procRoot = filepath.Join(root, "proc")
+
+	for class, schemata := range map[string]string{
+		"gold":   "    L3:0=fff;1=fff\n    MB:0=100;1=100\n",
+		"bronze": "    L3:0=00f;1=00f\n",
+		"mba":    "    MB:0=50\n",
+	} {
+		assert.NoError(t, os.MkdirAll(filepath.Join(resctrlRoot, class), 0755))
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(resctrlRoot, class, "tasks"), nil, 0644))
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(resctrlRoot, class, "schemata"), []byte(schemata), 0644))
+	}
+	assert.NoError(t, os.MkdirAll(procRoot, 0755))
+	mounts := filepath.Join(procRoot, "mounts")
+	assert.NoError(t, ioutil.WriteFile(mounts, []byte("proc /proc proc rw 0 0\n"), 0644))
+
+	c := &CriManager{rdtQoSClasses: map[string]string{qosClassBestEffort: "bronze"}}
+	sandboxConfig := &runtime.PodSandboxConfig{Linux: &runtime.LinuxPodSandboxConfig{CgroupParent: "/kubepods/besteffort/pod1"}}
+
+	// resctrl is not mounted.
+	hostConfig := &apitypes.HostConfig{}
+	assert.Error(t, c.applyContainerRdtClass(hostConfig, nil, sandboxConfig))
+
+	assert.NoError(t, ioutil.WriteFile(mounts, []byte("resctrl "+resctrlRoot+" resctrl rw 0 0\n"), 0644))
+	assert.NoError(t, c.applyContainerRdtClass(hostConfig, nil, sandboxConfig))
+	assert.Equal(t, "L3:0=00f;1=00f", hostConfig.IntelRdtL3Cbm)
+
+	// the class in annotations takes precedence.
+	hostConfig = &apitypes.HostConfig{}
+	assert.NoError(t, c.applyContainerRdtClass(hostConfig, map[string]string{anno.RdtClassExtendAnnotation: "gold"}, sandboxConfig))
+	assert.Equal(t, "L3:0=fff;1=fff", hostConfig.IntelRdtL3Cbm)
+
+	// the class does not exist, or has no L3 cache schema.
+	for _, class := range []string{"silver", "mba", "../gold"} {
+		assert.Error(t, c.applyContainerRdtClass(&apitypes.HostConfig{}, map[string]string{anno.RdtClassExtendAnnotation: class}, sandboxConfig), class)
+	}
+
+	// no class of the pods of other QoS classes.
+	hostConfig = &apitypes.HostConfig{}
+	sandboxConfig.Linux.CgroupParent = "/kubepods/pod1"
+	assert.NoError(t, c.applyContainerRdtClass(hostConfig, nil, sandboxConfig))
+	assert.Equal(t, "", hostConfig.IntelRdtL3Cbm)
+}
@@ -0,0 +1,36 @@
+package v1alpha2
+
+import (
+	"time"
+
+	criconfig "github.com/alibaba/pouch/cri/config"
+)
+
+// ReloadConfig applies the reloadable fields of cri config to the running cri manager.
+func (c *CriManager) ReloadConfig(cfg criconfig.Config) {
+	if cfg.SandboxImage != "" {
+		c.configLock.Lock()
+		c.SandboxImage = cfg.SandboxImage
+		c.configLock.Unlock()
+	}
+
+	if c.snapshotsSyncer != nil && cfg.CriStatsCollectPeriod > 0 {
+		c.snapshotsSyncer.SetPeriod(time.Duration(cfg.CriStatsCollectPeriod) * time.Second)
+	}
+
+	if c.StreamServer != nil && cfg.StreamIdleTimeout > 0 {
+		c.StreamServer.SetStreamIdleTimeout(time.Duration(cfg.StreamIdleTimeout) * time.Second)
+	}
+}
+
+// sandboxImage returns the image used by sandbox container, which is the one
+// of built-in pause if enabled.
+func (c *CriManager) sandboxImage() string {
+	if c.builtinPause != nil {
+		return c.builtinPause.image
+	}
+
+	c.configLock.RLock()
+	defer c.configLock.RUnlock()
+	return c.SandboxImage
+}
@@ -0,0 +1,171 @@
+package v1alpha2
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+	metatypes "github.com/alibaba/pouch/cri/v1alpha2/types"
+	"github.com/alibaba/pouch/pkg/log"
+
+	"google.golang.org/grpc"
+)
+
+const (
+	podNameField      = "PodName"
+	podNamespaceField = "PodNamespace"
+	podUIDField       = "PodUID"
+	podSandboxIDField = "PodSandboxID"
+	containerIDField  = "ContainerID"
+)
+
+type (
+	podSandboxIDGetter interface {
+		GetPodSandboxId() string
+	}
+	containerIDGetter interface {
+		GetContainerId() string
+	}
+	podSandboxConfigGetter interface {
+		GetConfig() *runtime.PodSandboxConfig
+	}
+	sandboxConfigGetter interface {
+		GetSandboxConfig() *runtime.PodSandboxConfig
+	}
+
+	// requestFieldsKey is the context key of the fields of request.
+	requestFieldsKey struct{}
+)
+
+// requestFieldsUnaryServerInterceptor attaches the pod and container of the cri request
+// as structured fields to the log entry of the context. The fields looked up from the
+// stores are resolved once they are first used, so that the requests never logged,
+// audited or published, e.g. the status calls polled by kubelet, do not look them up.
+func requestFieldsUnaryServerInterceptor(criMgr CriMgr) grpc.UnaryServerInterceptor {
+	c, _ := criMgr.(*CriManager)
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		fields := &lazyRequestFields{resolve: func() map[string]interface{} {
+			return c.requestFields(ctx, req)
+		}}
+		if names := requestFieldNames(req); len(names) != 0 {
+			logFields := make(map[string]interface{}, len(names))
+			for _, name := range names {
+				logFields[name] = lazyRequestField{fields: fields, name: name}
+			}
+			ctx = log.AddFields(ctx, logFields)
+		}
+		return handler(context.WithValue(ctx, requestFieldsKey{}, fields), req)
+	}
+}
+
+// lazyRequestFields are the fields of request resolved once.
+type lazyRequestFields struct {
+	once    sync.Once
+	resolve func() map[string]interface{}
+	fields  map[string]interface{}
+}
+
+func (f *lazyRequestFields) get() map[string]interface{} {
+	f.once.Do(func() {
+		f.fields = f.resolve()
+	})
+	return f.fields
+}
+
+// lazyRequestField is the field of request in the log entry, which is resolved
+// when the entry is formatted.
+type lazyRequestField struct {
+	fields *lazyRequestFields
+	name   string
+}
+
+func (f lazyRequestField) String() string {
+	if v, ok := f.fields.get()[f.name]; ok {
+		return fmt.Sprint(v)
+	}
+	return ""
+}
+
+// MarshalJSON resolves the field for the json formatter of logs.
+func (f lazyRequestField) MarshalJSON() ([]byte, error) {
+	return json.Marshal(f.String())
+}
+
+// requestFieldNames returns the names of the fields the request has, without
+// looking up the stores.
+func requestFieldNames(req interface{}) []string {
+	var names []string
+	_, hasPodConfig := req.(podSandboxConfigGetter)
+	_, hasSandboxConfig := req.(sandboxConfigGetter)
+	r, hasSandboxID := req.(podSandboxIDGetter)
+	hasSandboxID = hasSandboxID && r.GetPodSandboxId() != ""
+	if rc, ok := req.(containerIDGetter); ok && rc.GetContainerId() != "" {
+		names = append(names, containerIDField)
+		hasSandboxID = true
+	}
+	if hasSandboxID {
+		names = append(names, podSandboxIDField)
+	}
+	if hasPodConfig || hasSandboxConfig || hasSandboxID {
+		names = append(names, podNameField, podNamespaceField, podUIDField)
+	}
+	return names
+}
+
+// contextRequestFields returns the fields of request attached to the context
+// by requestFieldsUnaryServerInterceptor, so that the pod metadata is not looked
+// up again by the later interceptors. They are got if not attached.
+func (c *CriManager) contextRequestFields(ctx context.Context, req interface{}) map[string]interface{} {
+	if fields, ok := ctx.Value(requestFieldsKey{}).(*lazyRequestFields); ok {
+		return fields.get()
+	}
+	return c.requestFields(ctx, req)
+}
+
+// requestFields returns the pod name/namespace/uid and container id related to the request.
+// The pod metadata is looked up from the sandbox store if the request only carries ids.
+func (c *CriManager) requestFields(ctx context.Context, req interface{}) map[string]interface{} {
+	fields := make(map[string]interface{})
+
+	var (
+		sandboxID string
+		metadata  *runtime.PodSandboxMetadata
+	)
+	if r, ok := req.(podSandboxConfigGetter); ok {
+		metadata = r.GetConfig().GetMetadata()
+	}
+	if r, ok := req.(sandboxConfigGetter); ok {
+		metadata = r.GetSandboxConfig().GetMetadata()
+	}
+	if r, ok := req.(podSandboxIDGetter); ok {
+		sandboxID = r.GetPodSandboxId()
+	}
+	if r, ok := req.(containerIDGetter); ok && r.GetContainerId() != "" {
+		fields[containerIDField] = r.GetContainerId()
+		if sandboxID == "" && c != nil && c.ContainerMgr != nil {
+			if container, err := c.ContainerMgr.Get(ctx, r.GetContainerId()); err == nil && container.Config != nil {
+				sandboxID = container.Config.Labels[sandboxIDLabelKey]
+			}
+		}
+	}
+
+	if sandboxID != "" {
+		fields[podSandboxIDField] = sandboxID
+		if metadata == nil && c != nil && c.SandboxStore != nil {
+			if res, err := c.SandboxStore.Get(sandboxID); err == nil {
+				if sandboxMeta, ok := res.(*metatypes.SandboxMeta); ok {
+					metadata = sandboxMeta.Config.GetMetadata()
+				}
+			}
+		}
+	}
+
+	if metadata != nil {
+		fields[podNameField] = metadata.GetName()
+		fields[podNamespaceField] = metadata.GetNamespace()
+		fields[podUIDField] = metadata.GetUid()
+	}
+	return fields
+}
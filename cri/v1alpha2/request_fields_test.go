@@ -0,0 +1,127 @@
+package v1alpha2
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+	metatypes "github.com/alibaba/pouch/cri/v1alpha2/types"
+	"github.com/alibaba/pouch/daemon/mgr"
+	"github.com/alibaba/pouch/pkg/errtypes"
+	"github.com/alibaba/pouch/pkg/log"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+func TestRequestFields(t *testing.T) {
+	homeDir, err := ioutil.TempDir("", "request-fields")
+	assert.NoError(t, err)
+	defer os.RemoveAll(homeDir)
+
+	store, err := newSandboxStore(homeDir)
+	assert.NoError(t, err)
+	defer store.Shutdown()
+
+	metadata := &runtime.PodSandboxMetadata{Name: "nginx", Namespace: "default", Uid: "uid1"}
+	assert.NoError(t, store.Put(&metatypes.SandboxMeta{
+		ID:     "sandbox1",
+		Config: &runtime.PodSandboxConfig{Metadata: metadata},
+	}))
+	c := &CriManager{SandboxStore: store}
+
+	for _, tc := range []struct {
+		name string
+		req  interface{}
+		want map[string]interface{}
+	}{
+		{
+			name: "RunPodSandbox",
+			req:  &runtime.RunPodSandboxRequest{Config: &runtime.PodSandboxConfig{Metadata: metadata}},
+			want: map[string]interface{}{
+				podNameField:      "nginx",
+				podNamespaceField: "default",
+				podUIDField:       "uid1",
+			},
+		},
+		{
+			name: "StopPodSandbox",
+			req:  &runtime.StopPodSandboxRequest{PodSandboxId: "sandbox1"},
+			want: map[string]interface{}{
+				podSandboxIDField: "sandbox1",
+				podNameField:      "nginx",
+				podNamespaceField: "default",
+				podUIDField:       "uid1",
+			},
+		},
+		{
+			name: "StopPodSandbox of unknown sandbox",
+			req:  &runtime.StopPodSandboxRequest{PodSandboxId: "sandbox2"},
+			want: map[string]interface{}{
+				podSandboxIDField: "sandbox2",
+			},
+		},
+		{
+			name: "ContainerStatus",
+			req:  &runtime.ContainerStatusRequest{ContainerId: "container1"},
+			want: map[string]interface{}{
+				containerIDField: "container1",
+			},
+		},
+		{
+			name: "Version",
+			req:  &runtime.VersionRequest{},
+			want: map[string]interface{}{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, c.requestFields(context.Background(), tc.req))
+		})
+	}
+}
+
+// countingContainerMgr finds no container and counts the lookups.
+type countingContainerMgr struct {
+	mgr.ContainerMgr
+	gets int
+}
+
+func (f *countingContainerMgr) Get(ctx context.Context, name string) (*mgr.Container, error) {
+	f.gets++
+	return nil, errors.Wrap(errtypes.ErrNotfound, name)
+}
+
+func TestRequestFieldsResolvedLazily(t *testing.T) {
+	ctrMgr := &countingContainerMgr{}
+	c := &CriManager{ContainerMgr: ctrMgr}
+	req := &runtime.ContainerStatusRequest{ContainerId: "container1"}
+
+	// the container is not looked up until the fields are used.
+	var handlerCtx context.Context
+	_, err := requestFieldsUnaryServerInterceptor(c)(context.Background(), req, &grpc.UnaryServerInfo{},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			handlerCtx = ctx
+			return nil, nil
+		})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, ctrMgr.gets)
+
+	// the fields are resolved once.
+	assert.Equal(t, "container1", c.contextRequestFields(handlerCtx, req)[containerIDField])
+	field := log.With(handlerCtx).Data[containerIDField]
+	assert.Equal(t, "container1", fmt.Sprint(field))
+	data, err := json.Marshal(field)
+	assert.NoError(t, err)
+	assert.Equal(t, `"container1"`, string(data))
+	assert.Equal(t, "", fmt.Sprint(log.With(handlerCtx).Data[podNameField]))
+	assert.Equal(t, 1, ctrMgr.gets)
+
+	assert.Equal(t, []string{containerIDField, podSandboxIDField, podNameField, podNamespaceField, podUIDField}, requestFieldNames(req))
+	assert.Equal(t, []string{podNameField, podNamespaceField, podUIDField}, requestFieldNames(&runtime.RunPodSandboxRequest{}))
+	assert.Empty(t, requestFieldNames(&runtime.ListContainersRequest{}))
+}
@@ -0,0 +1,39 @@
+package v1alpha2
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	apitypes "github.com/alibaba/pouch/apis/types"
+)
+
+// rootless returns whether pouchd runs without the root of host.
+func (c *CriManager) rootless() bool {
+	return c.DaemonConfig != nil && c.DaemonConfig.Rootless
+}
+
+// rootlessOOMScoreAdj returns the oom score adj not lower than the one of
+// pouchd, since the unprivileged process could not lower the oom score adj
+// of its children, e.g. -998 of the sandboxes fails the creation.
+func rootlessOOMScoreAdj(adj int64) int64 {
+	data, err := ioutil.ReadFile(filepath.Join(procRoot, "self", "oom_score_adj"))
+	if err != nil {
+		return adj
+	}
+	min, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil || adj >= min {
+		return adj
+	}
+	return min
+}
+
+// applyRootlessConfig adjusts the create config of container which could not
+// be applied without the root of host.
+func (c *CriManager) applyRootlessConfig(createConfig *apitypes.ContainerCreateConfig) {
+	if !c.rootless() {
+		return
+	}
+	createConfig.HostConfig.OomScoreAdj = rootlessOOMScoreAdj(createConfig.HostConfig.OomScoreAdj)
+}
@@ -0,0 +1,43 @@
+package v1alpha2
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	apitypes "github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/daemon/config"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_applyRootlessConfig(t *testing.T) {
+	root, err := ioutil.TempDir("", "rootless")
+	assert.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	defer func(proc string) { procRoot = proc }(procRoot)
+	procRoot = root
+	assert.NoError(t, os.MkdirAll(filepath.Join(root, "self"), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(root, "self", "oom_score_adj"), []byte("100\n"), 0644))
+
+	newCreateConfig := func(adj int64) *apitypes.ContainerCreateConfig {
+		return &apitypes.ContainerCreateConfig{HostConfig: &apitypes.HostConfig{Resources: apitypes.Resources{}, OomScoreAdj: adj}}
+	}
+
+	// the oom score adj is not changed if not rootless.
+	c := &CriManager{DaemonConfig: &config.Config{}}
+	createConfig := newCreateConfig(-998)
+	c.applyRootlessConfig(createConfig)
+	assert.Equal(t, int64(-998), createConfig.HostConfig.OomScoreAdj)
+
+	// the oom score adj lower than the one of pouchd is raised.
+	c = &CriManager{DaemonConfig: &config.Config{Rootless: true}}
+	c.applyRootlessConfig(createConfig)
+	assert.Equal(t, int64(100), createConfig.HostConfig.OomScoreAdj)
+
+	createConfig = newCreateConfig(1000)
+	c.applyRootlessConfig(createConfig)
+	assert.Equal(t, int64(1000), createConfig.HostConfig.OomScoreAdj)
+}
@@ -0,0 +1,60 @@
+package v1alpha2
+
+import (
+	"fmt"
+	"strings"
+
+	anno "github.com/alibaba/pouch/cri/annotations"
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+	"github.com/alibaba/pouch/ctrd"
+
+	"golang.org/x/net/context"
+)
+
+// parseRuntimeSnapshotters parses the snapshotters of runtime handlers, each of
+// them is in the form of "handler=snapshotter", e.g. "kata=devmapper".
+func parseRuntimeSnapshotters(entries []string) (map[string]string, error) {
+	snapshotters := make(map[string]string)
+	for _, e := range entries {
+		parts := strings.SplitN(e, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
+			return nil, fmt.Errorf("invalid runtime snapshotter %q, should be handler=snapshotter", e)
+		}
+
+		handler, snapshotter := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+		if prev, ok := snapshotters[handler]; ok && prev != snapshotter {
+			return nil, fmt.Errorf("conflict snapshotters %q and %q of runtime handler %q", prev, snapshotter, handler)
+		}
+		snapshotters[handler] = snapshotter
+	}
+	return snapshotters, nil
+}
+
+// withRuntimeSnapshotter returns the context choosing the snapshotter of runtime
+// handler, in which the images are unpacked and the rootfs of containers are
+// prepared. The default snapshotter of daemon is used if none is configured.
+func (c *CriManager) withRuntimeSnapshotter(ctx context.Context, handler string) context.Context {
+	snapshotter, ok := c.runtimeSnapshotters[handler]
+	if !ok {
+		return ctx
+	}
+	return ctrd.WithSnapshotter(ctx, snapshotter)
+}
+
+// podRuntimeHandler returns the runtime handler of pod in the sandbox config
+// of request, which is the one of annotation io.kubernetes.runtime or the default
+// runtime of daemon, empty if the sandbox config is not specified. The image is
+// unpacked into the snapshotter of sandbox when the container is created anyway.
+func (c *CriManager) podRuntimeHandler(config *runtime.PodSandboxConfig) string {
+	if config == nil || len(c.runtimeSnapshotters) == 0 {
+		return ""
+	}
+
+	if rt, ok := config.GetAnnotations()[anno.KubernetesRuntime]; ok {
+		return rt
+	}
+	if c.DaemonConfig != nil {
+		return c.DaemonConfig.DefaultRuntime
+	}
+	return ""
+}
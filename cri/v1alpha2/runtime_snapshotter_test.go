@@ -0,0 +1,50 @@
+package v1alpha2
+
+import (
+	"testing"
+
+	anno "github.com/alibaba/pouch/cri/annotations"
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+	"github.com/alibaba/pouch/ctrd"
+	"github.com/alibaba/pouch/daemon/config"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+func TestParseRuntimeSnapshotters(t *testing.T) {
+	snapshotters, err := parseRuntimeSnapshotters([]string{"kata=devmapper", " runsc = overlayfs ", "kata=devmapper"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"kata": "devmapper", "runsc": "overlayfs"}, snapshotters)
+
+	for _, e := range []string{"kata", "kata=", "=devmapper"} {
+		_, err := parseRuntimeSnapshotters([]string{e})
+		assert.Error(t, err, e)
+	}
+
+	_, err = parseRuntimeSnapshotters([]string{"kata=devmapper", "kata=overlayfs"})
+	assert.Error(t, err)
+}
+
+func TestWithRuntimeSnapshotter(t *testing.T) {
+	c := &CriManager{runtimeSnapshotters: map[string]string{"kata": "devmapper"}}
+
+	ctx := c.withRuntimeSnapshotter(context.Background(), "kata")
+	assert.Equal(t, "devmapper", ctrd.GetSnapshotter(ctx))
+
+	ctx = c.withRuntimeSnapshotter(context.Background(), "runc")
+	assert.Equal(t, "", ctrd.GetSnapshotter(ctx))
+}
+
+func TestPodRuntimeHandler(t *testing.T) {
+	c := &CriManager{
+		DaemonConfig:        &config.Config{DefaultRuntime: "runc"},
+		runtimeSnapshotters: map[string]string{"kata": "devmapper"},
+	}
+	pod := func(annotations map[string]string) *runtime.PodSandboxConfig {
+		return &runtime.PodSandboxConfig{Annotations: annotations}
+	}
+	assert.Equal(t, "kata", c.podRuntimeHandler(pod(map[string]string{anno.KubernetesRuntime: "kata"})))
+	assert.Equal(t, "runc", c.podRuntimeHandler(pod(nil)))
+	assert.Equal(t, "", c.podRuntimeHandler(nil))
+}
@@ -0,0 +1,112 @@
+package v1alpha2
+
+import (
+	"sync"
+
+	apitypes "github.com/alibaba/pouch/apis/types"
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+
+	"github.com/gogo/protobuf/proto"
+)
+
+// sandboxCache caches the translated sandboxes served by ListPodSandbox, the
+// states of them are kept up to date by the container events, so that the
+// frequent relists of kubelet do not need to get every sandbox container.
+type sandboxCache struct {
+	sync.RWMutex
+	sandboxes map[string]*runtime.PodSandbox
+	// states are the states of sandboxes derived from the events, which take
+	// precedence over the ones translated from the containers, since the events
+	// may come before the states of containers are updated.
+	states map[string]runtime.PodSandboxState
+	// version is increased by every applied event, the sandboxes translated
+	// from the containers are only cached if no event is applied meanwhile.
+	version uint64
+}
+
+func newSandboxCache() *sandboxCache {
+	return &sandboxCache{
+		sandboxes: make(map[string]*runtime.PodSandbox),
+		states:    make(map[string]runtime.PodSandboxState),
+	}
+}
+
+// get returns a copy of the cached sandbox and the current version of cache.
+func (sc *sandboxCache) get(id string) (*runtime.PodSandbox, uint64) {
+	if sc == nil {
+		return nil, 0
+	}
+
+	sc.RLock()
+	defer sc.RUnlock()
+
+	s, ok := sc.sandboxes[id]
+	if !ok {
+		return nil, sc.version
+	}
+
+	s = proto.Clone(s).(*runtime.PodSandbox)
+	if state, ok := sc.states[id]; ok {
+		s.State = state
+	}
+	return s, sc.version
+}
+
+// put caches the sandbox if the cache is not changed since the version,
+// and returns the sandbox with the state derived from the events if any.
+func (sc *sandboxCache) put(s *runtime.PodSandbox, version uint64) *runtime.PodSandbox {
+	if sc == nil {
+		return s
+	}
+
+	sc.Lock()
+	defer sc.Unlock()
+
+	if sc.version == version {
+		sc.sandboxes[s.Id] = proto.Clone(s).(*runtime.PodSandbox)
+	}
+	if state, ok := sc.states[s.Id]; ok {
+		s.State = state
+	}
+	return s
+}
+
+// reset drops all the cached sandboxes.
+func (sc *sandboxCache) reset() {
+	sc.Lock()
+	defer sc.Unlock()
+
+	sc.sandboxes = make(map[string]*runtime.PodSandbox)
+	sc.states = make(map[string]runtime.PodSandboxState)
+	sc.version++
+}
+
+// apply updates the cache according to the event of sandbox container.
+func (sc *sandboxCache) apply(id, action string) {
+	sc.Lock()
+	defer sc.Unlock()
+
+	sc.version++
+
+	switch action {
+	case "start", "restart", "unpause":
+		sc.states[id] = runtime.PodSandboxState_SANDBOX_READY
+	case "die", "stop", "pause":
+		sc.states[id] = runtime.PodSandboxState_SANDBOX_NOTREADY
+	case "destroy":
+		delete(sc.sandboxes, id)
+		delete(sc.states, id)
+	default:
+		// the other events may change the sandbox in other ways,
+		// let it be translated from the container again.
+		delete(sc.sandboxes, id)
+	}
+}
+
+// handleEvent updates the cache according to the container event.
+func (sc *sandboxCache) handleEvent(ev *apitypes.EventsMessage) {
+	if ev.Actor == nil || ev.Actor.Attributes[containerTypeLabelKey] != containerTypeLabelSandbox {
+		return
+	}
+	sc.apply(ev.Actor.ID, ev.Action)
+}
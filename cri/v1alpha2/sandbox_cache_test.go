@@ -0,0 +1,111 @@
+package v1alpha2
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	apitypes "github.com/alibaba/pouch/apis/types"
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+	"github.com/alibaba/pouch/daemon/events"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSandboxCache(t *testing.T) {
+	sc := newSandboxCache()
+
+	s, version := sc.get("s1")
+	assert.Nil(t, s)
+	sc.put(&runtime.PodSandbox{Id: "s1", State: runtime.PodSandboxState_SANDBOX_READY}, version)
+
+	s, version = sc.get("s1")
+	assert.Equal(t, runtime.PodSandboxState_SANDBOX_READY, s.State)
+
+	// the returned sandbox is a copy.
+	s.State = runtime.PodSandboxState_SANDBOX_NOTREADY
+	s, _ = sc.get("s1")
+	assert.Equal(t, runtime.PodSandboxState_SANDBOX_READY, s.State)
+
+	// the state is updated by the events.
+	sc.apply("s1", "die")
+	s, _ = sc.get("s1")
+	assert.Equal(t, runtime.PodSandboxState_SANDBOX_NOTREADY, s.State)
+	sc.apply("s1", "start")
+	s, _ = sc.get("s1")
+	assert.Equal(t, runtime.PodSandboxState_SANDBOX_READY, s.State)
+
+	// the sandbox translated before an event is not cached, and the
+	// state derived from the event takes precedence.
+	_, version = sc.get("s2")
+	sc.apply("s2", "die")
+	s = sc.put(&runtime.PodSandbox{Id: "s2", State: runtime.PodSandboxState_SANDBOX_READY}, version)
+	assert.Equal(t, runtime.PodSandboxState_SANDBOX_NOTREADY, s.State)
+	s, version = sc.get("s2")
+	assert.Nil(t, s)
+	sc.put(&runtime.PodSandbox{Id: "s2", State: runtime.PodSandboxState_SANDBOX_READY}, version)
+	s, _ = sc.get("s2")
+	assert.Equal(t, runtime.PodSandboxState_SANDBOX_NOTREADY, s.State)
+
+	// the other events invalidate the sandbox.
+	sc.apply("s1", "update")
+	s, _ = sc.get("s1")
+	assert.Nil(t, s)
+
+	sc.apply("s2", "destroy")
+	s, _ = sc.get("s2")
+	assert.Nil(t, s)
+	_, exist := sc.states["s2"]
+	assert.False(t, exist)
+
+	// nil cache caches nothing.
+	var nilCache *sandboxCache
+	s, version = nilCache.get("s1")
+	assert.Nil(t, s)
+	assert.NotNil(t, nilCache.put(&runtime.PodSandbox{Id: "s1"}, version))
+}
+
+func TestSandboxCacheWatchEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	eventsService := events.NewEvents()
+	sc := newSandboxCache()
+	go watchEvents(ctx, eventsService, apitypes.EventTypeContainer, sc.reset, sc.handleEvent)
+
+	// wait for the subscription.
+	assert.True(t, waitFor(func() bool {
+		sc.RLock()
+		defer sc.RUnlock()
+		return sc.version > 0
+	}))
+
+	_, version := sc.get("s1")
+	sc.put(&runtime.PodSandbox{Id: "s1", State: runtime.PodSandboxState_SANDBOX_READY}, version)
+
+	// the events of the other containers are ignored.
+	assert.NoError(t, eventsService.Publish(ctx, "die", apitypes.EventTypeContainer, &apitypes.EventsActor{
+		ID:         "s1",
+		Attributes: map[string]string{containerTypeLabelKey: containerTypeLabelContainer},
+	}))
+	assert.NoError(t, eventsService.Publish(ctx, "die", apitypes.EventTypeContainer, &apitypes.EventsActor{
+		ID:         "s1",
+		Attributes: map[string]string{containerTypeLabelKey: containerTypeLabelSandbox},
+	}))
+
+	assert.True(t, waitFor(func() bool {
+		s, _ := sc.get("s1")
+		return s.State == runtime.PodSandboxState_SANDBOX_NOTREADY
+	}))
+}
+
+// waitFor polls the condition for at most one second.
+func waitFor(condition func() bool) bool {
+	for i := 0; i < 100; i++ {
+		if condition() {
+			return true
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return false
+}
@@ -0,0 +1,552 @@
+package v1alpha2
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	apitypes "github.com/alibaba/pouch/apis/types"
+	anno "github.com/alibaba/pouch/cri/annotations"
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+	cni "github.com/alibaba/pouch/cri/ocicni"
+	metatypes "github.com/alibaba/pouch/cri/v1alpha2/types"
+	"github.com/alibaba/pouch/daemon/mgr"
+	"github.com/alibaba/pouch/pkg/archive"
+	"github.com/alibaba/pouch/pkg/errtypes"
+	"github.com/alibaba/pouch/pkg/log"
+
+	dockerarchive "github.com/docker/docker/pkg/archive"
+	"github.com/pkg/errors"
+)
+
+const (
+	// podCheckpointManifest is the manifest in the archive of sandbox checkpoint.
+	podCheckpointManifest = "checkpoint.json"
+	// podCheckpointSandboxDir keeps the sandbox files in the archive.
+	podCheckpointSandboxDir = "sandbox"
+	// podCheckpointContainersDir keeps the checkpoints of containers in the archive.
+	podCheckpointContainersDir = "containers"
+)
+
+// CheckpointPodSandbox checkpoints the running containers of sandbox together with the
+// config, network and files of sandbox into one archive, and returns the path of archive.
+// The containers are paused during the checkpoint, so that they are consistent with
+// each other and with the files of sandbox.
+func (c *CriManager) CheckpointPodSandbox(ctx context.Context, id string, options *metatypes.PodCheckpointOptions) (_ string, retErr error) {
+	dir, err := c.podCheckpointPath(options.Dir)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", err
+	}
+
+	res, err := c.SandboxStore.Get(id)
+	if err != nil {
+		return "", fmt.Errorf("failed to get metadata of %q from SandboxStore: %v", id, err)
+	}
+	sandboxMeta := res.(*metatypes.SandboxMeta)
+	if isPartialSandbox(sandboxMeta) {
+		return "", fmt.Errorf("failed to checkpoint partially created sandbox %q", id)
+	}
+
+	sandbox, err := c.ContainerMgr.Get(ctx, id)
+	if err != nil {
+		return "", fmt.Errorf("failed to get sandbox %q: %v", id, err)
+	}
+	if !sandbox.IsRunning() {
+		return "", fmt.Errorf("failed to checkpoint sandbox %q: sandbox is not running", id)
+	}
+
+	containers, err := c.ContainerMgr.List(ctx, &mgr.ContainerListOption{
+		All: true,
+		Labels: map[string]string{
+			containerTypeLabelKey: containerTypeLabelContainer,
+			sandboxIDLabelKey:     id,
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to list containers of sandbox %q: %v", id, err)
+	}
+
+	workDir, err := ioutil.TempDir(dir, "pod-checkpoint-")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(workDir)
+
+	checkpoint := &metatypes.PodCheckpoint{
+		ID:             id,
+		Config:         sandboxMeta.Config,
+		RuntimeHandler: sandboxMeta.Runtime,
+		IP:             cni.PodIP(sandboxMeta.NetworkResults),
+		MAC:            cni.PodMAC(sandboxMeta.NetworkResults),
+		NetworkResults: sandboxMeta.NetworkResults,
+	}
+
+	// the containers exited are left to kubelet to start again.
+	var running []*mgr.Container
+	for _, container := range containers {
+		if container.IsRunningOrPaused() {
+			running = append(running, container)
+		}
+	}
+	paused, err := c.pauseContainers(ctx, running)
+	exited := make(map[string]bool)
+	defer func() {
+		// the containers exit after checkpointed if requested, and the others
+		// are unpaused whenever the checkpoint fails.
+		if options.Exit && retErr == nil {
+			return
+		}
+		var unpaused []*mgr.Container
+		for _, container := range paused {
+			if !exited[container.ID] {
+				unpaused = append(unpaused, container)
+			}
+		}
+		c.unpauseContainers(ctx, unpaused)
+	}()
+	if err != nil {
+		return "", fmt.Errorf("failed to pause containers of sandbox %q: %v", id, err)
+	}
+
+	sandboxRootDir := path.Join(c.SandboxBaseDir, id)
+	for _, container := range running {
+		config, err := checkpointContainerConfig(container, id, sandboxMeta.Config, sandboxRootDir)
+		if err != nil {
+			return "", fmt.Errorf("failed to get config of container %q: %v", container.ID, err)
+		}
+
+		if err := c.ContainerMgr.CreateCheckpoint(ctx, container.ID, &apitypes.CheckpointCreateOptions{
+			CheckpointDir: filepath.Join(workDir, podCheckpointContainersDir),
+			CheckpointID:  container.ID,
+			Exit:          options.Exit,
+		}); err != nil {
+			return "", fmt.Errorf("failed to checkpoint container %q: %v", container.ID, err)
+		}
+		exited[container.ID] = options.Exit
+		checkpoint.Containers = append(checkpoint.Containers, &metatypes.ContainerCheckpoint{ID: container.ID, Config: config})
+	}
+
+	if err := archive.CopyWithTar(sandboxRootDir, filepath.Join(workDir, podCheckpointSandboxDir)); err != nil {
+		return "", fmt.Errorf("failed to copy files of sandbox %q: %v", id, err)
+	}
+
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return "", err
+	}
+	if err := ioutil.WriteFile(filepath.Join(workDir, podCheckpointManifest), data, 0600); err != nil {
+		return "", err
+	}
+
+	archivePath := filepath.Join(dir, id+".tar.gz")
+	if err := writeArchive(workDir, archivePath); err != nil {
+		return "", fmt.Errorf("failed to write checkpoint archive of sandbox %q: %v", id, err)
+	}
+	return archivePath, nil
+}
+
+// RestorePodSandbox runs a new sandbox from the archive of sandbox checkpoint and
+// restores the containers in it, and returns the id of the new sandbox. The ip and
+// mac of sandbox are requested again, and the sandbox gets new ones if the CNI
+// plugin could not assign the same. The archive should be in the checkpoint
+// directory of daemon.
+func (c *CriManager) RestorePodSandbox(ctx context.Context, name string) (_ string, retErr error) {
+	archivePath, err := c.podCheckpointPath(name)
+	if err != nil {
+		return "", err
+	}
+
+	workDir, err := ioutil.TempDir(filepath.Dir(archivePath), "pod-restore-")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(workDir)
+
+	checkpoint, err := readArchive(archivePath, workDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to read checkpoint archive %q: %v", archivePath, err)
+	}
+
+	id, err := c.runRestoredPodSandbox(ctx, checkpoint)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if retErr == nil {
+			return
+		}
+		if _, err := c.StopPodSandbox(ctx, &runtime.StopPodSandboxRequest{PodSandboxId: id}); err != nil {
+			log.With(ctx).Errorf("failed to stop sandbox %q when restoring failed: %v", id, err)
+		}
+		if _, err := c.RemovePodSandbox(ctx, &runtime.RemovePodSandboxRequest{PodSandboxId: id}); err != nil {
+			log.With(ctx).Errorf("failed to remove sandbox %q when restoring failed: %v", id, err)
+		}
+	}()
+
+	// the files of sandbox may be changed by the containers, e.g. /etc/hosts.
+	if err := archive.CopyWithTar(filepath.Join(workDir, podCheckpointSandboxDir), path.Join(c.SandboxBaseDir, id)); err != nil {
+		return "", fmt.Errorf("failed to restore files of sandbox %q: %v", id, err)
+	}
+
+	for _, container := range checkpoint.Containers {
+		resp, err := c.CreateContainer(ctx, &runtime.CreateContainerRequest{
+			PodSandboxId:  id,
+			Config:        container.Config,
+			SandboxConfig: checkpoint.Config,
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to create container of checkpoint %q: %v", container.ID, err)
+		}
+
+		if err := c.ContainerMgr.Start(ctx, resp.GetContainerId(), &apitypes.ContainerStartOptions{
+			CheckpointDir: filepath.Join(workDir, podCheckpointContainersDir),
+			CheckpointID:  container.ID,
+		}); err != nil {
+			return "", fmt.Errorf("failed to restore container %q from checkpoint %q: %v", resp.GetContainerId(), container.ID, err)
+		}
+	}
+
+	return id, nil
+}
+
+// runRestoredPodSandbox runs the sandbox of checkpoint with the ip and mac of it
+// if they are not specified, and with new ones if the CNI plugin rejects the old.
+func (c *CriManager) runRestoredPodSandbox(ctx context.Context, checkpoint *metatypes.PodCheckpoint) (string, error) {
+	config := checkpoint.Config
+	if static := restoredNetworkAnnotations(checkpoint); len(static) > 0 {
+		withStatic := *config
+		withStatic.Annotations = make(map[string]string, len(config.GetAnnotations())+len(static))
+		for k, v := range config.GetAnnotations() {
+			withStatic.Annotations[k] = v
+		}
+		for k, v := range static {
+			withStatic.Annotations[k] = v
+		}
+
+		resp, err := c.RunPodSandbox(ctx, &runtime.RunPodSandboxRequest{Config: &withStatic, RuntimeHandler: checkpoint.RuntimeHandler})
+		if err == nil {
+			return resp.GetPodSandboxId(), nil
+		}
+		log.With(ctx).Warnf("failed to run sandbox of checkpoint %q with ip %s and mac %s, fall back to new ones: %v",
+			checkpoint.ID, checkpoint.IP, checkpoint.MAC, err)
+	}
+
+	resp, err := c.RunPodSandbox(ctx, &runtime.RunPodSandboxRequest{Config: config, RuntimeHandler: checkpoint.RuntimeHandler})
+	if err != nil {
+		return "", fmt.Errorf("failed to run sandbox of checkpoint %q: %v", checkpoint.ID, err)
+	}
+	return resp.GetPodSandboxId(), nil
+}
+
+// restoredNetworkAnnotations returns the annotations requesting the ip and mac
+// of checkpoint, the ones specified in the config of sandbox are kept.
+func restoredNetworkAnnotations(checkpoint *metatypes.PodCheckpoint) map[string]string {
+	static := make(map[string]string)
+	for k, v := range map[string]string{
+		anno.StaticIPAnnotation:  checkpoint.IP,
+		anno.StaticMACAnnotation: checkpoint.MAC,
+	} {
+		if _, ok := checkpoint.Config.GetAnnotations()[k]; !ok && v != "" {
+			static[k] = v
+		}
+	}
+	return static
+}
+
+// podCheckpointPath returns the path in the checkpoint directory of daemon, the
+// relative path is joined to the directory, and the one out of it is rejected.
+func (c *CriManager) podCheckpointPath(p string) (string, error) {
+	if !filepath.IsAbs(p) {
+		p = filepath.Join(c.podCheckpointDir, p)
+	}
+	p = filepath.Clean(p)
+	if c.podCheckpointDir == "" || (p != c.podCheckpointDir && !strings.HasPrefix(p, c.podCheckpointDir+"/")) {
+		return "", errors.Wrapf(errtypes.ErrInvalidParam, "%q is out of the checkpoint directory %s", p, c.podCheckpointDir)
+	}
+	return p, nil
+}
+
+// pauseContainers pauses the running containers, and returns the ones paused
+// here, which are unpaused if any fails.
+func (c *CriManager) pauseContainers(ctx context.Context, containers []*mgr.Container) ([]*mgr.Container, error) {
+	var paused []*mgr.Container
+	for _, container := range containers {
+		if container.State.Paused {
+			continue
+		}
+		if err := c.ContainerMgr.Pause(ctx, container.ID); err != nil {
+			c.unpauseContainers(ctx, paused)
+			return nil, fmt.Errorf("failed to pause container %q: %v", container.ID, err)
+		}
+		paused = append(paused, container)
+	}
+	return paused, nil
+}
+
+// unpauseContainers unpauses the containers paused by pauseContainers.
+func (c *CriManager) unpauseContainers(ctx context.Context, containers []*mgr.Container) {
+	for _, container := range containers {
+		if err := c.ContainerMgr.Unpause(ctx, container.ID); err != nil {
+			log.With(ctx).Warnf("failed to unpause container %q: %v", container.ID, err)
+		}
+	}
+}
+
+// writeArchive writes the directory into the gzipped tar archive.
+func writeArchive(dir, archivePath string) (retErr error) {
+	rc, err := dockerarchive.TarWithOptions(dir, &dockerarchive.TarOptions{Compression: dockerarchive.Gzip})
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	f, err := os.OpenFile(archivePath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		f.Close()
+		if retErr != nil {
+			os.Remove(archivePath)
+		}
+	}()
+
+	_, err = io.Copy(f, rc)
+	return err
+}
+
+// readArchive extracts the archive of sandbox checkpoint into the directory and returns the manifest.
+func readArchive(archivePath, dir string) (*metatypes.PodCheckpoint, error) {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	if err := dockerarchive.Untar(f, dir, &dockerarchive.TarOptions{NoLchown: true}); err != nil {
+		return nil, err
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, podCheckpointManifest))
+	if err != nil {
+		return nil, err
+	}
+	checkpoint := &metatypes.PodCheckpoint{}
+	if err := json.Unmarshal(data, checkpoint); err != nil {
+		return nil, err
+	}
+	if checkpoint.Config == nil {
+		return nil, fmt.Errorf("no sandbox config in %s", podCheckpointManifest)
+	}
+	return checkpoint, nil
+}
+
+// checkpointContainerConfig rebuilds the CRI config of container from the container,
+// which is used to create the container again on restore.
+func checkpointContainerConfig(container *mgr.Container, sandboxID string, sandboxConfig *runtime.PodSandboxConfig, sandboxRootDir string) (*runtime.ContainerConfig, error) {
+	metadata, err := containerMetadata(container)
+	if err != nil {
+		return nil, err
+	}
+	labels, annotations := extractLabels(container.Config.Labels)
+
+	var envs []*runtime.KeyValue
+	for _, env := range container.Config.Env {
+		parts := strings.SplitN(env, "=", 2)
+		kv := &runtime.KeyValue{Key: parts[0]}
+		if len(parts) == 2 {
+			kv.Value = parts[1]
+		}
+		envs = append(envs, kv)
+	}
+
+	var logPath string
+	if p := container.Config.Labels[containerLogPathLabelKey]; p != "" && sandboxConfig.GetLogDirectory() != "" {
+		if logPath, err = filepath.Rel(sandboxConfig.GetLogDirectory(), p); err != nil {
+			return nil, err
+		}
+	}
+
+	var devices []*runtime.Device
+	for _, d := range container.HostConfig.Devices {
+		devices = append(devices, &runtime.Device{
+			ContainerPath: d.PathInContainer,
+			HostPath:      d.PathOnHost,
+			Permissions:   d.CgroupPermissions,
+		})
+	}
+
+	resources := parseResourcesFromPouch(container.HostConfig.Resources, container.Config.DiskQuota)
+	// the cpuset assigned by the cpuset manager is assigned again.
+	if container.Config.Labels[cpusetPoolLabelKey] != "" {
+		resources.CpusetCpus, resources.CpusetMems = "", ""
+	}
+
+	return &runtime.ContainerConfig{
+		Metadata:    metadata,
+		Image:       &runtime.ImageSpec{Image: container.Config.Image},
+		Command:     container.Config.Entrypoint,
+		Args:        container.Config.Cmd,
+		WorkingDir:  container.Config.WorkingDir,
+		Envs:        envs,
+		Mounts:      parseMountBindings(container.HostConfig.Binds, sandboxRootDir),
+		Devices:     devices,
+		Labels:      labels,
+		Annotations: annotations,
+		LogPath:     logPath,
+		Stdin:       container.Config.OpenStdin,
+		StdinOnce:   container.Config.StdinOnce,
+		Tty:         container.Config.Tty,
+		Linux: &runtime.LinuxContainerConfig{
+			Resources:       resources,
+			SecurityContext: checkpointSecurityContext(container, sandboxID),
+		},
+	}, nil
+}
+
+// parseMountBindings is the reverse of generateMountBindings, the bindings
+// of the files of sandbox are skipped since they are added on creation.
+func parseMountBindings(binds []string, sandboxRootDir string) []*runtime.Mount {
+	var mounts []*runtime.Mount
+	for _, bind := range binds {
+		parts := strings.SplitN(bind, ":", 3)
+		if len(parts) < 2 || strings.HasPrefix(parts[0], sandboxRootDir+"/") {
+			continue
+		}
+
+		m := &runtime.Mount{HostPath: parts[0], ContainerPath: parts[1]}
+		if len(parts) == 3 {
+			for _, attr := range strings.Split(parts[2], ",") {
+				switch attr {
+				case "ro":
+					m.Readonly = true
+				case "Z":
+					m.SelinuxRelabel = true
+				case "rshared":
+					m.Propagation = runtime.MountPropagation_PROPAGATION_BIDIRECTIONAL
+				case "rslave":
+					m.Propagation = runtime.MountPropagation_PROPAGATION_HOST_TO_CONTAINER
+				}
+			}
+		}
+		mounts = append(mounts, m)
+	}
+	return mounts
+}
+
+// checkpointSecurityContext rebuilds the security context of container, which
+// is the reverse of applyContainerSecurityContext. The sysctls are in the config
+// of sandbox, which is checkpointed as is.
+func checkpointSecurityContext(container *mgr.Container, sandboxID string) *runtime.LinuxContainerSecurityContext {
+	hc := container.HostConfig
+	sc := &runtime.LinuxContainerSecurityContext{
+		Privileged:       hc.Privileged,
+		ReadonlyRootfs:   hc.ReadonlyRootfs,
+		NamespaceOptions: checkpointNamespaceOptions(hc, sandboxID),
+		MaskedPaths:      hc.MaskedPaths,
+		ReadonlyPaths:    hc.ReadonlyPaths,
+	}
+	if len(hc.CapAdd) > 0 || len(hc.CapDrop) > 0 {
+		sc.Capabilities = &runtime.Capability{
+			AddCapabilities:  hc.CapAdd,
+			DropCapabilities: hc.CapDrop,
+		}
+	}
+
+	// the user is in the form of "user[:group]".
+	parts := strings.SplitN(container.Config.User, ":", 2)
+	if user := parts[0]; user != "" {
+		if uid, err := strconv.ParseInt(user, 10, 64); err == nil {
+			sc.RunAsUser = &runtime.Int64Value{Value: uid}
+		} else {
+			sc.RunAsUsername = user
+		}
+		if len(parts) == 2 {
+			if gid, err := strconv.ParseInt(parts[1], 10, 64); err == nil {
+				sc.RunAsGroup = &runtime.Int64Value{Value: gid}
+			}
+		}
+	}
+	for _, group := range hc.GroupAdd {
+		if gid, err := strconv.ParseInt(group, 10, 64); err == nil {
+			sc.SupplementalGroups = append(sc.SupplementalGroups, gid)
+		}
+	}
+
+	// the seccomp is unconfined unless the default or a local profile is set.
+	sc.SeccompProfilePath = mgr.ProfileRuntimeDefault
+	selinux := &runtime.SELinuxOption{}
+	for _, opt := range hc.SecurityOpt {
+		kv := strings.SplitN(opt, "=", 2)
+		if len(kv) != 2 {
+			if opt == "no-new-privileges" {
+				sc.NoNewPrivs = true
+			}
+			continue
+		}
+		switch kv[0] {
+		case "seccomp":
+			sc.SeccompProfilePath = checkpointProfile(kv[1])
+		case "apparmor":
+			sc.ApparmorProfile = checkpointProfile(kv[1])
+		case "label":
+			label := strings.SplitN(kv[1], ":", 2)
+			if len(label) != 2 {
+				continue
+			}
+			switch label[0] {
+			case "user":
+				selinux.User = label[1]
+			case "role":
+				selinux.Role = label[1]
+			case "type":
+				selinux.Type = label[1]
+			case "level":
+				selinux.Level = label[1]
+			}
+		}
+	}
+	if *selinux != (runtime.SELinuxOption{}) {
+		sc.SelinuxOptions = selinux
+	}
+	return sc
+}
+
+// checkpointProfile returns the seccomp or AppArmor profile in the form of CRI.
+func checkpointProfile(profile string) string {
+	if profile == mgr.ProfileNameUnconfined {
+		return profile
+	}
+	return mgr.ProfileNamePrefix + profile
+}
+
+// checkpointNamespaceOptions rebuilds the namespace options of container. The
+// pid namespace of another container is not kept, since the container gets a
+// new id on restore, and the container is given its own one instead.
+func checkpointNamespaceOptions(hc *apitypes.HostConfig, sandboxID string) *runtime.NamespaceOption {
+	mode := func(nsMode string) runtime.NamespaceMode {
+		switch nsMode {
+		case namespaceModeHost:
+			return runtime.NamespaceMode_NODE
+		case "container:" + sandboxID:
+			return runtime.NamespaceMode_POD
+		default:
+			return runtime.NamespaceMode_CONTAINER
+		}
+	}
+	return &runtime.NamespaceOption{
+		Network: mode(hc.NetworkMode),
+		Pid:     mode(hc.PidMode),
+		Ipc:     mode(hc.IpcMode),
+	}
+}
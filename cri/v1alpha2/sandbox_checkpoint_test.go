@@ -0,0 +1,269 @@
+package v1alpha2
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	apitypes "github.com/alibaba/pouch/apis/types"
+	anno "github.com/alibaba/pouch/cri/annotations"
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+	metatypes "github.com/alibaba/pouch/cri/v1alpha2/types"
+	"github.com/alibaba/pouch/daemon/mgr"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckpointContainerConfig(t *testing.T) {
+	sandboxRootDir := "/var/lib/pouch-cri/sandboxes/s1"
+	container := &mgr.Container{
+		ID: "c1",
+		Config: &apitypes.ContainerConfig{
+			Image:      "busybox:latest",
+			Entrypoint: []string{"sh", "-c"},
+			Cmd:        []string{"top"},
+			Env:        []string{"A=1", "B"},
+			User:       "1000:1000",
+			Labels: map[string]string{
+				metadataNameLabelKey:     "app",
+				metadataAttemptLabelKey:  "2",
+				containerTypeLabelKey:    containerTypeLabelContainer,
+				sandboxIDLabelKey:        "s1",
+				cpusetPoolLabelKey:       "exclusive",
+				containerLogPathLabelKey: "/var/log/pods/s1/app/2.log",
+				annotationPrefix + "a":   "b",
+				"l":                      "v",
+			},
+		},
+		HostConfig: &apitypes.HostConfig{
+			Binds: []string{
+				sandboxRootDir + "/hosts:/etc/hosts",
+				"/data:/data:ro,rslave",
+				"/tmp:/tmp",
+			},
+			Resources:   apitypes.Resources{CPUShares: 512, CpusetCpus: "2-3"},
+			GroupAdd:    []string{"2000"},
+			SecurityOpt: []string{"seccomp=unconfined", "apparmor=custom", "label=user:system_u", "label=role:system_r", "label=type:spc_t", "label=level:s0", "no-new-privileges"},
+			PidMode:     "container:s1",
+			IpcMode:     "container:s1",
+			NetworkMode: namespaceModeHost,
+			MaskedPaths: []string{"/proc/kcore"},
+		},
+	}
+
+	config, err := checkpointContainerConfig(container, "s1", &runtime.PodSandboxConfig{LogDirectory: "/var/log/pods/s1"}, sandboxRootDir)
+	assert.NoError(t, err)
+	assert.Equal(t, &runtime.ContainerMetadata{Name: "app", Attempt: 2}, config.Metadata)
+	assert.Equal(t, "busybox:latest", config.Image.Image)
+	assert.Equal(t, []string{"sh", "-c"}, config.Command)
+	assert.Equal(t, []string{"top"}, config.Args)
+	assert.Equal(t, []*runtime.KeyValue{{Key: "A", Value: "1"}, {Key: "B"}}, config.Envs)
+	assert.Equal(t, "app/2.log", config.LogPath)
+	assert.Equal(t, "v", config.Labels["l"])
+	assert.Equal(t, map[string]string{"a": "b"}, config.Annotations)
+	assert.Equal(t, []*runtime.Mount{
+		{HostPath: "/data", ContainerPath: "/data", Readonly: true, Propagation: runtime.MountPropagation_PROPAGATION_HOST_TO_CONTAINER},
+		{HostPath: "/tmp", ContainerPath: "/tmp"},
+	}, config.Mounts)
+	assert.Equal(t, int64(512), config.Linux.Resources.CpuShares)
+	// the cpuset from the cpuset manager is not kept.
+	assert.Empty(t, config.Linux.Resources.CpusetCpus)
+
+	// the security context is the one applied on creation.
+	sc := config.Linux.SecurityContext
+	assert.Equal(t, int64(1000), sc.RunAsUser.Value)
+	assert.Equal(t, int64(1000), sc.RunAsGroup.Value)
+	assert.Equal(t, []int64{2000}, sc.SupplementalGroups)
+	assert.Equal(t, "unconfined", sc.SeccompProfilePath)
+	assert.Equal(t, "localhost/custom", sc.ApparmorProfile)
+	assert.Equal(t, &runtime.SELinuxOption{User: "system_u", Role: "system_r", Type: "spc_t", Level: "s0"}, sc.SelinuxOptions)
+	assert.True(t, sc.NoNewPrivs)
+	assert.Equal(t, &runtime.NamespaceOption{
+		Network: runtime.NamespaceMode_NODE,
+		Pid:     runtime.NamespaceMode_POD,
+		Ipc:     runtime.NamespaceMode_POD,
+	}, sc.NamespaceOptions)
+	assert.Equal(t, []string{"/proc/kcore"}, sc.MaskedPaths)
+
+	hc := &apitypes.HostConfig{}
+	assert.NoError(t, modifyHostConfig(sc, hc))
+	sort.Strings(hc.SecurityOpt)
+	expected := append([]string{}, container.HostConfig.SecurityOpt...)
+	sort.Strings(expected)
+	assert.Equal(t, expected, hc.SecurityOpt)
+
+	// the default seccomp profile is kept.
+	container.HostConfig.SecurityOpt = nil
+	assert.Equal(t, "runtime/default", checkpointSecurityContext(container, "s1").SeccompProfilePath)
+}
+
+func TestPodCheckpointPath(t *testing.T) {
+	c := &CriManager{podCheckpointDir: "/var/lib/pouch/pod-checkpoints"}
+	for p, expected := range map[string]string{
+		"":      "/var/lib/pouch/pod-checkpoints",
+		"daily": "/var/lib/pouch/pod-checkpoints/daily",
+		"/var/lib/pouch/pod-checkpoints/s1.tar.gz": "/var/lib/pouch/pod-checkpoints/s1.tar.gz",
+		"daily/../s1.tar.gz":                       "/var/lib/pouch/pod-checkpoints/s1.tar.gz",
+	} {
+		actual, err := c.podCheckpointPath(p)
+		assert.NoError(t, err, p)
+		assert.Equal(t, expected, actual)
+	}
+	for _, p := range []string{"../sandboxes", "/etc", "/var/lib/pouch/pod-checkpoints-other"} {
+		_, err := c.podCheckpointPath(p)
+		assert.Error(t, err, p)
+	}
+}
+
+func TestRestoredNetworkAnnotations(t *testing.T) {
+	checkpoint := &metatypes.PodCheckpoint{
+		Config: &runtime.PodSandboxConfig{Annotations: map[string]string{anno.StaticMACAnnotation: "02:42:0a:00:00:09"}},
+		IP:     "10.0.0.2",
+		MAC:    "02:42:0a:00:00:02",
+	}
+	assert.Equal(t, map[string]string{anno.StaticIPAnnotation: "10.0.0.2"}, restoredNetworkAnnotations(checkpoint))
+
+	checkpoint.Config.Annotations = nil
+	checkpoint.MAC = ""
+	assert.Equal(t, map[string]string{anno.StaticIPAnnotation: "10.0.0.2"}, restoredNetworkAnnotations(checkpoint))
+}
+
+func TestCheckpointArchive(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pod-checkpoint")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	src := filepath.Join(dir, "src")
+	assert.NoError(t, os.MkdirAll(filepath.Join(src, podCheckpointSandboxDir), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(src, podCheckpointSandboxDir, "hosts"), []byte("127.0.0.1 localhost\n"), 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(src, podCheckpointManifest), []byte(`{"id":"s1","config":{"metadata":{"name":"pod"}},"ip":"10.0.0.2"}`), 0644))
+
+	archivePath := filepath.Join(dir, "s1.tar.gz")
+	assert.NoError(t, writeArchive(src, archivePath))
+
+	dst := filepath.Join(dir, "dst")
+	assert.NoError(t, os.MkdirAll(dst, 0755))
+	checkpoint, err := readArchive(archivePath, dst)
+	assert.NoError(t, err)
+	assert.Equal(t, &metatypes.PodCheckpoint{
+		ID:     "s1",
+		Config: &runtime.PodSandboxConfig{Metadata: &runtime.PodSandboxMetadata{Name: "pod"}},
+		IP:     "10.0.0.2",
+	}, checkpoint)
+
+	data, err := ioutil.ReadFile(filepath.Join(dst, podCheckpointSandboxDir, "hosts"))
+	assert.NoError(t, err)
+	assert.Equal(t, "127.0.0.1 localhost\n", string(data))
+
+	// the manifest without config of sandbox is invalid.
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(src, podCheckpointManifest), []byte(`{"id":"s1"}`), 0644))
+	assert.NoError(t, writeArchive(src, archivePath))
+	_, err = readArchive(archivePath, filepath.Join(dir, "dst2"))
+	assert.Error(t, err)
+}
+
+// checkpointContainerMgr records the containers paused, unpaused and checkpointed.
+type checkpointContainerMgr struct {
+	mgr.ContainerMgr
+	sandbox       *mgr.Container
+	containers    []*mgr.Container
+	checkpointErr map[string]error
+	calls         []string
+}
+
+func (m *checkpointContainerMgr) Get(ctx context.Context, name string) (*mgr.Container, error) {
+	return m.sandbox, nil
+}
+
+func (m *checkpointContainerMgr) List(ctx context.Context, option *mgr.ContainerListOption) ([]*mgr.Container, error) {
+	return m.containers, nil
+}
+
+func (m *checkpointContainerMgr) Pause(ctx context.Context, name string) error {
+	m.calls = append(m.calls, "pause "+name)
+	return nil
+}
+
+func (m *checkpointContainerMgr) Unpause(ctx context.Context, name string) error {
+	m.calls = append(m.calls, "unpause "+name)
+	return nil
+}
+
+func (m *checkpointContainerMgr) CreateCheckpoint(ctx context.Context, name string, options *apitypes.CheckpointCreateOptions) error {
+	m.calls = append(m.calls, "checkpoint "+name)
+	return m.checkpointErr[name]
+}
+
+func TestCheckpointPodSandboxUnpause(t *testing.T) {
+	homeDir, err := ioutil.TempDir("", "cri-pod-checkpoint")
+	assert.NoError(t, err)
+	defer os.RemoveAll(homeDir)
+
+	store, err := newSandboxStore(homeDir)
+	assert.NoError(t, err)
+	defer store.Shutdown()
+	assert.NoError(t, store.Put(&metatypes.SandboxMeta{
+		ID:     "s1",
+		Config: &runtime.PodSandboxConfig{Metadata: &runtime.PodSandboxMetadata{Name: "pod"}},
+		Phase:  metatypes.SandboxPhaseReady,
+	}))
+
+	newContainer := func(id string) *mgr.Container {
+		return &mgr.Container{
+			ID:         id,
+			State:      &apitypes.ContainerState{Running: true},
+			Config:     &apitypes.ContainerConfig{Labels: map[string]string{metadataNameLabelKey: id, metadataAttemptLabelKey: "0"}},
+			HostConfig: &apitypes.HostConfig{},
+		}
+	}
+
+	for _, tc := range []struct {
+		exit     bool
+		fail     bool
+		expected []string
+	}{
+		{
+			exit:     false,
+			fail:     true,
+			expected: []string{"pause c1", "pause c2", "checkpoint c1", "checkpoint c2", "unpause c1", "unpause c2"},
+		},
+		// the container checkpointed with exit is not unpaused when the checkpoint fails.
+		{
+			exit:     true,
+			fail:     true,
+			expected: []string{"pause c1", "pause c2", "checkpoint c1", "checkpoint c2", "unpause c2"},
+		},
+		{
+			exit:     true,
+			fail:     false,
+			expected: []string{"pause c1", "pause c2", "checkpoint c1", "checkpoint c2"},
+		},
+	} {
+		ctrMgr := &checkpointContainerMgr{
+			sandbox:    &mgr.Container{ID: "s1", State: &apitypes.ContainerState{Running: true}},
+			containers: []*mgr.Container{newContainer("c1"), newContainer("c2")},
+		}
+		if tc.fail {
+			ctrMgr.checkpointErr = map[string]error{"c2": fmt.Errorf("criu failed")}
+		}
+		c := &CriManager{
+			ContainerMgr:     ctrMgr,
+			SandboxStore:     store,
+			SandboxBaseDir:   homeDir,
+			podCheckpointDir: homeDir + "/pod-checkpoints",
+		}
+		assert.NoError(t, os.MkdirAll(homeDir+"/s1", 0700))
+
+		_, err := c.CheckpointPodSandbox(context.Background(), "s1", &metatypes.PodCheckpointOptions{Exit: tc.exit})
+		if tc.fail {
+			assert.Error(t, err)
+		} else {
+			assert.NoError(t, err)
+		}
+		assert.Equal(t, tc.expected, ctrMgr.calls)
+	}
+}
@@ -0,0 +1,186 @@
+package v1alpha2
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"time"
+
+	anno "github.com/alibaba/pouch/cri/annotations"
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+	metatypes "github.com/alibaba/pouch/cri/v1alpha2/types"
+	"github.com/alibaba/pouch/pkg/log"
+	"github.com/alibaba/pouch/pkg/system"
+)
+
+// holderPollInterval is the interval to check whether the holder is stopped.
+const holderPollInterval = 100 * time.Millisecond
+
+// usesSandboxHolder returns whether the sandbox is held by a lightweight
+// process instead of the sandbox container. Only the pods in the network and
+// ipc namespaces of host, whose containers join no namespace of sandbox, are
+// held by the process, e.g. the daemonset-style pods, and they are run by the
+// default runtime since there is no VM or shim of sandbox.
+func (c *CriManager) usesSandboxHolder(config *runtime.PodSandboxConfig, runtimeHandler string) bool {
+	if !c.hostNetworkHolder || c.CriPlugin != nil {
+		return false
+	}
+
+	nsOpts := config.GetLinux().GetSecurityContext().GetNamespaceOptions()
+	if nsOpts.GetNetwork() != runtime.NamespaceMode_NODE || nsOpts.GetIpc() != runtime.NamespaceMode_NODE || nsOpts.GetPid() == runtime.NamespaceMode_POD {
+		return false
+	}
+
+	if runtimeHandler == "" {
+		runtimeHandler = config.GetAnnotations()[anno.KubernetesRuntime]
+	}
+	return runtimeHandler == "" || c.DaemonConfig == nil || runtimeHandler == c.DaemonConfig.DefaultRuntime
+}
+
+// startSandboxHolder starts the holder process of sandbox from the built-in
+// pause binary, and moves it into the pod cgroup so that it outlives pouchd.
+// It does nothing if the holder is running, the meta of sandbox is stored by
+// the caller.
+func (c *CriManager) startSandboxHolder(ctx context.Context, sandboxMeta *metatypes.SandboxMeta) error {
+	if sandboxMeta.Holder != nil && isHolderRunning(sandboxMeta.Holder) {
+		return nil
+	}
+
+	cmd := exec.Command(c.builtinPause.binary)
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start holder of sandbox %q: %v", sandboxMeta.ID, err)
+	}
+	// the holder is reaped by pouchd once it exits, or by init if it outlives pouchd.
+	go cmd.Wait()
+
+	pid := cmd.Process.Pid
+	stat, err := system.GetProcessStat(procRoot, pid)
+	if err != nil {
+		cmd.Process.Kill()
+		return fmt.Errorf("failed to get start time of holder %d of sandbox %q: %v", pid, sandboxMeta.ID, err)
+	}
+
+	useSystemd := c.DaemonConfig != nil && c.DaemonConfig.UseSystemd()
+	if err := joinPodCgroup(pid, sandboxMeta.Config.GetLinux().GetCgroupParent(), useSystemd); err != nil {
+		log.With(ctx).Warnf("failed to move holder %d of sandbox %q into pod cgroup: %v", pid, sandboxMeta.ID, err)
+	}
+
+	// the creation time is kept when the sandbox is started again.
+	created := time.Now().UnixNano()
+	if sandboxMeta.Holder != nil {
+		created = sandboxMeta.Holder.Created
+	}
+	sandboxMeta.Holder = &metatypes.SandboxHolder{Pid: pid, StartTime: stat.StartTime, Created: created}
+	return nil
+}
+
+// restartSandboxHolder starts the holder of the stopped sandbox again, and
+// sets up the sandbox files again as the sandbox container is started.
+func (c *CriManager) restartSandboxHolder(ctx context.Context, sandboxMeta *metatypes.SandboxMeta) error {
+	if err := c.startSandboxHolder(ctx, sandboxMeta); err != nil {
+		return err
+	}
+	if err := c.SandboxStore.Put(sandboxMeta); err != nil {
+		return err
+	}
+
+	sandboxRootDir := path.Join(c.SandboxBaseDir, sandboxMeta.ID)
+	if err := setupSandboxFiles(sandboxRootDir, sandboxMeta.Config); err != nil {
+		return fmt.Errorf("failed to setup sandbox files: %v", err)
+	}
+	return relabelSandboxFiles(ctx, sandboxMeta, sandboxRootDir)
+}
+
+// stopSandboxHolder terminates the holder process of sandbox, which is killed
+// if it is not stopped within the timeout (in time.Second).
+func stopSandboxHolder(holder *metatypes.SandboxHolder, timeout int64) error {
+	if holder == nil || !isHolderRunning(holder) {
+		return nil
+	}
+
+	if err := syscall.Kill(holder.Pid, syscall.SIGTERM); err != nil && err != syscall.ESRCH {
+		return err
+	}
+	for deadline := time.Now().Add(time.Duration(timeout) * time.Second); time.Now().Before(deadline); time.Sleep(holderPollInterval) {
+		if !isHolderRunning(holder) {
+			return nil
+		}
+	}
+
+	if err := syscall.Kill(holder.Pid, syscall.SIGKILL); err != nil && err != syscall.ESRCH {
+		return err
+	}
+	return nil
+}
+
+// holderSandboxState returns the state of sandbox held by the holder.
+func holderSandboxState(holder *metatypes.SandboxHolder) runtime.PodSandboxState {
+	if isHolderRunning(holder) {
+		return runtime.PodSandboxState_SANDBOX_READY
+	}
+	return runtime.PodSandboxState_SANDBOX_NOTREADY
+}
+
+// isHolderRunning returns whether the holder process is alive, the process
+// reusing its pid is not the holder.
+func isHolderRunning(holder *metatypes.SandboxHolder) bool {
+	stat, err := system.GetProcessStat(procRoot, holder.Pid)
+	return err == nil && stat.State != "Z" && stat.StartTime == holder.StartTime
+}
+
+// joinPodCgroup moves the process into the pod cgroup of every hierarchy,
+// including the named hierarchy of systemd on cgroup v1, which the process
+// is not moved into is skipped.
+func joinPodCgroup(pid int, cgroupParent string, useSystemd bool) error {
+	if cgroupParent == "" {
+		return nil
+	}
+
+	roots := []string{unifiedCgroupRoot}
+	if !isCgroup2UnifiedMode() {
+		roots = nil
+		entries, err := ioutil.ReadDir(cgroupRoot)
+		if err != nil {
+			return err
+		}
+		// the hierarchies of co-mounted controllers are symlinked, e.g. cpu.
+		for _, e := range entries {
+			if e.IsDir() {
+				roots = append(roots, filepath.Join(cgroupRoot, e.Name()))
+			}
+		}
+	}
+
+	for _, root := range roots {
+		cgroupPath, err := podCgroupPath(root, cgroupParent, useSystemd)
+		if err != nil {
+			return err
+		}
+		if _, err := os.Stat(cgroupPath); os.IsNotExist(err) {
+			continue
+		}
+		if err := ioutil.WriteFile(filepath.Join(cgroupPath, "cgroup.procs"), []byte(strconv.Itoa(pid)), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// holderPodSandbox returns the sandbox held by the holder in ListPodSandbox.
+func holderPodSandbox(sandboxMeta *metatypes.SandboxMeta) *runtime.PodSandbox {
+	return &runtime.PodSandbox{
+		Id:          sandboxMeta.ID,
+		Metadata:    sandboxMeta.Config.GetMetadata(),
+		State:       holderSandboxState(sandboxMeta.Holder),
+		CreatedAt:   sandboxMeta.Holder.Created,
+		Labels:      sandboxMeta.Config.GetLabels(),
+		Annotations: sandboxMeta.Config.GetAnnotations(),
+	}
+}
@@ -0,0 +1,101 @@
+package v1alpha2
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	anno "github.com/alibaba/pouch/cri/annotations"
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+	metatypes "github.com/alibaba/pouch/cri/v1alpha2/types"
+	"github.com/alibaba/pouch/daemon/config"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsesSandboxHolder(t *testing.T) {
+	hostConfig := func(pid runtime.NamespaceMode, annotations map[string]string) *runtime.PodSandboxConfig {
+		return &runtime.PodSandboxConfig{
+			Annotations: annotations,
+			Linux: &runtime.LinuxPodSandboxConfig{
+				SecurityContext: &runtime.LinuxSandboxSecurityContext{
+					NamespaceOptions: &runtime.NamespaceOption{
+						Network: runtime.NamespaceMode_NODE,
+						Ipc:     runtime.NamespaceMode_NODE,
+						Pid:     pid,
+					},
+				},
+			},
+		}
+	}
+
+	c := &CriManager{DaemonConfig: &config.Config{DefaultRuntime: "runc"}}
+	assert.False(t, c.usesSandboxHolder(hostConfig(runtime.NamespaceMode_NODE, nil), ""))
+
+	c.hostNetworkHolder = true
+	assert.True(t, c.usesSandboxHolder(hostConfig(runtime.NamespaceMode_NODE, nil), ""))
+	assert.True(t, c.usesSandboxHolder(hostConfig(runtime.NamespaceMode_CONTAINER, nil), "runc"))
+
+	// the containers join the pid namespace of sandbox.
+	assert.False(t, c.usesSandboxHolder(hostConfig(runtime.NamespaceMode_POD, nil), ""))
+	// the sandbox is run by another runtime.
+	assert.False(t, c.usesSandboxHolder(hostConfig(runtime.NamespaceMode_NODE, nil), "kata"))
+	assert.False(t, c.usesSandboxHolder(hostConfig(runtime.NamespaceMode_NODE, map[string]string{anno.KubernetesRuntime: "kata"}), ""))
+	// the network of pod is set up by CNI.
+	assert.False(t, c.usesSandboxHolder(&runtime.PodSandboxConfig{}, ""))
+}
+
+func TestIsHolderRunning(t *testing.T) {
+	defer func(proc string) { procRoot = proc }(procRoot)
+	procRoot = t.Name()
+	defer os.RemoveAll(procRoot)
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(procRoot, "42"), 0755))
+	stat := "42 (pause (holder)) S 1 42 42 0 -1 4194560 101 0 0 0 0 0 0 0 20 0 1 0 123456 1142784 1 18446744073709551615\n"
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(procRoot, "42", "stat"), []byte(stat), 0644))
+
+	assert.True(t, isHolderRunning(&metatypes.SandboxHolder{Pid: 42, StartTime: 123456}))
+	// the pid is reused by another process.
+	assert.False(t, isHolderRunning(&metatypes.SandboxHolder{Pid: 42, StartTime: 100}))
+	assert.False(t, isHolderRunning(&metatypes.SandboxHolder{Pid: 43, StartTime: 123456}))
+}
+
+func TestSandboxHolder(t *testing.T) {
+	homeDir, err := ioutil.TempDir("", "sandbox-holder")
+	assert.NoError(t, err)
+	defer os.RemoveAll(homeDir)
+
+	store, err := newSandboxStore(homeDir)
+	assert.NoError(t, err)
+	defer store.Shutdown()
+
+	binary := filepath.Join(homeDir, "pause")
+	assert.NoError(t, ioutil.WriteFile(binary, []byte("#!/bin/sh\nexec sleep 60\n"), 0755))
+	c := &CriManager{SandboxStore: store, builtinPause: &builtinPause{binary: binary}}
+
+	sandboxMeta := &metatypes.SandboxMeta{ID: "sandbox1", Config: &runtime.PodSandboxConfig{}}
+	assert.NoError(t, c.startSandboxHolder(context.Background(), sandboxMeta))
+	holder := sandboxMeta.Holder
+	assert.NotNil(t, holder)
+	assert.Equal(t, runtime.PodSandboxState_SANDBOX_READY, holderSandboxState(holder))
+
+	// the running holder is not started again.
+	assert.NoError(t, c.startSandboxHolder(context.Background(), sandboxMeta))
+	assert.Equal(t, holder, sandboxMeta.Holder)
+
+	assert.NoError(t, stopSandboxHolder(holder, 5))
+	assert.Equal(t, runtime.PodSandboxState_SANDBOX_NOTREADY, holderSandboxState(holder))
+	assert.NoError(t, stopSandboxHolder(holder, 5))
+
+	// the creation time is kept when the sandbox is started again.
+	assert.NoError(t, c.startSandboxHolder(context.Background(), sandboxMeta))
+	assert.NotEqual(t, holder.Pid, sandboxMeta.Holder.Pid)
+	assert.Equal(t, holder.Created, sandboxMeta.Holder.Created)
+	assert.NoError(t, stopSandboxHolder(sandboxMeta.Holder, 0))
+
+	res, err := store.Get("sandbox1")
+	assert.NoError(t, err)
+	assert.Equal(t, sandboxMeta.Holder, res.(*metatypes.SandboxMeta).Holder)
+}
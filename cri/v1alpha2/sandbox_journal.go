@@ -0,0 +1,38 @@
+package v1alpha2
+
+import (
+	"context"
+
+	metatypes "github.com/alibaba/pouch/cri/v1alpha2/types"
+	"github.com/alibaba/pouch/pkg/log"
+)
+
+// isPartialSandbox returns whether the sandbox is being created, or left
+// partially created by a crash, which is not exposed to kubelet.
+func isPartialSandbox(sandboxMeta *metatypes.SandboxMeta) bool {
+	return sandboxMeta.Config == nil || sandboxMeta.Phase == metatypes.SandboxPhaseCreating
+}
+
+// cleanupPartialSandboxes removes the sandboxes left partially created by a
+// crash of pouchd together with the resources journaled in their meta, e.g.
+// the network and the sandbox container, which are otherwise leaked forever. They are not completed since kubelet has got the
+// failure of RunPodSandbox, and runs another attempt of the pod.
+func (c *CriManager) cleanupPartialSandboxes(ctx context.Context) error {
+	metas, err := c.SandboxStore.List()
+	if err != nil {
+		return err
+	}
+
+	for id, obj := range metas {
+		sandboxMeta := obj.(*metatypes.SandboxMeta)
+		if sandboxMeta.Phase == metatypes.SandboxPhaseReady {
+			continue
+		}
+
+		log.With(ctx).Infof("clean up sandbox %q left in phase %q", id, sandboxMeta.Phase)
+		if err := c.removeBrokenSandbox(ctx, id); err != nil {
+			log.With(ctx).Warnf("failed to clean up partially created sandbox %q: %v", id, err)
+		}
+	}
+	return nil
+}
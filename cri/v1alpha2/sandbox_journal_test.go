@@ -0,0 +1,81 @@
+package v1alpha2
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	apitypes "github.com/alibaba/pouch/apis/types"
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+	metatypes "github.com/alibaba/pouch/cri/v1alpha2/types"
+	"github.com/alibaba/pouch/daemon/mgr"
+	"github.com/alibaba/pouch/pkg/errtypes"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// removedContainerMgr is the container manager whose containers have all been removed.
+type removedContainerMgr struct {
+	mgr.ContainerMgr
+	removed []string
+}
+
+func (m *removedContainerMgr) List(ctx context.Context, option *mgr.ContainerListOption) ([]*mgr.Container, error) {
+	return nil, nil
+}
+
+func (m *removedContainerMgr) Stop(ctx context.Context, name string, timeout int64) error {
+	return errtypes.ErrNotfound
+}
+
+func (m *removedContainerMgr) Remove(ctx context.Context, name string, options *apitypes.ContainerRemoveOptions) error {
+	m.removed = append(m.removed, name)
+	return errtypes.ErrNotfound
+}
+
+func TestIsPartialSandbox(t *testing.T) {
+	config := &runtime.PodSandboxConfig{}
+	assert.True(t, isPartialSandbox(&metatypes.SandboxMeta{}))
+	assert.True(t, isPartialSandbox(&metatypes.SandboxMeta{Config: config, Phase: metatypes.SandboxPhaseCreating}))
+	assert.False(t, isPartialSandbox(&metatypes.SandboxMeta{Config: config, Phase: metatypes.SandboxPhaseReady}))
+}
+
+func TestCleanupPartialSandboxes(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "sandbox-journal")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tmpdir)
+
+	store, err := newSandboxStore(tmpdir)
+	assert.NoError(t, err)
+	defer store.Shutdown()
+
+	hostNetwork := &runtime.PodSandboxConfig{
+		Linux: &runtime.LinuxPodSandboxConfig{
+			SecurityContext: &runtime.LinuxSandboxSecurityContext{
+				NamespaceOptions: &runtime.NamespaceOption{Network: runtime.NamespaceMode_NODE},
+			},
+		},
+	}
+	for id, phase := range map[string]metatypes.SandboxPhase{
+		"creating": metatypes.SandboxPhaseCreating,
+		"ready":    metatypes.SandboxPhaseReady,
+	} {
+		assert.NoError(t, store.Put(&metatypes.SandboxMeta{ID: id, Config: hostNetwork, Phase: phase}))
+	}
+
+	containerMgr := &removedContainerMgr{}
+	c := &CriManager{
+		ContainerMgr:   containerMgr,
+		SandboxStore:   store,
+		SandboxBaseDir: tmpdir,
+		attempts:       newAttemptCounter(),
+	}
+	assert.NoError(t, c.cleanupPartialSandboxes(context.Background()))
+
+	assert.Equal(t, []string{"creating"}, containerMgr.removed)
+
+	keys, err := store.Keys()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ready"}, keys)
+}
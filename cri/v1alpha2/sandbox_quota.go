@@ -0,0 +1,51 @@
+package v1alpha2
+
+import (
+	"fmt"
+
+	"github.com/alibaba/pouch/pkg/bytefmt"
+	"github.com/alibaba/pouch/storage/quota"
+)
+
+// setDirQuota sets the project quota with the quota id of the sandbox on the
+// directory, which is replaced in tests. The quota id is allocated under the
+// id of sandbox, and reclaimed by releaseSandboxDirQuota.
+var setDirQuota = func(id, dir, size string) error {
+	quotaID, err := quota.AllocateQuotaID(id, "sandbox-dir")
+	if err != nil {
+		return err
+	}
+	return quota.SetDiskQuota(dir, size, quotaID)
+}
+
+// validateSandboxDirQuota validates the size of quota of sandbox directory.
+func validateSandboxDirQuota(size string) error {
+	if size == "" {
+		return nil
+	}
+	if kb, err := bytefmt.ToKilobytes(size); err != nil || kb == 0 {
+		return fmt.Errorf("invalid quota %q of sandbox directory, should be a positive size, e.g. 10m", size)
+	}
+	return nil
+}
+
+// applySandboxDirQuota limits the size of the sandbox directory by project
+// quota. The files in it, e.g. resolv.conf, are bound into the containers, so
+// they could otherwise be written by the containers to fill the filesystem of
+// the home dir of pouchd.
+func (c *CriManager) applySandboxDirQuota(id, sandboxRootDir string) error {
+	if c.sandboxDirQuota == "" {
+		return nil
+	}
+	if err := setDirQuota(id, sandboxRootDir, c.sandboxDirQuota); err != nil {
+		return fmt.Errorf("failed to set quota %s on %s: %v", c.sandboxDirQuota, sandboxRootDir, err)
+	}
+	return nil
+}
+
+// releaseSandboxDirQuota reclaims the quota id of the sandbox directory. It is
+// not reclaimed with the sandbox container, since the holder sandboxes have no
+// container and the sandbox may fail before its container is created.
+func releaseSandboxDirQuota(id string) error {
+	return quota.ReleaseQuotaIDs(id)
+}
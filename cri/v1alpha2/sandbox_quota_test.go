@@ -0,0 +1,40 @@
+package v1alpha2
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSandboxDirQuota(t *testing.T) {
+	assert.NoError(t, validateSandboxDirQuota(""))
+	assert.NoError(t, validateSandboxDirQuota("10m"))
+	assert.NoError(t, validateSandboxDirQuota("1G"))
+	assert.Error(t, validateSandboxDirQuota("0"))
+	assert.Error(t, validateSandboxDirQuota("ten"))
+}
+
+func TestApplySandboxDirQuota(t *testing.T) {
+	defer func(orig func(string, string, string) error) { setDirQuota = orig }(setDirQuota)
+
+	var quotas []string
+	setDirQuota = func(id, dir, size string) error {
+		quotas = append(quotas, id+":"+dir+":"+size)
+		return nil
+	}
+
+	// no quota is set if not configured.
+	c := &CriManager{}
+	assert.NoError(t, c.applySandboxDirQuota("s1", "/var/lib/pouch/sandboxes/s1"))
+	assert.Empty(t, quotas)
+
+	c.sandboxDirQuota = "10m"
+	assert.NoError(t, c.applySandboxDirQuota("s1", "/var/lib/pouch/sandboxes/s1"))
+	assert.Equal(t, []string{"s1:/var/lib/pouch/sandboxes/s1:10m"}, quotas)
+
+	setDirQuota = func(id, dir, size string) error {
+		return fmt.Errorf("quota is not enabled")
+	}
+	assert.Error(t, c.applySandboxDirQuota("s2", "/var/lib/pouch/sandboxes/s2"))
+}
@@ -0,0 +1,49 @@
+package v1alpha2
+
+import (
+	"fmt"
+	"os"
+
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+)
+
+// parallelStep is a step of RunPodSandbox run in parallel with the others,
+// e.g. the image of sandbox is pulled while the network is being set up.
+type parallelStep struct {
+	done chan struct{}
+	err  error
+}
+
+// startParallelStep runs the step in background.
+func startParallelStep(fn func() error) *parallelStep {
+	s := &parallelStep{done: make(chan struct{})}
+	go func() {
+		defer close(s.done)
+		s.err = fn()
+	}()
+	return s
+}
+
+// wait waits for the step to be done and returns its error, it could be
+// called many times, e.g. by the rollback after the step fails.
+func (s *parallelStep) wait() error {
+	<-s.done
+	return s.err
+}
+
+// setupSandboxDir creates the sandbox directory with the quota, and sets up
+// the sandbox files /etc/resolv.conf and /etc/hostname in it.
+func (c *CriManager) setupSandboxDir(id, sandboxRootDir string, config *runtime.PodSandboxConfig) error {
+	if err := os.MkdirAll(sandboxRootDir, 0755); err != nil {
+		return fmt.Errorf("failed to create sandbox root directory: %v", err)
+	}
+
+	if err := c.applySandboxDirQuota(id, sandboxRootDir); err != nil {
+		return err
+	}
+
+	if err := setupSandboxFiles(sandboxRootDir, config); err != nil {
+		return fmt.Errorf("failed to setup sandbox files: %v", err)
+	}
+	return nil
+}
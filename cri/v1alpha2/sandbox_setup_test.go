@@ -0,0 +1,58 @@
+package v1alpha2
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParallelStep(t *testing.T) {
+	release := make(chan struct{})
+	step := startParallelStep(func() error {
+		<-release
+		return fmt.Errorf("failed")
+	})
+
+	select {
+	case <-step.done:
+		t.Fatal("the step should be running")
+	default:
+	}
+
+	close(release)
+	assert.Error(t, step.wait())
+	// the error is kept for the rollback.
+	assert.Error(t, step.wait())
+
+	assert.NoError(t, startParallelStep(func() error { return nil }).wait())
+}
+
+func TestSetupSandboxDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sandbox-dir")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	c := &CriManager{}
+	sandboxRootDir := filepath.Join(dir, "sandbox1")
+	config := &runtime.PodSandboxConfig{
+		Hostname: "pod1",
+		DnsConfig: &runtime.DNSConfig{
+			Servers: []string{"10.0.0.10"},
+		},
+	}
+	assert.NoError(t, c.setupSandboxDir("sandbox1", sandboxRootDir, config))
+
+	hostname, err := ioutil.ReadFile(filepath.Join(sandboxRootDir, "hostname"))
+	assert.NoError(t, err)
+	assert.Equal(t, "pod1\n", string(hostname))
+
+	resolv, err := ioutil.ReadFile(filepath.Join(sandboxRootDir, "resolv.conf"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(resolv), "nameserver 10.0.0.10")
+}
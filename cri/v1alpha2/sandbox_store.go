@@ -0,0 +1,137 @@
+package v1alpha2
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"reflect"
+
+	metatypes "github.com/alibaba/pouch/cri/v1alpha2/types"
+	"github.com/alibaba/pouch/pkg/log"
+	"github.com/alibaba/pouch/pkg/meta"
+)
+
+// sandboxMetaSchemaVersion is the current schema version of sandbox meta. Bump it and
+// add the conversion into upgradeSandboxMeta when the layout of SandboxMeta changes.
+const sandboxMetaSchemaVersion = 2
+
+// sandboxMetaBucket is the bucket storing sandbox meta in the database.
+const sandboxMetaBucket = "sandboxes"
+
+// newSandboxStore creates the transactional store of sandbox meta. The sandbox meta in
+// the legacy store is imported, the stale ones are upgraded to the current schema,
+// and the database is compacted to reclaim the space of removed sandboxes.
+func newSandboxStore(homeDir string) (*meta.Store, error) {
+	store, err := meta.NewStore(meta.Config{
+		Driver:  "boltdb",
+		BaseDir: path.Join(homeDir, "sandboxes-meta.db"),
+		Buckets: []meta.Bucket{
+			{
+				Name: sandboxMetaBucket,
+				Type: reflect.TypeOf(metatypes.SandboxMeta{}),
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := importLegacySandboxStore(store, path.Join(homeDir, "sandboxes-meta")); err != nil {
+		return nil, err
+	}
+
+	if err := upgradeSandboxStore(store); err != nil {
+		return nil, err
+	}
+
+	if err := store.Compact(); err != nil {
+		log.With(nil).Warnf("failed to compact sandbox meta store: %v", err)
+	}
+
+	return store, nil
+}
+
+// importLegacySandboxStore moves the sandbox meta in the legacy local store into the database.
+// The legacy store is renamed to <dir>.migrated after import, so it's only done once. The
+// migration is one-way: pouchd of the older versions reads no sandbox meta after it, unless
+// the directory is renamed back, in which the sandboxes created since then are missing.
+func importLegacySandboxStore(store *meta.Store, legacyDir string) error {
+	if _, err := os.Stat(legacyDir); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	legacy, err := meta.NewStore(meta.Config{
+		Driver:  "local",
+		BaseDir: legacyDir,
+		Buckets: []meta.Bucket{
+			{
+				Name: meta.MetaJSONFile,
+				Type: reflect.TypeOf(metatypes.SandboxMeta{}),
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to open legacy sandbox meta store: %v", err)
+	}
+
+	objs, err := legacy.List()
+	if err != nil {
+		return fmt.Errorf("failed to list legacy sandbox meta: %v", err)
+	}
+	for id, obj := range objs {
+		if err := store.Put(obj); err != nil {
+			return fmt.Errorf("failed to import meta of sandbox %q: %v", id, err)
+		}
+	}
+	legacy.Shutdown()
+
+	if err := os.Rename(legacyDir, legacyDir+".migrated"); err != nil {
+		return fmt.Errorf("failed to rename legacy sandbox meta store: %v", err)
+	}
+	log.With(nil).Infof("imported %d sandbox meta from legacy store %s", len(objs), legacyDir)
+	return nil
+}
+
+// upgradeSandboxStore upgrades all the sandbox meta to the current schema version.
+func upgradeSandboxStore(store *meta.Store) error {
+	keys, err := store.Keys()
+	if err != nil {
+		return err
+	}
+
+	for _, id := range keys {
+		err := store.Update(id, func(obj meta.Object) error {
+			return upgradeSandboxMeta(obj.(*metatypes.SandboxMeta))
+		})
+		if err != nil {
+			return fmt.Errorf("failed to upgrade meta of sandbox %q: %v", id, err)
+		}
+	}
+	return nil
+}
+
+// upgradeSandboxMeta converts the sandbox meta to the current schema version step by step.
+func upgradeSandboxMeta(sandboxMeta *metatypes.SandboxMeta) error {
+	if sandboxMeta.SchemaVersion > sandboxMetaSchemaVersion {
+		return fmt.Errorf("schema version %d is newer than the supported version %d", sandboxMeta.SchemaVersion, sandboxMetaSchemaVersion)
+	}
+
+	for sandboxMeta.SchemaVersion < sandboxMetaSchemaVersion {
+		switch sandboxMeta.SchemaVersion {
+		case 0:
+			// version 0 is the meta without schema version, the layout is the same as version 1.
+		case 1:
+			// version 1 has no phase, the meta without config is the partially created sandbox.
+			if sandboxMeta.Config == nil {
+				sandboxMeta.Phase = metatypes.SandboxPhaseCreating
+			} else {
+				sandboxMeta.Phase = metatypes.SandboxPhaseReady
+			}
+		}
+		sandboxMeta.SchemaVersion++
+	}
+	return nil
+}
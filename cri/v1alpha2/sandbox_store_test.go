@@ -0,0 +1,69 @@
+package v1alpha2
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"reflect"
+	"testing"
+
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+	metatypes "github.com/alibaba/pouch/cri/v1alpha2/types"
+	"github.com/alibaba/pouch/pkg/meta"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSandboxStoreImportsLegacyStore(t *testing.T) {
+	homeDir, err := ioutil.TempDir("", "sandbox-store")
+	assert.NoError(t, err)
+	defer os.RemoveAll(homeDir)
+
+	legacyDir := path.Join(homeDir, "sandboxes-meta")
+	legacy, err := meta.NewStore(meta.Config{
+		Driver:  "local",
+		BaseDir: legacyDir,
+		Buckets: []meta.Bucket{
+			{
+				Name: meta.MetaJSONFile,
+				Type: reflect.TypeOf(metatypes.SandboxMeta{}),
+			},
+		},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, legacy.Put(&metatypes.SandboxMeta{ID: "sandbox1", NetNS: "/var/run/netns/cni-1"}))
+
+	store, err := newSandboxStore(homeDir)
+	assert.NoError(t, err)
+
+	obj, err := store.Get("sandbox1")
+	assert.NoError(t, err)
+	sandboxMeta := obj.(*metatypes.SandboxMeta)
+	assert.Equal(t, "/var/run/netns/cni-1", sandboxMeta.NetNS)
+	assert.Equal(t, sandboxMetaSchemaVersion, sandboxMeta.SchemaVersion)
+
+	_, err = os.Stat(legacyDir)
+	assert.True(t, os.IsNotExist(err))
+	assert.NoError(t, store.Shutdown())
+
+	// the store is reopened without the legacy store.
+	store, err = newSandboxStore(homeDir)
+	assert.NoError(t, err)
+	keys, err := store.Keys()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sandbox1"}, keys)
+}
+
+func TestUpgradeSandboxMeta(t *testing.T) {
+	sandboxMeta := &metatypes.SandboxMeta{ID: "sandbox1"}
+	assert.NoError(t, upgradeSandboxMeta(sandboxMeta))
+	assert.Equal(t, sandboxMetaSchemaVersion, sandboxMeta.SchemaVersion)
+	assert.Equal(t, metatypes.SandboxPhaseCreating, sandboxMeta.Phase)
+
+	sandboxMeta = &metatypes.SandboxMeta{ID: "sandbox2", SchemaVersion: 1, Config: &runtime.PodSandboxConfig{}}
+	assert.NoError(t, upgradeSandboxMeta(sandboxMeta))
+	assert.Equal(t, metatypes.SandboxPhaseReady, sandboxMeta.Phase)
+
+	sandboxMeta.SchemaVersion = sandboxMetaSchemaVersion + 1
+	assert.Error(t, upgradeSandboxMeta(sandboxMeta))
+}
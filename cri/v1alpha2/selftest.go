@@ -0,0 +1,316 @@
+package v1alpha2
+
+import (
+	"fmt"
+	"time"
+
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+	metatypes "github.com/alibaba/pouch/cri/v1alpha2/types"
+	"github.com/alibaba/pouch/pkg/errtypes"
+	"github.com/alibaba/pouch/pkg/log"
+	"github.com/alibaba/pouch/pkg/randomid"
+
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+)
+
+const (
+	// selfTestNamespace is the namespace of the sandbox of self-test, which
+	// separates it from the pods of kubernetes.
+	selfTestNamespace = "pouch-selftest"
+	// selfTestStopTimeout is the timeout (in seconds) to stop the test container.
+	selfTestStopTimeout = 2
+	// selfTestExecTimeout is the timeout (in seconds) of the exec sync of test container.
+	selfTestExecTimeout = 10
+	// selfTestCleanupTimeout is the timeout to remove the test sandbox left,
+	// which is removed even if the caller has gone.
+	selfTestCleanupTimeout = 30 * time.Second
+)
+
+// selfTest runs the scenarios of critest against the runtime and image services,
+// each scenario is skipped once the scenario it depends on failed.
+type selfTest struct {
+	runtimeService runtime.RuntimeServiceServer
+	imageService   runtime.ImageServiceServer
+
+	image       string
+	execEnabled bool
+	hostNetwork bool
+
+	sandboxConfig *runtime.PodSandboxConfig
+	imagePulled   bool
+	sandboxID     string
+	containerID   string
+
+	report *metatypes.SelfTestReport
+}
+
+// run runs the scenario unless the reason to skip it is given.
+func (t *selfTest) run(ctx context.Context, name, skip string, f func(ctx context.Context) error) {
+	result := &metatypes.SelfTestResult{Name: name}
+	t.report.Results = append(t.report.Results, result)
+	if skip != "" {
+		result.Skipped, result.Error = true, skip
+		return
+	}
+
+	start := time.Now()
+	err := f(ctx)
+	result.Duration = int64(time.Since(start) / time.Millisecond)
+	if err != nil {
+		result.Error = err.Error()
+		t.report.Passed = false
+		log.With(ctx).Warnf("cri self-test %q failed: %v", name, err)
+		return
+	}
+	result.Passed = true
+}
+
+func (t *selfTest) requireSandbox() string {
+	if t.sandboxID == "" {
+		return "the test sandbox is not running"
+	}
+	return ""
+}
+
+func (t *selfTest) requireContainer() string {
+	if t.containerID == "" {
+		return "the test container is not running"
+	}
+	return ""
+}
+
+func (t *selfTest) containerConfig() *runtime.ContainerConfig {
+	config := &runtime.ContainerConfig{
+		Metadata: &runtime.ContainerMetadata{Name: "selftest"},
+		Image:    &runtime.ImageSpec{Image: t.image},
+	}
+	// the image to exec in may have no long-running entrypoint.
+	if t.execEnabled {
+		config.Command = []string{"sh", "-c", "sleep 3600"}
+	}
+	return config
+}
+
+func (t *selfTest) version(ctx context.Context) error {
+	resp, err := t.runtimeService.Version(ctx, &runtime.VersionRequest{})
+	if err != nil {
+		return err
+	}
+	if resp.GetRuntimeName() == "" || resp.GetRuntimeApiVersion() == "" {
+		return fmt.Errorf("runtime name or api version is empty")
+	}
+	return nil
+}
+
+func (t *selfTest) status(ctx context.Context) error {
+	resp, err := t.runtimeService.Status(ctx, &runtime.StatusRequest{})
+	if err != nil {
+		return err
+	}
+	for _, condition := range resp.GetStatus().GetConditions() {
+		if condition.GetType() == runtime.NetworkReady && t.hostNetwork {
+			continue
+		}
+		if !condition.GetStatus() {
+			return fmt.Errorf("condition %s is false: %s", condition.GetType(), condition.GetMessage())
+		}
+	}
+	return nil
+}
+
+func (t *selfTest) pullImage(ctx context.Context) error {
+	spec := &runtime.ImageSpec{Image: t.image}
+	if _, err := t.imageService.PullImage(ctx, &runtime.PullImageRequest{Image: spec}); err != nil {
+		return err
+	}
+	resp, err := t.imageService.ImageStatus(ctx, &runtime.ImageStatusRequest{Image: spec})
+	if err != nil {
+		return err
+	}
+	if resp.GetImage() == nil {
+		return fmt.Errorf("image %q is not found after pulled", t.image)
+	}
+	t.imagePulled = true
+	return nil
+}
+
+func (t *selfTest) runSandbox(ctx context.Context) error {
+	resp, err := t.runtimeService.RunPodSandbox(ctx, &runtime.RunPodSandboxRequest{Config: t.sandboxConfig})
+	if err != nil {
+		return err
+	}
+	t.sandboxID = resp.GetPodSandboxId()
+
+	status, err := t.runtimeService.PodSandboxStatus(ctx, &runtime.PodSandboxStatusRequest{PodSandboxId: t.sandboxID})
+	if err != nil {
+		return err
+	}
+	if state := status.GetStatus().GetState(); state != runtime.PodSandboxState_SANDBOX_READY {
+		return fmt.Errorf("sandbox %q is %s, expected %s", t.sandboxID, state, runtime.PodSandboxState_SANDBOX_READY)
+	}
+	if !t.hostNetwork && status.GetStatus().GetNetwork().GetIp() == "" {
+		return fmt.Errorf("sandbox %q has no ip", t.sandboxID)
+	}
+	return nil
+}
+
+func (t *selfTest) listSandbox(ctx context.Context) error {
+	resp, err := t.runtimeService.ListPodSandbox(ctx, &runtime.ListPodSandboxRequest{
+		Filter: &runtime.PodSandboxFilter{Id: t.sandboxID},
+	})
+	if err != nil {
+		return err
+	}
+	if len(resp.GetItems()) != 1 || resp.GetItems()[0].GetId() != t.sandboxID {
+		return fmt.Errorf("sandbox %q is not listed", t.sandboxID)
+	}
+	return nil
+}
+
+func (t *selfTest) runContainer(ctx context.Context) error {
+	resp, err := t.runtimeService.CreateContainer(ctx, &runtime.CreateContainerRequest{
+		PodSandboxId:  t.sandboxID,
+		Config:        t.containerConfig(),
+		SandboxConfig: t.sandboxConfig,
+	})
+	if err != nil {
+		return err
+	}
+	t.containerID = resp.GetContainerId()
+
+	if _, err := t.runtimeService.StartContainer(ctx, &runtime.StartContainerRequest{ContainerId: t.containerID}); err != nil {
+		return err
+	}
+	status, err := t.runtimeService.ContainerStatus(ctx, &runtime.ContainerStatusRequest{ContainerId: t.containerID})
+	if err != nil {
+		return err
+	}
+	if state := status.GetStatus().GetState(); state != runtime.ContainerState_CONTAINER_RUNNING {
+		return fmt.Errorf("container %q is %s, expected %s", t.containerID, state, runtime.ContainerState_CONTAINER_RUNNING)
+	}
+	return nil
+}
+
+func (t *selfTest) listContainer(ctx context.Context) error {
+	resp, err := t.runtimeService.ListContainers(ctx, &runtime.ListContainersRequest{
+		Filter: &runtime.ContainerFilter{PodSandboxId: t.sandboxID},
+	})
+	if err != nil {
+		return err
+	}
+	if len(resp.GetContainers()) != 1 || resp.GetContainers()[0].GetId() != t.containerID {
+		return fmt.Errorf("container %q is not listed in sandbox %q", t.containerID, t.sandboxID)
+	}
+	return nil
+}
+
+func (t *selfTest) execSync(ctx context.Context) error {
+	resp, err := t.runtimeService.ExecSync(ctx, &runtime.ExecSyncRequest{
+		ContainerId: t.containerID,
+		Cmd:         []string{"sh", "-c", "echo selftest"},
+		Timeout:     selfTestExecTimeout,
+	})
+	if err != nil {
+		return err
+	}
+	if resp.GetExitCode() != 0 || string(resp.GetStdout()) != "selftest\n" {
+		return fmt.Errorf("unexpected exit code %d and stdout %q of exec", resp.GetExitCode(), resp.GetStdout())
+	}
+	return nil
+}
+
+func (t *selfTest) removeContainer(ctx context.Context) error {
+	if _, err := t.runtimeService.StopContainer(ctx, &runtime.StopContainerRequest{ContainerId: t.containerID, Timeout: selfTestStopTimeout}); err != nil {
+		return err
+	}
+	if _, err := t.runtimeService.RemoveContainer(ctx, &runtime.RemoveContainerRequest{ContainerId: t.containerID}); err != nil {
+		return err
+	}
+	t.containerID = ""
+	return nil
+}
+
+func (t *selfTest) removeSandbox(ctx context.Context) error {
+	if _, err := t.runtimeService.StopPodSandbox(ctx, &runtime.StopPodSandboxRequest{PodSandboxId: t.sandboxID}); err != nil {
+		return err
+	}
+	if _, err := t.runtimeService.RemovePodSandbox(ctx, &runtime.RemovePodSandboxRequest{PodSandboxId: t.sandboxID}); err != nil {
+		return err
+	}
+	t.sandboxID = ""
+	return nil
+}
+
+// runSelfTest runs the scenarios in order and removes the test sandbox at last.
+func runSelfTest(ctx context.Context, t *selfTest) *metatypes.SelfTestReport {
+	t.report = &metatypes.SelfTestReport{Passed: true}
+	defer func() {
+		if t.sandboxID != "" {
+			cleanupCtx, cancel := context.WithTimeout(context.Background(), selfTestCleanupTimeout)
+			defer cancel()
+			if err := t.removeSandbox(cleanupCtx); err != nil {
+				log.With(ctx).Errorf("failed to remove the sandbox %q of cri self-test: %v", t.sandboxID, err)
+			}
+		}
+	}()
+
+	t.run(ctx, "runtime should report version", "", t.version)
+	t.run(ctx, "runtime should report ready status", "", t.status)
+	t.run(ctx, "image should be pulled and inspected", "", t.pullImage)
+	t.run(ctx, "sandbox should be run and ready", "", t.runSandbox)
+	t.run(ctx, "sandbox should be listed", t.requireSandbox(), t.listSandbox)
+
+	skip := t.requireSandbox()
+	if !t.imagePulled {
+		skip = "the image is not pulled"
+	}
+	t.run(ctx, "container should be created and running", skip, t.runContainer)
+	t.run(ctx, "container should be listed", t.requireContainer(), t.listContainer)
+
+	skip = t.requireContainer()
+	if !t.execEnabled {
+		skip = "exec is not enabled"
+	}
+	t.run(ctx, "exec sync should run command in container", skip, t.execSync)
+	t.run(ctx, "container should be stopped and removed", t.requireContainer(), t.removeContainer)
+	t.run(ctx, "sandbox should be stopped and removed", t.requireSandbox(), t.removeSandbox)
+	return t.report
+}
+
+// SelfTest runs a curated subset of the critest scenarios against the live cri
+// manager in a sandbox of its own namespace, so that the operators could
+// validate the nodes after upgrades.
+func (c *CriManager) SelfTest(ctx context.Context, options *metatypes.SelfTestOptions) (*metatypes.SelfTestReport, error) {
+	image := options.Image
+	if image == "" {
+		if options.Exec {
+			return nil, errors.Wrap(errtypes.ErrInvalidParam, "the image with /bin/sh should be specified to exec")
+		}
+		image = c.sandboxImage()
+	}
+
+	suffix := randomid.Generate()[:8]
+	sandboxConfig := &runtime.PodSandboxConfig{
+		Metadata: &runtime.PodSandboxMetadata{
+			Name:      "selftest-" + suffix,
+			Namespace: selfTestNamespace,
+			Uid:       suffix,
+		},
+		Linux: &runtime.LinuxPodSandboxConfig{},
+	}
+	if options.HostNetwork {
+		sandboxConfig.Linux.SecurityContext = &runtime.LinuxSandboxSecurityContext{
+			NamespaceOptions: &runtime.NamespaceOption{Network: runtime.NamespaceMode_NODE},
+		}
+	}
+
+	return runSelfTest(ctx, &selfTest{
+		runtimeService: c,
+		imageService:   c,
+		image:          image,
+		execEnabled:    options.Exec,
+		hostNetwork:    options.HostNetwork,
+		sandboxConfig:  sandboxConfig,
+	}), nil
+}
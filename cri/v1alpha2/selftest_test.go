@@ -0,0 +1,164 @@
+package v1alpha2
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// selfTestService fakes the runtime and image services for the self-test.
+type selfTestService struct {
+	runtime.RuntimeServiceServer
+	runtime.ImageServiceServer
+	runSandboxErr error
+	calls         []string
+}
+
+func (s *selfTestService) Version(ctx context.Context, r *runtime.VersionRequest) (*runtime.VersionResponse, error) {
+	return &runtime.VersionResponse{RuntimeName: "pouch", RuntimeApiVersion: "v1alpha2"}, nil
+}
+
+func (s *selfTestService) Status(ctx context.Context, r *runtime.StatusRequest) (*runtime.StatusResponse, error) {
+	return &runtime.StatusResponse{Status: &runtime.RuntimeStatus{Conditions: []*runtime.RuntimeCondition{
+		{Type: runtime.RuntimeReady, Status: true},
+		{Type: runtime.NetworkReady, Status: false, Message: "no cni"},
+	}}}, nil
+}
+
+func (s *selfTestService) PullImage(ctx context.Context, r *runtime.PullImageRequest) (*runtime.PullImageResponse, error) {
+	s.calls = append(s.calls, "pull "+r.GetImage().GetImage())
+	return &runtime.PullImageResponse{}, nil
+}
+
+func (s *selfTestService) ImageStatus(ctx context.Context, r *runtime.ImageStatusRequest) (*runtime.ImageStatusResponse, error) {
+	return &runtime.ImageStatusResponse{Image: &runtime.Image{Id: "i1"}}, nil
+}
+
+func (s *selfTestService) RunPodSandbox(ctx context.Context, r *runtime.RunPodSandboxRequest) (*runtime.RunPodSandboxResponse, error) {
+	s.calls = append(s.calls, "run "+r.GetConfig().GetMetadata().GetNamespace())
+	if s.runSandboxErr != nil {
+		return nil, s.runSandboxErr
+	}
+	return &runtime.RunPodSandboxResponse{PodSandboxId: "s1"}, nil
+}
+
+func (s *selfTestService) PodSandboxStatus(ctx context.Context, r *runtime.PodSandboxStatusRequest) (*runtime.PodSandboxStatusResponse, error) {
+	return &runtime.PodSandboxStatusResponse{Status: &runtime.PodSandboxStatus{State: runtime.PodSandboxState_SANDBOX_READY}}, nil
+}
+
+func (s *selfTestService) ListPodSandbox(ctx context.Context, r *runtime.ListPodSandboxRequest) (*runtime.ListPodSandboxResponse, error) {
+	return &runtime.ListPodSandboxResponse{Items: []*runtime.PodSandbox{{Id: r.GetFilter().GetId()}}}, nil
+}
+
+func (s *selfTestService) CreateContainer(ctx context.Context, r *runtime.CreateContainerRequest) (*runtime.CreateContainerResponse, error) {
+	s.calls = append(s.calls, fmt.Sprintf("create %v", r.GetConfig().GetCommand()))
+	return &runtime.CreateContainerResponse{ContainerId: "c1"}, nil
+}
+
+func (s *selfTestService) StartContainer(ctx context.Context, r *runtime.StartContainerRequest) (*runtime.StartContainerResponse, error) {
+	return &runtime.StartContainerResponse{}, nil
+}
+
+func (s *selfTestService) ContainerStatus(ctx context.Context, r *runtime.ContainerStatusRequest) (*runtime.ContainerStatusResponse, error) {
+	return &runtime.ContainerStatusResponse{Status: &runtime.ContainerStatus{State: runtime.ContainerState_CONTAINER_RUNNING}}, nil
+}
+
+func (s *selfTestService) ListContainers(ctx context.Context, r *runtime.ListContainersRequest) (*runtime.ListContainersResponse, error) {
+	return &runtime.ListContainersResponse{Containers: []*runtime.Container{{Id: "c1"}}}, nil
+}
+
+func (s *selfTestService) ExecSync(ctx context.Context, r *runtime.ExecSyncRequest) (*runtime.ExecSyncResponse, error) {
+	return &runtime.ExecSyncResponse{Stdout: []byte("selftest\n")}, nil
+}
+
+func (s *selfTestService) StopContainer(ctx context.Context, r *runtime.StopContainerRequest) (*runtime.StopContainerResponse, error) {
+	return &runtime.StopContainerResponse{}, nil
+}
+
+func (s *selfTestService) RemoveContainer(ctx context.Context, r *runtime.RemoveContainerRequest) (*runtime.RemoveContainerResponse, error) {
+	s.calls = append(s.calls, "remove "+r.GetContainerId())
+	return &runtime.RemoveContainerResponse{}, nil
+}
+
+func (s *selfTestService) StopPodSandbox(ctx context.Context, r *runtime.StopPodSandboxRequest) (*runtime.StopPodSandboxResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return &runtime.StopPodSandboxResponse{}, nil
+}
+
+func (s *selfTestService) RemovePodSandbox(ctx context.Context, r *runtime.RemovePodSandboxRequest) (*runtime.RemovePodSandboxResponse, error) {
+	s.calls = append(s.calls, "remove "+r.GetPodSandboxId())
+	return &runtime.RemovePodSandboxResponse{}, nil
+}
+
+func newSelfTest(s *selfTestService, execEnabled bool) *selfTest {
+	return &selfTest{
+		runtimeService: s,
+		imageService:   s,
+		image:          "busybox",
+		execEnabled:    execEnabled,
+		hostNetwork:    true,
+		sandboxConfig: &runtime.PodSandboxConfig{
+			Metadata: &runtime.PodSandboxMetadata{Name: "selftest", Namespace: selfTestNamespace},
+		},
+	}
+}
+
+func TestRunSelfTest(t *testing.T) {
+	service := &selfTestService{}
+	report := runSelfTest(context.Background(), newSelfTest(service, true))
+	assert.True(t, report.Passed)
+	assert.Len(t, report.Results, 10)
+	for _, result := range report.Results {
+		assert.True(t, result.Passed, result.Name)
+	}
+	assert.Equal(t, []string{
+		"pull busybox",
+		"run " + selfTestNamespace,
+		"create [sh -c sleep 3600]",
+		"remove c1",
+		"remove s1",
+	}, service.calls)
+
+	// the exec is skipped unless enabled.
+	service = &selfTestService{}
+	report = runSelfTest(context.Background(), newSelfTest(service, false))
+	assert.True(t, report.Passed)
+	assert.Equal(t, "create []", service.calls[2])
+	assert.True(t, report.Results[7].Skipped)
+}
+
+func TestRunSelfTestSkipsDependents(t *testing.T) {
+	service := &selfTestService{runSandboxErr: fmt.Errorf("cni failed")}
+	report := runSelfTest(context.Background(), newSelfTest(service, true))
+	assert.False(t, report.Passed)
+
+	var failed, skipped []string
+	for _, result := range report.Results {
+		if result.Skipped {
+			skipped = append(skipped, result.Name)
+		} else if !result.Passed {
+			failed = append(failed, result.Name)
+		}
+	}
+	assert.Equal(t, []string{"sandbox should be run and ready"}, failed)
+	assert.Len(t, skipped, 6)
+	assert.Equal(t, "cni failed", report.Results[3].Error)
+	assert.Equal(t, []string{"pull busybox", "run " + selfTestNamespace}, service.calls)
+}
+
+func TestRunSelfTestCleanupAfterCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// the sandbox is removed even if the caller has gone.
+	service := &selfTestService{}
+	report := runSelfTest(ctx, newSelfTest(service, true))
+	assert.False(t, report.Passed)
+	assert.Equal(t, "remove s1", service.calls[len(service.calls)-1])
+}
@@ -0,0 +1,121 @@
+package v1alpha2
+
+import (
+	"fmt"
+	"strings"
+
+	apitypes "github.com/alibaba/pouch/apis/types"
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+	metatypes "github.com/alibaba/pouch/cri/v1alpha2/types"
+	"github.com/alibaba/pouch/pkg/log"
+
+	"github.com/opencontainers/selinux/go-selinux"
+	"github.com/opencontainers/selinux/go-selinux/label"
+	"golang.org/x/net/context"
+)
+
+var (
+	// selinuxEnforcing returns whether SELinux is enforcing, which is replaced in tests.
+	selinuxEnforcing = func() bool {
+		return selinux.GetEnabled() && selinux.EnforceMode() == selinux.Enforcing
+	}
+
+	// initSelinuxLabels allocates the process and mount labels with the unique
+	// MCS categories, which is replaced in tests.
+	initSelinuxLabels = label.InitLabels
+)
+
+// selinuxLabelOptions returns the label options of the SELinux options of sandbox,
+// the unspecified parts are the defaults of the policy.
+func selinuxLabelOptions(opts *runtime.SELinuxOption) []string {
+	var result []string
+	for _, kv := range [][2]string{
+		{"user", opts.GetUser()},
+		{"role", opts.GetRole()},
+		{"type", opts.GetType()},
+		{"level", opts.GetLevel()},
+	} {
+		if kv[1] != "" {
+			result = append(result, kv[0]+":"+kv[1])
+		}
+	}
+	return result
+}
+
+// selinuxLevel returns the MLS/MCS level of the SELinux label, e.g. s0:c1,c2.
+func selinuxLevel(l string) string {
+	if len(strings.SplitN(l, ":", 4)) != 4 {
+		return ""
+	}
+	return selinux.NewContext(l)["level"]
+}
+
+// allocateSelinuxLabels allocates the SELinux labels with the unique MCS
+// categories for the pod if SELinux is enforcing, so that the containers of
+// different pods could not access the files of each other. The labels are
+// stored with the meta of sandbox by the caller.
+func (c *CriManager) allocateSelinuxLabels(sandboxMeta *metatypes.SandboxMeta, config *runtime.PodSandboxConfig) error {
+	if !selinuxEnforcing() {
+		return nil
+	}
+
+	processLabel, mountLabel, err := initSelinuxLabels(selinuxLabelOptions(config.GetLinux().GetSecurityContext().GetSelinuxOptions()))
+	if err != nil {
+		return fmt.Errorf("failed to allocate selinux labels: %v", err)
+	}
+	sandboxMeta.ProcessLabel, sandboxMeta.MountLabel = processLabel, mountLabel
+	return nil
+}
+
+// releaseSelinuxLabels releases the MCS categories of pod for the new pods.
+func releaseSelinuxLabels(sandboxMeta *metatypes.SandboxMeta) {
+	if sandboxMeta.ProcessLabel != "" {
+		label.ReleaseLabel(sandboxMeta.ProcessLabel)
+	}
+}
+
+// restoreSelinuxLabels reserves the MCS categories of the existing pods after
+// pouchd is restarted, so that they are not allocated to the new pods again.
+func (c *CriManager) restoreSelinuxLabels(ctx context.Context) error {
+	metas, err := c.SandboxStore.List()
+	if err != nil {
+		return err
+	}
+
+	for _, m := range metas {
+		if meta, ok := m.(*metatypes.SandboxMeta); ok && meta.ProcessLabel != "" {
+			label.ReserveLabel(meta.ProcessLabel)
+		}
+	}
+	return nil
+}
+
+// applySelinuxLevel sets the MCS level of pod to the container, unless the
+// container specifies its own level or disables the labeling.
+func applySelinuxLevel(sandboxMeta *metatypes.SandboxMeta, hc *apitypes.HostConfig) {
+	level := selinuxLevel(sandboxMeta.ProcessLabel)
+	if level == "" {
+		return
+	}
+
+	for _, opt := range hc.SecurityOpt {
+		if strings.HasPrefix(opt, "label=level:") || opt == "label=disable" {
+			return
+		}
+	}
+	hc.SecurityOpt = append(hc.SecurityOpt, "label=level:"+level)
+}
+
+// relabelSandboxFiles labels the sandbox files, e.g. resolv.conf, with the
+// mount label of pod, which are bound into all the containers of pod.
+func relabelSandboxFiles(ctx context.Context, sandboxMeta *metatypes.SandboxMeta, sandboxRootDir string) error {
+	if sandboxMeta.MountLabel == "" {
+		return nil
+	}
+
+	if err := label.Relabel(sandboxRootDir, sandboxMeta.MountLabel, false); err != nil {
+		return fmt.Errorf("failed to relabel %s with %q: %v", sandboxRootDir, sandboxMeta.MountLabel, err)
+	}
+	log.With(ctx).Debugf("relabel sandbox files of %q with %q", sandboxMeta.ID, sandboxMeta.MountLabel)
+	return nil
+}
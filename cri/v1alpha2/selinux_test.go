@@ -0,0 +1,97 @@
+package v1alpha2
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	apitypes "github.com/alibaba/pouch/apis/types"
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+	metatypes "github.com/alibaba/pouch/cri/v1alpha2/types"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+func TestSelinuxLevel(t *testing.T) {
+	assert.Equal(t, "s0:c1,c2", selinuxLevel("system_u:system_r:container_t:s0:c1,c2"))
+	assert.Equal(t, "s0", selinuxLevel("system_u:object_r:container_file_t:s0"))
+	assert.Equal(t, "", selinuxLevel(""))
+	assert.Equal(t, "", selinuxLevel("invalid"))
+}
+
+func TestSelinuxLabelOptions(t *testing.T) {
+	assert.Nil(t, selinuxLabelOptions(nil))
+	assert.Equal(t, []string{"type:spc_t", "level:s0:c3,c4"}, selinuxLabelOptions(&runtime.SELinuxOption{
+		Type:  "spc_t",
+		Level: "s0:c3,c4",
+	}))
+}
+
+func TestApplySelinuxLevel(t *testing.T) {
+	meta := &metatypes.SandboxMeta{ProcessLabel: "system_u:system_r:container_t:s0:c1,c2"}
+
+	hc := &apitypes.HostConfig{}
+	applySelinuxLevel(meta, hc)
+	assert.Equal(t, []string{"label=level:s0:c1,c2"}, hc.SecurityOpt)
+
+	// the level of container is kept.
+	hc = &apitypes.HostConfig{SecurityOpt: []string{"label=level:s0:c5,c6"}}
+	applySelinuxLevel(meta, hc)
+	assert.Equal(t, []string{"label=level:s0:c5,c6"}, hc.SecurityOpt)
+
+	hc = &apitypes.HostConfig{SecurityOpt: []string{"label=disable"}}
+	applySelinuxLevel(meta, hc)
+	assert.Equal(t, []string{"label=disable"}, hc.SecurityOpt)
+
+	// nothing is applied without the labels of pod.
+	hc = &apitypes.HostConfig{}
+	applySelinuxLevel(&metatypes.SandboxMeta{}, hc)
+	assert.Empty(t, hc.SecurityOpt)
+}
+
+func TestAllocateSelinuxLabels(t *testing.T) {
+	homeDir, err := ioutil.TempDir("", "selinux")
+	assert.NoError(t, err)
+	defer os.RemoveAll(homeDir)
+
+	store, err := newSandboxStore(homeDir)
+	assert.NoError(t, err)
+	defer store.Shutdown()
+	c := &CriManager{SandboxStore: store}
+
+	oldEnforcing, oldInit := selinuxEnforcing, initSelinuxLabels
+	defer func() { selinuxEnforcing, initSelinuxLabels = oldEnforcing, oldInit }()
+
+	var options []string
+	initSelinuxLabels = func(opts []string) (string, string, error) {
+		options = opts
+		return "system_u:system_r:container_t:s0:c1,c2", "system_u:object_r:container_file_t:s0:c1,c2", nil
+	}
+	config := &runtime.PodSandboxConfig{
+		Linux: &runtime.LinuxPodSandboxConfig{
+			SecurityContext: &runtime.LinuxSandboxSecurityContext{
+				SelinuxOptions: &runtime.SELinuxOption{Type: "container_t"},
+			},
+		},
+	}
+
+	// no labels are allocated unless SELinux is enforcing.
+	selinuxEnforcing = func() bool { return false }
+	meta := &metatypes.SandboxMeta{ID: "s1"}
+	assert.NoError(t, c.allocateSelinuxLabels(meta, config))
+	assert.Equal(t, "", meta.ProcessLabel)
+
+	selinuxEnforcing = func() bool { return true }
+	assert.NoError(t, c.allocateSelinuxLabels(meta, config))
+	assert.Equal(t, []string{"type:container_t"}, options)
+	assert.Equal(t, "system_u:system_r:container_t:s0:c1,c2", meta.ProcessLabel)
+	assert.Equal(t, "system_u:object_r:container_file_t:s0:c1,c2", meta.MountLabel)
+
+	// the labels stored with the meta are restored on the restart of pouchd.
+	assert.NoError(t, store.Put(meta))
+	res, err := store.Get("s1")
+	assert.NoError(t, err)
+	assert.Equal(t, meta.MountLabel, res.(*metatypes.SandboxMeta).MountLabel)
+	assert.NoError(t, c.restoreSelinuxLabels(context.Background()))
+}
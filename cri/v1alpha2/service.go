@@ -2,41 +2,79 @@ package v1alpha2
 
 import (
 	"context"
+	"crypto/tls"
+	"net"
 	"path"
+	"time"
 
 	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+	criconfig "github.com/alibaba/pouch/cri/config"
 	"github.com/alibaba/pouch/cri/metrics"
 	"github.com/alibaba/pouch/daemon/config"
 	"github.com/alibaba/pouch/pkg/grpc/interceptor"
 	"github.com/alibaba/pouch/pkg/netutils"
 
 	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
+)
+
+const (
+	criRuntimeServiceName = "runtime.v1alpha2.RuntimeService"
+	criImageServiceName   = "runtime.v1alpha2.ImageService"
 )
 
 // Service serves the kubelet runtime grpc api which will be consumed by kubelet.
 type Service struct {
-	config *config.Config
-	server *grpc.Server
+	config    *config.Config
+	server    *grpc.Server
+	tlsConfig *tls.Config
 }
 
 // NewService creates a brand new cri service.
 func NewService(cfg *config.Config, criMgr CriMgr) (*Service, error) {
 	s := &Service{
 		config: cfg,
-		server: grpc.NewServer(
-			grpc.StreamInterceptor(metrics.GRPCMetrics.StreamServerInterceptor()),
-			interceptor.WithUnaryServerChain(
-				metrics.GRPCMetrics.UnaryServerInterceptor(),
-				interceptor.PayloadUnaryServerInterceptor(criLogLevelDecider),
-			),
-		),
 	}
 
+	tlsConfig, err := criTLSConfig(&cfg.CriConfig)
+	if err != nil {
+		return nil, err
+	}
+	s.tlsConfig = tlsConfig
+
+	limits, err := parseMethodConcurrency(cfg.CriConfig.MethodConcurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := append(grpcServerOptions(&cfg.CriConfig),
+		grpc.StreamInterceptor(metrics.GRPCMetrics.StreamServerInterceptor()),
+		interceptor.WithUnaryServerChain(
+			metrics.GRPCMetrics.UnaryServerInterceptor(),
+			interceptor.RequestIDUnaryServerInterceptor(),
+			shutdownUnaryServerInterceptor(criMgr),
+			requestFieldsUnaryServerInterceptor(criMgr),
+			eventsUnaryServerInterceptor(criMgr),
+			auditUnaryServerInterceptor(criMgr),
+			authzUnaryServerInterceptor(criMgr),
+			newMethodThrottle(limits).unaryServerInterceptor(),
+			debugDumpUnaryServerInterceptor(criMgr),
+			interceptor.PayloadUnaryServerInterceptor(criLogLevelDecider),
+		),
+	)
+	s.server = grpc.NewServer(opts...)
+
 	runtime.RegisterRuntimeServiceServer(s.server, criMgr)
 	runtime.RegisterImageServiceServer(s.server, criMgr)
 	runtime.RegisterVolumeServiceServer(s.server, criMgr)
 
+	// the health service lets the standard tools, e.g. grpc_health_probe,
+	// probe the cri service.
+	healthpb.RegisterHealthServer(s.server, &healthServer{criMgr: criMgr})
+
 	// EnableHandlingTimeHistogram turns on recording of handling time
 	// of RPCs. Histogram metrics can be very expensive for Prometheus
 	// to retain and query.
@@ -53,8 +91,57 @@ func (s *Service) Serve() error {
 	if err != nil {
 		return err
 	}
+	// the credentials of callers on the unix socket are recorded in the audit
+	// log and sent to the authorization webhook.
+	if s.config.CriConfig.AuditLogPath != "" || s.config.CriConfig.AuthzWebhook != "" {
+		l = &peerCredListener{Listener: l}
+	}
+	if s.tlsConfig == nil {
+		return s.server.Serve(l)
+	}
+
+	// the remote kubelet connects to the tcp address with mutual TLS.
+	tl, err := netutils.GetListener("tcp://"+s.config.CriConfig.TCPListen, s.tlsConfig)
+	if err != nil {
+		l.Close()
+		return err
+	}
+
+	// both listeners are stopped once either of them fails, the server closes
+	// all its listeners when it's stopped.
+	var g errgroup.Group
+	for _, lis := range []net.Listener{tl, l} {
+		lis := lis
+		g.Go(func() error {
+			err := s.server.Serve(lis)
+			if err != nil {
+				s.server.Stop()
+			}
+			return err
+		})
+	}
+	return g.Wait()
+}
 
-	return s.server.Serve(l)
+// grpcServerOptions returns the options of the cri grpc server in the cri config.
+func grpcServerOptions(cfg *criconfig.Config) []grpc.ServerOption {
+	var opts []grpc.ServerOption
+	if cfg.MaxRecvMsgSize > 0 {
+		opts = append(opts, grpc.MaxRecvMsgSize(cfg.MaxRecvMsgSize))
+	}
+	if cfg.MaxSendMsgSize > 0 {
+		opts = append(opts, grpc.MaxSendMsgSize(cfg.MaxSendMsgSize))
+	}
+	if cfg.MaxConcurrentStreams > 0 {
+		opts = append(opts, grpc.MaxConcurrentStreams(cfg.MaxConcurrentStreams))
+	}
+	if cfg.KeepaliveTime > 0 || cfg.KeepaliveTimeout > 0 {
+		opts = append(opts, grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:    time.Duration(cfg.KeepaliveTime) * time.Second,
+			Timeout: time.Duration(cfg.KeepaliveTimeout) * time.Second,
+		}))
+	}
+	return opts
 }
 
 func criLogLevelDecider(ctx context.Context, fullMethodName string, servingObject interface{}) logrus.Level {
@@ -75,7 +162,8 @@ func criLogLevelDecider(ctx context.Context, fullMethodName string, servingObjec
 		"Status",
 		"ListImages",
 		"ImageStatus",
-		"ImageFsInfo":
+		"ImageFsInfo",
+		"Check":
 		return logrus.DebugLevel
 	default:
 		return logrus.InfoLevel
@@ -0,0 +1,180 @@
+package v1alpha2
+
+import (
+	"context"
+	"path"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/alibaba/pouch/pkg/log"
+	"github.com/alibaba/pouch/pkg/meta"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// requestTracker tracks the in-flight cri requests, so that pouchd could wait
+// for them to finish or roll back before it exits.
+type requestTracker struct {
+	mu       sync.Mutex
+	closed   bool
+	wg       sync.WaitGroup
+	inflight int32
+}
+
+// enter tracks a new request, false if the tracker is closed.
+func (t *requestTracker) enter() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed {
+		return false
+	}
+	t.wg.Add(1)
+	atomic.AddInt32(&t.inflight, 1)
+	return true
+}
+
+// closing returns whether the tracker is closed.
+func (t *requestTracker) closing() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.closed
+}
+
+// leave untracks the finished request.
+func (t *requestTracker) leave() {
+	atomic.AddInt32(&t.inflight, -1)
+	t.wg.Done()
+}
+
+// close rejects the new requests and waits for the in-flight ones until the
+// timeout, and returns the number of requests still in flight.
+func (t *requestTracker) close(timeout time.Duration) int {
+	t.mu.Lock()
+	t.closed = true
+	t.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		t.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return 0
+	case <-time.After(timeout):
+		return int(atomic.LoadInt32(&t.inflight))
+	}
+}
+
+// workerGroup runs the background workers of cri, e.g. the retries of network
+// teardown and the prefetches, which are stopped before the stores are closed.
+type workerGroup struct {
+	mu     sync.Mutex
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// init creates the context of workers, the caller must hold the lock.
+func (g *workerGroup) init() {
+	if g.ctx == nil {
+		g.ctx, g.cancel = context.WithCancel(context.Background())
+	}
+}
+
+// run runs the worker in background until its context is canceled by stop,
+// the worker is not run once the group is stopped.
+func (g *workerGroup) run(worker func(ctx context.Context)) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.init()
+	if g.ctx.Err() != nil {
+		return
+	}
+
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		worker(g.ctx)
+	}()
+}
+
+// stop cancels the workers and waits for them until the timeout, and returns
+// false if any of them is still running.
+func (g *workerGroup) stop(timeout time.Duration) bool {
+	g.mu.Lock()
+	g.init()
+	g.cancel()
+	g.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		g.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+// shutdownUnaryServerInterceptor returns a unary server interceptor which
+// tracks the in-flight requests and rejects the new ones once shutting down.
+func shutdownUnaryServerInterceptor(criMgr CriMgr) grpc.UnaryServerInterceptor {
+	c, _ := criMgr.(*CriManager)
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if c == nil {
+			return handler(ctx, req)
+		}
+		if !c.requests.enter() {
+			return nil, status.Errorf(codes.Unavailable, "pouchd is shutting down, %s is rejected", path.Base(info.FullMethod))
+		}
+		defer c.requests.leave()
+
+		return handler(ctx, req)
+	}
+}
+
+// Shutdown stops accepting new cri requests, waits for the in-flight ones,
+// e.g. RunPodSandbox and PullImage, to finish or roll back until the shutdown
+// timeout, stops the background workers, and then flushes the stores, instead
+// of leaving half-created pods. The stores are left open if the requests or
+// workers are still running after the timeout, since they are still in use.
+func (c *CriManager) Shutdown() error {
+	log.With(nil).Infof("waiting for the in-flight cri requests to finish")
+	n := c.requests.close(c.shutdownTimeout)
+	if n > 0 {
+		log.With(nil).Warnf("%d cri requests are still in flight after %s", n, c.shutdownTimeout)
+	}
+	stopped := c.workers.stop(c.shutdownTimeout)
+	if !stopped {
+		log.With(nil).Warnf("cri background workers are still running after %s", c.shutdownTimeout)
+	}
+	if n > 0 || !stopped {
+		log.With(nil).Warnf("cri stores are left open since they are still in use")
+		return nil
+	}
+
+	var err error
+	for _, store := range []*meta.Store{c.SandboxStore, c.NetworkTeardownStore} {
+		if store == nil {
+			continue
+		}
+		if e := store.Shutdown(); e != nil {
+			err = e
+		}
+	}
+	if c.auditLog != nil {
+		if e := c.auditLog.close(); e != nil {
+			err = e
+		}
+	}
+	return err
+}
@@ -0,0 +1,125 @@
+package v1alpha2
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRequestTracker(t *testing.T) {
+	tracker := &requestTracker{}
+	assert.True(t, tracker.enter())
+	assert.True(t, tracker.enter())
+	tracker.leave()
+
+	// the request still in flight is given up after the timeout.
+	assert.Equal(t, 1, tracker.close(10*time.Millisecond))
+	assert.False(t, tracker.enter())
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		tracker.leave()
+	}()
+	assert.Equal(t, 0, tracker.close(time.Second))
+}
+
+func TestWorkerGroup(t *testing.T) {
+	var workers workerGroup
+	block := make(chan struct{})
+	workers.run(func(ctx context.Context) {
+		<-ctx.Done()
+	})
+	workers.run(func(ctx context.Context) {
+		<-block
+	})
+
+	// the worker ignoring the cancellation is given up after the timeout.
+	assert.False(t, workers.stop(10*time.Millisecond))
+	close(block)
+	assert.True(t, workers.stop(time.Second))
+
+	// no worker is run once stopped.
+	ran := false
+	workers.run(func(ctx context.Context) { ran = true })
+	assert.True(t, workers.stop(time.Second))
+	assert.False(t, ran)
+}
+
+func TestShutdownLeavesStoresInUse(t *testing.T) {
+	homeDir, err := ioutil.TempDir("", "shutdown")
+	assert.NoError(t, err)
+	defer os.RemoveAll(homeDir)
+
+	store, err := newSandboxStore(homeDir)
+	assert.NoError(t, err)
+	defer store.Shutdown()
+	c := &CriManager{SandboxStore: store, shutdownTimeout: 10 * time.Millisecond}
+	block := make(chan struct{})
+	defer close(block)
+	c.workers.run(func(ctx context.Context) {
+		<-block
+	})
+
+	// the store is still used by the worker not stopped.
+	assert.NoError(t, c.Shutdown())
+	_, err = store.List()
+	assert.NoError(t, err)
+}
+
+func TestShutdownUnaryServerInterceptor(t *testing.T) {
+	homeDir, err := ioutil.TempDir("", "shutdown")
+	assert.NoError(t, err)
+	defer os.RemoveAll(homeDir)
+
+	store, err := newSandboxStore(homeDir)
+	assert.NoError(t, err)
+	c := &CriManager{SandboxStore: store, shutdownTimeout: time.Second}
+	interceptor := shutdownUnaryServerInterceptor(c)
+	info := &grpc.UnaryServerInfo{FullMethod: "/runtime.v1alpha2.RuntimeService/RunPodSandbox"}
+
+	started, finish := make(chan struct{}), make(chan struct{})
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := interceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			close(started)
+			<-finish
+			return nil, nil
+		})
+		errCh <- err
+	}()
+	<-started
+
+	shutdownCh := make(chan error, 1)
+	go func() { shutdownCh <- c.Shutdown() }()
+
+	// the in-flight request is waited for, and the new ones are rejected.
+	var rejected bool
+	for i := 0; i < 200 && !rejected; i++ {
+		_, err := interceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, nil
+		})
+		rejected = status.Code(err) == codes.Unavailable
+		time.Sleep(5 * time.Millisecond)
+	}
+	assert.True(t, rejected)
+	select {
+	case <-shutdownCh:
+		t.Fatal("shutdown should wait for the in-flight request")
+	default:
+	}
+
+	close(finish)
+	assert.NoError(t, <-errCh)
+	assert.NoError(t, <-shutdownCh)
+
+	// the sandbox store is closed.
+	_, err = store.List()
+	assert.Error(t, err)
+}
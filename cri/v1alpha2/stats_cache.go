@@ -0,0 +1,86 @@
+package v1alpha2
+
+import (
+	"sync"
+	"time"
+
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+	"github.com/alibaba/pouch/cri/metrics"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// statsCache caches the responses of ListContainerStats for a short time, keyed
+// by the filter, so that the concurrent stats consumers like kubelet, metrics
+// server and crictl do not each trigger a full collection. The concurrent
+// misses of the same filter are coalesced into a single collection.
+type statsCache struct {
+	ttl   time.Duration
+	group singleflight.Group
+
+	mu      sync.Mutex
+	entries map[string]*statsCacheEntry
+}
+
+type statsCacheEntry struct {
+	resp     *runtime.ListContainerStatsResponse
+	expireAt time.Time
+}
+
+// newStatsCache returns nil if the ttl is not positive, which means no cache.
+func newStatsCache(ttl time.Duration) *statsCache {
+	if ttl <= 0 {
+		return nil
+	}
+	return &statsCache{
+		ttl:     ttl,
+		entries: make(map[string]*statsCacheEntry),
+	}
+}
+
+// get returns the cached response of the key, or the one collected by collect.
+func (sc *statsCache) get(key string, collect func() (*runtime.ListContainerStatsResponse, error)) (*runtime.ListContainerStatsResponse, error) {
+	if sc == nil {
+		return collect()
+	}
+
+	sc.mu.Lock()
+	entry, ok := sc.entries[key]
+	sc.mu.Unlock()
+	if ok && time.Now().Before(entry.expireAt) {
+		metrics.StatsCacheCounter.WithLabelValues("hit").Inc()
+		return entry.resp, nil
+	}
+
+	v, err, shared := sc.group.Do(key, func() (interface{}, error) {
+		resp, err := collect()
+		if err != nil {
+			return nil, err
+		}
+		sc.put(key, resp)
+		return resp, nil
+	})
+	if shared {
+		metrics.StatsCacheCounter.WithLabelValues("hit").Inc()
+	} else {
+		metrics.StatsCacheCounter.WithLabelValues("miss").Inc()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return v.(*runtime.ListContainerStatsResponse), nil
+}
+
+// put caches the response and drops the expired ones.
+func (sc *statsCache) put(key string, resp *runtime.ListContainerStatsResponse) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	now := time.Now()
+	for k, entry := range sc.entries {
+		if !now.Before(entry.expireAt) {
+			delete(sc.entries, k)
+		}
+	}
+	sc.entries[key] = &statsCacheEntry{resp: resp, expireAt: now.Add(sc.ttl)}
+}
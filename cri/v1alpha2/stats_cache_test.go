@@ -0,0 +1,84 @@
+package v1alpha2
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatsCache(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		calls int
+	)
+	collect := func() (*runtime.ListContainerStatsResponse, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls++
+		return &runtime.ListContainerStatsResponse{}, nil
+	}
+
+	// no cache.
+	var sc *statsCache
+	assert.Nil(t, newStatsCache(0))
+	_, err := sc.get("k", collect)
+	assert.NoError(t, err)
+	_, err = sc.get("k", collect)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+
+	// the responses are cached by the key.
+	calls = 0
+	sc = newStatsCache(time.Hour)
+	resp, err := sc.get("k1", collect)
+	assert.NoError(t, err)
+	cached, err := sc.get("k1", collect)
+	assert.NoError(t, err)
+	assert.True(t, resp == cached)
+	_, err = sc.get("k2", collect)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+
+	// the expired ones are collected again and dropped.
+	sc.entries["k1"].expireAt = time.Now().Add(-time.Second)
+	sc.entries["k2"].expireAt = time.Now().Add(-time.Second)
+	_, err = sc.get("k1", collect)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+	assert.Len(t, sc.entries, 1)
+
+	// the errors are not cached.
+	_, err = sc.get("err", func() (*runtime.ListContainerStatsResponse, error) {
+		return nil, fmt.Errorf("failed")
+	})
+	assert.Error(t, err)
+	_, exist := sc.entries["err"]
+	assert.False(t, exist)
+
+	// the concurrent misses are coalesced.
+	calls = 0
+	sc = newStatsCache(time.Hour)
+	release := make(chan struct{})
+	slowCollect := func() (*runtime.ListContainerStatsResponse, error) {
+		<-release
+		return collect()
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := sc.get("k", slowCollect)
+			assert.NoError(t, err)
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	assert.True(t, calls >= 1 && calls < 5)
+}
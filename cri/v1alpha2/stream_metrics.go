@@ -0,0 +1,153 @@
+package v1alpha2
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"time"
+
+	apitypes "github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/cri/metrics"
+	"github.com/alibaba/pouch/cri/stream"
+	"github.com/alibaba/pouch/cri/stream/remotecommand"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// streamTypeExec is the metrics label of exec sessions.
+	streamTypeExec = "exec"
+	// streamTypeAttach is the metrics label of attach sessions.
+	streamTypeAttach = "attach"
+	// streamTypePortForward is the metrics label of port forward sessions.
+	streamTypePortForward = "portforward"
+	// streamTypeCopy is the metrics label of copy sessions.
+	streamTypeCopy = "copy"
+
+	// streamDirectionIn is the direction from client to container.
+	streamDirectionIn = "in"
+	// streamDirectionOut is the direction from container to client.
+	streamDirectionOut = "out"
+)
+
+// trackStreamSession records the start of a stream session, the returned function
+// should be called when the session ends.
+func trackStreamSession(streamType string) func() {
+	start := time.Now()
+	metrics.StreamSessionsCounter.WithLabelValues(streamType).Inc()
+	metrics.StreamSessionsGauge.WithLabelValues(streamType).Inc()
+	return func() {
+		metrics.StreamSessionsGauge.WithLabelValues(streamType).Dec()
+		metrics.StreamSessionTimer.WithLabelValues(streamType).Observe(time.Since(start).Seconds())
+	}
+}
+
+// meteredReadCloser counts the bytes read.
+type meteredReadCloser struct {
+	io.ReadCloser
+	counter prometheus.Counter
+}
+
+func (r *meteredReadCloser) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.counter.Add(float64(n))
+	return n, err
+}
+
+// meteredWriteCloser counts the bytes written.
+type meteredWriteCloser struct {
+	io.WriteCloser
+	counter prometheus.Counter
+}
+
+func (w *meteredWriteCloser) Write(p []byte) (int, error) {
+	n, err := w.WriteCloser.Write(p)
+	w.counter.Add(float64(n))
+	return n, err
+}
+
+// meteredReadWriteCloser counts the bytes read and written.
+type meteredReadWriteCloser struct {
+	io.ReadWriteCloser
+	in  prometheus.Counter
+	out prometheus.Counter
+}
+
+func (rw *meteredReadWriteCloser) Read(p []byte) (int, error) {
+	n, err := rw.ReadWriteCloser.Read(p)
+	rw.in.Add(float64(n))
+	return n, err
+}
+
+func (rw *meteredReadWriteCloser) Write(p []byte) (int, error) {
+	n, err := rw.ReadWriteCloser.Write(p)
+	rw.out.Add(float64(n))
+	return n, err
+}
+
+// meterStreams wraps the streams of remote command to count the bytes transferred.
+func meterStreams(streamType string, streams *remotecommand.Streams) *remotecommand.Streams {
+	in := metrics.StreamBytesCounter.WithLabelValues(streamType, streamDirectionIn)
+	out := metrics.StreamBytesCounter.WithLabelValues(streamType, streamDirectionOut)
+
+	metered := &remotecommand.Streams{}
+	if streams.StdinStream != nil {
+		metered.StdinStream = &meteredReadCloser{ReadCloser: streams.StdinStream, counter: in}
+	}
+	if streams.StdoutStream != nil {
+		metered.StdoutStream = &meteredWriteCloser{WriteCloser: streams.StdoutStream, counter: out}
+	}
+	if streams.StderrStream != nil {
+		metered.StderrStream = &meteredWriteCloser{WriteCloser: streams.StderrStream, counter: out}
+	}
+	return metered
+}
+
+// meteredRuntime records the bytes transferred by the stream runtime.
+type meteredRuntime struct {
+	stream.Runtime
+}
+
+// newMeteredRuntime wraps the stream runtime to record the bytes transferred.
+func newMeteredRuntime(runtime stream.Runtime) stream.Runtime {
+	return &meteredRuntime{Runtime: runtime}
+}
+
+// Exec executes the command in container with the metered streams.
+func (m *meteredRuntime) Exec(ctx context.Context, containerID string, cmd []string, resizeChan <-chan apitypes.ResizeOptions, streamOpts *remotecommand.Options, streams *remotecommand.Streams) (uint32, error) {
+	return m.Runtime.Exec(ctx, containerID, cmd, resizeChan, streamOpts, meterStreams(streamTypeExec, streams))
+}
+
+// Attach attaches to container with the metered streams.
+func (m *meteredRuntime) Attach(ctx context.Context, containerID string, resizeChan <-chan apitypes.ResizeOptions, streamOpts *remotecommand.Options, streams *remotecommand.Streams) error {
+	return m.Runtime.Attach(ctx, containerID, resizeChan, streamOpts, meterStreams(streamTypeAttach, streams))
+}
+
+// PortForward forwards the port of pod with the metered stream.
+func (m *meteredRuntime) PortForward(ctx context.Context, name string, port int32, s io.ReadWriteCloser) error {
+	return m.Runtime.PortForward(ctx, name, port, &meteredReadWriteCloser{
+		ReadWriteCloser: s,
+		in:              metrics.StreamBytesCounter.WithLabelValues(streamTypePortForward, streamDirectionIn),
+		out:             metrics.StreamBytesCounter.WithLabelValues(streamTypePortForward, streamDirectionOut),
+	})
+}
+
+// CopyFromContainer returns the metered tar archive of the path in container.
+func (m *meteredRuntime) CopyFromContainer(ctx context.Context, containerID string, path string) (io.ReadCloser, error) {
+	content, err := m.Runtime.CopyFromContainer(ctx, containerID, path)
+	if err != nil {
+		return nil, err
+	}
+	return &meteredReadCloser{
+		ReadCloser: content,
+		counter:    metrics.StreamBytesCounter.WithLabelValues(streamTypeCopy, streamDirectionOut),
+	}, nil
+}
+
+// CopyToContainer extracts the metered tar archive into the path in container.
+func (m *meteredRuntime) CopyToContainer(ctx context.Context, containerID string, path string, opts *stream.CopyOptions, content io.Reader) error {
+	return m.Runtime.CopyToContainer(ctx, containerID, path, opts, &meteredReadCloser{
+		ReadCloser: ioutil.NopCloser(content),
+		counter:    metrics.StreamBytesCounter.WithLabelValues(streamTypeCopy, streamDirectionIn),
+	})
+}
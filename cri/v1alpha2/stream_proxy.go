@@ -0,0 +1,60 @@
+package v1alpha2
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/alibaba/pouch/pkg/log"
+)
+
+const (
+	headerForwardedFor    = "X-Forwarded-For"
+	headerForwardedProto  = "X-Forwarded-Proto"
+	headerForwardedPrefix = "X-Forwarded-Prefix"
+)
+
+// stripPathPrefix removes the prefix from the path of requests, which is the
+// path of the advertised base url kept by the reverse proxy.
+func stripPathPrefix(prefix string, h http.Handler) http.Handler {
+	prefix = strings.TrimSuffix(prefix, "/")
+	if prefix == "" {
+		return h
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		trimPathPrefix(r, prefix)
+		h.ServeHTTP(w, r)
+	})
+}
+
+// trimPathPrefix removes the prefix from the path of request if it has.
+func trimPathPrefix(r *http.Request, prefix string) {
+	if p := strings.TrimPrefix(r.URL.Path, prefix); p != r.URL.Path && strings.HasPrefix(p, "/") {
+		r.URL.Path = p
+		r.URL.RawPath = ""
+	}
+}
+
+// proxyHeaders takes the client address, scheme and path prefix of requests
+// from the X-Forwarded headers set by the reverse proxy.
+func proxyHeaders(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the first one is the original client, the others are the proxies.
+		if fwd := r.Header.Get(headerForwardedFor); fwd != "" {
+			client := strings.TrimSpace(strings.Split(fwd, ",")[0])
+			if net.ParseIP(client) != nil {
+				r.RemoteAddr = net.JoinHostPort(client, "0")
+			}
+		}
+		if proto := strings.ToLower(r.Header.Get(headerForwardedProto)); proto == "http" || proto == "https" {
+			r.URL.Scheme = proto
+		}
+		if prefix := strings.TrimSuffix(r.Header.Get(headerForwardedPrefix), "/"); prefix != "" {
+			trimPathPrefix(r, prefix)
+		}
+
+		log.With(r.Context()).Debugf("stream request %s %s from %s", r.Method, r.URL.Path, r.RemoteAddr)
+		h.ServeHTTP(w, r)
+	})
+}
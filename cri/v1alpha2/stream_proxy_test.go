@@ -0,0 +1,55 @@
+package v1alpha2
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStripPathPrefix(t *testing.T) {
+	var path string
+	h := stripPathPrefix("/nodes/node1/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+	}))
+
+	for _, tc := range []struct {
+		path     string
+		expected string
+	}{
+		{"/nodes/node1/exec/token", "/exec/token"},
+		// the proxy has stripped the prefix.
+		{"/exec/token", "/exec/token"},
+		{"/nodes/node10/exec/token", "/nodes/node10/exec/token"},
+	} {
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, tc.path, nil))
+		assert.Equal(t, tc.expected, path)
+	}
+}
+
+func TestProxyHeaders(t *testing.T) {
+	var got *http.Request
+	h := proxyHeaders(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/gateway/exec/token", nil)
+	r.Header.Set(headerForwardedFor, "10.0.0.1, 192.168.0.1")
+	r.Header.Set(headerForwardedProto, "https")
+	r.Header.Set(headerForwardedPrefix, "/gateway")
+	h.ServeHTTP(httptest.NewRecorder(), r)
+	assert.Equal(t, "10.0.0.1:0", got.RemoteAddr)
+	assert.Equal(t, "https", got.URL.Scheme)
+	assert.Equal(t, "/exec/token", got.URL.Path)
+
+	// the invalid headers are ignored.
+	r = httptest.NewRequest(http.MethodGet, "/exec/token", nil)
+	remoteAddr := r.RemoteAddr
+	r.Header.Set(headerForwardedFor, "unknown")
+	r.Header.Set(headerForwardedProto, "ftp")
+	h.ServeHTTP(httptest.NewRecorder(), r)
+	assert.Equal(t, remoteAddr, got.RemoteAddr)
+	assert.Equal(t, "", got.URL.Scheme)
+	assert.Equal(t, "/exec/token", got.URL.Path)
+}
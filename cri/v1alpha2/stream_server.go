@@ -1,14 +1,19 @@
 package v1alpha2
 
 import (
+	"io"
 	"net/http"
 	"net/url"
 	"path"
+	"sync/atomic"
+	"time"
 
 	runtimeapi "github.com/alibaba/pouch/cri/apis/v1alpha2"
 	"github.com/alibaba/pouch/cri/stream"
 	"github.com/alibaba/pouch/cri/stream/portforward"
 	"github.com/alibaba/pouch/cri/stream/remotecommand"
+	metatypes "github.com/alibaba/pouch/cri/v1alpha2/types"
+	"github.com/alibaba/pouch/pkg/log"
 
 	"github.com/gorilla/mux"
 	"google.golang.org/grpc"
@@ -26,9 +31,15 @@ type StreamServer interface {
 	// GetPortForward get the serving URL for PortForward request.
 	GetPortForward(*runtimeapi.PortForwardRequest) (*runtimeapi.PortForwardResponse, error)
 
+	// GetCopy get the serving URL for Copy request.
+	GetCopy(*metatypes.CopyRequest) (*metatypes.CopyResponse, error)
+
 	// Start starts the stream server.
 	Start() error
 
+	// SetStreamIdleTimeout changes the idle timeout of the stream connections created afterwards.
+	SetStreamIdleTimeout(timeout time.Duration)
+
 	// Router is the Stream Server's handlers which we should export.
 	stream.Router
 }
@@ -38,14 +49,19 @@ type server struct {
 	runtime stream.Runtime
 	cache   *stream.RequestCache
 	server  *http.Server
+
+	// idleTimeout is the idle timeout of stream connections which could be reloaded.
+	idleTimeout int64
 }
 
 // NewStreamServer creates a new stream server.
 func NewStreamServer(config stream.Config, runtime stream.Runtime) (StreamServer, error) {
 	s := &server{
 		config:  config,
-		runtime: runtime,
+		runtime: newMeteredRuntime(runtime),
 		cache:   stream.NewRequestCache(),
+
+		idleTimeout: int64(config.StreamIdleTimeout),
 	}
 
 	endpoints := []struct {
@@ -55,6 +71,7 @@ func NewStreamServer(config stream.Config, runtime stream.Runtime) (StreamServer
 		{"/exec/{token}", s.ServeExec},
 		{"/attach/{token}", s.ServeAttach},
 		{"/portforward/{token}", s.ServePortForward},
+		{"/copy/{token}", s.ServeCopy},
 	}
 
 	r := mux.NewRouter()
@@ -64,9 +81,18 @@ func NewStreamServer(config stream.Config, runtime stream.Runtime) (StreamServer
 		}
 	}
 
+	// the path of advertised base url is kept by the reverse proxy or NAT.
+	var handler http.Handler = r
+	if config.BaseURL != nil {
+		handler = stripPathPrefix(config.BaseURL.Path, handler)
+	}
+	if config.BehindProxy {
+		handler = proxyHeaders(handler)
+	}
+
 	s.server = &http.Server{
 		Addr:    s.config.Address,
-		Handler: r,
+		Handler: handler,
 	}
 
 	return s, nil
@@ -77,6 +103,15 @@ func (s *server) Start() error {
 	return s.server.ListenAndServe()
 }
 
+// SetStreamIdleTimeout changes the idle timeout of the stream connections created afterwards.
+func (s *server) SetStreamIdleTimeout(timeout time.Duration) {
+	atomic.StoreInt64(&s.idleTimeout, int64(timeout))
+}
+
+func (s *server) streamIdleTimeout() time.Duration {
+	return time.Duration(atomic.LoadInt64(&s.idleTimeout))
+}
+
 func (s *server) ServeExec(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -92,6 +127,7 @@ func (s *server) ServeExec(w http.ResponseWriter, r *http.Request) {
 		http.NotFound(w, r)
 		return
 	}
+	defer trackStreamSession(streamTypeExec)()
 
 	streamOpts := &remotecommand.Options{
 		Stdin:  exec.Stdin,
@@ -108,7 +144,7 @@ func (s *server) ServeExec(w http.ResponseWriter, r *http.Request) {
 		exec.Cmd,
 		streamOpts,
 		s.config.SupportedRemoteCommandProtocols,
-		s.config.StreamIdleTimeout,
+		s.streamIdleTimeout(),
 		s.config.StreamCreationTimeout,
 	)
 }
@@ -127,6 +163,7 @@ func (s *server) ServeAttach(w http.ResponseWriter, r *http.Request) {
 		http.NotFound(w, r)
 		return
 	}
+	defer trackStreamSession(streamTypeAttach)()
 
 	streamOpts := &remotecommand.Options{
 		Stdin:  attach.Stdin,
@@ -141,7 +178,7 @@ func (s *server) ServeAttach(w http.ResponseWriter, r *http.Request) {
 		s.runtime,
 		attach.ContainerId,
 		streamOpts,
-		s.config.StreamIdleTimeout,
+		s.streamIdleTimeout(),
 		s.config.StreamCreationTimeout,
 		s.config.SupportedRemoteCommandProtocols,
 	)
@@ -161,6 +198,7 @@ func (s *server) ServePortForward(w http.ResponseWriter, r *http.Request) {
 		http.NotFound(w, r)
 		return
 	}
+	defer trackStreamSession(streamTypePortForward)()
 
 	portforward.ServePortForward(
 		ctx,
@@ -168,12 +206,60 @@ func (s *server) ServePortForward(w http.ResponseWriter, r *http.Request) {
 		r,
 		s.runtime,
 		pf.PodSandboxId,
-		s.config.StreamIdleTimeout,
+		s.streamIdleTimeout(),
 		s.config.StreamCreationTimeout,
 		s.config.SupportedPortForwardProtocols,
 	)
 }
 
+// ServeCopy downloads the tar archive of the path in container for GET, or
+// extracts the tar archive in the body into the path in container for POST.
+func (s *server) ServeCopy(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	token := mux.Vars(r)["token"]
+	cachedRequest, ok := s.cache.Consume(token)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	cp, ok := cachedRequest.(*metatypes.CopyRequest)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	if (r.Method == http.MethodPost) != cp.ToContainer {
+		http.Error(w, "copy to container should be POST, and copy from container should be GET", http.StatusMethodNotAllowed)
+		return
+	}
+	defer trackStreamSession(streamTypeCopy)()
+
+	if cp.ToContainer {
+		opts := &stream.CopyOptions{
+			CopyUIDGID:           cp.CopyUIDGID,
+			NoOverwriteDirNonDir: cp.NoOverwriteDirNonDir,
+		}
+		if err := s.runtime.CopyToContainer(ctx, cp.ContainerID, cp.Path, opts, r.Body); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	content, err := s.runtime.CopyFromContainer(ctx, cp.ContainerID, cp.Path)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer content.Close()
+
+	w.Header().Set("Content-Type", "application/x-tar")
+	if _, err := io.Copy(w, content); err != nil {
+		log.With(ctx).Errorf("failed to copy %q from container %q: %v", cp.Path, cp.ContainerID, err)
+	}
+}
+
 func (s *server) buildURL(method string, token string) string {
 	return s.config.BaseURL.ResolveReference(&url.URL{
 		Path: path.Join(method, token),
@@ -220,3 +306,21 @@ func (s *server) GetPortForward(req *runtimeapi.PortForwardRequest) (*runtimeapi
 		Url: s.buildURL("portforward", token),
 	}, nil
 }
+
+// GetCopy gets the serving URL for the Copy requests.
+func (s *server) GetCopy(req *metatypes.CopyRequest) (*metatypes.CopyResponse, error) {
+	if req.ContainerID == "" {
+		return nil, grpc.Errorf(codes.InvalidArgument, "missing required container id")
+	}
+	if req.Path == "" {
+		return nil, grpc.Errorf(codes.InvalidArgument, "missing required path")
+	}
+	token, err := s.cache.Insert(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &metatypes.CopyResponse{
+		URL: s.buildURL("copy", token),
+	}, nil
+}
@@ -0,0 +1,109 @@
+package v1alpha2
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	runtimeapi "github.com/alibaba/pouch/cri/apis/v1alpha2"
+	"github.com/alibaba/pouch/cri/stream"
+	metatypes "github.com/alibaba/pouch/cri/v1alpha2/types"
+	"github.com/alibaba/pouch/daemon/config"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// copyRuntime keeps the files copied in memory.
+type copyRuntime struct {
+	stream.Runtime
+	files map[string]string
+}
+
+func (r *copyRuntime) CopyFromContainer(ctx context.Context, containerID string, path string) (io.ReadCloser, error) {
+	return ioutil.NopCloser(strings.NewReader(r.files[containerID+path])), nil
+}
+
+func (r *copyRuntime) CopyToContainer(ctx context.Context, containerID string, path string, opts *stream.CopyOptions, content io.Reader) error {
+	data, err := ioutil.ReadAll(content)
+	r.files[containerID+path] = string(data)
+	return err
+}
+
+func TestServeCopy(t *testing.T) {
+	runtime := &copyRuntime{files: map[string]string{"c1/etc": "archive of /etc"}}
+	baseURL, _ := url.Parse("http://127.0.0.1:10010/")
+	s, err := NewStreamServer(stream.Config{BaseURL: baseURL}, runtime)
+	assert.NoError(t, err)
+	handler := s.(*server).server.Handler
+
+	serve := func(method, rawURL string, body io.Reader) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(method, rawURL, body))
+		return w
+	}
+
+	// the path is required.
+	_, err = s.GetCopy(&metatypes.CopyRequest{ContainerID: "c1"})
+	assert.Error(t, err)
+
+	resp, err := s.GetCopy(&metatypes.CopyRequest{ContainerID: "c1", Path: "/etc"})
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(resp.URL, "http://127.0.0.1:10010/copy/"))
+	w := serve(http.MethodGet, resp.URL, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "archive of /etc", w.Body.String())
+
+	// the token is used once.
+	w = serve(http.MethodGet, resp.URL, nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	resp, err = s.GetCopy(&metatypes.CopyRequest{ContainerID: "c1", Path: "/tmp", ToContainer: true})
+	assert.NoError(t, err)
+	w = serve(http.MethodPost, resp.URL, bytes.NewBufferString("archive of /tmp"))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "archive of /tmp", runtime.files["c1/tmp"])
+
+	// the direction should match the method.
+	resp, err = s.GetCopy(&metatypes.CopyRequest{ContainerID: "c1", Path: "/tmp", ToContainer: true})
+	assert.NoError(t, err)
+	w = serve(http.MethodGet, resp.URL, nil)
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+}
+
+func TestStreamServerBaseURL(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.CriConfig.StreamServerAddress = "0.0.0.0"
+	cfg.CriConfig.StreamServerPort = "10010"
+	streamCfg, err := toStreamConfig(cfg)
+	assert.NoError(t, err)
+	// listens on all the interfaces while a proper address is advertised.
+	assert.Equal(t, "0.0.0.0:10010", streamCfg.Address)
+	assert.NotEqual(t, "0.0.0.0:10010", streamCfg.BaseURL.Host)
+
+	cfg.CriConfig.StreamServerBaseURL = "gateway:8080"
+	_, err = toStreamConfig(cfg)
+	assert.Error(t, err)
+
+	cfg.CriConfig.StreamServerBaseURL = "https://gateway:8080/nodes/node1"
+	cfg.CriConfig.StreamServerBehindProxy = true
+	streamCfg, err = toStreamConfig(cfg)
+	assert.NoError(t, err)
+	assert.True(t, streamCfg.BehindProxy)
+
+	s, err := NewStreamServer(streamCfg, &copyRuntime{})
+	assert.NoError(t, err)
+	resp, err := s.GetAttach(&runtimeapi.AttachRequest{ContainerId: "c1"})
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(resp.Url, "https://gateway:8080/nodes/node1/attach/"))
+
+	// the unknown token is not found even with the prefix of base url.
+	w := httptest.NewRecorder()
+	s.(*server).server.Handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/nodes/node1/attach/unknown", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,275 @@
+package v1alpha2
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	anno "github.com/alibaba/pouch/cri/annotations"
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+
+	"golang.org/x/sys/unix"
+)
+
+// subPathsDir is the directory in sandbox root dir holding the bind mounts of
+// the subpaths of containers.
+const subPathsDir = "subpaths"
+
+// parseSubPaths parses the subpaths in the annotation of container, which maps
+// the container paths of mounts to the subpaths of their host paths.
+func parseSubPaths(annotations map[string]string) (map[string]string, error) {
+	value := strings.TrimSpace(annotations[anno.SubPathsAnnotation])
+	if value == "" {
+		return nil, nil
+	}
+
+	subPaths := make(map[string]string)
+	for _, e := range strings.Split(value, ",") {
+		parts := strings.SplitN(e, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid subpath %q in annotation %s, should be containerPath=subPath", e, anno.SubPathsAnnotation)
+		}
+
+		containerPath, subPath := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+		if containerPath == "" || subPath == "" {
+			return nil, fmt.Errorf("invalid subpath %q in annotation %s, should be containerPath=subPath", e, anno.SubPathsAnnotation)
+		}
+		if filepath.IsAbs(subPath) {
+			return nil, fmt.Errorf("subpath %q of %s should be relative", subPath, containerPath)
+		}
+		if cleaned := filepath.Clean(subPath); cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+			return nil, fmt.Errorf("subpath %q of %s should not reference the parent", subPath, containerPath)
+		}
+		subPaths[filepath.Clean(containerPath)] = filepath.Clean(subPath)
+	}
+	return subPaths, nil
+}
+
+// applySubPaths binds the subpaths of mounts into the directory of container
+// under the sandbox root dir, and returns the mounts whose host paths are the
+// bind mounts instead. The bind mounts are removed if it fails.
+func applySubPaths(sandboxRootDir, containerName string, mounts []*runtime.Mount, annotations map[string]string) (_ []*runtime.Mount, retErr error) {
+	subPaths, err := parseSubPaths(annotations)
+	if err != nil || len(subPaths) == 0 {
+		return mounts, err
+	}
+
+	dir := containerSubPathsDir(sandboxRootDir, containerName)
+	defer func() {
+		if retErr != nil {
+			if err := removeSubPaths(dir); err != nil {
+				retErr = fmt.Errorf("%v, and failed to remove subpaths: %v", retErr, err)
+			}
+		}
+	}()
+
+	result := make([]*runtime.Mount, 0, len(mounts))
+	for i, m := range mounts {
+		subPath, ok := subPaths[filepath.Clean(m.GetContainerPath())]
+		if !ok {
+			result = append(result, m)
+			continue
+		}
+		delete(subPaths, filepath.Clean(m.GetContainerPath()))
+
+		target := filepath.Join(dir, strconv.Itoa(i))
+		if err := bindSubPath(m.GetHostPath(), subPath, target); err != nil {
+			return nil, fmt.Errorf("failed to mount subpath %q of %s: %v", subPath, m.GetHostPath(), err)
+		}
+		mount := *m
+		mount.HostPath = target
+		result = append(result, &mount)
+	}
+
+	for containerPath := range subPaths {
+		return nil, fmt.Errorf("no mount at %s for subpath in annotation %s", containerPath, anno.SubPathsAnnotation)
+	}
+	return result, nil
+}
+
+// containerSubPathsDir returns the directory holding the bind mounts of the
+// subpaths of container.
+func containerSubPathsDir(sandboxRootDir, containerName string) string {
+	return filepath.Join(sandboxRootDir, subPathsDir, strings.TrimPrefix(containerName, "/"))
+}
+
+// containerSubPaths returns the directory of the subpaths of container, empty
+// if the container is not found.
+func (c *CriManager) containerSubPaths(ctx context.Context, containerID string) string {
+	container, err := c.ContainerMgr.Get(ctx, containerID)
+	if err != nil || container.Config == nil {
+		return ""
+	}
+	return containerSubPathsDir(path.Join(c.SandboxBaseDir, container.Config.Labels[sandboxIDLabelKey]), container.Name)
+}
+
+// bindSubPath binds the subpath of root to the target. The subpath resolved
+// must be in the root, and it's opened without following symlinks, so that a
+// symlink swapped in meanwhile could not escape the root. The missing
+// directories of subpath are created.
+func bindSubPath(root, subPath, target string) error {
+	if err := safeMkdirAll(root, subPath); err != nil {
+		return err
+	}
+
+	resolvedRoot, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return err
+	}
+	resolved, err := filepath.EvalSymlinks(filepath.Join(resolvedRoot, subPath))
+	if err != nil {
+		return err
+	}
+	rel, err := filepath.Rel(resolvedRoot, resolved)
+	if err != nil {
+		return err
+	}
+	if rel == ".." || strings.HasPrefix(rel, "../") {
+		return fmt.Errorf("subpath %q escapes %s through symlinks", subPath, root)
+	}
+
+	fd, err := safeOpen(resolvedRoot, rel)
+	if err != nil {
+		return err
+	}
+	defer unix.Close(fd)
+
+	var st unix.Stat_t
+	if err := unix.Fstat(fd, &st); err != nil {
+		return err
+	}
+
+	// the target is a file if the subpath is not a directory, e.g. a config file.
+	if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+		return err
+	}
+	if st.Mode&unix.S_IFMT == unix.S_IFDIR {
+		err = os.Mkdir(target, 0700)
+	} else {
+		err = ioutil.WriteFile(target, nil, 0600)
+	}
+	if err != nil {
+		return err
+	}
+
+	// the file opened is mounted, not the path which could be changed.
+	source := fmt.Sprintf("/proc/self/fd/%d", fd)
+	if err := unix.Mount(source, target, "", unix.MS_BIND, ""); err != nil {
+		os.Remove(target)
+		return err
+	}
+	return nil
+}
+
+// safeOpen opens the relative path in root with O_PATH, none of the components
+// is allowed to be a symlink.
+func safeOpen(root, rel string) (int, error) {
+	fd, err := unix.Open(root, unix.O_PATH|unix.O_DIRECTORY|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return -1, err
+	}
+	if rel == "." {
+		return fd, nil
+	}
+
+	for _, name := range strings.Split(rel, "/") {
+		next, err := unix.Openat(fd, name, unix.O_PATH|unix.O_NOFOLLOW|unix.O_CLOEXEC, 0)
+		unix.Close(fd)
+		if err != nil {
+			return -1, err
+		}
+		fd = next
+
+		var st unix.Stat_t
+		if err := unix.Fstat(fd, &st); err != nil {
+			unix.Close(fd)
+			return -1, err
+		}
+		if st.Mode&unix.S_IFMT == unix.S_IFLNK {
+			unix.Close(fd)
+			return -1, fmt.Errorf("%s in %s is changed to a symlink", name, filepath.Join(root, rel))
+		}
+	}
+	return fd, nil
+}
+
+// safeMkdirAll creates the missing directories of subpath in root. The existing
+// components are checked afterwards, only the ones missing are created without
+// following symlinks.
+func safeMkdirAll(root, subPath string) error {
+	if _, err := os.Stat(filepath.Join(root, subPath)); err == nil {
+		return nil
+	}
+
+	fd, err := unix.Open(root, unix.O_DIRECTORY|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return err
+	}
+	for _, name := range strings.Split(subPath, "/") {
+		if name == "." {
+			continue
+		}
+		if err := unix.Mkdirat(fd, name, 0755); err != nil && err != unix.EEXIST {
+			unix.Close(fd)
+			return err
+		}
+		next, err := unix.Openat(fd, name, unix.O_DIRECTORY|unix.O_NOFOLLOW|unix.O_CLOEXEC, 0)
+		unix.Close(fd)
+		if err != nil {
+			return fmt.Errorf("failed to create %s in %s: %v", name, root, err)
+		}
+		fd = next
+	}
+	unix.Close(fd)
+	return nil
+}
+
+// removeSubPaths unmounts and removes the bind mounts of subpaths in the dir.
+// The dir is kept if any of them fails to be unmounted, so that the volume is
+// never removed through the bind mount.
+func removeSubPaths(dir string) error {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	for _, e := range entries {
+		target := filepath.Join(dir, e.Name())
+		if err := unix.Unmount(target, unix.MNT_DETACH); err != nil && err != unix.EINVAL && err != unix.ENOENT {
+			return fmt.Errorf("failed to unmount subpath %s: %v", target, err)
+		}
+		if err := os.Remove(target); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return os.Remove(dir)
+}
+
+// removeSandboxSubPaths removes the bind mounts of subpaths of all the
+// containers in sandbox, which must be done before the sandbox root dir is
+// removed.
+func removeSandboxSubPaths(sandboxRootDir string) error {
+	dir := filepath.Join(sandboxRootDir, subPathsDir)
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	for _, e := range entries {
+		if err := removeSubPaths(filepath.Join(dir, e.Name())); err != nil {
+			return err
+		}
+	}
+	return os.Remove(dir)
+}
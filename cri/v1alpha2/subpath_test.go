@@ -0,0 +1,117 @@
+package v1alpha2
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	anno "github.com/alibaba/pouch/cri/annotations"
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/sys/unix"
+)
+
+func TestParseSubPaths(t *testing.T) {
+	subPaths, err := parseSubPaths(map[string]string{anno.SubPathsAnnotation: "/data=app/data, /etc/app.conf=conf/./app.conf"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"/data": "app/data", "/etc/app.conf": "conf/app.conf"}, subPaths)
+
+	subPaths, err = parseSubPaths(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, subPaths)
+
+	for _, v := range []string{"/data", "/data=", "=app", "/data=/app", "/data=../app", "/data=app/../../etc"} {
+		_, err := parseSubPaths(map[string]string{anno.SubPathsAnnotation: v})
+		assert.Error(t, err, v)
+	}
+}
+
+func TestBindSubPathEscape(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cri-subpath")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	volume := filepath.Join(dir, "volume")
+	assert.NoError(t, os.MkdirAll(filepath.Join(volume, "app"), 0755))
+	assert.NoError(t, os.Symlink("/etc", filepath.Join(volume, "escape")))
+	assert.NoError(t, os.Symlink("app", filepath.Join(volume, "inner")))
+
+	// the symlink out of the volume is rejected before anything is mounted.
+	err = bindSubPath(volume, "escape", filepath.Join(dir, "target"))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "escapes")
+	_, err = os.Stat(filepath.Join(dir, "target"))
+	assert.True(t, os.IsNotExist(err))
+
+	// the missing directories are created, but not through symlinks.
+	assert.NoError(t, safeMkdirAll(volume, "app/logs/2006"))
+	fi, err := os.Stat(filepath.Join(volume, "app/logs/2006"))
+	assert.NoError(t, err)
+	assert.True(t, fi.IsDir())
+	assert.Error(t, safeMkdirAll(volume, "escape/pouch-subpath-test"))
+	_, err = os.Stat("/etc/pouch-subpath-test")
+	assert.True(t, os.IsNotExist(err))
+
+	// the symlink in the volume is resolved, none of the components opened is a symlink.
+	fd, err := safeOpen(volume, "app/logs")
+	assert.NoError(t, err)
+	unix.Close(fd)
+	_, err = safeOpen(volume, "inner/logs")
+	assert.Error(t, err)
+}
+
+func TestApplySubPaths(t *testing.T) {
+	if os.Getuid() != 0 {
+		t.Skip("bind mount requires root")
+	}
+
+	dir, err := ioutil.TempDir("", "cri-subpath")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	volume := filepath.Join(dir, "volume")
+	assert.NoError(t, os.MkdirAll(filepath.Join(volume, "conf"), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(volume, "conf", "app.conf"), []byte("debug=true"), 0644))
+
+	sandboxRootDir := filepath.Join(dir, "sandbox")
+	mounts := []*runtime.Mount{
+		{ContainerPath: "/data", HostPath: volume},
+		{ContainerPath: "/etc/app.conf", HostPath: volume, Readonly: true},
+		{ContainerPath: "/logs", HostPath: filepath.Join(dir, "logs")},
+	}
+
+	// the mount of subpath must exist.
+	_, err = applySubPaths(sandboxRootDir, "app", mounts, map[string]string{anno.SubPathsAnnotation: "/cache=cache"})
+	assert.Error(t, err)
+
+	result, err := applySubPaths(sandboxRootDir, "app", mounts, map[string]string{
+		anno.SubPathsAnnotation: "/data=data/app,/etc/app.conf=conf/app.conf",
+	})
+	if err != nil && strings.Contains(err.Error(), "operation not permitted") {
+		t.Skipf("bind mount is not permitted: %v", err)
+	}
+	assert.NoError(t, err)
+	assert.Len(t, result, 3)
+	assert.Equal(t, mounts[2], result[2])
+	assert.True(t, result[1].Readonly)
+
+	// the missing subpath is created, and the file is bound.
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(result[0].HostPath, "cache"), []byte("1"), 0644))
+	_, err = os.Stat(filepath.Join(volume, "data", "app", "cache"))
+	assert.NoError(t, err)
+	content, err := ioutil.ReadFile(result[1].HostPath)
+	assert.NoError(t, err)
+	assert.Equal(t, "debug=true", string(content))
+
+	// the volume is kept after the subpaths are removed with the sandbox.
+	assert.NoError(t, removeSandboxSubPaths(sandboxRootDir))
+	_, err = os.Stat(filepath.Join(sandboxRootDir, subPathsDir))
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(filepath.Join(volume, "data", "app", "cache"))
+	assert.NoError(t, err)
+	_, err = os.Stat(filepath.Join(volume, "conf", "app.conf"))
+	assert.NoError(t, err)
+}
@@ -0,0 +1,52 @@
+package v1alpha2
+
+import (
+	"fmt"
+
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+)
+
+const (
+	// swapBehaviorLimited means the containers are not allowed to use swap
+	// unless the swap limit is specified, the memory + swap limit of them is
+	// the same as the memory limit.
+	swapBehaviorLimited = "LimitedSwap"
+	// swapBehaviorUnlimited means the containers are allowed to use as much
+	// swap as they want unless the swap limit is specified.
+	swapBehaviorUnlimited = "UnlimitedSwap"
+)
+
+// validateSwapBehavior validates the default swap behavior of containers,
+// empty means the one of pouch, which is twice the memory limit.
+func validateSwapBehavior(behavior string) error {
+	switch behavior {
+	case "", swapBehaviorLimited, swapBehaviorUnlimited:
+		return nil
+	default:
+		return fmt.Errorf("invalid swap behavior %q: must be %s or %s", behavior, swapBehaviorLimited, swapBehaviorUnlimited)
+	}
+}
+
+// memorySwapLimit returns the memory + swap limit of container, the swap
+// limit specified in resources takes precedence over the swap behavior,
+// 0 means the default one of pouch.
+func memorySwapLimit(resources *runtime.LinuxContainerResources, behavior string) int64 {
+	if swap := resources.GetMemorySwapLimitInBytes(); swap != 0 {
+		return swap
+	}
+
+	// the swap is not limited if the memory is not limited.
+	memory := resources.GetMemoryLimitInBytes()
+	if memory <= 0 {
+		return 0
+	}
+
+	switch behavior {
+	case swapBehaviorLimited:
+		return memory
+	case swapBehaviorUnlimited:
+		return -1
+	default:
+		return 0
+	}
+}
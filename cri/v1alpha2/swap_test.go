@@ -0,0 +1,36 @@
+package v1alpha2
+
+import (
+	"testing"
+
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_validateSwapBehavior(t *testing.T) {
+	for _, behavior := range []string{"", swapBehaviorLimited, swapBehaviorUnlimited} {
+		assert.NoError(t, validateSwapBehavior(behavior))
+	}
+	assert.Error(t, validateSwapBehavior("NoSwap"))
+}
+
+func Test_memorySwapLimit(t *testing.T) {
+	for _, tt := range []struct {
+		resources *runtime.LinuxContainerResources
+		behavior  string
+		want      int64
+	}{
+		{resources: nil, behavior: swapBehaviorLimited, want: 0},
+		{resources: &runtime.LinuxContainerResources{MemoryLimitInBytes: 1024}, behavior: "", want: 0},
+		{resources: &runtime.LinuxContainerResources{MemoryLimitInBytes: 1024}, behavior: swapBehaviorLimited, want: 1024},
+		{resources: &runtime.LinuxContainerResources{MemoryLimitInBytes: 1024}, behavior: swapBehaviorUnlimited, want: -1},
+		// the swap is not limited if the memory is not limited.
+		{resources: &runtime.LinuxContainerResources{}, behavior: swapBehaviorLimited, want: 0},
+		// the swap limit specified takes precedence.
+		{resources: &runtime.LinuxContainerResources{MemoryLimitInBytes: 1024, MemorySwapLimitInBytes: 4096}, behavior: swapBehaviorLimited, want: 4096},
+		{resources: &runtime.LinuxContainerResources{MemoryLimitInBytes: 1024, MemorySwapLimitInBytes: -1}, behavior: swapBehaviorLimited, want: -1},
+	} {
+		assert.Equal(t, tt.want, memorySwapLimit(tt.resources, tt.behavior), "resources %v, behavior %q", tt.resources, tt.behavior)
+	}
+}
@@ -0,0 +1,46 @@
+package v1alpha2
+
+import (
+	"sync"
+
+	"github.com/alibaba/pouch/daemon/mgr"
+	"github.com/alibaba/pouch/pkg/multierror"
+)
+
+// forEachContainer calls fn on each container with at most limit calls running
+// concurrently, a non-positive limit means no limit. It waits for all the calls
+// to finish and returns the aggregated errors of them.
+func forEachContainer(containers []*mgr.Container, limit int, fn func(*mgr.Container) error) error {
+	if limit <= 0 || limit > len(containers) {
+		limit = len(containers)
+	}
+
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		merrs = new(multierror.Multierrors)
+		slots = make(chan struct{}, limit)
+	)
+	for _, container := range containers {
+		slots <- struct{}{}
+		wg.Add(1)
+		go func(container *mgr.Container) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+
+			if err := fn(container); err != nil {
+				mu.Lock()
+				merrs.Append(err)
+				mu.Unlock()
+			}
+		}(container)
+	}
+	wg.Wait()
+
+	if merrs.Size() != 0 {
+		return merrs
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package v1alpha2
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/alibaba/pouch/daemon/mgr"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForEachContainer(t *testing.T) {
+	var containers []*mgr.Container
+	for i := 0; i < 10; i++ {
+		containers = append(containers, &mgr.Container{ID: fmt.Sprintf("c%d", i)})
+	}
+
+	// no container.
+	assert.NoError(t, forEachContainer(nil, 2, func(*mgr.Container) error {
+		return fmt.Errorf("should not be called")
+	}))
+
+	// all the containers are visited with bounded concurrency.
+	var (
+		mu               sync.Mutex
+		running, maxSeen int
+		visited          = map[string]bool{}
+	)
+	err := forEachContainer(containers, 3, func(c *mgr.Container) error {
+		mu.Lock()
+		running++
+		if running > maxSeen {
+			maxSeen = running
+		}
+		visited[c.ID] = true
+		mu.Unlock()
+
+		mu.Lock()
+		running--
+		mu.Unlock()
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Len(t, visited, len(containers))
+	assert.True(t, maxSeen <= 3)
+
+	// errors are aggregated and the others are still visited.
+	visited = map[string]bool{}
+	err = forEachContainer(containers, 0, func(c *mgr.Container) error {
+		mu.Lock()
+		visited[c.ID] = true
+		mu.Unlock()
+		if c.ID == "c1" || c.ID == "c5" {
+			return fmt.Errorf("failed on %s", c.ID)
+		}
+		return nil
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed on c1")
+	assert.Contains(t, err.Error(), "failed on c5")
+	assert.Len(t, visited, len(containers))
+}
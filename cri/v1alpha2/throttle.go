@@ -0,0 +1,101 @@
+package v1alpha2
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alibaba/pouch/cri/metrics"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// methodThrottle limits the number of concurrent requests of cri methods, the
+// requests exceeding the limit are queued until a slot is released or they are canceled.
+type methodThrottle struct {
+	// slots are the semaphores of the throttled methods, keyed by the method name.
+	slots map[string]chan struct{}
+}
+
+// parseMethodConcurrency parses the concurrency limits of cri methods, each of
+// which is in the form of "method=limit".
+func parseMethodConcurrency(entries []string) (map[string]int, error) {
+	limits := make(map[string]int)
+	for _, e := range entries {
+		parts := strings.SplitN(e, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, fmt.Errorf("invalid method concurrency %q, should be method=limit", e)
+		}
+
+		method := strings.TrimSpace(parts[0])
+		limit, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+		if err != nil || limit <= 0 {
+			return nil, fmt.Errorf("invalid limit in method concurrency %q", e)
+		}
+		if _, exists := limits[method]; exists {
+			return nil, fmt.Errorf("duplicated method %s in method concurrency", method)
+		}
+		limits[method] = limit
+	}
+	return limits, nil
+}
+
+// newMethodThrottle creates a throttle with the concurrency limits of methods.
+func newMethodThrottle(limits map[string]int) *methodThrottle {
+	t := &methodThrottle{
+		slots: make(map[string]chan struct{}, len(limits)),
+	}
+	for method, limit := range limits {
+		t.slots[method] = make(chan struct{}, limit)
+	}
+	return t
+}
+
+// acquire takes a slot of the method, and returns the function to release it.
+func (t *methodThrottle) acquire(ctx context.Context, method string) (func(), error) {
+	slot, ok := t.slots[method]
+	if !ok {
+		return func() {}, nil
+	}
+	release := func() { <-slot }
+
+	select {
+	case slot <- struct{}{}:
+		return release, nil
+	default:
+	}
+
+	// all slots are taken, wait in queue.
+	metrics.ThrottleQueuedGauge.WithLabelValues(method).Inc()
+	defer metrics.ThrottleQueuedGauge.WithLabelValues(method).Dec()
+	start := time.Now()
+	defer func() {
+		metrics.ThrottleWaitTimer.WithLabelValues(method).Observe(time.Since(start).Seconds())
+	}()
+
+	select {
+	case slot <- struct{}{}:
+		return release, nil
+	case <-ctx.Done():
+		metrics.ThrottleRejectedCounter.WithLabelValues(method).Inc()
+		return nil, status.Errorf(codes.ResourceExhausted, "too many concurrent %s requests, canceled in queue: %v", method, ctx.Err())
+	}
+}
+
+// unaryServerInterceptor returns a unary server interceptor which throttles the requests.
+func (t *methodThrottle) unaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		release, err := t.acquire(ctx, path.Base(info.FullMethod))
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,61 @@
+package v1alpha2
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestParseMethodConcurrency(t *testing.T) {
+	limits, err := parseMethodConcurrency([]string{"RunPodSandbox=10", " PullImage = 5 "})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"RunPodSandbox": 10, "PullImage": 5}, limits)
+
+	for _, entries := range [][]string{
+		{"RunPodSandbox"},
+		{"=1"},
+		{"RunPodSandbox=0"},
+		{"RunPodSandbox=a"},
+		{"RunPodSandbox=1", "RunPodSandbox=2"},
+	} {
+		_, err := parseMethodConcurrency(entries)
+		assert.Error(t, err, "entries %v", entries)
+	}
+}
+
+func TestMethodThrottleAcquire(t *testing.T) {
+	throttle := newMethodThrottle(map[string]int{"PullImage": 1})
+
+	// methods without limit are never throttled.
+	for i := 0; i < 3; i++ {
+		_, err := throttle.acquire(context.Background(), "ListImages")
+		assert.NoError(t, err)
+	}
+
+	release, err := throttle.acquire(context.Background(), "PullImage")
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = throttle.acquire(ctx, "PullImage")
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	acquired := make(chan struct{})
+	go func() {
+		r, err := throttle.acquire(context.Background(), "PullImage")
+		assert.NoError(t, err)
+		r()
+		close(acquired)
+	}()
+	release()
+
+	select {
+	case <-acquired:
+	case <-time.After(time.Second):
+		t.Fatal("the queued request should acquire the released slot")
+	}
+}
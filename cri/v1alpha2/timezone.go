@@ -0,0 +1,81 @@
+package v1alpha2
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	apitypes "github.com/alibaba/pouch/apis/types"
+	anno "github.com/alibaba/pouch/cri/annotations"
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+)
+
+const (
+	// localtimePath is the path of the local timezone file in container.
+	localtimePath = "/etc/localtime"
+)
+
+// zoneinfoDir is the directory of the timezone database on host.
+var zoneinfoDir = "/usr/share/zoneinfo"
+
+// containerTimezone returns the timezone of container in the annotations of
+// container or pod, the one of container takes precedence.
+func containerTimezone(config *runtime.ContainerConfig, sandboxConfig *runtime.PodSandboxConfig) string {
+	if tz, ok := config.GetAnnotations()[anno.TimezoneExtendAnnotation]; ok {
+		return tz
+	}
+	return sandboxConfig.GetAnnotations()[anno.TimezoneExtendAnnotation]
+}
+
+// zoneinfoFile returns the zoneinfo file of the timezone on host, e.g.
+// "/usr/share/zoneinfo/Asia/Shanghai" for "Asia/Shanghai".
+func zoneinfoFile(tz string) (string, error) {
+	if tz == "" || filepath.IsAbs(tz) || filepath.Clean(tz) != tz || strings.HasPrefix(tz, "..") {
+		return "", fmt.Errorf("invalid timezone %q", tz)
+	}
+
+	file := filepath.Join(zoneinfoDir, tz)
+	fi, err := os.Stat(file)
+	if err != nil {
+		return "", fmt.Errorf("failed to find zoneinfo of timezone %q: %v", tz, err)
+	}
+	if fi.IsDir() {
+		return "", fmt.Errorf("invalid timezone %q: %s is a directory", tz, file)
+	}
+	return file, nil
+}
+
+// applyTimezone sets the TZ env and binds the zoneinfo file to /etc/localtime
+// of container if the timezone is specified, the env and mount specified by the
+// container itself are kept.
+func applyTimezone(createConfig *apitypes.ContainerCreateConfig, config *runtime.ContainerConfig, sandboxConfig *runtime.PodSandboxConfig) error {
+	tz := containerTimezone(config, sandboxConfig)
+	if tz == "" {
+		return nil
+	}
+
+	file, err := zoneinfoFile(tz)
+	if err != nil {
+		return err
+	}
+
+	hasEnv := false
+	for _, env := range createConfig.Env {
+		if strings.HasPrefix(env, "TZ=") {
+			hasEnv = true
+			break
+		}
+	}
+	if !hasEnv {
+		createConfig.Env = append(createConfig.Env, "TZ="+tz)
+	}
+
+	for _, bind := range createConfig.HostConfig.Binds {
+		if bindDestination(bind) == localtimePath {
+			return nil
+		}
+	}
+	createConfig.HostConfig.Binds = append(createConfig.HostConfig.Binds, fmt.Sprintf("%s:%s:ro", file, localtimePath))
+	return nil
+}
@@ -0,0 +1,58 @@
+package v1alpha2
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	apitypes "github.com/alibaba/pouch/apis/types"
+	anno "github.com/alibaba/pouch/cri/annotations"
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_applyTimezone(t *testing.T) {
+	dir, err := ioutil.TempDir("", "zoneinfo")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	defer func(d string) { zoneinfoDir = d }(zoneinfoDir)
+	zoneinfoDir = dir
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "Asia"), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "Asia", "Shanghai"), []byte("TZif"), 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "UTC"), []byte("TZif"), 0644))
+
+	newCreateConfig := func(env []string, binds []string) *apitypes.ContainerCreateConfig {
+		return &apitypes.ContainerCreateConfig{
+			ContainerConfig: apitypes.ContainerConfig{Env: env},
+			HostConfig:      &apitypes.HostConfig{Binds: binds},
+		}
+	}
+	pod := &runtime.PodSandboxConfig{Annotations: map[string]string{anno.TimezoneExtendAnnotation: "Asia/Shanghai"}}
+
+	// the timezone of pod is applied.
+	createConfig := newCreateConfig([]string{"A=B"}, nil)
+	assert.NoError(t, applyTimezone(createConfig, &runtime.ContainerConfig{}, pod))
+	assert.Equal(t, []string{"A=B", "TZ=Asia/Shanghai"}, createConfig.Env)
+	assert.Equal(t, []string{filepath.Join(dir, "Asia", "Shanghai") + ":/etc/localtime:ro"}, createConfig.HostConfig.Binds)
+
+	// the timezone of container takes precedence, and the env and mount of container are kept.
+	createConfig = newCreateConfig([]string{"TZ=Europe/London"}, []string{"/data/tz:/etc/localtime"})
+	container := &runtime.ContainerConfig{Annotations: map[string]string{anno.TimezoneExtendAnnotation: "UTC"}}
+	assert.NoError(t, applyTimezone(createConfig, container, pod))
+	assert.Equal(t, []string{"TZ=Europe/London"}, createConfig.Env)
+	assert.Equal(t, []string{"/data/tz:/etc/localtime"}, createConfig.HostConfig.Binds)
+
+	// nothing is applied without timezone.
+	createConfig = newCreateConfig(nil, nil)
+	assert.NoError(t, applyTimezone(createConfig, &runtime.ContainerConfig{}, &runtime.PodSandboxConfig{}))
+	assert.Empty(t, createConfig.Env)
+	assert.Empty(t, createConfig.HostConfig.Binds)
+
+	for _, tz := range []string{"Asia", "Mars/Olympus", "../etc/passwd", "/etc/passwd", "Asia/../UTC"} {
+		pod := &runtime.PodSandboxConfig{Annotations: map[string]string{anno.TimezoneExtendAnnotation: tz}}
+		assert.Error(t, applyTimezone(newCreateConfig(nil, nil), &runtime.ContainerConfig{}, pod), tz)
+	}
+}
@@ -0,0 +1,49 @@
+package v1alpha2
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+
+	criconfig "github.com/alibaba/pouch/cri/config"
+	"github.com/alibaba/pouch/pkg/httputils"
+	"github.com/alibaba/pouch/pkg/log"
+	"github.com/alibaba/pouch/pkg/utils"
+)
+
+// criTLSConfig returns the mutual TLS config of the tcp address of cri, nil if
+// the tcp address is not set. The clients must present the certificates signed
+// by the CA, and with the allowed common names if any.
+func criTLSConfig(cfg *criconfig.Config) (*tls.Config, error) {
+	if cfg.TCPListen == "" {
+		return nil, nil
+	}
+	if cfg.TLSCert == "" || cfg.TLSKey == "" || cfg.TLSCACert == "" {
+		return nil, fmt.Errorf("tcp address %s of cri requires the cert, key and CA of TLS", cfg.TCPListen)
+	}
+
+	tlsConfig, err := httputils.GenTLSConfig(cfg.TLSKey, cfg.TLSCert, cfg.TLSCACert)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	tlsConfig.MinVersion = tls.VersionTLS12
+	// grpc clients require http2 to be negotiated by ALPN.
+	tlsConfig.NextProtos = []string{"h2"}
+
+	if len(cfg.TLSAllowedCNs) != 0 {
+		allowed := cfg.TLSAllowedCNs
+		tlsConfig.VerifyPeerCertificate = func(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error {
+			if len(verifiedChains) == 0 || len(verifiedChains[0]) == 0 {
+				return fmt.Errorf("no verified client certificate")
+			}
+			cn := verifiedChains[0][0].Subject.CommonName
+			if !utils.StringInSlice(allowed, cn) {
+				log.With(nil).Warnf("cri client with certificate of %q is rejected", cn)
+				return fmt.Errorf("common name %q of client certificate is not allowed", cn)
+			}
+			return nil
+		}
+	}
+	return tlsConfig, nil
+}
@@ -0,0 +1,130 @@
+package v1alpha2
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	criconfig "github.com/alibaba/pouch/cri/config"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestCert issues a certificate of the common name signed by the parent,
+// or a self-signed CA if the parent is nil.
+func newTestCert(t *testing.T, cn string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	if parent == nil {
+		tmpl.IsCA = true
+		tmpl.BasicConstraintsValid = true
+		tmpl.KeyUsage |= x509.KeyUsageCertSign
+		parent, parentKey = tmpl, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, parentKey)
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+	return cert, key
+}
+
+func writeTestCert(t *testing.T, dir, name string, cert *x509.Certificate, key *ecdsa.PrivateKey) {
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, name+".pem"), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}), 0600))
+	if key != nil {
+		b, err := x509.MarshalECPrivateKey(key)
+		assert.NoError(t, err)
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, name+"-key.pem"), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: b}), 0600))
+	}
+}
+
+func TestCriTLSConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cri-tls")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ca, caKey := newTestCert(t, "ca", nil, nil)
+	serverCert, serverKey := newTestCert(t, "pouchd", ca, caKey)
+	writeTestCert(t, dir, "ca", ca, nil)
+	writeTestCert(t, dir, "server", serverCert, serverKey)
+
+	cfg := &criconfig.Config{TCPListen: "127.0.0.1:0"}
+	_, err = criTLSConfig(cfg)
+	assert.Error(t, err, "the cert, key and CA are required")
+
+	cfg.TLSCert = filepath.Join(dir, "server.pem")
+	cfg.TLSKey = filepath.Join(dir, "server-key.pem")
+	cfg.TLSCACert = filepath.Join(dir, "ca.pem")
+	cfg.TLSAllowedCNs = []string{"kubelet"}
+	serverConfig, err := criTLSConfig(cfg)
+	assert.NoError(t, err)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+	handshake := func(cert *x509.Certificate, key *ecdsa.PrivateKey) error {
+		clientConfig := &tls.Config{RootCAs: roots, ServerName: "127.0.0.1"}
+		if cert != nil {
+			clientConfig.Certificates = []tls.Certificate{{Certificate: [][]byte{cert.Raw}, PrivateKey: key}}
+		}
+
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		assert.NoError(t, err)
+		defer l.Close()
+		errCh := make(chan error, 1)
+		go func() {
+			s, err := l.Accept()
+			if err != nil {
+				errCh <- err
+				return
+			}
+			errCh <- tls.Server(s, serverConfig).Handshake()
+			s.Close()
+		}()
+
+		c, err := tls.Dial("tcp", l.Addr().String(), clientConfig)
+		if err == nil {
+			// the client certificate may be verified after the handshake of
+			// client, read the alert of server if any.
+			c.Read(make([]byte, 1))
+			c.Close()
+		}
+		return <-errCh
+	}
+
+	kubelet, kubeletKey := newTestCert(t, "kubelet", ca, caKey)
+	assert.NoError(t, handshake(kubelet, kubeletKey))
+
+	other, otherKey := newTestCert(t, "other", ca, caKey)
+	assert.Error(t, handshake(other, otherKey), "the common name is not allowed")
+
+	untrustedCA, untrustedKey := newTestCert(t, "untrusted", nil, nil)
+	untrusted, key := newTestCert(t, "kubelet", untrustedCA, untrustedKey)
+	assert.Error(t, handshake(untrusted, key), "the certificate is not signed by the CA")
+
+	assert.Error(t, handshake(nil, nil), "the client certificate is required")
+
+	// no tls config without the tcp address.
+	serverConfig, err = criTLSConfig(&criconfig.Config{})
+	assert.NoError(t, err)
+	assert.Nil(t, serverConfig)
+}
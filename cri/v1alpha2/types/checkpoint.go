@@ -0,0 +1,50 @@
+package types
+
+import (
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+	"github.com/alibaba/pouch/cri/ocicni"
+)
+
+// PodCheckpointOptions are the options to checkpoint a sandbox.
+type PodCheckpointOptions struct {
+	// Dir is the directory in which the archive of checkpoint is written, which
+	// should be in the checkpoint directory of daemon, relative to it if not absolute.
+	Dir string `json:"dir"`
+
+	// Exit specify whether to stop the containers after they are checkpointed.
+	Exit bool `json:"exit,omitempty"`
+}
+
+// PodCheckpoint is the manifest in the archive of a sandbox checkpoint,
+// which is enough to restore the sandbox on the same or another node.
+type PodCheckpoint struct {
+	// ID is the id of the sandbox checkpointed.
+	ID string `json:"id"`
+
+	// Config is the CRI config of the sandbox.
+	Config *runtime.PodSandboxConfig `json:"config"`
+
+	// RuntimeHandler is the runtime handler of the sandbox.
+	RuntimeHandler string `json:"runtimeHandler,omitempty"`
+
+	// IP is the ip of the sandbox, which is requested again on restore.
+	IP string `json:"ip,omitempty"`
+
+	// MAC is the mac of the sandbox, which is requested again on restore.
+	MAC string `json:"mac,omitempty"`
+
+	// NetworkResults are the results of the CNI networks the sandbox attached to.
+	NetworkResults []*ocicni.NetworkResult `json:"networkResults,omitempty"`
+
+	// Containers are the running containers checkpointed.
+	Containers []*ContainerCheckpoint `json:"containers"`
+}
+
+// ContainerCheckpoint is a container in the sandbox checkpoint.
+type ContainerCheckpoint struct {
+	// ID is the id of the container checkpointed, which is also the id of its checkpoint.
+	ID string `json:"id"`
+
+	// Config is the CRI config of the container rebuilt from the container.
+	Config *runtime.ContainerConfig `json:"config"`
+}
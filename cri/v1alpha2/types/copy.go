@@ -0,0 +1,26 @@
+package types
+
+// CopyRequest is the request to copy files from or to a container through the stream server.
+type CopyRequest struct {
+	// ContainerID is the id of the container.
+	ContainerID string `json:"containerID"`
+
+	// Path is the path in the container to copy from or to.
+	Path string `json:"path"`
+
+	// ToContainer specify whether to extract the tar archive uploaded into the path,
+	// otherwise the tar archive of the path is downloaded.
+	ToContainer bool `json:"toContainer,omitempty"`
+
+	// CopyUIDGID sets the owner of the files extracted to the user and group of container.
+	CopyUIDGID bool `json:"copyUIDGID,omitempty"`
+
+	// NoOverwriteDirNonDir forbids replacing a directory with a non-directory and vice versa.
+	NoOverwriteDirNonDir bool `json:"noOverwriteDirNonDir,omitempty"`
+}
+
+// CopyResponse is the response of copy request.
+type CopyResponse struct {
+	// URL is the url of stream server to download or upload the tar archive.
+	URL string `json:"url"`
+}
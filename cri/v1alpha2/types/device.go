@@ -0,0 +1,37 @@
+package types
+
+// DeviceRequest is the request to attach a device of host to a running
+// container, or to detach it.
+type DeviceRequest struct {
+	// ContainerID is the id of the container.
+	ContainerID string `json:"containerID"`
+
+	// HostPath is the path of the block or character device on the host.
+	HostPath string `json:"hostPath"`
+
+	// ContainerPath is the path of the device node in the container, the same
+	// as the host path if empty.
+	ContainerPath string `json:"containerPath,omitempty"`
+
+	// Permissions are the cgroup permissions of the device, any of r, w and m,
+	// rwm if empty.
+	Permissions string `json:"permissions,omitempty"`
+}
+
+// DeviceResponse is the device attached to or detached from the container.
+type DeviceResponse struct {
+	// Type is b for the block device and c for the character device.
+	Type string `json:"type"`
+
+	// Major is the major number of the device.
+	Major int64 `json:"major"`
+
+	// Minor is the minor number of the device.
+	Minor int64 `json:"minor"`
+
+	// ContainerPath is the path of the device node in the container.
+	ContainerPath string `json:"containerPath"`
+
+	// Permissions are the cgroup permissions of the device.
+	Permissions string `json:"permissions"`
+}
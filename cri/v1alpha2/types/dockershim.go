@@ -0,0 +1,26 @@
+package types
+
+// DockershimImportReport is the result of importing the sandboxes and containers
+// created by dockershim through the docker compatible API of pouchd.
+type DockershimImportReport struct {
+	// Sandboxes are the sandboxes found.
+	Sandboxes []*DockershimSandbox `json:"sandboxes"`
+}
+
+// DockershimSandbox is a sandbox created by dockershim with its containers.
+type DockershimSandbox struct {
+	// ID is the id of sandbox.
+	ID string `json:"id"`
+
+	// Containers are the ids of containers in the sandbox.
+	Containers []string `json:"containers"`
+
+	// NetNS is the persistent network namespace adopted from the sandbox.
+	NetNS string `json:"netns,omitempty"`
+
+	// Imported specify whether the sandbox has been imported.
+	Imported bool `json:"imported"`
+
+	// Error is the error occurred when importing the sandbox.
+	Error string `json:"error,omitempty"`
+}
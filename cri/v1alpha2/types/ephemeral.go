@@ -0,0 +1,46 @@
+package types
+
+// EphemeralContainerRequest is the request to run a short-lived container in
+// the namespaces of an existing sandbox, e.g. to debug the pod.
+type EphemeralContainerRequest struct {
+	// PodSandboxID is the id of sandbox the container joins.
+	PodSandboxID string `json:"podSandboxID"`
+
+	// Name is the name of container, which should be unique in the sandbox.
+	Name string `json:"name"`
+
+	// Image is the image of container, which should have been pulled.
+	Image string `json:"image"`
+
+	// TargetContainerID is the id of container in the sandbox whose pid namespace
+	// the container joins, the one of sandbox is joined if empty.
+	TargetContainerID string `json:"targetContainerID,omitempty"`
+
+	// Command is the entrypoint of container.
+	Command []string `json:"command,omitempty"`
+
+	// Args are the arguments of command.
+	Args []string `json:"args,omitempty"`
+
+	// Env are the environments of container, in the form of key=value.
+	Env []string `json:"env,omitempty"`
+
+	// WorkingDir is the working directory of command.
+	WorkingDir string `json:"workingDir,omitempty"`
+
+	// Stdin specify whether to keep stdin of container open for attach.
+	Stdin bool `json:"stdin,omitempty"`
+
+	// StdinOnce closes the stdin once the first attach is detached.
+	StdinOnce bool `json:"stdinOnce,omitempty"`
+
+	// Tty specify whether to allocate a tty for container.
+	Tty bool `json:"tty,omitempty"`
+}
+
+// EphemeralContainerResponse is the response of ephemeral container request.
+type EphemeralContainerResponse struct {
+	// ContainerID is the id of the started container, which could be attached
+	// through the stream server.
+	ContainerID string `json:"containerID"`
+}
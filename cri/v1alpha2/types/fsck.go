@@ -0,0 +1,42 @@
+package types
+
+// FsckIssueKind is the kind of inconsistency found by the sandbox fsck.
+type FsckIssueKind string
+
+const (
+	// FsckDanglingMeta is a sandbox meta whose sandbox container doesn't exist.
+	FsckDanglingMeta FsckIssueKind = "dangling-meta"
+	// FsckOrphanContainer is a sandbox container which has no sandbox meta.
+	FsckOrphanContainer FsckIssueKind = "orphan-container"
+	// FsckOrphanNetNS is a network namespace which is referenced by no sandbox.
+	FsckOrphanNetNS FsckIssueKind = "orphan-netns"
+	// FsckDanglingNetNS is a sandbox meta referencing a network namespace which doesn't exist.
+	FsckDanglingNetNS FsckIssueKind = "dangling-netns"
+	// FsckOrphanDir is a directory in the sandbox base directory which belongs to no sandbox.
+	FsckOrphanDir FsckIssueKind = "orphan-dir"
+)
+
+// FsckIssue is an inconsistency between the sandbox meta, the sandbox containers,
+// the network namespaces and the sandbox directories.
+type FsckIssue struct {
+	// Kind is the kind of the issue.
+	Kind FsckIssueKind `json:"kind"`
+
+	// ID is the id of sandbox.
+	ID string `json:"id,omitempty"`
+
+	// Path is the path of the network namespace or the directory.
+	Path string `json:"path,omitempty"`
+
+	// Repaired specify whether the issue has been repaired.
+	Repaired bool `json:"repaired"`
+
+	// RepairError is the error occurred when repairing the issue.
+	RepairError string `json:"repairError,omitempty"`
+}
+
+// FsckReport is the result of the sandbox fsck.
+type FsckReport struct {
+	// Issues are the inconsistencies found.
+	Issues []*FsckIssue `json:"issues"`
+}
@@ -0,0 +1,35 @@
+package types
+
+import (
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+	"github.com/alibaba/pouch/cri/ocicni"
+)
+
+// NetworkTeardownTask represents a failed network teardown of sandbox which should be retried.
+type NetworkTeardownTask struct {
+	// ID is the id of sandbox.
+	ID string
+
+	// NetNS is the sandbox's network namespace to teardown.
+	NetNS string
+
+	// RemoveNetNS specify whether the network namespace should be removed after teardown.
+	RemoveNetNS bool
+
+	// Config is CRI sandbox config.
+	Config *runtime.PodSandboxConfig
+
+	// NetworkResults are the results of CNI ADD of the sandbox.
+	NetworkResults []*ocicni.NetworkResult
+
+	// Attempts is the number of teardown attempts.
+	Attempts int
+
+	// LastError is the error of last teardown attempt.
+	LastError string
+}
+
+// Key returns sandbox's id.
+func (task *NetworkTeardownTask) Key() string {
+	return task.ID
+}
@@ -0,0 +1,79 @@
+package types
+
+import (
+	apitypes "github.com/alibaba/pouch/apis/types"
+)
+
+// PrefetchState is the state of an image queued for prefetch.
+type PrefetchState string
+
+const (
+	// PrefetchQueued is the image waiting in the queue.
+	PrefetchQueued PrefetchState = "queued"
+	// PrefetchPulling is the image being pulled.
+	PrefetchPulling PrefetchState = "pulling"
+	// PrefetchDone is the image pulled, or present on the node already.
+	PrefetchDone PrefetchState = "done"
+	// PrefetchFailed is the image failed to be pulled.
+	PrefetchFailed PrefetchState = "failed"
+)
+
+// PrefetchImage is an image to pull in background before it is used, e.g. the
+// images of the next release of an application.
+type PrefetchImage struct {
+	// Image is the reference of the image.
+	Image string `json:"image"`
+
+	// Priority orders the images in the queue, the higher ones are pulled first.
+	Priority int `json:"priority,omitempty"`
+
+	// RuntimeHandler is the runtime handler whose snapshotter the image is unpacked in.
+	RuntimeHandler string `json:"runtimeHandler,omitempty"`
+
+	// Auth is the credentials to pull the image with.
+	Auth *apitypes.AuthConfig `json:"auth,omitempty"`
+}
+
+// PrefetchRequest is the request to queue images for prefetch.
+type PrefetchRequest struct {
+	// Images are the images to queue, the image queued already is moved ahead
+	// if it is given a higher priority.
+	Images []*PrefetchImage `json:"images"`
+}
+
+// PrefetchStatus is the status of an image queued for prefetch.
+type PrefetchStatus struct {
+	// Image is the reference of the image.
+	Image string `json:"image"`
+
+	// RuntimeHandler is the runtime handler whose snapshotter the image is unpacked in.
+	RuntimeHandler string `json:"runtimeHandler,omitempty"`
+
+	// Priority is the priority of the image in the queue.
+	Priority int `json:"priority"`
+
+	// Warm is true if the image is queued as one of the warm images.
+	Warm bool `json:"warm,omitempty"`
+
+	// State is the state of the prefetch.
+	State PrefetchState `json:"state"`
+
+	// Error is the error of the failed pull.
+	Error string `json:"error,omitempty"`
+
+	// QueuedAt is the time (in unix nanoseconds) the image is queued.
+	QueuedAt int64 `json:"queuedAt"`
+
+	// FinishedAt is the time (in unix nanoseconds) the prefetch is done or failed.
+	FinishedAt int64 `json:"finishedAt,omitempty"`
+}
+
+// PrefetchReport reports the images queued, being pulled and recently finished.
+type PrefetchReport struct {
+	// Bandwidth is the bandwidth (in bytes per second) shared by the prefetches, 0 means no limit.
+	Bandwidth int64 `json:"bandwidth"`
+
+	// Images are the images ordered by their states, the queued ones in the
+	// order they are pulled.
+	Images []*PrefetchStatus `json:"images"`
+}
@@ -2,6 +2,7 @@ package types
 
 import (
 	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+	"github.com/alibaba/pouch/cri/ocicni"
 )
 
 // SandboxMeta represents the sandbox's meta data.
@@ -9,9 +10,17 @@ type SandboxMeta struct {
 	// ID is the id of sandbox.
 	ID string
 
+	// SchemaVersion is the version of the layout of sandbox meta, used to upgrade
+	// the meta persisted by the older versions.
+	SchemaVersion int
+
 	// Config is CRI sandbox config.
 	Config *runtime.PodSandboxConfig
 
+	// Phase is the phase of the creation of sandbox, which is journaled so that
+	// the sandboxes left partially created by a crash could be cleaned up.
+	Phase SandboxPhase
+
 	// Runtime is the runtime handler name of the pod.
 	Runtime string
 
@@ -20,6 +29,95 @@ type SandboxMeta struct {
 
 	// NetNS is the sandbox's network namespace
 	NetNS string
+
+	// NetworkResults are the results of CNI ADD of the sandbox.
+	NetworkResults []*ocicni.NetworkResult
+
+	// NetworkDevices are the host network devices moved into the sandbox.
+	NetworkDevices []*ocicni.NetworkDevice
+
+	// NetPriority is the highest net priority of containers in the sandbox.
+	NetPriority int64
+
+	// PidsLimit is the maximum number of processes in the pod cgroup, 0 means no limit.
+	PidsLimit int64
+
+	// MemoryQoS is the memory QoS of the pod cgroup.
+	MemoryQoS MemoryQoS
+
+	// Overhead is the resources consumed by the runtime of sandbox itself.
+	Overhead PodOverhead
+
+	// ProcessLabel is the SELinux process label of the containers in sandbox,
+	// whose MCS categories are allocated uniquely for the pod.
+	ProcessLabel string
+
+	// MountLabel is the SELinux label of the sandbox files and the volumes
+	// relabeled for the containers in sandbox.
+	MountLabel string
+
+	// StopTimeout is the time duration (in time.Second) the containers are given to stop
+	// before being killed when the sandbox is stopped, 0 means the default one.
+	StopTimeout int64
+
+	// Holder is the process holding the sandbox in place of the sandbox container,
+	// nil if the sandbox is backed by a container.
+	Holder *SandboxHolder
+}
+
+// SandboxHolder is the lightweight process holding the sandbox of the pod in
+// the namespaces of host, which is started from the built-in pause binary.
+type SandboxHolder struct {
+	// Pid is the pid of the process.
+	Pid int
+	// StartTime is the start time of the process in clock ticks after boot,
+	// which tells the process from the one reusing its pid.
+	StartTime uint64
+	// Created is the time (in UnixNano) the sandbox is created.
+	Created int64
+}
+
+// SandboxPhase is the phase of the creation of sandbox.
+type SandboxPhase string
+
+const (
+	// SandboxPhaseCreating is the sandbox whose network is being set up and
+	// whose container is being created and started.
+	SandboxPhaseCreating SandboxPhase = "creating"
+	// SandboxPhaseReady is the sandbox created completely.
+	SandboxPhaseReady SandboxPhase = "ready"
+)
+
+// MemoryQoS is the memory protection and throttling of cgroup v2, the values
+// are in bytes and 0 means not specified.
+type MemoryQoS struct {
+	// Min is the memory.min, the memory which is never reclaimed.
+	Min int64
+	// Low is the memory.low, the memory which is reclaimed only if there is
+	// no reclaimable memory in the unprotected cgroups.
+	Low int64
+	// High is the memory.high, the processes are throttled and put under
+	// heavy reclaim pressure if the usage goes over it.
+	High int64
+}
+
+// IsEmpty returns whether none of the memory QoS is specified.
+func (q MemoryQoS) IsEmpty() bool {
+	return q.Min == 0 && q.Low == 0 && q.High == 0
+}
+
+// PodOverhead is the resources consumed by the runtime of sandbox itself,
+// e.g. the hypervisor and guest kernel of VM-based pods, 0 means none.
+type PodOverhead struct {
+	// CPUMilli is the cpu in millicores.
+	CPUMilli int64 `json:"cpuMilli,omitempty"`
+	// Memory is the memory in bytes.
+	Memory int64 `json:"memory,omitempty"`
+}
+
+// IsEmpty returns whether there is no overhead.
+func (o PodOverhead) IsEmpty() bool {
+	return o.CPUMilli == 0 && o.Memory == 0
 }
 
 // Key returns sandbox's id.
@@ -0,0 +1,42 @@
+package types
+
+// SelfTestOptions are the options of the CRI conformance self-test.
+type SelfTestOptions struct {
+	// Image is the image of the test container, the sandbox image is used if empty.
+	Image string `json:"image,omitempty"`
+
+	// Exec runs the exec scenarios with a shell in the test container, the image
+	// of which should have /bin/sh.
+	Exec bool `json:"exec,omitempty"`
+
+	// HostNetwork runs the test sandbox in the host network to skip the CNI plugins.
+	HostNetwork bool `json:"hostNetwork,omitempty"`
+}
+
+// SelfTestResult is the result of a scenario of the self-test.
+type SelfTestResult struct {
+	// Name is the name of scenario.
+	Name string `json:"name"`
+
+	// Passed specify whether the scenario passed.
+	Passed bool `json:"passed"`
+
+	// Skipped specify whether the scenario is skipped, since the scenario it
+	// depends on failed or it is not applicable to the options.
+	Skipped bool `json:"skipped,omitempty"`
+
+	// Error is the reason why the scenario failed or is skipped.
+	Error string `json:"error,omitempty"`
+
+	// Duration is the time (in milliseconds) the scenario took.
+	Duration int64 `json:"duration"`
+}
+
+// SelfTestReport is the report of the self-test.
+type SelfTestReport struct {
+	// Passed specify whether all the scenarios run passed.
+	Passed bool `json:"passed"`
+
+	// Results are the results of scenarios in the order of running.
+	Results []*SelfTestResult `json:"results"`
+}
@@ -0,0 +1,75 @@
+package v1alpha2
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	apitypes "github.com/alibaba/pouch/apis/types"
+	anno "github.com/alibaba/pouch/cri/annotations"
+)
+
+// upgradeAnnotation parses the in-place upgrade of container from the annotations
+// of UpdateContainerResources, nil means no upgrade. The annotations are removed
+// since the upgrade is an action rather than a config.
+func upgradeAnnotation(annotations map[string]string) (*apitypes.ContainerUpgradeConfig, error) {
+	image, ok := annotations[anno.UpgradeImageExtendAnnotation]
+	if !ok {
+		for _, key := range []string{anno.UpgradeCommandExtendAnnotation, anno.UpgradeArgsExtendAnnotation} {
+			if _, ok := annotations[key]; ok {
+				return nil, fmt.Errorf("%s is specified without %s", key, anno.UpgradeImageExtendAnnotation)
+			}
+		}
+		return nil, nil
+	}
+	if image == "" {
+		return nil, fmt.Errorf("invalid %s: image should not be empty", anno.UpgradeImageExtendAnnotation)
+	}
+
+	config := &apitypes.ContainerUpgradeConfig{Image: image}
+	for _, arg := range []struct {
+		key   string
+		value *[]string
+	}{
+		{anno.UpgradeCommandExtendAnnotation, &config.Entrypoint},
+		{anno.UpgradeArgsExtendAnnotation, &config.Cmd},
+	} {
+		if v, ok := annotations[arg.key]; ok {
+			if err := json.Unmarshal([]byte(v), arg.value); err != nil {
+				return nil, fmt.Errorf("invalid %s %q: must be a JSON array of strings", arg.key, v)
+			}
+		}
+	}
+
+	delete(annotations, anno.UpgradeImageExtendAnnotation)
+	delete(annotations, anno.UpgradeCommandExtendAnnotation)
+	delete(annotations, anno.UpgradeArgsExtendAnnotation)
+	return config, nil
+}
+
+// upgradeContainer replaces the image and command of container in place, the
+// running container is restarted with the new image in the same sandbox, and
+// its volumes and log are kept.
+func (c *CriManager) upgradeContainer(ctx context.Context, id string, config *apitypes.ContainerUpgradeConfig) error {
+	if err := c.ContainerMgr.Upgrade(ctx, id, config); err != nil {
+		return fmt.Errorf("failed to upgrade container %q to image %q: %v", id, config.Image, err)
+	}
+	// the image reference cached is the one of the image replaced.
+	c.imageRefCache.remove(id)
+
+	container, err := c.ContainerMgr.Get(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get container %q: %v", id, err)
+	}
+	if !container.IsRunning() {
+		return nil
+	}
+
+	// the IO of container is recreated by the restart.
+	if logPath := container.Config.Labels[containerLogPathLabelKey]; logPath != "" {
+		if err := c.ContainerMgr.AttachCRILog(ctx, id, logPath); err != nil {
+			return fmt.Errorf("failed to attach log of upgraded container %q: %v", id, err)
+		}
+	}
+	return nil
+}
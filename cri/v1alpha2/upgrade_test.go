@@ -0,0 +1,57 @@
+package v1alpha2
+
+import (
+	"context"
+	"testing"
+
+	apitypes "github.com/alibaba/pouch/apis/types"
+	anno "github.com/alibaba/pouch/cri/annotations"
+	"github.com/alibaba/pouch/daemon/mgr"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_upgradeAnnotation(t *testing.T) {
+	config, err := upgradeAnnotation(map[string]string{"a": "b"})
+	assert.NoError(t, err)
+	assert.Nil(t, config)
+
+	annotations := map[string]string{
+		"a":                                 "b",
+		anno.UpgradeImageExtendAnnotation:   "busybox:1.30",
+		anno.UpgradeCommandExtendAnnotation: `["sh","-c"]`,
+		anno.UpgradeArgsExtendAnnotation:    `["top"]`,
+	}
+	config, err = upgradeAnnotation(annotations)
+	assert.NoError(t, err)
+	assert.Equal(t, &apitypes.ContainerUpgradeConfig{
+		Image:      "busybox:1.30",
+		Entrypoint: []string{"sh", "-c"},
+		Cmd:        []string{"top"},
+	}, config)
+	// the annotations of upgrade are not kept.
+	assert.Equal(t, map[string]string{"a": "b"}, annotations)
+
+	for _, annotations := range []map[string]string{
+		{anno.UpgradeImageExtendAnnotation: ""},
+		{anno.UpgradeArgsExtendAnnotation: `["top"]`},
+		{anno.UpgradeImageExtendAnnotation: "busybox", anno.UpgradeCommandExtendAnnotation: "sh -c"},
+	} {
+		_, err := upgradeAnnotation(annotations)
+		assert.Error(t, err)
+	}
+}
+
+func TestUpgradeContainerDropsImageRef(t *testing.T) {
+	ctrMgr := &ephemeralContainerMgr{containers: map[string]*mgr.Container{
+		"c1": {ID: "c1", Config: &apitypes.ContainerConfig{}, State: &apitypes.ContainerState{}},
+	}}
+	c := &CriManager{ContainerMgr: ctrMgr, imageRefCache: newImageRefCache()}
+	_, _, version := c.imageRefCache.get("c1")
+	c.imageRefCache.put("c1", "busybox@sha256:old", version)
+
+	assert.NoError(t, c.upgradeContainer(context.Background(), "c1", &apitypes.ContainerUpgradeConfig{Image: "busybox:1.30"}))
+	assert.Equal(t, []string{"upgrade c1"}, ctrMgr.calls)
+	_, ok, _ := c.imageRefCache.get("c1")
+	assert.False(t, ok)
+}
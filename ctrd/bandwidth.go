@@ -0,0 +1,61 @@
+package ctrd
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"golang.org/x/time/rate"
+)
+
+type pullBandwidthKey struct{}
+
+// WithPullBandwidth limits the bandwidth (in bytes per second) of the images
+// pulled with the context, the limiter could be shared by many pulls.
+func WithPullBandwidth(ctx context.Context, limiter *rate.Limiter) context.Context {
+	return context.WithValue(ctx, pullBandwidthKey{}, limiter)
+}
+
+// GetPullBandwidth returns the bandwidth limiter of pull in context, nil if none.
+func GetPullBandwidth(ctx context.Context) *rate.Limiter {
+	limiter, _ := ctx.Value(pullBandwidthKey{}).(*rate.Limiter)
+	return limiter
+}
+
+// rateLimitedTransport limits the bandwidth of the response bodies read from
+// registries.
+type rateLimitedTransport struct {
+	transport http.RoundTripper
+	limiter   *rate.Limiter
+}
+
+// RoundTrip sends the request and wraps the body of its response.
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = &rateLimitedBody{ReadCloser: resp.Body, ctx: req.Context(), limiter: t.limiter}
+	return resp, nil
+}
+
+// rateLimitedBody waits for the limiter before the bytes read are returned.
+type rateLimitedBody struct {
+	io.ReadCloser
+	ctx     context.Context
+	limiter *rate.Limiter
+}
+
+// Read reads at most the burst of limiter at once.
+func (b *rateLimitedBody) Read(p []byte) (int, error) {
+	if burst := b.limiter.Burst(); len(p) > burst {
+		p = p[:burst]
+	}
+	n, err := b.ReadCloser.Read(p)
+	if n > 0 {
+		if werr := b.limiter.WaitN(b.ctx, n); werr != nil {
+			return n, werr
+		}
+	}
+	return n, err
+}
@@ -0,0 +1,57 @@
+package ctrd
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+func TestPullBandwidth(t *testing.T) {
+	if GetPullBandwidth(context.TODO()) != nil {
+		t.Fatalf("expect no limiter in context")
+	}
+
+	limiter := rate.NewLimiter(rate.Limit(4096), 1024)
+	ctx := WithPullBandwidth(context.TODO(), limiter)
+	if GetPullBandwidth(ctx) != limiter {
+		t.Fatalf("WithPullBandwidth does not take effect")
+	}
+}
+
+func TestRateLimitedTransport(t *testing.T) {
+	content := bytes.Repeat([]byte("a"), 4096)
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Write(content)
+	}))
+	defer server.Close()
+
+	// the burst is drained first, the rest is read in about 0.5 second.
+	client := &http.Client{Transport: &rateLimitedTransport{
+		transport: http.DefaultTransport,
+		limiter:   rate.NewLimiter(rate.Limit(6144), 1024),
+	}}
+
+	start := time.Now()
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, content) {
+		t.Fatalf("expect %d bytes read, got %d", len(content), len(data))
+	}
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond {
+		t.Fatalf("expect the body read at limited rate, but it took %v", elapsed)
+	}
+}
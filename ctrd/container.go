@@ -20,6 +20,7 @@ import (
 	"github.com/alibaba/pouch/pkg/log"
 
 	"github.com/containerd/containerd"
+	tasks "github.com/containerd/containerd/api/services/tasks/v1"
 	containerdtypes "github.com/containerd/containerd/api/types"
 	"github.com/containerd/containerd/archive"
 	"github.com/containerd/containerd/cio"
@@ -43,6 +44,9 @@ var (
 	RuntimeTypeV2kataV2 = "io.containerd.kata.v2"
 	// RuntimeTypeV2runcV1 is the runtime type name for runc containerd shim implement the shim v2 api.
 	RuntimeTypeV2runcV1 = "io.containerd.runc.v1"
+	// RuntimeTypeV2runcV2 is the runtime type name for runc containerd shim implement the shim v2 api,
+	// which could serve all the containers of a pod by one shim process.
+	RuntimeTypeV2runcV2 = "io.containerd.runc.v2"
 
 	// cleanupTimeout is used to clean up the container/task meta data in containerd.
 	cleanupTimeout = 100 * time.Second
@@ -90,6 +94,45 @@ func (c *Client) containerStats(ctx context.Context, id string) (*containerdtype
 	return metrics, nil
 }
 
+// ContainersStats returns stats of the containers in a single request, keyed by the container ID,
+// the containers without running tasks are omitted.
+func (c *Client) ContainersStats(ctx context.Context, ids []string) (map[string]*containerdtypes.Metric, error) {
+	metrics, err := c.containersStats(ctx, ids)
+	if err != nil {
+		return nil, convertCtrdErr(err)
+	}
+	return metrics, nil
+}
+
+// containersStats returns stats of the containers in a single request.
+func (c *Client) containersStats(ctx context.Context, ids []string) (map[string]*containerdtypes.Metric, error) {
+	metrics := make(map[string]*containerdtypes.Metric, len(ids))
+	if len(ids) == 0 {
+		return metrics, nil
+	}
+
+	wrapperCli, err := c.Get(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get a containerd grpc client: %v", err)
+	}
+
+	// the filters are matched if any of them is matched.
+	filters := make([]string, 0, len(ids))
+	for _, id := range ids {
+		filters = append(filters, fmt.Sprintf("id==%s", id))
+	}
+
+	resp, err := wrapperCli.client.TaskService().Metrics(ctx, &tasks.MetricsRequest{Filters: filters})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, m := range resp.Metrics {
+		metrics[m.ID] = m
+	}
+	return metrics, nil
+}
+
 // ExecContainer executes a process in container.
 func (c *Client) ExecContainer(ctx context.Context, process *Process, timeout int) error {
 	if err := c.execContainer(ctx, process, timeout); err != nil {
@@ -429,8 +472,8 @@ func (c *Client) killContainer(ctx context.Context, id string, signal int) error
 }
 
 // DestroyContainer kill container and delete it.
-func (c *Client) DestroyContainer(ctx context.Context, id string, timeout int64) (*Message, error) {
-	msg, err := c.destroyContainer(ctx, id, timeout)
+func (c *Client) DestroyContainer(ctx context.Context, id string, signal syscall.Signal, timeout int64) (*Message, error) {
+	msg, err := c.destroyContainer(ctx, id, signal, timeout)
 	if err != nil {
 		return msg, convertCtrdErr(err)
 	}
@@ -438,7 +481,7 @@ func (c *Client) DestroyContainer(ctx context.Context, id string, timeout int64)
 }
 
 // DestroyContainer kill container and delete it.
-func (c *Client) destroyContainer(ctx context.Context, id string, timeout int64) (*Message, error) {
+func (c *Client) destroyContainer(ctx context.Context, id string, signal syscall.Signal, timeout int64) (*Message, error) {
 	// TODO(ziren): if we just want to stop a container,
 	// we may need lease to lock the snapshot of container,
 	// in case, it be deleted by gc.
@@ -477,7 +520,7 @@ func (c *Client) destroyContainer(ctx context.Context, id string, timeout int64)
 	var msg *Message
 
 	// TODO: set task request timeout by context timeout
-	if err := pack.task.Kill(ctx, syscall.SIGTERM, containerd.WithKillAll); err != nil {
+	if err := pack.task.Kill(ctx, signal, containerd.WithKillAll); err != nil {
 		if !errdefs.IsNotFound(err) {
 			return nil, errors.Wrap(err, "failed to kill task")
 		}
@@ -3,6 +3,7 @@ package ctrd
 import (
 	"context"
 	"io"
+	"syscall"
 	"time"
 
 	"github.com/alibaba/pouch/apis/types"
@@ -37,8 +38,9 @@ type ContainerAPIClient interface {
 	CreateContainer(ctx context.Context, container *Container, checkpointDir string) error
 	// KillContainer kills a container's all processes by signal.
 	KillContainer(ctx context.Context, id string, signal int) error
-	// DestroyContainer kill container and delete it.
-	DestroyContainer(ctx context.Context, id string, timeout int64) (*Message, error)
+	// DestroyContainer kill container by the stop signal, and by SIGKILL if it does not exit
+	// within the timeout, then delete it.
+	DestroyContainer(ctx context.Context, id string, signal syscall.Signal, timeout int64) (*Message, error)
 	// ProbeContainer probe the container's status, if timeout <= 0, will block to receive message.
 	ProbeContainer(ctx context.Context, id string, timeout time.Duration) *Message
 	// ContainerPIDs returns the all processes's ids inside the container.
@@ -47,6 +49,8 @@ type ContainerAPIClient interface {
 	ContainerPID(ctx context.Context, id string) (int, error)
 	// ContainerStats returns stats of the container.
 	ContainerStats(ctx context.Context, id string) (*containerdtypes.Metric, error)
+	// ContainersStats returns stats of the containers in a single request.
+	ContainersStats(ctx context.Context, ids []string) (map[string]*containerdtypes.Metric, error)
 	// ExecContainer executes a process in container.
 	ExecContainer(ctx context.Context, process *Process, timeout int) error
 	// ResizeContainer changes the size of the TTY of the exec process running
@@ -114,6 +118,8 @@ type SnapshotAPIClient interface {
 	// WalkSnapshot walk all snapshots in specific snapshotter. If not set specific snapshotter,
 	// it will be set to current snapshotter. For each snapshot, the function will be called.
 	WalkSnapshot(ctx context.Context, snapshotter string, fn func(context.Context, snapshots.Info) error) error
+	// ListSnapshotters returns the names of snapshotters loaded in containerd.
+	ListSnapshotters(ctx context.Context) ([]string, error)
 	// CreateCheckpoint creates a checkpoint from a running container
 	CreateCheckpoint(ctx context.Context, id string, checkpointDir string, exit bool) error
 }
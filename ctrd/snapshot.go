@@ -8,6 +8,7 @@ import (
 	"github.com/containerd/containerd/errdefs"
 	"github.com/containerd/containerd/leases"
 	"github.com/containerd/containerd/mount"
+	"github.com/containerd/containerd/plugin"
 	"github.com/containerd/containerd/snapshots"
 	"github.com/opencontainers/image-spec/identity"
 )
@@ -167,3 +168,19 @@ func (c *Client) WalkSnapshot(ctx context.Context, snapshotter string, fn func(c
 
 	return service.Walk(ctx, fn)
 }
+
+// ListSnapshotters returns the names of snapshotters loaded in containerd.
+func (c *Client) ListSnapshotters(ctx context.Context) ([]string, error) {
+	plugins, err := c.Plugins(ctx, []string{fmt.Sprintf("type==%s", plugin.SnapshotPlugin)})
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, p := range plugins {
+		if p.Status == PluginStatusOk {
+			names = append(names, p.ID)
+		}
+	}
+	return names, nil
+}
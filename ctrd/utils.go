@@ -156,6 +156,9 @@ func (c *Client) getResolver(ctx context.Context, authConfig *types.AuthConfig,
 				Transport: tr,
 			},
 		}
+		if limiter := GetPullBandwidth(ctx); limiter != nil {
+			opt.Client.Transport = &rateLimitedTransport{transport: tr, limiter: limiter}
+		}
 
 		resolver := docker.NewResolver(opt)
 
@@ -273,6 +276,10 @@ func toLinuxResources(resources types.Resources) (*specs.LinuxResources, error)
 	r := &specs.LinuxResources{}
 
 	// toLinuxBlockIO
+	weightDevice, err := GetWeightDevice(resources.BlkioWeightDevice)
+	if err != nil {
+		return nil, err
+	}
 	readBpsDevice, err := GetThrottleDevice(resources.BlkioDeviceReadBps)
 	if err != nil {
 		return nil, err
@@ -291,6 +298,7 @@ func toLinuxResources(resources types.Resources) (*specs.LinuxResources, error)
 	}
 	r.BlockIO = &specs.LinuxBlockIO{
 		Weight:                  &resources.BlkioWeight,
+		WeightDevice:            weightDevice,
 		ThrottleReadBpsDevice:   readBpsDevice,
 		ThrottleReadIOPSDevice:  readIOpsDevice,
 		ThrottleWriteBpsDevice:  writeBpsDevice,
@@ -317,6 +325,13 @@ func toLinuxResources(resources types.Resources) (*specs.LinuxResources, error)
 		// TODO: add other fields of specs.LinuxMemory
 	}
 
+	// toLinuxPids
+	if resources.PidsLimit != 0 {
+		r.Pids = &specs.LinuxPids{
+			Limit: resources.PidsLimit,
+		}
+	}
+
 	// TODO: add more fields.
 
 	return r, nil
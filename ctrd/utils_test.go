@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/alibaba/pouch/apis/types"
 	"github.com/alibaba/pouch/pkg/errtypes"
 
 	"github.com/containerd/containerd/errdefs"
+	"github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/pkg/errors"
 )
 
@@ -81,3 +83,48 @@ func Test_convertCtrdErr(t *testing.T) {
 		})
 	}
 }
+
+func Test_toLinuxResourcesBlockIO(t *testing.T) {
+	resources := types.Resources{
+		BlkioWeight:          500,
+		BlkioWeightDevice:    []*types.WeightDevice{{Path: "/dev/null", Weight: 200}},
+		BlkioDeviceReadBps:   []*types.ThrottleDevice{{Path: "/dev/null", Rate: 1048576}},
+		BlkioDeviceWriteBps:  []*types.ThrottleDevice{{Path: "/dev/null", Rate: 2097152}},
+		BlkioDeviceReadIOps:  []*types.ThrottleDevice{{Path: "/dev/null", Rate: 100}},
+		BlkioDeviceWriteIOps: []*types.ThrottleDevice{{Path: "/dev/null", Rate: 200}},
+	}
+
+	r, err := toLinuxResources(resources)
+	if err != nil {
+		t.Fatalf("toLinuxResources() error = %v", err)
+	}
+
+	blkio := r.BlockIO
+	if *blkio.Weight != 500 {
+		t.Errorf("toLinuxResources() weight = %d, want 500", *blkio.Weight)
+	}
+	// /dev/null is the character device 1:3.
+	if len(blkio.WeightDevice) != 1 || *blkio.WeightDevice[0].Weight != 200 ||
+		blkio.WeightDevice[0].Major != 1 || blkio.WeightDevice[0].Minor != 3 {
+		t.Errorf("toLinuxResources() weight device = %+v", blkio.WeightDevice)
+	}
+	for _, tc := range []struct {
+		devices []specs.LinuxThrottleDevice
+		rate    uint64
+	}{
+		{blkio.ThrottleReadBpsDevice, 1048576},
+		{blkio.ThrottleWriteBpsDevice, 2097152},
+		{blkio.ThrottleReadIOPSDevice, 100},
+		{blkio.ThrottleWriteIOPSDevice, 200},
+	} {
+		if len(tc.devices) != 1 || tc.devices[0].Rate != tc.rate || tc.devices[0].Major != 1 || tc.devices[0].Minor != 3 {
+			t.Errorf("toLinuxResources() throttle device = %+v, want rate %d", tc.devices, tc.rate)
+		}
+	}
+
+	// the device which does not exist is an error.
+	resources.BlkioWeightDevice = []*types.WeightDevice{{Path: "/dev/not-exist", Weight: 200}}
+	if _, err := toLinuxResources(resources); err == nil {
+		t.Errorf("toLinuxResources() expected error for nonexistent device")
+	}
+}
@@ -141,8 +141,23 @@ type Config struct {
 	// EnableBuilder enable builder functionality
 	EnableBuilder bool `json:"enable-builder,omitempty"`
 
+	// DisabledHookPlugins are the names of hook plugins which are not invoked.
+	DisabledHookPlugins []string `json:"disable-hook-plugins,omitempty"`
+
+	// Rootless specify whether pouchd runs without the root of host, e.g. in the user namespace
+	// created by rootlesskit, in which the pods are connected by slirp4netns.
+	Rootless bool `json:"rootless,omitempty"`
+
+	// OCIHooks are the OCI hooks injected into the specs of containers.
+	OCIHooks []OCIHook `json:"oci-hooks,omitempty"`
+
 	// MachineMemory is the memory limit for a host.
 	MachineMemory uint64 `json:"-"`
+
+	// reloadDefaults are the reloadable configurations of the command line
+	// flags or their defaults, which the ones omitted in the config file are
+	// reset to when it's reloaded.
+	reloadDefaults *Config
 }
 
 // GetCgroupDriver gets cgroup driver used in runc.
@@ -186,16 +201,32 @@ func (cfg *Config) Validate() error {
 		cfg.Runtimes[cfg.DefaultRuntime] = types.Runtime{Path: cfg.DefaultRuntime}
 	}
 
+	for i := range cfg.OCIHooks {
+		if err := cfg.OCIHooks[i].Validate(); err != nil {
+			return err
+		}
+	}
+
 	// if cgroup driver is empty, use default cgroup driver
 	if cfg.CgroupDriver == "" {
 		cfg.CgroupDriver = DefaultCgroupDriver
 	}
 
-	return validateCgroupDriver(cfg.CgroupDriver)
+	if err := validateCgroupDriver(cfg.CgroupDriver); err != nil {
+		return err
+	}
+
+	// the cgroups could only be delegated to the unprivileged user by systemd.
+	if cfg.Rootless && cfg.CgroupDriver != CgroupSystemdDriver {
+		return fmt.Errorf("rootless mode requires cgroup driver %s to delegate cgroups", CgroupSystemdDriver)
+	}
+	return nil
 }
 
 //MergeConfigurations merges flagSet flags and config file flags into Config.
 func (cfg *Config) MergeConfigurations(flagSet *pflag.FlagSet) error {
+	cfg.reloadDefaults = cfg.reloadableConfig()
+
 	contents, err := ioutil.ReadFile(cfg.ConfigFile)
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -230,6 +261,84 @@ func (cfg *Config) MergeConfigurations(flagSet *pflag.FlagSet) error {
 	return mergeConfigurations(fileConfig, cfg.delValue(flagSet, fileFlags))
 }
 
+// LoadConfigFile loads the configurations in the config file, an empty config
+// is returned if the file doesn't exist.
+func LoadConfigFile(file string) (*Config, error) {
+	fileConfig := &Config{}
+	contents, err := ioutil.ReadFile(file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fileConfig, nil
+		}
+		return nil, fmt.Errorf("failed to read contents from config file %s: %s", file, err)
+	}
+
+	if err := json.NewDecoder(bytes.NewReader(contents)).Decode(fileConfig); err != nil {
+		return nil, fmt.Errorf("failed to decode json: %s", err)
+	}
+	return fileConfig, nil
+}
+
+// Reload updates the configurations which could be changed without restarting
+// pouchd with the ones set in the new config, the reloadable ones omitted are
+// reset to the ones of the command line flags or their defaults. Currently
+// registry mirrors, default log options and part of cri config are reloadable.
+// It returns the names of the configurations changed, the ones of cri config
+// are prefixed with "cri-config.".
+func (cfg *Config) Reload(newCfg *Config) []string {
+	cfg.Lock()
+	defer cfg.Unlock()
+
+	// the defaults are copied, so that they are never changed via the config.
+	defaults := cfg.reloadableConfig()
+	if cfg.reloadDefaults != nil {
+		defaults = cfg.reloadDefaults.reloadableConfig()
+	}
+
+	var changed []string
+	mirrors := newCfg.RegistryMirrors
+	if len(mirrors) == 0 {
+		mirrors = defaults.RegistryMirrors
+	}
+	if !(len(mirrors) == 0 && len(cfg.RegistryMirrors) == 0) && !reflect.DeepEqual(mirrors, cfg.RegistryMirrors) {
+		cfg.RegistryMirrors = mirrors
+		changed = append(changed, "registry-mirrors")
+	}
+
+	logOpts := newCfg.DefaultLogConfig.LogOpts
+	if len(logOpts) == 0 {
+		logOpts = defaults.DefaultLogConfig.LogOpts
+	}
+	if !(len(logOpts) == 0 && len(cfg.DefaultLogConfig.LogOpts) == 0) && !reflect.DeepEqual(logOpts, cfg.DefaultLogConfig.LogOpts) {
+		cfg.DefaultLogConfig.LogOpts = logOpts
+		changed = append(changed, "default-log-config")
+	}
+
+	for _, name := range cfg.CriConfig.Reload(&newCfg.CriConfig, &defaults.CriConfig) {
+		changed = append(changed, "cri-config."+name)
+	}
+	return changed
+}
+
+// reloadableConfig returns the copy of the reloadable configurations.
+func (cfg *Config) reloadableConfig() *Config {
+	c := &Config{
+		RegistryMirrors: append([]string(nil), cfg.RegistryMirrors...),
+		CriConfig: criconfig.Config{
+			SandboxImage:          cfg.CriConfig.SandboxImage,
+			CriStatsCollectPeriod: cfg.CriConfig.CriStatsCollectPeriod,
+			StreamIdleTimeout:     cfg.CriConfig.StreamIdleTimeout,
+		},
+	}
+	if cfg.DefaultLogConfig.LogOpts != nil {
+		c.DefaultLogConfig.LogOpts = make(map[string]string, len(cfg.DefaultLogConfig.LogOpts))
+		for k, v := range cfg.DefaultLogConfig.LogOpts {
+			c.DefaultLogConfig.LogOpts[k] = v
+		}
+	}
+	return c
+}
+
 // delValue deleles value in config, since we do not do conflict check for slice
 // type flag, note that we should remove default flag value in merging, cause
 // this is not reasonable if the flag is not passed. Just set the flag value to
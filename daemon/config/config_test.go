@@ -193,6 +193,11 @@ func TestConfigValidate(t *testing.T) {
 		},
 	}
 	assert.Equal(nil, cfg.Validate())
+	// Test rootless configuration
+	cfg = &Config{Rootless: true, CgroupDriver: CgroupSystemdDriver}
+	assert.Equal(nil, cfg.Validate())
+	cfg = &Config{Rootless: true, CgroupDriver: CgroupfsDriver}
+	assert.Error(cfg.Validate())
 }
 
 func TestGetConflictConfigurations(t *testing.T) {
@@ -300,3 +305,74 @@ func TestValidateCgroupDriver(t *testing.T) {
 		}
 	}
 }
+
+func TestReload(t *testing.T) {
+	assert := assert.New(t)
+
+	cfg := &Config{
+		RegistryMirrors: []string{"mirror1"},
+		DefaultLogConfig: types.LogConfig{
+			LogDriver: "json-file",
+			LogOpts:   map[string]string{"max-size": "10m"},
+		},
+		CriConfig: criconfig.Config{
+			SandboxImage:          "pause:3.0",
+			CriStatsCollectPeriod: 10,
+			StreamServerPort:      "10010",
+		},
+	}
+	cfg.reloadDefaults = cfg.reloadableConfig()
+
+	// nothing is changed by the config same as the defaults.
+	assert.Empty(cfg.Reload(&Config{}))
+	assert.Empty(cfg.Reload(&Config{RegistryMirrors: []string{"mirror1"}}))
+
+	changed := cfg.Reload(&Config{
+		RegistryMirrors: []string{"mirror2"},
+		DefaultLogConfig: types.LogConfig{
+			LogDriver: "syslog",
+			LogOpts:   map[string]string{"max-size": "20m"},
+		},
+		CriConfig: criconfig.Config{
+			SandboxImage:      "pause:3.1",
+			StreamIdleTimeout: 60,
+			StreamServerPort:  "10011",
+		},
+	})
+	assert.Equal([]string{"registry-mirrors", "default-log-config", "cri-config.sandbox-image", "cri-config.stream-idle-timeout"}, changed)
+	assert.Equal([]string{"mirror2"}, cfg.RegistryMirrors)
+	assert.Equal("json-file", cfg.DefaultLogConfig.LogDriver)
+	assert.Equal(map[string]string{"max-size": "20m"}, cfg.DefaultLogConfig.LogOpts)
+	assert.Equal("pause:3.1", cfg.CriConfig.SandboxImage)
+	assert.Equal(10, cfg.CriConfig.CriStatsCollectPeriod)
+	assert.Equal(60, cfg.CriConfig.StreamIdleTimeout)
+	assert.Equal("10010", cfg.CriConfig.StreamServerPort)
+
+	// the fields omitted are reset to the defaults.
+	changed = cfg.Reload(&Config{CriConfig: criconfig.Config{StreamIdleTimeout: 60}})
+	assert.Equal([]string{"registry-mirrors", "default-log-config", "cri-config.sandbox-image"}, changed)
+	assert.Equal([]string{"mirror1"}, cfg.RegistryMirrors)
+	assert.Equal(map[string]string{"max-size": "10m"}, cfg.DefaultLogConfig.LogOpts)
+	assert.Equal("pause:3.0", cfg.CriConfig.SandboxImage)
+	assert.Equal(60, cfg.CriConfig.StreamIdleTimeout)
+}
+
+func TestLoadConfigFile(t *testing.T) {
+	assert := assert.New(t)
+
+	cfg, err := LoadConfigFile("/path/not/exist/config.json")
+	assert.NoError(err)
+	assert.Equal(&Config{}, cfg)
+
+	f, err := ioutil.TempFile("", "config-file")
+	assert.NoError(err)
+	defer os.Remove(f.Name())
+	_, err = f.WriteString(`{"registry-mirrors": ["mirror1"], "cri-config": {"sandbox-image": "pause:3.1"}}`)
+	assert.NoError(err)
+	f.Close()
+
+	cfg, err = LoadConfigFile(f.Name())
+	assert.NoError(err)
+	assert.Equal([]string{"mirror1"}, cfg.RegistryMirrors)
+	assert.Equal("pause:3.1", cfg.CriConfig.SandboxImage)
+}
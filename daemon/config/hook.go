@@ -0,0 +1,81 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+)
+
+const (
+	// HookStagePrestart is the stage of hooks run after the container is created
+	// but before the user process is started.
+	HookStagePrestart = "prestart"
+	// HookStagePoststart is the stage of hooks run after the user process is started.
+	HookStagePoststart = "poststart"
+	// HookStagePoststop is the stage of hooks run after the container is stopped.
+	HookStagePoststop = "poststop"
+)
+
+// OCIHook is the OCI hook injected into the specs of containers, so that the
+// site integrations, e.g. the device setup, do not need custom plugins.
+type OCIHook struct {
+	// Path is the absolute path of hook binary.
+	Path string `json:"path"`
+	// Args are the arguments of hook, including the binary name as argv[0].
+	Args []string `json:"args,omitempty"`
+	// Env are the environments of hook, in the form of key=value.
+	Env []string `json:"env,omitempty"`
+	// Timeout is the number of seconds before aborting the hook.
+	Timeout *int `json:"timeout,omitempty"`
+	// Stages are the stages the hook is run at, prestart, poststart or poststop.
+	Stages []string `json:"stages"`
+	// Annotations are the regular expressions of annotation values by keys, the
+	// hook is only injected into the containers whose spec annotations match all
+	// of them, or all the containers if empty.
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// Validate validates the path, stages and annotations of hook.
+func (h *OCIHook) Validate() error {
+	if !filepath.IsAbs(h.Path) {
+		return fmt.Errorf("path %q of oci hook must be absolute", h.Path)
+	}
+
+	if len(h.Stages) == 0 {
+		return fmt.Errorf("stages of oci hook %q cannot be empty", h.Path)
+	}
+	for _, stage := range h.Stages {
+		switch stage {
+		case HookStagePrestart, HookStagePoststart, HookStagePoststop:
+		default:
+			return fmt.Errorf("invalid stage %q of oci hook %q, should be %s, %s or %s",
+				stage, h.Path, HookStagePrestart, HookStagePoststart, HookStagePoststop)
+		}
+	}
+
+	if h.Timeout != nil && *h.Timeout <= 0 {
+		return fmt.Errorf("timeout of oci hook %q must be positive", h.Path)
+	}
+
+	for k, v := range h.Annotations {
+		if _, err := regexp.Compile(v); err != nil {
+			return fmt.Errorf("invalid annotation %q of oci hook %q: %v", k, h.Path, err)
+		}
+	}
+	return nil
+}
+
+// Match returns whether the hook should be injected into the container with
+// the spec annotations.
+func (h *OCIHook) Match(annotations map[string]string) bool {
+	for k, pattern := range h.Annotations {
+		v, ok := annotations[k]
+		if !ok {
+			return false
+		}
+		if matched, err := regexp.MatchString(pattern, v); err != nil || !matched {
+			return false
+		}
+	}
+	return true
+}
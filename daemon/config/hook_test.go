@@ -0,0 +1,40 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOCIHookValidate(t *testing.T) {
+	zero := 0
+	for _, tc := range []struct {
+		hook  OCIHook
+		valid bool
+	}{
+		{OCIHook{Path: "/bin/hook", Stages: []string{HookStagePrestart, HookStagePoststop}}, true},
+		{OCIHook{Path: "/bin/hook", Stages: []string{HookStagePoststart}, Annotations: map[string]string{"a": "^b$"}}, true},
+		{OCIHook{Path: "hook", Stages: []string{HookStagePrestart}}, false},
+		{OCIHook{Path: "/bin/hook"}, false},
+		{OCIHook{Path: "/bin/hook", Stages: []string{"createRuntime"}}, false},
+		{OCIHook{Path: "/bin/hook", Stages: []string{HookStagePrestart}, Timeout: &zero}, false},
+		{OCIHook{Path: "/bin/hook", Stages: []string{HookStagePrestart}, Annotations: map[string]string{"a": "("}}, false},
+	} {
+		err := tc.hook.Validate()
+		if tc.valid {
+			assert.NoError(t, err, "%+v", tc.hook)
+		} else {
+			assert.Error(t, err, "%+v", tc.hook)
+		}
+	}
+}
+
+func TestOCIHookMatch(t *testing.T) {
+	h := &OCIHook{Annotations: map[string]string{"a": "^b", "c": "d$"}}
+	assert.True(t, h.Match(map[string]string{"a": "bb", "c": "dd", "e": "f"}))
+	assert.False(t, h.Match(map[string]string{"a": "bb"}))
+	assert.False(t, h.Match(map[string]string{"a": "ab", "c": "d"}))
+
+	// the hook without annotations matches all the containers.
+	assert.True(t, (&OCIHook{}).Match(nil))
+}
@@ -6,10 +6,12 @@ import (
 	"path"
 	"path/filepath"
 	"reflect"
+	"strings"
 
 	"github.com/alibaba/pouch/apis/server"
 	criservice "github.com/alibaba/pouch/cri"
 	"github.com/alibaba/pouch/cri/stream"
+	criv1alpha2 "github.com/alibaba/pouch/cri/v1alpha2"
 	"github.com/alibaba/pouch/ctrd"
 	"github.com/alibaba/pouch/ctrd/supervisord"
 	"github.com/alibaba/pouch/daemon/config"
@@ -21,6 +23,7 @@ import (
 	"github.com/alibaba/pouch/pkg/log"
 	"github.com/alibaba/pouch/pkg/meta"
 	"github.com/alibaba/pouch/pkg/system"
+	"github.com/alibaba/pouch/storage/quota"
 
 	systemddaemon "github.com/coreos/go-systemd/daemon"
 	systemdutil "github.com/coreos/go-systemd/util"
@@ -128,6 +131,9 @@ func NewDaemon(cfg *config.Config) *Daemon {
 func (d *Daemon) loadPlugin() error {
 	var err error
 
+	// disable the hook plugins before loading them
+	hookplugins.DisablePlugins(d.config.DisabledHookPlugins...)
+
 	// load daemon plugin if exist
 	if daemonPlugin := hookplugins.GetDaemonPlugin(); daemonPlugin != nil {
 		d.daemonPlugin = daemonPlugin
@@ -202,6 +208,14 @@ func (d *Daemon) Run() error {
 	}
 	d.volumeMgr = volumeMgr
 
+	// the quota ids of containers are allocated by the allocator persisted in
+	// home dir, so that they are unique and reclaimed on removal.
+	quotaIDs, err := quota.NewIDAllocator(d.config.HomeDir)
+	if err != nil {
+		return err
+	}
+	quota.SetIDAllocator(quotaIDs)
+
 	containerMgr, err := internal.GenContainerMgr(ctx, d)
 	if err != nil {
 		return err
@@ -241,12 +255,14 @@ func (d *Daemon) Run() error {
 	ctrd.SetImageProxy(d.config.ImageProxy)
 
 	criStreamRouterCh := make(chan stream.Router)
+	criMgrCh := make(chan criv1alpha2.CriMgr)
 	criReadyCh := make(chan bool)
 	criStopCh := make(chan error)
 
-	go criservice.RunCriService(d.config, d.containerMgr, d.imageMgr, d.volumeMgr, d.criPlugin, criStreamRouterCh, criStopCh, criReadyCh)
+	go criservice.RunCriService(d.config, d.containerMgr, d.imageMgr, d.volumeMgr, d.criPlugin, d.eventsService, criStreamRouterCh, criMgrCh, criStopCh, criReadyCh)
 
 	streamRouter := <-criStreamRouterCh
+	criMgr := <-criMgrCh
 
 	d.server = server.Server{
 		Config:          d.config,
@@ -256,6 +272,7 @@ func (d *Daemon) Run() error {
 		VolumeMgr:       volumeMgr,
 		NetworkMgr:      networkMgr,
 		StreamRouter:    streamRouter,
+		CriMgr:          criMgr,
 		ContainerPlugin: d.containerPlugin,
 		APIPlugin:       d.apiPlugin,
 	}
@@ -311,6 +328,12 @@ func (d *Daemon) Run() error {
 func (d *Daemon) Shutdown() error {
 	var errMsg string
 
+	if d.server.CriMgr != nil {
+		if err := d.server.CriMgr.Shutdown(); err != nil {
+			errMsg = fmt.Sprintf("%s\n", err.Error())
+		}
+	}
+
 	if err := d.server.Stop(); err != nil {
 		errMsg = fmt.Sprintf("%s\n", err.Error())
 	}
@@ -324,6 +347,12 @@ func (d *Daemon) Shutdown() error {
 		errMsg = fmt.Sprintf("%s\n", err.Error())
 	}
 
+	if quota.GIDAllocator != nil {
+		if err := quota.GIDAllocator.Close(); err != nil {
+			errMsg = fmt.Sprintf("%s\n", err.Error())
+		}
+	}
+
 	if errMsg != "" {
 		return fmt.Errorf("failed to shutdown pouchd: %s", errMsg)
 	}
@@ -395,6 +424,53 @@ func (d *Daemon) EventsService() *events.Events {
 	return d.eventsService
 }
 
+// Reload reloads the daemon config file, and applies the reloadable configurations
+// to the running daemon without restarting it.
+func (d *Daemon) Reload() error {
+	newCfg, err := config.LoadConfigFile(d.config.ConfigFile)
+	if err != nil {
+		return err
+	}
+
+	changed := d.config.Reload(newCfg)
+	if len(changed) == 0 {
+		log.With(nil).Infof("daemon config file %s reloaded, nothing changed", d.config.ConfigFile)
+		return nil
+	}
+
+	d.config.Lock()
+	mirrors := d.config.RegistryMirrors
+	criConfig := d.config.CriConfig
+	d.config.Unlock()
+
+	criChanged := false
+	for _, name := range changed {
+		switch {
+		case name == "registry-mirrors":
+			if d.imageMgr != nil {
+				d.imageMgr.SetRegistryMirrors(mirrors)
+			}
+		case strings.HasPrefix(name, "cri-config."):
+			criChanged = true
+		}
+	}
+	if criChanged && d.server.CriMgr != nil {
+		d.server.CriMgr.ReloadConfig(criConfig)
+	}
+
+	log.With(nil).Infof("daemon config file %s reloaded, changed %v", d.config.ConfigFile, changed)
+	return nil
+}
+
+// ToggleCriDrain turns the drain mode of cri on if it's off, and vice versa.
+func (d *Daemon) ToggleCriDrain() {
+	if d.server.CriMgr == nil {
+		log.With(nil).Warnf("failed to toggle drain mode: cri is not enabled")
+		return
+	}
+	d.server.CriMgr.SetDraining(!d.server.CriMgr.Draining())
+}
+
 // addSystemLabels adds some system labels to daemon's config.
 // Currently, pouchd add node ip and serial number to pouchd with the format:
 // node_ip=192.168.0.1
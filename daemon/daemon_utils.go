@@ -86,7 +86,9 @@ func getRuntimeOptionsType(runtimeType string) interface{} {
 		ctrd.RuntimeTypeV2runscV1,
 		ctrd.RuntimeTypeV2kataV2:
 		return &runctypes.RuncOptions{}
-	case ctrd.RuntimeTypeV2runcV1:
+	case
+		ctrd.RuntimeTypeV2runcV1,
+		ctrd.RuntimeTypeV2runcV2:
 		return &runcoptions.Options{}
 	default:
 		return nil
@@ -7,7 +7,9 @@ import (
 	"testing"
 
 	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/ctrd"
 
+	runcoptions "github.com/containerd/containerd/runtime/v2/runc/options"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -65,3 +67,27 @@ func TestInitialRuntime(t *testing.T) {
 		}
 	}
 }
+
+func TestInitialRuntimeShimV2Options(t *testing.T) {
+	assert := assert.New(t)
+	tmpDir, err := ioutil.TempDir("", "runtime-path")
+	assert.NoError(err)
+	defer os.RemoveAll(tmpDir)
+
+	runtimes := map[string]types.Runtime{
+		"runc-v2": {
+			Type:    ctrd.RuntimeTypeV2runcV2,
+			Options: map[string]interface{}{"no_new_keyring": true},
+		},
+		"unknown": {
+			Type:    "io.containerd.unknown.v1",
+			Options: map[string]interface{}{"foo": "bar"},
+		},
+	}
+	assert.NoError(initialRuntime(tmpDir, runtimes))
+
+	options, ok := runtimes["runc-v2"].Options.(*runcoptions.Options)
+	assert.True(ok)
+	assert.True(options.NoNewKeyring)
+	assert.Nil(runtimes["unknown"].Options)
+}
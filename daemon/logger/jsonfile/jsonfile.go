@@ -149,7 +149,7 @@ func (lf *JSONLogFile) checkRotate() error {
 		return err
 	}
 	// step3. reopen new log file with the same name
-	newfile, err := os.OpenFile(logName, os.O_WRONLY|os.O_TRUNC|os.O_CREATE, 0644)
+	newfile, err := os.OpenFile(logName, os.O_WRONLY|os.O_TRUNC|os.O_CREATE, lf.perms)
 	if err != nil {
 		return err
 	}
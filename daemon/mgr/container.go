@@ -31,10 +31,9 @@ import (
 	mountutils "github.com/alibaba/pouch/pkg/mount"
 	"github.com/alibaba/pouch/pkg/streams"
 	"github.com/alibaba/pouch/pkg/utils"
+	"github.com/alibaba/pouch/storage/quota"
 	volumetypes "github.com/alibaba/pouch/storage/volume/types"
 
-	"github.com/containerd/cgroups"
-	containerdtypes "github.com/containerd/containerd/api/types"
 	"github.com/containerd/containerd/mount"
 	"github.com/docker/go-units"
 	"github.com/go-openapi/strfmt"
@@ -92,7 +91,10 @@ type ContainerMgr interface {
 	StreamStats(ctx context.Context, name string, config *ContainerStatsConfig) error
 
 	// Stats of a container.
-	Stats(ctx context.Context, name string) (*containerdtypes.Metric, *cgroups.Metrics, error)
+	Stats(ctx context.Context, name string) (*ContainerMetrics, error)
+
+	// BatchStats returns the stats of containers in a single request to containerd.
+	BatchStats(ctx context.Context, ids []string) (map[string]*ContainerMetrics, error)
 
 	// AttachContainerIO attach stream to container IO.
 	AttachContainerIO(ctx context.Context, name string, cfg *streams.AttachConfig) error
@@ -118,8 +120,8 @@ type ContainerMgr interface {
 	// Remove removes a container, it may be running or stopped and so on.
 	Remove(ctx context.Context, name string, option *types.ContainerRemoveOptions) error
 
-	// Wait stops processing until the given container is stopped.
-	Wait(ctx context.Context, name string) (types.ContainerWaitOKBody, error)
+	// Wait stops processing until the given container meets the condition.
+	Wait(ctx context.Context, name string, condition string) (types.ContainerWaitOKBody, error)
 
 	// 2. The following five functions is related to container exec.
 
@@ -206,6 +208,9 @@ type ContainerManager struct {
 	// Element operated in cache must have a type of *Container.
 	cache *collect.SafeMap
 
+	// labelIndex indexes the containers in cache by the labels.
+	labelIndex *labelIndex
+
 	// monitor is used to handle container's event, eg: exit, stop and so on.
 	monitor *ContainerMonitor
 
@@ -226,6 +231,7 @@ func NewContainerManager(ctx context.Context, store *meta.Store, cli ctrd.APICli
 		IOs:             containerio.NewCache(),
 		ExecProcesses:   collect.NewSafeMap(),
 		cache:           collect.NewSafeMap(),
+		labelIndex:      newLabelIndex(),
 		Config:          cfg,
 		monitor:         NewContainerMonitor(),
 		containerPlugin: contPlugin,
@@ -256,6 +262,7 @@ func (mgr *ContainerManager) Load(ctx context.Context) error {
 
 		// put container into cache.
 		mgr.cache.Put(id, container)
+		mgr.labelIndex.put(id, container.Config.Labels)
 
 		return nil
 	}
@@ -343,8 +350,10 @@ func (mgr *ContainerManager) Restore(ctx context.Context) error {
 
 // Create checks passed in parameters and create a Container object whose status is set at Created.
 func (mgr *ContainerManager) Create(ctx context.Context, name string, config *types.ContainerCreateConfig) (resp *types.ContainerCreateResp, err error) {
-	currentSnapshotter := ctrd.CurrentSnapshotterName(ctx)
-	config.Snapshotter = currentSnapshotter
+	// the snapshotter may be chosen by the caller through the context, e.g.
+	// by the runtime handler of cri, which should be kept in the config.
+	defaultSnapshotter := ctrd.CurrentSnapshotterName(ctrd.CleanSnapshotter(ctx))
+	config.Snapshotter = ctrd.CurrentSnapshotterName(ctx)
 
 	if mgr.containerPlugin != nil {
 		log.With(ctx).Infof("invoke container pre-create hook in plugin")
@@ -353,14 +362,14 @@ func (mgr *ContainerManager) Create(ctx context.Context, name string, config *ty
 		}
 	}
 
-	// Attention, since we support multi snapshotter, if snapshotter not changed,
-	// means plugin not change it, so remove value in case to effect origin logic
-	if config.Snapshotter == currentSnapshotter {
+	// Attention, since we support multi snapshotter, if snapshotter is the default
+	// one, remove value in case to effect origin logic
+	if config.Snapshotter == defaultSnapshotter {
 		config.Snapshotter = ""
 	}
 
-	// NOTE: choose snapshotter, snapshotter can only be set
-	// through containerPlugin in Create function
+	// NOTE: choose snapshotter, snapshotter can only be set through the
+	// context of caller or containerPlugin in Create function
 	ctx = ctrd.WithSnapshotter(ctx, config.Snapshotter)
 
 	// cleanup allocated resources when failed
@@ -496,6 +505,12 @@ func (mgr *ContainerManager) Create(ctx context.Context, name string, config *ty
 	// after create options passed to containerd.
 	mgr.setBaseFS(ctx, container)
 
+	// the labels are parsed before the storage is initialized, since the
+	// volumes with z or Z are relabeled with the mount label.
+	if err := parseSecurityOpts(container, config.HostConfig.SecurityOpt); err != nil {
+		return nil, err
+	}
+
 	// init container storage module, such as: set volumes, set diskquota, set /etc/mtab, copy image's data to volume.
 	if err := mgr.initContainerStorage(ctx, container); err != nil {
 		return nil, errors.Wrapf(err, "failed to init container storage, id: (%s)", container.ID)
@@ -517,10 +532,6 @@ func (mgr *ContainerManager) Create(ctx context.Context, name string, config *ty
 	}
 	container.NetworkSettings.Ports = config.HostConfig.PortBindings
 
-	if err := parseSecurityOpts(container, config.HostConfig.SecurityOpt); err != nil {
-		return nil, err
-	}
-
 	// Get snapshot UpperDir
 	mounts, err := mgr.Client.GetMounts(ctx, id)
 	if err != nil {
@@ -529,7 +540,7 @@ func (mgr *ContainerManager) Create(ctx context.Context, name string, config *ty
 	if len(mounts) != 1 {
 		return nil, fmt.Errorf("failed to get snapshot %s mounts: not equals one", id)
 	}
-	container.SetSnapshotterMeta(mounts)
+	container.SetSnapshotterMeta(ctx, mounts)
 
 	// amendContainerSettings modify container config settings to wanted
 	amendContainerSettings(&config.ContainerConfig, config.HostConfig)
@@ -549,6 +560,7 @@ func (mgr *ContainerManager) Create(ctx context.Context, name string, config *ty
 	// add to collection
 	mgr.NameToID.Put(name, id)
 	mgr.cache.Put(id, container)
+	mgr.labelIndex.put(id, container.Config.Labels)
 
 	mgr.LogContainerEvent(ctx, container, "create")
 
@@ -560,19 +572,22 @@ func (mgr *ContainerManager) Create(ctx context.Context, name string, config *ty
 }
 
 func (mgr *ContainerManager) getDefaultLogConfigIfMissing(logConfig *types.LogConfig) *types.LogConfig {
+	// the default log options could be reloaded.
+	mgr.Config.Lock()
+	defaultConfig := mgr.Config.DefaultLogConfig
 	defaultLogOpts := make(map[string]string)
-	for k, v := range mgr.Config.DefaultLogConfig.LogOpts {
+	for k, v := range defaultConfig.LogOpts {
 		defaultLogOpts[k] = v
 	}
+	mgr.Config.Unlock()
 
 	if logConfig == nil {
-		defaultConfig := mgr.Config.DefaultLogConfig
 		defaultConfig.LogOpts = defaultLogOpts
 		return &defaultConfig
 	}
 
 	if logConfig.LogDriver == "" {
-		logConfig.LogDriver = mgr.Config.DefaultLogConfig.LogDriver
+		logConfig.LogDriver = defaultConfig.LogDriver
 	}
 
 	if len(logConfig.LogOpts) == 0 {
@@ -813,6 +828,7 @@ func (mgr *ContainerManager) createContainerdContainer(ctx context.Context, c *C
 		prioArr:    prioArr,
 		argsArr:    argsArr,
 		useSystemd: mgr.Config.UseSystemd(),
+		ociHooks:   mgr.Config.OCIHooks,
 	}
 
 	if err = createSpec(ctx, c, sw); err != nil {
@@ -964,7 +980,7 @@ func (mgr *ContainerManager) stop(ctx context.Context, c *Container, timeout int
 	}
 
 	id := c.ID
-	msg, err := mgr.Client.DestroyContainer(ctx, id, timeout)
+	msg, err := mgr.Client.DestroyContainer(ctx, id, c.StopSignal(), timeout)
 	if err != nil {
 		return errors.Wrapf(err, "failed to destroy container %s", id)
 	}
@@ -1160,7 +1176,7 @@ func (mgr *ContainerManager) AttachContainerIO(ctx context.Context, name string,
 			io.Copy(pstdinw, oldStdin)
 		}()
 		cfg.Stdin = pstdinr
-		cfg.CloseStdin = true
+		cfg.CloseStdin = !cfg.HonorStdinOnce || c.Config.StdinOnce
 	} else {
 		cfg.UseStdin = false
 	}
@@ -1287,6 +1303,7 @@ func (mgr *ContainerManager) Update(ctx context.Context, name string, config *ty
 			}
 		}
 	}
+	mgr.labelIndex.put(c.ID, c.Config.Labels)
 
 	// update Resources of a container.
 	if err := mgr.updateContainerResources(c, config.Resources); err != nil {
@@ -1373,7 +1390,7 @@ func (mgr *ContainerManager) Remove(ctx context.Context, name string, options *t
 
 	// if the container is running, force to stop it.
 	if c.IsRunningOrPaused() && options.Force {
-		_, err := mgr.Client.DestroyContainer(ctx, c.ID, c.StopTimeout())
+		_, err := mgr.Client.DestroyContainer(ctx, c.ID, c.StopSignal(), c.StopTimeout())
 		if err != nil && !errtypes.IsNotfound(err) {
 			return errors.Wrapf(err, "failed to destroy container %s when removing", c.ID)
 		}
@@ -1408,6 +1425,11 @@ func (mgr *ContainerManager) Remove(ctx context.Context, name string, options *t
 		log.With(ctx).Errorf("failed to remove snapshot of container %s: %v", c.ID, err)
 	}
 
+	// reclaim the quota ids allocated to the container.
+	if err := quota.ReleaseQuotaIDs(c.ID); err != nil {
+		log.With(ctx).Errorf("failed to release quota ids of container %s: %v", c.ID, err)
+	}
+
 	// When removing a container, we have set up such rule for object removing sequences:
 	// 1. container object in pouchd's memory;
 	// 2. meta.json for container in local disk.
@@ -1417,6 +1439,7 @@ func (mgr *ContainerManager) Remove(ctx context.Context, name string, options *t
 	mgr.NameToID.Remove(c.Name)
 	// remove container cache
 	mgr.cache.Remove(c.ID)
+	mgr.labelIndex.remove(c.ID)
 	// remove the container IO
 	mgr.IOs.Remove(c.ID)
 	c.State.Dead = true
@@ -1479,6 +1502,9 @@ func (mgr *ContainerManager) updateContainerResources(c *Container, resources ty
 	if resources.BlkioWeight != 0 {
 		cResources.BlkioWeight = resources.BlkioWeight
 	}
+	if len(resources.BlkioWeightDevice) != 0 {
+		cResources.BlkioWeightDevice = resources.BlkioWeightDevice
+	}
 	if len(resources.BlkioDeviceReadBps) != 0 {
 		cResources.BlkioDeviceReadBps = resources.BlkioDeviceReadBps
 	}
@@ -1523,6 +1549,9 @@ func (mgr *ContainerManager) updateContainerResources(c *Container, resources ty
 	if resources.KernelMemory != 0 {
 		cResources.KernelMemory = resources.KernelMemory
 	}
+	if resources.PidsLimit != 0 {
+		cResources.PidsLimit = resources.PidsLimit
+	}
 
 	return nil
 }
@@ -1586,8 +1615,22 @@ func (mgr *ContainerManager) Resize(ctx context.Context, name string, opts types
 	return mgr.Client.ResizeContainer(ctx, c.ID, opts)
 }
 
-// Wait stops processing until the given container is stopped.
-func (mgr *ContainerManager) Wait(ctx context.Context, name string) (types.ContainerWaitOKBody, error) {
+// Wait stops processing until the given container meets the condition, which
+// is WaitConditionNotRunning if not specified, and returns the exit code.
+func (mgr *ContainerManager) Wait(ctx context.Context, name string, condition string) (types.ContainerWaitOKBody, error) {
+	switch condition {
+	case "":
+		condition = WaitConditionNotRunning
+	case WaitConditionNotRunning, WaitConditionNextExit, WaitConditionRemoved:
+	default:
+		return types.ContainerWaitOKBody{}, errors.Wrapf(errtypes.ErrInvalidParam, "invalid wait condition %q", condition)
+	}
+
+	// subscribe the events before checking the container, so that none of them is missed.
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	_, evch, errch := mgr.eventsService.Subscribe(subCtx, time.Time{}, time.Time{}, nil)
+
 	c, err := mgr.container(name)
 	if err != nil {
 		return types.ContainerWaitOKBody{}, err
@@ -1595,15 +1638,53 @@ func (mgr *ContainerManager) Wait(ctx context.Context, name string) (types.Conta
 
 	ctx = log.AddFields(ctx, map[string]interface{}{"ContainerID": c.ID})
 
-	// We should notice that container's meta data shouldn't be locked in wait process, otherwise waiting for
-	// a running container to stop would make other client commands which manage this container are blocked.
-	// If a container status is exited or stopped, return exit code immediately.
-	if !c.IsRunningOrPaused() {
+	// waitEvent blocks until the event of container happens.
+	waitEvent := func(action string) error {
+		for {
+			select {
+			case ev := <-evch:
+				if ev.Type == types.EventTypeContainer && ev.ID == c.ID && ev.Action == action {
+					return nil
+				}
+			case err := <-errch:
+				// the error channel is closed without error if the context is canceled.
+				if err == nil {
+					err = subCtx.Err()
+				}
+				return errors.Wrapf(err, "failed to wait event %s of container %s", action, c.ID)
+			}
+		}
+	}
+
+	switch condition {
+	case WaitConditionRemoved:
+		if err := waitEvent("destroy"); err != nil {
+			return types.ContainerWaitOKBody{}, err
+		}
 		return types.ContainerWaitOKBody{
 			Error:      c.State.Error,
 			StatusCode: c.ExitCode(),
 		}, nil
+	case WaitConditionNextExit:
+		// the stopped container exits next time after it is started.
+		if !c.IsRunningOrPaused() {
+			if err := waitEvent("start"); err != nil {
+				return types.ContainerWaitOKBody{}, err
+			}
+		}
+	default:
+		// We should notice that container's meta data shouldn't be locked in wait process, otherwise waiting for
+		// a running container to stop would make other client commands which manage this container are blocked.
+		// If a container status is exited or stopped, return exit code immediately.
+		if !c.IsRunningOrPaused() {
+			return types.ContainerWaitOKBody{
+				Error:      c.State.Error,
+				StatusCode: c.ExitCode(),
+			}, nil
+		}
 	}
+	// the events are not needed any more.
+	cancel()
 
 	return mgr.Client.WaitContainer(ctx, c.ID)
 }
@@ -55,6 +55,16 @@ func (mgr *ContainerManager) ResizeExec(ctx context.Context, execid string, opts
 		return err
 	}
 
+	// the exec process is not started yet, keep the size as the initial one,
+	// otherwise the first output is wrapped by the default size.
+	execConfig.Lock()
+	if !execConfig.Running && !execConfig.Exited {
+		execConfig.ConsoleSize = &opts
+		execConfig.Unlock()
+		return nil
+	}
+	execConfig.Unlock()
+
 	return mgr.Client.ResizeExec(ctx, execConfig.ContainerID, execid, opts)
 }
 
@@ -118,6 +128,13 @@ func (mgr *ContainerManager) StartExec(ctx context.Context, execid string, cfg *
 		},
 	}
 
+	if execConfig.Tty && execConfig.ConsoleSize != nil {
+		process.ConsoleSize = &specs.Box{
+			Height: uint(execConfig.ConsoleSize.Height),
+			Width:  uint(execConfig.ConsoleSize.Width),
+		}
+	}
+
 	if execConfig.Privileged {
 		capList := caps.GetAllCapabilities()
 		process.Capabilities = &specs.LinuxCapabilities{
@@ -133,6 +150,11 @@ func (mgr *ContainerManager) StartExec(ctx context.Context, execid string, cfg *
 		process.Capabilities = spec.Process.Capabilities
 	}
 
+	// exec process could not gain more privileges than the container.
+	if !execConfig.Privileged && !c.HostConfig.Privileged {
+		process.NoNewPrivileges = c.NoNewPrivileges
+	}
+
 	// set exec process ulimit, ulimit not decided by exec config
 	if err := setupRlimits(ctx, c.HostConfig, &specs.Spec{Process: process}); err != nil {
 		execConfig.Unlock()
@@ -0,0 +1,98 @@
+package mgr
+
+import (
+	"sync"
+)
+
+// labelIndex indexes the containers by the labels, so that the containers
+// with the specified labels could be found without scanning all containers.
+type labelIndex struct {
+	sync.RWMutex
+	// ids maps the label key and value to the IDs of containers.
+	ids map[string]map[string]struct{}
+	// labels maps the container ID to its indexed labels.
+	labels map[string][]string
+}
+
+func newLabelIndex() *labelIndex {
+	return &labelIndex{
+		ids:    make(map[string]map[string]struct{}),
+		labels: make(map[string][]string),
+	}
+}
+
+func labelIndexKey(k, v string) string {
+	return k + "\x00" + v
+}
+
+// put indexes the container with its labels, the old ones are replaced.
+func (idx *labelIndex) put(id string, labels map[string]string) {
+	idx.Lock()
+	defer idx.Unlock()
+
+	idx.removeLocked(id)
+
+	keys := make([]string, 0, len(labels))
+	for k, v := range labels {
+		key := labelIndexKey(k, v)
+		if idx.ids[key] == nil {
+			idx.ids[key] = make(map[string]struct{})
+		}
+		idx.ids[key][id] = struct{}{}
+		keys = append(keys, key)
+	}
+	idx.labels[id] = keys
+}
+
+// remove deletes the container from the index.
+func (idx *labelIndex) remove(id string) {
+	idx.Lock()
+	defer idx.Unlock()
+
+	idx.removeLocked(id)
+}
+
+func (idx *labelIndex) removeLocked(id string) {
+	for _, key := range idx.labels[id] {
+		delete(idx.ids[key], id)
+		if len(idx.ids[key]) == 0 {
+			delete(idx.ids, key)
+		}
+	}
+	delete(idx.labels, id)
+}
+
+// lookup returns the IDs of containers which have all the labels.
+func (idx *labelIndex) lookup(labels map[string]string) []string {
+	idx.RLock()
+	defer idx.RUnlock()
+
+	// start from the smallest set to make the intersection cheap.
+	var smallest map[string]struct{}
+	for k, v := range labels {
+		set := idx.ids[labelIndexKey(k, v)]
+		if len(set) == 0 {
+			return nil
+		}
+		if smallest == nil || len(set) < len(smallest) {
+			smallest = set
+		}
+	}
+
+	var ids []string
+	for id := range smallest {
+		if idx.match(id, labels) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func (idx *labelIndex) match(id string, labels map[string]string) bool {
+	for k, v := range labels {
+		if _, ok := idx.ids[labelIndexKey(k, v)][id]; !ok {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,87 @@
+package mgr
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/pkg/collect"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLabelIndex(t *testing.T) {
+	idx := newLabelIndex()
+	idx.put("c1", map[string]string{"sandbox": "s1", "type": "container"})
+	idx.put("c2", map[string]string{"sandbox": "s1", "type": "container"})
+	idx.put("s1", map[string]string{"sandbox": "s1", "type": "sandbox"})
+	idx.put("c3", map[string]string{"sandbox": "s2", "type": "container"})
+
+	lookup := func(labels map[string]string) []string {
+		ids := idx.lookup(labels)
+		sort.Strings(ids)
+		return ids
+	}
+
+	assert.Equal(t, []string{"c1", "c2", "s1"}, lookup(map[string]string{"sandbox": "s1"}))
+	assert.Equal(t, []string{"c1", "c2", "c3"}, lookup(map[string]string{"type": "container"}))
+	assert.Equal(t, []string{"c1", "c2"}, lookup(map[string]string{"sandbox": "s1", "type": "container"}))
+	assert.Empty(t, lookup(map[string]string{"sandbox": "s3"}))
+	assert.Empty(t, lookup(map[string]string{"sandbox": "s2", "type": "sandbox"}))
+
+	// the key and value are not ambiguous.
+	idx.put("c4", map[string]string{"a=b": "c"})
+	assert.Empty(t, lookup(map[string]string{"a": "b=c"}))
+
+	// the labels are replaced.
+	idx.put("c1", map[string]string{"sandbox": "s2", "type": "container"})
+	assert.Equal(t, []string{"c2", "s1"}, lookup(map[string]string{"sandbox": "s1"}))
+	assert.Equal(t, []string{"c1", "c3"}, lookup(map[string]string{"sandbox": "s2"}))
+
+	idx.remove("c1")
+	idx.remove("c4")
+	assert.Equal(t, []string{"c3"}, lookup(map[string]string{"sandbox": "s2"}))
+	assert.Len(t, idx.labels, 3)
+	_, exist := idx.ids[labelIndexKey("a=b", "c")]
+	assert.False(t, exist)
+}
+
+func TestListByLabels(t *testing.T) {
+	mgr := &ContainerManager{
+		cache:      collect.NewSafeMap(),
+		labelIndex: newLabelIndex(),
+	}
+	for _, c := range []*Container{
+		{ID: "c1", Config: &types.ContainerConfig{Labels: map[string]string{"sandbox": "s1"}}, State: &types.ContainerState{Status: types.StatusRunning, Running: true}},
+		{ID: "c2", Config: &types.ContainerConfig{Labels: map[string]string{"sandbox": "s1"}}, State: &types.ContainerState{Status: types.StatusExited}},
+		{ID: "c3", Config: &types.ContainerConfig{Labels: map[string]string{"sandbox": "s2"}}, State: &types.ContainerState{Status: types.StatusRunning, Running: true}},
+	} {
+		mgr.cache.Put(c.ID, c)
+		mgr.labelIndex.put(c.ID, c.Config.Labels)
+	}
+
+	ids := func(option *ContainerListOption) []string {
+		containers, err := mgr.List(context.Background(), option)
+		assert.NoError(t, err)
+		var ids []string
+		for _, c := range containers {
+			ids = append(ids, c.ID)
+		}
+		sort.Strings(ids)
+		return ids
+	}
+
+	assert.Equal(t, []string{"c1", "c2"}, ids(&ContainerListOption{All: true, Labels: map[string]string{"sandbox": "s1"}}))
+	assert.Equal(t, []string{"c1"}, ids(&ContainerListOption{Labels: map[string]string{"sandbox": "s1"}}))
+	assert.Equal(t, []string{"c2"}, ids(&ContainerListOption{
+		All:        true,
+		Labels:     map[string]string{"sandbox": "s1"},
+		FilterFunc: func(c *Container) bool { return c.ID == "c2" },
+	}))
+
+	// the labels changed in place but not reindexed are not matched.
+	c, _ := mgr.cache.Get("c3").Result()
+	c.(*Container).Config.Labels["sandbox"] = "s3"
+	assert.Empty(t, ids(&ContainerListOption{All: true, Labels: map[string]string{"sandbox": "s2"}}))
+}
@@ -190,13 +190,31 @@ func (fc *filterContext) filter(c *Container) bool {
 // List returns the container's list.
 func (mgr *ContainerManager) List(ctx context.Context, option *ContainerListOption) ([]*Container, error) {
 	var cons []*Container
-	list := mgr.cache.Values(nil)
 
 	fc, err := newFilterContext(option)
 	if err != nil {
 		return nil, err
 	}
 
+	if option != nil && len(option.Labels) > 0 {
+		for _, id := range mgr.labelIndex.lookup(option.Labels) {
+			c, ok := mgr.cache.Get(id).Result()
+			if !ok {
+				continue
+			}
+			container, ok := c.(*Container)
+			if !ok || !hasLabels(container, option.Labels) {
+				continue
+			}
+
+			if fc.filter(container) {
+				cons = append(cons, container)
+			}
+		}
+		return cons, nil
+	}
+
+	list := mgr.cache.Values(nil)
 	for id, obj := range list {
 		c, ok := obj.(*Container)
 		if !ok {
@@ -211,3 +229,16 @@ func (mgr *ContainerManager) List(ctx context.Context, option *ContainerListOpti
 
 	return cons, nil
 }
+
+// hasLabels checks whether the container has all the labels.
+func hasLabels(c *Container, labels map[string]string) bool {
+	if c.Config == nil {
+		return false
+	}
+	for k, v := range labels {
+		if value, exist := c.Config.Labels[k]; !exist || value != v {
+			return false
+		}
+	}
+	return true
+}
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/pkg/cgroupv2"
 	"github.com/alibaba/pouch/pkg/log"
 
 	"github.com/containerd/cgroups"
@@ -37,8 +38,8 @@ func (mgr *ContainerManager) StreamStats(ctx context.Context, name string, confi
 
 	var preCPUStats *types.CPUStats
 
-	wrapContainerStats := func(metricMeta *containerdtypes.Metric, metric *cgroups.Metrics) (*types.ContainerStats, error) {
-		stats := toContainerStats(c, metricMeta, metric)
+	wrapContainerStats := func(metrics *ContainerMetrics) (*types.ContainerStats, error) {
+		stats := toContainerStats(c, metrics)
 
 		// if the container does not set memory limit, use the machineMemory
 		if stats.MemoryStats.Limit > mgr.Config.MachineMemory && mgr.Config.MachineMemory > 0 {
@@ -64,11 +65,11 @@ func (mgr *ContainerManager) StreamStats(ctx context.Context, name string, confi
 
 	// just collect stats data once.
 	if !config.Stream {
-		metrics, stats, err := mgr.Stats(ctx, name)
+		metrics, err := mgr.Stats(ctx, name)
 		if err != nil {
 			return err
 		}
-		containerStat, err := wrapContainerStats(metrics, stats)
+		containerStat, err := wrapContainerStats(metrics)
 		if err != nil {
 			return errors.Errorf("failed to wrap the containerStat: %v", err)
 		}
@@ -89,12 +90,12 @@ func (mgr *ContainerManager) StreamStats(ctx context.Context, name string, confi
 			return nil
 		default:
 			log.With(nil).Debugf("Start to stream stats of container %s", c.ID)
-			metrics, stats, err := mgr.Stats(ctx, name)
+			metrics, err := mgr.Stats(ctx, name)
 			if err != nil {
 				return err
 			}
 
-			containerStat, err := wrapContainerStats(metrics, stats)
+			containerStat, err := wrapContainerStats(metrics)
 			if err != nil {
 				return errors.Errorf("failed to wrap the containerStat: %v", err)
 			}
@@ -108,10 +109,10 @@ func (mgr *ContainerManager) StreamStats(ctx context.Context, name string, confi
 }
 
 // Stats gets the stat of a container.
-func (mgr *ContainerManager) Stats(ctx context.Context, name string) (*containerdtypes.Metric, *cgroups.Metrics, error) {
+func (mgr *ContainerManager) Stats(ctx context.Context, name string) (*ContainerMetrics, error) {
 	c, err := mgr.container(name)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
 	ctx = log.AddFields(ctx, map[string]interface{}{"ContainerID": c.ID})
@@ -121,23 +122,77 @@ func (mgr *ContainerManager) Stats(ctx context.Context, name string) (*container
 
 	// empty stats for not-running container.
 	if !c.IsRunningOrPaused() {
-		return nil, nil, nil
+		return nil, nil
 	}
 
 	metric, err := mgr.Client.ContainerStats(ctx, c.ID)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
+	return decodeMetrics(metric)
+}
+
+// ContainerMetrics is the metrics of a container got from containerd, only
+// one of Metrics and MetricsV2 is set according to the cgroup version.
+type ContainerMetrics struct {
+	// Meta is the metadata of metrics, like the timestamp.
+	Meta *containerdtypes.Metric
+	// Metrics is the metrics of cgroup v1.
+	Metrics *cgroups.Metrics
+	// MetricsV2 is the metrics of cgroup v2 unified hierarchy.
+	MetricsV2 *cgroupv2.Metrics
+}
+
+// decodeMetrics decodes the metrics of cgroup v1 or v2 reported by containerd.
+func decodeMetrics(metric *containerdtypes.Metric) (*ContainerMetrics, error) {
 	v, err := typeurl.UnmarshalAny(metric.Data)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
-	return metric, v.(*cgroups.Metrics), nil
+	switch m := v.(type) {
+	case *cgroups.Metrics:
+		return &ContainerMetrics{Meta: metric, Metrics: m}, nil
+	case *cgroupv2.Metrics:
+		return &ContainerMetrics{Meta: metric, MetricsV2: m}, nil
+	default:
+		return nil, fmt.Errorf("unknown metrics type %s", metric.Data.TypeUrl)
+	}
 }
 
-func toContainerStats(container *Container, metricMeta *containerdtypes.Metric, metric *cgroups.Metrics) *types.ContainerStats {
+// BatchStats returns the stats of the running or paused containers in a single
+// request to containerd, keyed by the container ID, the others are omitted.
+func (mgr *ContainerManager) BatchStats(ctx context.Context, ids []string) (map[string]*ContainerMetrics, error) {
+	running := make([]string, 0, len(ids))
+	for _, id := range ids {
+		c, err := mgr.container(id)
+		if err != nil {
+			continue
+		}
+		if c.IsRunningOrPaused() {
+			running = append(running, c.ID)
+		}
+	}
+
+	metrics, err := mgr.Client.ContainersStats(ctx, running)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]*ContainerMetrics, len(metrics))
+	for id, metric := range metrics {
+		m, err := decodeMetrics(metric)
+		if err != nil {
+			log.With(ctx).Warnf("failed to decode metrics of container %s: %v", id, err)
+			continue
+		}
+		result[id] = m
+	}
+	return result, nil
+}
+
+func toContainerStats(container *Container, metrics *ContainerMetrics) *types.ContainerStats {
 	res := &types.ContainerStats{
 		ID:          container.ID,
 		Name:        container.Name,
@@ -147,11 +202,17 @@ func toContainerStats(container *Container, metricMeta *containerdtypes.Metric,
 		MemoryStats: &types.MemoryStats{},
 	}
 
-	if metricMeta == nil {
+	if metrics == nil {
 		return res
 	}
 
-	res.Read = strfmt.DateTime(metricMeta.Timestamp)
+	res.Read = strfmt.DateTime(metrics.Meta.Timestamp)
+	if metrics.MetricsV2 != nil {
+		fillContainerStatsV2(res, metrics.MetricsV2)
+		return res
+	}
+
+	metric := metrics.Metrics
 
 	if metric.Pids != nil {
 		res.PidsStats = &types.PidsStats{
@@ -236,6 +297,72 @@ func toContainerStats(container *Container, metricMeta *containerdtypes.Metric,
 	return res
 }
 
+// fillContainerStatsV2 fills the stats with the metrics of cgroup v2, the
+// time in microseconds is converted into nanoseconds as the one of v1.
+func fillContainerStatsV2(res *types.ContainerStats, metric *cgroupv2.Metrics) {
+	if metric.Pids != nil {
+		res.PidsStats = &types.PidsStats{
+			Current: metric.Pids.Current,
+		}
+	}
+
+	if metric.CPU != nil {
+		res.CPUStats.CPUUsage = &types.CPUUsage{
+			TotalUsage:        metric.CPU.UsageUsec * 1000,
+			UsageInKernelmode: metric.CPU.SystemUsec * 1000,
+			UsageInUsermode:   metric.CPU.UserUsec * 1000,
+		}
+		res.CPUStats.ThrottlingData = &types.ThrottlingData{
+			Periods:          metric.CPU.NrPeriods,
+			ThrottledPeriods: metric.CPU.NrThrottled,
+			ThrottledTime:    metric.CPU.ThrottledUsec * 1000,
+		}
+	}
+
+	if metric.Io != nil {
+		var serviceBytes, serviced []*types.BlkioStatEntry
+		for _, entry := range metric.Io.Usage {
+			serviceBytes = append(serviceBytes,
+				&types.BlkioStatEntry{Major: entry.Major, Minor: entry.Minor, Op: "Read", Value: entry.Rbytes},
+				&types.BlkioStatEntry{Major: entry.Major, Minor: entry.Minor, Op: "Write", Value: entry.Wbytes},
+			)
+			serviced = append(serviced,
+				&types.BlkioStatEntry{Major: entry.Major, Minor: entry.Minor, Op: "Read", Value: entry.Rios},
+				&types.BlkioStatEntry{Major: entry.Major, Minor: entry.Minor, Op: "Write", Value: entry.Wios},
+			)
+		}
+		res.BlkioStats = &types.BlkioStats{
+			IoServiceBytesRecursive: serviceBytes,
+			IoServicedRecursive:     serviced,
+		}
+	}
+
+	if metric.Memory != nil {
+		res.MemoryStats = &types.MemoryStats{
+			Stats: map[string]uint64{
+				"anon":           metric.Memory.Anon,
+				"file":           metric.Memory.File,
+				"kernel_stack":   metric.Memory.KernelStack,
+				"slab":           metric.Memory.Slab,
+				"sock":           metric.Memory.Sock,
+				"shmem":          metric.Memory.Shmem,
+				"file_mapped":    metric.Memory.FileMapped,
+				"file_dirty":     metric.Memory.FileDirty,
+				"file_writeback": metric.Memory.FileWriteback,
+				"inactive_anon":  metric.Memory.InactiveAnon,
+				"active_anon":    metric.Memory.ActiveAnon,
+				"inactive_file":  metric.Memory.InactiveFile,
+				"active_file":    metric.Memory.ActiveFile,
+				"unevictable":    metric.Memory.Unevictable,
+				"pgfault":        metric.Memory.Pgfault,
+				"pgmajfault":     metric.Memory.Pgmajfault,
+			},
+			Usage: metric.Memory.Usage,
+			Limit: metric.Memory.UsageLimit,
+		}
+	}
+}
+
 func toContainerBlkioStatsEntry(statEntrys []*cgroups.BlkIOEntry) []*types.BlkioStatEntry {
 	blkioStatEntrys := []*types.BlkioStatEntry{}
 	for _, item := range statEntrys {
@@ -0,0 +1,46 @@
+package mgr
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alibaba/pouch/pkg/cgroupv2"
+
+	containerdtypes "github.com/containerd/containerd/api/types"
+	"github.com/gogo/protobuf/proto"
+	prototypes "github.com/gogo/protobuf/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeMetricsV2(t *testing.T) {
+	data, err := proto.Marshal(&cgroupv2.Metrics{
+		Pids:   &cgroupv2.PidsStat{Current: 3},
+		CPU:    &cgroupv2.CPUStat{UsageUsec: 100, UserUsec: 60, SystemUsec: 40},
+		Memory: &cgroupv2.MemoryStat{Usage: 4096, UsageLimit: 8192, InactiveFile: 1024},
+		Io:     &cgroupv2.IOStat{Usage: []*cgroupv2.IOEntry{{Major: 8, Rbytes: 512, Wbytes: 256}}},
+	})
+	assert.NoError(t, err)
+
+	metrics, err := decodeMetrics(&containerdtypes.Metric{
+		Timestamp: time.Now(),
+		ID:        "c1",
+		Data:      &prototypes.Any{TypeUrl: cgroupv2.MetricsTypeURL, Value: data},
+	})
+	assert.NoError(t, err)
+	assert.Nil(t, metrics.Metrics)
+	assert.Equal(t, uint64(3072), metrics.MetricsV2.Memory.WorkingSet())
+
+	stats := toContainerStats(&Container{ID: "c1"}, metrics)
+	assert.Equal(t, uint64(3), stats.PidsStats.Current)
+	assert.Equal(t, uint64(100000), stats.CPUStats.CPUUsage.TotalUsage)
+	assert.Equal(t, uint64(40000), stats.CPUStats.CPUUsage.UsageInKernelmode)
+	assert.Equal(t, uint64(4096), stats.MemoryStats.Usage)
+	assert.Equal(t, uint64(8192), stats.MemoryStats.Limit)
+	assert.Len(t, stats.BlkioStats.IoServiceBytesRecursive, 2)
+
+	// the unknown metrics are errors.
+	_, err = decodeMetrics(&containerdtypes.Metric{
+		Data: &prototypes.Any{TypeUrl: "io.containerd.cgroups.v3.Metrics"},
+	})
+	assert.Error(t, err)
+}
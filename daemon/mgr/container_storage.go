@@ -24,9 +24,18 @@ import (
 	volumetypes "github.com/alibaba/pouch/storage/volume/types"
 
 	"github.com/containerd/containerd/mount"
+	"github.com/opencontainers/selinux/go-selinux/label"
 	"github.com/pkg/errors"
 )
 
+var (
+	// setSnapshotDiskQuota, setRootfsDiskQuota and setDirDiskQuota set the disk
+	// quotas, which are replaced in tests.
+	setSnapshotDiskQuota = quota.SetSnapshotDiskQuota
+	setRootfsDiskQuota   = quota.SetRootfsDiskQuota
+	setDirDiskQuota      = quota.SetDiskQuota
+)
+
 func (mgr *ContainerManager) attachVolume(ctx context.Context, name string, c *Container) (string, string, error) {
 	driver := volumetypes.DefaultBackend
 	v, err := mgr.VolumeMgr.Get(ctx, name)
@@ -400,6 +409,17 @@ func (mgr *ContainerManager) populateVolumes(ctx context.Context, c *Container,
 				return errors.Wrapf(err, "failed to mkdir %q", mp.Source)
 			}
 		}
+
+		// relabel the source with the mount label of container, z shares it
+		// among all the containers while Z makes it private to the container.
+		if label.RelabelNeeded(mp.Mode) {
+			if err := label.Validate(mp.Mode); err != nil {
+				return errors.Wrapf(err, "invalid bind mode %q of %q", mp.Mode, mp.Destination)
+			}
+			if err := label.Relabel(mp.Source, c.MountLabel, label.IsShared(mp.Mode)); err != nil {
+				return errors.Wrapf(err, "failed to relabel %q", mp.Source)
+			}
+		}
 	}
 
 	return nil
@@ -499,10 +519,14 @@ func (mgr *ContainerManager) getDiskQuotaMountPoints(ctx context.Context, c *Con
 		mounts = append(mounts, mp)
 	}
 
-	// add rootfs mountpoint
-	rootfs, err := mgr.getRootfs(ctx, c, mounted)
-	if err != nil {
-		return nil, errors.Wrapf(err, "failed to get rootfs")
+	// add rootfs mountpoint, the quota of rootfs is set on the upper dir of
+	// snapshot if known, otherwise it is found in the mount of rootfs.
+	rootfs, _, ok := snapshotQuotaDirs(c)
+	if !ok {
+		var err error
+		if rootfs, err = mgr.getRootfs(ctx, c, mounted); err != nil {
+			return nil, errors.Wrapf(err, "failed to get rootfs")
+		}
 	}
 	mounts = append(mounts, &types.MountPoint{
 		Source:      rootfs,
@@ -527,7 +551,7 @@ func (mgr *ContainerManager) prepareQuotaMap(ctx context.Context, c *Container,
 
 		// if QuotaID is < 0, it means pouchd alloc a unique quota id.
 		if id < 0 {
-			globalQuotaID, err = quota.GetNextQuotaID()
+			globalQuotaID, err = quota.AllocateQuotaID(c.ID, "QuotaID")
 			if err != nil {
 				return nil, errors.Wrap(err, "failed to get next quota id")
 			}
@@ -593,7 +617,7 @@ func (mgr *ContainerManager) prepareQuotaMap(ctx context.Context, c *Container,
 				// get new quota id
 				id := globalQuotaID
 				if id == 0 {
-					id, err = quota.GetNextQuotaID()
+					id, err = quota.AllocateQuotaID(c.ID, qm.Destination)
 					if err != nil {
 						return nil, errors.Wrap(err, "failed to get next quota id")
 					}
@@ -619,29 +643,48 @@ func checkDupQuotaMap(qms []*quota.QMap, qm *quota.QMap) *quota.QMap {
 	return nil
 }
 
+// snapshotQuotaDirs returns the upper and work dir of the overlay snapshot of
+// container, which are known since the snapshot is prepared no matter whether
+// the rootfs is mounted.
+func snapshotQuotaDirs(c *Container) (string, string, bool) {
+	if c.Snapshotter == nil || c.Snapshotter.Data == nil {
+		return "", "", false
+	}
+	upperDir, workDir := c.Snapshotter.Data["UpperDir"], c.Snapshotter.Data["WorkDir"]
+	return upperDir, workDir, upperDir != "" && workDir != ""
+}
+
 func (mgr *ContainerManager) setDiskQuota(ctx context.Context, c *Container, update bool, qms []*quota.QMap) error {
-	var (
-		err error
-	)
+	var errMsgs []string
 
 	// make quota effective
 	for _, qm := range qms {
 		if qm.Destination == "/" {
-			// set rootfs quota
-			_, err = quota.SetRootfsDiskQuota(qm.Source, qm.Size, qm.QuotaID, update)
+			// set rootfs quota on the writable layer of snapshot.
+			var err error
+			if upperDir, workDir, ok := snapshotQuotaDirs(c); ok {
+				_, err = setSnapshotDiskQuota(upperDir, workDir, qm.Size, qm.QuotaID, update)
+			} else {
+				_, err = setRootfsDiskQuota(qm.Source, qm.Size, qm.QuotaID, update)
+			}
 			if err != nil {
 				log.With(ctx).Warnf("failed to set rootfs quota, mountfs(%s), size(%s), quota id(%d), err(%v)",
 					qm.Source, qm.Size, qm.QuotaID, err)
+				errMsgs = append(errMsgs, err.Error())
 			}
 		} else {
-			err := quota.SetDiskQuota(qm.Source, qm.Size, qm.QuotaID)
+			err := setDirDiskQuota(qm.Source, qm.Size, qm.QuotaID)
 			if err != nil {
 				log.With(ctx).Warnf("failed to set disk quota, directory(%s), size(%s), quota id(%d), err(%v)",
 					qm.Source, qm.Size, qm.QuotaID, err)
+				errMsgs = append(errMsgs, err.Error())
 			}
 		}
 	}
 
+	if len(errMsgs) != 0 {
+		return errors.New(strings.Join(errMsgs, "\n"))
+	}
 	return nil
 }
 
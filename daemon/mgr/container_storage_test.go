@@ -1,6 +1,8 @@
 package mgr
 
 import (
+	"context"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -8,6 +10,9 @@ import (
 	"testing"
 
 	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/storage/quota"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestSortMountPoint(t *testing.T) {
@@ -127,3 +132,61 @@ func TestCopyOwnership(t *testing.T) {
 		t.Fatalf("Gid %d is not equal to %d", sysInfo.Gid, uint32(300))
 	}
 }
+
+func TestSetDiskQuotaOnSnapshot(t *testing.T) {
+	origSnapshot, origRootfs, origDir := setSnapshotDiskQuota, setRootfsDiskQuota, setDirDiskQuota
+	defer func() {
+		setSnapshotDiskQuota, setRootfsDiskQuota, setDirDiskQuota = origSnapshot, origRootfs, origDir
+	}()
+
+	var calls []string
+	setSnapshotDiskQuota = func(upperDir, workDir, size string, quotaID uint32, update bool) (uint32, error) {
+		calls = append(calls, fmt.Sprintf("snapshot %s %s %s %d %v", upperDir, workDir, size, quotaID, update))
+		return quotaID, nil
+	}
+	setRootfsDiskQuota = func(basefs, size string, quotaID uint32, update bool) (uint32, error) {
+		calls = append(calls, fmt.Sprintf("rootfs %s %s %d %v", basefs, size, quotaID, update))
+		return quotaID, nil
+	}
+	setDirDiskQuota = func(dir, size string, quotaID uint32) error {
+		return fmt.Errorf("quota is not enabled on %s", dir)
+	}
+
+	mgr := &ContainerManager{}
+	c := &Container{
+		Snapshotter: &types.SnapshotterData{
+			Data: map[string]string{"UpperDir": "/snapshots/1/fs", "WorkDir": "/snapshots/1/work"},
+		},
+	}
+	qms := []*quota.QMap{{Source: "/snapshots/1/fs", Destination: "/", Size: "10g", QuotaID: 1000}}
+
+	// the quota of rootfs is set on the snapshot even though the rootfs is not mounted.
+	assert.NoError(t, mgr.setDiskQuota(context.Background(), c, true, qms))
+	assert.Equal(t, []string{"snapshot /snapshots/1/fs /snapshots/1/work 10g 1000 true"}, calls)
+
+	// the mount of rootfs is looked up if the snapshot is unknown.
+	calls = nil
+	c.Snapshotter = nil
+	qms[0].Source = "/merged"
+	assert.NoError(t, mgr.setDiskQuota(context.Background(), c, false, qms))
+	assert.Equal(t, []string{"rootfs /merged 10g 1000 false"}, calls)
+
+	// the failure is reported.
+	qms = append(qms, &quota.QMap{Source: "/volume", Destination: "/data", Size: "1g", QuotaID: 1001})
+	assert.Error(t, mgr.setDiskQuota(context.Background(), c, true, qms))
+}
+
+func TestSnapshotQuotaDirs(t *testing.T) {
+	_, _, ok := snapshotQuotaDirs(&Container{})
+	assert.False(t, ok)
+
+	_, _, ok = snapshotQuotaDirs(&Container{Snapshotter: &types.SnapshotterData{Data: map[string]string{"UpperDir": "/fs"}}})
+	assert.False(t, ok)
+
+	upperDir, workDir, ok := snapshotQuotaDirs(&Container{Snapshotter: &types.SnapshotterData{
+		Data: map[string]string{"UpperDir": "/fs", "WorkDir": "/work"},
+	}})
+	assert.True(t, ok)
+	assert.Equal(t, "/fs", upperDir)
+	assert.Equal(t, "/work", workDir)
+}
@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/alibaba/pouch/apis/types"
@@ -17,6 +18,7 @@ import (
 	"github.com/alibaba/pouch/pkg/log"
 	"github.com/alibaba/pouch/pkg/meta"
 	"github.com/alibaba/pouch/pkg/utils"
+	"github.com/alibaba/pouch/pkg/utils/signal"
 
 	"github.com/containerd/containerd/mount"
 	v1 "github.com/opencontainers/image-spec/specs-go/v1"
@@ -98,6 +100,17 @@ const (
 	RuntimeDir = "runtimes"
 )
 
+const (
+	// WaitConditionNotRunning waits until the container is not running.
+	WaitConditionNotRunning = "not-running"
+
+	// WaitConditionNextExit waits until the container exits next time.
+	WaitConditionNextExit = "next-exit"
+
+	// WaitConditionRemoved waits until the container is removed.
+	WaitConditionRemoved = "removed"
+)
+
 // ContainerFilter defines a function to filter
 // container in the store.
 type ContainerFilter func(*Container) bool
@@ -135,6 +148,10 @@ type ContainerExecConfig struct {
 
 	// Exited means exec process exit or not
 	Exited bool
+
+	// ConsoleSize is the initial size of tty, which is set by the resize
+	// before the exec process is started.
+	ConsoleSize *types.ResizeOptions
 }
 
 // AttachConfig wraps some infos of attaching.
@@ -168,6 +185,9 @@ type ContainerListOption struct {
 	All        bool
 	Filter     map[string][]string
 	FilterFunc ContainerFilter
+	// Labels selects the containers having all the labels through the
+	// label index, before the other filters are applied.
+	Labels map[string]string
 }
 
 // ContainerStatsConfig contains all configs on stats interface.
@@ -310,6 +330,17 @@ func (c *Container) StopTimeout() int64 {
 	return DefaultStopTimeout
 }
 
+// StopSignal returns the signal used to stop the container, which is the
+// one in config if valid, otherwise SIGTERM.
+func (c *Container) StopSignal() syscall.Signal {
+	if c.Config.StopSignal != "" {
+		if sig, err := signal.ParseSignal(c.Config.StopSignal); err == nil {
+			return sig
+		}
+	}
+	return syscall.SIGTERM
+}
+
 func (c *Container) merge(getconfig func() (v1.ImageConfig, error)) error {
 	imageConf, err := getconfig()
 	if err != nil {
@@ -445,7 +476,7 @@ func (c *Container) UnsetMergedDir() {
 }
 
 // SetSnapshotterMeta sets snapshotter for container
-func (c *Container) SetSnapshotterMeta(mounts []mount.Mount) {
+func (c *Container) SetSnapshotterMeta(ctx context.Context, mounts []mount.Mount) {
 	// TODO(ziren): now we only support overlayfs
 	data := make(map[string]string)
 	for _, opt := range mounts[0].Options {
@@ -461,7 +492,7 @@ func (c *Container) SetSnapshotterMeta(mounts []mount.Mount) {
 	}
 
 	c.Snapshotter = &types.SnapshotterData{
-		Name: ctrd.CurrentSnapshotterName(context.TODO()),
+		Name: ctrd.CurrentSnapshotterName(ctx),
 		Data: data,
 	}
 }
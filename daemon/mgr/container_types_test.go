@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"reflect"
 	"sort"
+	"syscall"
 	"testing"
 	"time"
 
@@ -256,3 +257,19 @@ func TestMerge(t *testing.T) {
 		assert.Equal(true, ret, fmt.Sprintf("test %d fails\n %+v should equal with %+v\n", idx, tc.c.Config, tc.expected))
 	}
 }
+
+func TestContainerStopSignal(t *testing.T) {
+	for _, tc := range []struct {
+		stopSignal string
+		expected   syscall.Signal
+	}{
+		{stopSignal: "", expected: syscall.SIGTERM},
+		{stopSignal: "SIGQUIT", expected: syscall.SIGQUIT},
+		{stopSignal: "usr1", expected: syscall.SIGUSR1},
+		{stopSignal: "9", expected: syscall.SIGKILL},
+		{stopSignal: "SIGFOO", expected: syscall.SIGTERM},
+	} {
+		c := &Container{Config: &types.ContainerConfig{StopSignal: tc.stopSignal}}
+		assert.Equal(t, tc.expected, c.StopSignal(), tc.stopSignal)
+	}
+}
@@ -110,6 +110,7 @@ func (mgr *ContainerManager) Upgrade(ctx context.Context, name string, config *t
 
 	// Upgrade succeeded, refresh the cache
 	mgr.cache.Put(c.ID, c)
+	mgr.labelIndex.put(c.ID, c.Config.Labels)
 
 	// Works fine, store new container info to disk.
 	if err := c.Write(mgr.Store); err != nil {
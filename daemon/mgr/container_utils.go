@@ -152,7 +152,7 @@ func (mgr *ContainerManager) generateRuntimeOptions(runtime string) (interface{}
 			CriuPath:      o.CriuPath,
 			SystemdCgroup: mgr.Config.UseSystemd(),
 		}
-	// io.containerd.runc.v1
+	// io.containerd.runc.v1 and io.containerd.runc.v2
 	case *runcoptions.Options:
 		options = &runcoptions.Options{
 			NoPivotRoot:   o.NoPivotRoot,
@@ -234,6 +234,12 @@ func parseSecurityOpts(c *Container, securityOpts []string) error {
 			c.SeccompProfile = value
 		case "label":
 			labelOpts = append(labelOpts, value)
+		case "no-new-privileges":
+			noNewPrivileges, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("invalid --security-opt %s: no-new-privileges should be a boolean", securityOpt)
+			}
+			c.NoNewPrivileges = noNewPrivileges
 		default:
 			return fmt.Errorf("invalid type %s in --security-opt %s: unknown type from apparmor, seccomp, no-new-privileges and SELinux label", key, securityOpt)
 		}
@@ -129,6 +129,22 @@ func Test_parseSecurityOpt(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "valid no-new-privileges option",
+			args: args{
+				meta:        &Container{},
+				securityOpt: "no-new-privileges=true",
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid no-new-privileges option",
+			args: args{
+				meta:        &Container{},
+				securityOpt: "no-new-privileges=yes",
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -242,6 +242,12 @@ func validateResource(r *types.Resources, update bool) ([]string, error) {
 			warnings = append(warnings, CPUSharesWarn)
 			r.CPUShares = 0
 		}
+		// cpu shares is translated into cpu.weight in range [1, 10000] on cgroup v2.
+		if r.CPUShares > 0 && system.IsCgroup2UnifiedMode() {
+			if r.CPUShares < 2 || system.CPUSharesToCPUWeight(uint64(r.CPUShares)) > 10000 {
+				return warnings, fmt.Errorf("CPU shares should be in range [2, 262144] on cgroup v2")
+			}
+		}
 		if r.CPUQuota > 0 && !cgroupInfo.CPU.CPUQuota {
 			log.With(nil).Warn(CPUQuotaWarn)
 			warnings = append(warnings, CPUQuotaWarn)
@@ -295,6 +301,16 @@ func validateResource(r *types.Resources, update bool) ([]string, error) {
 		}
 	}
 
+	// blkio.weight and blkio.weight_device only accept the weight in range [10, 1000]
+	if r.BlkioWeight > 0 && (r.BlkioWeight < 10 || r.BlkioWeight > 1000) {
+		return warnings, fmt.Errorf("Blkio weight should be in range [10, 1000]")
+	}
+	for _, dev := range r.BlkioWeightDevice {
+		if dev.Weight < 10 || dev.Weight > 1000 {
+			return warnings, fmt.Errorf("Blkio weight of device %s should be in range [10, 1000]", dev.Path)
+		}
+	}
+
 	// validates pid cgroup value
 	if cgroupInfo.Pids != nil {
 		if r.PidsLimit != 0 && !cgroupInfo.Pids.Pids {
@@ -0,0 +1,62 @@
+package mgr
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/daemon/events"
+	"github.com/alibaba/pouch/pkg/collect"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWaitCondition(t *testing.T) {
+	mgr := &ContainerManager{
+		cache:         collect.NewSafeMap(),
+		eventsService: events.NewEvents(),
+	}
+	c := &Container{
+		ID:     "c1",
+		Config: &types.ContainerConfig{},
+		State:  &types.ContainerState{Status: types.StatusExited, ExitCode: 3},
+	}
+	mgr.cache.Put(c.ID, c)
+	ctx := context.Background()
+
+	_, err := mgr.Wait(ctx, c.ID, "exited")
+	assert.Error(t, err)
+
+	// the stopped container returns immediately.
+	body, err := mgr.Wait(ctx, c.ID, "")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), body.StatusCode)
+
+	// the removed condition waits for the destroy event.
+	done := make(chan types.ContainerWaitOKBody)
+	go func() {
+		body, err := mgr.Wait(ctx, c.ID, WaitConditionRemoved)
+		assert.NoError(t, err)
+		done <- body
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("should wait until the container is removed")
+	case <-time.After(100 * time.Millisecond):
+	}
+	mgr.LogContainerEvent(ctx, c, "destroy")
+	select {
+	case body := <-done:
+		assert.Equal(t, int64(3), body.StatusCode)
+	case <-time.After(time.Second):
+		t.Fatal("should return after the container is removed")
+	}
+
+	// the canceled wait returns error.
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = mgr.Wait(cctx, c.ID, WaitConditionNextExit)
+	assert.Error(t, err)
+}
@@ -11,6 +11,7 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/alibaba/pouch/apis/filters"
@@ -58,6 +59,9 @@ type ImageMgr interface {
 	// LookupImageReferences find possible image reference list.
 	LookupImageReferences(ref string) []string
 
+	// SetRegistryMirrors replaces the registry mirrors.
+	SetRegistryMirrors(mirrors []string)
+
 	// PullImage pulls images from specified registry.
 	PullImage(ctx context.Context, ref string, authConfig *types.AuthConfig, out io.Writer) error
 
@@ -114,6 +118,9 @@ type ImageManager struct {
 	// RegistryMirrors is a list of registry URLs that act as a mirror for the default registry.
 	RegistryMirrors []string
 
+	// mirrorsLock protects RegistryMirrors which could be reloaded.
+	mirrorsLock sync.RWMutex
+
 	// client is a interface to the containerd client.
 	// It is used to interact with containerd.
 	client ctrd.APIClient
@@ -173,9 +180,11 @@ func (mgr *ImageManager) LookupImageReferences(ref string) []string {
 
 	// if the domain field is empty, concat the ref with registry mirror urls.
 	if registry == "" {
+		mgr.mirrorsLock.RLock()
 		for _, reg := range mgr.RegistryMirrors {
 			fullRefs = append(fullRefs, path.Join(reg, ref))
 		}
+		mgr.mirrorsLock.RUnlock()
 		registry = mgr.DefaultRegistry
 	}
 
@@ -189,6 +198,13 @@ func (mgr *ImageManager) LookupImageReferences(ref string) []string {
 	return fullRefs
 }
 
+// SetRegistryMirrors replaces the registry mirrors.
+func (mgr *ImageManager) SetRegistryMirrors(mirrors []string) {
+	mgr.mirrorsLock.Lock()
+	defer mgr.mirrorsLock.Unlock()
+	mgr.RegistryMirrors = mirrors
+}
+
 // PullImage pulls images from specified registry.
 func (mgr *ImageManager) PullImage(ctx context.Context, ref string, authConfig *types.AuthConfig, out io.Writer) error {
 	namedRef, err := reference.Parse(ref)
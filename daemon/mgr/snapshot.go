@@ -3,6 +3,7 @@ package mgr
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"sync"
 	"time"
 
@@ -10,6 +11,7 @@ import (
 
 	"github.com/alibaba/pouch/pkg/errtypes"
 	"github.com/alibaba/pouch/pkg/log"
+	"github.com/alibaba/pouch/pkg/meta"
 	"github.com/containerd/containerd/snapshots"
 	"github.com/pkg/errors"
 )
@@ -18,6 +20,8 @@ import (
 type Snapshot struct {
 	// Key is the key of the snapshot
 	Key string
+	// Snapshotter is the name of snapshotter the snapshot belongs to.
+	Snapshotter string
 	// Kind is the kind of the snapshot (active, committed, view)
 	Kind snapshots.Kind
 	// Size is the size of the snapshot in bytes.
@@ -29,10 +33,27 @@ type Snapshot struct {
 	Timestamp int64
 }
 
-// SnapshotStore stores all snapshots.
+// snapshotsMetaKey is the key of snapshots persisted in the meta store.
+const snapshotsMetaKey = "snapshots"
+
+// snapshotsMeta is the persisted form of all snapshots in the snapshot store.
+type snapshotsMeta struct {
+	Snapshots []Snapshot
+}
+
+// Key returns the key of snapshots in the meta store.
+func (m *snapshotsMeta) Key() string {
+	return snapshotsMetaKey
+}
+
+// SnapshotStore stores all snapshots of all snapshotters.
 type SnapshotStore struct {
-	lock      sync.RWMutex
+	lock sync.RWMutex
+	// snapshots are indexed by snapshotter and key, since the snapshots of
+	// the same layer in different snapshotters have the same key.
 	snapshots map[string]Snapshot
+	// metaStore persists the snapshots, nil if they are only kept in memory.
+	metaStore *meta.Store
 }
 
 // NewSnapshotStore create a new snapshot store.
@@ -40,22 +61,70 @@ func NewSnapshotStore() *SnapshotStore {
 	return &SnapshotStore{snapshots: make(map[string]Snapshot)}
 }
 
+// NewPersistentSnapshotStore creates a snapshot store persisted in baseDir, and loads
+// the snapshots persisted before, so that the stats are available right after restart.
+func NewPersistentSnapshotStore(baseDir string) (*SnapshotStore, error) {
+	metaStore, err := meta.NewStore(meta.Config{
+		Driver:  "local",
+		BaseDir: baseDir,
+		Buckets: []meta.Bucket{
+			{
+				Name: meta.MetaJSONFile,
+				Type: reflect.TypeOf(snapshotsMeta{}),
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create snapshot meta store: %v", err)
+	}
+
+	s := &SnapshotStore{
+		snapshots: make(map[string]Snapshot),
+		metaStore: metaStore,
+	}
+
+	obj, err := metaStore.Get(snapshotsMetaKey)
+	if err != nil {
+		if err == meta.ErrObjectNotFound {
+			return s, nil
+		}
+		return nil, fmt.Errorf("failed to load snapshots from meta store: %v", err)
+	}
+	for _, sn := range obj.(*snapshotsMeta).Snapshots {
+		s.snapshots[snapshotStoreKey(sn.Snapshotter, sn.Key)] = sn
+	}
+	return s, nil
+}
+
+// Persist saves all snapshots into the meta store. It's a no-op if the store is in memory.
+func (s *SnapshotStore) Persist() error {
+	if s.metaStore == nil {
+		return nil
+	}
+	return s.metaStore.Put(&snapshotsMeta{Snapshots: s.List()})
+}
+
+// snapshotStoreKey returns the index of snapshot in the snapshot store.
+func snapshotStoreKey(snapshotter, key string) string {
+	return snapshotter + "/" + key
+}
+
 // Add a snapshot into the store.
 func (s *SnapshotStore) Add(sn Snapshot) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
-	s.snapshots[sn.Key] = sn
+	s.snapshots[snapshotStoreKey(sn.Snapshotter, sn.Key)] = sn
 }
 
-// Get returns the snapshot with specified key. Returns error if the
-// snapshot doesn't exist.
-func (s *SnapshotStore) Get(key string) (Snapshot, error) {
+// Get returns the snapshot with specified key in the snapshotter. Returns
+// error if the snapshot doesn't exist.
+func (s *SnapshotStore) Get(snapshotter, key string) (Snapshot, error) {
 	s.lock.RLock()
 	defer s.lock.RUnlock()
-	if sn, ok := s.snapshots[key]; ok {
+	if sn, ok := s.snapshots[snapshotStoreKey(snapshotter, key)]; ok {
 		return sn, nil
 	}
-	return Snapshot{}, errors.Wrapf(errtypes.ErrNotfound, "snapshot %s", key)
+	return Snapshot{}, errors.Wrapf(errtypes.ErrNotfound, "snapshot %s of %s", key, snapshotter)
 }
 
 // List lists all snapshots.
@@ -69,18 +138,20 @@ func (s *SnapshotStore) List() []Snapshot {
 	return snapshots
 }
 
-// Delete deletes the snapshot with specified key.
-func (s *SnapshotStore) Delete(key string) {
+// Delete deletes the snapshot with specified key in the snapshotter.
+func (s *SnapshotStore) Delete(snapshotter, key string) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
-	delete(s.snapshots, key)
+	delete(s.snapshots, snapshotStoreKey(snapshotter, key))
 }
 
 // SnapshotsSyncer syncs snapshot stats periodically.
 type SnapshotsSyncer struct {
 	store      *SnapshotStore
 	client     ctrd.APIClient
+	lock       sync.Mutex
 	syncPeriod time.Duration
+	tick       *time.Ticker
 }
 
 // newSnapshotsSyncer creates a snapshot syncer.
@@ -94,7 +165,11 @@ func newSnapshotsSyncer(store *SnapshotStore, cli ctrd.APIClient, period time.Du
 
 // Start starts the snapshots syncer.
 func (s *SnapshotsSyncer) Start() {
-	tick := time.NewTicker(s.syncPeriod)
+	s.lock.Lock()
+	s.tick = time.NewTicker(s.syncPeriod)
+	tick := s.tick
+	s.lock.Unlock()
+
 	go func() {
 		defer tick.Stop()
 		for {
@@ -108,19 +183,69 @@ func (s *SnapshotsSyncer) Start() {
 	}()
 }
 
-// Sync updates the snapshots in the snapshot store.
+// SetPeriod changes the sync period, the next sync is a period after it's changed.
+func (s *SnapshotsSyncer) SetPeriod(period time.Duration) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.syncPeriod = period
+	if s.tick != nil {
+		s.tick.Reset(period)
+	}
+}
+
+// Sync updates the snapshots of all the snapshotters in the snapshot store.
 func (s *SnapshotsSyncer) Sync() error {
 	start := time.Now().UnixNano()
+
+	snapshotters, err := s.client.ListSnapshotters(context.Background())
+	if err != nil || len(snapshotters) == 0 {
+		log.With(nil).Warnf("failed to list snapshotters, only sync the current one: %v", err)
+		snapshotters = []string{ctrd.CurrentSnapshotterName(context.Background())}
+	}
+
+	// the snapshots of the snapshotters failed to walk are kept.
+	failed := make(map[string]bool)
+	for _, snapshotter := range snapshotters {
+		if err := s.syncSnapshotter(snapshotter); err != nil {
+			if snapshotter == ctrd.CurrentSnapshotterName(context.Background()) {
+				return err
+			}
+			log.With(nil).Warnf("failed to sync snapshot stats: %v", err)
+			failed[snapshotter] = true
+		}
+	}
+
+	for _, sn := range s.store.List() {
+		if sn.Timestamp > start || failed[sn.Snapshotter] {
+			continue
+		}
+		// Delete the snapshot stats if it's not updated this time.
+		// When remove a container,you also need to remove snapshot.
+		// However, SnapshotStore will not be notified.
+		// So wo need to delete snapshots from SnapshotStore that doesn't exist actually.
+		s.store.Delete(sn.Snapshotter, sn.Key)
+	}
+
+	if err := s.store.Persist(); err != nil {
+		return fmt.Errorf("failed to persist snapshot stats: %v", err)
+	}
+	return nil
+}
+
+// syncSnapshotter updates the snapshots of the snapshotter in the snapshot store.
+func (s *SnapshotsSyncer) syncSnapshotter(snapshotter string) error {
+	ctx := ctrd.WithSnapshotter(context.Background(), snapshotter)
+
 	var infos []snapshots.Info
-	err := s.client.WalkSnapshot(context.Background(), "", func(ctx context.Context, info snapshots.Info) error {
+	err := s.client.WalkSnapshot(ctx, snapshotter, func(ctx context.Context, info snapshots.Info) error {
 		infos = append(infos, info)
 		return nil
 	})
 	if err != nil {
-		return fmt.Errorf("failed to walk all snapshots: %v", err)
+		return fmt.Errorf("failed to walk all snapshots of %s: %v", snapshotter, err)
 	}
 	for _, info := range infos {
-		sn, err := s.store.Get(info.Name)
+		sn, err := s.store.Get(snapshotter, info.Name)
 		if err == nil {
 			// Only update timestamp for non-active snapshot.
 			if sn.Kind == info.Kind && sn.Kind != snapshots.KindActive {
@@ -131,29 +256,19 @@ func (s *SnapshotsSyncer) Sync() error {
 		}
 		// Get newest stats if the snapshot is new or active.
 		sn = Snapshot{
-			Key:       info.Name,
-			Kind:      info.Kind,
-			Timestamp: time.Now().UnixNano(),
+			Key:         info.Name,
+			Snapshotter: snapshotter,
+			Kind:        info.Kind,
+			Timestamp:   time.Now().UnixNano(),
 		}
-		usage, err := s.client.GetSnapshotUsage(context.Background(), info.Name)
+		usage, err := s.client.GetSnapshotUsage(ctx, info.Name)
 		if err != nil {
-			log.With(nil).Warnf("failed to get usage for snapshot %q: %v", info.Name, err)
+			log.With(nil).Warnf("failed to get usage for snapshot %q of %s: %v", info.Name, snapshotter, err)
 			continue
 		}
 		sn.Size = uint64(usage.Size)
 		sn.Inodes = uint64(usage.Inodes)
 		s.store.Add(sn)
 	}
-	for _, sn := range s.store.List() {
-		if sn.Timestamp > start {
-			continue
-		}
-		// Delete the snapshot stats if it's not updated this time.
-		// When remove a container,you also need to remove snapshot.
-		// However, SnapshotStore will not be notified.
-		// So wo need to delete snapshots from SnapshotStore that doesn't exist actually.
-		s.store.Delete(sn.Key)
-	}
-
 	return nil
 }
@@ -1,6 +1,8 @@
 package mgr
 
 import (
+	"io/ioutil"
+	"os"
 	"testing"
 	"time"
 
@@ -12,25 +14,28 @@ import (
 func Test_SnapshotStore(t *testing.T) {
 	snapshots := map[string]Snapshot{
 		"key1": {
-			Key:       "key1",
-			Kind:      snapshot.KindActive,
-			Size:      10,
-			Inodes:    100,
-			Timestamp: time.Now().UnixNano(),
+			Key:         "key1",
+			Snapshotter: "overlayfs",
+			Kind:        snapshot.KindActive,
+			Size:        10,
+			Inodes:      100,
+			Timestamp:   time.Now().UnixNano(),
 		},
 		"key2": {
-			Key:       "key2",
-			Kind:      snapshot.KindCommitted,
-			Size:      20,
-			Inodes:    200,
-			Timestamp: time.Now().UnixNano(),
+			Key:         "key2",
+			Snapshotter: "overlayfs",
+			Kind:        snapshot.KindCommitted,
+			Size:        20,
+			Inodes:      200,
+			Timestamp:   time.Now().UnixNano(),
 		},
 		"key3": {
-			Key:       "key3",
-			Kind:      snapshot.KindView,
-			Size:      0,
-			Inodes:    0,
-			Timestamp: time.Now().UnixNano(),
+			Key:         "key3",
+			Snapshotter: "overlayfs",
+			Kind:        snapshot.KindView,
+			Size:        0,
+			Inodes:      0,
+			Timestamp:   time.Now().UnixNano(),
 		},
 	}
 
@@ -43,7 +48,7 @@ func Test_SnapshotStore(t *testing.T) {
 
 	t.Logf("should be able to get snapshot")
 	for id, sn := range snapshots {
-		got, err := s.Get(id)
+		got, err := s.Get("overlayfs", id)
 		assert.NoError(t, err)
 		assert.Equal(t, sn, got)
 	}
@@ -52,15 +57,53 @@ func Test_SnapshotStore(t *testing.T) {
 	sns := s.List()
 	assert.Len(t, sns, 3)
 
+	t.Logf("should keep the snapshots of the same key in different snapshotters")
+	s.Add(Snapshot{Key: "key1", Snapshotter: "nydus", Size: 30})
+	got, err := s.Get("nydus", "key1")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(30), got.Size)
+	got, err = s.Get("overlayfs", "key1")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(10), got.Size)
+	s.Delete("nydus", "key1")
+
 	testKey := "key2"
 
 	t.Logf("should be able to delete snapshot")
-	s.Delete(testKey)
+	s.Delete("overlayfs", testKey)
 	sns = s.List()
 	assert.Len(t, sns, 2)
 
 	t.Logf("get should return empty struct and ErrNotExist after deletion")
-	sn, err := s.Get(testKey)
+	sn, err := s.Get("overlayfs", testKey)
 	assert.Equal(t, Snapshot{}, sn)
 	assert.Equal(t, errtypes.IsNotfound(err), true)
 }
+
+func Test_PersistentSnapshotStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshot-store")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	s, err := NewPersistentSnapshotStore(dir)
+	assert.NoError(t, err)
+	assert.Len(t, s.List(), 0)
+
+	sn := Snapshot{
+		Key:         "key1",
+		Snapshotter: "overlayfs",
+		Kind:        snapshot.KindActive,
+		Size:        10,
+		Inodes:      100,
+		Timestamp:   time.Now().UnixNano(),
+	}
+	s.Add(sn)
+	assert.NoError(t, s.Persist())
+
+	t.Logf("should be able to load persisted snapshot")
+	s, err = NewPersistentSnapshotStore(dir)
+	assert.NoError(t, err)
+	got, err := s.Get("overlayfs", "key1")
+	assert.NoError(t, err)
+	assert.Equal(t, sn, got)
+}
@@ -3,6 +3,7 @@ package mgr
 import (
 	"context"
 
+	"github.com/alibaba/pouch/daemon/config"
 	"github.com/alibaba/pouch/oci"
 
 	specs "github.com/opencontainers/runtime-spec/specs-go"
@@ -18,6 +19,7 @@ type SpecWrapper struct {
 	prioArr    []int
 	argsArr    [][]string
 	useSystemd bool
+	ociHooks   []config.OCIHook
 }
 
 // All the functions related to the spec is lock-free for container instance,
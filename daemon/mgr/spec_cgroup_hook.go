@@ -0,0 +1,149 @@
+package mgr
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/alibaba/pouch/pkg/system"
+
+	"github.com/docker/docker/pkg/reexec"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// CgroupFileSpecAnnotationPrefix is the prefix of the spec annotations of the
+// cgroup files which the OCI spec has no fields of, e.g. "__cgroup_file.memory.min".
+// The files are written into the container cgroup by the prestart hook of pouchd,
+// so that they take effect before the user process is started.
+const CgroupFileSpecAnnotationPrefix = "__cgroup_file."
+
+// cgroupFileHookName is the name of the prestart hook reexecuted from pouchd.
+const cgroupFileHookName = "pouch-cgroup-file"
+
+var (
+	// cgroupFileRoot is the directory where the cgroup hierarchies are mounted.
+	cgroupFileRoot = "/sys/fs/cgroup"
+	// cgroupFileProcRoot is the mount point of procfs.
+	cgroupFileProcRoot = "/proc"
+	// isCgroup2UnifiedMode returns whether the host boots with cgroup v2.
+	isCgroup2UnifiedMode = system.IsCgroup2UnifiedMode
+)
+
+func init() {
+	reexec.Register(cgroupFileHookName, cgroupFileHookMain)
+}
+
+// cgroupFiles returns the cgroup files in the spec annotations in the form of
+// file=value, sorted by the file name.
+func cgroupFiles(specAnnotation map[string]string) ([]string, error) {
+	var files []string
+	for k, v := range specAnnotation {
+		if !strings.HasPrefix(k, CgroupFileSpecAnnotationPrefix) {
+			continue
+		}
+		file := strings.TrimPrefix(k, CgroupFileSpecAnnotationPrefix)
+		if !strings.Contains(file, ".") || strings.ContainsAny(file, "/=") {
+			return nil, fmt.Errorf("invalid cgroup file %q in spec annotation %q", file, k)
+		}
+		files = append(files, file+"="+v)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// setCgroupFileHook adds the prestart hook writing the cgroup files of container.
+func setCgroupFileHook(c *Container, spec *SpecWrapper) error {
+	files, err := cgroupFiles(c.Config.SpecAnnotation)
+	if err != nil || len(files) == 0 {
+		return err
+	}
+
+	target, err := os.Readlink(filepath.Join("/proc", strconv.Itoa(os.Getpid()), "exe"))
+	if err != nil {
+		return err
+	}
+	spec.s.Hooks.Prestart = append(spec.s.Hooks.Prestart, specs.Hook{
+		Path: target,
+		Args: append([]string{cgroupFileHookName}, files...),
+	})
+	return nil
+}
+
+// cgroupFileHookMain is the entrypoint of the prestart hook, which reads the
+// state of container from stdin and writes the files of args into the cgroup
+// of its init process.
+func cgroupFileHookMain() {
+	var state specs.State
+	if err := json.NewDecoder(os.Stdin).Decode(&state); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to decode container state: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := writeCgroupFiles(state.Pid, os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to write cgroup files of container %s: %v\n", state.ID, err)
+		os.Exit(1)
+	}
+}
+
+// writeCgroupFiles writes the files in the form of file=value into the cgroup
+// of process, the controller of file is the prefix of its name.
+func writeCgroupFiles(pid int, files []string) error {
+	for _, f := range files {
+		parts := strings.SplitN(f, "=", 2)
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid cgroup file %q, should be file=value", f)
+		}
+		file, value := parts[0], parts[1]
+
+		path, err := processCgroupPath(pid, strings.SplitN(file, ".", 2)[0])
+		if err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(filepath.Join(path, file), []byte(value), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// processCgroupPath returns the path of the cgroup of controller the process is in.
+func processCgroupPath(pid int, controller string) (string, error) {
+	f, err := os.Open(filepath.Join(cgroupFileProcRoot, strconv.Itoa(pid), "cgroup"))
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	unified := isCgroup2UnifiedMode()
+
+	// the entries are in the format of "hierarchy-ID:controller-list:path",
+	// the one of unified hierarchy is in the format of "0::path".
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		parts := strings.SplitN(scanner.Text(), ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		if unified {
+			if parts[0] == "0" && parts[1] == "" {
+				return filepath.Join(cgroupFileRoot, parts[2]), nil
+			}
+			continue
+		}
+		for _, c := range strings.Split(parts[1], ",") {
+			if c == controller {
+				return filepath.Join(cgroupFileRoot, controller, parts[2]), nil
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("cgroup %s of process %d is not found", controller, pid)
+}
@@ -0,0 +1,68 @@
+package mgr
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alibaba/pouch/apis/types"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetCgroupFileHook(t *testing.T) {
+	sw := &SpecWrapper{s: &specs.Spec{Hooks: &specs.Hooks{}}}
+	c := &Container{Config: &types.ContainerConfig{SpecAnnotation: map[string]string{"io.kubernetes.cri.container-type": "container"}}}
+	assert.NoError(t, setCgroupFileHook(c, sw))
+	assert.Empty(t, sw.s.Hooks.Prestart)
+
+	c.Config.SpecAnnotation[CgroupFileSpecAnnotationPrefix+"memory.min"] = "1024"
+	c.Config.SpecAnnotation[CgroupFileSpecAnnotationPrefix+"cpu.max.burst"] = "1000"
+	assert.NoError(t, setCgroupFileHook(c, sw))
+	assert.Len(t, sw.s.Hooks.Prestart, 1)
+	assert.Equal(t, []string{cgroupFileHookName, "cpu.max.burst=1000", "memory.min=1024"}, sw.s.Hooks.Prestart[0].Args)
+
+	// the files out of the cgroup are never written.
+	c.Config.SpecAnnotation[CgroupFileSpecAnnotationPrefix+"../memory.min"] = "1024"
+	assert.Error(t, setCgroupFileHook(c, sw))
+}
+
+func TestWriteCgroupFiles(t *testing.T) {
+	root, err := ioutil.TempDir("", "cgroup-file")
+	assert.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	defer func(cgroup, proc string, isUnified func() bool) {
+		cgroupFileRoot, cgroupFileProcRoot, isCgroup2UnifiedMode = cgroup, proc, isUnified
+	}(cgroupFileRoot, cgroupFileProcRoot, isCgroup2UnifiedMode)
+	cgroupFileRoot = filepath.Join(root, "cgroup")
+	cgroupFileProcRoot = filepath.Join(root, "proc")
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(cgroupFileProcRoot, "100"), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(cgroupFileProcRoot, "100", "cgroup"),
+		[]byte("4:cpu,cpuacct:/kubepods/pod1/c1\n3:memory:/kubepods/pod1/c1\n0::/kubepods/pod1/c1\n"), 0644))
+
+	// the cgroup of each controller on cgroup v1.
+	isCgroup2UnifiedMode = func() bool { return false }
+	cpuPath := filepath.Join(cgroupFileRoot, "cpu", "kubepods", "pod1", "c1")
+	assert.NoError(t, os.MkdirAll(cpuPath, 0755))
+	assert.NoError(t, writeCgroupFiles(100, []string{"cpu.cfs_burst_us=1000"}))
+	got, err := ioutil.ReadFile(filepath.Join(cpuPath, "cpu.cfs_burst_us"))
+	assert.NoError(t, err)
+	assert.Equal(t, "1000", string(got))
+	assert.Error(t, writeCgroupFiles(100, []string{"pids.max=10"}))
+
+	// the unified cgroup on cgroup v2.
+	isCgroup2UnifiedMode = func() bool { return true }
+	unifiedPath := filepath.Join(cgroupFileRoot, "kubepods", "pod1", "c1")
+	assert.NoError(t, os.MkdirAll(unifiedPath, 0755))
+	assert.NoError(t, writeCgroupFiles(100, []string{"memory.min=1024", "memory.high=4096"}))
+	got, err = ioutil.ReadFile(filepath.Join(unifiedPath, "memory.high"))
+	assert.NoError(t, err)
+	assert.Equal(t, "4096", string(got))
+
+	assert.Error(t, writeCgroupFiles(100, []string{"memory.min"}))
+	assert.Error(t, writeCgroupFiles(200, []string{"memory.min=1024"}))
+}
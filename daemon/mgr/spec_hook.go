@@ -52,6 +52,14 @@ func setupHook(ctx context.Context, c *Container, specWrapper *SpecWrapper) erro
 		return errors.Wrap(err, "failed to set nvidia prestart hook")
 	}
 
+	// set the cgroup files the spec has no fields of
+	if err := setCgroupFileHook(c, specWrapper); err != nil {
+		return errors.Wrap(err, "failed to set cgroup file prestart hook")
+	}
+
+	// set the hooks declared in daemon config
+	setOCIHooks(specWrapper)
+
 	return nil
 }
 
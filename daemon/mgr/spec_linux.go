@@ -404,8 +404,24 @@ func getPidContainer(ctx context.Context, mgr ContainerMgr, id string) (*Contain
 		return nil, fmt.Errorf("can't join PID namespace of %q: %v", id, err)
 	}
 
-	// TODO: check whether the container is running and not restarting.
+	// the pid namespace is gone with the init of container.
+	if !c.IsRunningOrPaused() {
+		return nil, fmt.Errorf("can't join PID namespace of %q: container is not running", id)
+	}
+
+	return c, nil
+}
+
+func getUtsContainer(ctx context.Context, mgr ContainerMgr, id string) (*Container, error) {
+	// Check the container exists.
+	c, err := mgr.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("can't join UTS namespace of %q: %v", id, err)
+	}
 
+	if !c.IsRunningOrPaused() {
+		return nil, fmt.Errorf("can't join UTS namespace of %q: container is not running", id)
+	}
 	return c, nil
 }
 
@@ -506,6 +522,16 @@ func setupUtsNamespace(ctx context.Context, c *Container, specWrapper *SpecWrapp
 	s := specWrapper.s
 	utsMode := c.HostConfig.UTSMode
 	switch {
+	case isContainer(utsMode):
+		ns := specs.LinuxNamespace{Type: specs.UTSNamespace}
+		c, err := getUtsContainer(ctx, specWrapper.ctrMgr, connectedContainer(utsMode))
+		if err != nil {
+			return fmt.Errorf("setup container uts namespace mode failed: %v", err)
+		}
+		ns.Path = fmt.Sprintf("/proc/%d/ns/uts", c.State.Pid)
+		setNamespace(s, ns)
+		// the hostname is owned by the connected container.
+		s.Hostname = ""
 	case isHost(utsMode):
 		removeNamespace(s, specs.UTSNamespace)
 		// remove hostname
@@ -93,6 +93,9 @@ func mergeContainerMount(mounts []specs.Mount, c *Container, s *specs.Spec) ([]s
 		if !mp.RW {
 			opts = append(opts, "ro")
 		}
+		// the bind mount is remounted with the hardening options, otherwise
+		// they are cleared by the remount, e.g. readonly.
+		opts = append(opts, bindHardenOptions(mp.Mode)...)
 
 		// set rprivate propagation to bind mount if pg is ""
 		if pg == "" {
@@ -118,6 +121,18 @@ func mergeContainerMount(mounts []specs.Mount, c *Container, s *specs.Spec) ([]s
 	return mounts, nil
 }
 
+// bindHardenOptions returns the nosuid, nodev and noexec options in the mode of bind.
+func bindHardenOptions(mode string) []string {
+	var opts []string
+	for _, m := range strings.Split(mode, ",") {
+		switch m {
+		case "nosuid", "nodev", "noexec":
+			opts = append(opts, m)
+		}
+	}
+	return opts
+}
+
 // setupMounts create mount spec.
 func setupMounts(ctx context.Context, c *Container, s *specs.Spec) error {
 	var (
@@ -4,6 +4,8 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/alibaba/pouch/apis/types"
+
 	specs "github.com/opencontainers/runtime-spec/specs-go"
 )
 
@@ -39,3 +41,26 @@ func Test_sortMounts(t *testing.T) {
 		})
 	}
 }
+
+func Test_mergeContainerMountHardenOptions(t *testing.T) {
+	c := &Container{
+		Config: &types.ContainerConfig{DisableNetworkFiles: true},
+		Mounts: []*types.MountPoint{
+			{Source: "/var/lib/kubelet/secret", Destination: "/secret", Mode: "ro,nosuid,nodev,noexec"},
+			{Source: "/data", Destination: "/data", RW: true, Mode: "rshared", Propagation: "rshared"},
+		},
+	}
+	s := &specs.Spec{Linux: &specs.Linux{}}
+
+	got, err := mergeContainerMount(nil, c, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []specs.Mount{
+		{Source: "/var/lib/kubelet/secret", Destination: "/secret", Type: "bind", Options: []string{"rbind", "ro", "nosuid", "nodev", "noexec", "rprivate"}},
+		{Source: "/data", Destination: "/data", Type: "bind", Options: []string{"rbind", "rshared"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("mergeContainerMount() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,39 @@
+package mgr
+
+import (
+	"github.com/alibaba/pouch/daemon/config"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// setOCIHooks injects the OCI hooks declared in daemon config into the spec,
+// the hooks are matched against the spec annotations of container.
+func setOCIHooks(spec *SpecWrapper) {
+	s := spec.s
+	for _, h := range spec.ociHooks {
+		if !h.Match(s.Annotations) {
+			continue
+		}
+
+		hook := specs.Hook{
+			Path:    h.Path,
+			Args:    h.Args,
+			Env:     h.Env,
+			Timeout: h.Timeout,
+		}
+		if len(hook.Args) == 0 {
+			hook.Args = []string{h.Path}
+		}
+
+		for _, stage := range h.Stages {
+			switch stage {
+			case config.HookStagePrestart:
+				s.Hooks.Prestart = append(s.Hooks.Prestart, hook)
+			case config.HookStagePoststart:
+				s.Hooks.Poststart = append(s.Hooks.Poststart, hook)
+			case config.HookStagePoststop:
+				s.Hooks.Poststop = append(s.Hooks.Poststop, hook)
+			}
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package mgr
+
+import (
+	"testing"
+
+	"github.com/alibaba/pouch/daemon/config"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetOCIHooks(t *testing.T) {
+	timeout := 5
+	sw := &SpecWrapper{
+		s: &specs.Spec{
+			Annotations: map[string]string{"io.kubernetes.cri.container-type": "sandbox"},
+			Hooks:       &specs.Hooks{},
+		},
+		ociHooks: []config.OCIHook{
+			{
+				Path:    "/usr/bin/setup-devices",
+				Args:    []string{"setup-devices", "--all"},
+				Timeout: &timeout,
+				Stages:  []string{config.HookStagePrestart, config.HookStagePoststop},
+			},
+			{
+				Path:        "/usr/bin/lxcfs-remount",
+				Stages:      []string{config.HookStagePoststart},
+				Annotations: map[string]string{"io.kubernetes.cri.container-type": "^container$"},
+			},
+			{
+				Path:        "/usr/bin/sandbox-hook",
+				Stages:      []string{config.HookStagePrestart},
+				Annotations: map[string]string{"io.kubernetes.cri.container-type": "sand.*"},
+			},
+		},
+	}
+
+	setOCIHooks(sw)
+	assert.Equal(t, []specs.Hook{
+		{Path: "/usr/bin/setup-devices", Args: []string{"setup-devices", "--all"}, Timeout: &timeout},
+		{Path: "/usr/bin/sandbox-hook", Args: []string{"/usr/bin/sandbox-hook"}},
+	}, sw.s.Hooks.Prestart)
+	assert.Empty(t, sw.s.Hooks.Poststart)
+	assert.Equal(t, []specs.Hook{
+		{Path: "/usr/bin/setup-devices", Args: []string{"setup-devices", "--all"}, Timeout: &timeout},
+	}, sw.s.Hooks.Poststop)
+}
@@ -30,14 +30,94 @@ type ContainerPlugin interface {
 	PostUpdate(context.Context, string, []string) error
 }
 
-var containerPlugin ContainerPlugin
+var containerPlugins pluginRegistry
 
 // RegisterContainerPlugin is used to register container plugin.
 func RegisterContainerPlugin(cp ContainerPlugin) {
-	containerPlugin = cp
+	RegisterContainerPluginWithPriority(DefaultPluginName, DefaultPluginPriority, cp)
 }
 
-// GetContainerPlugin returns the container plugin.
+// RegisterContainerPluginWithPriority is used to register a named container plugin with priority, the container
+// plugins are invoked in ascending order of priority, and the one with the same name is replaced.
+func RegisterContainerPluginWithPriority(name string, priority int, cp ContainerPlugin) {
+	containerPlugins.register(name, priority, cp)
+}
+
+// GetContainerPlugin returns the container plugin, which chains all the enabled container plugins in order.
 func GetContainerPlugin() ContainerPlugin {
-	return containerPlugin
+	var chain containerPluginChain
+	for _, p := range containerPlugins.enabled() {
+		chain = append(chain, p.(ContainerPlugin))
+	}
+
+	switch len(chain) {
+	case 0:
+		return nil
+	case 1:
+		return chain[0]
+	default:
+		return chain
+	}
+}
+
+// containerPluginChain invokes the container plugins in order, the mutations of the create
+// config made by a plugin are visible to the following ones.
+type containerPluginChain []ContainerPlugin
+
+// PreCreate invokes PreCreate of plugins in order until error returned.
+func (c containerPluginChain) PreCreate(ctx context.Context, createConfig *types.ContainerCreateConfig) error {
+	for _, p := range c {
+		if err := p.PreCreate(ctx, createConfig); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PreStart merges the pre start hooks of all plugins, which are sorted by their priorities later.
+func (c containerPluginChain) PreStart(ctx context.Context, container interface{}) ([]int, [][]string, error) {
+	var (
+		priorities []int
+		args       [][]string
+	)
+	for _, p := range c {
+		prio, arg, err := p.PreStart(ctx, container)
+		if err != nil {
+			return nil, nil, err
+		}
+		priorities = append(priorities, prio...)
+		args = append(args, arg...)
+	}
+	return priorities, args, nil
+}
+
+// PreCreateEndpoint invokes PreCreateEndpoint of plugins in order until error returned.
+func (c containerPluginChain) PreCreateEndpoint(ctx context.Context, containerID string, env []string, endpoint *networktypes.Endpoint) error {
+	for _, p := range c {
+		if err := p.PreCreateEndpoint(ctx, containerID, env, endpoint); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PreUpdate passes the update body returned by a plugin to the next one.
+func (c containerPluginChain) PreUpdate(ctx context.Context, in io.ReadCloser) (io.ReadCloser, error) {
+	var err error
+	for _, p := range c {
+		if in, err = p.PreUpdate(ctx, in); err != nil {
+			return nil, err
+		}
+	}
+	return in, nil
+}
+
+// PostUpdate invokes PostUpdate of plugins in order until error returned.
+func (c containerPluginChain) PostUpdate(ctx context.Context, rootfs string, env []string) error {
+	for _, p := range c {
+		if err := p.PostUpdate(ctx, rootfs, env); err != nil {
+			return err
+		}
+	}
+	return nil
 }
@@ -11,16 +11,106 @@ type CriPlugin interface {
 	// PreCreateContainer defines plugin point where receives a container create request, in this plugin point user
 	// could update the container's config in cri interface.
 	PreCreateContainer(context.Context, *types.ContainerCreateConfig, interface{}) error
+
+	// PreRunPodSandbox defines plugin point where receives a sandbox create request, in this plugin point user
+	// could update the sandbox container's config with the sandbox meta which contains the sandbox config in cri interface.
+	PreRunPodSandbox(context.Context, *types.ContainerCreateConfig, interface{}) error
+
+	// PostStartContainer called after the container is started, the method accepts the container id and
+	// the meta of sandbox the container belongs to, the error returned is only logged.
+	PostStartContainer(context.Context, string, interface{}) error
+
+	// PreStopPodSandbox defines plugin point where receives a sandbox stop request, the method accepts the sandbox meta,
+	// and the sandbox is not stopped if error returned.
+	PreStopPodSandbox(context.Context, interface{}) error
+
+	// PostRemoveContainer called after the container is removed, the method accepts the container id and
+	// the meta of sandbox the container belongs to, the error returned is only logged.
+	PostRemoveContainer(context.Context, string, interface{}) error
 }
 
-var criPlugin CriPlugin
+var criPlugins pluginRegistry
 
 // RegisterCriPlugin is used to register the cri plugin.
 func RegisterCriPlugin(crip CriPlugin) {
-	criPlugin = crip
+	RegisterCriPluginWithPriority(DefaultPluginName, DefaultPluginPriority, crip)
 }
 
-// GetCriPlugin returns the cri plugin.
+// RegisterCriPluginWithPriority is used to register a named cri plugin with priority, the cri
+// plugins are invoked in ascending order of priority, and the one with the same name is replaced.
+func RegisterCriPluginWithPriority(name string, priority int, crip CriPlugin) {
+	criPlugins.register(name, priority, crip)
+}
+
+// GetCriPlugin returns the cri plugin, which chains all the enabled cri plugins in order.
 func GetCriPlugin() CriPlugin {
-	return criPlugin
+	var chain criPluginChain
+	for _, p := range criPlugins.enabled() {
+		chain = append(chain, p.(CriPlugin))
+	}
+
+	switch len(chain) {
+	case 0:
+		return nil
+	case 1:
+		return chain[0]
+	default:
+		return chain
+	}
+}
+
+// criPluginChain invokes the cri plugins in order, the mutations of the create config
+// made by a plugin are visible to the following ones.
+type criPluginChain []CriPlugin
+
+// PreCreateContainer invokes PreCreateContainer of plugins in order until error returned.
+func (c criPluginChain) PreCreateContainer(ctx context.Context, createConfig *types.ContainerCreateConfig, res interface{}) error {
+	for _, p := range c {
+		if err := p.PreCreateContainer(ctx, createConfig, res); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PreRunPodSandbox invokes PreRunPodSandbox of plugins in order until error returned.
+func (c criPluginChain) PreRunPodSandbox(ctx context.Context, createConfig *types.ContainerCreateConfig, res interface{}) error {
+	for _, p := range c {
+		if err := p.PreRunPodSandbox(ctx, createConfig, res); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PostStartContainer invokes PostStartContainer of all plugins, and returns the first error.
+func (c criPluginChain) PostStartContainer(ctx context.Context, containerID string, res interface{}) error {
+	var firstErr error
+	for _, p := range c {
+		if err := p.PostStartContainer(ctx, containerID, res); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// PreStopPodSandbox invokes PreStopPodSandbox of plugins in order until error returned.
+func (c criPluginChain) PreStopPodSandbox(ctx context.Context, res interface{}) error {
+	for _, p := range c {
+		if err := p.PreStopPodSandbox(ctx, res); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PostRemoveContainer invokes PostRemoveContainer of all plugins, and returns the first error.
+func (c criPluginChain) PostRemoveContainer(ctx context.Context, containerID string, res interface{}) error {
+	var firstErr error
+	for _, p := range c {
+		if err := p.PostRemoveContainer(ctx, containerID, res); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
 }
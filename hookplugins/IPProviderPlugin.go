@@ -0,0 +1,35 @@
+package hookplugins
+
+// IPProviderPlugin reports the ips of the pods allocated by the network backends
+// other than the CNI plugins, e.g. ENI or the agents of VPC CNI, the plugins are
+// queried in order until one of them manages the sandbox.
+type IPProviderPlugin interface {
+	// Name returns the unique name of the plugin, which it's registered with.
+	Name() string
+
+	// PodIP accepts the *ocicni.PodIPRequest of the sandbox, and returns its ip,
+	// ok is false if the sandbox is not managed by the plugin.
+	PodIP(interface{}) (ip string, ok bool, err error)
+}
+
+var ipProviderPlugins pluginRegistry
+
+// RegisterIPProviderPlugin is used to register the ip provider plugin with its name.
+func RegisterIPProviderPlugin(p IPProviderPlugin) {
+	RegisterIPProviderPluginWithPriority(DefaultPluginPriority, p)
+}
+
+// RegisterIPProviderPluginWithPriority is used to register the ip provider plugin with
+// its name and priority, the plugins are queried in ascending order of priority.
+func RegisterIPProviderPluginWithPriority(priority int, p IPProviderPlugin) {
+	ipProviderPlugins.register(p.Name(), priority, p)
+}
+
+// GetIPProviderPlugins returns the enabled ip provider plugins in order.
+func GetIPProviderPlugins() []IPProviderPlugin {
+	var plugins []IPProviderPlugin
+	for _, p := range ipProviderPlugins.enabled() {
+		plugins = append(plugins, p.(IPProviderPlugin))
+	}
+	return plugins
+}
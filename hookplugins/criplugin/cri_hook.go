@@ -19,3 +19,28 @@ func (c *criPlugin) PreCreateContainer(ctx context.Context, createConfig *types.
 	// TODO: Implemented by the developer
 	return nil
 }
+
+// PreRunPodSandbox defines plugin point where receives a sandbox create request, in this plugin point user
+// could update the sandbox container's config in cri interface.
+func (c *criPlugin) PreRunPodSandbox(ctx context.Context, createConfig *types.ContainerCreateConfig, res interface{}) error {
+	// TODO: Implemented by the developer
+	return nil
+}
+
+// PostStartContainer called after the container is started.
+func (c *criPlugin) PostStartContainer(ctx context.Context, containerID string, res interface{}) error {
+	// TODO: Implemented by the developer
+	return nil
+}
+
+// PreStopPodSandbox defines plugin point where receives a sandbox stop request.
+func (c *criPlugin) PreStopPodSandbox(ctx context.Context, res interface{}) error {
+	// TODO: Implemented by the developer
+	return nil
+}
+
+// PostRemoveContainer called after the container is removed.
+func (c *criPlugin) PostRemoveContainer(ctx context.Context, containerID string, res interface{}) error {
+	// TODO: Implemented by the developer
+	return nil
+}
@@ -0,0 +1,76 @@
+package hookplugins
+
+import (
+	"sort"
+	"sync"
+)
+
+// DefaultPluginName is the name of plugin registered without a name.
+const DefaultPluginName = "default"
+
+// DefaultPluginPriority is the priority of plugin registered without a priority.
+const DefaultPluginPriority = 0
+
+// pluginEntry is a plugin registered with its name and priority.
+type pluginEntry struct {
+	name     string
+	priority int
+	plugin   interface{}
+}
+
+// pluginRegistry holds the plugins of a kind, the plugins are invoked in ascending
+// order of priority, and the ones with the same priority in order of registration.
+type pluginRegistry struct {
+	entries []pluginEntry
+}
+
+var (
+	disabledLock    sync.Mutex
+	disabledPlugins = map[string]bool{}
+)
+
+// DisablePlugins disables the hook plugins of the names, it should be called
+// before the plugins are got by daemon.
+func DisablePlugins(names ...string) {
+	disabledLock.Lock()
+	defer disabledLock.Unlock()
+	for _, name := range names {
+		disabledPlugins[name] = true
+	}
+}
+
+func isDisabled(name string) bool {
+	disabledLock.Lock()
+	defer disabledLock.Unlock()
+	return disabledPlugins[name]
+}
+
+// register adds the plugin, the plugin registered with the same name is replaced.
+func (r *pluginRegistry) register(name string, priority int, plugin interface{}) {
+	for i, e := range r.entries {
+		if e.name == name {
+			r.entries = append(r.entries[:i], r.entries[i+1:]...)
+			break
+		}
+	}
+	r.entries = append(r.entries, pluginEntry{name: name, priority: priority, plugin: plugin})
+}
+
+// enabled returns the enabled plugins in order.
+func (r *pluginRegistry) enabled() []interface{} {
+	entries := make([]pluginEntry, 0, len(r.entries))
+	for _, e := range r.entries {
+		if e.plugin != nil && !isDisabled(e.name) {
+			entries = append(entries, e)
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].priority < entries[j].priority
+	})
+
+	plugins := make([]interface{}, 0, len(entries))
+	for _, e := range entries {
+		plugins = append(plugins, e.plugin)
+	}
+	return plugins
+}
@@ -0,0 +1,104 @@
+package hookplugins
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alibaba/pouch/apis/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type labelCriPlugin struct {
+	CriPlugin
+	label string
+}
+
+func (p *labelCriPlugin) PreCreateContainer(ctx context.Context, createConfig *types.ContainerCreateConfig, res interface{}) error {
+	createConfig.Labels["order"] += p.label
+	return nil
+}
+
+func TestGetCriPlugin(t *testing.T) {
+	defer func() {
+		criPlugins = pluginRegistry{}
+		disabledPlugins = map[string]bool{}
+	}()
+
+	assert.Nil(t, GetCriPlugin())
+
+	RegisterCriPlugin(&labelCriPlugin{label: "x"})
+	RegisterCriPluginWithPriority("b", 10, &labelCriPlugin{label: "b"})
+	RegisterCriPluginWithPriority("a", -10, &labelCriPlugin{label: "a"})
+	RegisterCriPluginWithPriority("c", 10, &labelCriPlugin{label: "c"})
+	// the default plugin is replaced.
+	RegisterCriPlugin(&labelCriPlugin{label: "d"})
+
+	createConfig := &types.ContainerCreateConfig{}
+	createConfig.Labels = map[string]string{}
+	assert.NoError(t, GetCriPlugin().PreCreateContainer(context.TODO(), createConfig, nil))
+	assert.Equal(t, "adbc", createConfig.Labels["order"])
+
+	DisablePlugins("a", "c", DefaultPluginName)
+	createConfig.Labels = map[string]string{}
+	plugin := GetCriPlugin()
+	assert.NoError(t, plugin.PreCreateContainer(context.TODO(), createConfig, nil))
+	assert.Equal(t, "b", createConfig.Labels["order"])
+	_, isChain := plugin.(criPluginChain)
+	assert.False(t, isChain)
+}
+
+type hookContainerPlugin struct {
+	ContainerPlugin
+	priorities []int
+	args       [][]string
+}
+
+func (p *hookContainerPlugin) PreStart(ctx context.Context, c interface{}) ([]int, [][]string, error) {
+	return p.priorities, p.args, nil
+}
+
+func TestContainerPluginChainPreStart(t *testing.T) {
+	chain := containerPluginChain{
+		&hookContainerPlugin{priorities: []int{1}, args: [][]string{{"a"}}},
+		&hookContainerPlugin{priorities: []int{-1, 2}, args: [][]string{{"b"}, {"c"}}},
+	}
+	priorities, args, err := chain.PreStart(context.TODO(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, -1, 2}, priorities)
+	assert.Equal(t, [][]string{{"a"}, {"b"}, {"c"}}, args)
+}
+
+type nameIPProviderPlugin struct {
+	name string
+}
+
+func (p *nameIPProviderPlugin) Name() string {
+	return p.name
+}
+
+func (p *nameIPProviderPlugin) PodIP(res interface{}) (string, bool, error) {
+	return "", false, nil
+}
+
+func TestGetIPProviderPlugins(t *testing.T) {
+	defer func() {
+		ipProviderPlugins = pluginRegistry{}
+		disabledPlugins = map[string]bool{}
+	}()
+
+	assert.Empty(t, GetIPProviderPlugins())
+
+	RegisterIPProviderPlugin(&nameIPProviderPlugin{name: "b"})
+	RegisterIPProviderPluginWithPriority(-10, &nameIPProviderPlugin{name: "a"})
+	RegisterIPProviderPlugin(&nameIPProviderPlugin{name: "c"})
+
+	var names []string
+	for _, p := range GetIPProviderPlugins() {
+		names = append(names, p.Name())
+	}
+	assert.Equal(t, []string{"a", "b", "c"}, names)
+
+	DisablePlugins("a")
+	assert.Len(t, GetIPProviderPlugins(), 2)
+}
@@ -34,6 +34,7 @@ import (
 var (
 	sigHandles   []func() error
 	printVersion bool
+	configCheck  bool
 	logOpts      []string
 	cfg          = &config.Config{}
 )
@@ -77,14 +78,75 @@ func setupFlags(cmd *cobra.Command) {
 	flagSet.BoolVar(&cfg.IsCriEnabled, "enable-cri", false, "Specify whether enable the cri part of pouchd which is used to support Kubernetes")
 	flagSet.StringVar(&cfg.CriConfig.CriVersion, "cri-version", "v1alpha2", "Specify the version of cri which is used to support Kubernetes")
 	flagSet.StringVar(&cfg.CriConfig.Listen, "listen-cri", "unix:///var/run/pouchcri.sock", "Specify listening address of CRI")
+	flagSet.StringVar(&cfg.CriConfig.TCPListen, "cri-tcp-listen", "", "Specify the tcp address which also serves CRI protected by mutual TLS, e.g. 0.0.0.0:10011")
+	flagSet.StringVar(&cfg.CriConfig.TLSCert, "cri-tlscert", "", "Specify cert file of TLS of the tcp address of CRI")
+	flagSet.StringVar(&cfg.CriConfig.TLSKey, "cri-tlskey", "", "Specify key file of TLS of the tcp address of CRI")
+	flagSet.StringVar(&cfg.CriConfig.TLSCACert, "cri-tlscacert", "", "Specify CA file verifying the client certificates on the tcp address of CRI")
+	flagSet.StringSliceVar(&cfg.CriConfig.TLSAllowedCNs, "cri-tls-allowed-cns", nil, "Specify the common names of client certificates allowed on the tcp address of CRI, empty means all the verified ones")
 	flagSet.StringVar(&cfg.CriConfig.NetworkPluginBinDir, "cni-bin-dir", "/opt/cni/bin", "The directory for putting cni plugin binaries.")
 	flagSet.StringVar(&cfg.CriConfig.NetworkPluginConfDir, "cni-conf-dir", "/etc/cni/net.d", "The directory for putting cni plugin configuration files.")
 	flagSet.StringVar(&cfg.CriConfig.SandboxImage, "sandbox-image", "registry.cn-hangzhou.aliyuncs.com/google-containers/pause-amd64:3.0", "The image used by sandbox container.")
+	flagSet.StringVar(&cfg.CriConfig.BuiltinPause, "cri-builtin-pause", "", "The path of the static pause binary backing the cri sandboxes instead of the sandbox image, e.g. /usr/local/bin/pouch-pause, which is imported as a local image without pulling.")
+	flagSet.BoolVar(&cfg.CriConfig.HostNetworkHolder, "cri-host-network-holder", false, "Hold the sandboxes of the pods in the network and ipc namespaces of host by the process of built-in pause instead of the sandbox containers, requires cri-builtin-pause.")
+	flagSet.BoolVar(&cfg.CriConfig.PullOnCreate, "cri-pull-on-create", false, "Pull the image of container missing on CreateContainer by the reference and credentials the pod pulled it with, e.g. the image removed by the image gc after it is pulled.")
+	flagSet.StringVar(&cfg.CriConfig.PrefetchBandwidth, "cri-prefetch-bandwidth", "", "The bandwidth (in bytes per second) shared by the images prefetched in background by the debug api of pouchd, e.g. 10m, empty means no limit. The pulls of kubelet are not limited.")
+	flagSet.StringSliceVar(&cfg.CriConfig.WarmImages, "cri-warm-images", nil, "The images kept on the node, which are prefetched in background once they are missing, e.g. removed by the image gc, but not within --cri-warm-images-interval since they are prefetched, in the form of [handler=]image, e.g. kata=busybox:latest.")
+	flagSet.IntVar(&cfg.CriConfig.WarmImagesInterval, "cri-warm-images-interval", 300, "The time duration (in time.Second) the missing images of --cri-warm-images are checked at.")
+	flagSet.BoolVar(&cfg.CriConfig.EnsureMountPropagation, "cri-ensure-mount-propagation", false, "Remount the mounts of root and home dir rshared on startup if they are not shared, which the bidirectional mount propagation of cri containers requires. The propagation is reported in the verbose status of cri.")
+	flagSet.StringVar(&cfg.CriConfig.StreamServerAddress, "stream-server-address", "", "The address stream server of cri is listening on, empty means a proper one chosen by pouchd, and 0.0.0.0 means all the interfaces.")
 	flagSet.StringVar(&cfg.CriConfig.StreamServerPort, "stream-server-port", "10010", "The port stream server of cri is listening on.")
+	flagSet.StringVar(&cfg.CriConfig.StreamServerBaseURL, "stream-server-base-url", "", "The base url of the streaming urls returned by cri, e.g. the address of NAT or reverse proxy, empty means the one built from the listening address.")
+	flagSet.BoolVar(&cfg.CriConfig.StreamServerBehindProxy, "stream-server-behind-proxy", false, "Specify whether cri stream server is behind a reverse proxy, which takes the client address, scheme and path prefix from the X-Forwarded headers.")
+	flagSet.IntVar(&cfg.CriConfig.StreamIdleTimeout, "stream-idle-timeout", 0, "The time duration (in time.Second) after which an idle stream connection of cri is closed, 0 means the default of 4 hours.")
 	flagSet.BoolVar(&cfg.CriConfig.StreamServerReusePort, "stream-server-reuse-port", false, "Specify whether cri stream server share port with pouchd. If this is true, the listen option of pouchd should specify a tcp socket and its port should be same with stream-server-port.")
 	flagSet.IntVar(&cfg.CriConfig.CriStatsCollectPeriod, "cri-stats-collect-period", 10, "The time duration (in time.Second) cri collect stats from containerd.")
+	flagSet.IntVar(&cfg.CriConfig.CriStatsCacheTTL, "cri-stats-cache-ttl", 0, "The time duration (in time.Millisecond) the responses of cri ListContainerStats are cached and shared by the stats consumers, 0 means no cache.")
+	flagSet.IntVar(&cfg.CriConfig.CriStatsStaleness, "cri-stats-staleness", 0, "The time duration (in time.Millisecond) within which the metrics of cri containers collected from containerd are reused, 0 means no reuse.")
 	flagSet.BoolVar(&cfg.CriConfig.EnableCriStatsCollect, "enable-cri-stats-collect", false, "Specify whether cri collect stats from containerd. If this is true, option CriStatsCollectPeriod will take effect.")
 	flagSet.StringVar(&cfg.CriConfig.RuntimeConfigFile, "cni-runtime-config", "/etc/pouch/cni-runtime-config.json", "A config file to make the cni runtime config persistent.")
+	flagSet.StringVar(&cfg.CriConfig.NetPriorityDevice, "net-priority-device", "", "The host device on which the traffic of sandboxes is classified by the net priority of containers, empty to disable it.")
+	flagSet.Uint64Var(&cfg.CriConfig.NetPriorityBandwidth, "net-priority-bandwidth", 1000, "The bandwidth (in Mbit/s) of the net priority device.")
+	flagSet.StringSliceVar(&cfg.CriConfig.NetPriorityShares, "net-priority-shares", nil, "The bandwidth shares of net priority classes, in the form of priority=share, e.g. 0=1,5=3,10=6.")
+	flagSet.BoolVar(&cfg.CriConfig.EnableConntrackCleanup, "enable-conntrack-cleanup", false, "Specify whether to remove the conntrack entries of pod addresses and udp host ports when the pod sandbox is stopped.")
+	flagSet.StringSliceVar(&cfg.CriConfig.AllowedNetworkDevices, "allowed-network-devices", nil, "The patterns of host network devices allowed to be moved into pod sandboxes by the annotation io.alibaba.pouch.network.devices, e.g. ens1f0v*. No device is allowed if empty.")
+	flagSet.BoolVar(&cfg.CriConfig.EnableNetworkPolicy, "enable-network-policy", false, "Specify whether to enforce the ingress and egress rules in the annotations of pod sandbox with iptables in its network namespace.")
+	flagSet.IntVar(&cfg.CriConfig.MaxRecvMsgSize, "cri-max-recv-msg-size", 16*1024*1024, "The max message size (in bytes) the cri grpc server could receive.")
+	flagSet.IntVar(&cfg.CriConfig.MaxSendMsgSize, "cri-max-send-msg-size", 16*1024*1024, "The max message size (in bytes) the cri grpc server could send.")
+	flagSet.Uint32Var(&cfg.CriConfig.MaxConcurrentStreams, "cri-max-concurrent-streams", 0, "The max number of concurrent streams of each cri grpc connection, 0 means no limit.")
+	flagSet.IntVar(&cfg.CriConfig.KeepaliveTime, "cri-keepalive-time", 0, "The time duration (in time.Second) after which the cri grpc server pings an idle connection, 0 means the default of grpc.")
+	flagSet.IntVar(&cfg.CriConfig.KeepaliveTimeout, "cri-keepalive-timeout", 0, "The time duration (in time.Second) the cri grpc server waits for the ping ack before closing the connection, 0 means the default of grpc.")
+	flagSet.StringSliceVar(&cfg.CriConfig.DefaultCapabilities, "cri-default-capabilities", nil, "The default capabilities of cri containers, which replace the default ones of pouch, e.g. CHOWN,KILL,NET_BIND_SERVICE.")
+	flagSet.StringSliceVar(&cfg.CriConfig.DefaultMaskedPaths, "cri-default-masked-paths", nil, "The default masked paths of cri containers which are used if the security context specifies none, empty means the default ones of pouch.")
+	flagSet.StringSliceVar(&cfg.CriConfig.DefaultReadonlyPaths, "cri-default-readonly-paths", nil, "The default readonly paths of cri containers which are used if the security context specifies none, empty means the default ones of pouch.")
+	flagSet.StringSliceVar(&cfg.CriConfig.DefaultMounts, "cri-default-mounts", nil, "The mounts injected into all the cri containers unless the container path is mounted by the container or excluded by the pod annotation io.alibaba.pouch.default-mounts.exclude, in the form of hostPath:containerPath[:ro], e.g. /etc/localtime:/etc/localtime:ro.")
+	flagSet.StringSliceVar(&cfg.CriConfig.DefaultUlimits, "cri-default-ulimits", nil, "The default ulimits of cri containers, in the form of name=soft[:hard], e.g. nofile=65536:65536,nproc=4096.")
+	flagSet.BoolVar(&cfg.CriConfig.DisallowPrivileged, "cri-disallow-privileged", false, "Reject all the privileged cri containers.")
+	flagSet.StringSliceVar(&cfg.CriConfig.PrivilegedNamespaces, "cri-privileged-namespaces", nil, "The namespaces of pods allowed to run privileged cri containers, the privileged containers are allowed in all namespaces if neither this nor --cri-privileged-annotations is set.")
+	flagSet.StringSliceVar(&cfg.CriConfig.PrivilegedAnnotations, "cri-privileged-annotations", nil, "The annotations of pods allowed to run privileged cri containers, in the form of key=value.")
+	flagSet.BoolVar(&cfg.CriConfig.EnableLxcfs, "cri-enable-lxcfs", false, "Enable lxcfs for the cri pods without the annotation io.kubernetes.lxcfs.enabled, which requires --enable-lxcfs.")
+	flagSet.StringSliceVar(&cfg.CriConfig.RuntimeOverheads, "cri-runtime-overheads", nil, "The overheads of runtime handlers reported in the cri sandbox status, in the form of handler:resource=quantity, e.g. kata:cpu=250m,kata:memory=160Mi.")
+	flagSet.StringSliceVar(&cfg.CriConfig.RuntimeSnapshotters, "cri-runtime-snapshotters", nil, "The snapshotters of runtime handlers, in which the images of cri pods are unpacked and the rootfs of containers are prepared, in the form of handler=snapshotter, e.g. kata=devmapper. The default snapshotter is used for the other handlers.")
+	flagSet.StringSliceVar(&cfg.CriConfig.PassthroughAnnotations, "cri-passthrough-annotations", nil, "The annotations of cri pods and containers copied into the OCI spec annotations, which are the keys or the prefixes ending with *, e.g. io.katacontainers.*.")
+	flagSet.StringSliceVar(&cfg.CriConfig.CNIArgsAnnotations, "cri-cni-args-annotations", nil, "The annotations of pods appended to the CNI_ARGS of network plugins, in the form of annotation=arg, e.g. example.com/subnet=SUBNET.")
+	flagSet.StringSliceVar(&cfg.CriConfig.CNICapabilityAnnotations, "cri-cni-caps-annotations", nil, "The annotations of pods passed as the capability args of network plugins, in the form of annotation=capability, the json values are decoded, e.g. example.com/vlan=vlan.")
+	flagSet.StringSliceVar(&cfg.CriConfig.MethodConcurrency, "cri-method-concurrency", nil, "The max numbers of concurrent requests of cri methods, in the form of method=limit, e.g. RunPodSandbox=10,PullImage=5. The exceeded requests are queued until they are canceled.")
+	flagSet.IntVar(&cfg.CriConfig.DefaultStopTimeout, "cri-default-stop-timeout", 10, "The time duration (in time.Second) the containers are given to stop before being killed when a cri sandbox is stopped, which could be overridden by the pod annotation io.alibaba.pouch.stop-timeout.")
+	flagSet.StringVar(&cfg.CriConfig.SwapBehavior, "cri-swap-behavior", "", "The default swap behavior of cri containers without swap limit, LimitedSwap means no swap and UnlimitedSwap means no limit of swap, empty means twice the memory limit.")
+	flagSet.StringSliceVar(&cfg.CriConfig.RdtQoSClasses, "cri-rdt-qos-classes", nil, "The Intel RDT classes of service of cri containers in the pods of QoS classes, in the form of qos=class, e.g. Guaranteed=gold,BestEffort=bronze. The class is overridden by the container annotation io.alibaba.pouch.resources.rdt-class.")
+	flagSet.BoolVar(&cfg.CriConfig.EnableCPUSetManager, "cri-enable-cpuset-manager", false, "Assign the cpuset of cri containers by the annotations io.alibaba.pouch.resources.exclusive-cpus and io.alibaba.pouch.resources.numa-nodes, the containers without exclusive cpus share the cpus left.")
+	flagSet.StringVar(&cfg.CriConfig.ReservedCPUs, "cri-reserved-cpus", "", "The cpus never assigned to cri containers by the cpuset manager, e.g. 0-1.")
+	flagSet.IntVar(&cfg.CriConfig.TeardownConcurrency, "cri-teardown-concurrency", 8, "The max number of containers stopped or removed concurrently when a cri sandbox is stopped or removed.")
+	flagSet.IntVar(&cfg.CriConfig.ShutdownTimeout, "cri-shutdown-timeout", 30, "The time duration (in time.Second) to wait for the in-flight cri requests, e.g. RunPodSandbox and PullImage, to finish or roll back when pouchd is shut down, the new requests are rejected meanwhile.")
+	flagSet.StringVar(&cfg.CriConfig.SandboxDirQuota, "cri-sandbox-dir-quota", "", "The project quota, e.g. 10m, of the directory of each cri sandbox holding the files bound into containers, e.g. resolv.conf, so that the containers could not fill the filesystem of home dir through them. No quota is set if empty.")
+	flagSet.StringVar(&cfg.CriConfig.AuditLogPath, "cri-audit-log-path", "", "The path of the audit log recording the mutating cri requests as json lines, with the credentials of callers on the unix socket, the summaries of requests, the results and the latencies. No audit log is written if empty.")
+	flagSet.StringVar(&cfg.CriConfig.AuditLogMaxSize, "cri-audit-log-max-size", "100m", "The size the cri audit log is rotated at.")
+	flagSet.IntVar(&cfg.CriConfig.AuditLogMaxFiles, "cri-audit-log-max-files", 5, "The max number of cri audit log files kept, including the current one.")
+	flagSet.StringVar(&cfg.CriConfig.AuthzPolicyFile, "cri-authz-policy-file", "", "The json policy authorizing the mutating cri requests, the first rule matching the methods, the pod namespaces, the privileged and the images of a request decides whether it's allowed, the denied ones are rejected with PermissionDenied.")
+	flagSet.StringVar(&cfg.CriConfig.AuthzWebhook, "cri-authz-webhook", "", "The url of the webhook authorizing the mutating cri requests, which are posted as json and allowed only if the webhook responds {\"allowed\": true}. The requests are allowed if the webhook fails, unless --cri-authz-webhook-fail-closed is set.")
+	flagSet.IntVar(&cfg.CriConfig.AuthzWebhookTimeout, "cri-authz-webhook-timeout", 5, "The time duration (in time.Second) to wait for the response of cri authorization webhook.")
+	flagSet.BoolVar(&cfg.CriConfig.AuthzWebhookFailClosed, "cri-authz-webhook-fail-closed", false, "Reject the mutating cri requests with Unavailable if the authorization webhook fails, except ExecSync and the stop and remove of pods and containers, which are always allowed so that the outage of webhook never kills pods by the exec probes or blocks their teardown.")
+	flagSet.StringSliceVar(&cfg.CriConfig.DebugDumpMethods, "cri-debug-dump-methods", nil, "The cri methods whose full requests and responses are dumped into the log with the auth fields and env values redacted, e.g. CreateContainer,PullImage, * means all. It could be changed at runtime by the debug api of pouchd.")
+	flagSet.IntVar(&cfg.CriConfig.DebugDumpRate, "cri-debug-dump-rate", 10, "The max number of cri debug dumps written per second, the others are dropped.")
 	flagSet.BoolVarP(&cfg.Debug, "debug", "D", false, "Switch daemon log level to DEBUG mode")
 	flagSet.StringVarP(&cfg.ContainerdAddr, "containerd", "c", "/var/run/containerd.sock", "Specify listening address of containerd")
 	flagSet.StringVar(&cfg.ContainerdPath, "containerd-path", "", "Specify the path of containerd binary")
@@ -94,6 +156,7 @@ func setupFlags(cmd *cobra.Command) {
 	flagSet.BoolVar(&cfg.TLS.VerifyRemote, "tlsverify", false, "Use TLS and verify remote")
 	flagSet.StringVar(&cfg.TLS.ManagerWhiteList, "manager-whitelist", "", "Set tls name whitelist, multiple values are separated by commas")
 	flagSet.BoolVarP(&printVersion, "version", "v", false, "Print daemon version")
+	flagSet.BoolVar(&configCheck, "config-check", false, "Check the config, e.g. the sandbox image, the cni directories and the stream server address of cri, print the problems found and exit")
 	flagSet.StringVar(&cfg.DefaultRuntime, "default-runtime", "runc", "Default OCI Runtime")
 	flagSet.BoolVar(&cfg.IsLxcfsEnabled, "enable-lxcfs", false, "Enable Lxcfs to make container to isolate /proc")
 	flagSet.StringVar(&cfg.LxcfsBinPath, "lxcfs", "/usr/local/bin/lxcfs", "Specify the path of lxcfs binary")
@@ -140,6 +203,7 @@ func setupFlags(cmd *cobra.Command) {
 	// to k8s.io
 	flagSet.StringVar(&cfg.DefaultNamespace, "default-namespace", namespaces.Default, "default-namespace is passed to containerd, the default value is 'default'")
 	flagSet.StringVar(&cfg.CgroupDriver, "cgroup-driver", "cgroupfs", "Set cgroup driver for all containers(cgroupfs|systemd), default cgroupfs")
+	flagSet.BoolVar(&cfg.Rootless, "rootless", false, "Run pouchd without the root of host, e.g. in the user namespace created by rootlesskit, which requires --cgroup-driver=systemd and connects the cri pods by slirp4netns.")
 
 	// registry
 	flagSet.StringArrayVar(&cfg.InsecureRegistries, "insecure-registries", []string{}, "enable insecure registry")
@@ -147,6 +211,9 @@ func setupFlags(cmd *cobra.Command) {
 
 	// buildkit
 	flagSet.BoolVar(&cfg.EnableBuilder, "enable-builder", false, "Enable buildkit functionality")
+
+	// hook plugins
+	flagSet.StringSliceVar(&cfg.DisabledHookPlugins, "disable-hook-plugins", nil, "The names of hook plugins which are not invoked, the plugins registered without a name are named default")
 }
 
 // runDaemon prepares configs, setups essential details and runs pouchd daemon.
@@ -187,12 +254,16 @@ func runDaemon(cmd *cobra.Command) error {
 		log.With(nil).Fatal(err)
 	}
 
+	// user specifies --config-check, check the config and return.
+	if configCheck {
+		return checkConfig(cfg)
+	}
+
 	// import debugger tools for pouch when in debug mode.
 	if cfg.Debug || cfg.EnableProfiler {
 		if err := agent.Listen(agent.Options{}); err != nil {
 			log.With(nil).Fatal(err)
 		}
-		debug.SetupDumpStackTrap()
 	}
 
 	// resolve home dir.
@@ -202,6 +273,9 @@ func runDaemon(cmd *cobra.Command) error {
 	}
 	cfg.HomeDir = dir
 
+	// dump the goroutine stacks on SIGUSR1, no matter whether in debug mode.
+	debug.SetupDumpStackTrap(path.Join(cfg.HomeDir, "debug"))
+
 	// saves daemon pid to pidfile.
 	if cfg.Pidfile != "" {
 		if err := utils.NewPidfile(cfg.Pidfile); err != nil {
@@ -246,9 +320,29 @@ func runDaemon(cmd *cobra.Command) error {
 		return fmt.Errorf("failed to new daemon")
 	}
 
-	signal.Notify(signalCh, syscall.SIGINT, syscall.SIGQUIT, syscall.SIGTERM, syscall.SIGHUP)
+	signal.Notify(signalCh, syscall.SIGINT, syscall.SIGQUIT, syscall.SIGTERM)
 	sigHandles = append(sigHandles, d.Shutdown, d.ShutdownPlugin)
 
+	// reload the config file on SIGHUP.
+	reloadCh := make(chan os.Signal, 1)
+	signal.Notify(reloadCh, syscall.SIGHUP)
+	go func() {
+		for range reloadCh {
+			if err := d.Reload(); err != nil {
+				log.With(nil).Errorf("failed to reload daemon config: %v", err)
+			}
+		}
+	}()
+
+	// toggle the drain mode of cri on SIGUSR2.
+	drainCh := make(chan os.Signal, 1)
+	signal.Notify(drainCh, syscall.SIGUSR2)
+	go func() {
+		for range drainCh {
+			d.ToggleCriDrain()
+		}
+	}()
+
 	go func() {
 		// FIXME: I think the Run() should always return error.
 		errCh <- d.Run()
@@ -298,3 +392,30 @@ func loadDaemonFile(cfg *config.Config, flagSet *pflag.FlagSet) error {
 
 	return cfg.MergeConfigurations(flagSet)
 }
+
+// checkConfig prints the problems of the config found by the config check,
+// and returns error if any of them is not a warning.
+func checkConfig(cfg *config.Config) error {
+	if !cfg.IsCriEnabled {
+		fmt.Println("cri is not enabled, skip checking cri config")
+		return nil
+	}
+
+	runtimes := make([]string, 0, len(cfg.Runtimes))
+	for name := range cfg.Runtimes {
+		runtimes = append(runtimes, name)
+	}
+
+	errs := 0
+	for _, p := range cfg.CriConfig.Check(runtimes) {
+		fmt.Println(p)
+		if !p.Warning {
+			errs++
+		}
+	}
+	if errs != 0 {
+		return fmt.Errorf("config check found %d errors", errs)
+	}
+	fmt.Println("config check passed")
+	return nil
+}
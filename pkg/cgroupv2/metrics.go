@@ -0,0 +1,101 @@
+// Package cgroupv2 mirrors the metrics of cgroup v2 reported by containerd,
+// the vendored github.com/containerd/cgroups only knows the v1 format, so
+// that the metrics of containers on the hosts with unified hierarchy could
+// not be decoded without it.
+//
+// Only the fields used by pouch are declared, the others are skipped when
+// decoding. The field numbers must be kept the same as the ones defined in
+// github.com/containerd/cgroups/v2/stats/metrics.proto.
+package cgroupv2
+
+import (
+	"github.com/gogo/protobuf/proto"
+)
+
+// MetricsTypeURL is the type url of cgroup v2 metrics reported by containerd.
+const MetricsTypeURL = "io.containerd.cgroups.v2.Metrics"
+
+func init() {
+	proto.RegisterType((*Metrics)(nil), MetricsTypeURL)
+}
+
+// Metrics is the metrics of a cgroup in the unified hierarchy.
+type Metrics struct {
+	Pids   *PidsStat   `protobuf:"bytes,1,opt,name=pids" json:"pids,omitempty"`
+	CPU    *CPUStat    `protobuf:"bytes,2,opt,name=cpu" json:"cpu,omitempty"`
+	Memory *MemoryStat `protobuf:"bytes,4,opt,name=memory" json:"memory,omitempty"`
+	Io     *IOStat     `protobuf:"bytes,6,opt,name=io" json:"io,omitempty"`
+}
+
+// Reset implements proto.Message.
+func (m *Metrics) Reset() { *m = Metrics{} }
+
+// String implements proto.Message.
+func (m *Metrics) String() string { return proto.CompactTextString(m) }
+
+// ProtoMessage implements proto.Message.
+func (*Metrics) ProtoMessage() {}
+
+// PidsStat is the statistics of pids controller.
+type PidsStat struct {
+	Current uint64 `protobuf:"varint,1,opt,name=current" json:"current,omitempty"`
+	Limit   uint64 `protobuf:"varint,2,opt,name=limit" json:"limit,omitempty"`
+}
+
+// CPUStat is the statistics of cpu controller, the time is in microseconds.
+type CPUStat struct {
+	UsageUsec     uint64 `protobuf:"varint,1,opt,name=usage_usec" json:"usage_usec,omitempty"`
+	UserUsec      uint64 `protobuf:"varint,2,opt,name=user_usec" json:"user_usec,omitempty"`
+	SystemUsec    uint64 `protobuf:"varint,3,opt,name=system_usec" json:"system_usec,omitempty"`
+	NrPeriods     uint64 `protobuf:"varint,4,opt,name=nr_periods" json:"nr_periods,omitempty"`
+	NrThrottled   uint64 `protobuf:"varint,5,opt,name=nr_throttled" json:"nr_throttled,omitempty"`
+	ThrottledUsec uint64 `protobuf:"varint,6,opt,name=throttled_usec" json:"throttled_usec,omitempty"`
+}
+
+// MemoryStat is the statistics of memory controller.
+type MemoryStat struct {
+	Anon          uint64 `protobuf:"varint,1,opt,name=anon" json:"anon,omitempty"`
+	File          uint64 `protobuf:"varint,2,opt,name=file" json:"file,omitempty"`
+	KernelStack   uint64 `protobuf:"varint,3,opt,name=kernel_stack" json:"kernel_stack,omitempty"`
+	Slab          uint64 `protobuf:"varint,4,opt,name=slab" json:"slab,omitempty"`
+	Sock          uint64 `protobuf:"varint,5,opt,name=sock" json:"sock,omitempty"`
+	Shmem         uint64 `protobuf:"varint,6,opt,name=shmem" json:"shmem,omitempty"`
+	FileMapped    uint64 `protobuf:"varint,7,opt,name=file_mapped" json:"file_mapped,omitempty"`
+	FileDirty     uint64 `protobuf:"varint,8,opt,name=file_dirty" json:"file_dirty,omitempty"`
+	FileWriteback uint64 `protobuf:"varint,9,opt,name=file_writeback" json:"file_writeback,omitempty"`
+	InactiveAnon  uint64 `protobuf:"varint,11,opt,name=inactive_anon" json:"inactive_anon,omitempty"`
+	ActiveAnon    uint64 `protobuf:"varint,12,opt,name=active_anon" json:"active_anon,omitempty"`
+	InactiveFile  uint64 `protobuf:"varint,13,opt,name=inactive_file" json:"inactive_file,omitempty"`
+	ActiveFile    uint64 `protobuf:"varint,14,opt,name=active_file" json:"active_file,omitempty"`
+	Unevictable   uint64 `protobuf:"varint,15,opt,name=unevictable" json:"unevictable,omitempty"`
+	Pgfault       uint64 `protobuf:"varint,18,opt,name=pgfault" json:"pgfault,omitempty"`
+	Pgmajfault    uint64 `protobuf:"varint,19,opt,name=pgmajfault" json:"pgmajfault,omitempty"`
+	Usage         uint64 `protobuf:"varint,32,opt,name=usage" json:"usage,omitempty"`
+	UsageLimit    uint64 `protobuf:"varint,33,opt,name=usage_limit" json:"usage_limit,omitempty"`
+	SwapUsage     uint64 `protobuf:"varint,34,opt,name=swap_usage" json:"swap_usage,omitempty"`
+	SwapLimit     uint64 `protobuf:"varint,35,opt,name=swap_limit" json:"swap_limit,omitempty"`
+}
+
+// IOStat is the statistics of io controller.
+type IOStat struct {
+	Usage []*IOEntry `protobuf:"bytes,1,rep,name=usage" json:"usage,omitempty"`
+}
+
+// IOEntry is the io statistics of a block device.
+type IOEntry struct {
+	Major  uint64 `protobuf:"varint,1,opt,name=major" json:"major,omitempty"`
+	Minor  uint64 `protobuf:"varint,2,opt,name=minor" json:"minor,omitempty"`
+	Rbytes uint64 `protobuf:"varint,3,opt,name=rbytes" json:"rbytes,omitempty"`
+	Wbytes uint64 `protobuf:"varint,4,opt,name=wbytes" json:"wbytes,omitempty"`
+	Rios   uint64 `protobuf:"varint,5,opt,name=rios" json:"rios,omitempty"`
+	Wios   uint64 `protobuf:"varint,6,opt,name=wios" json:"wios,omitempty"`
+}
+
+// WorkingSet returns the working set of memory, which is the usage
+// excluding the inactive file cache, the same as the one of kubelet.
+func (m *MemoryStat) WorkingSet() uint64 {
+	if m.Usage < m.InactiveFile {
+		return 0
+	}
+	return m.Usage - m.InactiveFile
+}
@@ -14,19 +14,32 @@ func init() {
 	rdebug.SetTraceback("all")
 }
 
-// SetupDumpStackTrap setups signal trap to dump stack.
-func SetupDumpStackTrap() {
+// SetupDumpStackTrap setups signal trap to dump stack into the log and a file
+// under dir.
+func SetupDumpStackTrap(dir string) {
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, syscall.SIGUSR1)
 	go func() {
 		for range c {
 			DumpStacks()
+			if file, err := WriteStacks(dir); err != nil {
+				log.With(nil).Errorf("failed to write goroutine stack dump: %v", err)
+			} else {
+				log.With(nil).Infof("goroutine stack dump is written to %s", file)
+			}
 		}
 	}()
 }
 
 // DumpStacks dumps the runtime stack.
 func DumpStacks() {
+	// Note that if the daemon is started with a less-verbose log-level than "info" (the default), the goroutine
+	// traces won't show up in the log.
+	log.With(nil).Infof("=== BEGIN goroutine stack dump ===\n%s\n=== END goroutine stack dump ===", stacks())
+}
+
+// stacks returns the stacks of all goroutines.
+func stacks() []byte {
 	var (
 		buf       []byte
 		stackSize int
@@ -37,8 +50,5 @@ func DumpStacks() {
 		stackSize = runtime.Stack(buf, true)
 		bufferLen *= 2
 	}
-	buf = buf[:stackSize]
-	// Note that if the daemon is started with a less-verbose log-level than "info" (the default), the goroutine
-	// traces won't show up in the log.
-	log.With(nil).Infof("=== BEGIN goroutine stack dump ===\n%s\n=== END goroutine stack dump ===", buf)
+	return buf[:stackSize]
 }
@@ -0,0 +1,69 @@
+package debug
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/trace"
+	"sync/atomic"
+	"time"
+)
+
+// profilerEnabled is non-zero when the pprof endpoints are served.
+var profilerEnabled int32
+
+// SetProfilerEnabled turns on or off the pprof endpoints at runtime.
+func SetProfilerEnabled(enabled bool) {
+	var v int32
+	if enabled {
+		v = 1
+	}
+	atomic.StoreInt32(&profilerEnabled, v)
+}
+
+// ProfilerEnabled returns whether the pprof endpoints are served.
+func ProfilerEnabled() bool {
+	return atomic.LoadInt32(&profilerEnabled) != 0
+}
+
+// WriteStacks writes the stacks of all goroutines into a file under dir, and
+// returns the path of file.
+func WriteStacks(dir string) (string, error) {
+	f, err := createDumpFile(dir, "goroutine-stacks", "log")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	if _, err := f.Write(stacks()); err != nil {
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+// WriteTrace captures the execution trace for the duration into a file under
+// dir, and returns the path of file. Only one trace could be captured at a time.
+func WriteTrace(dir string, duration time.Duration) (string, error) {
+	f, err := createDumpFile(dir, "trace", "out")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	if err := trace.Start(f); err != nil {
+		os.Remove(f.Name())
+		return "", fmt.Errorf("failed to start execution trace: %v", err)
+	}
+	time.Sleep(duration)
+	trace.Stop()
+	return f.Name(), nil
+}
+
+// createDumpFile creates the file named by the kind and the current time under dir.
+func createDumpFile(dir, kind, ext string) (*os.File, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	name := fmt.Sprintf("%s-%s.%s", kind, time.Now().UTC().Format("20060102T150405.000000000Z"), ext)
+	return os.OpenFile(filepath.Join(dir, name), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+}
@@ -0,0 +1,43 @@
+package debug
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetProfilerEnabled(t *testing.T) {
+	defer SetProfilerEnabled(false)
+
+	assert.False(t, ProfilerEnabled())
+	SetProfilerEnabled(true)
+	assert.True(t, ProfilerEnabled())
+	SetProfilerEnabled(false)
+	assert.False(t, ProfilerEnabled())
+}
+
+func TestWriteStacksAndTrace(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pouch-debug")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	dumpDir := filepath.Join(dir, "debug")
+	file, err := WriteStacks(dumpDir)
+	assert.NoError(t, err)
+	assert.Equal(t, dumpDir, filepath.Dir(file))
+	content, err := ioutil.ReadFile(file)
+	assert.NoError(t, err)
+	assert.True(t, strings.Contains(string(content), "TestWriteStacksAndTrace"))
+
+	file, err = WriteTrace(dumpDir, 10*time.Millisecond)
+	assert.NoError(t, err)
+	info, err := os.Stat(file)
+	assert.NoError(t, err)
+	assert.NotZero(t, info.Size())
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
@@ -2,7 +2,7 @@ package interceptor
 
 import (
 	"context"
-	"encoding/json"
+	"fmt"
 	"path"
 	"time"
 
@@ -11,8 +11,18 @@ import (
 
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
 )
 
+type key int
+
+const (
+	requestIDKey key = iota
+)
+
+// RequestIDField is the log field name of the request id.
+const RequestIDField = "RequestID"
+
 // ServerPayloadLoggingDecider is a user-provided function for deciding how to log the server-side
 // request/response payloads
 type ServerPayloadLoggingDecider func(ctx context.Context, fullMethodName string, servingObject interface{}) logrus.Level
@@ -20,8 +30,10 @@ type ServerPayloadLoggingDecider func(ctx context.Context, fullMethodName string
 // PayloadUnaryServerInterceptor returns a new unary server interceptors that logs the payloads of requests.
 func PayloadUnaryServerInterceptor(decider ServerPayloadLoggingDecider) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-		// add request id for cri trace log
-		ctx = log.NewContext(ctx, map[string]interface{}{"RequestID": randomid.Generate()[:10]})
+		// add request id for cri trace log if no one has been generated
+		if _, ok := RequestIDFromContext(ctx); !ok {
+			ctx = log.AddFields(ctx, map[string]interface{}{RequestIDField: randomid.Generate()[:10]})
+		}
 
 		logLevel := decider(ctx, info.FullMethod, info.Server)
 
@@ -44,9 +56,44 @@ func PayloadUnaryServerInterceptor(decider ServerPayloadLoggingDecider) grpc.Una
 	}
 }
 
+// RequestIDUnaryServerInterceptor returns a new unary server interceptor that generates
+// a request id for every request. The id is attached to the log entry of the context, and
+// is appended to the message of returned error so that client side errors could be
+// correlated with server logs.
+func RequestIDUnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		id := randomid.Generate()[:10]
+		ctx = context.WithValue(ctx, requestIDKey, id)
+		ctx = log.AddFields(ctx, map[string]interface{}{RequestIDField: id})
+
+		resp, err := handler(ctx, req)
+		if err != nil {
+			err = withRequestID(err, id)
+		}
+		return resp, err
+	}
+}
+
+// RequestIDFromContext returns the request id generated by RequestIDUnaryServerInterceptor.
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey).(string)
+	return id, ok
+}
+
+// withRequestID appends the request id to the error message, and keeps the grpc
+// status code and details.
+func withRequestID(err error, id string) error {
+	if s, ok := status.FromError(err); ok {
+		p := s.Proto()
+		p.Message = fmt.Sprintf("%s (request id: %s)", s.Message(), id)
+		return status.ErrorProto(p)
+	}
+	return fmt.Errorf("%v (request id: %s)", err, id)
+}
+
+// logProtoMessageAsJSON logs the payload as json with the secrets redacted.
 func logProtoMessageAsJSON(ctx context.Context, pbMsg interface{}, key string, msg string, level logrus.Level) {
-	b, _ := json.Marshal(pbMsg)
-	entry := log.WithFields(ctx, map[string]interface{}{key: string(b)})
+	entry := log.WithFields(ctx, map[string]interface{}{key: RedactPayload(pbMsg)})
 
 	switch level {
 	case logrus.DebugLevel:
@@ -0,0 +1,54 @@
+package interceptor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alibaba/pouch/pkg/log"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRequestIDUnaryServerInterceptor(t *testing.T) {
+	var requestID string
+	info := &grpc.UnaryServerInfo{FullMethod: "Foo.UnaryMethod"}
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		id, ok := RequestIDFromContext(ctx)
+		assert.True(t, ok)
+		assert.Equal(t, id, log.With(ctx).Data[RequestIDField])
+		requestID = id
+		return nil, status.Error(codes.NotFound, "not found")
+	}
+
+	_, err := RequestIDUnaryServerInterceptor()(context.TODO(), "input", info, handler)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.True(t, strings.Contains(err.Error(), requestID))
+
+	// the details of status are kept.
+	handler = func(ctx context.Context, req interface{}) (interface{}, error) {
+		s, err := status.New(codes.ResourceExhausted, "too many requests").WithDetails(ptypes.DurationProto(time.Second))
+		assert.NoError(t, err)
+		return nil, s.Err()
+	}
+	_, err = RequestIDUnaryServerInterceptor()(context.TODO(), "input", info, handler)
+	s, ok := status.FromError(err)
+	assert.True(t, ok)
+	assert.Equal(t, codes.ResourceExhausted, s.Code())
+	assert.True(t, strings.HasPrefix(s.Message(), "too many requests (request id: "))
+	assert.Len(t, s.Details(), 1)
+	assert.Equal(t, ptypes.DurationProto(time.Second), s.Details()[0])
+
+	handler = func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, fmt.Errorf("plain error")
+	}
+	_, err = RequestIDUnaryServerInterceptor()(context.TODO(), "input", info, handler)
+	assert.True(t, strings.HasPrefix(err.Error(), "plain error (request id: "))
+}
@@ -0,0 +1,80 @@
+package interceptor
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+const redactedValue = "<redacted>"
+
+// redactedFields are the json fields of the secrets in cri payloads, e.g. the
+// ones of AuthConfig in PullImageRequest.
+var redactedFields = map[string]bool{
+	"password":       true,
+	"auth":           true,
+	"identity_token": true,
+	"registry_token": true,
+}
+
+// RedactPayload returns the json of the grpc payload, in which the secrets and
+// the values of env are redacted.
+func RedactPayload(payload interface{}) string {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return ""
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return ""
+	}
+	redactValue(v)
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return ""
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// redactValue redacts the secrets in the decoded json value in place.
+func redactValue(v interface{}) {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, field := range val {
+			// the secrets are strings, e.g. the auth of AuthConfig, not the
+			// AuthConfig itself named auth in PullImageRequest.
+			if _, isString := field.(string); isString && redactedFields[k] {
+				val[k] = redactedValue
+				continue
+			}
+			if k == "envs" {
+				redactEnvs(field)
+				continue
+			}
+			redactValue(field)
+		}
+	case []interface{}:
+		for _, item := range val {
+			redactValue(item)
+		}
+	}
+}
+
+// redactEnvs redacts the values of the env key-value pairs, the keys are kept.
+func redactEnvs(v interface{}) {
+	envs, ok := v.([]interface{})
+	if !ok {
+		return
+	}
+	for _, env := range envs {
+		if kv, ok := env.(map[string]interface{}); ok {
+			if _, exists := kv["value"]; exists {
+				kv["value"] = redactedValue
+			}
+		}
+	}
+}
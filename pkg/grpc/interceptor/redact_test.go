@@ -0,0 +1,31 @@
+package interceptor
+
+import (
+	"strings"
+	"testing"
+
+	runtime "github.com/alibaba/pouch/cri/apis/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactPayload(t *testing.T) {
+	pull := RedactPayload(&runtime.PullImageRequest{
+		Image: &runtime.ImageSpec{Image: "busybox"},
+		Auth:  &runtime.AuthConfig{Username: "user", Password: "secret1", Auth: "secret2", IdentityToken: "secret3", RegistryToken: "secret4"},
+	})
+	assert.Contains(t, pull, `"image":"busybox"`)
+	assert.Contains(t, pull, `"username":"user"`)
+	assert.False(t, strings.Contains(pull, "secret"), pull)
+
+	create := RedactPayload(&runtime.CreateContainerRequest{
+		PodSandboxId: "sandbox1",
+		Config: &runtime.ContainerConfig{
+			Envs:   []*runtime.KeyValue{{Key: "TOKEN", Value: "secret"}},
+			Labels: map[string]string{"value": "kept"},
+		},
+	})
+	assert.Contains(t, create, `"key":"TOKEN"`)
+	assert.Contains(t, create, `"value":"kept"`)
+	assert.False(t, strings.Contains(create, "secret"), create)
+}
@@ -30,6 +30,18 @@ type Backend interface {
 	Close() error
 }
 
+// Transactional is implemented by the backends which could update a key atomically.
+type Transactional interface {
+	// Update reads the value of key and writes back the value returned by fn in one transaction.
+	Update(bucket string, key string, fn func(value []byte) ([]byte, error)) error
+}
+
+// Compactable is implemented by the backends whose storage could be compacted.
+type Compactable interface {
+	// Compact rewrites the storage to reclaim the space of removed data.
+	Compact() error
+}
+
 // Register registers a backend to be daemon's store.
 func Register(name string, create func(Config) (Backend, error)) {
 	if backendFactory == nil {
@@ -14,8 +14,12 @@ func init() {
 	Register("boltdb", NewBolt)
 }
 
+// openBolt opens the boltdb file, it's only changed by tests.
+var openBolt = boltdb.Open
+
 type bolt struct {
-	db *boltdb.DB
+	db  *boltdb.DB
+	opt *boltdb.Options
 	sync.Mutex
 }
 
@@ -31,7 +35,7 @@ func NewBolt(cfg Config) (Backend, error) {
 		}
 	}
 
-	b := &bolt{}
+	b := &bolt{opt: opt}
 
 	db, err := boltdb.Open(cfg.BaseDir, 0644, opt)
 	if err != nil {
@@ -130,9 +134,12 @@ func (b *bolt) Get(bucket string, key string) ([]byte, error) {
 		if bkt == nil {
 			return ErrBucketNotFound
 		}
-		if value = bkt.Get([]byte(key)); value == nil {
+		v := bkt.Get([]byte(key))
+		if v == nil {
 			return ErrObjectNotFound
 		}
+		// the value is only valid in the transaction.
+		value = append([]byte(nil), v...)
 		return nil
 	})
 
@@ -153,7 +160,7 @@ func (b *bolt) List(bucket string) ([][]byte, error) {
 		}
 
 		return bkt.ForEach(func(k, v []byte) error {
-			values = append(values, v)
+			values = append(values, append([]byte(nil), v...))
 			return nil
 		})
 	})
@@ -161,6 +168,103 @@ func (b *bolt) List(bucket string) ([][]byte, error) {
 	return values, err
 }
 
+// Update reads the value of key and writes back the value returned by fn in one transaction.
+// The value passed to fn is nil if the key doesn't exist.
+func (b *bolt) Update(bucket, key string, fn func(value []byte) ([]byte, error)) error {
+	b.Lock()
+	defer b.Unlock()
+
+	return b.db.Update(func(tx *boltdb.Tx) error {
+		bkt := tx.Bucket([]byte(bucket))
+		if bkt == nil {
+			return ErrBucketNotFound
+		}
+
+		var old []byte
+		if v := bkt.Get([]byte(key)); v != nil {
+			old = append([]byte(nil), v...)
+		}
+		value, err := fn(old)
+		if err != nil {
+			return err
+		}
+		if err := bkt.Put([]byte(key), value); err != nil {
+			return errors.Wrapf(err, "failed to put key %s in boltdb", key)
+		}
+		return nil
+	})
+}
+
+// Compact copies all buckets into a new database file and replaces the current one with it,
+// since boltdb never shrinks the file after data is removed. The current database is kept
+// in use until the compacted one is opened, and put back if it fails to be opened.
+func (b *bolt) Compact() error {
+	b.Lock()
+	defer b.Unlock()
+
+	dbPath := b.db.Path()
+	tmpPath := dbPath + ".compact"
+	if err := os.RemoveAll(tmpPath); err != nil {
+		return err
+	}
+
+	tmp, err := boltdb.Open(tmpPath, 0644, b.opt)
+	if err != nil {
+		return errors.Wrap(err, "failed to create compacted boltdb")
+	}
+
+	err = b.db.View(func(tx *boltdb.Tx) error {
+		return tmp.Update(func(tmpTx *boltdb.Tx) error {
+			return tx.ForEach(func(name []byte, bkt *boltdb.Bucket) error {
+				tmpBkt, err := tmpTx.CreateBucketIfNotExists(name)
+				if err != nil {
+					return err
+				}
+				return bkt.ForEach(func(k, v []byte) error {
+					return tmpBkt.Put(k, v)
+				})
+			})
+		})
+	})
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.RemoveAll(tmpPath)
+		return errors.Wrap(err, "failed to copy data into compacted boltdb")
+	}
+
+	// the current database is linked to the backup, so that it could be put
+	// back while its handle is still open.
+	backupPath := dbPath + ".backup"
+	if err := os.RemoveAll(backupPath); err != nil {
+		os.RemoveAll(tmpPath)
+		return err
+	}
+	if err := os.Link(dbPath, backupPath); err != nil {
+		os.RemoveAll(tmpPath)
+		return errors.Wrap(err, "failed to back up boltdb before compaction")
+	}
+	defer os.RemoveAll(backupPath)
+
+	if err := os.Rename(tmpPath, dbPath); err != nil {
+		os.RemoveAll(tmpPath)
+		return errors.Wrap(err, "failed to replace boltdb with the compacted one")
+	}
+
+	db, err := openBolt(dbPath, 0644, b.opt)
+	if err != nil {
+		if renameErr := os.Rename(backupPath, dbPath); renameErr != nil {
+			return errors.Wrapf(err, "failed to open compacted boltdb and put back the original one: %v", renameErr)
+		}
+		return errors.Wrap(err, "failed to open compacted boltdb")
+	}
+
+	old := b.db
+	b.db = db
+	return old.Close()
+}
+
 // Close releases all database resources.
 // All transactions must be closed before closing the database.
 func (b *bolt) Close() error {
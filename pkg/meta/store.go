@@ -42,6 +42,8 @@ type Store struct {
 	Config
 	trieLock *sync.Mutex // trieLock use to protect 'trie'.
 	trie     *patricia.Trie
+	// updateLock serializes the updates if the backend is not transactional.
+	updateLock *sync.Mutex
 	current  *Bucket
 	backend  Backend
 }
@@ -65,10 +67,11 @@ func NewStore(cfg Config) (*Store, error) {
 	}
 
 	s := &Store{
-		Config:   cfg,
-		backend:  backend,
-		trieLock: new(sync.Mutex),
-		trie:     patricia.NewTrie(),
+		Config:     cfg,
+		backend:    backend,
+		trieLock:   new(sync.Mutex),
+		trie:       patricia.NewTrie(),
+		updateLock: new(sync.Mutex),
 	}
 
 	keys := []string{}
@@ -116,11 +119,12 @@ func (s *Store) Bucket(name string) *Store {
 	}
 
 	return &Store{
-		Config:   s.Config,
-		backend:  s.backend,
-		current:  pb,
-		trieLock: s.trieLock,
-		trie:     s.trie,
+		Config:     s.Config,
+		backend:    s.backend,
+		current:    pb,
+		trieLock:   s.trieLock,
+		trie:       s.trie,
+		updateLock: s.updateLock,
 	}
 }
 
@@ -142,6 +146,51 @@ func (s *Store) Put(obj Object) error {
 	return nil
 }
 
+// Update reads the object of key, modifies it with fn and writes it back atomically,
+// so that a crash never leaves a half-updated object. The object must exist.
+func (s *Store) Update(key string, fn func(Object) error) error {
+	update := func(value []byte) ([]byte, error) {
+		if value == nil {
+			return nil, ErrObjectNotFound
+		}
+		obj := s.current.NewObject()
+		if err := json.Unmarshal(value, obj); err != nil {
+			return nil, fmt.Errorf("failed to decode meta data: %v", err)
+		}
+		if err := fn(obj); err != nil {
+			return nil, err
+		}
+		if obj.Key() != key {
+			return nil, fmt.Errorf("failed to update meta data: key changed from %s to %s", key, obj.Key())
+		}
+		return json.Marshal(obj)
+	}
+
+	if tx, ok := s.backend.(Transactional); ok {
+		return tx.Update(s.current.Name, key, update)
+	}
+
+	s.updateLock.Lock()
+	defer s.updateLock.Unlock()
+
+	value, err := s.backend.Get(s.current.Name, key)
+	if err != nil {
+		return err
+	}
+	if value, err = update(value); err != nil {
+		return err
+	}
+	return s.backend.Put(s.current.Name, key, value)
+}
+
+// Compact reclaims the space of removed data if the backend supports it.
+func (s *Store) Compact() error {
+	if c, ok := s.backend.(Compactable); ok {
+		return c.Compact()
+	}
+	return nil
+}
+
 // Fetch uses to get meta data and decode it into 'obj'.
 func (s *Store) Fetch(obj Object) error {
 	value, err := s.backend.Get(s.current.Name, obj.Key())
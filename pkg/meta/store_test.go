@@ -6,8 +6,11 @@ import (
 	"path"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/alibaba/pouch/pkg/utils"
+
+	boltdb "github.com/boltdb/bolt"
 )
 
 type Demo struct {
@@ -389,3 +392,122 @@ func testKeysWithPrefix(t *testing.T, boltdb4 *Store) {
 func TestKeysWithPrefix(t *testing.T) {
 	testStoreWrapper(t, "TestKeysWithPrefix", "boltdb", boltdbBuckets, testKeysWithPrefix)
 }
+
+func testUpdate(t *testing.T, s *Store) {
+	if err := s.Put(&Demo{A: 1, B: "key"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Update("key", func(obj Object) error {
+		obj.(*Demo).A++
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	obj, err := s.Get("key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if obj.(*Demo).A != 2 {
+		t.Fatalf("failed to update, expected 2, got %d", obj.(*Demo).A)
+	}
+
+	// the object is left unchanged if fn fails.
+	if err := s.Update("key", func(obj Object) error {
+		obj.(*Demo).A++
+		return fmt.Errorf("fail")
+	}); err == nil {
+		t.Fatal("should return the error of fn")
+	}
+	obj, err = s.Get("key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if obj.(*Demo).A != 2 {
+		t.Fatalf("should not update if fn fails, got %d", obj.(*Demo).A)
+	}
+
+	if err := s.Update("nonexist", func(obj Object) error { return nil }); err != ErrObjectNotFound {
+		t.Fatalf("expected ErrObjectNotFound, got %v", err)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	testStoreWrapper(t, "TestUpdate", "local", localBuckets, testUpdate)
+}
+
+func TestBoltdbUpdate(t *testing.T) {
+	testStoreWrapper(t, "TestBoltdbUpdate", "boltdb", []Bucket{{"boltdb", reflect.TypeOf(Demo{})}}, testUpdate)
+}
+
+func testBoltdbCompact(t *testing.T, boltdbStore *Store) {
+	for i := 0; i < 100; i++ {
+		if err := boltdbStore.Put(&Demo3{A: i, B: fmt.Sprintf("key%d", i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i := 1; i < 100; i++ {
+		if err := boltdbStore.Remove(fmt.Sprintf("key%d", i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := boltdbStore.Compact(); err != nil {
+		t.Fatal(err)
+	}
+
+	objs, err := boltdbStore.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(objs) != 1 || objs["key0"] == nil {
+		t.Fatalf("failed to keep data after compaction: %v", objs)
+	}
+}
+
+func TestBoltdbCompact(t *testing.T) {
+	testStoreWrapper(t, "TestBoltdbCompact", "boltdb", boltdbBuckets, testBoltdbCompact)
+}
+
+func testBoltdbCompactOpenFailure(t *testing.T, boltdbStore *Store) {
+	if err := boltdbStore.Put(&Demo3{A: 1, B: "key1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func(open func(string, os.FileMode, *boltdb.Options) (*boltdb.DB, error)) { openBolt = open }(openBolt)
+	openBolt = func(string, os.FileMode, *boltdb.Options) (*boltdb.DB, error) {
+		return nil, fmt.Errorf("open failure")
+	}
+	if err := boltdbStore.Compact(); err == nil {
+		t.Fatal("compaction should fail if the compacted boltdb could not be opened")
+	}
+
+	// the original database is still in use and kept on disk.
+	if err := boltdbStore.Put(&Demo3{A: 2, B: "key2"}); err != nil {
+		t.Fatal(err)
+	}
+	dbFile := boltdbStore.Path("key2")
+	db, err := boltdb.Open(dbFile, 0644, &boltdb.Options{ReadOnly: true, Timeout: 10 * time.Millisecond})
+	if err == nil {
+		db.Close()
+		t.Fatal("the original boltdb should still be locked by the store")
+	}
+	boltdbStore.Shutdown()
+
+	store, err := initStore(dbFile, "boltdb", boltdbBuckets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Shutdown()
+	objs, err := store.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(objs) != 2 {
+		t.Fatalf("failed to keep data after the failed compaction: %v", objs)
+	}
+}
+
+func TestBoltdbCompactOpenFailure(t *testing.T) {
+	testStoreWrapper(t, "TestBoltdbCompactOpenFailure", "boltdb", boltdbBuckets, testBoltdbCompactOpenFailure)
+}
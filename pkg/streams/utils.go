@@ -27,6 +27,12 @@ type AttachConfig struct {
 	// caller, the stdin of process's stream should be closed.
 	CloseStdin bool
 
+	// HonorStdinOnce means the stdin of process's stream is closed with the
+	// client's one only if the container is created with StdinOnce, as the
+	// docker does. Otherwise the stdin is kept open for the next attach and
+	// the client's stream is half-closed, e.g. the cri attach.
+	HonorStdinOnce bool
+
 	// UseStdin/UseStdout/UseStderr can be used to check the client's stream
 	// is nil or not. It is hard to check io.Write/io.ReadCloser != nil
 	// directly, because they might be specific type, which means
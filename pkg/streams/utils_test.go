@@ -73,3 +73,42 @@ func TestAttachWithCloseStdin(t *testing.T) {
 		t.Fatalf("failed to stop stream: %v", err)
 	}
 }
+
+func TestAttachWithoutCloseStdin(t *testing.T) {
+	var (
+		aStdin  = &bufferWrapper{bytes.NewBufferString("hello")}
+		aStdout = bytes.NewBuffer(nil)
+	)
+
+	attachCfg := &AttachConfig{
+		UseStdin:  true,
+		Stdin:     aStdin,
+		UseStdout: true,
+		Stdout:    aStdout,
+	}
+
+	stream := NewStream()
+	stream.NewStdinInput()
+	attachErr := stream.Attach(context.Background(), attachCfg)
+
+	buf := make([]byte, 5)
+	if _, err := io.ReadFull(stream.Stdin(), buf); err != nil || string(buf) != "hello" {
+		t.Fatalf("expected to get hello from stdin, but got (%s): %v", buf, err)
+	}
+
+	// the output is still streamed after the stdin of client is closed.
+	stream.Stdout().Write([]byte("world"))
+	stream.Stdout().Close()
+	if err := <-attachErr; err != nil {
+		t.Fatalf("failed to attach: %v", err)
+	}
+	if got := aStdout.String(); got != "world" {
+		t.Fatalf("expected to get (world), but got (%s)", got)
+	}
+
+	// the stdin of process is kept open for the next attach.
+	go stream.StdinPipe().Write([]byte("again"))
+	if _, err := io.ReadFull(stream.Stdin(), buf); err != nil || string(buf) != "again" {
+		t.Fatalf("expected to get again from stdin, but got (%s): %v", buf, err)
+	}
+}
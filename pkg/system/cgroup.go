@@ -51,6 +51,10 @@ type CgroupInfo struct {
 
 // NewCgroupInfo news a CgroupInfo struct
 func NewCgroupInfo() *CgroupInfo {
+	if IsCgroup2UnifiedMode() {
+		return newUnifiedCgroupInfo(UnifiedCgroupMountpoint)
+	}
+
 	cgroupRootPath := getCgroupRootMount("/proc/self/mountinfo")
 	if cgroupRootPath == "" {
 		return nil
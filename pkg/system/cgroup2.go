@@ -0,0 +1,82 @@
+package system
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"golang.org/x/sys/unix"
+)
+
+// UnifiedCgroupMountpoint is the mount point of cgroup v2 unified hierarchy.
+const UnifiedCgroupMountpoint = "/sys/fs/cgroup"
+
+var (
+	isUnifiedOnce sync.Once
+	isUnified     bool
+)
+
+// IsCgroup2UnifiedMode returns whether the host boots with cgroup v2
+// unified hierarchy.
+func IsCgroup2UnifiedMode() bool {
+	isUnifiedOnce.Do(func() {
+		var st unix.Statfs_t
+		if err := unix.Statfs(UnifiedCgroupMountpoint, &st); err != nil {
+			return
+		}
+		isUnified = st.Type == unix.CGROUP2_SUPER_MAGIC
+	})
+	return isUnified
+}
+
+// CPUSharesToCPUWeight converts the cpu shares of cgroup v1 in range
+// [2, 262144] into the cpu.weight of cgroup v2 in range [1, 10000],
+// which is the same conversion as the one of runc.
+func CPUSharesToCPUWeight(shares uint64) uint64 {
+	if shares == 0 {
+		return 0
+	}
+	return 1 + ((shares-2)*9999)/262142
+}
+
+// newUnifiedCgroupInfo returns the cgroup information according to the
+// controllers enabled in the unified hierarchy.
+func newUnifiedCgroupInfo(root string) *CgroupInfo {
+	data, err := ioutil.ReadFile(filepath.Join(root, "cgroup.controllers"))
+	if err != nil {
+		return nil
+	}
+
+	controllers := make(map[string]bool)
+	for _, c := range strings.Fields(string(data)) {
+		controllers[c] = true
+	}
+
+	// NOTE: memory.swappiness and memory.oom_control are gone in cgroup v2.
+	return &CgroupInfo{
+		Memory: &MemoryCgroupInfo{
+			MemoryLimit:       controllers["memory"],
+			MemoryReservation: controllers["memory"],
+			MemorySwap:        controllers["memory"],
+		},
+		CPU: &CPUCgroupInfo{
+			CpusetCpus: controllers["cpuset"],
+			CpusetMems: controllers["cpuset"],
+			CPUShares:  controllers["cpu"],
+			CPUQuota:   controllers["cpu"],
+			CPUPeriod:  controllers["cpu"],
+		},
+		Blkio: &BlkioCgroupInfo{
+			BlkioWeight:          controllers["io"],
+			BlkioWeightDevice:    controllers["io"],
+			BlkioDeviceReadBps:   controllers["io"],
+			BlkioDeviceWriteBps:  controllers["io"],
+			BlkioDeviceReadIOps:  controllers["io"],
+			BlkioDeviceWriteIOps: controllers["io"],
+		},
+		Pids: &PidsCgroupInfo{
+			Pids: controllers["pids"],
+		},
+	}
+}
@@ -0,0 +1,40 @@
+package system
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCPUSharesToCPUWeight(t *testing.T) {
+	for shares, weight := range map[uint64]uint64{
+		0:      0,
+		2:      1,
+		1024:   39,
+		262144: 10000,
+	} {
+		assert.Equal(t, weight, CPUSharesToCPUWeight(shares), "shares %d", shares)
+	}
+}
+
+func TestNewUnifiedCgroupInfo(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "test-unified-cgroup")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	// no cgroup.controllers, not a unified hierarchy.
+	assert.Nil(t, newUnifiedCgroupInfo(tmpDir))
+
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(tmpDir, "cgroup.controllers"), []byte("cpuset cpu memory pids\n"), 0644))
+	info := newUnifiedCgroupInfo(tmpDir)
+	assert.True(t, info.Memory.MemoryLimit)
+	assert.False(t, info.Memory.MemorySwappiness)
+	assert.False(t, info.Memory.OOMKillDisable)
+	assert.True(t, info.CPU.CPUShares)
+	assert.True(t, info.CPU.CpusetCpus)
+	assert.False(t, info.Blkio.BlkioWeight)
+	assert.True(t, info.Pids.Pids)
+}
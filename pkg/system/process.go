@@ -0,0 +1,51 @@
+package system
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ProcessStat is the stat of process in procfs.
+type ProcessStat struct {
+	// State is the state of process, e.g. R, S and Z.
+	State string
+	// StartTime is the time the process started after boot in clock ticks,
+	// which tells the process from another one reusing its pid.
+	StartTime uint64
+}
+
+// GetProcessStat reads the stat of process from the procfs mounted at procRoot,
+// i.e. <procRoot>/<pid>/stat.
+func GetProcessStat(procRoot string, pid int) (*ProcessStat, error) {
+	data, err := ioutil.ReadFile(filepath.Join(procRoot, strconv.Itoa(pid), "stat"))
+	if err != nil {
+		return nil, err
+	}
+	stat, err := ParseProcessStat(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("invalid stat of process %d: %v", pid, err)
+	}
+	return stat, nil
+}
+
+// ParseProcessStat parses the stat of process in procfs.
+func ParseProcessStat(stat string) (*ProcessStat, error) {
+	// the command in parentheses may contain spaces, the fields after it
+	// start from the state, and the start time is the 20th one.
+	i := strings.LastIndex(stat, ")")
+	if i < 0 {
+		return nil, fmt.Errorf("no command found")
+	}
+	fields := strings.Fields(stat[i+1:])
+	if len(fields) < 20 {
+		return nil, fmt.Errorf("too few fields")
+	}
+	startTime, err := strconv.ParseUint(fields[19], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start time: %v", err)
+	}
+	return &ProcessStat{State: fields[0], StartTime: startTime}, nil
+}
@@ -0,0 +1,28 @@
+package system
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseProcessStat(t *testing.T) {
+	stat, err := ParseProcessStat("42 (pause (holder)) S 1 42 42 0 -1 4194560 101 0 0 0 0 0 0 0 20 0 1 0 123456 1142784 1 18446744073709551615\n")
+	assert.NoError(t, err)
+	assert.Equal(t, &ProcessStat{State: "S", StartTime: 123456}, stat)
+
+	for _, s := range []string{"", "42 (pause) S 1 42", "42 (pause) S 1 42 42 0 -1 4194560 101 0 0 0 0 0 0 0 20 0 1 0 now"} {
+		_, err := ParseProcessStat(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestGetProcessStat(t *testing.T) {
+	stat, err := GetProcessStat("/proc", os.Getpid())
+	assert.NoError(t, err)
+	assert.True(t, stat.StartTime > 0)
+
+	_, err = GetProcessStat("/proc", 1<<22+1)
+	assert.Error(t, err)
+}
@@ -69,6 +69,17 @@ func Get(passwdPath, groupPath, username string, groups []string) (uint32, uint3
 	return uid, gid, additionalGids, nil
 }
 
+// HasUser checks whether the user of name exists in the passwd file.
+func HasUser(passwdPath, username string) (bool, error) {
+	users, err := user.ParsePasswdFileFilter(passwdPath, func(u user.User) bool {
+		return u.Name == username
+	})
+	if err != nil {
+		return false, err
+	}
+	return len(users) > 0, nil
+}
+
 // GetAdditionalGids parse supplementary gids from slice groups.
 func GetAdditionalGids(groups []string) []uint32 {
 	var additionalGids []uint32
@@ -2,6 +2,9 @@ package user
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 
@@ -30,3 +33,28 @@ func TestGetAdditionalGids(t *testing.T) {
 	assert := assert.New(t)
 	assert.True(reflect.DeepEqual(expected, result), true)
 }
+
+func TestHasUser(t *testing.T) {
+	dir, err := ioutil.TempDir("", "passwd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	passwd := filepath.Join(dir, "passwd")
+	if err := ioutil.WriteFile(passwd, []byte("root:x:0:0:root:/root:/bin/sh\nnginx:x:101:101::/home/nginx:/sbin/nologin\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	assert := assert.New(t)
+	exists, err := HasUser(passwd, "nginx")
+	assert.NoError(err)
+	assert.True(exists)
+
+	exists, err = HasUser(passwd, "foo")
+	assert.NoError(err)
+	assert.False(exists)
+
+	_, err = HasUser(filepath.Join(dir, "none"), "nginx")
+	assert.Error(err)
+}
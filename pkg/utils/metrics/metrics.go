@@ -80,6 +80,19 @@ func NewLabelTimer(subsystem, name, help string, labels ...string) *prometheus.H
 		}, labels)
 }
 
+// NewLabelTimerWithBuckets return a new HistogramVec with the specified buckets.
+func NewLabelTimerWithBuckets(subsystem, name, help string, buckets []float64, labels ...string) *prometheus.HistogramVec {
+	return prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace:   namespace,
+			Subsystem:   subsystem,
+			Name:        fmt.Sprintf("%s_%s", name, seconds),
+			Help:        help,
+			Buckets:     buckets,
+			ConstLabels: nil,
+		}, labels)
+}
+
 // GetPrometheusRegistry return a resigtry of Prometheus.
 func GetPrometheusRegistry() *prometheus.Registry {
 	return prometheusRegistry
@@ -0,0 +1,187 @@
+// +build linux
+
+package quota
+
+import (
+	"path"
+	"reflect"
+	"sync"
+
+	"github.com/alibaba/pouch/pkg/log"
+	"github.com/alibaba/pouch/pkg/meta"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// allocationBucket is the bucket storing quota id allocations in the database.
+	allocationBucket = "quota-ids"
+
+	// maxAllocateRetries is the max number of ids skipped for the conflicts
+	// with the allocations before giving up.
+	maxAllocateRetries = 1024
+)
+
+var (
+	// GIDAllocator represents global quota id allocator, the ids are allocated
+	// without persistence if it's not set.
+	GIDAllocator *IDAllocator
+)
+
+// IDAllocation is the quota ids allocated to an owner, e.g. a container or
+// a sandbox, indexed by the names of the directories of owner.
+type IDAllocation struct {
+	Owner    string
+	QuotaIDs map[string]uint32
+}
+
+// Key returns the owner of allocation.
+func (a *IDAllocation) Key() string {
+	return a.Owner
+}
+
+// IDAllocator hands out the unique quota ids to the directories of containers
+// and volumes, the allocations are persisted in the meta store, so that an
+// allocated id is neither handed out again after pouchd is restarted before
+// the quota is set on the filesystem, nor leaked when the quota is set again
+// on the same directory.
+type IDAllocator struct {
+	lock  sync.Mutex
+	store *meta.Store
+	// owners are the owners of allocated quota ids.
+	owners map[uint32]string
+	// nextID returns the next quota id not used on the filesystem.
+	nextID func() (uint32, error)
+}
+
+// NewIDAllocator creates the quota id allocator persisted in the database
+// under the directory.
+func NewIDAllocator(dir string) (*IDAllocator, error) {
+	store, err := meta.NewStore(meta.Config{
+		Driver:  "boltdb",
+		BaseDir: path.Join(dir, "quota-ids.db"),
+		Buckets: []meta.Bucket{
+			{
+				Name: allocationBucket,
+				Type: reflect.TypeOf(IDAllocation{}),
+			},
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create quota id store")
+	}
+	return newIDAllocator(store, GetNextQuotaID)
+}
+
+func newIDAllocator(store *meta.Store, nextID func() (uint32, error)) (*IDAllocator, error) {
+	a := &IDAllocator{
+		store:  store,
+		owners: make(map[uint32]string),
+		nextID: nextID,
+	}
+
+	if err := store.ForEach(func(obj meta.Object) error {
+		allocation := obj.(*IDAllocation)
+		for _, id := range allocation.QuotaIDs {
+			a.owners[id] = allocation.Owner
+		}
+		return nil
+	}); err != nil {
+		return nil, errors.Wrap(err, "failed to load quota id allocations")
+	}
+	return a, nil
+}
+
+// SetIDAllocator is used to set global quota id allocator.
+func SetIDAllocator(a *IDAllocator) {
+	GIDAllocator = a
+}
+
+// Allocate returns the quota id of the directory of owner, a new one is
+// allocated if none. The new id is neither used on the filesystem nor
+// allocated to another directory.
+func (a *IDAllocator) Allocate(owner, name string) (uint32, error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	allocation := &IDAllocation{Owner: owner}
+	if err := a.store.Fetch(allocation); err != nil && err != meta.ErrObjectNotFound {
+		return 0, errors.Wrapf(err, "failed to get quota ids of %s", owner)
+	}
+	if id, ok := allocation.QuotaIDs[name]; ok {
+		return id, nil
+	}
+
+	var id uint32
+	for i := 0; ; i++ {
+		if i == maxAllocateRetries {
+			return 0, errors.Errorf("failed to find a free quota id for %s of %s", name, owner)
+		}
+
+		var err error
+		if id, err = a.nextID(); err != nil {
+			return 0, err
+		}
+		if prev, ok := a.owners[id]; ok {
+			log.With(nil).Debugf("skip quota id %d allocated to %s", id, prev)
+			continue
+		}
+		break
+	}
+
+	if allocation.QuotaIDs == nil {
+		allocation.QuotaIDs = make(map[string]uint32)
+	}
+	allocation.QuotaIDs[name] = id
+	if err := a.store.Put(allocation); err != nil {
+		return 0, errors.Wrapf(err, "failed to save quota id %d of %s", id, owner)
+	}
+	a.owners[id] = owner
+	return id, nil
+}
+
+// Release reclaims all the quota ids of owner once it's removed.
+func (a *IDAllocator) Release(owner string) error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	allocation := &IDAllocation{Owner: owner}
+	if err := a.store.Fetch(allocation); err != nil {
+		if err == meta.ErrObjectNotFound {
+			return nil
+		}
+		return errors.Wrapf(err, "failed to get quota ids of %s", owner)
+	}
+
+	if err := a.store.Remove(owner); err != nil {
+		return errors.Wrapf(err, "failed to remove quota ids of %s", owner)
+	}
+	for _, id := range allocation.QuotaIDs {
+		if a.owners[id] == owner {
+			delete(a.owners, id)
+		}
+	}
+	return nil
+}
+
+// Close closes the store of allocations.
+func (a *IDAllocator) Close() error {
+	return a.store.Shutdown()
+}
+
+// AllocateQuotaID returns the quota id of the directory of owner by the global
+// allocator, or the next quota id if the allocator is not set.
+func AllocateQuotaID(owner, name string) (uint32, error) {
+	if GIDAllocator == nil {
+		return GetNextQuotaID()
+	}
+	return GIDAllocator.Allocate(owner, name)
+}
+
+// ReleaseQuotaIDs reclaims the quota ids of owner by the global allocator.
+func ReleaseQuotaIDs(owner string) error {
+	if GIDAllocator == nil {
+		return nil
+	}
+	return GIDAllocator.Release(owner)
+}
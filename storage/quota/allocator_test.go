@@ -0,0 +1,81 @@
+// +build linux
+
+package quota
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"reflect"
+	"testing"
+
+	"github.com/alibaba/pouch/pkg/meta"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestIDStore(t *testing.T, dir string) *meta.Store {
+	store, err := meta.NewStore(meta.Config{
+		Driver:  "boltdb",
+		BaseDir: path.Join(dir, "quota-ids.db"),
+		Buckets: []meta.Bucket{
+			{
+				Name: allocationBucket,
+				Type: reflect.TypeOf(IDAllocation{}),
+			},
+		},
+	})
+	assert.NoError(t, err)
+	return store
+}
+
+func TestIDAllocator(t *testing.T) {
+	dir, err := ioutil.TempDir("", "quota-ids")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// the filesystem hands out the ids in sequence since the last one used.
+	next := QuotaMinID
+	nextID := func() (uint32, error) {
+		next++
+		return next, nil
+	}
+
+	a, err := newIDAllocator(newTestIDStore(t, dir), nextID)
+	assert.NoError(t, err)
+
+	id1, err := a.Allocate("c1", "/")
+	assert.NoError(t, err)
+	id2, err := a.Allocate("c1", "/data")
+	assert.NoError(t, err)
+	assert.NotEqual(t, id1, id2)
+
+	// the same directory gets the same id.
+	id, err := a.Allocate("c1", "/")
+	assert.NoError(t, err)
+	assert.Equal(t, id1, id)
+	assert.NoError(t, a.Close())
+
+	// the allocations survive the restart, the allocated ids are skipped
+	// even though the filesystem hands them out again.
+	next = QuotaMinID
+	a, err = newIDAllocator(newTestIDStore(t, dir), nextID)
+	assert.NoError(t, err)
+	id, err = a.Allocate("c1", "/data")
+	assert.NoError(t, err)
+	assert.Equal(t, id2, id)
+
+	id3, err := a.Allocate("c2", "/")
+	assert.NoError(t, err)
+	assert.NotEqual(t, id1, id3)
+	assert.NotEqual(t, id2, id3)
+
+	// the ids are reclaimed once the owner is removed.
+	assert.NoError(t, a.Release("c1"))
+	assert.NoError(t, a.Release("c1"))
+	next = QuotaMinID
+	id, err = a.Allocate("c3", "/")
+	assert.NoError(t, err)
+	assert.Equal(t, id1, id)
+	assert.NoError(t, a.Close())
+}
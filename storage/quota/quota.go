@@ -143,7 +143,14 @@ func SetRootfsDiskQuota(basefs, size string, quotaID uint32, update bool) (uint3
 		return 0, errors.Wrapf(err, "failed to get overlay(%s) mount info", basefs)
 	}
 
-	for _, dir := range []string{overlayMountInfo.Upper, overlayMountInfo.Work} {
+	return SetSnapshotDiskQuota(overlayMountInfo.Upper, overlayMountInfo.Work, size, quotaID, update)
+}
+
+// SetSnapshotDiskQuota is to set disk quota on the upper and work dir of the
+// overlay snapshot of container, which needs no mount of the rootfs.
+func SetSnapshotDiskQuota(upperDir, workDir, size string, quotaID uint32, update bool) (uint32, error) {
+	var err error
+	for _, dir := range []string{upperDir, workDir} {
 		if quotaID == 0 {
 			quotaID, err = GetQuotaID(dir)
 			if err != nil {